@@ -0,0 +1,27 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// mx-kube deploys MX applications on Kubernetes. "mx kube <command>"
+// dispatches to "mx-kube <command>". Run "mx kube help" for more
+// information.
+package main
+
+import (
+	"github.com/sh3lk/mx/internal/tool/kube"
+	"github.com/sh3lk/mx/runtime/tool"
+)
+
+func main() {
+	tool.Run("mx kube", kube.Commands)
+}
@@ -75,6 +75,10 @@ func main() {
 	case "generate":
 		generateFlags := flag.NewFlagSet("generate", flag.ExitOnError)
 		tags := generateFlags.String("tags", "", "Optional tags for the generate command")
+		gateway := generateFlags.Bool("gateway", false, "Generate HTTP+JSON gateways for components")
+		mocks := generateFlags.Bool("mocks", false, "Generate mock implementations of components")
+		grpc := generateFlags.String("grpc", "", "Comma-separated component interfaces to generate gRPC services for")
+		checkCompat := generateFlags.String("check-compat", "", "Binary of a previous version to check wire compatibility against")
 		generateFlags.Usage = func() {
 			fmt.Fprintln(os.Stderr, generate.Usage)
 		}
@@ -86,7 +90,11 @@ func main() {
 			// extra validation at some point.
 			buildTags = buildTags + "," + *tags
 		}
-		if err := generate.Generate(".", generateFlags.Args(), generate.Options{BuildTags: buildTags}); err != nil {
+		opt := generate.Options{BuildTags: buildTags, Gateway: *gateway, Mocks: *mocks, CheckCompat: *checkCompat}
+		if *grpc != "" {
+			opt.GRPC = strings.Split(*grpc, ",")
+		}
+		if err := generate.Generate(".", generateFlags.Args(), opt); err != nil {
 			fmt.Fprintln(os.Stderr, err)
 			os.Exit(1)
 		}
@@ -86,3 +86,13 @@ func (*localDeployerControl) VerifyClientCertificate(context.Context, *protos.Ve
 func (*localDeployerControl) VerifyServerCertificate(context.Context, *protos.VerifyServerCertificateRequest) (*protos.VerifyServerCertificateReply, error) {
 	return nil, fmt.Errorf("localDeployerControl.VerifyServerCertificate not implemented")
 }
+
+// GetSelfToken implements the control.DeployerControl interface.
+func (*localDeployerControl) GetSelfToken(context.Context, *protos.GetSelfTokenRequest) (*protos.GetSelfTokenReply, error) {
+	return nil, fmt.Errorf("localDeployerControl.GetSelfToken not implemented")
+}
+
+// VerifyClientToken implements the control.DeployerControl interface.
+func (*localDeployerControl) VerifyClientToken(context.Context, *protos.VerifyClientTokenRequest) (*protos.VerifyClientTokenReply, error) {
+	return nil, fmt.Errorf("localDeployerControl.VerifyClientToken not implemented")
+}
@@ -30,7 +30,7 @@ func init() {
 		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
 			return t_reflect_stub{caller: caller}
 		},
-		RefData: "",
+		RefData: "⟦5890be33:MxSchema:github.com/sh3lk/mx/examples/bankofanthos/balancereader/T→[{\"name\":\"GetBalance\",\"args\":[{\"kind\":\"string\"}],\"results\":[{\"kind\":\"int64\"}]}]⟧\n",
 	})
 }
 
@@ -113,7 +113,8 @@ func (s t_client_stub) GetBalance(ctx context.Context, a0 string) (r0 int64, err
 	// Preallocate a buffer of the right size.
 	size := 0
 	size += (4 + len(a0))
-	enc := codegen.NewEncoder()
+	enc := codegen.GetEncoder()
+	defer codegen.PutEncoder(enc)
 	enc.Reset(size)
 
 	// Encode arguments.
@@ -131,7 +132,7 @@ func (s t_client_stub) GetBalance(ctx context.Context, a0 string) (r0 int64, err
 	}
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewZeroCopyDecoder(results)
 	r0 = dec.Int64()
 	err = dec.Error()
 	return
@@ -140,10 +141,10 @@ func (s t_client_stub) GetBalance(ctx context.Context, a0 string) (r0 int64, err
 // Note that "mx generate" will always generate the error message below.
 // Everything is okay. The error message is only relevant if you see it when
 // you run "go build" or "go run".
-var _ codegen.LatestVersion = codegen.Version[[0][24]struct{}](`
+var _ codegen.LatestVersion = codegen.Version[[0][25]struct{}](`
 
 ERROR: You generated this file with 'mx generate' (devel) (codegen
-version v0.24.0). The generated code is incompatible with the version of the
+version v0.25.0). The generated code is incompatible with the version of the
 github.com/sh3lk/mx module that you're using. The mx module
 version can be found in your go.mod file or by running the following command.
 
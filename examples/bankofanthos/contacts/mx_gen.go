@@ -31,7 +31,7 @@ func init() {
 		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
 			return t_reflect_stub{caller: caller}
 		},
-		RefData: "",
+		RefData: "⟦7d846ef9:MxSchema:github.com/sh3lk/mx/examples/bankofanthos/contacts/T→[{\"name\":\"AddContact\",\"args\":[{\"kind\":\"string\"},{\"kind\":\"struct\",\"name\":\"contacts.Contact\",\"fields\":[{\"name\":\"Username\",\"type\":{\"kind\":\"string\"}},{\"name\":\"Label\",\"type\":{\"kind\":\"string\"}},{\"name\":\"AccountNum\",\"type\":{\"kind\":\"string\"}},{\"name\":\"RoutingNum\",\"type\":{\"kind\":\"string\"}},{\"name\":\"IsExternal\",\"type\":{\"kind\":\"bool\"}}]}]},{\"name\":\"GetContacts\",\"args\":[{\"kind\":\"string\"}],\"results\":[{\"kind\":\"slice\",\"elem\":{\"kind\":\"struct\",\"name\":\"contacts.Contact\",\"fields\":[{\"name\":\"Username\",\"type\":{\"kind\":\"string\"}},{\"name\":\"Label\",\"type\":{\"kind\":\"string\"}},{\"name\":\"AccountNum\",\"type\":{\"kind\":\"string\"}},{\"name\":\"RoutingNum\",\"type\":{\"kind\":\"string\"}},{\"name\":\"IsExternal\",\"type\":{\"kind\":\"bool\"}}]}}]}]⟧\n",
 	})
 }
 
@@ -136,8 +136,9 @@ func (s t_client_stub) AddContact(ctx context.Context, a0 string, a1 Contact) (e
 	// Preallocate a buffer of the right size.
 	size := 0
 	size += (4 + len(a0))
-	size += mx_size_Contact_15811618(&a1)
-	enc := codegen.NewEncoder()
+	size += mx_size_Contact_591a6459(&a1)
+	enc := codegen.GetEncoder()
+	defer codegen.PutEncoder(enc)
 	enc.Reset(size)
 
 	// Encode arguments.
@@ -156,7 +157,7 @@ func (s t_client_stub) AddContact(ctx context.Context, a0 string, a1 Contact) (e
 	}
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewZeroCopyDecoder(results)
 	err = dec.Error()
 	return
 }
@@ -193,7 +194,8 @@ func (s t_client_stub) GetContacts(ctx context.Context, a0 string) (r0 []Contact
 	// Preallocate a buffer of the right size.
 	size := 0
 	size += (4 + len(a0))
-	enc := codegen.NewEncoder()
+	enc := codegen.GetEncoder()
+	defer codegen.PutEncoder(enc)
 	enc.Reset(size)
 
 	// Encode arguments.
@@ -211,8 +213,8 @@ func (s t_client_stub) GetContacts(ctx context.Context, a0 string) (r0 []Contact
 	}
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
-	r0 = mx_dec_slice_Contact_d00a3378(dec)
+	dec := codegen.NewZeroCopyDecoder(results)
+	r0 = mx_dec_slice_Contact_2644cb59(dec)
 	err = dec.Error()
 	return
 }
@@ -220,10 +222,10 @@ func (s t_client_stub) GetContacts(ctx context.Context, a0 string) (r0 []Contact
 // Note that "mx generate" will always generate the error message below.
 // Everything is okay. The error message is only relevant if you see it when
 // you run "go build" or "go run".
-var _ codegen.LatestVersion = codegen.Version[[0][24]struct{}](`
+var _ codegen.LatestVersion = codegen.Version[[0][25]struct{}](`
 
 ERROR: You generated this file with 'mx generate' (devel) (codegen
-version v0.24.0). The generated code is incompatible with the version of the
+version v0.25.0). The generated code is incompatible with the version of the
 github.com/sh3lk/mx module that you're using. The mx module
 version can be found in your go.mod file or by running the following command.
 
@@ -308,7 +310,7 @@ func (s t_server_stub) getContacts(ctx context.Context, args []byte) (res []byte
 
 	// Encode the results.
 	enc := codegen.NewEncoder()
-	mx_enc_slice_Contact_d00a3378(enc, r0)
+	mx_enc_slice_Contact_2644cb59(enc, r0)
 	enc.Error(appErr)
 	return enc.Data(), nil
 }
@@ -371,7 +373,7 @@ func (x *Contact) MXUnmarshal(dec *codegen.Decoder) {
 
 // Encoding/decoding implementations.
 
-func mx_enc_slice_Contact_d00a3378(enc *codegen.Encoder, arg []Contact) {
+func mx_enc_slice_Contact_2644cb59(enc *codegen.Encoder, arg []Contact) {
 	if arg == nil {
 		enc.Len(-1)
 		return
@@ -382,7 +384,7 @@ func mx_enc_slice_Contact_d00a3378(enc *codegen.Encoder, arg []Contact) {
 	}
 }
 
-func mx_dec_slice_Contact_d00a3378(dec *codegen.Decoder) []Contact {
+func mx_dec_slice_Contact_2644cb59(dec *codegen.Decoder) []Contact {
 	n := dec.Len()
 	if n == -1 {
 		return nil
@@ -396,9 +398,9 @@ func mx_dec_slice_Contact_d00a3378(dec *codegen.Decoder) []Contact {
 
 // Size implementations.
 
-// mx_size_Contact_15811618 returns the size (in bytes) of the serialization
+// mx_size_Contact_591a6459 returns the size (in bytes) of the serialization
 // of the provided type.
-func mx_size_Contact_15811618(x *Contact) int {
+func mx_size_Contact_591a6459(x *Contact) int {
 	size := 0
 	size += 0
 	size += (4 + len(x.Username))
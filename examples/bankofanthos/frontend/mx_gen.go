@@ -27,7 +27,7 @@ func init() {
 		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
 			return main_reflect_stub{caller: caller}
 		},
-		RefData: "⟦583f439b:MxEdge:github.com/sh3lk/mx/Main→github.com/sh3lk/mx/examples/bankofanthos/balancereader/T⟧\n⟦01efa328:MxEdge:github.com/sh3lk/mx/Main→github.com/sh3lk/mx/examples/bankofanthos/contacts/T⟧\n⟦285db949:MxEdge:github.com/sh3lk/mx/Main→github.com/sh3lk/mx/examples/bankofanthos/ledgerwriter/T⟧\n⟦c236fa3b:MxEdge:github.com/sh3lk/mx/Main→github.com/sh3lk/mx/examples/bankofanthos/transactionhistory/T⟧\n⟦0906345d:MxEdge:github.com/sh3lk/mx/Main→github.com/sh3lk/mx/examples/bankofanthos/userservice/T⟧\n⟦969790bc:wEaVeRlIsTeNeRs:github.com/sh3lk/mx/Main→bank⟧\n",
+		RefData: "⟦6726a4cd:MxEdge:github.com/sh3lk/mx/Main→github.com/sh3lk/mx/examples/bankofanthos/balancereader/T⟧\n⟦399fdb3a:MxEdge:github.com/sh3lk/mx/Main→github.com/sh3lk/mx/examples/bankofanthos/contacts/T⟧\n⟦62582101:MxEdge:github.com/sh3lk/mx/Main→github.com/sh3lk/mx/examples/bankofanthos/ledgerwriter/T⟧\n⟦95bc2f62:MxEdge:github.com/sh3lk/mx/Main→github.com/sh3lk/mx/examples/bankofanthos/transactionhistory/T⟧\n⟦56c2a3e6:MxEdge:github.com/sh3lk/mx/Main→github.com/sh3lk/mx/examples/bankofanthos/userservice/T⟧\n⟦359cd72b:wEaVeRlIsTeNeRs:github.com/sh3lk/mx/Main→bank⟧\n",
 	})
 }
 
@@ -59,10 +59,10 @@ var _ mx.Main = (*main_client_stub)(nil)
 // Note that "mx generate" will always generate the error message below.
 // Everything is okay. The error message is only relevant if you see it when
 // you run "go build" or "go run".
-var _ codegen.LatestVersion = codegen.Version[[0][24]struct{}](`
+var _ codegen.LatestVersion = codegen.Version[[0][25]struct{}](`
 
 ERROR: You generated this file with 'mx generate' (devel) (codegen
-version v0.24.0). The generated code is incompatible with the version of the
+version v0.25.0). The generated code is incompatible with the version of the
 github.com/sh3lk/mx module that you're using. The mx module
 version can be found in your go.mod file or by running the following command.
 
@@ -105,3 +105,4 @@ type main_reflect_stub struct {
 
 // Check that main_reflect_stub implements the mx.Main interface.
 var _ mx.Main = (*main_reflect_stub)(nil)
+
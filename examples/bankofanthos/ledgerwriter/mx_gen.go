@@ -31,7 +31,7 @@ func init() {
 		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
 			return t_reflect_stub{caller: caller}
 		},
-		RefData: "⟦7237a6f4:MxEdge:github.com/sh3lk/mx/examples/bankofanthos/ledgerwriter/T→github.com/sh3lk/mx/examples/bankofanthos/balancereader/T⟧\n",
+		RefData: "⟦413e61f3:MxEdge:github.com/sh3lk/mx/examples/bankofanthos/ledgerwriter/T→github.com/sh3lk/mx/examples/bankofanthos/balancereader/T⟧\n⟦c6d99dcd:MxSchema:github.com/sh3lk/mx/examples/bankofanthos/ledgerwriter/T→[{\"name\":\"AddTransaction\",\"args\":[{\"kind\":\"string\"},{\"kind\":\"string\"},{\"kind\":\"struct\",\"name\":\"model.Transaction\",\"fields\":[{\"name\":\"FromAccountNum\",\"type\":{\"kind\":\"string\"}},{\"name\":\"FromRoutingNum\",\"type\":{\"kind\":\"string\"}},{\"name\":\"ToAccountNum\",\"type\":{\"kind\":\"string\"}},{\"name\":\"ToRoutingNum\",\"type\":{\"kind\":\"string\"}},{\"name\":\"Amount\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"Timestamp\",\"type\":{\"kind\":\"binary\",\"name\":\"time.Time\"}}]}]}]⟧\n",
 	})
 }
 
@@ -112,7 +112,8 @@ func (s t_client_stub) AddTransaction(ctx context.Context, a0 string, a1 string,
 	}()
 
 	// Encode arguments.
-	enc := codegen.NewEncoder()
+	enc := codegen.GetEncoder()
+	defer codegen.PutEncoder(enc)
 	enc.String(a0)
 	enc.String(a1)
 	(a2).MXMarshal(enc)
@@ -129,7 +130,7 @@ func (s t_client_stub) AddTransaction(ctx context.Context, a0 string, a1 string,
 	}
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewZeroCopyDecoder(results)
 	err = dec.Error()
 	return
 }
@@ -137,10 +138,10 @@ func (s t_client_stub) AddTransaction(ctx context.Context, a0 string, a1 string,
 // Note that "mx generate" will always generate the error message below.
 // Everything is okay. The error message is only relevant if you see it when
 // you run "go build" or "go run".
-var _ codegen.LatestVersion = codegen.Version[[0][24]struct{}](`
+var _ codegen.LatestVersion = codegen.Version[[0][25]struct{}](`
 
 ERROR: You generated this file with 'mx generate' (devel) (codegen
-version v0.24.0). The generated code is incompatible with the version of the
+version v0.25.0). The generated code is incompatible with the version of the
 github.com/sh3lk/mx module that you're using. The mx module
 version can be found in your go.mod file or by running the following command.
 
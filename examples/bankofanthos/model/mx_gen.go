@@ -21,10 +21,10 @@ import (
 // Note that "mx generate" will always generate the error message below.
 // Everything is okay. The error message is only relevant if you see it when
 // you run "go build" or "go run".
-var _ codegen.LatestVersion = codegen.Version[[0][24]struct{}](`
+var _ codegen.LatestVersion = codegen.Version[[0][25]struct{}](`
 
 ERROR: You generated this file with 'mx generate' (devel) (codegen
-version v0.24.0). The generated code is incompatible with the version of the
+version v0.25.0). The generated code is incompatible with the version of the
 github.com/sh3lk/mx module that you're using. The mx module
 version can be found in your go.mod file or by running the following command.
 
@@ -31,7 +31,7 @@ func init() {
 		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
 			return t_reflect_stub{caller: caller}
 		},
-		RefData: "",
+		RefData: "⟦738ad0b3:MxSchema:github.com/sh3lk/mx/examples/bankofanthos/transactionhistory/T→[{\"name\":\"GetTransactions\",\"args\":[{\"kind\":\"string\"}],\"results\":[{\"kind\":\"slice\",\"elem\":{\"kind\":\"struct\",\"name\":\"model.Transaction\",\"fields\":[{\"name\":\"FromAccountNum\",\"type\":{\"kind\":\"string\"}},{\"name\":\"FromRoutingNum\",\"type\":{\"kind\":\"string\"}},{\"name\":\"ToAccountNum\",\"type\":{\"kind\":\"string\"}},{\"name\":\"ToRoutingNum\",\"type\":{\"kind\":\"string\"}},{\"name\":\"Amount\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"Timestamp\",\"type\":{\"kind\":\"binary\",\"name\":\"time.Time\"}}]}}]}]⟧\n",
 	})
 }
 
@@ -114,7 +114,8 @@ func (s t_client_stub) GetTransactions(ctx context.Context, a0 string) (r0 []mod
 	// Preallocate a buffer of the right size.
 	size := 0
 	size += (4 + len(a0))
-	enc := codegen.NewEncoder()
+	enc := codegen.GetEncoder()
+	defer codegen.PutEncoder(enc)
 	enc.Reset(size)
 
 	// Encode arguments.
@@ -132,8 +133,8 @@ func (s t_client_stub) GetTransactions(ctx context.Context, a0 string) (r0 []mod
 	}
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
-	r0 = mx_dec_slice_Transaction_d2a36fba(dec)
+	dec := codegen.NewZeroCopyDecoder(results)
+	r0 = mx_dec_slice_Transaction_35e83c8d(dec)
 	err = dec.Error()
 	return
 }
@@ -141,10 +142,10 @@ func (s t_client_stub) GetTransactions(ctx context.Context, a0 string) (r0 []mod
 // Note that "mx generate" will always generate the error message below.
 // Everything is okay. The error message is only relevant if you see it when
 // you run "go build" or "go run".
-var _ codegen.LatestVersion = codegen.Version[[0][24]struct{}](`
+var _ codegen.LatestVersion = codegen.Version[[0][25]struct{}](`
 
 ERROR: You generated this file with 'mx generate' (devel) (codegen
-version v0.24.0). The generated code is incompatible with the version of the
+version v0.25.0). The generated code is incompatible with the version of the
 github.com/sh3lk/mx module that you're using. The mx module
 version can be found in your go.mod file or by running the following command.
 
@@ -201,7 +202,7 @@ func (s t_server_stub) getTransactions(ctx context.Context, args []byte) (res []
 
 	// Encode the results.
 	enc := codegen.NewEncoder()
-	mx_enc_slice_Transaction_d2a36fba(enc, r0)
+	mx_enc_slice_Transaction_35e83c8d(enc, r0)
 	enc.Error(appErr)
 	return enc.Data(), nil
 }
@@ -222,7 +223,7 @@ func (s t_reflect_stub) GetTransactions(ctx context.Context, a0 string) (r0 []mo
 
 // Encoding/decoding implementations.
 
-func mx_enc_slice_Transaction_d2a36fba(enc *codegen.Encoder, arg []model.Transaction) {
+func mx_enc_slice_Transaction_35e83c8d(enc *codegen.Encoder, arg []model.Transaction) {
 	if arg == nil {
 		enc.Len(-1)
 		return
@@ -233,7 +234,7 @@ func mx_enc_slice_Transaction_d2a36fba(enc *codegen.Encoder, arg []model.Transac
 	}
 }
 
-func mx_dec_slice_Transaction_d2a36fba(dec *codegen.Decoder) []model.Transaction {
+func mx_dec_slice_Transaction_35e83c8d(dec *codegen.Decoder) []model.Transaction {
 	n := dec.Len()
 	if n == -1 {
 		return nil
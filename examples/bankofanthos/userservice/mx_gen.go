@@ -31,7 +31,7 @@ func init() {
 		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
 			return t_reflect_stub{caller: caller}
 		},
-		RefData: "",
+		RefData: "⟦95fc78ff:MxSchema:github.com/sh3lk/mx/examples/bankofanthos/userservice/T→[{\"name\":\"CreateUser\",\"args\":[{\"kind\":\"struct\",\"name\":\"userservice.CreateUserRequest\",\"fields\":[{\"name\":\"Username\",\"type\":{\"kind\":\"string\"}},{\"name\":\"Password\",\"type\":{\"kind\":\"string\"}},{\"name\":\"PasswordRepeat\",\"type\":{\"kind\":\"string\"}},{\"name\":\"FirstName\",\"type\":{\"kind\":\"string\"}},{\"name\":\"LastName\",\"type\":{\"kind\":\"string\"}},{\"name\":\"Birthday\",\"type\":{\"kind\":\"string\"}},{\"name\":\"Timezone\",\"type\":{\"kind\":\"string\"}},{\"name\":\"Address\",\"type\":{\"kind\":\"string\"}},{\"name\":\"State\",\"type\":{\"kind\":\"string\"}},{\"name\":\"Zip\",\"type\":{\"kind\":\"string\"}},{\"name\":\"Ssn\",\"type\":{\"kind\":\"string\"}}]}]},{\"name\":\"Login\",\"args\":[{\"kind\":\"struct\",\"name\":\"userservice.LoginRequest\",\"fields\":[{\"name\":\"Username\",\"type\":{\"kind\":\"string\"}},{\"name\":\"Password\",\"type\":{\"kind\":\"string\"}}]}],\"results\":[{\"kind\":\"string\"}]}]⟧\n",
 	})
 }
 
@@ -135,8 +135,9 @@ func (s t_client_stub) CreateUser(ctx context.Context, a0 CreateUserRequest) (er
 
 	// Preallocate a buffer of the right size.
 	size := 0
-	size += mx_size_CreateUserRequest_4ef79cd1(&a0)
-	enc := codegen.NewEncoder()
+	size += mx_size_CreateUserRequest_27c0c1d8(&a0)
+	enc := codegen.GetEncoder()
+	defer codegen.PutEncoder(enc)
 	enc.Reset(size)
 
 	// Encode arguments.
@@ -154,7 +155,7 @@ func (s t_client_stub) CreateUser(ctx context.Context, a0 CreateUserRequest) (er
 	}
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewZeroCopyDecoder(results)
 	err = dec.Error()
 	return
 }
@@ -190,8 +191,9 @@ func (s t_client_stub) Login(ctx context.Context, a0 LoginRequest) (r0 string, e
 
 	// Preallocate a buffer of the right size.
 	size := 0
-	size += mx_size_LoginRequest_cbd66e76(&a0)
-	enc := codegen.NewEncoder()
+	size += mx_size_LoginRequest_4b5c8ae5(&a0)
+	enc := codegen.GetEncoder()
+	defer codegen.PutEncoder(enc)
 	enc.Reset(size)
 
 	// Encode arguments.
@@ -209,7 +211,7 @@ func (s t_client_stub) Login(ctx context.Context, a0 LoginRequest) (r0 string, e
 	}
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewZeroCopyDecoder(results)
 	r0 = dec.String()
 	err = dec.Error()
 	return
@@ -218,10 +220,10 @@ func (s t_client_stub) Login(ctx context.Context, a0 LoginRequest) (r0 string, e
 // Note that "mx generate" will always generate the error message below.
 // Everything is okay. The error message is only relevant if you see it when
 // you run "go build" or "go run".
-var _ codegen.LatestVersion = codegen.Version[[0][24]struct{}](`
+var _ codegen.LatestVersion = codegen.Version[[0][25]struct{}](`
 
 ERROR: You generated this file with 'mx generate' (devel) (codegen
-version v0.24.0). The generated code is incompatible with the version of the
+version v0.25.0). The generated code is incompatible with the version of the
 github.com/sh3lk/mx module that you're using. The mx module
 version can be found in your go.mod file or by running the following command.
 
@@ -487,9 +489,9 @@ func mx_dec_slice_byte_87461245(dec *codegen.Decoder) []byte {
 
 // Size implementations.
 
-// mx_size_CreateUserRequest_4ef79cd1 returns the size (in bytes) of the serialization
+// mx_size_CreateUserRequest_27c0c1d8 returns the size (in bytes) of the serialization
 // of the provided type.
-func mx_size_CreateUserRequest_4ef79cd1(x *CreateUserRequest) int {
+func mx_size_CreateUserRequest_27c0c1d8(x *CreateUserRequest) int {
 	size := 0
 	size += 0
 	size += (4 + len(x.Username))
@@ -506,9 +508,9 @@ func mx_size_CreateUserRequest_4ef79cd1(x *CreateUserRequest) int {
 	return size
 }
 
-// mx_size_LoginRequest_cbd66e76 returns the size (in bytes) of the serialization
+// mx_size_LoginRequest_4b5c8ae5 returns the size (in bytes) of the serialization
 // of the provided type.
-func mx_size_LoginRequest_cbd66e76(x *LoginRequest) int {
+func mx_size_LoginRequest_4b5c8ae5(x *LoginRequest) int {
 	size := 0
 	size += 0
 	size += (4 + len(x.Username))
@@ -32,7 +32,7 @@ func init() {
 		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
 			return imageScaler_reflect_stub{caller: caller}
 		},
-		RefData: "",
+		RefData: "⟦62dacbdd:MxSchema:github.com/sh3lk/mx/examples/chat/ImageScaler→[{\"name\":\"Scale\",\"args\":[{\"kind\":\"slice\",\"elem\":{\"kind\":\"uint8\"}},{\"kind\":\"int64\"},{\"kind\":\"int64\"}],\"results\":[{\"kind\":\"slice\",\"elem\":{\"kind\":\"uint8\"}}]}]⟧\n",
 	})
 	codegen.Register(codegen.Registration{
 		Name:  "github.com/sh3lk/mx/examples/chat/LocalCache",
@@ -50,7 +50,7 @@ func init() {
 		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
 			return localCache_reflect_stub{caller: caller}
 		},
-		RefData: "",
+		RefData: "⟦caee9a70:MxSchema:github.com/sh3lk/mx/examples/chat/LocalCache→[{\"name\":\"Get\",\"args\":[{\"kind\":\"string\"}],\"results\":[{\"kind\":\"string\"}]},{\"name\":\"Put\",\"args\":[{\"kind\":\"string\"},{\"kind\":\"string\"}]}]⟧\n",
 	})
 	codegen.Register(codegen.Registration{
 		Name:      "github.com/sh3lk/mx/Main",
@@ -67,7 +67,7 @@ func init() {
 		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
 			return main_reflect_stub{caller: caller}
 		},
-		RefData: "⟦7e1a0aa0:MxEdge:github.com/sh3lk/mx/Main→github.com/sh3lk/mx/examples/chat/SQLStore⟧\n⟦ae108c0d:MxEdge:github.com/sh3lk/mx/Main→github.com/sh3lk/mx/examples/chat/ImageScaler⟧\n⟦c86a1d44:MxEdge:github.com/sh3lk/mx/Main→github.com/sh3lk/mx/examples/chat/LocalCache⟧\n⟦7b9a3b0b:wEaVeRlIsTeNeRs:github.com/sh3lk/mx/Main→chat⟧\n",
+		RefData: "⟦7e4491e6:MxEdge:github.com/sh3lk/mx/Main→github.com/sh3lk/mx/examples/chat/SQLStore⟧\n⟦ce85ae7b:MxEdge:github.com/sh3lk/mx/Main→github.com/sh3lk/mx/examples/chat/ImageScaler⟧\n⟦26c79e6f:MxEdge:github.com/sh3lk/mx/Main→github.com/sh3lk/mx/examples/chat/LocalCache⟧\n⟦4d3e3787:wEaVeRlIsTeNeRs:github.com/sh3lk/mx/Main→chat⟧\n",
 	})
 	codegen.Register(codegen.Registration{
 		Name:    "github.com/sh3lk/mx/examples/chat/SQLStore",
@@ -86,7 +86,7 @@ func init() {
 		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
 			return sQLStore_reflect_stub{caller: caller}
 		},
-		RefData: "",
+		RefData: "⟦37a0df96:MxSchema:github.com/sh3lk/mx/examples/chat/SQLStore→[{\"name\":\"CreatePost\",\"args\":[{\"kind\":\"string\"},{\"kind\":\"binary\",\"name\":\"time.Time\"},{\"kind\":\"int64\",\"name\":\"main.ThreadID\"},{\"kind\":\"string\"}]},{\"name\":\"CreateThread\",\"args\":[{\"kind\":\"string\"},{\"kind\":\"binary\",\"name\":\"time.Time\"},{\"kind\":\"slice\",\"elem\":{\"kind\":\"string\"}},{\"kind\":\"string\"},{\"kind\":\"slice\",\"elem\":{\"kind\":\"uint8\"}}],\"results\":[{\"kind\":\"int64\",\"name\":\"main.ThreadID\"}]},{\"name\":\"GetFeed\",\"args\":[{\"kind\":\"string\"}],\"results\":[{\"kind\":\"slice\",\"elem\":{\"kind\":\"struct\",\"name\":\"main.Thread\",\"fields\":[{\"name\":\"ID\",\"type\":{\"kind\":\"int64\",\"name\":\"main.ThreadID\"}},{\"name\":\"Posts\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"struct\",\"name\":\"main.Post\",\"fields\":[{\"name\":\"ID\",\"type\":{\"kind\":\"int64\",\"name\":\"main.PostID\"}},{\"name\":\"Creator\",\"type\":{\"kind\":\"string\"}},{\"name\":\"When\",\"type\":{\"kind\":\"binary\",\"name\":\"time.Time\"}},{\"name\":\"Text\",\"type\":{\"kind\":\"string\"}},{\"name\":\"ImageID\",\"type\":{\"kind\":\"int64\",\"name\":\"main.ImageID\"}}]}}}]}}]},{\"name\":\"GetImage\",\"args\":[{\"kind\":\"string\"},{\"kind\":\"int64\",\"name\":\"main.ImageID\"}],\"results\":[{\"kind\":\"slice\",\"elem\":{\"kind\":\"uint8\"}}]}]⟧\n",
 	})
 }
 
@@ -327,7 +327,8 @@ func (s imageScaler_client_stub) Scale(ctx context.Context, a0 []byte, a1 int, a
 	size += (4 + (len(a0) * 1))
 	size += 8
 	size += 8
-	enc := codegen.NewEncoder()
+	enc := codegen.GetEncoder()
+	defer codegen.PutEncoder(enc)
 	enc.Reset(size)
 
 	// Encode arguments.
@@ -347,7 +348,7 @@ func (s imageScaler_client_stub) Scale(ctx context.Context, a0 []byte, a1 int, a
 	}
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewZeroCopyDecoder(results)
 	r0 = mx_dec_slice_byte_87461245(dec)
 	err = dec.Error()
 	return
@@ -394,7 +395,8 @@ func (s localCache_client_stub) Get(ctx context.Context, a0 string) (r0 string,
 	// Preallocate a buffer of the right size.
 	size := 0
 	size += (4 + len(a0))
-	enc := codegen.NewEncoder()
+	enc := codegen.GetEncoder()
+	defer codegen.PutEncoder(enc)
 	enc.Reset(size)
 
 	// Encode arguments.
@@ -412,7 +414,7 @@ func (s localCache_client_stub) Get(ctx context.Context, a0 string) (r0 string,
 	}
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewZeroCopyDecoder(results)
 	r0 = dec.String()
 	err = dec.Error()
 	return
@@ -451,7 +453,8 @@ func (s localCache_client_stub) Put(ctx context.Context, a0 string, a1 string) (
 	size := 0
 	size += (4 + len(a0))
 	size += (4 + len(a1))
-	enc := codegen.NewEncoder()
+	enc := codegen.GetEncoder()
+	defer codegen.PutEncoder(enc)
 	enc.Reset(size)
 
 	// Encode arguments.
@@ -470,7 +473,7 @@ func (s localCache_client_stub) Put(ctx context.Context, a0 string, a1 string) (
 	}
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewZeroCopyDecoder(results)
 	err = dec.Error()
 	return
 }
@@ -523,7 +526,8 @@ func (s sQLStore_client_stub) CreatePost(ctx context.Context, a0 string, a1 time
 	}()
 
 	// Encode arguments.
-	enc := codegen.NewEncoder()
+	enc := codegen.GetEncoder()
+	defer codegen.PutEncoder(enc)
 	enc.String(a0)
 	enc.EncodeBinaryMarshaler(&a1)
 	enc.Int64((int64)(a2))
@@ -541,7 +545,7 @@ func (s sQLStore_client_stub) CreatePost(ctx context.Context, a0 string, a1 time
 	}
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewZeroCopyDecoder(results)
 	err = dec.Error()
 	return
 }
@@ -576,7 +580,8 @@ func (s sQLStore_client_stub) CreateThread(ctx context.Context, a0 string, a1 ti
 	}()
 
 	// Encode arguments.
-	enc := codegen.NewEncoder()
+	enc := codegen.GetEncoder()
+	defer codegen.PutEncoder(enc)
 	enc.String(a0)
 	enc.EncodeBinaryMarshaler(&a1)
 	mx_enc_slice_string_4af10117(enc, a2)
@@ -595,7 +600,7 @@ func (s sQLStore_client_stub) CreateThread(ctx context.Context, a0 string, a1 ti
 	}
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewZeroCopyDecoder(results)
 	*(*int64)(&r0) = dec.Int64()
 	err = dec.Error()
 	return
@@ -633,7 +638,8 @@ func (s sQLStore_client_stub) GetFeed(ctx context.Context, a0 string) (r0 []Thre
 	// Preallocate a buffer of the right size.
 	size := 0
 	size += (4 + len(a0))
-	enc := codegen.NewEncoder()
+	enc := codegen.GetEncoder()
+	defer codegen.PutEncoder(enc)
 	enc.Reset(size)
 
 	// Encode arguments.
@@ -651,8 +657,8 @@ func (s sQLStore_client_stub) GetFeed(ctx context.Context, a0 string) (r0 []Thre
 	}
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
-	r0 = mx_dec_slice_Thread_511e1469(dec)
+	dec := codegen.NewZeroCopyDecoder(results)
+	r0 = mx_dec_slice_Thread_1162cfa3(dec)
 	err = dec.Error()
 	return
 }
@@ -690,7 +696,8 @@ func (s sQLStore_client_stub) GetImage(ctx context.Context, a0 string, a1 ImageI
 	size := 0
 	size += (4 + len(a0))
 	size += 8
-	enc := codegen.NewEncoder()
+	enc := codegen.GetEncoder()
+	defer codegen.PutEncoder(enc)
 	enc.Reset(size)
 
 	// Encode arguments.
@@ -709,7 +716,7 @@ func (s sQLStore_client_stub) GetImage(ctx context.Context, a0 string, a1 ImageI
 	}
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewZeroCopyDecoder(results)
 	r0 = mx_dec_slice_byte_87461245(dec)
 	err = dec.Error()
 	return
@@ -718,10 +725,10 @@ func (s sQLStore_client_stub) GetImage(ctx context.Context, a0 string, a1 ImageI
 // Note that "mx generate" will always generate the error message below.
 // Everything is okay. The error message is only relevant if you see it when
 // you run "go build" or "go run".
-var _ codegen.LatestVersion = codegen.Version[[0][24]struct{}](`
+var _ codegen.LatestVersion = codegen.Version[[0][25]struct{}](`
 
-ERROR: You generated this file with 'mx generate' v0.24.7-0.20250401231336-b01860e0378a+dirty (codegen
-version v0.24.0). The generated code is incompatible with the version of the
+ERROR: You generated this file with 'mx generate' (devel) (codegen
+version v0.25.0). The generated code is incompatible with the version of the
 github.com/sh3lk/mx module that you're using. The mx module
 version can be found in your go.mod file or by running the following command.
 
@@ -981,7 +988,7 @@ func (s sQLStore_server_stub) getFeed(ctx context.Context, args []byte) (res []b
 
 	// Encode the results.
 	enc := codegen.NewEncoder()
-	mx_enc_slice_Thread_511e1469(enc, r0)
+	mx_enc_slice_Thread_1162cfa3(enc, r0)
 	enc.Error(appErr)
 	return enc.Data(), nil
 }
@@ -1130,7 +1137,7 @@ func (x *Thread) MXMarshal(enc *codegen.Encoder) {
 		panic(fmt.Errorf("Thread.MXMarshal: nil receiver"))
 	}
 	enc.Int64((int64)(x.ID))
-	mx_enc_slice_Post_29a9ee83(enc, x.Posts)
+	mx_enc_slice_Post_7d792fed(enc, x.Posts)
 }
 
 func (x *Thread) MXUnmarshal(dec *codegen.Decoder) {
@@ -1138,10 +1145,10 @@ func (x *Thread) MXUnmarshal(dec *codegen.Decoder) {
 		panic(fmt.Errorf("Thread.MXUnmarshal: nil receiver"))
 	}
 	*(*int64)(&x.ID) = dec.Int64()
-	x.Posts = mx_dec_slice_Post_29a9ee83(dec)
+	x.Posts = mx_dec_slice_Post_7d792fed(dec)
 }
 
-func mx_enc_slice_Post_29a9ee83(enc *codegen.Encoder, arg []Post) {
+func mx_enc_slice_Post_7d792fed(enc *codegen.Encoder, arg []Post) {
 	if arg == nil {
 		enc.Len(-1)
 		return
@@ -1152,7 +1159,7 @@ func mx_enc_slice_Post_29a9ee83(enc *codegen.Encoder, arg []Post) {
 	}
 }
 
-func mx_dec_slice_Post_29a9ee83(dec *codegen.Decoder) []Post {
+func mx_dec_slice_Post_7d792fed(dec *codegen.Decoder) []Post {
 	n := dec.Len()
 	if n == -1 {
 		return nil
@@ -1212,7 +1219,7 @@ func mx_dec_slice_string_4af10117(dec *codegen.Decoder) []string {
 	return res
 }
 
-func mx_enc_slice_Thread_511e1469(enc *codegen.Encoder, arg []Thread) {
+func mx_enc_slice_Thread_1162cfa3(enc *codegen.Encoder, arg []Thread) {
 	if arg == nil {
 		enc.Len(-1)
 		return
@@ -1223,7 +1230,7 @@ func mx_enc_slice_Thread_511e1469(enc *codegen.Encoder, arg []Thread) {
 	}
 }
 
-func mx_dec_slice_Thread_511e1469(dec *codegen.Decoder) []Thread {
+func mx_dec_slice_Thread_1162cfa3(dec *codegen.Decoder) []Thread {
 	n := dec.Len()
 	if n == -1 {
 		return nil
@@ -30,7 +30,7 @@ func init() {
 		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
 			return even_reflect_stub{caller: caller}
 		},
-		RefData: "",
+		RefData: "⟦0953c201:MxSchema:github.com/sh3lk/mx/examples/collatz/Even→[{\"name\":\"Do\",\"args\":[{\"kind\":\"int64\"}],\"results\":[{\"kind\":\"int64\"}]}]⟧\n",
 	})
 	codegen.Register(codegen.Registration{
 		Name:      "github.com/sh3lk/mx/Main",
@@ -47,7 +47,7 @@ func init() {
 		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
 			return main_reflect_stub{caller: caller}
 		},
-		RefData: "⟦f95ad2dd:MxEdge:github.com/sh3lk/mx/Main→github.com/sh3lk/mx/examples/collatz/Odd⟧\n⟦987c175b:MxEdge:github.com/sh3lk/mx/Main→github.com/sh3lk/mx/examples/collatz/Even⟧\n⟦f3b62957:wEaVeRlIsTeNeRs:github.com/sh3lk/mx/Main→collatz⟧\n",
+		RefData: "⟦58468fc3:MxEdge:github.com/sh3lk/mx/Main→github.com/sh3lk/mx/examples/collatz/Odd⟧\n⟦fc8a7be4:MxEdge:github.com/sh3lk/mx/Main→github.com/sh3lk/mx/examples/collatz/Even⟧\n⟦28481b2f:wEaVeRlIsTeNeRs:github.com/sh3lk/mx/Main→collatz⟧\n",
 	})
 	codegen.Register(codegen.Registration{
 		Name:  "github.com/sh3lk/mx/examples/collatz/Odd",
@@ -65,7 +65,7 @@ func init() {
 		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
 			return odd_reflect_stub{caller: caller}
 		},
-		RefData: "",
+		RefData: "⟦89294d1a:MxSchema:github.com/sh3lk/mx/examples/collatz/Odd→[{\"name\":\"Do\",\"args\":[{\"kind\":\"int64\"}],\"results\":[{\"kind\":\"int64\"}]}]⟧\n",
 	})
 }
 
@@ -189,7 +189,8 @@ func (s even_client_stub) Do(ctx context.Context, a0 int) (r0 int, err error) {
 	// Preallocate a buffer of the right size.
 	size := 0
 	size += 8
-	enc := codegen.NewEncoder()
+	enc := codegen.GetEncoder()
+	defer codegen.PutEncoder(enc)
 	enc.Reset(size)
 
 	// Encode arguments.
@@ -207,7 +208,7 @@ func (s even_client_stub) Do(ctx context.Context, a0 int) (r0 int, err error) {
 	}
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewZeroCopyDecoder(results)
 	r0 = dec.Int()
 	err = dec.Error()
 	return
@@ -260,7 +261,8 @@ func (s odd_client_stub) Do(ctx context.Context, a0 int) (r0 int, err error) {
 	// Preallocate a buffer of the right size.
 	size := 0
 	size += 8
-	enc := codegen.NewEncoder()
+	enc := codegen.GetEncoder()
+	defer codegen.PutEncoder(enc)
 	enc.Reset(size)
 
 	// Encode arguments.
@@ -278,7 +280,7 @@ func (s odd_client_stub) Do(ctx context.Context, a0 int) (r0 int, err error) {
 	}
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewZeroCopyDecoder(results)
 	r0 = dec.Int()
 	err = dec.Error()
 	return
@@ -287,10 +289,10 @@ func (s odd_client_stub) Do(ctx context.Context, a0 int) (r0 int, err error) {
 // Note that "mx generate" will always generate the error message below.
 // Everything is okay. The error message is only relevant if you see it when
 // you run "go build" or "go run".
-var _ codegen.LatestVersion = codegen.Version[[0][24]struct{}](`
+var _ codegen.LatestVersion = codegen.Version[[0][25]struct{}](`
 
-ERROR: You generated this file with 'mx generate' v0.24.7-0.20250401231336-b01860e0378a+dirty (codegen
-version v0.24.0). The generated code is incompatible with the version of the
+ERROR: You generated this file with 'mx generate' (devel) (codegen
+version v0.25.0). The generated code is incompatible with the version of the
 github.com/sh3lk/mx module that you're using. The mx module
 version can be found in your go.mod file or by running the following command.
 
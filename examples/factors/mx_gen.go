@@ -31,7 +31,7 @@ func init() {
 		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
 			return factorer_reflect_stub{caller: caller}
 		},
-		RefData: "",
+		RefData: "⟦4ba052af:MxSchema:github.com/sh3lk/mx/examples/factors/Factorer→[{\"name\":\"Factors\",\"args\":[{\"kind\":\"int64\"}],\"results\":[{\"kind\":\"slice\",\"elem\":{\"kind\":\"int64\"}}]}]⟧\n",
 	})
 	codegen.Register(codegen.Registration{
 		Name:      "github.com/sh3lk/mx/Main",
@@ -48,7 +48,7 @@ func init() {
 		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
 			return main_reflect_stub{caller: caller}
 		},
-		RefData: "⟦4724da9b:MxEdge:github.com/sh3lk/mx/Main→github.com/sh3lk/mx/examples/factors/Factorer⟧\n⟦68699208:wEaVeRlIsTeNeRs:github.com/sh3lk/mx/Main→factors⟧\n",
+		RefData: "⟦2ae9b113:MxEdge:github.com/sh3lk/mx/Main→github.com/sh3lk/mx/examples/factors/Factorer⟧\n⟦c75ce091:wEaVeRlIsTeNeRs:github.com/sh3lk/mx/Main→factors⟧\n",
 	})
 }
 
@@ -144,7 +144,8 @@ func (s factorer_client_stub) Factors(ctx context.Context, a0 int) (r0 []int, er
 	// Preallocate a buffer of the right size.
 	size := 0
 	size += 8
-	enc := codegen.NewEncoder()
+	enc := codegen.GetEncoder()
+	defer codegen.PutEncoder(enc)
 	enc.Reset(size)
 
 	// Encode arguments.
@@ -165,7 +166,7 @@ func (s factorer_client_stub) Factors(ctx context.Context, a0 int) (r0 []int, er
 	}
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewZeroCopyDecoder(results)
 	r0 = mx_dec_slice_int_7c8c8866(dec)
 	err = dec.Error()
 	return
@@ -181,10 +182,10 @@ var _ mx.Main = (*main_client_stub)(nil)
 // Note that "mx generate" will always generate the error message below.
 // Everything is okay. The error message is only relevant if you see it when
 // you run "go build" or "go run".
-var _ codegen.LatestVersion = codegen.Version[[0][24]struct{}](`
+var _ codegen.LatestVersion = codegen.Version[[0][25]struct{}](`
 
-ERROR: You generated this file with 'mx generate' v0.24.7-0.20250401231336-b01860e0378a+dirty (codegen
-version v0.24.0). The generated code is incompatible with the version of the
+ERROR: You generated this file with 'mx generate' (devel) (codegen
+version v0.25.0). The generated code is incompatible with the version of the
 github.com/sh3lk/mx module that you're using. The mx module
 version can be found in your go.mod file or by running the following command.
 
@@ -30,7 +30,7 @@ func init() {
 		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
 			return clock_reflect_stub{caller: caller}
 		},
-		RefData: "",
+		RefData: "⟦55ed47fd:MxSchema:github.com/sh3lk/mx/examples/fakes/Clock→[{\"name\":\"UnixMicro\",\"results\":[{\"kind\":\"int64\"}]}]⟧\n",
 	})
 }
 
@@ -122,7 +122,7 @@ func (s clock_client_stub) UnixMicro(ctx context.Context) (r0 int64, err error)
 	}
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewZeroCopyDecoder(results)
 	r0 = dec.Int64()
 	err = dec.Error()
 	return
@@ -131,10 +131,10 @@ func (s clock_client_stub) UnixMicro(ctx context.Context) (r0 int64, err error)
 // Note that "mx generate" will always generate the error message below.
 // Everything is okay. The error message is only relevant if you see it when
 // you run "go build" or "go run".
-var _ codegen.LatestVersion = codegen.Version[[0][24]struct{}](`
+var _ codegen.LatestVersion = codegen.Version[[0][25]struct{}](`
 
-ERROR: You generated this file with 'mx generate' v0.24.7-0.20250401231336-b01860e0378a+dirty (codegen
-version v0.24.0). The generated code is incompatible with the version of the
+ERROR: You generated this file with 'mx generate' (devel) (codegen
+version v0.25.0). The generated code is incompatible with the version of the
 github.com/sh3lk/mx module that you're using. The mx module
 version can be found in your go.mod file or by running the following command.
 
@@ -29,7 +29,7 @@ func init() {
 		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
 			return main_reflect_stub{caller: caller}
 		},
-		RefData: "⟦8d621687:MxEdge:github.com/sh3lk/mx/Main→github.com/sh3lk/mx/examples/hello/Reverser⟧\n⟦17f36ff9:wEaVeRlIsTeNeRs:github.com/sh3lk/mx/Main→hello⟧\n",
+		RefData: "⟦fb0dc743:MxEdge:github.com/sh3lk/mx/Main→github.com/sh3lk/mx/examples/hello/Reverser⟧\n⟦cbcda6de:wEaVeRlIsTeNeRs:github.com/sh3lk/mx/Main→hello⟧\n",
 	})
 	codegen.Register(codegen.Registration{
 		Name:  "github.com/sh3lk/mx/examples/hello/Reverser",
@@ -47,7 +47,7 @@ func init() {
 		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
 			return reverser_reflect_stub{caller: caller}
 		},
-		RefData: "",
+		RefData: "⟦1fd83360:MxSchema:github.com/sh3lk/mx/examples/hello/Reverser→[{\"name\":\"Reverse\",\"args\":[{\"kind\":\"string\"}],\"results\":[{\"kind\":\"string\"}]}]⟧\n",
 	})
 }
 
@@ -147,7 +147,8 @@ func (s reverser_client_stub) Reverse(ctx context.Context, a0 string) (r0 string
 	// Preallocate a buffer of the right size.
 	size := 0
 	size += (4 + len(a0))
-	enc := codegen.NewEncoder()
+	enc := codegen.GetEncoder()
+	defer codegen.PutEncoder(enc)
 	enc.Reset(size)
 
 	// Encode arguments.
@@ -165,7 +166,7 @@ func (s reverser_client_stub) Reverse(ctx context.Context, a0 string) (r0 string
 	}
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewZeroCopyDecoder(results)
 	r0 = dec.String()
 	err = dec.Error()
 	return
@@ -174,10 +175,10 @@ func (s reverser_client_stub) Reverse(ctx context.Context, a0 string) (r0 string
 // Note that "mx generate" will always generate the error message below.
 // Everything is okay. The error message is only relevant if you see it when
 // you run "go build" or "go run".
-var _ codegen.LatestVersion = codegen.Version[[0][24]struct{}](`
+var _ codegen.LatestVersion = codegen.Version[[0][25]struct{}](`
 
-ERROR: You generated this file with 'mx generate' v0.24.7-0.20250401231336-b01860e0378a+dirty (codegen
-version v0.24.0). The generated code is incompatible with the version of the
+ERROR: You generated this file with 'mx generate' (devel) (codegen
+version v0.25.0). The generated code is incompatible with the version of the
 github.com/sh3lk/mx module that you're using. The mx module
 version can be found in your go.mod file or by running the following command.
 
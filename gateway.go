@@ -0,0 +1,229 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mx
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/sh3lk/mx/metadata"
+	"github.com/sh3lk/mx/runtime/codegen"
+)
+
+const (
+	// gatewayTimeoutHeader is the HTTP header that holds the timeout of a
+	// gateway call, formatted as a time.Duration (e.g., "1.5s").
+	gatewayTimeoutHeader = "Mx-Timeout"
+
+	// gatewayMetadataPrefix is the prefix of the HTTP headers that hold the
+	// metadata of a gateway call (see the metadata package).
+	gatewayMetadataPrefix = "Mx-Metadata-"
+
+	// maxGatewayBodySize is the maximum size of the body of a gateway call.
+	maxGatewayBodySize = 10 << 20
+)
+
+// NewGateway returns an HTTP handler that serves the methods of component T
+// as JSON over HTTP, so that programs not written in Go, scripts, and curl can
+// call them. The code for the gateway must be generated by running "mx
+// generate -gateway". Streaming methods are not served.
+//
+// Pass the component obtained from an mx.Ref, so that calls made through the
+// gateway are routed, traced, and authorized like any other component method
+// call. The gateway serves the following requests:
+//
+//   - POST /<Method> calls the method. The body of the request is a JSON
+//     object with a field per argument, named like the argument. The body of
+//     the reply is the JSON-encoded result of the method, a JSON array if the
+//     method has several results, or null if it has none.
+//   - GET /<Method> returns the JSON schemas of the arguments and results of
+//     the method.
+//   - GET / returns the JSON schemas of all methods.
+//
+// The timeout of a call, if any, is set in the Mx-Timeout header, formatted as
+// a time.Duration (e.g., "500ms"). Headers prefixed with Mx-Metadata- are
+// propagated to the component as metadata, with the prefix removed and the key
+// lowercased. A failed call returns a JSON object with an "error" field. The
+// body of a call is limited to 10 MiB; larger calls fail with status 413.
+//
+// The gateway is usually served on an mx.Listener:
+//
+//	type app struct {
+//	    mx.Implements[mx.Main]
+//	    cache mx.Ref[Cache]
+//	    api   mx.Listener
+//	}
+//
+//	func serve(ctx context.Context, app *app) error {
+//	    gateway, err := mx.NewGateway(app.cache.Get())
+//	    if err != nil {
+//	        return err
+//	    }
+//	    return http.Serve(app.api, gateway)
+//	}
+func NewGateway[T any](component T) (http.Handler, error) {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	for _, reg := range codegen.Registered() {
+		if reg.Iface != t {
+			continue
+		}
+		if reg.GatewayFn == nil {
+			return nil, fmt.Errorf("NewGateway: no gateway for component %s; run \"mx generate -gateway\"", reg.Name)
+		}
+		return newGateway(reg.Name, reg.GatewayFn(component)), nil
+	}
+	return nil, fmt.Errorf("NewGateway: %v is not a registered component", t)
+}
+
+// gateway is an HTTP handler that calls the methods of a component.
+type gateway struct {
+	component string                           // component name
+	methods   map[string]codegen.GatewayMethod // methods, by name
+}
+
+// gatewaySchema holds the JSON schemas of a method.
+type gatewaySchema struct {
+	Args   json.RawMessage `json:"args"`
+	Result json.RawMessage `json:"result"`
+}
+
+// newGateway returns a gateway that serves the provided methods of the named
+// component.
+func newGateway(component string, methods []codegen.GatewayMethod) *gateway {
+	g := &gateway{component: component, methods: map[string]codegen.GatewayMethod{}}
+	for _, m := range methods {
+		g.methods[m.Name] = m
+	}
+	return g
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (g *gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	name := strings.TrimPrefix(r.URL.Path, "/")
+	if name == "" {
+		if r.Method != http.MethodGet {
+			w.Header().Set("Allow", http.MethodGet)
+			writeGatewayError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s not allowed", r.Method))
+			return
+		}
+		schemas := map[string]gatewaySchema{}
+		for name, m := range g.methods {
+			schemas[name] = gatewaySchema{json.RawMessage(m.Args), json.RawMessage(m.Result)}
+		}
+		writeGatewayReply(w, http.StatusOK, struct {
+			Component string                   `json:"component"`
+			Methods   map[string]gatewaySchema `json:"methods"`
+		}{g.component, schemas})
+		return
+	}
+
+	m, ok := g.methods[name]
+	if !ok {
+		writeGatewayError(w, http.StatusNotFound, fmt.Errorf("component %s has no method %q", g.component, name))
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		writeGatewayReply(w, http.StatusOK, gatewaySchema{json.RawMessage(m.Args), json.RawMessage(m.Result)})
+	case http.MethodPost:
+		g.call(w, r, m)
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		writeGatewayError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s not allowed", r.Method))
+	}
+}
+
+// call calls the provided method with the arguments in the body of r.
+func (g *gateway) call(w http.ResponseWriter, r *http.Request, m codegen.GatewayMethod) {
+	ctx := r.Context()
+	if timeout := r.Header.Get(gatewayTimeoutHeader); timeout != "" {
+		d, err := time.ParseDuration(timeout)
+		if err != nil || d <= 0 {
+			writeGatewayError(w, http.StatusBadRequest, fmt.Errorf("bad %s header %q", gatewayTimeoutHeader, timeout))
+			return
+		}
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, d)
+		defer cancel()
+	}
+	meta := map[string]string{}
+	for key, values := range r.Header {
+		if k, ok := strings.CutPrefix(key, gatewayMetadataPrefix); ok && k != "" && len(values) > 0 {
+			meta[strings.ToLower(k)] = values[0]
+		}
+	}
+	if len(meta) > 0 {
+		ctx = metadata.NewContext(ctx, meta)
+	}
+
+	args, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxGatewayBodySize))
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.As(err, new(*http.MaxBytesError)) {
+			status = http.StatusRequestEntityTooLarge
+		}
+		writeGatewayError(w, status, err)
+		return
+	}
+	result, err := m.Call(ctx, args)
+	if err != nil {
+		writeGatewayError(w, gatewayStatus(err), err)
+		return
+	}
+	writeGatewayReply(w, http.StatusOK, result)
+}
+
+// gatewayStatus returns the HTTP status code for a failed gateway call.
+func gatewayStatus(err error) int {
+	switch {
+	case errors.As(err, &codegen.GatewayArgsError{}):
+		return http.StatusBadRequest
+	case errors.Is(err, context.DeadlineExceeded):
+		return http.StatusGatewayTimeout
+	case errors.Is(err, RemoteCallError):
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// writeGatewayReply writes the JSON encoding of v with the provided status.
+func writeGatewayReply(w http.ResponseWriter, status int, v any) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		writeGatewayError(w, http.StatusInternalServerError, fmt.Errorf("cannot encode the results: %w", err))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(data)
+}
+
+// writeGatewayError writes err as a JSON object with the provided status.
+func writeGatewayError(w http.ResponseWriter, status int, err error) {
+	data, _ := json.Marshal(struct {
+		Error string `json:"error"`
+	}{err.Error()})
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(data)
+}
@@ -0,0 +1,129 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mx
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sh3lk/mx/metadata"
+	"github.com/sh3lk/mx/runtime/codegen"
+)
+
+func TestGateway(t *testing.T) {
+	// Gateway methods, like the ones generated by "mx generate -gateway".
+	methods := []codegen.GatewayMethod{
+		{
+			Name:   "Add",
+			Args:   `{"type":"object","properties":{"x":{"type":"integer"},"y":{"type":"integer"}}}`,
+			Result: `{"type":"integer"}`,
+			Call: func(ctx context.Context, data []byte) (any, error) {
+				var args struct {
+					A0 int `json:"x"`
+					A1 int `json:"y"`
+				}
+				if err := codegen.DecodeGatewayArgs(data, &args); err != nil {
+					return nil, err
+				}
+				return args.A0 + args.A1, nil
+			},
+		},
+		{
+			Name:   "Whoami",
+			Args:   `{"type":"object","properties":{}}`,
+			Result: `{"type":"string"}`,
+			Call: func(ctx context.Context, data []byte) (any, error) {
+				meta, _ := metadata.FromContext(ctx)
+				return meta["user"], nil
+			},
+		},
+		{
+			Name:   "Wait",
+			Args:   `{"type":"object","properties":{}}`,
+			Result: `{"type":"null"}`,
+			Call: func(ctx context.Context, data []byte) (any, error) {
+				<-ctx.Done()
+				return nil, ctx.Err()
+			},
+		},
+		{
+			Name:   "Fail",
+			Args:   `{"type":"object","properties":{}}`,
+			Result: `{"type":"null"}`,
+			Call: func(ctx context.Context, data []byte) (any, error) {
+				return nil, fmt.Errorf("%w: unreachable", RemoteCallError)
+			},
+		},
+	}
+	server := httptest.NewServer(newGateway("Calc", methods))
+	defer server.Close()
+
+	for _, test := range []struct {
+		name    string
+		method  string
+		path    string
+		body    string
+		headers map[string]string
+		status  int
+		reply   string
+	}{
+		{"Call", "POST", "/Add", `{"x": 1, "y": 2}`, nil, http.StatusOK, `3`},
+		{"NoArgs", "POST", "/Add", ``, nil, http.StatusOK, `0`},
+		{"UnknownArg", "POST", "/Add", `{"z": 1}`, nil, http.StatusBadRequest, `{"error":"bad arguments: json: unknown field \"z\""}`},
+		{"BadArg", "POST", "/Add", `{"x": "1"}`, nil, http.StatusBadRequest, ``},
+		{"Metadata", "POST", "/Whoami", `{}`, map[string]string{"Mx-Metadata-User": "alice"}, http.StatusOK, `"alice"`},
+		{"Timeout", "POST", "/Wait", `{}`, map[string]string{"Mx-Timeout": "10ms"}, http.StatusGatewayTimeout, `{"error":"context deadline exceeded"}`},
+		{"BadTimeout", "POST", "/Wait", `{}`, map[string]string{"Mx-Timeout": "soon"}, http.StatusBadRequest, ``},
+		{"RemoteCallError", "POST", "/Fail", `{}`, nil, http.StatusServiceUnavailable, ``},
+		{"UnknownMethod", "POST", "/Sub", `{}`, nil, http.StatusNotFound, ``},
+		{"BadHTTPMethod", "PUT", "/Add", `{}`, nil, http.StatusMethodNotAllowed, ``},
+		{"TooLarge", "POST", "/Add", `{"x": 1, "y": 2, "z": "` + strings.Repeat("x", maxGatewayBodySize) + `"}`, nil, http.StatusRequestEntityTooLarge, ``},
+		{"Schema", "GET", "/Add", ``, nil, http.StatusOK, `{"args":{"type":"object","properties":{"x":{"type":"integer"},"y":{"type":"integer"}}},"result":{"type":"integer"}}`},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			req, err := http.NewRequestWithContext(ctx, test.method, server.URL+test.path, strings.NewReader(test.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for k, v := range test.headers {
+				req.Header.Set(k, v)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+			data, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			reply := string(data)
+			if resp.StatusCode != test.status {
+				t.Fatalf("status: got %d, want %d (reply %s)", resp.StatusCode, test.status, reply)
+			}
+			if test.reply != "" && reply != test.reply {
+				t.Fatalf("reply: got %s, want %s", reply, test.reply)
+			}
+		})
+	}
+}
@@ -0,0 +1,366 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpcbridge
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// This file converts between protobuf values and Go values, following the
+// mapping of Go types to protobuf types of "mx generate -grpc" (see
+// internal/tool/generate/grpc.go).
+
+var (
+	timeType    = reflect.TypeOf(time.Time{})
+	messageType = reflect.TypeOf((*proto.Message)(nil)).Elem()
+)
+
+// toGo returns the Go value of type t for the value v of field fd.
+func toGo(fd protoreflect.FieldDescriptor, v protoreflect.Value, t reflect.Type) (reflect.Value, error) {
+	switch {
+	case fd.IsList():
+		list := v.List()
+		var out reflect.Value
+		switch t.Kind() {
+		case reflect.Slice:
+			out = reflect.MakeSlice(t, list.Len(), list.Len())
+		case reflect.Array:
+			if list.Len() > t.Len() {
+				return reflect.Value{}, fmt.Errorf("too many elements for %v: %d", t, list.Len())
+			}
+			out = reflect.New(t).Elem()
+		default:
+			return reflect.Value{}, fmt.Errorf("repeated field for %v", t)
+		}
+		for i := 0; i < list.Len(); i++ {
+			elem, err := singularToGo(fd, list.Get(i), t.Elem())
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			out.Index(i).Set(elem)
+		}
+		return out, nil
+
+	case fd.IsMap():
+		if t.Kind() != reflect.Map {
+			return reflect.Value{}, fmt.Errorf("map field for %v", t)
+		}
+		m := v.Map()
+		out := reflect.MakeMapWithSize(t, m.Len())
+		var err error
+		m.Range(func(k protoreflect.MapKey, v protoreflect.Value) bool {
+			var key, value reflect.Value
+			if key, err = singularToGo(fd.MapKey(), k.Value(), t.Key()); err != nil {
+				return false
+			}
+			if value, err = singularToGo(fd.MapValue(), v, t.Elem()); err != nil {
+				return false
+			}
+			out.SetMapIndex(key, value)
+			return true
+		})
+		return out, err
+
+	default:
+		return singularToGo(fd, v, t)
+	}
+}
+
+// singularToGo returns the Go value of type t for the value v of a
+// non-repeated field, or an element of a repeated field, fd.
+func singularToGo(fd protoreflect.FieldDescriptor, v protoreflect.Value, t reflect.Type) (reflect.Value, error) {
+	out := reflect.New(t).Elem()
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		if t.Kind() != reflect.Bool {
+			break
+		}
+		out.SetBool(v.Bool())
+		return out, nil
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		if !out.CanInt() {
+			break
+		}
+		if out.OverflowInt(v.Int()) {
+			return reflect.Value{}, fmt.Errorf("%d overflows %v", v.Int(), t)
+		}
+		out.SetInt(v.Int())
+		return out, nil
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind, protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		if !out.CanUint() {
+			break
+		}
+		if out.OverflowUint(v.Uint()) {
+			return reflect.Value{}, fmt.Errorf("%d overflows %v", v.Uint(), t)
+		}
+		out.SetUint(v.Uint())
+		return out, nil
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		if !out.CanFloat() {
+			break
+		}
+		out.SetFloat(v.Float())
+		return out, nil
+	case protoreflect.StringKind:
+		if t.Kind() != reflect.String {
+			break
+		}
+		out.SetString(v.String())
+		return out, nil
+	case protoreflect.BytesKind:
+		if t.Elem().Kind() != reflect.Uint8 {
+			break
+		}
+		b := v.Bytes()
+		switch t.Kind() {
+		case reflect.Slice:
+			out.SetBytes(append([]byte(nil), b...))
+			return out, nil
+		case reflect.Array:
+			if len(b) != t.Len() {
+				return reflect.Value{}, fmt.Errorf("got %d bytes for %v", len(b), t)
+			}
+			reflect.Copy(out, reflect.ValueOf(b))
+			return out, nil
+		}
+	case protoreflect.MessageKind:
+		return messageToGo(v.Message(), t)
+	}
+	return reflect.Value{}, fmt.Errorf("cannot convert %v to %v", fd.Kind(), t)
+}
+
+// messageToGo returns the Go value of type t for the message m.
+func messageToGo(m protoreflect.Message, t reflect.Type) (reflect.Value, error) {
+	switch {
+	case t == timeType:
+		fields := m.Descriptor().Fields()
+		seconds, nanos := fields.ByName("seconds"), fields.ByName("nanos")
+		if seconds == nil || nanos == nil {
+			return reflect.Value{}, fmt.Errorf("cannot convert %v to %v", m.Descriptor().FullName(), t)
+		}
+		return reflect.ValueOf(time.Unix(m.Get(seconds).Int(), m.Get(nanos).Int()).UTC()), nil
+
+	case t.Implements(messageType) && t.Kind() == reflect.Pointer:
+		out := reflect.New(t.Elem())
+		msg := out.Interface().(proto.Message)
+		if msg.ProtoReflect().Descriptor().FullName() != m.Descriptor().FullName() {
+			return reflect.Value{}, fmt.Errorf("cannot convert %v to %v", m.Descriptor().FullName(), t)
+		}
+		proto.Merge(msg, m.Interface())
+		return out, nil
+
+	case t.Kind() == reflect.Pointer && t.Elem().Kind() == reflect.Struct:
+		out := reflect.New(t.Elem())
+		if err := structToGo(m, out.Elem()); err != nil {
+			return reflect.Value{}, err
+		}
+		return out, nil
+
+	case t.Kind() == reflect.Struct:
+		out := reflect.New(t).Elem()
+		if err := structToGo(m, out); err != nil {
+			return reflect.Value{}, err
+		}
+		return out, nil
+	}
+	return reflect.Value{}, fmt.Errorf("cannot convert %v to %v", m.Descriptor().FullName(), t)
+}
+
+// structToGo sets the fields of the struct v to the fields of the message m.
+func structToGo(m protoreflect.Message, v reflect.Value) error {
+	fields := m.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if !m.Has(fd) {
+			continue
+		}
+		f := v.FieldByName(string(fd.Name()))
+		if !f.IsValid() || !f.CanSet() {
+			return fmt.Errorf("%v has no field %s", v.Type(), fd.Name())
+		}
+		x, err := toGo(fd, m.Get(fd), f.Type())
+		if err != nil {
+			return fmt.Errorf("%s: %w", fd.Name(), err)
+		}
+		f.Set(x)
+	}
+	return nil
+}
+
+// fromGo sets field fd of message m to the Go value v.
+func fromGo(fd protoreflect.FieldDescriptor, v reflect.Value, m protoreflect.Message) error {
+	switch {
+	case fd.IsList():
+		if v.Len() == 0 {
+			return nil
+		}
+		list := m.Mutable(fd).List()
+		for i := 0; i < v.Len(); i++ {
+			if fd.Kind() == protoreflect.MessageKind {
+				elem := list.NewElement()
+				if err := messageFromGo(v.Index(i), elem.Message()); err != nil {
+					return err
+				}
+				list.Append(elem)
+				continue
+			}
+			elem, err := singularFromGo(fd, v.Index(i))
+			if err != nil {
+				return err
+			}
+			list.Append(elem)
+		}
+		return nil
+
+	case fd.IsMap():
+		if v.Len() == 0 {
+			return nil
+		}
+		out := m.Mutable(fd).Map()
+		iter := v.MapRange()
+		for iter.Next() {
+			key, err := singularFromGo(fd.MapKey(), iter.Key())
+			if err != nil {
+				return err
+			}
+			if fd.MapValue().Kind() == protoreflect.MessageKind {
+				if err := messageFromGo(iter.Value(), out.Mutable(key.MapKey()).Message()); err != nil {
+					return err
+				}
+				continue
+			}
+			value, err := singularFromGo(fd.MapValue(), iter.Value())
+			if err != nil {
+				return err
+			}
+			out.Set(key.MapKey(), value)
+		}
+		return nil
+
+	case fd.Kind() == protoreflect.MessageKind:
+		if v.Kind() == reflect.Pointer && v.IsNil() {
+			return nil
+		}
+		return messageFromGo(v, m.Mutable(fd).Message())
+
+	default:
+		x, err := singularFromGo(fd, v)
+		if err != nil {
+			return err
+		}
+		m.Set(fd, x)
+		return nil
+	}
+}
+
+// singularFromGo returns the value of a non-repeated, non-message field, or of
+// an element of a repeated field, fd for the Go value v.
+func singularFromGo(fd protoreflect.FieldDescriptor, v reflect.Value) (protoreflect.Value, error) {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		if v.Kind() == reflect.Bool {
+			return protoreflect.ValueOfBool(v.Bool()), nil
+		}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		if v.CanInt() {
+			return protoreflect.ValueOfInt32(int32(v.Int())), nil
+		}
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		if v.CanInt() {
+			return protoreflect.ValueOfInt64(v.Int()), nil
+		}
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		if v.CanUint() {
+			return protoreflect.ValueOfUint32(uint32(v.Uint())), nil
+		}
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		if v.CanUint() {
+			return protoreflect.ValueOfUint64(v.Uint()), nil
+		}
+	case protoreflect.FloatKind:
+		if v.CanFloat() {
+			return protoreflect.ValueOfFloat32(float32(v.Float())), nil
+		}
+	case protoreflect.DoubleKind:
+		if v.CanFloat() {
+			return protoreflect.ValueOfFloat64(v.Float()), nil
+		}
+	case protoreflect.StringKind:
+		if v.Kind() == reflect.String {
+			return protoreflect.ValueOfString(v.String()), nil
+		}
+	case protoreflect.BytesKind:
+		if (v.Kind() == reflect.Slice || v.Kind() == reflect.Array) && v.Type().Elem().Kind() == reflect.Uint8 {
+			b := make([]byte, v.Len())
+			reflect.Copy(reflect.ValueOf(b), v)
+			return protoreflect.ValueOfBytes(b), nil
+		}
+	}
+	return protoreflect.Value{}, fmt.Errorf("cannot convert %v to %v", v.Type(), fd.Kind())
+}
+
+// messageFromGo sets the fields of the empty message m to the Go value v.
+func messageFromGo(v reflect.Value, m protoreflect.Message) error {
+	switch {
+	case v.Type() == timeType:
+		fields := m.Descriptor().Fields()
+		seconds, nanos := fields.ByName("seconds"), fields.ByName("nanos")
+		if seconds == nil || nanos == nil {
+			return fmt.Errorf("cannot convert %v to %v", v.Type(), m.Descriptor().FullName())
+		}
+		t := v.Interface().(time.Time)
+		m.Set(seconds, protoreflect.ValueOfInt64(t.Unix()))
+		m.Set(nanos, protoreflect.ValueOfInt32(int32(t.Nanosecond())))
+		return nil
+
+	case v.Type().Implements(messageType):
+		if v.Kind() == reflect.Pointer && v.IsNil() {
+			return nil
+		}
+		msg := v.Interface().(proto.Message)
+		if msg.ProtoReflect().Descriptor().FullName() != m.Descriptor().FullName() {
+			return fmt.Errorf("cannot convert %v to %v", v.Type(), m.Descriptor().FullName())
+		}
+		proto.Merge(m.Interface(), msg)
+		return nil
+
+	case v.Kind() == reflect.Pointer:
+		if v.IsNil() {
+			return nil
+		}
+		return messageFromGo(v.Elem(), m)
+
+	case v.Kind() == reflect.Struct:
+		fields := m.Descriptor().Fields()
+		for i := 0; i < fields.Len(); i++ {
+			fd := fields.Get(i)
+			f := v.FieldByName(string(fd.Name()))
+			if !f.IsValid() {
+				return fmt.Errorf("%v has no field %s", v.Type(), fd.Name())
+			}
+			if err := fromGo(fd, f, m); err != nil {
+				return fmt.Errorf("%s: %w", fd.Name(), err)
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("cannot convert %v to %v", v.Type(), m.Descriptor().FullName())
+}
@@ -0,0 +1,293 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package grpcbridge serves MX components as gRPC services, so that programs
+// written in other languages can call them.
+//
+// Running "mx generate -grpc Cache,Store" generates a gRPC service for the
+// Cache and Store components of a package, defined in a mx_gen.proto file in
+// the package's directory. Clients compile mx_gen.proto with the protobuf
+// compiler, and a server registers the services with Register:
+//
+//	type app struct {
+//	    mx.Implements[mx.Main]
+//	    cache mx.Ref[Cache]
+//	    rpc   mx.Listener
+//	}
+//
+//	func serve(ctx context.Context, app *app) error {
+//	    s := grpc.NewServer()
+//	    if err := grpcbridge.Register(s, app.cache.Get()); err != nil {
+//	        return err
+//	    }
+//	    return s.Serve(app.rpc)
+//	}
+//
+// The bridge translates every gRPC call into a call to the component passed to
+// Register. Pass the component obtained from an mx.Ref, so that calls go
+// through the component's stub and are routed, traced, and measured like any
+// other component method call. The deadline of a gRPC call is the deadline of
+// the component method call, and incoming gRPC metadata with keys prefixed with
+// mx-metadata- is propagated to the component as metadata (see the metadata
+// package), with the prefix removed.
+package grpcbridge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/sh3lk/mx"
+	"github.com/sh3lk/mx/metadata"
+	"github.com/sh3lk/mx/runtime/codegen"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	grpcmetadata "google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	// Register google/protobuf/timestamp.proto, which the generated services
+	// use for time.Time values.
+	_ "google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// metadataPrefix is the prefix of the keys of the incoming gRPC metadata that
+// is propagated to components.
+const metadataPrefix = "mx-metadata-"
+
+// Register registers the gRPC service of component T, generated by "mx
+// generate -grpc", with s. Calls to the service are translated into calls to
+// the provided component.
+func Register[T any](s grpc.ServiceRegistrar, component T) error {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	for _, reg := range codegen.Registered() {
+		if reg.Iface != t {
+			continue
+		}
+		if reg.GRPCFile == nil {
+			return fmt.Errorf("grpcbridge: no gRPC service for component %s; run \"mx generate -grpc %s\"", reg.Name, t.Name())
+		}
+		desc, err := serviceDesc(reg, reflect.ValueOf(component))
+		if err != nil {
+			return fmt.Errorf("grpcbridge: component %s: %w", reg.Name, err)
+		}
+		s.RegisterService(desc, component)
+		return nil
+	}
+	return fmt.Errorf("grpcbridge: %v is not a registered component", t)
+}
+
+// serviceDesc returns the description of the gRPC service of the provided
+// component.
+func serviceDesc(reg *codegen.Registration, component reflect.Value) (*grpc.ServiceDesc, error) {
+	fdp := &descriptorpb.FileDescriptorProto{}
+	if err := proto.Unmarshal(reg.GRPCFile, fdp); err != nil {
+		return nil, err
+	}
+	file, err := protodesc.NewFile(fdp, protoregistry.GlobalFiles)
+	if err != nil {
+		return nil, err
+	}
+	service := file.Services().ByName(protoreflect.Name(reg.Iface.Name()))
+	if service == nil {
+		return nil, fmt.Errorf("service %s not found in %s", reg.Iface.Name(), file.Path())
+	}
+
+	desc := &grpc.ServiceDesc{
+		ServiceName: string(service.FullName()),
+		HandlerType: reflect.New(reg.Iface).Interface(),
+		Metadata:    file.Path(),
+	}
+	for i := 0; i < service.Methods().Len(); i++ {
+		md := service.Methods().Get(i)
+		m := &method{
+			file:     file,
+			desc:     md,
+			fn:       component.MethodByName(string(md.Name())),
+			fullName: fmt.Sprintf("/%s/%s", service.FullName(), md.Name()),
+		}
+		if err := m.check(); err != nil {
+			return nil, err
+		}
+		desc.Methods = append(desc.Methods, grpc.MethodDesc{
+			MethodName: string(md.Name()),
+			Handler:    m.handle,
+		})
+	}
+	return desc, nil
+}
+
+// method is a method of a gRPC service, bridged to a component method.
+type method struct {
+	file     protoreflect.FileDescriptor // file of the service
+	desc     protoreflect.MethodDescriptor
+	fn       reflect.Value // component method
+	fullName string        // e.g., /pkg.Service/Method
+}
+
+// wraps returns whether the provided message is the request or reply of the
+// method, generated to hold its arguments or results, as opposed to a message
+// passed to, or returned by, the method.
+func (m *method) wraps(msg protoreflect.MessageDescriptor) bool {
+	return msg.ParentFile().Path() == m.file.Path()
+}
+
+// check checks that the method matches the signature of the component method.
+func (m *method) check() error {
+	if !m.fn.IsValid() {
+		return fmt.Errorf("method %s not found; rerun \"mx generate\"", m.desc.Name())
+	}
+	t := m.fn.Type()
+	if m.wraps(m.desc.Input()) {
+		fields := m.desc.Input().Fields()
+		for i := 0; i < fields.Len(); i++ {
+			if n := int(fields.Get(i).Number()); n >= t.NumIn() {
+				return fmt.Errorf("method %s: unexpected argument %d; rerun \"mx generate\"", m.desc.Name(), n)
+			}
+		}
+	} else if t.NumIn() != 2 {
+		return fmt.Errorf("method %s: want 1 argument, got %d; rerun \"mx generate\"", m.desc.Name(), t.NumIn()-1)
+	}
+	if m.wraps(m.desc.Output()) {
+		fields := m.desc.Output().Fields()
+		for i := 0; i < fields.Len(); i++ {
+			if n := int(fields.Get(i).Number()); n >= t.NumOut() {
+				return fmt.Errorf("method %s: unexpected result %d; rerun \"mx generate\"", m.desc.Name(), n)
+			}
+		}
+	} else if t.NumOut() != 2 {
+		return fmt.Errorf("method %s: want 1 result, got %d; rerun \"mx generate\"", m.desc.Name(), t.NumOut()-1)
+	}
+	return nil
+}
+
+// handle implements the grpc.methodHandler type.
+func (m *method) handle(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+	req := newMessage(m.desc.Input())
+	if err := dec(req); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return m.call(ctx, req)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: m.fullName}
+	return interceptor(ctx, req, info, func(ctx context.Context, req any) (any, error) {
+		return m.call(ctx, req.(proto.Message))
+	})
+}
+
+// call calls the component method with the arguments in req, and returns its
+// results.
+func (m *method) call(ctx context.Context, req proto.Message) (any, error) {
+	if md, ok := grpcmetadata.FromIncomingContext(ctx); ok {
+		meta := map[string]string{}
+		for key, values := range md {
+			if k, ok := strings.CutPrefix(key, metadataPrefix); ok && k != "" && len(values) > 0 {
+				meta[k] = values[0]
+			}
+		}
+		if len(meta) > 0 {
+			ctx = metadata.NewContext(ctx, meta)
+		}
+	}
+
+	t := m.fn.Type()
+	args := make([]reflect.Value, t.NumIn())
+	args[0] = reflect.ValueOf(ctx)
+	for i := 1; i < len(args); i++ {
+		args[i] = reflect.New(t.In(i)).Elem()
+	}
+	if m.wraps(m.desc.Input()) {
+		msg := req.ProtoReflect()
+		fields := msg.Descriptor().Fields()
+		for i := 0; i < fields.Len(); i++ {
+			fd := fields.Get(i)
+			if !msg.Has(fd) {
+				continue
+			}
+			v, err := toGo(fd, msg.Get(fd), t.In(int(fd.Number())))
+			if err != nil {
+				return nil, status.Errorf(codes.InvalidArgument, "%s: %v", fd.Name(), err)
+			}
+			args[fd.Number()] = v
+		}
+	} else {
+		v, err := messageToGo(req.ProtoReflect(), t.In(1))
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		args[1] = v
+	}
+	if t.IsVariadic() {
+		results := m.fn.CallSlice(args)
+		return m.reply(results)
+	}
+	return m.reply(m.fn.Call(args))
+}
+
+// reply returns the reply with the provided results of the component method.
+func (m *method) reply(results []reflect.Value) (any, error) {
+	if err, _ := results[len(results)-1].Interface().(error); err != nil {
+		return nil, toStatus(err)
+	}
+	if !m.wraps(m.desc.Output()) {
+		if r := results[0]; r.Kind() != reflect.Pointer || !r.IsNil() {
+			return r.Interface(), nil
+		}
+		return newMessage(m.desc.Output()), nil
+	}
+	reply := dynamicpb.NewMessage(m.desc.Output())
+	fields := m.desc.Output().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		if err := fromGo(fd, results[fd.Number()-1], reply); err != nil {
+			return nil, status.Errorf(codes.Internal, "r%d: %v", fd.Number()-1, err)
+		}
+	}
+	return reply, nil
+}
+
+// newMessage returns a new, empty message of the provided type. It is a
+// message of the generated Go type, if registered, or a dynamic message.
+func newMessage(md protoreflect.MessageDescriptor) proto.Message {
+	if mt, err := protoregistry.GlobalTypes.FindMessageByName(md.FullName()); err == nil {
+		return mt.New().Interface()
+	}
+	return dynamicpb.NewMessage(md)
+}
+
+// toStatus returns the gRPC status error for an error returned by a component
+// method.
+func toStatus(err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, mx.RemoteCallError):
+		return status.Error(codes.Unavailable, err.Error())
+	default:
+		return status.Error(codes.Unknown, err.Error())
+	}
+}
@@ -0,0 +1,312 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpcbridge
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/sh3lk/mx"
+	"github.com/sh3lk/mx/metadata"
+	"github.com/sh3lk/mx/runtime/codegen"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	grpcmetadata "google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type Point struct {
+	X, Y int
+}
+
+type calc interface {
+	Add(ctx context.Context, x, y int) (int, error)
+	Sum(ctx context.Context, points []Point, scale map[string]float64) (*Point, error)
+	Later(ctx context.Context, t time.Time, d int64) (time.Time, error)
+	Echo(ctx context.Context, t *timestamppb.Timestamp) (*timestamppb.Timestamp, error)
+	Whoami(ctx context.Context) (string, error)
+	Fail(ctx context.Context) error
+}
+
+type calcImpl struct{}
+
+func (calcImpl) Add(_ context.Context, x, y int) (int, error) {
+	return x + y, nil
+}
+
+func (calcImpl) Sum(_ context.Context, points []Point, scale map[string]float64) (*Point, error) {
+	var p Point
+	for _, q := range points {
+		p.X += q.X
+		p.Y += q.Y
+	}
+	p.X *= int(scale["x"])
+	p.Y *= int(scale["y"])
+	return &p, nil
+}
+
+func (calcImpl) Later(_ context.Context, t time.Time, d int64) (time.Time, error) {
+	return t.Add(time.Duration(d)), nil
+}
+
+func (calcImpl) Echo(_ context.Context, t *timestamppb.Timestamp) (*timestamppb.Timestamp, error) {
+	return t, nil
+}
+
+func (calcImpl) Whoami(ctx context.Context) (string, error) {
+	meta, _ := metadata.FromContext(ctx)
+	return meta["user"], nil
+}
+
+func (calcImpl) Fail(context.Context) error {
+	return fmt.Errorf("%w: unreachable", mx.RemoteCallError)
+}
+
+// calcFile returns the file descriptor of the gRPC service of calc, like the
+// one generated by "mx generate -grpc calc".
+func calcFile() *descriptorpb.FileDescriptorProto {
+	field := func(name string, number int32, typ descriptorpb.FieldDescriptorProto_Type, typeName string) *descriptorpb.FieldDescriptorProto {
+		f := &descriptorpb.FieldDescriptorProto{
+			Name:     proto.String(name),
+			JsonName: proto.String(name),
+			Number:   proto.Int32(number),
+			Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+			Type:     typ.Enum(),
+		}
+		if typeName != "" {
+			f.TypeName = proto.String(typeName)
+		}
+		return f
+	}
+	repeated := func(f *descriptorpb.FieldDescriptorProto) *descriptorpb.FieldDescriptorProto {
+		f.Label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+		return f
+	}
+	message := func(name string, fields ...*descriptorpb.FieldDescriptorProto) *descriptorpb.DescriptorProto {
+		return &descriptorpb.DescriptorProto{Name: proto.String(name), Field: fields}
+	}
+	method := func(name, input, output string) *descriptorpb.MethodDescriptorProto {
+		return &descriptorpb.MethodDescriptorProto{
+			Name:       proto.String(name),
+			InputType:  proto.String(input),
+			OutputType: proto.String(output),
+		}
+	}
+	const (
+		int64Type  = descriptorpb.FieldDescriptorProto_TYPE_INT64
+		doubleType = descriptorpb.FieldDescriptorProto_TYPE_DOUBLE
+		stringType = descriptorpb.FieldDescriptorProto_TYPE_STRING
+		msgType    = descriptorpb.FieldDescriptorProto_TYPE_MESSAGE
+		timestamp  = ".google.protobuf.Timestamp"
+	)
+
+	scaleEntry := message("ScaleEntry", field("key", 1, stringType, ""), field("value", 2, doubleType, ""))
+	scaleEntry.Options = &descriptorpb.MessageOptions{MapEntry: proto.Bool(true)}
+	sumRequest := message("calcSumRequest",
+		repeated(field("points", 1, msgType, ".test.Point")),
+		repeated(field("scale", 2, msgType, ".test.calcSumRequest.ScaleEntry")))
+	sumRequest.NestedType = []*descriptorpb.DescriptorProto{scaleEntry}
+
+	return &descriptorpb.FileDescriptorProto{
+		Name:       proto.String("github.com/sh3lk/mx/grpcbridge/mx_gen.proto"),
+		Package:    proto.String("test"),
+		Syntax:     proto.String("proto3"),
+		Dependency: []string{"google/protobuf/timestamp.proto"},
+		MessageType: []*descriptorpb.DescriptorProto{
+			message("Point", field("X", 1, int64Type, ""), field("Y", 2, int64Type, "")),
+			message("calcAddRequest", field("x", 1, int64Type, ""), field("y", 2, int64Type, "")),
+			message("calcAddReply", field("r0", 1, int64Type, "")),
+			sumRequest,
+			message("calcSumReply", field("r0", 1, msgType, ".test.Point")),
+			message("calcLaterRequest", field("t", 1, msgType, timestamp), field("d", 2, int64Type, "")),
+			message("calcLaterReply", field("r0", 1, msgType, timestamp)),
+			message("calcWhoamiRequest"),
+			message("calcWhoamiReply", field("r0", 1, stringType, "")),
+			message("calcFailRequest"),
+			message("calcFailReply"),
+		},
+		Service: []*descriptorpb.ServiceDescriptorProto{{
+			Name: proto.String("calc"),
+			Method: []*descriptorpb.MethodDescriptorProto{
+				method("Add", ".test.calcAddRequest", ".test.calcAddReply"),
+				method("Sum", ".test.calcSumRequest", ".test.calcSumReply"),
+				method("Later", ".test.calcLaterRequest", ".test.calcLaterReply"),
+				method("Echo", timestamp, timestamp),
+				method("Whoami", ".test.calcWhoamiRequest", ".test.calcWhoamiReply"),
+				method("Fail", ".test.calcFailRequest", ".test.calcFailReply"),
+			},
+		}},
+	}
+}
+
+func init() {
+	raw, err := proto.Marshal(calcFile())
+	if err != nil {
+		panic(err)
+	}
+	codegen.Register(codegen.Registration{
+		Name:         "github.com/sh3lk/mx/grpcbridge/calc",
+		Iface:        reflect.TypeOf((*calc)(nil)).Elem(),
+		Impl:         reflect.TypeOf(calcImpl{}),
+		LocalStubFn:  func(impl any, caller string, tracer trace.Tracer) any { return impl },
+		ClientStubFn: func(stub codegen.Stub, caller string) any { return nil },
+		ServerStubFn: func(impl any, load func(key uint64, load float64)) codegen.Server { return nil },
+		GRPCFile:     raw,
+	})
+}
+
+func TestBridge(t *testing.T) {
+	s := grpc.NewServer()
+	if err := Register[calc](s, calcImpl{}); err != nil {
+		t.Fatal(err)
+	}
+	lis, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go s.Serve(lis)
+	defer s.Stop()
+
+	conn, err := grpc.Dial(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	file, err := protodesc.NewFile(calcFile(), protoregistry.GlobalFiles)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// call calls the named method with a request built by fill, and returns
+	// the reply.
+	call := func(ctx context.Context, name string, fill func(req protoreflect.Message)) (protoreflect.Message, error) {
+		md := file.Services().ByName("calc").Methods().ByName(protoreflect.Name(name))
+		req := dynamicpb.NewMessage(md.Input())
+		if fill != nil {
+			fill(req)
+		}
+		reply := dynamicpb.NewMessage(md.Output())
+		err := conn.Invoke(ctx, "/test.calc/"+name, req, reply)
+		return reply, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	t.Run("Add", func(t *testing.T) {
+		reply, err := call(ctx, "Add", func(req protoreflect.Message) {
+			fields := req.Descriptor().Fields()
+			req.Set(fields.ByName("x"), protoreflect.ValueOfInt64(1))
+			req.Set(fields.ByName("y"), protoreflect.ValueOfInt64(2))
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := reply.Get(reply.Descriptor().Fields().ByName("r0")).Int(), int64(3); got != want {
+			t.Fatalf("Add: got %d, want %d", got, want)
+		}
+	})
+
+	t.Run("Sum", func(t *testing.T) {
+		reply, err := call(ctx, "Sum", func(req protoreflect.Message) {
+			fields := req.Descriptor().Fields()
+			points := req.Mutable(fields.ByName("points")).List()
+			for i := 1; i <= 3; i++ {
+				p := points.NewElement()
+				pfields := p.Message().Descriptor().Fields()
+				p.Message().Set(pfields.ByName("X"), protoreflect.ValueOfInt64(int64(i)))
+				p.Message().Set(pfields.ByName("Y"), protoreflect.ValueOfInt64(int64(10*i)))
+				points.Append(p)
+			}
+			scale := req.Mutable(fields.ByName("scale")).Map()
+			scale.Set(protoreflect.ValueOfString("x").MapKey(), protoreflect.ValueOfFloat64(2))
+			scale.Set(protoreflect.ValueOfString("y").MapKey(), protoreflect.ValueOfFloat64(3))
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		p := reply.Get(reply.Descriptor().Fields().ByName("r0")).Message()
+		pfields := p.Descriptor().Fields()
+		x, y := p.Get(pfields.ByName("X")).Int(), p.Get(pfields.ByName("Y")).Int()
+		if x != 12 || y != 180 {
+			t.Fatalf("Sum: got {%d %d}, want {12 180}", x, y)
+		}
+	})
+
+	t.Run("Later", func(t *testing.T) {
+		now := time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC)
+		reply, err := call(ctx, "Later", func(req protoreflect.Message) {
+			fields := req.Descriptor().Fields()
+			req.Set(fields.ByName("t"), protoreflect.ValueOfMessage(timestamppb.New(now).ProtoReflect()))
+			req.Set(fields.ByName("d"), protoreflect.ValueOfInt64(int64(time.Hour)))
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		ts := &timestamppb.Timestamp{}
+		proto.Merge(ts, reply.Get(reply.Descriptor().Fields().ByName("r0")).Message().Interface())
+		if got, want := ts.AsTime(), now.Add(time.Hour); !got.Equal(want) {
+			t.Fatalf("Later: got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("Echo", func(t *testing.T) {
+		want := timestamppb.New(time.Unix(42, 7))
+		got := &timestamppb.Timestamp{}
+		if err := conn.Invoke(ctx, "/test.calc/Echo", want, got); err != nil {
+			t.Fatal(err)
+		}
+		if !proto.Equal(got, want) {
+			t.Fatalf("Echo: got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("Metadata", func(t *testing.T) {
+		ctx := grpcmetadata.AppendToOutgoingContext(ctx, "mx-metadata-user", "alice")
+		reply, err := call(ctx, "Whoami", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := reply.Get(reply.Descriptor().Fields().ByName("r0")).String(), "alice"; got != want {
+			t.Fatalf("Whoami: got %q, want %q", got, want)
+		}
+	})
+
+	t.Run("RemoteCallError", func(t *testing.T) {
+		_, err := call(ctx, "Fail", nil)
+		if got, want := status.Code(err), codes.Unavailable; got != want {
+			t.Fatalf("Fail: got code %v, want %v (err %v)", got, want, err)
+		}
+	})
+}
+
+func TestRegisterUnknownComponent(t *testing.T) {
+	type unknown interface{}
+	if err := Register[unknown](grpc.NewServer(), nil); err == nil {
+		t.Fatal("unexpected success")
+	}
+}
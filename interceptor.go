@@ -0,0 +1,59 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mx
+
+import "github.com/sh3lk/mx/runtime/codegen"
+
+// MethodCall describes a component method call observed by an Interceptor. It
+// includes the component and method names, the decoded method arguments and,
+// once the call has been invoked, the method results.
+type MethodCall = codegen.MethodCall
+
+// Interceptor intercepts component method calls. An interceptor receives the
+// context and description of a call, along with an invoke function that
+// continues the call. For example, the following interceptor logs every call:
+//
+//	func logCalls(ctx context.Context, call *mx.MethodCall, invoke func(context.Context) error) error {
+//	    err := invoke(ctx)
+//	    slog.Info("call", "component", call.Component, "method", call.Method, "err", err)
+//	    return err
+//	}
+//
+// An interceptor may reject a call by returning an error without calling
+// invoke, pass a derived context to invoke, or inspect and replace
+// call.Args before invoke and call.Results after it.
+type Interceptor = codegen.Interceptor
+
+// InterceptClient registers an interceptor that runs in the caller of every
+// component method call, whether the callee is local or remote. Interceptors
+// run in registration order, the first registered being the outermost.
+//
+// InterceptClient must be called before Run, typically in an init function.
+// Interceptors registered later may not apply to component handles that have
+// already been created.
+func InterceptClient(i Interceptor) {
+	codegen.RegisterClientInterceptor(i)
+}
+
+// InterceptServer registers an interceptor that runs in the callee of every
+// component method call, whether the caller is local or remote. Interceptors
+// run in registration order, the first registered being the outermost.
+//
+// InterceptServer must be called before Run, typically in an init function.
+// Interceptors registered later may not apply to components that have
+// already been created.
+func InterceptServer(i Interceptor) {
+	codegen.RegisterServerInterceptor(i)
+}
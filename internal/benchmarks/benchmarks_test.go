@@ -48,15 +48,19 @@ type codec interface {
 // mxCodec uses MX's serialization and deserialization code.
 type mxCodec struct {
 	presizeBuffer bool             // presize the buffer? (MX does this)
+	pooled        bool             // use pooled encoders and zero-copy decoding?
 	enc           *codegen.Encoder // reusable encoder, if not nil
+	last          *codegen.Encoder // pooled encoder of the last serialization
 }
 
 // newMXCodec returns a new mxCodec. If presizeBuffer is true, the
 // serialization buffer is presized to fit the serialization (MX does this
 // by default). If reuseBuffer is true, then the encoder reuses the same buffer
-// for every serialization.
-func newMXCodec(presizeBuffer, reuseBuffer bool) *mxCodec {
-	d := mxCodec{presizeBuffer: presizeBuffer}
+// for every serialization. If pooled is true, the encoder is taken from the
+// encoder pool and values are decoded without copying, like MX client stubs
+// do.
+func newMXCodec(presizeBuffer, reuseBuffer, pooled bool) *mxCodec {
+	d := mxCodec{presizeBuffer: presizeBuffer, pooled: pooled}
 	if reuseBuffer {
 		d.enc = codegen.NewEncoder()
 	}
@@ -71,11 +75,25 @@ func (d *mxCodec) Encode(p *payloadC) []byte {
 		return d.enc.Data()
 	}
 
+	if d.pooled {
+		// Return the previous encoder to the pool, since its serialization
+		// is no longer used.
+		if d.last != nil {
+			codegen.PutEncoder(d.last)
+		}
+		d.last = codegen.GetEncoder()
+		if d.presizeBuffer {
+			d.last.Reset(mx_size_payloadC_2cf90129(p))
+		}
+		p.MXMarshal(d.last)
+		return d.last.Data()
+	}
+
 	// Use a new encoder (which internally allocates a new serialization
 	// buffer).
 	enc := codegen.NewEncoder()
 	if d.presizeBuffer {
-		enc.Reset(mx_size_payloadC_7e82696e(p))
+		enc.Reset(mx_size_payloadC_2cf90129(p))
 	}
 	p.MXMarshal(enc)
 	return enc.Data()
@@ -84,6 +102,9 @@ func (d *mxCodec) Encode(p *payloadC) []byte {
 func (d *mxCodec) Decode(b []byte) *payloadC {
 	var p payloadC
 	dec := codegen.NewDecoder(b)
+	if d.pooled {
+		dec = codegen.NewZeroCopyDecoder(b)
+	}
 	p.MXUnmarshal(dec)
 	return &p
 }
@@ -264,9 +285,10 @@ func BenchmarkEncDec(b *testing.B) {
 		name  string
 		codec codec
 	}{
-		{"MX", newMXCodec(true, false)},
-		{"MXNoPresize", newMXCodec(false, false)},
-		{"MXReuseBuffer", newMXCodec(true, true)},
+		{"MX", newMXCodec(true, false, false)},
+		{"MXNoPresize", newMXCodec(false, false, false)},
+		{"MXReuseBuffer", newMXCodec(true, true, false)},
+		{"MXPooled", newMXCodec(true, false, true)},
 		{"Proto", protoCodec{}},
 		{"FreshGob", freshGobCodec{}},
 		{"Gob", newGobCodec(false)},
@@ -284,8 +306,9 @@ func BenchmarkEncDec(b *testing.B) {
 			}
 		})
 
-		// Decode only.
-		encoded := bench.codec.Encode(payloads[0])
+		// Decode only. Codecs that reuse their buffers overwrite the
+		// serialization on the next Encode, so we decode a copy.
+		encoded := bytes.Clone(bench.codec.Encode(payloads[0]))
 		b.Run(fmt.Sprintf("%s/Decode", bench.name), func(b *testing.B) {
 			b.SetBytes(averageSize(bench.codec))
 			b.ReportAllocs()
@@ -309,7 +332,8 @@ func BenchmarkEncDec(b *testing.B) {
 }
 
 // BenchmarkPing tests the performance of sending a payload of a given size
-// through an N-deep chain of components.
+// through an N-deep chain of components, both in a single process and over
+// RPCs between processes.
 //
 // TODO(rgrandl): we need to kill the corresponding processes once the benchmark
 // finishes.
@@ -338,25 +362,27 @@ func BenchmarkPing(b *testing.B) {
 		}
 		name := fmt.Sprintf("chain=%02d,size=%s", bm.numChainedComponents, size)
 		b.Run(name, func(b *testing.B) {
-			mxtest.Local.Bench(b, func(b *testing.B, pObj Ping1) {
-				if bm.componentSize == complex {
-					payload := genWorkload(1)[0]
-					for i := 0; i < b.N; i++ {
-						_, err := pObj.PingC(ctx, *payload, bm.numChainedComponents)
-						if err != nil {
-							b.Fatal(err)
+			for _, runner := range []mxtest.Runner{mxtest.Local, mxtest.Multi} {
+				runner.Bench(b, func(b *testing.B, pObj Ping1) {
+					if bm.componentSize == complex {
+						payload := genWorkload(1)[0]
+						for i := 0; i < b.N; i++ {
+							_, err := pObj.PingC(ctx, *payload, bm.numChainedComponents)
+							if err != nil {
+								b.Fatal(err)
+							}
 						}
-					}
-				} else {
-					payload := buildPayloadS(bm.componentSize)
-					for i := 0; i < b.N; i++ {
-						_, err := pObj.PingS(ctx, payload, bm.numChainedComponents)
-						if err != nil {
-							b.Fatal(err)
+					} else {
+						payload := buildPayloadS(bm.componentSize)
+						for i := 0; i < b.N; i++ {
+							_, err := pObj.PingS(ctx, payload, bm.numChainedComponents)
+							if err != nil {
+								b.Fatal(err)
+							}
 						}
 					}
-				}
-			})
+				})
+			}
 		})
 	}
 }
@@ -31,7 +31,7 @@ func init() {
 		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
 			return ping1_reflect_stub{caller: caller}
 		},
-		RefData: "⟦544443c5:MxEdge:github.com/sh3lk/mx/internal/benchmarks/Ping1→github.com/sh3lk/mx/internal/benchmarks/Ping2⟧\n",
+		RefData: "⟦18b96545:MxEdge:github.com/sh3lk/mx/internal/benchmarks/Ping1→github.com/sh3lk/mx/internal/benchmarks/Ping2⟧\n⟦4497aed3:MxSchema:github.com/sh3lk/mx/internal/benchmarks/Ping1→[{\"name\":\"PingC\",\"args\":[{\"kind\":\"struct\",\"name\":\"benchmarks.payloadC\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"float64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"string\"}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"D\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X1\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X2\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X3\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X4\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X5\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"int64\"}}]}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}}]}},{\"name\":\"B\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}}]}}]}},{\"name\":\"B\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"int64\"}}}]}},{\"name\":\"E\",\"type\":{\"kind\":\"string\"}},{\"name\":\"F\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"G\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X6\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"bool\"}}}]}},{\"name\":\"H\",\"type\":{\"kind\":\"string\"}},{\"name\":\"I\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"J\",\"type\":{\"kind\":\"float32\"}},{\"name\":\"K\",\"type\":{\"kind\":\"string\"}}]},{\"kind\":\"int64\"}],\"results\":[{\"kind\":\"struct\",\"name\":\"benchmarks.payloadC\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"float64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"string\"}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"D\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X1\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X2\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X3\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X4\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X5\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"int64\"}}]}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}}]}},{\"name\":\"B\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}}]}}]}},{\"name\":\"B\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"int64\"}}}]}},{\"name\":\"E\",\"type\":{\"kind\":\"string\"}},{\"name\":\"F\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"G\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X6\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"bool\"}}}]}},{\"name\":\"H\",\"type\":{\"kind\":\"string\"}},{\"name\":\"I\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"J\",\"type\":{\"kind\":\"float32\"}},{\"name\":\"K\",\"type\":{\"kind\":\"string\"}}]}]},{\"name\":\"PingS\",\"args\":[{\"kind\":\"struct\",\"name\":\"benchmarks.payloadS\",\"fields\":[{\"name\":\"Values\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"string\"}}}]},{\"kind\":\"int64\"}],\"results\":[{\"kind\":\"struct\",\"name\":\"benchmarks.payloadS\",\"fields\":[{\"name\":\"Values\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"string\"}}}]}]}]⟧\n",
 	})
 	codegen.Register(codegen.Registration{
 		Name:  "github.com/sh3lk/mx/internal/benchmarks/Ping10",
@@ -49,7 +49,7 @@ func init() {
 		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
 			return ping10_reflect_stub{caller: caller}
 		},
-		RefData: "",
+		RefData: "⟦18c6ae85:MxSchema:github.com/sh3lk/mx/internal/benchmarks/Ping10→[{\"name\":\"PingC\",\"args\":[{\"kind\":\"struct\",\"name\":\"benchmarks.payloadC\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"float64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"string\"}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"D\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X1\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X2\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X3\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X4\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X5\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"int64\"}}]}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}}]}},{\"name\":\"B\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}}]}}]}},{\"name\":\"B\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"int64\"}}}]}},{\"name\":\"E\",\"type\":{\"kind\":\"string\"}},{\"name\":\"F\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"G\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X6\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"bool\"}}}]}},{\"name\":\"H\",\"type\":{\"kind\":\"string\"}},{\"name\":\"I\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"J\",\"type\":{\"kind\":\"float32\"}},{\"name\":\"K\",\"type\":{\"kind\":\"string\"}}]},{\"kind\":\"int64\"}],\"results\":[{\"kind\":\"struct\",\"name\":\"benchmarks.payloadC\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"float64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"string\"}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"D\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X1\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X2\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X3\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X4\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X5\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"int64\"}}]}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}}]}},{\"name\":\"B\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}}]}}]}},{\"name\":\"B\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"int64\"}}}]}},{\"name\":\"E\",\"type\":{\"kind\":\"string\"}},{\"name\":\"F\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"G\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X6\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"bool\"}}}]}},{\"name\":\"H\",\"type\":{\"kind\":\"string\"}},{\"name\":\"I\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"J\",\"type\":{\"kind\":\"float32\"}},{\"name\":\"K\",\"type\":{\"kind\":\"string\"}}]}]},{\"name\":\"PingS\",\"args\":[{\"kind\":\"struct\",\"name\":\"benchmarks.payloadS\",\"fields\":[{\"name\":\"Values\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"string\"}}}]},{\"kind\":\"int64\"}],\"results\":[{\"kind\":\"struct\",\"name\":\"benchmarks.payloadS\",\"fields\":[{\"name\":\"Values\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"string\"}}}]}]}]⟧\n",
 	})
 	codegen.Register(codegen.Registration{
 		Name:  "github.com/sh3lk/mx/internal/benchmarks/Ping2",
@@ -67,7 +67,7 @@ func init() {
 		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
 			return ping2_reflect_stub{caller: caller}
 		},
-		RefData: "⟦b42b173c:MxEdge:github.com/sh3lk/mx/internal/benchmarks/Ping2→github.com/sh3lk/mx/internal/benchmarks/Ping3⟧\n",
+		RefData: "⟦e248f967:MxEdge:github.com/sh3lk/mx/internal/benchmarks/Ping2→github.com/sh3lk/mx/internal/benchmarks/Ping3⟧\n⟦c2f78fc7:MxSchema:github.com/sh3lk/mx/internal/benchmarks/Ping2→[{\"name\":\"PingC\",\"args\":[{\"kind\":\"struct\",\"name\":\"benchmarks.payloadC\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"float64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"string\"}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"D\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X1\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X2\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X3\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X4\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X5\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"int64\"}}]}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}}]}},{\"name\":\"B\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}}]}}]}},{\"name\":\"B\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"int64\"}}}]}},{\"name\":\"E\",\"type\":{\"kind\":\"string\"}},{\"name\":\"F\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"G\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X6\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"bool\"}}}]}},{\"name\":\"H\",\"type\":{\"kind\":\"string\"}},{\"name\":\"I\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"J\",\"type\":{\"kind\":\"float32\"}},{\"name\":\"K\",\"type\":{\"kind\":\"string\"}}]},{\"kind\":\"int64\"}],\"results\":[{\"kind\":\"struct\",\"name\":\"benchmarks.payloadC\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"float64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"string\"}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"D\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X1\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X2\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X3\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X4\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X5\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"int64\"}}]}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}}]}},{\"name\":\"B\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}}]}}]}},{\"name\":\"B\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"int64\"}}}]}},{\"name\":\"E\",\"type\":{\"kind\":\"string\"}},{\"name\":\"F\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"G\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X6\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"bool\"}}}]}},{\"name\":\"H\",\"type\":{\"kind\":\"string\"}},{\"name\":\"I\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"J\",\"type\":{\"kind\":\"float32\"}},{\"name\":\"K\",\"type\":{\"kind\":\"string\"}}]}]},{\"name\":\"PingS\",\"args\":[{\"kind\":\"struct\",\"name\":\"benchmarks.payloadS\",\"fields\":[{\"name\":\"Values\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"string\"}}}]},{\"kind\":\"int64\"}],\"results\":[{\"kind\":\"struct\",\"name\":\"benchmarks.payloadS\",\"fields\":[{\"name\":\"Values\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"string\"}}}]}]}]⟧\n",
 	})
 	codegen.Register(codegen.Registration{
 		Name:  "github.com/sh3lk/mx/internal/benchmarks/Ping3",
@@ -85,7 +85,7 @@ func init() {
 		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
 			return ping3_reflect_stub{caller: caller}
 		},
-		RefData: "⟦8c498b47:MxEdge:github.com/sh3lk/mx/internal/benchmarks/Ping3→github.com/sh3lk/mx/internal/benchmarks/Ping4⟧\n",
+		RefData: "⟦e4e669dc:MxEdge:github.com/sh3lk/mx/internal/benchmarks/Ping3→github.com/sh3lk/mx/internal/benchmarks/Ping4⟧\n⟦55a8ac43:MxSchema:github.com/sh3lk/mx/internal/benchmarks/Ping3→[{\"name\":\"PingC\",\"args\":[{\"kind\":\"struct\",\"name\":\"benchmarks.payloadC\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"float64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"string\"}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"D\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X1\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X2\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X3\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X4\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X5\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"int64\"}}]}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}}]}},{\"name\":\"B\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}}]}}]}},{\"name\":\"B\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"int64\"}}}]}},{\"name\":\"E\",\"type\":{\"kind\":\"string\"}},{\"name\":\"F\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"G\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X6\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"bool\"}}}]}},{\"name\":\"H\",\"type\":{\"kind\":\"string\"}},{\"name\":\"I\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"J\",\"type\":{\"kind\":\"float32\"}},{\"name\":\"K\",\"type\":{\"kind\":\"string\"}}]},{\"kind\":\"int64\"}],\"results\":[{\"kind\":\"struct\",\"name\":\"benchmarks.payloadC\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"float64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"string\"}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"D\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X1\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X2\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X3\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X4\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X5\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"int64\"}}]}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}}]}},{\"name\":\"B\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}}]}}]}},{\"name\":\"B\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"int64\"}}}]}},{\"name\":\"E\",\"type\":{\"kind\":\"string\"}},{\"name\":\"F\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"G\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X6\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"bool\"}}}]}},{\"name\":\"H\",\"type\":{\"kind\":\"string\"}},{\"name\":\"I\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"J\",\"type\":{\"kind\":\"float32\"}},{\"name\":\"K\",\"type\":{\"kind\":\"string\"}}]}]},{\"name\":\"PingS\",\"args\":[{\"kind\":\"struct\",\"name\":\"benchmarks.payloadS\",\"fields\":[{\"name\":\"Values\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"string\"}}}]},{\"kind\":\"int64\"}],\"results\":[{\"kind\":\"struct\",\"name\":\"benchmarks.payloadS\",\"fields\":[{\"name\":\"Values\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"string\"}}}]}]}]⟧\n",
 	})
 	codegen.Register(codegen.Registration{
 		Name:  "github.com/sh3lk/mx/internal/benchmarks/Ping4",
@@ -103,7 +103,7 @@ func init() {
 		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
 			return ping4_reflect_stub{caller: caller}
 		},
-		RefData: "⟦90669915:MxEdge:github.com/sh3lk/mx/internal/benchmarks/Ping4→github.com/sh3lk/mx/internal/benchmarks/Ping5⟧\n",
+		RefData: "⟦5af6f46f:MxEdge:github.com/sh3lk/mx/internal/benchmarks/Ping4→github.com/sh3lk/mx/internal/benchmarks/Ping5⟧\n⟦cc524425:MxSchema:github.com/sh3lk/mx/internal/benchmarks/Ping4→[{\"name\":\"PingC\",\"args\":[{\"kind\":\"struct\",\"name\":\"benchmarks.payloadC\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"float64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"string\"}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"D\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X1\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X2\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X3\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X4\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X5\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"int64\"}}]}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}}]}},{\"name\":\"B\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}}]}}]}},{\"name\":\"B\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"int64\"}}}]}},{\"name\":\"E\",\"type\":{\"kind\":\"string\"}},{\"name\":\"F\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"G\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X6\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"bool\"}}}]}},{\"name\":\"H\",\"type\":{\"kind\":\"string\"}},{\"name\":\"I\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"J\",\"type\":{\"kind\":\"float32\"}},{\"name\":\"K\",\"type\":{\"kind\":\"string\"}}]},{\"kind\":\"int64\"}],\"results\":[{\"kind\":\"struct\",\"name\":\"benchmarks.payloadC\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"float64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"string\"}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"D\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X1\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X2\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X3\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X4\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X5\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"int64\"}}]}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}}]}},{\"name\":\"B\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}}]}}]}},{\"name\":\"B\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"int64\"}}}]}},{\"name\":\"E\",\"type\":{\"kind\":\"string\"}},{\"name\":\"F\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"G\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X6\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"bool\"}}}]}},{\"name\":\"H\",\"type\":{\"kind\":\"string\"}},{\"name\":\"I\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"J\",\"type\":{\"kind\":\"float32\"}},{\"name\":\"K\",\"type\":{\"kind\":\"string\"}}]}]},{\"name\":\"PingS\",\"args\":[{\"kind\":\"struct\",\"name\":\"benchmarks.payloadS\",\"fields\":[{\"name\":\"Values\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"string\"}}}]},{\"kind\":\"int64\"}],\"results\":[{\"kind\":\"struct\",\"name\":\"benchmarks.payloadS\",\"fields\":[{\"name\":\"Values\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"string\"}}}]}]}]⟧\n",
 	})
 	codegen.Register(codegen.Registration{
 		Name:  "github.com/sh3lk/mx/internal/benchmarks/Ping5",
@@ -121,7 +121,7 @@ func init() {
 		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
 			return ping5_reflect_stub{caller: caller}
 		},
-		RefData: "⟦a38d1914:MxEdge:github.com/sh3lk/mx/internal/benchmarks/Ping5→github.com/sh3lk/mx/internal/benchmarks/Ping6⟧\n",
+		RefData: "⟦21c7d926:MxEdge:github.com/sh3lk/mx/internal/benchmarks/Ping5→github.com/sh3lk/mx/internal/benchmarks/Ping6⟧\n⟦7a784014:MxSchema:github.com/sh3lk/mx/internal/benchmarks/Ping5→[{\"name\":\"PingC\",\"args\":[{\"kind\":\"struct\",\"name\":\"benchmarks.payloadC\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"float64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"string\"}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"D\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X1\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X2\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X3\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X4\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X5\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"int64\"}}]}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}}]}},{\"name\":\"B\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}}]}}]}},{\"name\":\"B\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"int64\"}}}]}},{\"name\":\"E\",\"type\":{\"kind\":\"string\"}},{\"name\":\"F\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"G\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X6\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"bool\"}}}]}},{\"name\":\"H\",\"type\":{\"kind\":\"string\"}},{\"name\":\"I\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"J\",\"type\":{\"kind\":\"float32\"}},{\"name\":\"K\",\"type\":{\"kind\":\"string\"}}]},{\"kind\":\"int64\"}],\"results\":[{\"kind\":\"struct\",\"name\":\"benchmarks.payloadC\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"float64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"string\"}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"D\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X1\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X2\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X3\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X4\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X5\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"int64\"}}]}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}}]}},{\"name\":\"B\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}}]}}]}},{\"name\":\"B\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"int64\"}}}]}},{\"name\":\"E\",\"type\":{\"kind\":\"string\"}},{\"name\":\"F\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"G\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X6\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"bool\"}}}]}},{\"name\":\"H\",\"type\":{\"kind\":\"string\"}},{\"name\":\"I\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"J\",\"type\":{\"kind\":\"float32\"}},{\"name\":\"K\",\"type\":{\"kind\":\"string\"}}]}]},{\"name\":\"PingS\",\"args\":[{\"kind\":\"struct\",\"name\":\"benchmarks.payloadS\",\"fields\":[{\"name\":\"Values\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"string\"}}}]},{\"kind\":\"int64\"}],\"results\":[{\"kind\":\"struct\",\"name\":\"benchmarks.payloadS\",\"fields\":[{\"name\":\"Values\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"string\"}}}]}]}]⟧\n",
 	})
 	codegen.Register(codegen.Registration{
 		Name:  "github.com/sh3lk/mx/internal/benchmarks/Ping6",
@@ -139,7 +139,7 @@ func init() {
 		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
 			return ping6_reflect_stub{caller: caller}
 		},
-		RefData: "⟦ebf8b6d3:MxEdge:github.com/sh3lk/mx/internal/benchmarks/Ping6→github.com/sh3lk/mx/internal/benchmarks/Ping7⟧\n",
+		RefData: "⟦00ba8f0e:MxEdge:github.com/sh3lk/mx/internal/benchmarks/Ping6→github.com/sh3lk/mx/internal/benchmarks/Ping7⟧\n⟦ada74e10:MxSchema:github.com/sh3lk/mx/internal/benchmarks/Ping6→[{\"name\":\"PingC\",\"args\":[{\"kind\":\"struct\",\"name\":\"benchmarks.payloadC\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"float64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"string\"}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"D\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X1\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X2\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X3\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X4\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X5\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"int64\"}}]}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}}]}},{\"name\":\"B\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}}]}}]}},{\"name\":\"B\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"int64\"}}}]}},{\"name\":\"E\",\"type\":{\"kind\":\"string\"}},{\"name\":\"F\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"G\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X6\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"bool\"}}}]}},{\"name\":\"H\",\"type\":{\"kind\":\"string\"}},{\"name\":\"I\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"J\",\"type\":{\"kind\":\"float32\"}},{\"name\":\"K\",\"type\":{\"kind\":\"string\"}}]},{\"kind\":\"int64\"}],\"results\":[{\"kind\":\"struct\",\"name\":\"benchmarks.payloadC\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"float64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"string\"}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"D\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X1\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X2\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X3\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X4\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X5\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"int64\"}}]}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}}]}},{\"name\":\"B\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}}]}}]}},{\"name\":\"B\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"int64\"}}}]}},{\"name\":\"E\",\"type\":{\"kind\":\"string\"}},{\"name\":\"F\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"G\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X6\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"bool\"}}}]}},{\"name\":\"H\",\"type\":{\"kind\":\"string\"}},{\"name\":\"I\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"J\",\"type\":{\"kind\":\"float32\"}},{\"name\":\"K\",\"type\":{\"kind\":\"string\"}}]}]},{\"name\":\"PingS\",\"args\":[{\"kind\":\"struct\",\"name\":\"benchmarks.payloadS\",\"fields\":[{\"name\":\"Values\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"string\"}}}]},{\"kind\":\"int64\"}],\"results\":[{\"kind\":\"struct\",\"name\":\"benchmarks.payloadS\",\"fields\":[{\"name\":\"Values\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"string\"}}}]}]}]⟧\n",
 	})
 	codegen.Register(codegen.Registration{
 		Name:  "github.com/sh3lk/mx/internal/benchmarks/Ping7",
@@ -157,7 +157,7 @@ func init() {
 		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
 			return ping7_reflect_stub{caller: caller}
 		},
-		RefData: "⟦88d68418:MxEdge:github.com/sh3lk/mx/internal/benchmarks/Ping7→github.com/sh3lk/mx/internal/benchmarks/Ping8⟧\n",
+		RefData: "⟦bd82ee4f:MxEdge:github.com/sh3lk/mx/internal/benchmarks/Ping7→github.com/sh3lk/mx/internal/benchmarks/Ping8⟧\n⟦cae662b8:MxSchema:github.com/sh3lk/mx/internal/benchmarks/Ping7→[{\"name\":\"PingC\",\"args\":[{\"kind\":\"struct\",\"name\":\"benchmarks.payloadC\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"float64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"string\"}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"D\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X1\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X2\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X3\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X4\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X5\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"int64\"}}]}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}}]}},{\"name\":\"B\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}}]}}]}},{\"name\":\"B\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"int64\"}}}]}},{\"name\":\"E\",\"type\":{\"kind\":\"string\"}},{\"name\":\"F\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"G\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X6\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"bool\"}}}]}},{\"name\":\"H\",\"type\":{\"kind\":\"string\"}},{\"name\":\"I\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"J\",\"type\":{\"kind\":\"float32\"}},{\"name\":\"K\",\"type\":{\"kind\":\"string\"}}]},{\"kind\":\"int64\"}],\"results\":[{\"kind\":\"struct\",\"name\":\"benchmarks.payloadC\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"float64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"string\"}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"D\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X1\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X2\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X3\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X4\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X5\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"int64\"}}]}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}}]}},{\"name\":\"B\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}}]}}]}},{\"name\":\"B\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"int64\"}}}]}},{\"name\":\"E\",\"type\":{\"kind\":\"string\"}},{\"name\":\"F\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"G\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X6\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"bool\"}}}]}},{\"name\":\"H\",\"type\":{\"kind\":\"string\"}},{\"name\":\"I\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"J\",\"type\":{\"kind\":\"float32\"}},{\"name\":\"K\",\"type\":{\"kind\":\"string\"}}]}]},{\"name\":\"PingS\",\"args\":[{\"kind\":\"struct\",\"name\":\"benchmarks.payloadS\",\"fields\":[{\"name\":\"Values\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"string\"}}}]},{\"kind\":\"int64\"}],\"results\":[{\"kind\":\"struct\",\"name\":\"benchmarks.payloadS\",\"fields\":[{\"name\":\"Values\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"string\"}}}]}]}]⟧\n",
 	})
 	codegen.Register(codegen.Registration{
 		Name:  "github.com/sh3lk/mx/internal/benchmarks/Ping8",
@@ -175,7 +175,7 @@ func init() {
 		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
 			return ping8_reflect_stub{caller: caller}
 		},
-		RefData: "⟦ed98271d:MxEdge:github.com/sh3lk/mx/internal/benchmarks/Ping8→github.com/sh3lk/mx/internal/benchmarks/Ping9⟧\n",
+		RefData: "⟦d491dd1f:MxEdge:github.com/sh3lk/mx/internal/benchmarks/Ping8→github.com/sh3lk/mx/internal/benchmarks/Ping9⟧\n⟦8447e2d1:MxSchema:github.com/sh3lk/mx/internal/benchmarks/Ping8→[{\"name\":\"PingC\",\"args\":[{\"kind\":\"struct\",\"name\":\"benchmarks.payloadC\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"float64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"string\"}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"D\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X1\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X2\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X3\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X4\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X5\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"int64\"}}]}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}}]}},{\"name\":\"B\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}}]}}]}},{\"name\":\"B\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"int64\"}}}]}},{\"name\":\"E\",\"type\":{\"kind\":\"string\"}},{\"name\":\"F\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"G\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X6\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"bool\"}}}]}},{\"name\":\"H\",\"type\":{\"kind\":\"string\"}},{\"name\":\"I\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"J\",\"type\":{\"kind\":\"float32\"}},{\"name\":\"K\",\"type\":{\"kind\":\"string\"}}]},{\"kind\":\"int64\"}],\"results\":[{\"kind\":\"struct\",\"name\":\"benchmarks.payloadC\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"float64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"string\"}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"D\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X1\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X2\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X3\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X4\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X5\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"int64\"}}]}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}}]}},{\"name\":\"B\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}}]}}]}},{\"name\":\"B\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"int64\"}}}]}},{\"name\":\"E\",\"type\":{\"kind\":\"string\"}},{\"name\":\"F\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"G\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X6\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"bool\"}}}]}},{\"name\":\"H\",\"type\":{\"kind\":\"string\"}},{\"name\":\"I\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"J\",\"type\":{\"kind\":\"float32\"}},{\"name\":\"K\",\"type\":{\"kind\":\"string\"}}]}]},{\"name\":\"PingS\",\"args\":[{\"kind\":\"struct\",\"name\":\"benchmarks.payloadS\",\"fields\":[{\"name\":\"Values\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"string\"}}}]},{\"kind\":\"int64\"}],\"results\":[{\"kind\":\"struct\",\"name\":\"benchmarks.payloadS\",\"fields\":[{\"name\":\"Values\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"string\"}}}]}]}]⟧\n",
 	})
 	codegen.Register(codegen.Registration{
 		Name:  "github.com/sh3lk/mx/internal/benchmarks/Ping9",
@@ -193,7 +193,7 @@ func init() {
 		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
 			return ping9_reflect_stub{caller: caller}
 		},
-		RefData: "⟦5ceb96a7:MxEdge:github.com/sh3lk/mx/internal/benchmarks/Ping9→github.com/sh3lk/mx/internal/benchmarks/Ping10⟧\n",
+		RefData: "⟦3fbd1608:MxEdge:github.com/sh3lk/mx/internal/benchmarks/Ping9→github.com/sh3lk/mx/internal/benchmarks/Ping10⟧\n⟦a9f5aaf6:MxSchema:github.com/sh3lk/mx/internal/benchmarks/Ping9→[{\"name\":\"PingC\",\"args\":[{\"kind\":\"struct\",\"name\":\"benchmarks.payloadC\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"float64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"string\"}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"D\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X1\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X2\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X3\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X4\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X5\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"int64\"}}]}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}}]}},{\"name\":\"B\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}}]}}]}},{\"name\":\"B\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"int64\"}}}]}},{\"name\":\"E\",\"type\":{\"kind\":\"string\"}},{\"name\":\"F\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"G\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X6\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"bool\"}}}]}},{\"name\":\"H\",\"type\":{\"kind\":\"string\"}},{\"name\":\"I\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"J\",\"type\":{\"kind\":\"float32\"}},{\"name\":\"K\",\"type\":{\"kind\":\"string\"}}]},{\"kind\":\"int64\"}],\"results\":[{\"kind\":\"struct\",\"name\":\"benchmarks.payloadC\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"float64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"string\"}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"D\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X1\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X2\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X3\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X4\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X5\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"B\",\"type\":{\"kind\":\"int64\"}}]}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}}]}},{\"name\":\"B\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"C\",\"type\":{\"kind\":\"int64\"}}]}}]}},{\"name\":\"B\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"int64\"}}}]}},{\"name\":\"E\",\"type\":{\"kind\":\"string\"}},{\"name\":\"F\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"G\",\"type\":{\"kind\":\"struct\",\"name\":\"benchmarks.X6\",\"fields\":[{\"name\":\"A\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"bool\"}}}]}},{\"name\":\"H\",\"type\":{\"kind\":\"string\"}},{\"name\":\"I\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"J\",\"type\":{\"kind\":\"float32\"}},{\"name\":\"K\",\"type\":{\"kind\":\"string\"}}]}]},{\"name\":\"PingS\",\"args\":[{\"kind\":\"struct\",\"name\":\"benchmarks.payloadS\",\"fields\":[{\"name\":\"Values\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"string\"}}}]},{\"kind\":\"int64\"}],\"results\":[{\"kind\":\"struct\",\"name\":\"benchmarks.payloadS\",\"fields\":[{\"name\":\"Values\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"string\"}}}]}]}]⟧\n",
 	})
 }
 
@@ -765,9 +765,10 @@ func (s ping1_client_stub) PingC(ctx context.Context, a0 payloadC, a1 int) (r0 p
 
 	// Preallocate a buffer of the right size.
 	size := 0
-	size += mx_size_payloadC_7e82696e(&a0)
+	size += mx_size_payloadC_2cf90129(&a0)
 	size += 8
-	enc := codegen.NewEncoder()
+	enc := codegen.GetEncoder()
+	defer codegen.PutEncoder(enc)
 	enc.Reset(size)
 
 	// Encode arguments.
@@ -786,7 +787,7 @@ func (s ping1_client_stub) PingC(ctx context.Context, a0 payloadC, a1 int) (r0 p
 	}
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewZeroCopyDecoder(results)
 	(&r0).MXUnmarshal(dec)
 	err = dec.Error()
 	return
@@ -822,7 +823,8 @@ func (s ping1_client_stub) PingS(ctx context.Context, a0 payloadS, a1 int) (r0 p
 	}()
 
 	// Encode arguments.
-	enc := codegen.NewEncoder()
+	enc := codegen.GetEncoder()
+	defer codegen.PutEncoder(enc)
 	(a0).MXMarshal(enc)
 	enc.Int(a1)
 	var shardKey uint64
@@ -838,7 +840,7 @@ func (s ping1_client_stub) PingS(ctx context.Context, a0 payloadS, a1 int) (r0 p
 	}
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewZeroCopyDecoder(results)
 	(&r0).MXUnmarshal(dec)
 	err = dec.Error()
 	return
@@ -884,9 +886,10 @@ func (s ping10_client_stub) PingC(ctx context.Context, a0 payloadC, a1 int) (r0
 
 	// Preallocate a buffer of the right size.
 	size := 0
-	size += mx_size_payloadC_7e82696e(&a0)
+	size += mx_size_payloadC_2cf90129(&a0)
 	size += 8
-	enc := codegen.NewEncoder()
+	enc := codegen.GetEncoder()
+	defer codegen.PutEncoder(enc)
 	enc.Reset(size)
 
 	// Encode arguments.
@@ -905,7 +908,7 @@ func (s ping10_client_stub) PingC(ctx context.Context, a0 payloadC, a1 int) (r0
 	}
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewZeroCopyDecoder(results)
 	(&r0).MXUnmarshal(dec)
 	err = dec.Error()
 	return
@@ -941,7 +944,8 @@ func (s ping10_client_stub) PingS(ctx context.Context, a0 payloadS, a1 int) (r0
 	}()
 
 	// Encode arguments.
-	enc := codegen.NewEncoder()
+	enc := codegen.GetEncoder()
+	defer codegen.PutEncoder(enc)
 	(a0).MXMarshal(enc)
 	enc.Int(a1)
 	var shardKey uint64
@@ -957,7 +961,7 @@ func (s ping10_client_stub) PingS(ctx context.Context, a0 payloadS, a1 int) (r0
 	}
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewZeroCopyDecoder(results)
 	(&r0).MXUnmarshal(dec)
 	err = dec.Error()
 	return
@@ -1003,9 +1007,10 @@ func (s ping2_client_stub) PingC(ctx context.Context, a0 payloadC, a1 int) (r0 p
 
 	// Preallocate a buffer of the right size.
 	size := 0
-	size += mx_size_payloadC_7e82696e(&a0)
+	size += mx_size_payloadC_2cf90129(&a0)
 	size += 8
-	enc := codegen.NewEncoder()
+	enc := codegen.GetEncoder()
+	defer codegen.PutEncoder(enc)
 	enc.Reset(size)
 
 	// Encode arguments.
@@ -1024,7 +1029,7 @@ func (s ping2_client_stub) PingC(ctx context.Context, a0 payloadC, a1 int) (r0 p
 	}
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewZeroCopyDecoder(results)
 	(&r0).MXUnmarshal(dec)
 	err = dec.Error()
 	return
@@ -1060,7 +1065,8 @@ func (s ping2_client_stub) PingS(ctx context.Context, a0 payloadS, a1 int) (r0 p
 	}()
 
 	// Encode arguments.
-	enc := codegen.NewEncoder()
+	enc := codegen.GetEncoder()
+	defer codegen.PutEncoder(enc)
 	(a0).MXMarshal(enc)
 	enc.Int(a1)
 	var shardKey uint64
@@ -1076,7 +1082,7 @@ func (s ping2_client_stub) PingS(ctx context.Context, a0 payloadS, a1 int) (r0 p
 	}
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewZeroCopyDecoder(results)
 	(&r0).MXUnmarshal(dec)
 	err = dec.Error()
 	return
@@ -1122,9 +1128,10 @@ func (s ping3_client_stub) PingC(ctx context.Context, a0 payloadC, a1 int) (r0 p
 
 	// Preallocate a buffer of the right size.
 	size := 0
-	size += mx_size_payloadC_7e82696e(&a0)
+	size += mx_size_payloadC_2cf90129(&a0)
 	size += 8
-	enc := codegen.NewEncoder()
+	enc := codegen.GetEncoder()
+	defer codegen.PutEncoder(enc)
 	enc.Reset(size)
 
 	// Encode arguments.
@@ -1143,7 +1150,7 @@ func (s ping3_client_stub) PingC(ctx context.Context, a0 payloadC, a1 int) (r0 p
 	}
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewZeroCopyDecoder(results)
 	(&r0).MXUnmarshal(dec)
 	err = dec.Error()
 	return
@@ -1179,7 +1186,8 @@ func (s ping3_client_stub) PingS(ctx context.Context, a0 payloadS, a1 int) (r0 p
 	}()
 
 	// Encode arguments.
-	enc := codegen.NewEncoder()
+	enc := codegen.GetEncoder()
+	defer codegen.PutEncoder(enc)
 	(a0).MXMarshal(enc)
 	enc.Int(a1)
 	var shardKey uint64
@@ -1195,7 +1203,7 @@ func (s ping3_client_stub) PingS(ctx context.Context, a0 payloadS, a1 int) (r0 p
 	}
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewZeroCopyDecoder(results)
 	(&r0).MXUnmarshal(dec)
 	err = dec.Error()
 	return
@@ -1241,9 +1249,10 @@ func (s ping4_client_stub) PingC(ctx context.Context, a0 payloadC, a1 int) (r0 p
 
 	// Preallocate a buffer of the right size.
 	size := 0
-	size += mx_size_payloadC_7e82696e(&a0)
+	size += mx_size_payloadC_2cf90129(&a0)
 	size += 8
-	enc := codegen.NewEncoder()
+	enc := codegen.GetEncoder()
+	defer codegen.PutEncoder(enc)
 	enc.Reset(size)
 
 	// Encode arguments.
@@ -1262,7 +1271,7 @@ func (s ping4_client_stub) PingC(ctx context.Context, a0 payloadC, a1 int) (r0 p
 	}
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewZeroCopyDecoder(results)
 	(&r0).MXUnmarshal(dec)
 	err = dec.Error()
 	return
@@ -1298,7 +1307,8 @@ func (s ping4_client_stub) PingS(ctx context.Context, a0 payloadS, a1 int) (r0 p
 	}()
 
 	// Encode arguments.
-	enc := codegen.NewEncoder()
+	enc := codegen.GetEncoder()
+	defer codegen.PutEncoder(enc)
 	(a0).MXMarshal(enc)
 	enc.Int(a1)
 	var shardKey uint64
@@ -1314,7 +1324,7 @@ func (s ping4_client_stub) PingS(ctx context.Context, a0 payloadS, a1 int) (r0 p
 	}
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewZeroCopyDecoder(results)
 	(&r0).MXUnmarshal(dec)
 	err = dec.Error()
 	return
@@ -1360,9 +1370,10 @@ func (s ping5_client_stub) PingC(ctx context.Context, a0 payloadC, a1 int) (r0 p
 
 	// Preallocate a buffer of the right size.
 	size := 0
-	size += mx_size_payloadC_7e82696e(&a0)
+	size += mx_size_payloadC_2cf90129(&a0)
 	size += 8
-	enc := codegen.NewEncoder()
+	enc := codegen.GetEncoder()
+	defer codegen.PutEncoder(enc)
 	enc.Reset(size)
 
 	// Encode arguments.
@@ -1381,7 +1392,7 @@ func (s ping5_client_stub) PingC(ctx context.Context, a0 payloadC, a1 int) (r0 p
 	}
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewZeroCopyDecoder(results)
 	(&r0).MXUnmarshal(dec)
 	err = dec.Error()
 	return
@@ -1417,7 +1428,8 @@ func (s ping5_client_stub) PingS(ctx context.Context, a0 payloadS, a1 int) (r0 p
 	}()
 
 	// Encode arguments.
-	enc := codegen.NewEncoder()
+	enc := codegen.GetEncoder()
+	defer codegen.PutEncoder(enc)
 	(a0).MXMarshal(enc)
 	enc.Int(a1)
 	var shardKey uint64
@@ -1433,7 +1445,7 @@ func (s ping5_client_stub) PingS(ctx context.Context, a0 payloadS, a1 int) (r0 p
 	}
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewZeroCopyDecoder(results)
 	(&r0).MXUnmarshal(dec)
 	err = dec.Error()
 	return
@@ -1479,9 +1491,10 @@ func (s ping6_client_stub) PingC(ctx context.Context, a0 payloadC, a1 int) (r0 p
 
 	// Preallocate a buffer of the right size.
 	size := 0
-	size += mx_size_payloadC_7e82696e(&a0)
+	size += mx_size_payloadC_2cf90129(&a0)
 	size += 8
-	enc := codegen.NewEncoder()
+	enc := codegen.GetEncoder()
+	defer codegen.PutEncoder(enc)
 	enc.Reset(size)
 
 	// Encode arguments.
@@ -1500,7 +1513,7 @@ func (s ping6_client_stub) PingC(ctx context.Context, a0 payloadC, a1 int) (r0 p
 	}
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewZeroCopyDecoder(results)
 	(&r0).MXUnmarshal(dec)
 	err = dec.Error()
 	return
@@ -1536,7 +1549,8 @@ func (s ping6_client_stub) PingS(ctx context.Context, a0 payloadS, a1 int) (r0 p
 	}()
 
 	// Encode arguments.
-	enc := codegen.NewEncoder()
+	enc := codegen.GetEncoder()
+	defer codegen.PutEncoder(enc)
 	(a0).MXMarshal(enc)
 	enc.Int(a1)
 	var shardKey uint64
@@ -1552,7 +1566,7 @@ func (s ping6_client_stub) PingS(ctx context.Context, a0 payloadS, a1 int) (r0 p
 	}
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewZeroCopyDecoder(results)
 	(&r0).MXUnmarshal(dec)
 	err = dec.Error()
 	return
@@ -1598,9 +1612,10 @@ func (s ping7_client_stub) PingC(ctx context.Context, a0 payloadC, a1 int) (r0 p
 
 	// Preallocate a buffer of the right size.
 	size := 0
-	size += mx_size_payloadC_7e82696e(&a0)
+	size += mx_size_payloadC_2cf90129(&a0)
 	size += 8
-	enc := codegen.NewEncoder()
+	enc := codegen.GetEncoder()
+	defer codegen.PutEncoder(enc)
 	enc.Reset(size)
 
 	// Encode arguments.
@@ -1619,7 +1634,7 @@ func (s ping7_client_stub) PingC(ctx context.Context, a0 payloadC, a1 int) (r0 p
 	}
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewZeroCopyDecoder(results)
 	(&r0).MXUnmarshal(dec)
 	err = dec.Error()
 	return
@@ -1655,7 +1670,8 @@ func (s ping7_client_stub) PingS(ctx context.Context, a0 payloadS, a1 int) (r0 p
 	}()
 
 	// Encode arguments.
-	enc := codegen.NewEncoder()
+	enc := codegen.GetEncoder()
+	defer codegen.PutEncoder(enc)
 	(a0).MXMarshal(enc)
 	enc.Int(a1)
 	var shardKey uint64
@@ -1671,7 +1687,7 @@ func (s ping7_client_stub) PingS(ctx context.Context, a0 payloadS, a1 int) (r0 p
 	}
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewZeroCopyDecoder(results)
 	(&r0).MXUnmarshal(dec)
 	err = dec.Error()
 	return
@@ -1717,9 +1733,10 @@ func (s ping8_client_stub) PingC(ctx context.Context, a0 payloadC, a1 int) (r0 p
 
 	// Preallocate a buffer of the right size.
 	size := 0
-	size += mx_size_payloadC_7e82696e(&a0)
+	size += mx_size_payloadC_2cf90129(&a0)
 	size += 8
-	enc := codegen.NewEncoder()
+	enc := codegen.GetEncoder()
+	defer codegen.PutEncoder(enc)
 	enc.Reset(size)
 
 	// Encode arguments.
@@ -1738,7 +1755,7 @@ func (s ping8_client_stub) PingC(ctx context.Context, a0 payloadC, a1 int) (r0 p
 	}
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewZeroCopyDecoder(results)
 	(&r0).MXUnmarshal(dec)
 	err = dec.Error()
 	return
@@ -1774,7 +1791,8 @@ func (s ping8_client_stub) PingS(ctx context.Context, a0 payloadS, a1 int) (r0 p
 	}()
 
 	// Encode arguments.
-	enc := codegen.NewEncoder()
+	enc := codegen.GetEncoder()
+	defer codegen.PutEncoder(enc)
 	(a0).MXMarshal(enc)
 	enc.Int(a1)
 	var shardKey uint64
@@ -1790,7 +1808,7 @@ func (s ping8_client_stub) PingS(ctx context.Context, a0 payloadS, a1 int) (r0 p
 	}
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewZeroCopyDecoder(results)
 	(&r0).MXUnmarshal(dec)
 	err = dec.Error()
 	return
@@ -1836,9 +1854,10 @@ func (s ping9_client_stub) PingC(ctx context.Context, a0 payloadC, a1 int) (r0 p
 
 	// Preallocate a buffer of the right size.
 	size := 0
-	size += mx_size_payloadC_7e82696e(&a0)
+	size += mx_size_payloadC_2cf90129(&a0)
 	size += 8
-	enc := codegen.NewEncoder()
+	enc := codegen.GetEncoder()
+	defer codegen.PutEncoder(enc)
 	enc.Reset(size)
 
 	// Encode arguments.
@@ -1857,7 +1876,7 @@ func (s ping9_client_stub) PingC(ctx context.Context, a0 payloadC, a1 int) (r0 p
 	}
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewZeroCopyDecoder(results)
 	(&r0).MXUnmarshal(dec)
 	err = dec.Error()
 	return
@@ -1893,7 +1912,8 @@ func (s ping9_client_stub) PingS(ctx context.Context, a0 payloadS, a1 int) (r0 p
 	}()
 
 	// Encode arguments.
-	enc := codegen.NewEncoder()
+	enc := codegen.GetEncoder()
+	defer codegen.PutEncoder(enc)
 	(a0).MXMarshal(enc)
 	enc.Int(a1)
 	var shardKey uint64
@@ -1909,7 +1929,7 @@ func (s ping9_client_stub) PingS(ctx context.Context, a0 payloadS, a1 int) (r0 p
 	}
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewZeroCopyDecoder(results)
 	(&r0).MXUnmarshal(dec)
 	err = dec.Error()
 	return
@@ -1918,10 +1938,10 @@ func (s ping9_client_stub) PingS(ctx context.Context, a0 payloadS, a1 int) (r0 p
 // Note that "mx generate" will always generate the error message below.
 // Everything is okay. The error message is only relevant if you see it when
 // you run "go build" or "go run".
-var _ codegen.LatestVersion = codegen.Version[[0][24]struct{}](`
+var _ codegen.LatestVersion = codegen.Version[[0][25]struct{}](`
 
-ERROR: You generated this file with 'mx generate' v0.24.7-0.20250401231336-b01860e0378a+dirty (codegen
-version v0.24.0). The generated code is incompatible with the version of the
+ERROR: You generated this file with 'mx generate' (devel) (codegen
+version v0.25.0). The generated code is incompatible with the version of the
 github.com/sh3lk/mx module that you're using. The mx module
 version can be found in your go.mod file or by running the following command.
 
@@ -3157,50 +3177,50 @@ func mx_dec_slice_string_4af10117(dec *codegen.Decoder) []string {
 
 // Size implementations.
 
-// mx_size_X1_25e7d26b returns the size (in bytes) of the serialization
+// mx_size_X1_450899e2 returns the size (in bytes) of the serialization
 // of the provided type.
-func mx_size_X1_25e7d26b(x *X1) int {
+func mx_size_X1_450899e2(x *X1) int {
 	size := 0
 	size += 0
-	size += mx_size_X2_4061aeb8(&x.A)
+	size += mx_size_X2_1d07011c(&x.A)
 	size += (4 + (len(x.B) * 8))
 	return size
 }
 
-// mx_size_X2_4061aeb8 returns the size (in bytes) of the serialization
+// mx_size_X2_1d07011c returns the size (in bytes) of the serialization
 // of the provided type.
-func mx_size_X2_4061aeb8(x *X2) int {
+func mx_size_X2_1d07011c(x *X2) int {
 	size := 0
 	size += 0
-	size += mx_size_X3_25c4a6ab(&x.A)
+	size += mx_size_X3_aae8a865(&x.A)
 	return size
 }
 
-// mx_size_X3_25c4a6ab returns the size (in bytes) of the serialization
+// mx_size_X3_aae8a865 returns the size (in bytes) of the serialization
 // of the provided type.
-func mx_size_X3_25c4a6ab(x *X3) int {
+func mx_size_X3_aae8a865(x *X3) int {
 	size := 0
 	size += 0
-	size += mx_size_X4_d3ce6092(&x.A)
+	size += mx_size_X4_07a25872(&x.A)
 	size += 8
 	size += 8
 	return size
 }
 
-// mx_size_X4_d3ce6092 returns the size (in bytes) of the serialization
+// mx_size_X4_07a25872 returns the size (in bytes) of the serialization
 // of the provided type.
-func mx_size_X4_d3ce6092(x *X4) int {
+func mx_size_X4_07a25872(x *X4) int {
 	size := 0
 	size += 0
 	size += 8
-	size += mx_size_X5_474b9a71(&x.B)
+	size += mx_size_X5_d0846960(&x.B)
 	size += 8
 	return size
 }
 
-// mx_size_X5_474b9a71 returns the size (in bytes) of the serialization
+// mx_size_X5_d0846960 returns the size (in bytes) of the serialization
 // of the provided type.
-func mx_size_X5_474b9a71(x *X5) int {
+func mx_size_X5_d0846960(x *X5) int {
 	size := 0
 	size += 0
 	size += 8
@@ -3208,27 +3228,27 @@ func mx_size_X5_474b9a71(x *X5) int {
 	return size
 }
 
-// mx_size_X6_7a3484cc returns the size (in bytes) of the serialization
+// mx_size_X6_acd86362 returns the size (in bytes) of the serialization
 // of the provided type.
-func mx_size_X6_7a3484cc(x *X6) int {
+func mx_size_X6_acd86362(x *X6) int {
 	size := 0
 	size += 0
 	size += (4 + (len(x.A) * 1))
 	return size
 }
 
-// mx_size_payloadC_7e82696e returns the size (in bytes) of the serialization
+// mx_size_payloadC_2cf90129 returns the size (in bytes) of the serialization
 // of the provided type.
-func mx_size_payloadC_7e82696e(x *payloadC) int {
+func mx_size_payloadC_2cf90129(x *payloadC) int {
 	size := 0
 	size += 0
 	size += 8
 	size += (4 + len(x.B))
 	size += 8
-	size += mx_size_X1_25e7d26b(&x.D)
+	size += mx_size_X1_450899e2(&x.D)
 	size += (4 + len(x.E))
 	size += 8
-	size += mx_size_X6_7a3484cc(&x.G)
+	size += mx_size_X6_acd86362(&x.G)
 	size += (4 + len(x.H))
 	size += 8
 	size += 4
@@ -77,4 +77,23 @@ type DeployerControl interface {
 	// NOTE: This method is only called if mTLS was enabled for the mxn,
 	// by passing it a MXNArgs with mtls=true.
 	VerifyServerCertificate(context.Context, *protos.VerifyServerCertificateRequest) (*protos.VerifyServerCertificateReply, error)
+
+	// GetSelfToken returns the bearer token the mxn should send with the
+	// calls it makes to other mxns, along with its expiration time. The
+	// mxn caches the token, and issues this request again before the token
+	// expires.
+	//
+	// NOTE: This method is only called if token authentication was enabled
+	// for the mxn, by passing it a MXNArgs with token_auth=true.
+	GetSelfToken(context.Context, *protos.GetSelfTokenRequest) (*protos.GetSelfTokenReply, error)
+
+	// VerifyClientToken verifies the bearer token sent by a client with a
+	// call to the mxn. It returns an error if the call should be rejected.
+	// Otherwise, it returns the list of mxn components that the client is
+	// authorized to invoke methods on, and the time until which the mxn
+	// may accept the token without verifying it again.
+	//
+	// NOTE: This method is only called if token authentication was enabled
+	// for the mxn, by passing it a MXNArgs with token_auth=true.
+	VerifyClientToken(context.Context, *protos.VerifyClientTokenRequest) (*protos.VerifyClientTokenReply, error)
 }
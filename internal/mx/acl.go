@@ -0,0 +1,129 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mx
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+
+	"github.com/sh3lk/mx/internal/control"
+	"github.com/sh3lk/mx/metrics"
+	"github.com/sh3lk/mx/runtime"
+	"github.com/sh3lk/mx/runtime/codegen"
+)
+
+// rootCaller is the name of the caller of the components obtained via
+// MXN.GetIntf, rather than via an mx.Ref. It is not a component, so it is never
+// hosted by a verified client, and remote calls from it are only accepted when
+// clients are not authenticated (see RemoteMXN.checkCaller).
+const rootCaller = "root"
+
+var aclDenials = metrics.NewCounterMap[aclLabels](
+	"mx_system_call_acl_denied_count",
+	"Count of MX RPC calls rejected by the server because the caller is not allowed to call the component",
+)
+
+type aclLabels struct {
+	Component string // full component name
+	Method    string // method name
+	Caller    string // full name of the calling component
+
+	// Is this a metric implicitly created by the framework?
+	Generated bool `mx:"mx_generated"`
+}
+
+// callerACL records which components may call which components. A component
+// may be called by the components that have an mx.Ref to it and, if the
+// allowed_callers field of its config section is set, that are listed in it.
+type callerACL struct {
+	allowed    map[string]map[string]bool // callee -> allowed callers
+	restricted []string                   // components with allowed_callers set
+}
+
+// newCallerACL returns the access control list of the provided components,
+// given the caller→callee edges between them (see callEdges) and configured by
+// the provided config sections.
+func newCallerACL(regs []*codegen.Registration, edges [][2]string, sections map[string]string) (*callerACL, error) {
+	// Collect the callers of every component.
+	graph := map[string]map[string]bool{}
+	for _, edge := range edges {
+		caller, callee := edge[0], edge[1]
+		if graph[callee] == nil {
+			graph[callee] = map[string]bool{}
+		}
+		graph[callee][caller] = true
+	}
+
+	// Restrict the callers to the configured allowlists, if any.
+	allowed := map[string]map[string]bool{}
+	var restricted []string
+	for _, reg := range regs {
+		calls, err := runtime.ParseCallConfig(reg.Name, sections)
+		if err != nil {
+			return nil, fmt.Errorf("component %q: %w", reg.Name, err)
+		}
+		callers := graph[reg.Name]
+		if calls.AllowedCallers != nil {
+			restricted = append(restricted, reg.Name)
+			listed := map[string]bool{}
+			for _, caller := range calls.AllowedCallers {
+				if callers[caller] {
+					listed[caller] = true
+				}
+			}
+			callers = listed
+		}
+		allowed[reg.Name] = callers
+	}
+	slices.Sort(restricted)
+	return &callerACL{allowed: allowed, restricted: restricted}, nil
+}
+
+// callEdges returns the caller→callee edges, by component name, of the
+// component call graph of the provided components.
+func callEdges(regs []*codegen.Registration) [][2]string {
+	names := map[reflect.Type]string{}
+	for _, reg := range regs {
+		names[reg.Iface] = reg.Name
+	}
+	var edges [][2]string
+	for _, edge := range codegen.CallGraph() {
+		caller, ok1 := names[edge.Caller]
+		callee, ok2 := names[edge.Callee]
+		if ok1 && ok2 {
+			edges = append(edges, [2]string{caller, callee})
+		}
+	}
+	return edges
+}
+
+// isControlComponent returns whether the provided component is one of the
+// control components, which are called by the deployers rather than by other
+// components.
+func isControlComponent(name string) bool {
+	return name == control.DeployerPath || name == control.MXNPath
+}
+
+// allows returns whether the provided caller may call the provided callee.
+// The calls to the control components are always allowed.
+func (a *callerACL) allows(caller, callee string) bool {
+	switch {
+	case isControlComponent(callee):
+		return true
+	default:
+		return a.allowed[callee][caller]
+	}
+}
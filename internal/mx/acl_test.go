@@ -0,0 +1,95 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mx
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sh3lk/mx/internal/control"
+	"github.com/sh3lk/mx/runtime/codegen"
+)
+
+func TestCallerACL(t *testing.T) {
+	// Frontend and Admin call Cache, and Admin calls Frontend.
+	regs := []*codegen.Registration{{Name: "Frontend"}, {Name: "Admin"}, {Name: "Cache"}}
+	edges := [][2]string{
+		{"Frontend", "Cache"},
+		{"Admin", "Cache"},
+		{"Admin", "Frontend"},
+	}
+
+	for _, test := range []struct {
+		name       string
+		sections   map[string]string
+		allowed    [][2]string // caller, callee
+		denied     [][2]string // caller, callee
+		restricted []string    // components with allowed_callers set
+	}{
+		{
+			name: "Graph",
+			allowed: [][2]string{
+				{"Frontend", "Cache"},
+				{"Admin", "Cache"},
+				{"Admin", "Frontend"},
+				{"", control.MXNPath},
+			},
+			denied: [][2]string{
+				{"root", "Frontend"},
+				{"Cache", "Frontend"},
+				{"Frontend", "Admin"},
+				{"Cache", "Cache"},
+				{"", "Cache"},
+				{"Unknown", "Cache"},
+			},
+		},
+		{
+			name: "Allowlist",
+			sections: map[string]string{
+				"Cache":    `allowed_callers = ["Admin", "Unknown"]`,
+				"Frontend": `allowed_callers = []`,
+			},
+			allowed: [][2]string{
+				{"Admin", "Cache"},
+			},
+			denied: [][2]string{
+				{"Frontend", "Cache"},
+				{"Unknown", "Cache"},
+				{"Admin", "Frontend"},
+			},
+			restricted: []string{"Cache", "Frontend"},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			acl, err := newCallerACL(regs, edges, test.sections)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(test.restricted, acl.restricted); diff != "" {
+				t.Errorf("restricted components (-want +got):\n%s", diff)
+			}
+			for _, edge := range test.allowed {
+				if !acl.allows(edge[0], edge[1]) {
+					t.Errorf("%q -> %q denied, want allowed", edge[0], edge[1])
+				}
+			}
+			for _, edge := range test.denied {
+				if acl.allows(edge[0], edge[1]) {
+					t.Errorf("%q -> %q allowed, want denied", edge[0], edge[1])
+				}
+			}
+		})
+	}
+}
@@ -29,6 +29,7 @@ import (
 	"sync"
 	"sync/atomic"
 	"syscall"
+	"time"
 
 	"github.com/sh3lk/mx/internal/config"
 	"github.com/sh3lk/mx/internal/control"
@@ -40,6 +41,7 @@ import (
 	"github.com/sh3lk/mx/runtime/deployers"
 	"github.com/sh3lk/mx/runtime/logging"
 	"github.com/sh3lk/mx/runtime/metrics"
+	"github.com/sh3lk/mx/runtime/protomsg"
 	"github.com/sh3lk/mx/runtime/protos"
 	"github.com/sh3lk/mx/runtime/retry"
 	"github.com/sh3lk/mx/runtime/version"
@@ -77,6 +79,8 @@ type RemoteMXN struct {
 	syslogger *slog.Logger            // system logger
 	tracer    trace.Tracer            // tracer used by all components
 	metrics   metrics.Exporter        // helper for sending metrics to envelope
+	selfToken *selfToken              // sent with calls, if token auth is enabled
+	tokens    *tokenVerifier          // verifies tokens, if token auth is enabled
 
 	// state to synchronize with envelope initiated initialization handshake.
 	initMu     sync.Mutex
@@ -84,7 +88,13 @@ type RemoteMXN struct {
 	initDone   chan struct{}
 
 	// Ready to use by the time initDone is closed.
-	sectionConfig map[string]string
+	sectionConfig     map[string]string
+	acl               *callerACL       // callers allowed to call the components
+	hedgePercentile   float64          // see protos.AppConfig.HedgePercentile
+	keepaliveInterval time.Duration    // see protos.AppConfig.KeepaliveIntervalNanos
+	keepaliveTimeout  time.Duration    // see protos.AppConfig.KeepaliveTimeoutNanos
+	compression       call.Compression // see protos.AppConfig.Compression
+	compressThreshold int              // see protos.AppConfig.CompressionThreshold
 
 	// channel that is closed when deployer is ready.
 	deployerReady chan struct{}
@@ -164,6 +174,21 @@ func NewRemoteMXN(ctx context.Context, regs []*codegen.Registration, bootstrap r
 			lis.Close()
 		}
 	}()
+	listeners := []net.Listener{lis}
+
+	// Make shared-memory listener, if requested.
+	if args.SharedMemorySocket != "" {
+		shmLis, err := call.ListenSharedMemory(args.SharedMemorySocket)
+		if err != nil {
+			return nil, err
+		}
+		defer func() {
+			if cleanupListener {
+				shmLis.Close()
+			}
+		}()
+		listeners = append(listeners, shmLis)
+	}
 
 	// Pre-construct reply for InitMXN call.
 	dialAddr := fmt.Sprintf("tcp://%s", lis.Addr().String())
@@ -233,7 +258,7 @@ func NewRemoteMXN(ctx context.Context, regs []*codegen.Registration, bootstrap r
 
 		// Initialize the resolver and balancer.
 		c.resolver = newRoutingResolver()
-		c.balancer = newRoutingBalancer(c.clientTLS)
+		c.balancer = newRoutingBalancer(call.RoundRobin(), c.clientTLS)
 	}
 
 	// Process all redirects.
@@ -252,6 +277,12 @@ func NewRemoteMXN(ctx context.Context, regs []*codegen.Registration, bootstrap r
 	}
 	close(w.deployerReady)
 
+	// Set up token authentication, if enabled.
+	if info.TokenAuth {
+		w.selfToken = newSelfToken(ctx, w.deployer, w.syslogger)
+		w.tokens = newTokenVerifier(w.deployer)
+	}
+
 	// Wire-up log writing.
 	servers.Go(func() error {
 		w.logDst.run(ctx, w.deployer.LogBatch)
@@ -274,19 +305,24 @@ func NewRemoteMXN(ctx context.Context, regs []*codegen.Registration, bootstrap r
 	}
 
 	// Serve RPC requests from other mxns.
-	cleanupListener = false // handing listener to server
-	servers.Go(func() error {
-		server := &server{Listener: lis, wlet: w}
-		opts := call.ServerOptions{
-			Logger: w.syslogger,
-			Tracer: w.tracer,
-		}
-		if err := call.Serve(w.ctx, server, opts); err != nil {
-			w.syslogger.Error("RPC server failed", "err", err)
-			return err
-		}
-		return nil
-	})
+	cleanupListener = false // handing listeners to servers
+	for _, lis := range listeners {
+		servers.Go(func() error {
+			server := &server{Listener: lis, wlet: w}
+			opts := call.ServerOptions{
+				Logger:               w.syslogger,
+				Tracer:               w.tracer,
+				Compression:          w.compression,
+				CompressionThreshold: w.compressThreshold,
+				Admission:            w.admission,
+			}
+			if err := call.Serve(w.ctx, server, opts); err != nil {
+				w.syslogger.Error("RPC server failed", "err", err)
+				return err
+			}
+			return nil
+		})
+	}
 
 	// Start a signal handler to detect when the process is killed. This isn't
 	// perfect, as we can't catch a SIGKILL, but it's good in the common case.
@@ -317,7 +353,45 @@ func (w *RemoteMXN) InitMXN(ctx context.Context, req *protos.InitMXNRequest) (*p
 	w.initMu.Lock()
 	defer w.initMu.Unlock()
 	if !w.initCalled {
+		// Configure the balancers of the components. Stubs are created only
+		// after initialization, so none of the balancers are in use yet.
+		app, err := runtime.ParseAppSection(req.Sections)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range w.componentsByName {
+			balancer, err := call.NewBalancer(app.Balancers[c.reg.Name])
+			if err != nil {
+				return nil, fmt.Errorf("component %q: %w", c.reg.Name, err)
+			}
+			c.balancer.balancer = balancer
+		}
+
+		regs := make([]*codegen.Registration, 0, len(w.componentsByName))
+		for _, c := range w.componentsByName {
+			regs = append(regs, c.reg)
+		}
+		acl, err := newCallerACL(regs, callEdges(regs), req.Sections)
+		if err != nil {
+			return nil, err
+		}
+		compression, err := call.ParseCompression(app.Compression)
+		if err != nil {
+			return nil, err
+		}
+		if len(acl.restricted) > 0 && !w.args.Mtls && !w.args.TokenAuth {
+			// Without mTLS or tokens, the callers are not known (see
+			// checkCaller), so the ACL isn't enforced.
+			w.syslogger.Warn("allowed_callers is not enforced, because callers are not authenticated; enable mTLS or token authentication to enforce it", "components", acl.restricted)
+		}
+
 		w.sectionConfig = req.Sections
+		w.acl = acl
+		w.hedgePercentile = app.HedgePercentile
+		w.keepaliveInterval = time.Duration(app.KeepaliveIntervalNanos)
+		w.keepaliveTimeout = time.Duration(app.KeepaliveTimeoutNanos)
+		w.compression = compression
+		w.compressThreshold = int(app.CompressionThreshold)
 		w.initCalled = true
 		close(w.initDone)
 	}
@@ -340,7 +414,7 @@ func (w *RemoteMXN) Wait() error {
 
 // GetIntf implements the MXN interface.
 func (w *RemoteMXN) GetIntf(t reflect.Type) (any, error) {
-	return w.getIntf(t, "root")
+	return w.getIntf(t, rootCaller)
 }
 
 // getIntf is identical to [GetIntf], but has an additional requester argument
@@ -386,7 +460,7 @@ func (w *RemoteMXN) getIntf(t reflect.Type, requester string) (any, error) {
 		if err != nil {
 			return nil, err
 		}
-		return c.reg.LocalStubFn(impl, requester, w.tracer), nil
+		return localStub(c.reg, impl, requester, w.tracer, w.logger(requester)), nil
 	}
 
 	// Return a remote stub.
@@ -394,7 +468,7 @@ func (w *RemoteMXN) getIntf(t reflect.Type, requester string) (any, error) {
 	if err != nil {
 		return nil, err
 	}
-	return c.reg.ClientStubFn(stub, requester), nil
+	return clientStub(c.reg, call.WithCaller(stub, requester), requester, w.logger(requester)), nil
 }
 
 // redirect creates a component interface for c that redirects calls to the
@@ -418,7 +492,7 @@ func (w *RemoteMXN) redirect(requester string, c *component, target, address str
 	if c.stubErr != nil {
 		return nil, c.stubErr
 	}
-	return c.reg.ClientStubFn(c.stub, requester), nil
+	return c.reg.ClientStubFn(call.WithCaller(c.stub, requester), requester), nil
 }
 
 // GetImpl implements the MXN interface.
@@ -441,7 +515,7 @@ func (w *RemoteMXN) GetImpl(t reflect.Type) (any, error) {
 		}
 
 		logger := w.logger(c.reg.Name)
-		c.serverStub = c.reg.ServerStubFn(c.impl, func(key uint64, v float64) {
+		c.serverStub = serverStub(c.reg, c.impl, func(key uint64, v float64) {
 			if c.reg.Routed {
 				if err := c.load.add(key, v); err != nil {
 					logger.Error("add load", "err", err, "component", c.reg.Name, "key", key)
@@ -467,7 +541,7 @@ func (w *RemoteMXN) createComponent(ctx context.Context, reg *codegen.Registrati
 
 	// Fill config if necessary.
 	if cfg := config.Config(v); cfg != nil {
-		if err := runtime.ParseConfigSection(reg.Name, "", w.sectionConfig, cfg); err != nil {
+		if err := runtime.ParseComponentConfigSection(reg.Name, w.sectionConfig, cfg); err != nil {
 			return nil, err
 		}
 	}
@@ -523,8 +597,34 @@ func (w *RemoteMXN) makeStub(fullName string, reg *codegen.Registration, resolve
 	name := logging.ShortenComponent(fullName)
 	w.syslogger.Debug("Connecting to remote", "component", name)
 	opts := call.ClientOptions{
-		Balancer: balancer,
-		Logger:   w.syslogger,
+		Balancer:             balancer,
+		Logger:               w.syslogger,
+		HedgePercentile:      w.hedgePercentile,
+		Component:            fullName,
+		KeepaliveInterval:    w.keepaliveInterval,
+		KeepaliveTimeout:     w.keepaliveTimeout,
+		Compression:          w.compression,
+		CompressionThreshold: w.compressThreshold,
+	}
+	if w.selfToken != nil && !isControlComponent(fullName) {
+		opts.Token = w.selfToken.get
+	}
+	calls, err := runtime.ParseCallConfig(fullName, w.sectionConfig)
+	if err != nil {
+		return nil, err
+	}
+	if b := calls.CircuitBreaker; b != nil {
+		opts.CircuitBreaker = call.CircuitBreakerOptions{
+			Failures:      b.Failures,
+			Timeout:       b.Timeout,
+			HalfOpenCalls: b.HalfOpenCalls,
+		}
+	}
+	if b := calls.RetryBudget; b != nil {
+		opts.RetryBudget = call.RetryBudgetOptions{
+			Ratio:        b.Ratio,
+			MinPerSecond: b.MinPerSecond,
+		}
 	}
 	conn, err := call.Connect(w.ctx, resolver, opts)
 	if err != nil {
@@ -645,15 +745,23 @@ func (w *RemoteMXN) UpdateRoutingInfo(_ context.Context, req *protos.UpdateRouti
 	}
 
 	// Update resolver.
-	endpoints, err := parseEndpoints(info.Replicas, c.clientTLS)
+	endpoints, err := parseEndpoints(info.Replicas, info.SharedMemory, c.clientTLS)
 	if err != nil {
 		return nil, err
 	}
 	c.resolver.update(endpoints)
 
-	// Update balancer.
+	// Update balancer. The balancer identifies replicas by the addresses of
+	// their endpoints, which differ from the replica addresses for replicas
+	// reached through shared memory.
 	if info.Assignment != nil {
-		c.balancer.update(info.Assignment)
+		renamed := map[string]string{}
+		for i, addr := range info.Replicas {
+			if ep := endpoints[i].Address(); ep != addr {
+				renamed[addr] = ep
+			}
+		}
+		c.balancer.update(renameReplicas(info.Assignment, renamed))
 	}
 
 	// Update load collector.
@@ -728,11 +836,39 @@ func (w *RemoteMXN) getComponent(name string) (*component, error) {
 // addHandlers registers a component's methods as handlers in the given map.
 // Specifically, for every method m in the component, we register a function f
 // that (1) creates the local component if it hasn't been created yet and (2)
-// calls m.
-func (w *RemoteMXN) addHandlers(handlers *call.HandlerMap, c *component) {
+// calls m. callers holds the components hosted by the client whose calls the
+// handlers serve, or is nil if the client is not authenticated (see
+// checkCaller).
+func (w *RemoteMXN) addHandlers(handlers *call.HandlerMap, c *component, callers map[string]bool) {
+	streaming := map[int]bool{}
+	for _, i := range c.reg.Streaming {
+		streaming[i] = true
+	}
 	for i, n := 0, c.reg.Iface.NumMethod(); i < n; i++ {
 		mname := c.reg.Iface.Method(i).Name
+		if streaming[i] {
+			handler := func(ctx context.Context, args []byte, in codegen.ByteStream) ([]byte, codegen.ByteStream, error) {
+				if err := w.checkCaller(ctx, c, mname, callers); err != nil {
+					return nil, nil, err
+				}
+				// Start the component if needed. See the handler below.
+				if _, err := w.GetImpl(c.reg.Impl); err != nil {
+					return nil, nil, err
+				}
+				server, ok := c.serverStub.(codegen.StreamServer)
+				if !ok {
+					return nil, nil, fmt.Errorf("component %q has no streaming methods; maybe you forgot to run mx generate", c.reg.Name)
+				}
+				fn := server.GetStreamStubFn(mname)
+				return fn(ctx, args, in)
+			}
+			handlers.SetStream(c.reg.Name, mname, handler)
+			continue
+		}
 		handler := func(ctx context.Context, args []byte) (res []byte, err error) {
+			if err := w.checkCaller(ctx, c, mname, callers); err != nil {
+				return nil, err
+			}
 			// This handler is supposed to invoke the method named mname on the
 			// local component. However, it is possible that the component has
 			// not yet been started. w.GetImpl will start the component if it
@@ -758,6 +894,74 @@ func (w *RemoteMXN) addHandlers(handlers *call.HandlerMap, c *component) {
 	})
 }
 
+// checkCaller returns call.PermissionDenied if the caller of the call with the
+// provided context may not call component c, either because the call's token
+// doesn't allow it (if token authentication is enabled) or because the ACL
+// doesn't. Denied calls are logged and counted.
+//
+// The caller named in the header of a call is only trusted if it is one of the
+// components hosted by the client, as verified by the deployer: callers holds
+// the components hosted by the client whose certificate was verified when its
+// connection was accepted, if mTLS is enabled, and the components hosted by
+// the client are returned with the verified token of the call, if token
+// authentication is enabled. Without either, the caller cannot be verified,
+// and the ACL is not checked.
+func (w *RemoteMXN) checkCaller(ctx context.Context, c *component, method string, callers map[string]bool) error {
+	if isControlComponent(c.reg.Name) {
+		return nil
+	}
+	caller := call.Caller(ctx)
+	if w.tokens != nil {
+		var err error
+		if callers, err = w.tokens.verify(ctx, call.Token(ctx), c.reg.Name); err != nil {
+			return w.deny(c, method, caller, "err", err)
+		}
+	}
+	if callers == nil {
+		return nil
+	}
+	if !callers[caller] {
+		return w.deny(c, method, caller, "err", "caller is not hosted by the client")
+	}
+	if w.acl.allows(caller, c.reg.Name) {
+		return nil
+	}
+	return w.deny(c, method, caller)
+}
+
+// deny logs and counts a denied call, and returns call.PermissionDenied.
+func (w *RemoteMXN) deny(c *component, method, caller string, attrs ...any) error {
+	attrs = append([]any{"component", c.reg.Name, "method", method, "caller", caller}, attrs...)
+	w.syslogger.Warn("Call denied", attrs...)
+	aclDenials.Get(aclLabels{
+		Component: c.reg.Name,
+		Method:    method,
+		Caller:    caller,
+		Generated: true,
+	}).Inc()
+	return call.PermissionDenied
+}
+
+// admission returns the admission control options of the provided method of
+// the provided component.
+func (w *RemoteMXN) admission(component, method string) call.AdmissionOptions {
+	if method == readyMethodName {
+		return call.AdmissionOptions{}
+	}
+	calls, err := runtime.ParseCallConfig(component, w.sectionConfig)
+	if err != nil {
+		// The config is validated by the deployer, so this shouldn't happen.
+		w.syslogger.Error("Bad admission config", "component", component, "err", err)
+		return call.AdmissionOptions{}
+	}
+	limits := calls.Admission.Limits(method)
+	return call.AdmissionOptions{
+		MaxConcurrency: limits.MaxConcurrency,
+		MaxQueue:       limits.MaxQueue,
+		Adaptive:       limits.Adaptive,
+	}
+}
+
 // repeatedly repeatedly executes f until it succeeds or until ctx is cancelled.
 func (w *RemoteMXN) repeatedly(ctx context.Context, errMsg string, f func() error) error {
 	for r := retry.Begin(); r.Continue(ctx); {
@@ -882,13 +1086,9 @@ func (w *RemoteMXN) getSelfCertificate() (*tls.Certificate, error) {
 	return &tlsCert, nil
 }
 
-func (w *RemoteMXN) verifyClientCertificate(certChain [][]byte) ([]string, error) {
+func (w *RemoteMXN) verifyClientCertificate(certChain [][]byte) (*protos.VerifyClientCertificateReply, error) {
 	request := &protos.VerifyClientCertificateRequest{CertChain: certChain}
-	reply, err := w.deployer.VerifyClientCertificate(context.TODO(), request)
-	if err != nil {
-		return nil, err
-	}
-	return reply.Components, nil
+	return w.deployer.VerifyClientCertificate(context.TODO(), request)
 }
 
 func (w *RemoteMXN) verifyServerCertificate(certChain [][]byte, targetComponent string) error {
@@ -917,13 +1117,13 @@ func (s *server) Accept() (net.Conn, *call.HandlerMap, error) {
 
 	if !s.wlet.Info().Mtls {
 		// No security: all components are accessible.
-		hm, err := s.handlers(maps.Keys(s.wlet.componentsByName))
+		hm, err := s.handlers(maps.Keys(s.wlet.componentsByName), nil)
 		return conn, hm, err
 	}
 
 	// Establish a TLS connection with the client and get the list of
-	// components it can access.
-	var accessibleComponents []string
+	// components it can access, and the components it hosts.
+	var verified *protos.VerifyClientCertificateReply
 	tlsConfig := &tls.Config{
 		GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
 			return s.wlet.getSelfCertificate()
@@ -931,7 +1131,7 @@ func (s *server) Accept() (net.Conn, *call.HandlerMap, error) {
 		ClientAuth: tls.RequireAnyClientCert,
 		VerifyPeerCertificate: func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
 			var err error
-			accessibleComponents, err = s.wlet.verifyClientCertificate(rawCerts)
+			verified, err = s.wlet.verifyClientCertificate(rawCerts)
 			return err
 		},
 	}
@@ -941,12 +1141,17 @@ func (s *server) Accept() (net.Conn, *call.HandlerMap, error) {
 	}
 
 	// NOTE: VerifyPeerCertificate above has been called at this point.
-	hm, err := s.handlers(accessibleComponents)
+	callers := map[string]bool{}
+	for _, caller := range verified.Callers {
+		callers[caller] = true
+	}
+	hm, err := s.handlers(verified.Components, callers)
 	return tlsConn, hm, err
 }
 
-// handlers returns method handlers for the given components.
-func (s *server) handlers(components []string) (*call.HandlerMap, error) {
+// handlers returns method handlers for the given components, serving calls
+// from a client that hosts the provided callers (see checkCaller).
+func (s *server) handlers(components []string, callers map[string]bool) (*call.HandlerMap, error) {
 	// Note that the components themselves may not be started, but we still
 	// register their handlers to avoid concurrency issues with on-demand
 	// handler additions.
@@ -956,7 +1161,7 @@ func (s *server) handlers(components []string) (*call.HandlerMap, error) {
 		if err != nil {
 			return nil, err
 		}
-		s.wlet.addHandlers(hm, c)
+		s.wlet.addHandlers(hm, c, callers)
 	}
 	return hm, nil
 }
@@ -974,14 +1179,17 @@ func waitUntilReady(ctx context.Context, client call.Connection, fullComponentNa
 }
 
 // parseEndpoints parses a list of endpoint addresses into a list of
-// call.Endpoints.
-func parseEndpoints(addrs []string, config *tls.Config) ([]call.Endpoint, error) {
+// call.Endpoints. Addresses with an entry in shm are reached through shared
+// memory, using the Unix socket in the entry.
+func parseEndpoints(addrs []string, shm map[string]string, config *tls.Config) ([]call.Endpoint, error) {
 	var endpoints []call.Endpoint
 	var err error
 	var ep call.Endpoint
 	for _, addr := range addrs {
 		const mtlsPrefix = "mtls://"
-		if ep, err = call.ParseNetEndpoint(strings.TrimPrefix(addr, mtlsPrefix)); err != nil {
+		if socket, ok := shm[addr]; ok {
+			ep = call.SharedMemory(socket)
+		} else if ep, err = call.ParseNetEndpoint(strings.TrimPrefix(addr, mtlsPrefix)); err != nil {
 			return nil, err
 		}
 		if strings.HasPrefix(addr, mtlsPrefix) {
@@ -994,3 +1202,20 @@ func parseEndpoints(addrs []string, config *tls.Config) ([]call.Endpoint, error)
 	}
 	return endpoints, nil
 }
+
+// renameReplicas returns a copy of the provided assignment where every replica
+// address with an entry in renamed is replaced by the entry.
+func renameReplicas(assignment *protos.Assignment, renamed map[string]string) *protos.Assignment {
+	if len(renamed) == 0 {
+		return assignment
+	}
+	assignment = protomsg.Clone(assignment)
+	for _, slice := range assignment.Slices {
+		for i, replica := range slice.Replicas {
+			if addr, ok := renamed[replica]; ok {
+				slice.Replicas[i] = addr
+			}
+		}
+	}
+	return assignment
+}
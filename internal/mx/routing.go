@@ -19,6 +19,7 @@ import (
 	"crypto/tls"
 	"math/rand"
 	"sync"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/sh3lk/mx/internal/cond"
@@ -41,10 +42,13 @@ type routingBalancer struct {
 	conns map[string]call.ReplicaConnection
 }
 
-// newRoutingBalancer returns a new routingBalancer.
-func newRoutingBalancer(tlsConfig *tls.Config) *routingBalancer {
+var _ call.CallTracker = &routingBalancer{}
+
+// newRoutingBalancer returns a new routingBalancer that uses the provided
+// balancer for non-routed calls.
+func newRoutingBalancer(balancer call.Balancer, tlsConfig *tls.Config) *routingBalancer {
 	return &routingBalancer{
-		balancer:  call.RoundRobin(),
+		balancer:  balancer,
 		tlsConfig: tlsConfig,
 		conns:     map[string]call.ReplicaConnection{},
 	}
@@ -68,6 +72,14 @@ func (rb *routingBalancer) Remove(c call.ReplicaConnection) {
 	delete(rb.conns, c.Address())
 }
 
+// Done implements the call.CallTracker interface.
+func (rb *routingBalancer) Done(c call.ReplicaConnection, latency time.Duration, err error) {
+	// The latency and health of a replica are tracked for routed calls too.
+	if tracker, ok := rb.balancer.(call.CallTracker); ok {
+		tracker.Done(c, latency, err)
+	}
+}
+
 // update updates the balancer with the provided assignment
 func (rb *routingBalancer) update(assignment *protos.Assignment) {
 	if assignment == nil {
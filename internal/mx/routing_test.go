@@ -42,7 +42,8 @@ func (ne nilEndpoint) Address() string {
 // fakeConn is a fake call.ReplicaConnection used for testing.
 type fakeConn string
 
-func (f fakeConn) Address() string { return string(f) }
+func (f fakeConn) Address() string  { return string(f) }
+func (f fakeConn) Outstanding() int { return 0 }
 
 // TestRoutingBalancerNoAssignment tests that a routingBalancer with no
 // assignment will use its default balancer instead.
@@ -247,7 +247,7 @@ func (w *SingleMXN) getIntf(t reflect.Type, requester string) (any, error) {
 	if err != nil {
 		return nil, err
 	}
-	return reg.LocalStubFn(c, requester, w.tracer), nil
+	return localStub(reg, c, requester, w.tracer, w.logger(requester)), nil
 }
 
 // getImpl returns the component with the provided implementation type. The
@@ -283,7 +283,7 @@ func (w *SingleMXN) get(reg *codegen.Registration) (any, error) {
 
 	// Fill config.
 	if cfg := config.Config(v); cfg != nil {
-		if err := runtime.ParseConfigSection(reg.Name, "", w.config.App.Sections, cfg); err != nil {
+		if err := runtime.ParseComponentConfigSection(reg.Name, w.config.App.Sections, cfg); err != nil {
 			return nil, err
 		}
 	}
@@ -0,0 +1,184 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mx
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/sh3lk/mx/internal/control"
+	"github.com/sh3lk/mx/runtime/protos"
+)
+
+// selfToken is the bearer token that a mxn sends with the calls it makes to
+// other mxns, when token authentication is enabled (see
+// protos.MXNArgs.TokenAuth). The token is obtained from the deployer, and
+// refreshed in the background once two thirds of its lifetime have elapsed.
+type selfToken struct {
+	ctx      context.Context // bounds background refreshes
+	deployer control.DeployerControl
+	logger   *slog.Logger
+
+	// fetchMu serializes the calls to the deployer. It is never acquired
+	// while mu is held, so that calls keep using the current token while a
+	// new one is fetched.
+	fetchMu sync.Mutex
+
+	mu         sync.Mutex // guards the following fields
+	token      string
+	fetched    time.Time // when token was fetched
+	expires    time.Time // when token expires
+	refreshing bool      // is a background refresh running?
+}
+
+func newSelfToken(ctx context.Context, deployer control.DeployerControl, logger *slog.Logger) *selfToken {
+	return &selfToken{ctx: ctx, deployer: deployer, logger: logger}
+}
+
+// get returns the current token, fetching a new one if the current one has
+// expired.
+func (t *selfToken) get(ctx context.Context) (string, error) {
+	t.mu.Lock()
+	now := time.Now()
+	if !now.Before(t.expires) {
+		// The token has expired, or hasn't been fetched yet.
+		t.mu.Unlock()
+		return t.fetch(ctx)
+	}
+	if !now.Before(t.renewAt()) && !t.refreshing {
+		// Keep using the current token until a new one is fetched.
+		t.refreshing = true
+		go t.refresh()
+	}
+	token := t.token
+	t.mu.Unlock()
+	return token, nil
+}
+
+// refresh fetches a new token.
+func (t *selfToken) refresh() {
+	_, err := t.fetch(t.ctx)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.refreshing = false
+	if err != nil {
+		t.logger.Error("Unable to refresh token", "err", err, "expires", t.expires)
+	}
+}
+
+// fetch fetches a new token from the deployer and returns it. If another
+// fetch obtained a token that doesn't need renewing yet while fetch waited
+// for it to finish, fetch returns that token instead.
+//
+// REQUIRES: t.mu is not held.
+func (t *selfToken) fetch(ctx context.Context) (string, error) {
+	t.fetchMu.Lock()
+	defer t.fetchMu.Unlock()
+
+	t.mu.Lock()
+	token, fresh := t.token, time.Now().Before(t.renewAt())
+	t.mu.Unlock()
+	if fresh {
+		return token, nil
+	}
+
+	fetched := time.Now()
+	reply, err := t.deployer.GetSelfToken(ctx, &protos.GetSelfTokenRequest{})
+	if err != nil {
+		return "", err
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.token = reply.Token
+	t.fetched = fetched
+	t.expires = time.UnixMicro(reply.ExpiresMicros)
+	return t.token, nil
+}
+
+// renewAt returns when the token should be replaced by a new one, once two
+// thirds of its lifetime have elapsed.
+//
+// REQUIRES: t.mu is held.
+func (t *selfToken) renewAt() time.Time {
+	return t.fetched.Add(t.expires.Sub(t.fetched) * 2 / 3)
+}
+
+// tokenVerifier verifies the bearer tokens sent by the clients of a mxn,
+// when token authentication is enabled. Tokens are verified by the deployer,
+// and the results are cached until the tokens expire.
+type tokenVerifier struct {
+	deployer control.DeployerControl
+
+	mu       sync.Mutex
+	verified map[string]verifiedToken // by token
+}
+
+// verifiedToken is a token verified by the deployer.
+type verifiedToken struct {
+	components map[string]bool // components the client may call
+	callers    map[string]bool // components hosted by the client
+	expires    time.Time       // when the token expires
+}
+
+func newTokenVerifier(deployer control.DeployerControl) *tokenVerifier {
+	return &tokenVerifier{deployer: deployer, verified: map[string]verifiedToken{}}
+}
+
+// verify returns an error if a client with the provided token may not call
+// the provided component. Otherwise, it returns the components hosted by the
+// client, on whose behalf the client may make calls.
+func (v *tokenVerifier) verify(ctx context.Context, token, component string) (map[string]bool, error) {
+	if token == "" {
+		return nil, fmt.Errorf("missing token")
+	}
+	now := time.Now()
+	v.mu.Lock()
+	t, ok := v.verified[token]
+	v.mu.Unlock()
+	if !ok || !now.Before(t.expires) {
+		request := &protos.VerifyClientTokenRequest{Token: token}
+		reply, err := v.deployer.VerifyClientToken(ctx, request)
+		if err != nil {
+			return nil, err
+		}
+		t = verifiedToken{
+			components: map[string]bool{},
+			callers:    map[string]bool{},
+			expires:    time.UnixMicro(reply.ExpiresMicros),
+		}
+		for _, c := range reply.Components {
+			t.components[c] = true
+		}
+		for _, c := range reply.Callers {
+			t.callers[c] = true
+		}
+
+		v.mu.Lock()
+		for token, t := range v.verified {
+			if !now.Before(t.expires) {
+				delete(v.verified, token)
+			}
+		}
+		v.verified[token] = t
+		v.mu.Unlock()
+	}
+	if !t.components[component] {
+		return nil, fmt.Errorf("token doesn't allow calls to %q", component)
+	}
+	return t.callers, nil
+}
@@ -0,0 +1,186 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mx
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sh3lk/mx/internal/control"
+	"github.com/sh3lk/mx/runtime/logging"
+	"github.com/sh3lk/mx/runtime/protos"
+)
+
+// tokenDeployer is a fake deployer that issues and verifies tokens that are
+// valid for the provided lifetime.
+type tokenDeployer struct {
+	control.DeployerControl
+	lifetime time.Duration
+	block    chan struct{} // if not nil, GetSelfToken waits for it to close
+
+	mu       sync.Mutex
+	issued   int // number of issued tokens
+	verified int // number of verified tokens
+}
+
+func (d *tokenDeployer) GetSelfToken(context.Context, *protos.GetSelfTokenRequest) (*protos.GetSelfTokenReply, error) {
+	if d.block != nil {
+		<-d.block
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.issued++
+	return &protos.GetSelfTokenReply{
+		Token:         fmt.Sprintf("token%d", d.issued),
+		ExpiresMicros: time.Now().Add(d.lifetime).UnixMicro(),
+	}, nil
+}
+
+func (d *tokenDeployer) VerifyClientToken(_ context.Context, req *protos.VerifyClientTokenRequest) (*protos.VerifyClientTokenReply, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.verified++
+	if req.Token != "good" {
+		return nil, fmt.Errorf("bad token %q", req.Token)
+	}
+	return &protos.VerifyClientTokenReply{
+		Components:    []string{"A"},
+		Callers:       []string{"B"},
+		ExpiresMicros: time.Now().Add(d.lifetime).UnixMicro(),
+	}, nil
+}
+
+func (d *tokenDeployer) counts() (int, int) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.issued, d.verified
+}
+
+func TestSelfToken(t *testing.T) {
+	ctx := context.Background()
+	d := &tokenDeployer{lifetime: 300 * time.Millisecond}
+	token := newSelfToken(ctx, d, logging.StderrLogger(logging.Options{}))
+
+	get := func(want string) {
+		t.Helper()
+		got, err := token.get(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != want {
+			t.Fatalf("got token %q, want %q", got, want)
+		}
+	}
+
+	// The token is fetched once, and then cached.
+	get("token1")
+	get("token1")
+	if issued, _ := d.counts(); issued != 1 {
+		t.Fatalf("got %d issued tokens, want 1", issued)
+	}
+
+	// Past two thirds of its lifetime, the token is still used while a new
+	// one is fetched in the background.
+	time.Sleep(250 * time.Millisecond)
+	get("token1")
+	for {
+		if issued, _ := d.counts(); issued == 2 {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	get("token2")
+
+	// An expired token is replaced right away.
+	time.Sleep(350 * time.Millisecond)
+	get("token3")
+}
+
+func TestSelfTokenRefreshDoesNotBlock(t *testing.T) {
+	ctx := context.Background()
+	d := &tokenDeployer{lifetime: time.Second}
+	token := newSelfToken(ctx, d, logging.StderrLogger(logging.Options{}))
+	if _, err := token.get(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	// While a refresh is stuck in the deployer, calls keep getting the
+	// current token, and no other refresh starts.
+	d.block = make(chan struct{})
+	time.Sleep(700 * time.Millisecond)
+	for i := 0; i < 10; i++ {
+		got, err := token.get(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != "token1" {
+			t.Fatalf("got token %q, want %q", got, "token1")
+		}
+	}
+	close(d.block)
+	for {
+		if got, _ := token.get(ctx); got == "token2" {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if issued, _ := d.counts(); issued != 2 {
+		t.Fatalf("got %d issued tokens, want 2", issued)
+	}
+}
+
+func TestTokenVerifier(t *testing.T) {
+	ctx := context.Background()
+	d := &tokenDeployer{lifetime: 200 * time.Millisecond}
+	v := newTokenVerifier(d)
+
+	for _, test := range []struct {
+		token     string
+		component string
+		ok        bool
+	}{
+		{"good", "A", true},
+		{"good", "A", true},
+		{"good", "B", false},
+		{"bad", "A", false},
+		{"", "A", false},
+	} {
+		callers, err := v.verify(ctx, test.token, test.component)
+		if got, want := err == nil, test.ok; got != want {
+			t.Errorf("verify(%q, %q): got %v, want ok=%v", test.token, test.component, err, want)
+		}
+		if err == nil && !reflect.DeepEqual(callers, map[string]bool{"B": true}) {
+			t.Errorf("verify(%q, %q): got callers %v, want [B]", test.token, test.component, callers)
+		}
+	}
+
+	// The good token is verified once, and the empty token never.
+	if _, verified := d.counts(); verified != 2 {
+		t.Errorf("got %d verified tokens, want 2", verified)
+	}
+
+	// Expired tokens are verified again.
+	time.Sleep(250 * time.Millisecond)
+	if _, err := v.verify(ctx, "good", "A"); err != nil {
+		t.Fatal(err)
+	}
+	if _, verified := d.counts(); verified != 3 {
+		t.Errorf("got %d verified tokens, want 3", verified)
+	}
+}
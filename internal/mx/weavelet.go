@@ -15,7 +15,12 @@
 package mx
 
 import (
+	"log/slog"
 	"reflect"
+
+	"github.com/sh3lk/mx/internal/control"
+	"github.com/sh3lk/mx/runtime/codegen"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // A MXN is an agent that hosts a set of components.
@@ -31,3 +36,43 @@ type MXN interface {
 	// returns an instance of type *foo.
 	GetImpl(t reflect.Type) (any, error)
 }
+
+// intercepted returns whether calls to the provided component should run
+// through the registered interceptors. Calls to the control components used to
+// talk to deployers are never intercepted.
+func intercepted(reg *codegen.Registration) bool {
+	return reg.Name != control.DeployerPath && reg.Name != control.MXNPath
+}
+
+// localStub returns a local stub for the provided component implementation
+// that enforces the method directives of the component and runs the registered
+// client and server interceptors. Calls to deprecated methods are logged to
+// logger.
+func localStub(reg *codegen.Registration, impl any, requester string, tracer trace.Tracer, logger *slog.Logger) any {
+	if !intercepted(reg) {
+		return reg.LocalStubFn(impl, requester, tracer)
+	}
+	stub := reg.LocalStubFn(codegen.InterceptServer(reg, impl), requester, tracer)
+	stub = codegen.ApplyDirectives(reg, requester, stub, logger)
+	return codegen.InterceptClient(reg, requester, stub)
+}
+
+// clientStub returns a client stub for the provided component that enforces
+// the method directives of the component and runs the registered client
+// interceptors. Calls to deprecated methods are logged to logger.
+func clientStub(reg *codegen.Registration, stub codegen.Stub, requester string, logger *slog.Logger) any {
+	if !intercepted(reg) {
+		return reg.ClientStubFn(stub, requester)
+	}
+	client := codegen.ApplyDirectives(reg, requester, reg.ClientStubFn(stub, requester), logger)
+	return codegen.InterceptClient(reg, requester, client)
+}
+
+// serverStub returns a server stub for the provided component implementation
+// that runs the registered server interceptors.
+func serverStub(reg *codegen.Registration, impl any, load func(key uint64, load float64)) codegen.Server {
+	if !intercepted(reg) {
+		return reg.ServerStubFn(impl, load)
+	}
+	return reg.ServerStubFn(codegen.InterceptServer(reg, impl), load)
+}
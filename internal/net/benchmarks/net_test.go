@@ -21,6 +21,7 @@ import (
 	"crypto/x509"
 	"flag"
 	"fmt"
+	"io"
 	"log"
 	"net"
 	"os"
@@ -37,7 +38,7 @@ import (
 
 var (
 	subproccess           = flag.Bool("subprocess", false, "Is this a subprocess?")
-	network               = flag.String("network", "", "Network (e.g., tcp, unix, mtls)")
+	network               = flag.String("network", "", "Network (e.g., tcp, unix, mtls, shm)")
 	address               = flag.String("address", "", "Server address")
 	inlineHandlerDuration = flag.Duration("inline_handler_duration", -1, "ServerOptions.InlineHandlerDuration")
 	writeFlattenLimit     = flag.Int("write_flatten_limit", -1, "ServerOptions.WriteFlattenLimit")
@@ -135,7 +136,7 @@ func TestMain(m *testing.M) {
 
 // config configures a benchmark.
 type config struct {
-	network                string        // e.g., tcp, unix, mtls
+	network                string        // e.g., tcp, unix, mtls, shm
 	addr                   string        // e.g., localhost:12345, /tmp/socket
 	optimisticSpinDuration time.Duration // call.ClientOptions
 	inlineHandlerDuration  time.Duration // call.ServerOptions
@@ -174,6 +175,12 @@ func (c config) listen() call.Listener {
 			panic(err)
 		}
 		return testListener{Listener: l, tlsConfig: tlsConfig}
+	case "shm":
+		l, err := call.ListenSharedMemory(c.addr)
+		if err != nil {
+			panic(err)
+		}
+		return testListener{Listener: l}
 	default:
 		panic(fmt.Sprintf("invalid network: %s", c.network))
 	}
@@ -188,6 +195,8 @@ func (c config) endpoint() call.Endpoint {
 		return call.Unix(c.addr)
 	case "mtls":
 		return call.MTLS(tlsConfig, call.TCP(c.addr))
+	case "shm":
+		return call.SharedMemory(c.addr)
 	default:
 		panic(fmt.Sprintf("invalid network: %s", c.network))
 	}
@@ -212,12 +221,12 @@ func (c config) serverOpts() call.ServerOptions {
 // configs returns a set of configs.
 func configs(b testing.TB) []config {
 	var configs []config
-	for _, network := range []string{"tcp", "unix", "mtls"} {
+	for _, network := range []string{"tcp", "unix", "mtls", "shm"} {
 		for _, spin := range []time.Duration{0, 20 * time.Microsecond} {
 			for _, inline := range []time.Duration{-1, 20 * time.Microsecond} {
 				for _, flatten := range []int{-1, 1 << 10, 4 << 10} {
 					addr := "localhost:12345"
-					if network == "unix" {
+					if network == "unix" || network == "shm" {
 						addr = filepath.Join(b.TempDir(), "socket")
 					}
 					configs = append(configs, config{
@@ -389,6 +398,61 @@ func BenchmarkMultiprocRPC(b *testing.B) {
 	}
 }
 
+// BenchmarkConn benchmarks echoing bytes over a connection, without the RPC
+// protocol on top, to compare the cost of the transports themselves.
+func BenchmarkConn(b *testing.B) {
+	for _, network := range []string{"unix", "shm"} {
+		b.Run(network, func(b *testing.B) {
+			addr := filepath.Join(b.TempDir(), "socket")
+			var lis net.Listener
+			var err error
+			var endpoint call.Endpoint
+			switch network {
+			case "unix":
+				lis, err = net.Listen("unix", addr)
+				endpoint = call.Unix(addr)
+			case "shm":
+				lis, err = call.ListenSharedMemory(addr)
+				endpoint = call.SharedMemory(addr)
+			}
+			if err != nil {
+				b.Fatal(err)
+			}
+			defer lis.Close()
+
+			// Start the echo server.
+			go func() {
+				conn, err := lis.Accept()
+				if err != nil {
+					return
+				}
+				defer conn.Close()
+				io.Copy(conn, conn)
+			}()
+
+			conn, err := endpoint.Dial(context.Background())
+			if err != nil {
+				b.Fatal(err)
+			}
+			defer conn.Close()
+			for _, size := range []int{1, 1024, 100 * 1024, 1024 * 1024} {
+				b.Run(fmt.Sprintf("Msg-%s", sizeString(size)), func(b *testing.B) {
+					msg := make([]byte, size)
+					buf := make([]byte, size)
+					b.SetBytes(int64(size))
+					b.ResetTimer()
+					for i := 0; i < b.N; i++ {
+						go conn.Write(msg)
+						if _, err := io.ReadFull(conn, buf); err != nil {
+							b.Fatal(err)
+						}
+					}
+				})
+			}
+		})
+	}
+}
+
 // connEndpoint is an endpoint that always returns the provided net.Conn.
 type connEndpoint struct {
 	name string
@@ -0,0 +1,373 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package call
+
+import (
+	"context"
+	"math"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sh3lk/mx/metrics"
+	"github.com/sh3lk/mx/priority"
+)
+
+// # Admission control
+//
+// A server limits the number of calls to a method that run concurrently.
+// Calls that arrive when the limit is reached wait in a queue, and calls that
+// arrive when the queue is full are rejected with a responseOverloaded
+// message, without being run. The client returns an Overloaded error for
+// rejected calls, and retries them, possibly on another replica.
+//
+// The limit is either fixed or adaptive. An adaptive limit follows the
+// gradient of the latency of the method: it grows while the latency of the
+// method stays close to its long term average, and shrinks when the latency
+// rises above it, which is a sign that the calls are queueing up somewhere.
+// This is similar to the gradient algorithm of Netflix's concurrency-limits
+// library.
+//
+// Calls are prioritized by their priority class (see the priority package).
+// Waiting calls run in priority order, and a call that arrives when the queue
+// is full takes the place of a waiting call with a lower priority, if any,
+// which is rejected instead. Lower priority calls also only get a share of the
+// limit (see classShares), so that they are shed first as the load rises.
+
+const (
+	// Initial and maximum adaptive concurrency limits.
+	initialAdaptiveLimit    = 20
+	defaultMaxAdaptiveLimit = 1000
+
+	// Weights of a new latency in the short and long term averages of the
+	// latency of a method.
+	shortLatencyWeight = 0.2
+	longLatencyWeight  = 0.01
+
+	// The latency of a method may rise up to this factor above its long
+	// term average before an adaptive limit shrinks.
+	latencyTolerance = 1.5
+
+	// Weight of a new adaptive limit in the smoothed limit.
+	limitSmoothing = 0.2
+)
+
+// classShares holds the share of the concurrency limit that the calls of
+// every priority class may use.
+var classShares = [priority.NumClasses]float64{
+	priority.Interactive: 1,
+	priority.Batch:       0.9,
+	priority.BestEffort:  0.75,
+}
+
+// AdmissionOptions configure the admission control of the calls to a method
+// by a server.
+//
+// At most MaxConcurrency calls to the method run concurrently. Calls that
+// arrive when MaxConcurrency calls are running wait in a queue of at most
+// MaxQueue calls, and calls that arrive when the queue is full are rejected.
+// The client of a rejected call receives an Overloaded error. Calls with a
+// lower priority class are rejected first. Streaming calls are never
+// rejected.
+type AdmissionOptions struct {
+	// Maximum number of concurrent calls. If Adaptive is true, this is the
+	// maximum of the adaptive limit, and defaults to 1000. Otherwise, if
+	// zero, the number of concurrent calls is unlimited.
+	MaxConcurrency int
+
+	// Maximum number of calls waiting for other calls to finish. If zero,
+	// calls that can't run immediately are rejected.
+	MaxQueue int
+
+	// If true, the limit on the number of concurrent calls adapts to the
+	// latency of the calls.
+	Adaptive bool
+}
+
+var (
+	admissionRejections = metrics.NewCounterMap[admissionRejectionLabels](
+		"mx_system_call_admission_rejected_count",
+		"Count of MX RPC calls rejected by the server because it was overloaded",
+	)
+	admissionLimit = metrics.NewGaugeMap[admissionLabels](
+		"mx_system_call_admission_limit",
+		"Maximum number of concurrent MX RPC calls to a method",
+	)
+	admissionQueued = metrics.NewGaugeMap[admissionLabels](
+		"mx_system_call_admission_queued",
+		"Number of MX RPC calls to a method waiting for other calls to finish",
+	)
+)
+
+type admissionLabels struct {
+	Component string // full component name
+	Method    string // method name
+
+	// Is this a metric implicitly created by the framework?
+	Generated bool `mx:"mx_generated"`
+}
+
+type admissionRejectionLabels struct {
+	Component string // full component name
+	Method    string // method name
+	Priority  string // priority class (e.g., "batch")
+
+	// Is this a metric implicitly created by the framework?
+	Generated bool `mx:"mx_generated"`
+}
+
+// limiters holds the limiters of the methods served by a server.
+type limiters struct {
+	admission func(component, method string) AdmissionOptions // see ServerOptions.Admission
+
+	mu sync.Mutex
+	m  map[MethodKey]*limiter // nil values for methods without limits
+}
+
+// acquire waits until a call to the provided method may run. On success, it
+// returns a function that must be called with the latency of the call when
+// the call ends. It returns Overloaded if the call is rejected, and the
+// context's error if the context is done before the call may run. name is the
+// full name of the method (e.g., "example.com/mypkg/Cache.Get").
+func (ls *limiters) acquire(ctx context.Context, h MethodKey, name string) (func(time.Duration), error) {
+	return ls.get(h, name).acquire(ctx)
+}
+
+// get returns the limiter of the provided method, or nil if calls to the
+// method are not limited.
+func (ls *limiters) get(h MethodKey, name string) *limiter {
+	if ls == nil || ls.admission == nil {
+		return nil
+	}
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+	l, ok := ls.m[h]
+	if !ok {
+		i := strings.LastIndexByte(name, '.')
+		if i >= 0 {
+			component, method := name[:i], name[i+1:]
+			l = newLimiter(ls.admission(component, method), component, method)
+		}
+		if ls.m == nil {
+			ls.m = map[MethodKey]*limiter{}
+		}
+		ls.m[h] = l
+	}
+	return l
+}
+
+// limiter limits the number of concurrent calls to a method. See
+// AdmissionOptions. A nil limiter doesn't limit calls.
+type limiter struct {
+	maxQueue int
+	adaptive bool
+	maxLimit float64
+
+	rejected [priority.NumClasses]*metrics.Counter
+	limitG   *metrics.Gauge
+	queuedG  *metrics.Gauge
+
+	mu           sync.Mutex
+	limit        float64                        // maximum number of concurrent calls
+	running      int                            // number of running calls
+	queue        [priority.NumClasses][]*waiter // calls waiting to run, by class, oldest first
+	queued       int                            // number of waiting calls
+	shortLatency float64                        // short term average latency, in nanoseconds
+	longLatency  float64                        // long term average latency, in nanoseconds
+}
+
+// waiter is a call waiting in the queue of a limiter.
+type waiter struct {
+	ready    chan struct{} // closed when the call may run or is rejected
+	admitted bool          // may the call run? Guarded by limiter.mu
+}
+
+// newLimiter returns a new limiter, or nil if opts don't limit calls.
+func newLimiter(opts AdmissionOptions, component, method string) *limiter {
+	if opts.MaxConcurrency <= 0 && !opts.Adaptive {
+		return nil
+	}
+	labels := admissionLabels{Component: component, Method: method, Generated: true}
+	l := &limiter{
+		maxQueue: opts.MaxQueue,
+		adaptive: opts.Adaptive,
+		maxLimit: float64(opts.MaxConcurrency),
+		limitG:   admissionLimit.Get(labels),
+		queuedG:  admissionQueued.Get(labels),
+		limit:    float64(opts.MaxConcurrency),
+	}
+	for c := range l.rejected {
+		l.rejected[c] = admissionRejections.Get(admissionRejectionLabels{
+			Component: component,
+			Method:    method,
+			Priority:  priority.Class(c).String(),
+			Generated: true,
+		})
+	}
+	if opts.Adaptive {
+		if l.maxLimit <= 0 {
+			l.maxLimit = defaultMaxAdaptiveLimit
+		}
+		l.limit = min(initialAdaptiveLimit, l.maxLimit)
+	}
+	l.limitG.Set(l.limit)
+	return l
+}
+
+// acquire waits until a call may run. See limiters.acquire.
+func (l *limiter) acquire(ctx context.Context) (func(time.Duration), error) {
+	if l == nil {
+		return func(time.Duration) {}, nil
+	}
+	class := priority.FromContext(ctx)
+	l.mu.Lock()
+	if l.fits(class) && !l.waiting(class) {
+		l.running++
+		l.mu.Unlock()
+		return l.release, nil
+	}
+	if l.queued >= l.maxQueue {
+		// Reject the newest waiting call with a lower priority, if any, or
+		// this call otherwise.
+		victim := -1
+		for c := priority.NumClasses - 1; c > int(class); c-- {
+			if len(l.queue[c]) > 0 {
+				victim = c
+				break
+			}
+		}
+		if victim < 0 {
+			l.mu.Unlock()
+			l.rejected[class].Inc()
+			return nil, Overloaded
+		}
+		n := len(l.queue[victim])
+		close(l.queue[victim][n-1].ready)
+		l.queue[victim] = l.queue[victim][:n-1]
+		l.queued--
+	}
+	w := &waiter{ready: make(chan struct{})}
+	l.queue[class] = append(l.queue[class], w)
+	l.queued++
+	l.queuedG.Set(float64(l.queued))
+	l.mu.Unlock()
+
+	select {
+	case <-w.ready:
+		l.mu.Lock()
+		admitted := w.admitted
+		l.mu.Unlock()
+		if !admitted {
+			l.rejected[class].Inc()
+			return nil, Overloaded
+		}
+		return l.release, nil
+	case <-ctx.Done():
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		if w.admitted {
+			// The call was admitted concurrently. Let another one run.
+			l.running--
+			l.admit()
+		} else if i := slices.Index(l.queue[class], w); i >= 0 {
+			l.queue[class] = slices.Delete(l.queue[class], i, i+1)
+			l.queued--
+			l.queuedG.Set(float64(l.queued))
+		}
+		return nil, ctx.Err()
+	}
+}
+
+// fits returns whether a call of the provided class may run without
+// exceeding the share of the limit of its class.
+// REQUIRES: l.mu is held.
+func (l *limiter) fits(class priority.Class) bool {
+	return l.running < max(1, int(l.limit*classShares[class]))
+}
+
+// waiting returns whether a call of the provided class or of a higher
+// priority class is waiting to run.
+// REQUIRES: l.mu is held.
+func (l *limiter) waiting(class priority.Class) bool {
+	for c := 0; c <= int(class); c++ {
+		if len(l.queue[c]) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// release records the end of a call with the provided latency.
+func (l *limiter) release(latency time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.adaptive {
+		l.update(latency)
+	}
+	l.running--
+	l.admit()
+}
+
+// admit lets the waiting calls run, highest priority and oldest first, up to
+// the limit.
+// REQUIRES: l.mu is held.
+func (l *limiter) admit() {
+	for c := range l.queue {
+		class := priority.Class(c)
+		for len(l.queue[c]) > 0 && l.fits(class) {
+			w := l.queue[c][0]
+			l.queue[c] = l.queue[c][1:]
+			l.queued--
+			w.admitted = true
+			l.running++
+			close(w.ready)
+		}
+		if len(l.queue[c]) > 0 {
+			// Don't let lower priority calls overtake this one.
+			break
+		}
+	}
+	l.queuedG.Set(float64(l.queued))
+}
+
+// update updates the adaptive limit with the latency of a call.
+// REQUIRES: l.mu is held.
+func (l *limiter) update(latency time.Duration) {
+	sample := max(float64(latency), 1)
+	if l.longLatency == 0 {
+		l.shortLatency, l.longLatency = sample, sample
+	} else {
+		l.shortLatency += shortLatencyWeight * (sample - l.shortLatency)
+		l.longLatency += longLatencyWeight * (sample - l.longLatency)
+	}
+	if l.longLatency > 2*l.shortLatency {
+		// The latency dropped well below its long term average, e.g.,
+		// because the load dropped. Let the average catch up.
+		l.longLatency *= 0.95
+	}
+	if float64(l.running) < l.limit/2 {
+		// The limit isn't reached, so there is no point in raising it.
+		return
+	}
+
+	// The gradient is 1 if the latency is within the tolerance of its long
+	// term average, and lower if it is above it. The square root of the
+	// limit lets the limit grow while the gradient is 1.
+	gradient := max(0.5, min(1, latencyTolerance*l.longLatency/l.shortLatency))
+	target := l.limit*gradient + math.Sqrt(l.limit)
+	l.limit = max(1, min(l.maxLimit, l.limit+limitSmoothing*(target-l.limit)))
+	l.limitG.Set(l.limit)
+}
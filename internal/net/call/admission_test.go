@@ -0,0 +1,204 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package call
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sh3lk/mx/priority"
+)
+
+func TestLimiterQueue(t *testing.T) {
+	ctx := context.Background()
+	l := newLimiter(AdmissionOptions{MaxConcurrency: 1, MaxQueue: 1}, "TestLimiterQueue", "m")
+
+	// The first call runs.
+	release, err := l.acquire(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The second call waits.
+	admitted := make(chan func(time.Duration))
+	go func() {
+		release, err := l.acquire(ctx)
+		if err != nil {
+			t.Error(err)
+		}
+		admitted <- release
+	}()
+	waitFor(t, func() bool {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		return l.queued == 1
+	})
+
+	// The third call is rejected.
+	if _, err := l.acquire(ctx); !errors.Is(err, Overloaded) {
+		t.Fatalf("got %v, want %v", err, Overloaded)
+	}
+
+	// The second call runs when the first call ends.
+	release(time.Millisecond)
+	(<-admitted)(time.Millisecond)
+	if l.running != 0 || l.queued != 0 {
+		t.Fatalf("got %d running and %d queued calls, want none", l.running, l.queued)
+	}
+}
+
+func TestLimiterCanceledWhileQueued(t *testing.T) {
+	l := newLimiter(AdmissionOptions{MaxConcurrency: 1, MaxQueue: 1}, "TestLimiterCanceledWhileQueued", "m")
+	release, err := l.acquire(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	if _, err := l.acquire(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got %v, want %v", err, context.DeadlineExceeded)
+	}
+	if l.queued != 0 {
+		t.Fatalf("got %d queued calls, want none", l.queued)
+	}
+	release(time.Millisecond)
+}
+
+func TestNilLimiter(t *testing.T) {
+	l := newLimiter(AdmissionOptions{MaxQueue: 10}, "TestNilLimiter", "m")
+	if l != nil {
+		t.Fatal("unexpected limiter")
+	}
+	for i := 0; i < 100; i++ {
+		if _, err := l.acquire(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestAdaptiveLimit(t *testing.T) {
+	l := newLimiter(AdmissionOptions{Adaptive: true, MaxConcurrency: 100}, "TestAdaptiveLimit", "m")
+
+	// run runs n rounds of calls that use the whole limit, with the provided
+	// latency.
+	run := func(n int, latency time.Duration) {
+		for i := 0; i < n; i++ {
+			var releases []func(time.Duration)
+			for j := 0; j < int(l.limit); j++ {
+				release, err := l.acquire(context.Background())
+				if err != nil {
+					t.Fatal(err)
+				}
+				releases = append(releases, release)
+			}
+			for _, release := range releases {
+				release(latency)
+			}
+		}
+	}
+
+	// The limit grows while the latency is stable, up to the maximum.
+	run(100, time.Millisecond)
+	if got, want := l.limit, 100.0; got != want {
+		t.Fatalf("limit: got %v, want %v", got, want)
+	}
+
+	// The limit shrinks when the latency rises.
+	run(1, 10*time.Millisecond)
+	if l.limit >= 50 {
+		t.Fatalf("limit: got %v, want < 50", l.limit)
+	}
+}
+
+// waitFor waits until f returns true.
+func waitFor(t *testing.T, f func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(10 * time.Second)
+	for !f() {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestLimiterPriority(t *testing.T) {
+	ctx := context.Background()
+	batch := priority.NewContext(ctx, priority.Batch)
+	bestEffort := priority.NewContext(ctx, priority.BestEffort)
+	l := newLimiter(AdmissionOptions{MaxConcurrency: 1, MaxQueue: 1}, "TestLimiterPriority", "m")
+
+	// acquire acquires the limiter in the background.
+	acquire := func(ctx context.Context) chan error {
+		errs := make(chan error, 1)
+		go func() {
+			release, err := l.acquire(ctx)
+			if err == nil {
+				release(time.Millisecond)
+			}
+			errs <- err
+		}()
+		return errs
+	}
+
+	release, err := l.acquire(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A batch call waits.
+	batchErr := acquire(batch)
+	waitFor(t, func() bool {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		return l.queued == 1
+	})
+
+	// A best-effort call is rejected, since the queue is full.
+	if _, err := l.acquire(bestEffort); !errors.Is(err, Overloaded) {
+		t.Fatalf("best-effort call: got %v, want %v", err, Overloaded)
+	}
+
+	// An interactive call takes the place of the batch call.
+	interactiveErr := acquire(ctx)
+	if err := <-batchErr; !errors.Is(err, Overloaded) {
+		t.Fatalf("batch call: got %v, want %v", err, Overloaded)
+	}
+	release(time.Millisecond)
+	if err := <-interactiveErr; err != nil {
+		t.Fatalf("interactive call: %v", err)
+	}
+}
+
+func TestLimiterShares(t *testing.T) {
+	ctx := context.Background()
+	bestEffort := priority.NewContext(ctx, priority.BestEffort)
+	l := newLimiter(AdmissionOptions{MaxConcurrency: 4}, "TestLimiterShares", "m")
+
+	// Best-effort calls may only use three quarters of the limit.
+	for i := 0; i < 3; i++ {
+		if _, err := l.acquire(bestEffort); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := l.acquire(bestEffort); !errors.Is(err, Overloaded) {
+		t.Fatalf("best-effort call: got %v, want %v", err, Overloaded)
+	}
+	if _, err := l.acquire(ctx); err != nil {
+		t.Fatalf("interactive call: %v", err)
+	}
+}
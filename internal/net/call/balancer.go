@@ -14,21 +14,31 @@
 
 package call
 
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+)
+
 // ReplicaConnection is a connection to a single replica. A single Connection
 // may consist of many ReplicaConnections (typically one per replica).
 type ReplicaConnection interface {
 	// Address returns the name of the endpoint to which the ReplicaConnection
 	// is connected.
 	Address() string
+
+	// Outstanding returns the number of calls in progress on the
+	// ReplicaConnection. It may only be called by the Balancer that manages
+	// the ReplicaConnection, from one of the Balancer's methods.
+	Outstanding() int
 }
 
 // Balancer manages a set of ReplicaConnections and picks one of them per
 // call. A Balancer requires external synchronization (no concurrent calls
 // should be made to the same Balancer).
-//
-// TODO(mwhittaker): Right now, balancers have no load information about
-// endpoints. In the short term, we can at least add information about the
-// number of pending requests for every endpoint.
 type Balancer interface {
 	// Add adds a ReplicaConnection to the set of connections.
 	Add(ReplicaConnection)
@@ -41,6 +51,34 @@ type Balancer interface {
 	Pick(CallOptions) (ReplicaConnection, bool)
 }
 
+// CallTracker is a Balancer that is informed of the outcome of the calls made
+// on the ReplicaConnections it manages, e.g., to track their latency or to
+// stop picking replicas that keep failing.
+type CallTracker interface {
+	Balancer
+
+	// Done is called when a call made on the provided ReplicaConnection
+	// ends, with the latency and the error of the call. The connection may
+	// have been removed from the Balancer while the call was in progress.
+	Done(c ReplicaConnection, latency time.Duration, err error)
+}
+
+// NewBalancer returns the balancer with the provided name: "round_robin"
+// (see RoundRobin), "least_loaded" (see LeastLoaded), or "p2c" (see
+// PowerOfTwoChoices). An empty name is equivalent to "round_robin".
+func NewBalancer(name string) (Balancer, error) {
+	switch name {
+	case "", "round_robin":
+		return RoundRobin(), nil
+	case "least_loaded":
+		return LeastLoaded(), nil
+	case "p2c":
+		return PowerOfTwoChoices(), nil
+	default:
+		return nil, fmt.Errorf("unknown balancer %q", name)
+	}
+}
+
 // balancerFuncImpl is the implementation of the "functional" balancer
 // returned by BalancerFunc.
 type balancerFuncImpl struct {
@@ -84,6 +122,97 @@ func (rr *roundRobin) Pick(CallOptions) (ReplicaConnection, bool) {
 	return c, true
 }
 
+type leastLoaded struct {
+	replicaSet
+}
+
+var _ CallTracker = &leastLoaded{}
+
+// LeastLoaded returns a balancer that picks the replica with the fewest
+// outstanding calls, breaking ties randomly. Replicas that keep failing are
+// temporarily ejected (see replicaSet).
+func LeastLoaded() *leastLoaded {
+	return &leastLoaded{replicaSet: newReplicaSet()}
+}
+
+func (ll *leastLoaded) Pick(CallOptions) (ReplicaConnection, bool) {
+	n := len(ll.list)
+	if n == 0 {
+		return nil, false
+	}
+
+	// Scan the replicas starting at a random offset to break ties randomly.
+	now := ll.now()
+	offset := rand.Intn(n)
+	var best *replica
+	var bestLoad int
+	for i := 0; i < n; i++ {
+		r := ll.list[(offset+i)%n]
+		if r.ejected(now) {
+			continue
+		}
+		if load := r.conn.Outstanding(); best == nil || load < bestLoad {
+			best, bestLoad = r, load
+		}
+	}
+	if best == nil {
+		// Every replica is ejected. This is only possible if the healthy
+		// replicas were removed after the others were ejected.
+		best = ll.list[offset]
+	}
+	return best.conn, true
+}
+
+type powerOfTwoChoices struct {
+	replicaSet
+}
+
+var _ CallTracker = &powerOfTwoChoices{}
+
+// PowerOfTwoChoices returns a balancer that picks two random replicas and
+// sends the call to the one with the lower expected cost, where the cost of
+// a replica is the exponentially weighted moving average (EWMA) of its call
+// latencies, multiplied by its number of outstanding calls. Replicas that
+// keep failing are temporarily ejected (see replicaSet).
+func PowerOfTwoChoices() *powerOfTwoChoices {
+	return &powerOfTwoChoices{replicaSet: newReplicaSet()}
+}
+
+func (p *powerOfTwoChoices) Pick(CallOptions) (ReplicaConnection, bool) {
+	n := len(p.list)
+	switch n {
+	case 0:
+		return nil, false
+	case 1:
+		return p.list[0].conn, true
+	}
+
+	// Pick two distinct replicas. If both of them are ejected, try again a
+	// few times, before settling on the cheapest ejected replica.
+	now := p.now()
+	var a, b *replica
+	for attempt := 0; attempt < 3; attempt++ {
+		i, j := rand.Intn(n), rand.Intn(n-1)
+		if j >= i {
+			j++
+		}
+		a, b = p.list[i], p.list[j]
+		if !a.ejected(now) || !b.ejected(now) {
+			break
+		}
+	}
+	switch {
+	case a.ejected(now) && !b.ejected(now):
+		return b.conn, true
+	case b.ejected(now) && !a.ejected(now):
+		return a.conn, true
+	case a.cost() <= b.cost():
+		return a.conn, true
+	default:
+		return b.conn, true
+	}
+}
+
 // connList is a helper type used by balancers to maintain set of connections.
 type connList struct {
 	list []ReplicaConnection
@@ -104,3 +233,145 @@ func (cl *connList) Remove(c ReplicaConnection) {
 		return
 	}
 }
+
+const (
+	// A replica is ejected after this many consecutive failed calls.
+	ejectionFailures = 5
+
+	// A replica ejected for the n-th time in a row is ejected for n times
+	// baseEjectionTime, up to maxEjectionTime.
+	baseEjectionTime = 10 * time.Second
+	maxEjectionTime  = 5 * time.Minute
+
+	// At most this fraction of the replicas are ejected at any given time.
+	maxEjectionFraction = 0.5
+
+	// Time it takes for the weight of a latency sample in the EWMA latency of
+	// a replica to decay by a factor of e.
+	latencyDecay = 10 * time.Second
+)
+
+// replica holds the information a balancer keeps about a ReplicaConnection.
+type replica struct {
+	conn         ReplicaConnection
+	latency      float64   // EWMA of call latencies, in nanoseconds
+	updated      time.Time // when latency was last updated
+	failures     int       // number of consecutive failed calls
+	ejections    int       // number of consecutive ejections
+	ejectedUntil time.Time // the replica is ejected until this time
+}
+
+// ejected returns whether the replica is ejected at the provided time.
+func (r *replica) ejected(now time.Time) bool {
+	return now.Before(r.ejectedUntil)
+}
+
+// cost returns the expected cost of sending a call to the replica.
+func (r *replica) cost() float64 {
+	// Add a microsecond to the latency, so that outstanding calls are
+	// accounted for even if the latency is unknown.
+	return (r.latency + float64(time.Microsecond)) * float64(r.conn.Outstanding()+1)
+}
+
+// replicaSet is a helper type used by balancers to maintain a set of replicas,
+// along with their latency and health.
+//
+// A replicaSet detects outliers: a replica with ejectionFailures consecutive
+// failed calls is ejected, i.e., balancers stop picking it for some time. A
+// call fails if it returns a CommunicationError, Unreachable, or a deadline
+// exceeded error. Errors returned by the application are not failures.
+type replicaSet struct {
+	now      func() time.Time // returns the current time; replaced in tests
+	list     []*replica
+	replicas map[ReplicaConnection]*replica
+}
+
+func newReplicaSet() replicaSet {
+	return replicaSet{now: time.Now, replicas: map[ReplicaConnection]*replica{}}
+}
+
+func (s *replicaSet) Add(c ReplicaConnection) {
+	r := &replica{conn: c}
+
+	// Start with the average latency of the other replicas, so that a new
+	// replica is neither flooded with nor starved of calls.
+	var sum float64
+	for _, other := range s.list {
+		sum += other.latency
+	}
+	if len(s.list) > 0 {
+		r.latency = sum / float64(len(s.list))
+	}
+
+	s.list = append(s.list, r)
+	s.replicas[c] = r
+}
+
+func (s *replicaSet) Remove(c ReplicaConnection) {
+	if _, ok := s.replicas[c]; !ok {
+		return
+	}
+	delete(s.replicas, c)
+	for i, r := range s.list {
+		if r.conn != c {
+			continue
+		}
+		// Replace removed entry with last entry.
+		s.list[i] = s.list[len(s.list)-1]
+		s.list = s.list[:len(s.list)-1]
+		return
+	}
+}
+
+func (s *replicaSet) Done(c ReplicaConnection, latency time.Duration, err error) {
+	r, ok := s.replicas[c]
+	if !ok {
+		return // c was removed
+	}
+	now := s.now()
+
+	// Update the latency.
+	if r.updated.IsZero() {
+		r.latency = float64(latency)
+	} else {
+		w := math.Exp(-float64(now.Sub(r.updated)) / float64(latencyDecay))
+		r.latency = r.latency*w + float64(latency)*(1-w)
+	}
+	r.updated = now
+
+	// Update the health.
+	if !failed(err) {
+		r.failures = 0
+		if !r.ejected(now) {
+			r.ejections = 0
+		}
+		return
+	}
+	r.failures++
+	if r.failures < ejectionFailures || r.ejected(now) {
+		return
+	}
+	ejected := 1
+	for _, other := range s.list {
+		if other.ejected(now) {
+			ejected++
+		}
+	}
+	if float64(ejected) > maxEjectionFraction*float64(len(s.list)) {
+		return
+	}
+	r.failures = 0
+	r.ejections++
+	d := time.Duration(r.ejections) * baseEjectionTime
+	if d > maxEjectionTime {
+		d = maxEjectionTime
+	}
+	r.ejectedUntil = now.Add(d)
+}
+
+// failed returns whether err indicates that a replica failed to process a call.
+func failed(err error) bool {
+	return errors.Is(err, CommunicationError) ||
+		errors.Is(err, Unreachable) ||
+		errors.Is(err, context.DeadlineExceeded)
+}
@@ -0,0 +1,229 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package call
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+)
+
+// fakeReplica is a fake ReplicaConnection with a fixed number of outstanding
+// calls.
+type fakeReplica struct {
+	addr        string
+	outstanding int
+}
+
+func (f *fakeReplica) Address() string  { return f.addr }
+func (f *fakeReplica) Outstanding() int { return f.outstanding }
+
+// fakeClock is a fake clock, for use as replicaSet.now.
+type fakeClock struct {
+	t time.Time
+}
+
+func (c *fakeClock) now() time.Time          { return c.t }
+func (c *fakeClock) advance(d time.Duration) { c.t = c.t.Add(d) }
+
+// replicas returns n fake replicas.
+func replicas(n int) []*fakeReplica {
+	var rs []*fakeReplica
+	for i := 0; i < n; i++ {
+		rs = append(rs, &fakeReplica{addr: fmt.Sprintf("tcp://replica%d", i)})
+	}
+	return rs
+}
+
+// picks returns the number of times every replica is picked by b in n picks.
+func picks(t *testing.T, b Balancer, n int) map[string]int {
+	t.Helper()
+	counts := map[string]int{}
+	for i := 0; i < n; i++ {
+		c, ok := b.Pick(CallOptions{})
+		if !ok {
+			t.Fatal("no replica picked")
+		}
+		counts[c.Address()]++
+	}
+	return counts
+}
+
+func TestNewBalancer(t *testing.T) {
+	for _, name := range []string{"", "round_robin", "least_loaded", "p2c"} {
+		if _, err := NewBalancer(name); err != nil {
+			t.Errorf("NewBalancer(%q): %v", name, err)
+		}
+	}
+	if _, err := NewBalancer("random"); err == nil {
+		t.Error(`NewBalancer("random"): unexpected success`)
+	}
+}
+
+func TestEmptyBalancers(t *testing.T) {
+	for _, b := range []Balancer{LeastLoaded(), PowerOfTwoChoices()} {
+		if _, ok := b.Pick(CallOptions{}); ok {
+			t.Errorf("%T: unexpected pick with no replicas", b)
+		}
+	}
+}
+
+func TestLeastLoaded(t *testing.T) {
+	rs := replicas(3)
+	rs[0].outstanding = 10
+	rs[1].outstanding = 2
+	rs[2].outstanding = 2
+	b := LeastLoaded()
+	for _, r := range rs {
+		b.Add(r)
+	}
+
+	// The least loaded replicas are picked, with ties broken randomly.
+	counts := picks(t, b, 1000)
+	if counts[rs[0].addr] != 0 {
+		t.Errorf("overloaded replica picked %d times", counts[rs[0].addr])
+	}
+	if counts[rs[1].addr] == 0 || counts[rs[2].addr] == 0 {
+		t.Errorf("tied replicas not both picked: %v", counts)
+	}
+
+	// Removed replicas are never picked.
+	b.Remove(rs[1])
+	if got := picks(t, b, 100); got[rs[2].addr] != 100 {
+		t.Errorf("got %v, want only %s", got, rs[2].addr)
+	}
+}
+
+func TestPowerOfTwoChoicesPrefersLowLatency(t *testing.T) {
+	clock := &fakeClock{t: time.Now()}
+	rs := replicas(2)
+	b := PowerOfTwoChoices()
+	b.now = clock.now
+	for _, r := range rs {
+		b.Add(r)
+	}
+	b.Done(rs[0], 100*time.Millisecond, nil)
+	b.Done(rs[1], time.Millisecond, nil)
+
+	if got := picks(t, b, 100); got[rs[1].addr] != 100 {
+		t.Errorf("got %v, want only %s", got, rs[1].addr)
+	}
+
+	// A fast replica with many outstanding calls is more expensive than a
+	// slow idle one.
+	rs[1].outstanding = 1000
+	if got := picks(t, b, 100); got[rs[0].addr] != 100 {
+		t.Errorf("got %v, want only %s", got, rs[0].addr)
+	}
+}
+
+func TestEWMALatency(t *testing.T) {
+	clock := &fakeClock{t: time.Now()}
+	s := newReplicaSet()
+	s.now = clock.now
+	r := replicas(1)[0]
+	s.Add(r)
+
+	s.Done(r, time.Second, nil)
+	if got, want := s.replicas[r].latency, float64(time.Second); got != want {
+		t.Fatalf("latency: got %v, want %v", got, want)
+	}
+
+	// After a long time, the old latency has no weight.
+	clock.advance(100 * latencyDecay)
+	s.Done(r, time.Millisecond, nil)
+	if got, want := s.replicas[r].latency, float64(time.Millisecond); math.Abs(got-want) > 1 {
+		t.Fatalf("latency: got %v, want %v", got, want)
+	}
+
+	// New replicas start with the average latency.
+	other := replicas(2)[1]
+	s.Add(other)
+	if got, want := s.replicas[other].latency, s.replicas[r].latency; got != want {
+		t.Fatalf("latency: got %v, want %v", got, want)
+	}
+}
+
+func TestOutlierEjection(t *testing.T) {
+	clock := &fakeClock{t: time.Now()}
+	rs := replicas(2)
+	b := LeastLoaded()
+	b.now = clock.now
+	for _, r := range rs {
+		b.Add(r)
+	}
+	rs[1].outstanding = 1 // rs[0] is picked, unless ejected
+
+	// Application errors don't eject replicas.
+	for i := 0; i < 2*ejectionFailures; i++ {
+		b.Done(rs[0], time.Millisecond, errors.New("application error"))
+	}
+	if got := picks(t, b, 10); got[rs[0].addr] != 10 {
+		t.Fatalf("got %v, want only %s", got, rs[0].addr)
+	}
+
+	// Successive communication errors do.
+	for i := 0; i < ejectionFailures; i++ {
+		b.Done(rs[0], time.Millisecond, CommunicationError)
+	}
+	if got := picks(t, b, 10); got[rs[1].addr] != 10 {
+		t.Fatalf("got %v, want only %s", got, rs[1].addr)
+	}
+
+	// The ejection expires.
+	clock.advance(baseEjectionTime)
+	if got := picks(t, b, 10); got[rs[0].addr] != 10 {
+		t.Fatalf("got %v, want only %s", got, rs[0].addr)
+	}
+
+	// A replica that is ejected again is ejected for longer.
+	for i := 0; i < ejectionFailures; i++ {
+		b.Done(rs[0], time.Millisecond, Unreachable)
+	}
+	clock.advance(baseEjectionTime)
+	if got := picks(t, b, 10); got[rs[1].addr] != 10 {
+		t.Fatalf("got %v, want only %s", got, rs[1].addr)
+	}
+	clock.advance(baseEjectionTime)
+	if got := picks(t, b, 10); got[rs[0].addr] != 10 {
+		t.Fatalf("got %v, want only %s", got, rs[0].addr)
+	}
+}
+
+func TestOutlierEjectionLimit(t *testing.T) {
+	clock := &fakeClock{t: time.Now()}
+	rs := replicas(2)
+	b := PowerOfTwoChoices()
+	b.now = clock.now
+	for _, r := range rs {
+		b.Add(r)
+	}
+
+	// At most half of the replicas are ejected.
+	for _, r := range rs {
+		for i := 0; i < ejectionFailures; i++ {
+			b.Done(r, time.Millisecond, CommunicationError)
+		}
+	}
+	now := clock.now()
+	if !b.replicas[rs[0]].ejected(now) {
+		t.Errorf("%s not ejected", rs[0].addr)
+	}
+	if b.replicas[rs[1]].ejected(now) {
+		t.Errorf("%s ejected", rs[1].addr)
+	}
+}
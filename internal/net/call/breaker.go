@@ -0,0 +1,300 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package call
+
+import (
+	"sync"
+	"time"
+
+	"github.com/sh3lk/mx/metrics"
+)
+
+const (
+	defaultBreakerTimeout   = 5 * time.Second
+	defaultRetryBudgetRatio = 0.2
+	defaultMinRetryRate     = 10
+
+	// A retry budget holds at most the retries accrued at its minimum rate
+	// over this window, and at least minRetryBurst retries.
+	retryBudgetWindow = 10 * time.Second
+	minRetryBurst     = 10
+)
+
+// CircuitBreakerOptions configure the circuit breaker of a Connection.
+//
+// A circuit breaker is closed, open, or half-open. A closed breaker lets all
+// calls through. After Failures consecutive failed calls, the breaker opens,
+// and rejects all calls with a CircuitOpen error. After Timeout, the breaker
+// becomes half-open, and lets HalfOpenCalls trial calls through. If a trial
+// call succeeds, the breaker closes. If it fails, the breaker opens again.
+//
+// A call fails if it returns a CommunicationError, Unreachable, or a deadline
+// exceeded error. Errors returned by the application are not failures.
+type CircuitBreakerOptions struct {
+	// Number of consecutive failed calls that open the breaker. If zero,
+	// the Connection has no circuit breaker.
+	Failures int
+
+	// How long an open breaker rejects calls. Defaults to 5 seconds.
+	Timeout time.Duration
+
+	// Number of concurrent trial calls a half-open breaker lets through.
+	// Defaults to 1.
+	HalfOpenCalls int
+}
+
+// RetryBudgetOptions configure the retry budget of a Connection.
+//
+// A retry budget caps the retries of the calls that fail with a
+// CommunicationError or Unreachable error to a fraction of the calls, so that
+// retries don't overload servers that are already struggling. Every call adds
+// Ratio retries to the budget, and every retry takes one retry from it. The
+// budget also accrues MinPerSecond retries per second, so that retries are
+// possible when there are few calls. Calls that can't be retried because the
+// budget is exhausted return their error.
+type RetryBudgetOptions struct {
+	// Fraction of calls that may be retried. For example, if Ratio is 0.2,
+	// one retry is allowed every five calls. If zero, it defaults to 0.2. If
+	// negative, retries are not limited.
+	Ratio float64
+
+	// Number of retries allowed every second, regardless of Ratio. If zero,
+	// it defaults to 10.
+	MinPerSecond float64
+}
+
+var (
+	breakerState = metrics.NewGaugeMap[breakerLabels](
+		CircuitBreakerStateMetric,
+		"State of the circuit breaker of MX RPC calls to a component (0 if closed, 1 if half-open, 2 if open)",
+	)
+	breakerRejections = metrics.NewCounterMap[breakerLabels](
+		"mx_system_call_circuit_breaker_rejected_count",
+		"Count of MX RPC calls rejected by an open circuit breaker",
+	)
+	retryBudgetExhausted = metrics.NewCounterMap[breakerLabels](
+		"mx_system_call_retry_budget_exhausted_count",
+		"Count of failed MX RPC calls not retried because the retry budget was exhausted",
+	)
+)
+
+// CircuitBreakerStateMetric is the name of the metric that holds the state
+// of the circuit breaker of the calls to a component, labeled with the name
+// of the component.
+const CircuitBreakerStateMetric = "mx_system_call_circuit_breaker_state"
+
+type breakerLabels struct {
+	Component string // called component
+
+	// Is this a metric implicitly created by the framework?
+	Generated bool `mx:"mx_generated"`
+}
+
+// circuitState is the state of a circuitBreaker. The values are the values
+// of the mx_system_call_circuit_breaker_state metric.
+type circuitState int
+
+const (
+	circuitClosed   circuitState = 0
+	circuitHalfOpen circuitState = 1
+	circuitOpen     circuitState = 2
+)
+
+// circuitBreaker is a circuit breaker. See CircuitBreakerOptions. A nil
+// circuitBreaker lets all calls through.
+type circuitBreaker struct {
+	opts     CircuitBreakerOptions
+	now      func() time.Time // returns the current time; replaced in tests
+	state    *metrics.Gauge
+	rejected *metrics.Counter
+
+	mu       sync.Mutex
+	st       circuitState
+	failures int       // consecutive failed calls, if closed
+	until    time.Time // when the breaker becomes half-open, if open
+	trials   int       // trial calls in progress, if half-open
+}
+
+// newCircuitBreaker returns a new circuit breaker, or nil if opts.Failures is
+// zero.
+func newCircuitBreaker(opts CircuitBreakerOptions, component string) *circuitBreaker {
+	if opts.Failures <= 0 {
+		return nil
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = defaultBreakerTimeout
+	}
+	if opts.HalfOpenCalls <= 0 {
+		opts.HalfOpenCalls = 1
+	}
+	labels := breakerLabels{Component: component, Generated: true}
+	return &circuitBreaker{
+		opts:     opts,
+		now:      time.Now,
+		state:    breakerState.Get(labels),
+		rejected: breakerRejections.Get(labels),
+	}
+}
+
+// allow returns whether a call may be sent. If it returns true, done must be
+// called when the call ends, with the returned trial.
+func (b *circuitBreaker) allow() (trial bool, ok bool) {
+	if b == nil {
+		return false, true
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.st == circuitOpen && !b.now().Before(b.until) {
+		b.setState(circuitHalfOpen)
+		b.trials = 0
+	}
+	switch b.st {
+	case circuitClosed:
+		return false, true
+	case circuitHalfOpen:
+		if b.trials < b.opts.HalfOpenCalls {
+			b.trials++
+			return true, true
+		}
+	}
+	b.rejected.Inc()
+	return false, false
+}
+
+// done records the outcome of a call let through by allow.
+func (b *circuitBreaker) done(trial bool, err error) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if trial {
+		if b.st != circuitHalfOpen {
+			return
+		}
+		b.trials--
+		if failed(err) {
+			b.trip()
+		} else {
+			b.setState(circuitClosed)
+			b.failures = 0
+		}
+		return
+	}
+	if b.st != circuitClosed {
+		// The call started before the breaker opened.
+		return
+	}
+	if !failed(err) {
+		b.failures = 0
+		return
+	}
+	b.failures++
+	if b.failures >= b.opts.Failures {
+		b.trip()
+	}
+}
+
+// trip opens the breaker.
+// REQUIRES: b.mu is held.
+func (b *circuitBreaker) trip() {
+	b.setState(circuitOpen)
+	b.until = b.now().Add(b.opts.Timeout)
+	b.failures = 0
+}
+
+// setState sets the state of the breaker.
+// REQUIRES: b.mu is held.
+func (b *circuitBreaker) setState(st circuitState) {
+	b.st = st
+	b.state.Set(float64(st))
+}
+
+// retryBudget is a token bucket of retries. See RetryBudgetOptions. A nil
+// retryBudget allows all retries.
+type retryBudget struct {
+	ratio     float64          // retries added per call
+	rate      float64          // retries added per second
+	max       float64          // maximum number of retries
+	now       func() time.Time // returns the current time; replaced in tests
+	exhausted *metrics.Counter
+
+	mu      sync.Mutex
+	tokens  float64   // number of retries allowed
+	updated time.Time // when tokens was last refilled
+}
+
+// newRetryBudget returns a new retry budget, or nil if opts.Ratio is
+// negative.
+func newRetryBudget(opts RetryBudgetOptions, component string) *retryBudget {
+	if opts.Ratio < 0 {
+		return nil
+	}
+	if opts.Ratio == 0 {
+		opts.Ratio = defaultRetryBudgetRatio
+	}
+	if opts.MinPerSecond <= 0 {
+		opts.MinPerSecond = defaultMinRetryRate
+	}
+	max := max(minRetryBurst, opts.MinPerSecond*retryBudgetWindow.Seconds())
+	labels := breakerLabels{Component: component, Generated: true}
+	return &retryBudget{
+		ratio:     opts.Ratio,
+		rate:      opts.MinPerSecond,
+		max:       max,
+		now:       time.Now,
+		exhausted: retryBudgetExhausted.Get(labels),
+		tokens:    max,
+		updated:   time.Now(),
+	}
+}
+
+// deposit adds the retries allowed by a call to the budget.
+func (b *retryBudget) deposit() {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.refill()
+	b.tokens = min(b.max, b.tokens+b.ratio)
+}
+
+// withdraw takes a retry from the budget. It returns false if the budget is
+// exhausted.
+func (b *retryBudget) withdraw() bool {
+	if b == nil {
+		return true
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.refill()
+	if b.tokens < 1 {
+		b.exhausted.Inc()
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// refill adds the retries accrued since the last refill to the budget.
+// REQUIRES: b.mu is held.
+func (b *retryBudget) refill() {
+	now := b.now()
+	if elapsed := now.Sub(b.updated); elapsed > 0 {
+		b.tokens = min(b.max, b.tokens+b.rate*elapsed.Seconds())
+	}
+	b.updated = now
+}
@@ -0,0 +1,144 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package call
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// call sends a call through b that returns err. It returns false if b
+// rejects the call.
+func (b *circuitBreaker) call(err error) bool {
+	trial, ok := b.allow()
+	if ok {
+		b.done(trial, err)
+	}
+	return ok
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	clock := &fakeClock{t: time.Now()}
+	b := newCircuitBreaker(CircuitBreakerOptions{Failures: 3, Timeout: time.Second}, "TestCircuitBreaker")
+	b.now = clock.now
+
+	// Application errors, and failures interleaved with successes, don't
+	// open the breaker.
+	for i := 0; i < 10; i++ {
+		b.call(errors.New("application error"))
+		b.call(CommunicationError)
+		b.call(CommunicationError)
+		b.call(nil)
+	}
+	if b.st != circuitClosed {
+		t.Fatalf("state: got %v, want closed", b.st)
+	}
+
+	// Consecutive failures do.
+	for i := 0; i < 3; i++ {
+		b.call(Unreachable)
+	}
+	if b.st != circuitOpen {
+		t.Fatalf("state: got %v, want open", b.st)
+	}
+	if b.call(nil) {
+		t.Fatal("open breaker let a call through")
+	}
+
+	// After the timeout, a single trial call is let through.
+	clock.advance(time.Second)
+	trial, ok := b.allow()
+	if !trial || !ok {
+		t.Fatalf("allow: got (%v, %v), want (true, true)", trial, ok)
+	}
+	if _, ok := b.allow(); ok {
+		t.Fatal("half-open breaker let a second trial call through")
+	}
+
+	// A failed trial call opens the breaker again.
+	b.done(trial, CommunicationError)
+	if b.st != circuitOpen {
+		t.Fatalf("state: got %v, want open", b.st)
+	}
+
+	// A successful trial call closes it.
+	clock.advance(time.Second)
+	if !b.call(nil) {
+		t.Fatal("half-open breaker rejected a trial call")
+	}
+	if b.st != circuitClosed {
+		t.Fatalf("state: got %v, want closed", b.st)
+	}
+}
+
+func TestNilCircuitBreaker(t *testing.T) {
+	b := newCircuitBreaker(CircuitBreakerOptions{}, "TestNilCircuitBreaker")
+	if b != nil {
+		t.Fatal("unexpected circuit breaker")
+	}
+	for i := 0; i < 100; i++ {
+		if !b.call(CommunicationError) {
+			t.Fatal("nil breaker rejected a call")
+		}
+	}
+}
+
+func TestRetryBudget(t *testing.T) {
+	clock := &fakeClock{t: time.Now()}
+	b := newRetryBudget(RetryBudgetOptions{Ratio: 0.5, MinPerSecond: 1}, "TestRetryBudget")
+	b.now = clock.now
+	b.updated = clock.now()
+
+	// The budget starts with a burst of retries.
+	for i := 0; i < minRetryBurst; i++ {
+		if !b.withdraw() {
+			t.Fatalf("retry %d not allowed", i)
+		}
+	}
+	if b.withdraw() {
+		t.Fatal("retry allowed with an exhausted budget")
+	}
+
+	// Every two calls allow a retry.
+	b.deposit()
+	if b.withdraw() {
+		t.Fatal("retry allowed after one call")
+	}
+	b.deposit()
+	if !b.withdraw() {
+		t.Fatal("retry not allowed after two calls")
+	}
+
+	// Retries accrue over time.
+	clock.advance(3 * time.Second)
+	for i := 0; i < 3; i++ {
+		if !b.withdraw() {
+			t.Fatalf("retry %d not allowed", i)
+		}
+	}
+	if b.withdraw() {
+		t.Fatal("retry allowed with an exhausted budget")
+	}
+}
+
+func TestUnlimitedRetryBudget(t *testing.T) {
+	b := newRetryBudget(RetryBudgetOptions{Ratio: -1}, "TestUnlimitedRetryBudget")
+	for i := 0; i < 1000; i++ {
+		if !b.withdraw() {
+			t.Fatal("unlimited budget disallowed a retry")
+		}
+	}
+}
@@ -87,9 +87,16 @@ import (
 
 // Connection allows a client to send RPCs.
 type Connection interface {
-	// Call makes an RPC over a Connection.
+	// Call makes an RPC over a Connection. The arguments are not retained
+	// once Call returns, and the returned results are owned by the caller.
 	Call(context.Context, MethodKey, []byte, CallOptions) ([]byte, error)
 
+	// Stream makes a streaming RPC over a Connection. If in is not nil, the
+	// values it produces are streamed to the server after the arguments. The
+	// returned stream holds the values streamed back by the server, and must
+	// be closed by the caller if the returned error is nil.
+	Stream(context.Context, MethodKey, []byte, codegen.ByteStream, CallOptions) ([]byte, codegen.ByteStream, error)
+
 	// Close closes a connection. Pending invocations of Call are cancelled and
 	// return an error. All future invocations of Call fail and return an error
 	// immediately. Close can be called more than once.
@@ -107,13 +114,16 @@ type Listener interface {
 // It automatically reconnects to the servers on first call or the first call
 // after a shutdown.
 type reconnectingConnection struct {
-	opts ClientOptions
+	opts    ClientOptions
+	breaker *circuitBreaker // nil if there is no circuit breaker
+	budget  *retryBudget    // nil if retries are not limited
 
 	// mu guards the following fields and some of the fields in the
 	// clientConnections inside connections and draining.
-	mu     sync.Mutex
-	conns  map[string]*clientConnection
-	closed bool
+	mu      sync.Mutex
+	conns   map[string]*clientConnection
+	hedgers map[MethodKey]*hedger // latencies of hedged methods
+	closed  bool
 
 	resolver       Resolver
 	cancelResolver func()         // cancels the watchResolver goroutine
@@ -178,8 +188,13 @@ type clientConnection struct {
 	c              net.Conn         // Active network connection, or nil
 	cbuf           *bufio.Reader    // Buffered reader wrapped around c
 	version        version          // Version number to use for connection
+	comp           *compressor      // Compresses messages sent over c, or nil
 	calls          map[uint64]*call // In-progress calls
 	lastID         uint64           // Last assigned request ID for a call
+
+	// When the last message from the server was read, in nanoseconds since
+	// the Unix epoch. Accessed atomically.
+	lastRead atomic.Int64
 }
 
 var _ ReplicaConnection = &clientConnection{}
@@ -198,6 +213,12 @@ type call struct {
 	// This field is accessed across goroutines using atomics.
 	done uint32 // is the call done?
 
+	// Streaming state, nil for non-streaming calls. A streaming call remains
+	// registered with its connection after the response is received, until
+	// the server's stream ends.
+	recv      *recvStream // values streamed by the server
+	send      *sendWindow // credits for values streamed to the server, or nil
+	responded bool        // has the response been received? Guarded by rc.mu
 }
 
 // serverConnection manages one network connection on the server-side.
@@ -207,23 +228,34 @@ type serverConnection struct {
 	cbuf        *bufio.Reader // Buffered reader wrapped around c
 	wlock       sync.Mutex    // Guards writes to c
 	mu          sync.Mutex
-	closed      bool              // has c been closed?
-	version     version           // Version number to use for connection
-	cancelFuncs map[uint64]func() // Cancellation functions for in-progress calls
+	closed      bool                     // has c been closed?
+	version     version                  // Version number to use for connection
+	comp        *compressor              // Compresses messages sent over c, or nil
+	limiters    *limiters                // Admission control of the calls
+	cancelFuncs map[uint64]func()        // Cancellation functions for in-progress calls
+	streams     map[uint64]*serverStream // Streams of in-progress streaming calls
+}
+
+// serverStream holds the state for an active streaming call at the server.
+type serverStream struct {
+	handler StreamHandler
+	recv    *recvStream // values streamed by the client
+	send    *sendWindow // credits for values streamed to the client
 }
 
 // serverState tracks all live server-side connections so we can clean things up when canceled.
 type serverState struct {
-	opts  ServerOptions
-	mu    sync.Mutex
-	conns map[*serverConnection]struct{} // Live connections
+	opts     ServerOptions
+	limiters *limiters // Admission control, shared by all connections
+	mu       sync.Mutex
+	conns    map[*serverConnection]struct{} // Live connections
 }
 
 // Serve starts listening for connections and requests on l. It always returns a
 // non-nil error and closes l.
 func Serve(ctx context.Context, l Listener, opts ServerOptions) error {
 	opts = opts.withDefaults()
-	ss := &serverState{opts: opts}
+	ss := &serverState{opts: opts, limiters: &limiters{admission: opts.Admission}}
 	defer ss.stop()
 	l = &onceCloseListener{Listener: l, closer: sync.OnceValue(l.Close)}
 
@@ -260,7 +292,7 @@ func (oc *onceCloseListener) Close() error {
 // network connection with a client. This can be useful in tests or
 // when using custom networking transports.
 func ServeOn(ctx context.Context, conn net.Conn, hmap *HandlerMap, opts ServerOptions) {
-	ss := &serverState{opts: opts.withDefaults()}
+	ss := &serverState{opts: opts.withDefaults(), limiters: &limiters{admission: opts.Admission}}
 	ss.serveConnection(ctx, conn, hmap)
 }
 
@@ -270,7 +302,9 @@ func (ss *serverState) serveConnection(ctx context.Context, conn net.Conn, hmap
 		c:           conn,
 		cbuf:        bufio.NewReader(conn),
 		version:     initialVersion, // Updated when we hear from client
+		limiters:    ss.limiters,
 		cancelFuncs: map[uint64]func(){},
+		streams:     map[uint64]*serverStream{},
 	}
 	ss.register(c)
 
@@ -304,9 +338,13 @@ func (ss *serverState) unregister(c *serverConnection) {
 // resolver.
 func Connect(ctx context.Context, resolver Resolver, opts ClientOptions) (Connection, error) {
 	// Construct the connection.
+	opts = opts.withDefaults()
 	conn := reconnectingConnection{
-		opts:           opts.withDefaults(),
+		opts:           opts,
+		breaker:        newCircuitBreaker(opts.CircuitBreaker, opts.Component),
+		budget:         newRetryBudget(opts.RetryBudget, opts.Component),
 		conns:          map[string]*clientConnection{},
+		hedgers:        map[MethodKey]*hedger{},
 		resolver:       resolver,
 		cancelResolver: func() {},
 	}
@@ -365,22 +403,40 @@ func (rc *reconnectingConnection) Close() {
 	rc.resolverDone.Wait()
 }
 
-// Call makes an RPC over connection c, retrying it on network errors if retries are allowed.
+// Call makes an RPC over connection c, retrying it on network errors if
+// retries are allowed, and hedging it if hedging is allowed.
 func (rc *reconnectingConnection) Call(ctx context.Context, h MethodKey, arg []byte, opts CallOptions) ([]byte, error) {
-	if !opts.Retry {
-		return rc.callOnce(ctx, h, arg, opts)
+	rc.budget.deposit()
+	if opts.Hedge {
+		return rc.hedgedCall(ctx, h, arg, opts)
 	}
+	return rc.call(ctx, h, arg, opts, nil)
+}
+
+// call makes an RPC over connection c, retrying it on network errors if
+// retries are allowed, and retrying it if it is rejected by an overloaded
+// server, as long as the retry budget isn't exhausted. If a is not nil, the
+// call is one of the two calls of a hedged call.
+func (rc *reconnectingConnection) call(ctx context.Context, h MethodKey, arg []byte, opts CallOptions, a *attempt) ([]byte, error) {
 	for r := retry.Begin(); r.Continue(ctx); {
-		response, err := rc.callOnce(ctx, h, arg, opts)
-		if errors.Is(err, Unreachable) || errors.Is(err, CommunicationError) {
-			continue
+		response, err := rc.callOnce(ctx, h, arg, opts, a)
+		switch {
+		case errors.Is(err, Overloaded):
+			// The server didn't run the call, so it is safe to retry it,
+			// even if it isn't retriable. The balancer may pick another
+			// replica.
+		case opts.Retry && (errors.Is(err, Unreachable) || errors.Is(err, CommunicationError)):
+		default:
+			return response, err
+		}
+		if !rc.budget.withdraw() {
+			return response, err
 		}
-		return response, err
 	}
 	return nil, ctx.Err()
 }
 
-func (rc *reconnectingConnection) callOnce(ctx context.Context, h MethodKey, arg []byte, opts CallOptions) ([]byte, error) {
+func (rc *reconnectingConnection) callOnce(ctx context.Context, h MethodKey, arg []byte, opts CallOptions, a *attempt) (_ []byte, err error) {
 	var micros int64
 	deadline, haveDeadline := ctx.Deadline()
 	if haveDeadline {
@@ -396,8 +452,20 @@ func (rc *reconnectingConnection) callOnce(ctx context.Context, h MethodKey, arg
 		}
 	}
 
+	token, err := rc.token(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check the circuit breaker.
+	trial, ok := rc.breaker.allow()
+	if !ok {
+		return nil, CircuitOpen
+	}
+	defer func() { rc.breaker.done(trial, err) }()
+
 	// Encode the header.
-	hdr := encodeHeader(ctx, h, micros)
+	hdr := encodeHeader(ctx, h, micros, opts.Caller, token)
 
 	// Note that we send the header and the payload as follows:
 	// [header_length][encoded_header][payload]
@@ -409,11 +477,19 @@ func (rc *reconnectingConnection) callOnce(ctx context.Context, h MethodKey, arg
 	rpc.doneSignal = make(chan struct{})
 
 	// TODO: Arrange to obey deadline in any reconnection done inside startCall.
-	conn, nc, err := rc.startCall(ctx, rpc, opts)
+	conn, nc, comp, err := rc.startCall(ctx, rpc, opts, a)
 	if err != nil {
 		return nil, err
 	}
-	if err := writeMessage(nc, &conn.wlock, requestMessage, rpc.id, hdrSlice, arg, rc.opts.WriteFlattenLimit); err != nil {
+	if a != nil {
+		a.conn.Store(conn)
+		a.started.Store(true)
+	}
+	if tracker, ok := rc.opts.Balancer.(CallTracker); ok {
+		start := time.Now()
+		defer func() { rc.callDone(tracker, conn, start, err) }()
+	}
+	if err := writeMessage(nc, &conn.wlock, comp, requestMessage, rpc.id, hdrSlice, arg, rc.opts.WriteFlattenLimit); err != nil {
 		conn.shutdown("client send request", err)
 		conn.endCall(rpc)
 		return nil, fmt.Errorf("%w: %s", CommunicationError, err)
@@ -438,7 +514,7 @@ func (rc *reconnectingConnection) callOnce(ctx context.Context, h MethodKey, arg
 
 			if !haveDeadline || time.Now().Before(deadline) {
 				// Early cancellation. Tell server about it.
-				if err := writeMessage(nc, &conn.wlock, cancelMessage, rpc.id, nil, nil, rc.opts.WriteFlattenLimit); err != nil {
+				if err := writeMessage(nc, &conn.wlock, comp, cancelMessage, rpc.id, nil, nil, rc.opts.WriteFlattenLimit); err != nil {
 					conn.shutdown("client send cancel", err)
 				}
 			}
@@ -451,6 +527,128 @@ func (rc *reconnectingConnection) callOnce(ctx context.Context, h MethodKey, arg
 	return rpc.response, rpc.err
 }
 
+// Stream makes a streaming RPC over connection c. Streaming calls are never
+// retried.
+func (rc *reconnectingConnection) Stream(ctx context.Context, h MethodKey, arg []byte, in codegen.ByteStream, opts CallOptions) (_ []byte, _ codegen.ByteStream, err error) {
+	var micros int64
+	deadline, haveDeadline := ctx.Deadline()
+	if haveDeadline {
+		// See callOnce.
+		micros = time.Until(deadline).Microseconds()
+		if micros <= 0 {
+			<-ctx.Done()
+			return nil, nil, ctx.Err()
+		}
+	}
+
+	token, err := rc.token(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Check the circuit breaker.
+	trial, ok := rc.breaker.allow()
+	if !ok {
+		return nil, nil, CircuitOpen
+	}
+	defer func() { rc.breaker.done(trial, err) }()
+
+	// Encode the header.
+	hdr := encodeHeader(ctx, h, micros, opts.Caller, token)
+	var hdrLen [hdrLenLen]byte
+	binary.LittleEndian.PutUint32(hdrLen[:], uint32(len(hdr)))
+	hdrSlice := append(hdrLen[:], hdr...)
+
+	rpc := &call{}
+	rpc.doneSignal = make(chan struct{})
+	rpc.recv = newRecvStream()
+	if in != nil {
+		rpc.send = newSendWindow()
+	}
+
+	conn, nc, comp, err := rc.startCall(ctx, rpc, opts, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	if tracker, ok := rc.opts.Balancer.(CallTracker); ok {
+		// Only the latency of the response is tracked, not the duration of
+		// the streams.
+		start := time.Now()
+		defer func() { rc.callDone(tracker, conn, start, err) }()
+	}
+	if conn.version < streamVersion {
+		conn.endCall(rpc)
+		return nil, nil, fmt.Errorf("%w: peer %s does not support streaming calls", CommunicationError, conn.Address())
+	}
+	write := func(mt messageType, payload []byte) error {
+		return writeMessage(nc, &conn.wlock, comp, mt, rpc.id, nil, payload, rc.opts.WriteFlattenLimit)
+	}
+	cancel := func() {
+		// Tell the server that we are no longer interested in the call.
+		if conn.endCall(rpc) {
+			if err := write(cancelMessage, nil); err != nil {
+				conn.shutdown("client send cancel", err)
+			}
+		}
+	}
+	rpc.recv.credit = func(n uint32) {
+		if err := write(streamCreditMessage, encodeCredit(n)); err != nil {
+			conn.shutdown("client send credit", err)
+		}
+	}
+	rpc.recv.onClose = cancel
+
+	if err := writeMessage(nc, &conn.wlock, comp, requestMessage, rpc.id, hdrSlice, arg, rc.opts.WriteFlattenLimit); err != nil {
+		conn.shutdown("client send request", err)
+		conn.endCall(rpc)
+		return nil, nil, fmt.Errorf("%w: %s", CommunicationError, err)
+	}
+	rpc.recv.credit(streamWindow)
+
+	// Stream the client values to the server until the server responds. Once
+	// the server has responded, it is no longer interested in the values.
+	stop := func() {}
+	if in != nil {
+		var sendCtx context.Context
+		sendCtx, stop = context.WithCancel(ctx)
+		go func() {
+			err := sendStream(sendCtx, in, rpc.send, write)
+			if err != nil && sendCtx.Err() == nil {
+				conn.shutdown("client send stream", err)
+			}
+		}()
+	}
+	defer stop()
+
+	select {
+	case <-rpc.doneSignal:
+		// Regular return
+	case <-ctx.Done():
+		// Canceled or deadline expired.
+		cancel()
+		return nil, nil, ctx.Err()
+	}
+	if rpc.err != nil {
+		return nil, nil, rpc.err
+	}
+	return rpc.response, rpc.recv, nil
+}
+
+// callDone informs the provided tracker that a call on c, started at the
+// provided time, has ended with the provided error.
+// REQUIRES: rc.mu is not held.
+func (rc *reconnectingConnection) callDone(tracker CallTracker, c *clientConnection, start time.Time, err error) {
+	if errors.Is(err, context.Canceled) {
+		// The caller lost interest in the call (e.g., because the call was
+		// hedged and the hedge won), which says nothing about c.
+		return
+	}
+	latency := time.Since(start)
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	tracker.Done(c, latency, err)
+}
+
 // watchResolver watches for updates to the set of endpoints. When a new set of
 // updates is available, watchResolver passes it to updateEndpoints.
 // REQUIRES: version != nil.
@@ -525,14 +723,18 @@ func (rc *reconnectingConnection) updateEndpoints(ctx context.Context, endpoints
 	return nil
 }
 
-// startCall registers a new in-progress call.
+// startCall registers a new in-progress call. It returns the connection the
+// call was registered with, along with its network connection and the
+// compressor for the messages sent over it. If a is not nil and a.avoid is
+// not nil, the call is registered with a connection other than a.avoid, or
+// errNoHedgeReplica is returned.
 // REQUIRES: rc.mu is not held.
-func (rc *reconnectingConnection) startCall(ctx context.Context, rpc *call, opts CallOptions) (*clientConnection, net.Conn, error) {
+func (rc *reconnectingConnection) startCall(ctx context.Context, rpc *call, opts CallOptions, a *attempt) (*clientConnection, net.Conn, *compressor, error) {
 	for r := retry.Begin(); r.Continue(ctx); {
 		rc.mu.Lock()
 		if rc.closed {
 			rc.mu.Unlock()
-			return nil, nil, fmt.Errorf("Call on closed Connection")
+			return nil, nil, nil, fmt.Errorf("Call on closed Connection")
 		}
 
 		replica, ok := rc.opts.Balancer.Pick(opts)
@@ -540,30 +742,46 @@ func (rc *reconnectingConnection) startCall(ctx context.Context, rpc *call, opts
 			rc.mu.Unlock()
 			continue
 		}
+		if a != nil && a.avoid != nil {
+			// Pick again until the balancer picks a different replica.
+			for i := 0; ok && replica == ReplicaConnection(a.avoid) && i < len(rc.conns); i++ {
+				replica, ok = rc.opts.Balancer.Pick(opts)
+			}
+			if !ok || replica == ReplicaConnection(a.avoid) {
+				rc.mu.Unlock()
+				return nil, nil, nil, errNoHedgeReplica
+			}
+		}
 
 		c, ok := replica.(*clientConnection)
 		if !ok {
 			rc.mu.Unlock()
-			return nil, nil, fmt.Errorf("internal error: wrong connection type %#v returned by load balancer", replica)
+			return nil, nil, nil, fmt.Errorf("internal error: wrong connection type %#v returned by load balancer", replica)
 		}
 
 		c.lastID++
 		rpc.id = c.lastID
 		c.calls[rpc.id] = rpc
 		c.callstart()
-		nc := c.c
+		nc, comp := c.c, c.comp
 		rc.mu.Unlock()
 
-		return c, nc, nil
+		return c, nc, comp, nil
 	}
 
-	return nil, nil, ctx.Err()
+	return nil, nil, nil, ctx.Err()
 }
 
 func (c *clientConnection) Address() string {
 	return c.endpoint.Address()
 }
 
+// Outstanding implements the ReplicaConnection interface.
+// REQUIRES: c.rc.mu is held.
+func (c *clientConnection) Outstanding() int {
+	return len(c.calls)
+}
+
 // State transition actions: all of these are called with rc.mu held.
 
 func (c *clientConnection) register() {
@@ -731,28 +949,53 @@ func (c *clientConnection) setState(s connState) {
 	c.checkInvariants()
 }
 
-func (c *clientConnection) endCall(rpc *call) {
+// endCall ends the provided call. It returns false if the call had already
+// ended.
+func (c *clientConnection) endCall(rpc *call) bool {
 	c.rc.mu.Lock()
 	defer c.rc.mu.Unlock()
+	if c.calls[rpc.id] != rpc {
+		return false
+	}
 	delete(c.calls, rpc.id)
 	if len(c.calls) == 0 {
 		c.lastdone()
 	}
+	return true
 }
 
+// findAndEndCall returns the call with the provided id, ending it unless it
+// is a streaming call. It returns nil if the call has ended or if the
+// response to the (streaming) call has already been received.
 func (c *clientConnection) findAndEndCall(id uint64) *call {
 	c.rc.mu.Lock()
 	defer c.rc.mu.Unlock()
 	rpc := c.calls[id]
-	if rpc != nil {
-		delete(c.calls, id)
-		if len(c.calls) == 0 {
-			c.lastdone()
+	if rpc == nil {
+		return nil
+	}
+	if rpc.recv != nil {
+		// The call stays registered until the server's stream ends.
+		if rpc.responded {
+			return nil
 		}
+		rpc.responded = true
+		return rpc
+	}
+	delete(c.calls, id)
+	if len(c.calls) == 0 {
+		c.lastdone()
 	}
 	return rpc
 }
 
+// findCall returns the call with the provided id, or nil if there is none.
+func (c *clientConnection) findCall(id uint64) *call {
+	c.rc.mu.Lock()
+	defer c.rc.mu.Unlock()
+	return c.calls[id]
+}
+
 // shutdown processes an error detected while operating on a connection.
 // It closes the network connection and cancels all requests in progress on the connection.
 // REQUIRES: c.mu is not held.
@@ -766,9 +1009,14 @@ func (c *clientConnection) shutdown(details string, err error) {
 // REQUIRES: c.mu is held.
 func (c *clientConnection) endCalls(err error) {
 	for id, active := range c.calls {
-		active.err = err
-		atomic.StoreUint32(&active.done, 1)
-		close(active.doneSignal)
+		if active.recv != nil {
+			active.recv.finish(err)
+		}
+		if !active.responded {
+			active.err = err
+			atomic.StoreUint32(&active.done, 1)
+			close(active.doneSignal)
+		}
 		delete(c.calls, id)
 	}
 }
@@ -808,6 +1056,13 @@ func (c *clientConnection) connectOnce(ctx context.Context) bool {
 	}
 	c.checked()
 
+	// Check that the peer stays live.
+	if c.version >= keepaliveVersion && c.rc.opts.KeepaliveInterval > 0 {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		go c.keepalive(ctx, nc)
+	}
+
 	for c.state == idle || c.state == active || c.state == draining {
 		if err := c.readAndProcessMessage(); err != nil {
 			c.fail("client read", err)
@@ -824,24 +1079,80 @@ func (c *clientConnection) exchangeVersions() error {
 	c.rc.mu.Unlock()
 	defer c.rc.mu.Lock()
 
+	// Don't wait forever for a peer that doesn't reply.
+	if err := nc.SetReadDeadline(time.Now().Add(c.rc.opts.KeepaliveTimeout)); err != nil {
+		return err
+	}
 	if err := writeVersion(nc, &c.wlock); err != nil {
 		return err
 	}
-	mt, id, msg, err := readMessage(buf)
+	mt, id, msg, err := readMessage(buf, nil)
 	if err != nil {
 		return err
 	}
+	if err := nc.SetReadDeadline(time.Time{}); err != nil {
+		return err
+	}
 	if mt != versionMessage {
 		return fmt.Errorf("wrong message type %d, expecting %d", mt, versionMessage)
 	}
-	v, err := getVersion(id, msg)
+	v, compressions, err := getVersion(id, msg)
 	if err != nil {
 		return err
 	}
 	c.version = v
+	c.comp = newCompressor(c.rc.opts.Compression, c.rc.opts.CompressionThreshold, compressions)
 	return nil
 }
 
+// keepalive pings the server over nc every KeepaliveInterval, until ctx is
+// done. If no message is read from the server within KeepaliveTimeout of a
+// ping, keepalive fails the connection.
+// REQUIRES: c.rc.mu is not held.
+func (c *clientConnection) keepalive(ctx context.Context, nc net.Conn) {
+	interval, timeout := c.rc.opts.KeepaliveInterval, c.rc.opts.KeepaliveTimeout
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	var id uint64
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		id++
+		sent := time.Now()
+		if err := writeMessage(nc, &c.wlock, nil, pingMessage, id, nil, nil, c.rc.opts.WriteFlattenLimit); err != nil {
+			c.failConn(nc, "client send ping", err)
+			return
+		}
+		timer := time.NewTimer(timeout)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+		if c.lastRead.Load() < sent.UnixNano() {
+			c.failConn(nc, "keepalive", fmt.Errorf("no reply to ping within %v", timeout))
+			return
+		}
+	}
+}
+
+// failConn fails the connection if its network connection is nc, and closes
+// nc to unblock the reader of the connection.
+// REQUIRES: c.rc.mu is not held.
+func (c *clientConnection) failConn(nc net.Conn, details string, err error) {
+	c.rc.mu.Lock()
+	defer c.rc.mu.Unlock()
+	if c.c == nc {
+		c.fail(details, err)
+		nc.Close()
+	}
+}
+
 // readAndProcessMessage reads and handles one message sent from the server.
 func (c *clientConnection) readAndProcessMessage() error {
 	buf := c.cbuf
@@ -850,33 +1161,64 @@ func (c *clientConnection) readAndProcessMessage() error {
 	c.rc.mu.Unlock()
 	defer c.rc.mu.Lock()
 
-	mt, id, msg, err := readMessage(buf)
+	mt, id, msg, err := readMessage(buf, nil)
 	if err != nil {
 		return err
 	}
+	c.lastRead.Store(time.Now().UnixNano())
 	switch mt {
 	case versionMessage:
-		_, err := getVersion(id, msg)
+		_, _, err := getVersion(id, msg)
 		if err != nil {
 			return err
 		}
 		// Ignore versions sent after initial hand-shake
-	case responseMessage, responseError:
+	case pongMessage:
+		// The server is live. See keepalive.
+	case responseMessage, responseError, responseOverloaded:
 		rpc := c.findAndEndCall(id)
 		if rpc == nil {
 			return nil // May have been canceled
 		}
-		if mt == responseError {
+		switch mt {
+		case responseError:
 			if err, ok := decodeError(msg); ok {
 				rpc.err = err
 			} else {
 				rpc.err = fmt.Errorf("%w: could not decode error", CommunicationError)
 			}
-		} else {
+		case responseOverloaded:
+			rpc.err = Overloaded
+		default:
 			rpc.response = msg
 		}
 		atomic.StoreUint32(&rpc.done, 1)
 		close(rpc.doneSignal)
+		if rpc.recv != nil && mt != responseMessage {
+			// The server will not stream any values.
+			rpc.recv.finish(rpc.err)
+			c.endCall(rpc)
+		}
+	case streamValueMessage, streamEndMessage, streamCreditMessage:
+		rpc := c.findCall(id)
+		if rpc == nil || rpc.recv == nil {
+			return nil // May have been canceled
+		}
+		switch mt {
+		case streamValueMessage:
+			return rpc.recv.deliver(msg)
+		case streamEndMessage:
+			rpc.recv.finish(decodeStreamEnd(msg))
+			c.endCall(rpc)
+		case streamCreditMessage:
+			n, err := decodeCredit(msg)
+			if err != nil {
+				return err
+			}
+			if rpc.send != nil {
+				rpc.send.grant(n)
+			}
+		}
 	default:
 		return fmt.Errorf("invalid response %d", mt)
 	}
@@ -886,7 +1228,11 @@ func (c *clientConnection) readAndProcessMessage() error {
 // readRequests runs on the server side reading messages sent over a connection by the client.
 func (c *serverConnection) readRequests(ctx context.Context, hmap *HandlerMap, onDone func()) {
 	for ctx.Err() == nil {
-		mt, id, msg, err := readMessage(c.cbuf)
+		// Messages are read into pooled buffers. A buffer is returned to the
+		// pool once the message has been handled, unless the message is
+		// retained by a stream.
+		buf := getBuffer()
+		mt, id, msg, err := readMessage(c.cbuf, buf)
 		if err != nil {
 			c.shutdown("server read", err)
 			onDone()
@@ -895,7 +1241,7 @@ func (c *serverConnection) readRequests(ctx context.Context, hmap *HandlerMap, o
 
 		switch mt {
 		case versionMessage:
-			v, err := getVersion(id, msg)
+			v, compressions, err := getVersion(id, msg)
 			if err != nil {
 				c.shutdown("server read version", err)
 				onDone()
@@ -903,8 +1249,11 @@ func (c *serverConnection) readRequests(ctx context.Context, hmap *HandlerMap, o
 			}
 			c.mu.Lock()
 			c.version = v
+			c.comp = newCompressor(c.opts.Compression, c.opts.CompressionThreshold, compressions)
 			c.mu.Unlock()
 
+			putBuffer(buf)
+
 			// Respond with my version.
 			if err := writeVersion(c.c, &c.wlock); err != nil {
 				c.shutdown("server send version", err)
@@ -912,23 +1261,50 @@ func (c *serverConnection) readRequests(ctx context.Context, hmap *HandlerMap, o
 				return
 			}
 		case requestMessage:
+			if s := c.startStream(hmap, id, msg); s != nil {
+				// Streaming handlers block waiting for stream messages read
+				// by this goroutine, so they are never run inline. The
+				// arguments may be retained by the streams, so buf is not
+				// reused.
+				go c.runHandler(hmap, id, msg, s)
+				continue
+			}
 			if c.opts.InlineHandlerDuration > 0 {
 				// Run the handler inline. If it doesn't return in the specified
 				// time period, launch another goroutine to read incoming requests.
 				t := time.AfterFunc(c.opts.InlineHandlerDuration, func() {
 					c.readRequests(ctx, hmap, onDone)
 				})
-				c.runHandler(hmap, id, msg)
+				c.runHandler(hmap, id, msg, nil)
+				putBuffer(buf)
 				if !t.Stop() {
 					// Another goroutine is reading incoming requests: bail out.
 					return
 				}
 			} else {
 				// Run the handler in a separate goroutine.
-				go c.runHandler(hmap, id, msg)
+				go func() {
+					c.runHandler(hmap, id, msg, nil)
+					putBuffer(buf)
+				}()
 			}
 		case cancelMessage:
 			c.endRequest(id)
+			putBuffer(buf)
+		case pingMessage:
+			putBuffer(buf)
+			if err := writeMessage(c.c, &c.wlock, nil, pongMessage, id, nil, nil, c.opts.WriteFlattenLimit); err != nil {
+				c.shutdown("server send pong", err)
+				onDone()
+				return
+			}
+		case streamValueMessage, streamEndMessage, streamCreditMessage:
+			// Stream values are retained by the streams, so buf is not reused.
+			if err := c.processStreamMessage(mt, id, msg); err != nil {
+				c.shutdown("server read stream", err)
+				onDone()
+				return
+			}
 		default:
 			c.shutdown("server read", fmt.Errorf("invalid request type %d", mt))
 			onDone()
@@ -941,7 +1317,12 @@ func (c *serverConnection) readRequests(ctx context.Context, hmap *HandlerMap, o
 
 // runHandler runs an application specified RPC handler at the server side.
 // The result (or error) from the handler is sent back to the client over c.
-func (c *serverConnection) runHandler(hmap *HandlerMap, id uint64, msg []byte) {
+// s holds the state of the call if it is a streaming call, or nil otherwise.
+func (c *serverConnection) runHandler(hmap *HandlerMap, id uint64, msg []byte, s *serverStream) {
+	if s != nil {
+		defer c.endStream(id)
+	}
+
 	msgLen := uint32(len(msg))
 	if msgLen < hdrLenLen {
 		c.shutdown("server handler", fmt.Errorf("missing request header length"))
@@ -995,8 +1376,10 @@ func (c *serverConnection) runHandler(hmap *HandlerMap, id uint64, msg []byte) {
 	payload := msg[hdrEndOffset:]
 	var err error
 	var result []byte
+	var out codegen.ByteStream
+	var overloaded bool // was the call rejected by admission control?
 	fn, ok := hmap.handlers[hkey]
-	if !ok {
+	if !ok && s == nil {
 		err = fmt.Errorf("internal error: unknown function")
 	} else {
 		if err := c.startRequest(id, cancelFunc); err != nil {
@@ -1005,7 +1388,15 @@ func (c *serverConnection) runHandler(hmap *HandlerMap, id uint64, msg []byte) {
 		}
 		cancelFunc = nil // endRequest() or cancellation will deal with it
 		defer c.endRequest(id)
-		result, err = fn(ctx, payload)
+		if s != nil {
+			result, out, err = s.handler(ctx, payload, s.recv)
+		} else if release, aerr := c.limiters.acquire(ctx, hkey, hmap.names[hkey]); aerr != nil {
+			err, overloaded = aerr, errors.Is(aerr, Overloaded)
+		} else {
+			start := time.Now()
+			result, err = fn(ctx, payload)
+			release(time.Since(start))
+		}
 	}
 
 	mt := responseMessage
@@ -1015,12 +1406,97 @@ func (c *serverConnection) runHandler(hmap *HandlerMap, id uint64, msg []byte) {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, err.Error())
 	}
+	if overloaded {
+		c.mu.Lock()
+		v := c.version
+		c.mu.Unlock()
+		if v >= admissionVersion {
+			mt, result = responseOverloaded, nil
+		}
+	}
 
-	if err := writeMessage(c.c, &c.wlock, mt, id, nil, result, c.opts.WriteFlattenLimit); err != nil {
+	if err := writeMessage(c.c, &c.wlock, c.comp, mt, id, nil, result, c.opts.WriteFlattenLimit); err != nil {
 		c.shutdown("server write "+hmap.names[hkey], err)
+		return
+	}
+	if s == nil || err != nil {
+		return
+	}
+
+	// Stream the server values to the client. Note that a successful response
+	// to a streaming call is always followed by a (possibly empty) stream.
+	if out == nil {
+		out = codegen.EmptyStream()
+	}
+	write := func(mt messageType, payload []byte) error {
+		return writeMessage(c.c, &c.wlock, c.comp, mt, id, nil, payload, c.opts.WriteFlattenLimit)
+	}
+	if err := sendStream(ctx, out, s.send, write); err != nil && ctx.Err() == nil {
+		c.shutdown("server write stream "+hmap.names[hkey], err)
 	}
 }
 
+// startStream registers the stream state for the provided request if it is a
+// request for a streaming method. It returns nil if it is not.
+func (c *serverConnection) startStream(hmap *HandlerMap, id uint64, msg []byte) *serverStream {
+	// The encoded header starts with the method key. See encodeHeader.
+	var hkey MethodKey
+	if len(msg) < int(hdrLenLen)+len(hkey) {
+		return nil
+	}
+	copy(hkey[:], msg[hdrLenLen:])
+	handler, ok := hmap.streamHandlers[hkey]
+	if !ok {
+		return nil
+	}
+
+	s := &serverStream{handler: handler, recv: newRecvStream(), send: newSendWindow()}
+	s.recv.credit = func(n uint32) {
+		if err := writeMessage(c.c, &c.wlock, c.comp, streamCreditMessage, id, nil, encodeCredit(n), c.opts.WriteFlattenLimit); err != nil {
+			c.shutdown("server send credit", err)
+		}
+	}
+	c.mu.Lock()
+	c.streams[id] = s
+	c.mu.Unlock()
+	s.recv.credit(streamWindow)
+	return s
+}
+
+// endStream unregisters the stream state for the provided request.
+func (c *serverConnection) endStream(id uint64) {
+	c.mu.Lock()
+	s := c.streams[id]
+	delete(c.streams, id)
+	c.mu.Unlock()
+	if s != nil {
+		s.recv.Close()
+	}
+}
+
+// processStreamMessage handles a stream message sent by the client.
+func (c *serverConnection) processStreamMessage(mt messageType, id uint64, msg []byte) error {
+	c.mu.Lock()
+	s := c.streams[id]
+	c.mu.Unlock()
+	if s == nil {
+		return nil // May have been canceled
+	}
+	switch mt {
+	case streamValueMessage:
+		return s.recv.deliver(msg)
+	case streamEndMessage:
+		s.recv.finish(decodeStreamEnd(msg))
+	case streamCreditMessage:
+		n, err := decodeCredit(msg)
+		if err != nil {
+			return err
+		}
+		s.send.grant(n)
+	}
+	return nil
+}
+
 func (c *serverConnection) startRequest(id uint64, cancelFunc func()) error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
@@ -1058,8 +1534,21 @@ func (c *serverConnection) shutdown(details string, err error) {
 	}
 }
 
+// token returns the bearer token to send with a call, or the empty string if
+// the client doesn't send tokens. See ClientOptions.Token.
+func (rc *reconnectingConnection) token(ctx context.Context) (string, error) {
+	if rc.opts.Token == nil {
+		return "", nil
+	}
+	token, err := rc.opts.Token(ctx)
+	if err != nil {
+		return "", fmt.Errorf("cannot get token: %w", err)
+	}
+	return token, nil
+}
+
 // encodeHeader encodes the header information that is propagated by each message.
-func encodeHeader(ctx context.Context, h MethodKey, micros int64) []byte {
+func encodeHeader(ctx context.Context, h MethodKey, micros int64, caller, token string) []byte {
 	enc := codegen.NewEncoder()
 	copy(enc.Grow(len(h)), h[:])
 	enc.Int64(micros)
@@ -1070,6 +1559,9 @@ func encodeHeader(ctx context.Context, h MethodKey, micros int64) []byte {
 	// Send context metadata in the header.
 	writeContextMetadata(ctx, enc)
 
+	// Send the priority class, the caller, and the token in the header.
+	writeCallerInfo(ctx, enc, caller, token)
+
 	return enc.Data()
 }
 
@@ -1089,6 +1581,9 @@ func decodeHeader(hdr []byte) (context.Context, MethodKey, int64, *trace.SpanCon
 
 	// Extract metadata context information if any.
 	ctx := readContextMetadata(context.Background(), dec)
+
+	// Extract the priority class, the caller, and the token if any.
+	ctx = readCallerInfo(ctx, dec)
 	return ctx, hkey, micros, sc
 }
 
@@ -22,6 +22,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"log"
 	"log/slog"
 	"math/rand"
@@ -37,6 +38,8 @@ import (
 	"github.com/sh3lk/mx/internal/cond"
 	"github.com/sh3lk/mx/internal/net/call"
 	"github.com/sh3lk/mx/internal/traceio"
+	"github.com/sh3lk/mx/priority"
+	"github.com/sh3lk/mx/runtime/codegen"
 	"github.com/sh3lk/mx/runtime/logging"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/trace"
@@ -51,6 +54,8 @@ var (
 	cancelWaitKey = call.MakeMethodKey("", "cancelwait")
 	sleepKey      = call.MakeMethodKey("", "sleep")
 	customKey     = call.MakeMethodKey("", "custom")
+	seqKey        = call.MakeMethodKey("", "seq")
+	sumKey        = call.MakeMethodKey("", "sum")
 	handlers      = makeHandlerMap()
 	tlsConfig     = makeTLSConfig()
 
@@ -71,6 +76,8 @@ func makeHandlerMap() *call.HandlerMap {
 	m.Set("", "cancelwait", cancelWaitHandler)
 	m.Set("", "sleep", sleepHandler)
 	m.Set("", "custom", customHandler)
+	m.SetStream("", "seq", seqHandler)
+	m.SetStream("", "sum", sumHandler)
 	return m
 }
 
@@ -186,6 +193,61 @@ func echoHandler(_ context.Context, arg []byte) ([]byte, error) {
 	return arg, nil
 }
 
+// seqHandler streams back the values 0, 1, ..., n-1 where n is the argument,
+// each value encoded as a decimal string.
+func seqHandler(_ context.Context, arg []byte, _ codegen.ByteStream) ([]byte, codegen.ByteStream, error) {
+	n, err := strconv.Atoi(string(arg))
+	if err != nil {
+		return nil, nil, err
+	}
+	values := make([][]byte, n)
+	for i := range values {
+		values[i] = []byte(strconv.Itoa(i))
+	}
+	return nil, &sliceStream{values: values}, nil
+}
+
+// sumHandler returns the sum of the values streamed by the caller, each value
+// encoded as a decimal string.
+func sumHandler(ctx context.Context, _ []byte, in codegen.ByteStream) ([]byte, codegen.ByteStream, error) {
+	defer in.Close()
+	sum := 0
+	for {
+		v, err := in.Recv(ctx)
+		if errors.Is(err, io.EOF) {
+			return []byte(strconv.Itoa(sum)), nil, nil
+		} else if err != nil {
+			return nil, nil, err
+		}
+		x, err := strconv.Atoi(string(v))
+		if err != nil {
+			return nil, nil, err
+		}
+		sum += x
+	}
+}
+
+// sliceStream is a codegen.ByteStream that holds a fixed list of values,
+// optionally followed by an error.
+type sliceStream struct {
+	values [][]byte
+	err    error // returned after values; io.EOF if nil
+}
+
+func (s *sliceStream) Recv(context.Context) ([]byte, error) {
+	if len(s.values) == 0 {
+		if s.err != nil {
+			return nil, s.err
+		}
+		return nil, io.EOF
+	}
+	v := s.values[0]
+	s.values = s.values[1:]
+	return v, nil
+}
+
+func (s *sliceStream) Close() {}
+
 func whoHandler(name string) call.Handler {
 	return func(context.Context, []byte) ([]byte, error) {
 		return []byte(name), nil
@@ -597,6 +659,71 @@ func testError(t *testing.T, client call.Connection) {
 	}
 }
 
+func testServerStream(t *testing.T, client call.Connection) {
+	// Stream more values than fit in a single flow control window.
+	ctx := context.Background()
+	const n = 1000
+	_, out, err := client.Stream(ctx, seqKey, []byte(strconv.Itoa(n)), nil, call.CallOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer out.Close()
+	for i := 0; ; i++ {
+		v, err := out.Recv(ctx)
+		if errors.Is(err, io.EOF) {
+			if i != n {
+				t.Fatalf("received %d values, expecting %d", i, n)
+			}
+			return
+		} else if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got, want := string(v), strconv.Itoa(i); got != want {
+			t.Fatalf("bad value: %q, expecting %q", got, want)
+		}
+	}
+}
+
+func testClientStream(t *testing.T, client call.Connection) {
+	ctx := context.Background()
+	const n = 1000
+	values := make([][]byte, n)
+	for i := range values {
+		values[i] = []byte(strconv.Itoa(i))
+	}
+	result, out, err := client.Stream(ctx, sumKey, nil, &sliceStream{values: values}, call.CallOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out.Close()
+	if got, want := string(result), strconv.Itoa(n*(n-1)/2); got != want {
+		t.Fatalf("bad result: %q, expecting %q", got, want)
+	}
+
+	// Check that a failed client stream fails the call.
+	in := &sliceStream{values: values[:10], err: os.ErrInvalid}
+	_, _, err = client.Stream(ctx, sumKey, nil, in, call.CallOptions{})
+	if !errors.Is(err, os.ErrInvalid) {
+		t.Fatalf("bad error %v; does not match os.ErrInvalid", err)
+	}
+}
+
+func testStreamClose(t *testing.T, client call.Connection) {
+	// Close streams before they end, and check that subsequent calls succeed.
+	ctx := context.Background()
+	for i := 0; i < 10; i++ {
+		_, out, err := client.Stream(ctx, seqKey, []byte("1000000"), nil, call.CallOptions{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, err := out.Recv(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		out.Close()
+	}
+	testCall(ctx, t, client)
+}
+
 func testDeadlineHandling(t *testing.T, client call.Connection) {
 	// Test cancellation and deadline expiration.
 	for _, useDeadline := range []bool{false, true} {
@@ -659,6 +786,9 @@ func TestSingleTCPServer(t *testing.T) {
 		{"TestConcurrentCalls", testConcurrentCalls},
 		{"TestError", testError},
 		{"TestDeadlineHandling", testDeadlineHandling},
+		{"TestServerStream", testServerStream},
+		{"TestClientStream", testClientStream},
+		{"TestStreamClose", testStreamClose},
 		// Note that testClose has to come last because once the connection is
 		// closed, all other operations will fail.
 		{"TestClose", testClose},
@@ -695,6 +825,57 @@ func TestSingleTCPServer(t *testing.T) {
 	}
 }
 
+// TestCompression tests that large messages are compressed if and only if
+// the sender enables compression.
+func TestCompression(t *testing.T) {
+	arg := strings.Repeat("compressible ", 1000)
+	for _, test := range []struct {
+		name   string
+		client call.ClientOptions
+		server call.ServerOptions
+	}{
+		{"None", call.ClientOptions{}, call.ServerOptions{}},
+		{"Zstd", call.ClientOptions{CompressionThreshold: 100}, call.ServerOptions{CompressionThreshold: 100}},
+		{"Snappy", call.ClientOptions{CompressionThreshold: 100, Compression: call.Snappy}, call.ServerOptions{CompressionThreshold: 100, Compression: call.Snappy}},
+		{"Mixed", call.ClientOptions{CompressionThreshold: 100, Compression: call.Snappy}, call.ServerOptions{CompressionThreshold: 100}},
+		{"ClientOnly", call.ClientOptions{CompressionThreshold: 100}, call.ServerOptions{}},
+		{"ServerOnly", call.ClientOptions{}, call.ServerOptions{CompressionThreshold: 100}},
+		{"AboveThreshold", call.ClientOptions{CompressionThreshold: 100000}, call.ServerOptions{CompressionThreshold: 100000}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+			clientConn, serverConn := pipe(t)
+			test.server.Logger = logger(t)
+			call.ServeOn(ctx, serverConn, handlers, test.server)
+
+			conn := &countingConn{connWrapper: connWrapper{clientConn}}
+			test.client.Logger = logger(t)
+			client, err := call.Connect(ctx, call.NewConstantResolver(&connEndpoint{"server", conn}), test.client)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer client.Close()
+
+			result, err := client.Call(ctx, echoKey, []byte(arg), call.CallOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(result) != arg {
+				t.Fatalf("bad result: got %d bytes, want %d", len(result), len(arg))
+			}
+
+			written, read := conn.counts()
+			compress := func(threshold int) bool { return threshold > 0 && threshold < len(arg) }
+			if got, want := written < int64(len(arg)), compress(test.client.CompressionThreshold); got != want {
+				t.Errorf("request compressed: got %t (%d bytes written), want %t", got, written, want)
+			}
+			if got, want := read < int64(len(arg)), compress(test.server.CompressionThreshold); got != want {
+				t.Errorf("reply compressed: got %t (%d bytes read), want %t", got, read, want)
+			}
+		})
+	}
+}
+
 // TestTracePropagation tests that the trace context is propagated across an RPC
 func TestTracePropagation(t *testing.T) {
 	ct := startTest(t)
@@ -725,6 +906,109 @@ func TestTracePropagation(t *testing.T) {
 	}
 }
 
+// TestPriorityPropagation tests that the priority class is propagated across
+// an RPC.
+func TestPriorityPropagation(t *testing.T) {
+	ct := startTest(t)
+	client := ct.connect(call.NewConstantResolver(ct.startTCPServer()))
+
+	for c := priority.Interactive; c <= priority.BestEffort; c++ {
+		ctx := priority.NewContext(context.Background(), c)
+		result, err := runAtServer(ctx, client, call.CallOptions{}, func(ctx context.Context) ([]byte, error) {
+			return []byte(priority.FromContext(ctx).String()), nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := string(result), c.String(); got != want {
+			t.Errorf("got %q, want %q", got, want)
+		}
+	}
+}
+
+// TestCallerPropagation tests that the calling component and the priority
+// class are propagated across an RPC, with and without each other.
+func TestCallerPropagation(t *testing.T) {
+	ct := startTest(t)
+	client := ct.connect(call.NewConstantResolver(ct.startTCPServer()))
+
+	for _, caller := range []string{"", "example.com/Caller"} {
+		for _, c := range []priority.Class{priority.Interactive, priority.Batch} {
+			ctx := priority.NewContext(context.Background(), c)
+			opts := call.CallOptions{Caller: caller}
+			result, err := runAtServer(ctx, client, opts, func(ctx context.Context) ([]byte, error) {
+				return []byte(call.Caller(ctx) + "/" + priority.FromContext(ctx).String()), nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got, want := string(result), caller+"/"+c.String(); got != want {
+				t.Errorf("got %q, want %q", got, want)
+			}
+		}
+	}
+}
+
+// TestTokenPropagation tests that the client's bearer token is propagated
+// across an RPC, with and without a calling component, and that a call fails
+// without being sent if the client cannot get a token.
+func TestTokenPropagation(t *testing.T) {
+	ct := startTest(t)
+	endpoint := ct.startTCPServer()
+	var tokenErr error
+	opts := call.ClientOptions{
+		Logger: logger(t),
+		Token: func(context.Context) (string, error) {
+			return "secret", tokenErr
+		},
+	}
+	client, err := call.Connect(ct.ctx, call.NewConstantResolver(endpoint), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	for _, caller := range []string{"", "example.com/Caller"} {
+		opts := call.CallOptions{Caller: caller}
+		result, err := runAtServer(context.Background(), client, opts, func(ctx context.Context) ([]byte, error) {
+			return []byte(call.Caller(ctx) + "/" + call.Token(ctx)), nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := string(result), caller+"/secret"; got != want {
+			t.Errorf("got %q, want %q", got, want)
+		}
+	}
+
+	tokenErr = errors.New("no token")
+	ran := false
+	_, err = runAtServer(context.Background(), client, call.CallOptions{}, func(context.Context) ([]byte, error) {
+		ran = true
+		return nil, nil
+	})
+	if !errors.Is(err, tokenErr) {
+		t.Errorf("got %v, want %v", err, tokenErr)
+	}
+	if ran {
+		t.Error("call without a token was sent")
+	}
+}
+
+// TestPermissionDenied tests that a PermissionDenied error returned by a
+// handler is seen as such by the client.
+func TestPermissionDenied(t *testing.T) {
+	ct := startTest(t)
+	client := ct.connect(call.NewConstantResolver(ct.startTCPServer()))
+
+	_, err := runAtServer(context.Background(), client, call.CallOptions{}, func(context.Context) ([]byte, error) {
+		return nil, call.PermissionDenied
+	})
+	if !errors.Is(err, call.PermissionDenied) {
+		t.Fatalf("got %v, want %v", err, call.PermissionDenied)
+	}
+}
+
 // TestMultipleEndpoints tests that RPC calls succeed when the resolver returns
 // a constant set of multiple endpoints.
 func TestMultipleEndpoints(t *testing.T) {
@@ -1761,6 +2045,31 @@ func (c *readErrorInjector) Read(b []byte) (int, error) {
 	return n, nil
 }
 
+// countingConn counts the number of bytes written and read.
+type countingConn struct {
+	connWrapper
+	written atomic.Int64
+	read    atomic.Int64
+}
+
+var _ net.Conn = &countingConn{}
+
+func (c *countingConn) Write(b []byte) (int, error) {
+	n, err := c.connWrapper.Write(b)
+	c.written.Add(int64(n))
+	return n, err
+}
+
+func (c *countingConn) Read(b []byte) (int, error) {
+	n, err := c.connWrapper.Read(b)
+	c.read.Add(int64(n))
+	return n, err
+}
+
+func (c *countingConn) counts() (written, read int64) {
+	return c.written.Load(), c.read.Load()
+}
+
 // closeMock records whether Close was called.
 type closeMock struct {
 	connWrapper
@@ -1797,3 +2106,288 @@ func sizeString(s int) string {
 func logger(t testing.TB) *slog.Logger {
 	return logging.NewTestSlogger(t, testing.Verbose())
 }
+
+// TestHedging tests that hedged calls to a stalled replica are answered by
+// another replica, and that the stalled calls are canceled.
+func TestHedging(t *testing.T) {
+	ctx := context.Background()
+
+	// The slow replica answers quickly, until it stalls.
+	var stalled atomic.Bool
+	var canceled atomic.Int32
+	slow := makeHandlerMap()
+	slow.Set("", "who", func(ctx context.Context, _ []byte) ([]byte, error) {
+		if !stalled.Load() {
+			return []byte("slow"), nil
+		}
+		<-ctx.Done()
+		canceled.Add(1)
+		return nil, ctx.Err()
+	})
+	resolver := call.NewConstantResolver(
+		&pipeEndpoint{name: "slow", handlers: slow, t: t},
+		server(t, "fast"),
+	)
+	client, err := call.Connect(ctx, resolver, call.ClientOptions{Logger: logger(t)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	// Record the latencies of the method, before the replica stalls.
+	opts := call.CallOptions{Retry: true, Hedge: true}
+	for i := 0; i < 100; i++ {
+		if _, err := client.Call(ctx, whoKey, nil, opts); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Every call is answered by the fast replica.
+	stalled.Store(true)
+	ctx, cancel := context.WithTimeout(ctx, testTimeout)
+	defer cancel()
+	for i := 0; i < 20; i++ {
+		result, err := client.Call(ctx, whoKey, nil, opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := string(result), "fast"; got != want {
+			t.Fatalf("got %q, want %q", got, want)
+		}
+	}
+	waitUntil(t, func() bool { return canceled.Load() > 0 })
+}
+
+// TestHedgeFailsFast tests that a hedged call whose hedge fails returns the
+// reply of the call it hedges.
+func TestHedgeFailsFast(t *testing.T) {
+	ctx := context.Background()
+
+	// Once broken, the slow replica answers slowly, and the broken replica
+	// fails right away.
+	var broken atomic.Bool
+	slow := makeHandlerMap()
+	slow.Set("", "who", func(context.Context, []byte) ([]byte, error) {
+		if broken.Load() {
+			time.Sleep(shortDelay)
+		}
+		return []byte("slow"), nil
+	})
+	failing := makeHandlerMap()
+	failing.Set("", "who", func(context.Context, []byte) ([]byte, error) {
+		if broken.Load() {
+			return nil, fmt.Errorf("broken")
+		}
+		return []byte("broken"), nil
+	})
+
+	// Calls are sent to the broken replica, unless preferSlow is set, in
+	// which case the next call is sent to the slow replica.
+	var preferSlow atomic.Bool
+	balancer := call.BalancerFunc(func(conns []call.ReplicaConnection, _ call.CallOptions) (call.ReplicaConnection, bool) {
+		want := "pipe://broken"
+		if preferSlow.Swap(false) {
+			want = "pipe://slow"
+		}
+		for _, conn := range conns {
+			if conn.Address() == want {
+				return conn, true
+			}
+		}
+		return nil, false
+	})
+	resolver := call.NewConstantResolver(
+		&pipeEndpoint{name: "slow", handlers: slow, t: t},
+		&pipeEndpoint{name: "broken", handlers: failing, t: t},
+	)
+	client, err := call.Connect(ctx, resolver, call.ClientOptions{Logger: logger(t), Balancer: balancer})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	// Record the latencies of the method, before the replicas break.
+	opts := call.CallOptions{Hedge: true}
+	for i := 0; i < 100; i++ {
+		if _, err := client.Call(ctx, whoKey, nil, opts); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The calls are sent to the slow replica, and hedged on the broken one,
+	// which fails first. The calls still succeed.
+	broken.Store(true)
+	ctx, cancel := context.WithTimeout(ctx, testTimeout)
+	defer cancel()
+	for i := 0; i < 5; i++ {
+		preferSlow.Store(true)
+		result, err := client.Call(ctx, whoKey, nil, opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := string(result), "slow"; got != want {
+			t.Fatalf("got %q, want %q", got, want)
+		}
+	}
+}
+
+// handlersListener is a TCP listener that serves the provided handlers.
+type handlersListener struct {
+	net.Listener
+	handlers *call.HandlerMap
+}
+
+func (l handlersListener) Accept() (net.Conn, *call.HandlerMap, error) {
+	conn, err := l.Listener.Accept()
+	return conn, l.handlers, err
+}
+
+// TestAdmissionControl tests that a server rejects the calls that exceed the
+// concurrency limit of their method, and that rejected calls are retried.
+func TestAdmissionControl(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	// At most one call to "block" runs at a time.
+	started := make(chan struct{}, 10)
+	hmap := makeHandlerMap()
+	hmap.Set("", "block", func(ctx context.Context, _ []byte) ([]byte, error) {
+		started <- struct{}{}
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+	blockKey := call.MakeMethodKey("", "block")
+	lis, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go call.Serve(ctx, handlersListener{lis, hmap}, call.ServerOptions{
+		Logger: logger(t),
+		Admission: func(_, method string) call.AdmissionOptions {
+			if method == "block" {
+				return call.AdmissionOptions{MaxConcurrency: 1}
+			}
+			return call.AdmissionOptions{}
+		},
+	})
+	connect := func(opts call.ClientOptions) call.Connection {
+		opts.Logger = logger(t)
+		client, err := call.Connect(ctx, call.NewConstantResolver(call.TCP(lis.Addr().String())), opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(client.Close)
+		return client
+	}
+	client := connect(call.ClientOptions{RetryBudget: call.RetryBudgetOptions{MinPerSecond: 0.001}})
+
+	// Run a call to "block".
+	blockCtx, unblock := context.WithCancel(ctx)
+	defer unblock()
+	blocked := make(chan error, 1)
+	go func() {
+		_, err := client.Call(blockCtx, blockKey, nil, call.CallOptions{})
+		blocked <- err
+	}()
+	<-started
+
+	// Calls to other methods are not limited.
+	if _, err := client.Call(ctx, echoKey, []byte("hello"), call.CallOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Other calls to "block" are rejected, and retried until the retry budget
+	// runs out.
+	if _, err := client.Call(ctx, blockKey, nil, call.CallOptions{}); !errors.Is(err, call.Overloaded) {
+		t.Fatalf("got %v, want %v", err, call.Overloaded)
+	}
+
+	// A rejected call runs once the running call ends.
+	unlimited := connect(call.ClientOptions{RetryBudget: call.RetryBudgetOptions{Ratio: -1}})
+	go unlimited.Call(ctx, blockKey, nil, call.CallOptions{})
+	time.Sleep(shortDelay)
+	unblock()
+	if err := <-blocked; !errors.Is(err, context.Canceled) {
+		t.Fatalf("got %v, want %v", err, context.Canceled)
+	}
+	select {
+	case <-started:
+	case <-ctx.Done():
+		t.Fatal("retried call never ran")
+	}
+}
+
+// freezingEndpoint is an endpoint whose connections stop delivering messages
+// to the server once the endpoint is frozen, like a half-open connection.
+type freezingEndpoint struct {
+	call.Endpoint
+	frozen atomic.Bool
+}
+
+func (f *freezingEndpoint) Dial(ctx context.Context) (net.Conn, error) {
+	c, err := f.Endpoint.Dial(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &freezingConn{connWrapper{c}, &f.frozen}, nil
+}
+
+// freezingConn is a connection of a freezingEndpoint.
+type freezingConn struct {
+	connWrapper
+	frozen *atomic.Bool
+}
+
+func (f *freezingConn) Write(b []byte) (int, error) {
+	if f.frozen.Load() {
+		// Throw away the message.
+		return len(b), nil
+	}
+	return f.connWrapper.Write(b)
+}
+
+func TestKeepalive(t *testing.T) {
+	ctx := context.Background()
+	frozen := &freezingEndpoint{Endpoint: server(t, "1")}
+	resolver := call.NewConstantResolver(server(t, "0"), frozen)
+	client, err := call.Connect(ctx, resolver, call.ClientOptions{
+		Balancer:          call.RoundRobin(),
+		Logger:            logger(t),
+		KeepaliveInterval: 10 * time.Millisecond,
+		KeepaliveTimeout:  shortDelay,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	// who returns the replicas that serve n calls.
+	who := func(n int) (map[string]bool, error) {
+		replicas := map[string]bool{}
+		for i := 0; i < n; i++ {
+			result, err := client.Call(ctx, whoKey, []byte{}, call.CallOptions{})
+			if err != nil {
+				return nil, err
+			}
+			replicas[string(result)] = true
+		}
+		return replicas, nil
+	}
+	waitUntil(t, func() bool {
+		replicas, err := who(10)
+		return err == nil && len(replicas) == 2
+	})
+
+	// Replica "1" stops responding, but its connections stay open. A call
+	// sent to it fails once it misses a ping, and later calls are only sent
+	// to replica "0".
+	frozen.frozen.Store(true)
+	start := time.Now()
+	waitUntil(t, func() bool {
+		replicas, err := who(10)
+		return err == nil && len(replicas) == 1 && replicas["0"]
+	})
+	if elapsed := time.Since(start); elapsed > shortDelay+delaySlop {
+		t.Fatalf("unresponsive replica removed after %v, want at most %v", elapsed, shortDelay+delaySlop)
+	}
+}
@@ -0,0 +1,242 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package call
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/klauspost/compress/s2"
+	"github.com/klauspost/compress/zstd"
+	imetrics "github.com/sh3lk/mx/internal/metrics"
+	"github.com/sh3lk/mx/metrics"
+)
+
+// Compression is an algorithm used to compress the messages sent over a
+// connection.
+type Compression uint8
+
+const (
+	// Zstd compresses messages using zstd. It compresses better than Snappy,
+	// at a higher CPU cost.
+	Zstd Compression = iota + 1
+
+	// Snappy compresses messages using snappy. It is faster than Zstd, but
+	// compresses worse.
+	Snappy
+)
+
+// supportedCompressions is the bitmask of the compression algorithms that
+// this package can decompress. Bit i is set iff Compression(i) is supported.
+const supportedCompressions = 1<<Zstd | 1<<Snappy
+
+// String implements the fmt.Stringer interface.
+func (c Compression) String() string {
+	switch c {
+	case Zstd:
+		return "zstd"
+	case Snappy:
+		return "snappy"
+	default:
+		return fmt.Sprintf("Compression(%d)", c)
+	}
+}
+
+// ParseCompression returns the compression algorithm with the provided name,
+// as returned by Compression.String. The empty name is parsed as Zstd.
+func ParseCompression(name string) (Compression, error) {
+	switch name {
+	case "", "zstd":
+		return Zstd, nil
+	case "snappy":
+		return Snappy, nil
+	default:
+		return 0, fmt.Errorf("unknown compression %q", name)
+	}
+}
+
+var (
+	compressionBytesUncompressed = metrics.NewCounterMap[compressionLabels](
+		"mx_system_call_compression_bytes_uncompressed",
+		"Number of bytes in compressed MX RPC messages, before compression",
+	)
+	compressionBytesCompressed = metrics.NewCounterMap[compressionLabels](
+		"mx_system_call_compression_bytes_compressed",
+		"Number of bytes in compressed MX RPC messages, after compression",
+	)
+	compressionRatio = metrics.NewHistogramMap[compressionLabels](
+		"mx_system_call_compression_ratio",
+		"Ratio of the uncompressed size to the compressed size of compressed MX RPC messages",
+		[]float64{1, 1.25, 1.5, 2, 3, 5, 10, 20, 50, 100},
+	)
+	compressionIncompressible = metrics.NewCounterMap[compressionLabels](
+		"mx_system_call_compression_incompressible_count",
+		"Count of MX RPC messages sent uncompressed because compression didn't shrink them",
+	)
+	compressionLatencyMicros = metrics.NewHistogramMap[compressionOpLabels](
+		"mx_system_call_compression_latency_micros",
+		"Duration, in microseconds, of MX RPC message compression and decompression",
+		imetrics.GeneratedBuckets,
+	)
+)
+
+type compressionLabels struct {
+	Algorithm string // compression algorithm (e.g., "zstd")
+
+	// Is this a metric implicitly created by the framework?
+	Generated bool `mx:"mx_generated"`
+}
+
+type compressionOpLabels struct {
+	Algorithm string // compression algorithm (e.g., "zstd")
+	Op        string // "compress" or "decompress"
+
+	// Is this a metric implicitly created by the framework?
+	Generated bool `mx:"mx_generated"`
+}
+
+// zstdEncoder and zstdDecoder return the zstd encoder and decoder shared by
+// all connections. Both are safe for concurrent use by EncodeAll and
+// DecodeAll respectively.
+var (
+	zstdEncoder = sync.OnceValue(func() *zstd.Encoder {
+		enc, err := zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.SpeedFastest))
+		if err != nil {
+			panic(fmt.Sprintf("create zstd encoder: %v", err))
+		}
+		return enc
+	})
+	zstdDecoder = sync.OnceValue(func() *zstd.Decoder {
+		dec, err := zstd.NewReader(nil, zstd.WithDecoderConcurrency(0), zstd.WithDecoderMaxMemory(maxMessageSize))
+		if err != nil {
+			panic(fmt.Sprintf("create zstd decoder: %v", err))
+		}
+		return dec
+	})
+)
+
+// compressor compresses the messages sent over a connection. A nil
+// compressor doesn't compress any messages.
+type compressor struct {
+	algo      Compression // algorithm used to compress messages
+	threshold int         // messages larger than threshold bytes are compressed
+
+	uncompressed   *metrics.Counter
+	compressed     *metrics.Counter
+	ratio          *metrics.Histogram
+	incompressible *metrics.Counter
+	latency        *metrics.Histogram
+}
+
+// newCompressor returns a compressor that compresses messages larger than
+// threshold bytes using algo, for a peer that can decompress the algorithms
+// in the provided bitmask (see supportedCompressions). It returns nil if
+// messages shouldn't be compressed, e.g., because threshold is not positive
+// or because the peer can't decompress algo.
+func newCompressor(algo Compression, threshold int, peer uint8) *compressor {
+	if threshold <= 0 || peer&(1<<algo) == 0 || supportedCompressions&(1<<algo) == 0 {
+		return nil
+	}
+	labels := compressionLabels{Algorithm: algo.String(), Generated: true}
+	return &compressor{
+		algo:           algo,
+		threshold:      threshold,
+		uncompressed:   compressionBytesUncompressed.Get(labels),
+		compressed:     compressionBytesCompressed.Get(labels),
+		ratio:          compressionRatio.Get(labels),
+		incompressible: compressionIncompressible.Get(labels),
+		latency: compressionLatencyMicros.Get(compressionOpLabels{
+			Algorithm: algo.String(),
+			Op:        "compress",
+			Generated: true,
+		}),
+	}
+}
+
+// compress returns the compressed concatenation of extraHdr and payload, as
+// the payload of a compressed message (see msg.go). It returns false if the
+// message should be sent uncompressed, either because it is small or because
+// compressing it doesn't shrink it.
+func (c *compressor) compress(extraHdr, payload []byte) ([]byte, bool) {
+	if c == nil {
+		return nil, false
+	}
+	n := len(extraHdr) + len(payload)
+	if n <= c.threshold {
+		return nil, false
+	}
+
+	start := time.Now()
+	src := payload
+	if len(extraHdr) > 0 {
+		src = make([]byte, 0, n)
+		src = append(src, extraHdr...)
+		src = append(src, payload...)
+	}
+	dst := []byte{byte(c.algo)}
+	switch c.algo {
+	case Zstd:
+		dst = zstdEncoder().EncodeAll(src, dst)
+	case Snappy:
+		dst = append(dst, s2.EncodeSnappy(nil, src)...)
+	}
+	c.latency.Put(float64(time.Since(start).Microseconds()))
+
+	if len(dst) >= n {
+		c.incompressible.Inc()
+		return nil, false
+	}
+	c.uncompressed.Add(float64(n))
+	c.compressed.Add(float64(len(dst)))
+	c.ratio.Put(float64(n) / float64(len(dst)))
+	return dst, true
+}
+
+// decompress returns the decompressed payload of a compressed message.
+func decompress(msg []byte) ([]byte, error) {
+	if len(msg) == 0 {
+		return nil, fmt.Errorf("missing compression algorithm")
+	}
+	algo, data := Compression(msg[0]), msg[1:]
+
+	start := time.Now()
+	var out []byte
+	var err error
+	switch algo {
+	case Zstd:
+		out, err = zstdDecoder().DecodeAll(data, nil)
+	case Snappy:
+		var n int
+		n, err = s2.DecodedLen(data)
+		if err == nil && n > maxMessageSize {
+			err = fmt.Errorf("overly large message length %d", n)
+		}
+		if err == nil {
+			out, err = s2.Decode(nil, data)
+		}
+	default:
+		return nil, fmt.Errorf("unknown compression algorithm %d", algo)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("decompress %v message: %w", algo, err)
+	}
+	compressionLatencyMicros.Get(compressionOpLabels{
+		Algorithm: algo.String(),
+		Op:        "decompress",
+		Generated: true,
+	}).Put(float64(time.Since(start).Microseconds()))
+	return out, nil
+}
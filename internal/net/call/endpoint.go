@@ -59,6 +59,19 @@ func Unix(filename string) NetEndpoint {
 	return NetEndpoint{"unix", filename}
 }
 
+// SharedMemory returns an endpoint that exchanges data with a server on the
+// same machine through shared memory. The provided filename is the Unix socket
+// on which the server's ListenSharedMemory listener listens. For example:
+//
+//	SharedMemory("/tmp/shm.sock")
+//
+// The bytes exchanged over shared memory are the same bytes that would be
+// exchanged over a TCP or Unix socket, so a server can serve shared-memory and
+// socket connections alike.
+func SharedMemory(filename string) Endpoint {
+	return shmEndpoint{filename}
+}
+
 // MTLS returns an endpoint that performs MTLS authentication over the
 // underlying endpoint. For example:
 //
@@ -107,6 +120,26 @@ func ParseNetEndpoint(endpoint string) (NetEndpoint, error) {
 	return NetEndpoint{Net: net, Addr: addr}, nil
 }
 
+type shmEndpoint struct {
+	filename string
+}
+
+var _ Endpoint = shmEndpoint{}
+
+// Dial implements the Endpoint interface.
+func (s shmEndpoint) Dial(ctx context.Context) (net.Conn, error) {
+	return dialSharedMemory(ctx, s.filename)
+}
+
+// Address implements the Endpoint interface.
+func (s shmEndpoint) Address() string {
+	return fmt.Sprintf("shm://%s", s.filename)
+}
+
+func (s shmEndpoint) String() string {
+	return s.Address()
+}
+
 type tlsEndpoint struct {
 	config *tls.Config
 	ep     Endpoint
@@ -32,6 +32,22 @@ const (
 	// server is unreachable. Check for it via errors.Is(call.Unreachable).
 	Unreachable
 
+	// CircuitOpen is the type of the error returned by a call that is
+	// rejected without being sent, because the circuit breaker of the
+	// connection is open. Check for it via errors.Is(call.CircuitOpen).
+	CircuitOpen
+
+	// Overloaded is the type of the error returned by a call that is
+	// rejected by the server, without being run, because the server is
+	// overloaded. Check for it via errors.Is(call.Overloaded).
+	Overloaded
+
+	// PermissionDenied is the type of the error returned by a call that is
+	// rejected by the server, without being run, because the caller is not
+	// allowed to call the method. Check for it via
+	// errors.Is(call.PermissionDenied).
+	PermissionDenied
+
 	// TODO: Decide what error most applications will want to check for. We may
 	// need to combine CommunicationError and Unreachable. We may also want to
 	// make errors.Is(CommunicationError) return true for both types of errors.
@@ -45,6 +61,12 @@ func (e transportError) Error() string {
 		return "communication error"
 	case Unreachable:
 		return "unreachable"
+	case CircuitOpen:
+		return "circuit breaker open"
+	case Overloaded:
+		return "server overloaded"
+	case PermissionDenied:
+		return "permission denied"
 	default:
 		return fmt.Sprintf("unknown error %d", e)
 	}
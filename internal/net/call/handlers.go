@@ -37,22 +37,29 @@ func MakeMethodKey(component, method string) MethodKey {
 // Handler is a function that handles remote procedure calls. Regular
 // application errors should be serialized in the returned bytes. A Handler
 // should only return a non-nil error if the handler was not able to execute
-// successfully.
+// successfully. args is only valid until the Handler returns.
 type Handler func(ctx context.Context, args []byte) ([]byte, error)
 
+// StreamHandler is a function that handles streaming remote procedure calls.
+// in holds the values streamed by the caller. The returned stream, if not
+// nil, holds the values to stream back to the caller.
+type StreamHandler func(ctx context.Context, args []byte, in codegen.ByteStream) ([]byte, codegen.ByteStream, error)
+
 // HandlerMap is a mapping from MethodID to a Handler. The zero value for a
 // HandlerMap is an empty map.
 type HandlerMap struct {
-	handlers map[MethodKey]Handler
-	names    map[MethodKey]string
+	handlers       map[MethodKey]Handler
+	streamHandlers map[MethodKey]StreamHandler
+	names          map[MethodKey]string
 }
 
 // NewHandlerMap returns a handler map to which the server handlers can
 // be added.
 func NewHandlerMap() *HandlerMap {
 	return &HandlerMap{
-		handlers: map[MethodKey]Handler{},
-		names:    map[MethodKey]string{},
+		handlers:       map[MethodKey]Handler{},
+		streamHandlers: map[MethodKey]StreamHandler{},
+		names:          map[MethodKey]string{},
 	}
 }
 
@@ -63,6 +70,14 @@ func (hm *HandlerMap) Set(component, method string, handler Handler) {
 	hm.names[fp] = component + "." + method
 }
 
+// SetStream registers a handler for the specified streaming method of
+// component.
+func (hm *HandlerMap) SetStream(component, method string, handler StreamHandler) {
+	fp := MakeMethodKey(component, method)
+	hm.streamHandlers[fp] = handler
+	hm.names[fp] = component + "." + method
+}
+
 // AddHandlers adds handlers for all methods of the component with the
 // specified name. The handlers invoke methods on the specified impl.
 func (hm *HandlerMap) AddHandlers(name string, impl any) error {
@@ -72,8 +87,15 @@ func (hm *HandlerMap) AddHandlers(name string, impl any) error {
 	}
 	addLoad := func(uint64, float64) {} // We ignore load updates for now.
 	serverStub := reg.ServerStubFn(impl, addLoad)
+	streamServer, _ := serverStub.(codegen.StreamServer)
 	for i, n := 0, reg.Iface.NumMethod(); i < n; i++ {
 		mname := reg.Iface.Method(i).Name
+		if streamServer != nil {
+			if handler := streamServer.GetStreamStubFn(mname); handler != nil {
+				hm.SetStream(reg.Name, mname, handler)
+				continue
+			}
+		}
 		handler := serverStub.GetStubFn(mname)
 		hm.Set(reg.Name, mname, handler)
 	}
@@ -0,0 +1,205 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package call
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sh3lk/mx/metrics"
+)
+
+// # Hedging
+//
+// A hedged call is first sent to a single replica. If the replica hasn't
+// replied after a high percentile of the recent latencies of the method, the
+// call is sent again to a different replica. The first successful reply to
+// arrive is returned, and the other call is canceled with a cancel message.
+// The call only fails if both calls fail.
+//
+// The latencies of a method are tracked by a hedger. A method isn't hedged
+// until the hedger has recorded a few latencies.
+
+const (
+	// Number of recent latencies a hedger keeps per method.
+	hedgeSamples = 128
+
+	// Number of latencies a hedger needs before a method can be hedged.
+	minHedgeSamples = 16
+
+	// The hedging delay is recomputed every time this many latencies are
+	// recorded.
+	hedgeRecompute = 8
+)
+
+// errNoHedgeReplica is returned by a hedge that can't be sent to a replica
+// other than the replica of the call it hedges.
+var errNoHedgeReplica = errors.New("no replica to hedge the call on")
+
+var (
+	hedgeableCalls = metrics.NewCounterMap[hedgeLabels](
+		"mx_system_call_hedgeable_count",
+		"Count of MX RPC calls to hedged methods",
+	)
+	hedges = metrics.NewCounterMap[hedgeLabels](
+		"mx_system_call_hedge_count",
+		"Count of MX RPC calls that were hedged",
+	)
+	hedgeWins = metrics.NewCounterMap[hedgeLabels](
+		"mx_system_call_hedge_win_count",
+		"Count of hedged MX RPC calls where the hedge replied first",
+	)
+)
+
+type hedgeLabels struct {
+	Component string // called component
+	Method    string // called method
+
+	// Is this a metric implicitly created by the framework?
+	Generated bool `mx:"mx_generated"`
+}
+
+// hedger tracks the recent latencies of a method, and the delay after which
+// calls to the method are hedged.
+type hedger struct {
+	percentile float64 // see ClientOptions.HedgePercentile
+
+	mu      sync.Mutex
+	samples [hedgeSamples]time.Duration // ring buffer of recent latencies
+	n       int                         // number of recorded latencies
+	delay   time.Duration               // hedging delay
+}
+
+// record records the latency of a successful call.
+func (h *hedger) record(latency time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.samples[h.n%hedgeSamples] = latency
+	h.n++
+	if h.n >= minHedgeSamples && h.n%hedgeRecompute == 0 {
+		sorted := slices.Clone(h.samples[:min(h.n, hedgeSamples)])
+		slices.Sort(sorted)
+		i := int(math.Ceil(h.percentile*float64(len(sorted)))) - 1
+		h.delay = sorted[max(0, min(i, len(sorted)-1))]
+	}
+}
+
+// hedgeDelay returns the delay after which a call should be hedged. It
+// returns false if the call shouldn't be hedged, because too few latencies
+// have been recorded.
+func (h *hedger) hedgeDelay() (time.Duration, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.delay, h.n >= minHedgeSamples
+}
+
+// attempt holds the state of one of the two calls of a hedged call.
+type attempt struct {
+	avoid *clientConnection // connection the call must not be sent to, or nil
+
+	conn    atomic.Pointer[clientConnection] // connection of the latest try
+	started atomic.Bool                      // has the call been sent?
+}
+
+// hedger returns the hedger of the method with the provided key.
+// REQUIRES: rc.mu is not held.
+func (rc *reconnectingConnection) hedger(h MethodKey) *hedger {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	hg, ok := rc.hedgers[h]
+	if !ok {
+		hg = &hedger{percentile: rc.opts.HedgePercentile}
+		rc.hedgers[h] = hg
+	}
+	return hg
+}
+
+// hedgedCall makes an RPC over connection c, like call, hedging it if it is
+// slow to return.
+func (rc *reconnectingConnection) hedgedCall(ctx context.Context, h MethodKey, arg []byte, opts CallOptions) ([]byte, error) {
+	labels := hedgeLabels{Component: rc.opts.Component, Method: opts.Method, Generated: true}
+	hedgeableCalls.Get(labels).Inc()
+	hg := rc.hedger(h)
+	start := time.Now()
+	delay, ok := hg.hedgeDelay()
+	if !ok {
+		response, err := rc.call(ctx, h, arg, opts, nil)
+		if err == nil {
+			hg.record(time.Since(start))
+		}
+		return response, err
+	}
+
+	// The losing call may still be sending arg after hedgedCall returns, when
+	// the caller is free to reuse arg, so the calls send a copy of it.
+	arg = bytes.Clone(arg)
+
+	type result struct {
+		response []byte
+		err      error
+		hedge    bool // is this the result of the hedge?
+	}
+	results := make(chan result, 2)
+	run := func(ctx context.Context, a *attempt, hedge bool) {
+		response, err := rc.call(ctx, h, arg, opts, a)
+		results <- result{response, err, hedge}
+	}
+
+	// Send the call.
+	primaryCtx, cancelPrimary := context.WithCancel(ctx)
+	defer cancelPrimary()
+	primary := &attempt{}
+	go run(primaryCtx, primary, false)
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	var r result
+	select {
+	case r = <-results:
+	case <-timer.C:
+		// The call is slow. Hedge it.
+		hedgeCtx, cancelHedge := context.WithCancel(ctx)
+		defer cancelHedge()
+		hedge := &attempt{avoid: primary.conn.Load()}
+		go run(hedgeCtx, hedge, true)
+
+		r = <-results
+		if r.err != nil {
+			// The first call to return failed, or the hedge wasn't sent (see
+			// errNoHedgeReplica). Wait for the other call, and return its
+			// result, unless both calls failed, in which case the error of
+			// the hedged call is returned.
+			if other := <-results; other.err == nil || r.hedge {
+				r = other
+			}
+		}
+		if hedge.started.Load() {
+			hedges.Get(labels).Inc()
+			if r.hedge {
+				hedgeWins.Get(labels).Inc()
+			}
+		}
+	}
+	if r.err == nil {
+		hg.record(time.Since(start))
+	}
+	return r.response, r.err
+}
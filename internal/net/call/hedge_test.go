@@ -0,0 +1,52 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package call
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHedgerDelay(t *testing.T) {
+	h := &hedger{percentile: 0.9}
+
+	// Too few latencies are recorded to hedge.
+	for i := 1; i < minHedgeSamples; i++ {
+		h.record(time.Duration(i) * time.Millisecond)
+		if _, ok := h.hedgeDelay(); ok {
+			t.Fatalf("hedging after %d latencies", i)
+		}
+	}
+
+	// The delay is the percentile of the recent latencies.
+	for i := minHedgeSamples; i <= hedgeSamples; i++ {
+		h.record(time.Duration(i) * time.Millisecond)
+	}
+	delay, ok := h.hedgeDelay()
+	if !ok {
+		t.Fatalf("not hedging after %d latencies", hedgeSamples)
+	}
+	if want := 116 * time.Millisecond; delay != want {
+		t.Errorf("delay: got %v, want %v", delay, want)
+	}
+
+	// Old latencies are forgotten.
+	for i := 0; i < hedgeSamples; i++ {
+		h.record(time.Millisecond)
+	}
+	if delay, _ := h.hedgeDelay(); delay != time.Millisecond {
+		t.Errorf("delay: got %v, want %v", delay, time.Millisecond)
+	}
+}
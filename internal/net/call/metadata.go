@@ -18,6 +18,7 @@ import (
 	"context"
 
 	"github.com/sh3lk/mx/metadata"
+	"github.com/sh3lk/mx/priority"
 	"github.com/sh3lk/mx/runtime/codegen"
 )
 
@@ -52,3 +53,64 @@ func readContextMetadata(ctx context.Context, dec *codegen.Decoder) context.Cont
 	}
 	return metadata.NewContext(ctx, res)
 }
+
+// writeCallerInfo serializes the priority class of the context, the name of
+// the calling component, and the caller's bearer token into enc. All three are
+// optional fields at the end of the header, so that peers that predate them
+// ignore them. A field is omitted if it is empty (or, for the priority class,
+// the default class) and the fields after it are omitted.
+func writeCallerInfo(ctx context.Context, enc *codegen.Encoder, caller, token string) {
+	c := priority.FromContext(ctx)
+	if c == priority.Interactive && caller == "" && token == "" {
+		return
+	}
+	enc.Uint8(uint8(c))
+	if caller == "" && token == "" {
+		return
+	}
+	enc.String(caller)
+	if token != "" {
+		enc.String(token)
+	}
+}
+
+// readCallerInfo returns ctx with the priority class, the calling component,
+// and the caller's bearer token (if any) stored in dec.
+func readCallerInfo(ctx context.Context, dec *codegen.Decoder) context.Context {
+	if dec.Empty() {
+		return ctx
+	}
+	ctx = priority.NewContext(ctx, priority.Class(dec.Uint8()))
+	if dec.Empty() {
+		return ctx
+	}
+	if caller := dec.String(); caller != "" {
+		ctx = context.WithValue(ctx, callerKey{}, caller)
+	}
+	if dec.Empty() {
+		return ctx
+	}
+	return context.WithValue(ctx, tokenKey{}, dec.String())
+}
+
+// callerKey is the context key of the calling component.
+type callerKey struct{}
+
+// Caller returns the name of the component that made the call handled with
+// the provided context, or the empty string if it is unknown. See
+// CallOptions.Caller.
+func Caller(ctx context.Context) string {
+	caller, _ := ctx.Value(callerKey{}).(string)
+	return caller
+}
+
+// tokenKey is the context key of the caller's bearer token.
+type tokenKey struct{}
+
+// Token returns the bearer token sent by the client that made the call
+// handled with the provided context, or the empty string if the client didn't
+// send one. See ClientOptions.Token.
+func Token(ctx context.Context) string {
+	token, _ := ctx.Value(tokenKey{}).(string)
+	return token
+}
@@ -31,23 +31,71 @@ const (
 	responseMessage
 	responseError
 	cancelMessage
+	streamValueMessage
+	streamEndMessage
+	streamCreditMessage
+	responseOverloaded
+	pingMessage
+	pongMessage
 	// Other types to add?
 	// - chunked request/response messages?
-	// - health check
 	// - server status info
 )
 
+// compressedFlag is set in the type of a message if the message payload is
+// compressed.
+const compressedFlag messageType = 0x80
+
 // version holds the protocol version number.
 type version uint32
 
 const (
-	initialVersion version = iota
+	initialVersion     version = iota
+	streamVersion              // adds streaming calls
+	compressionVersion         // adds compression negotiation
+	admissionVersion           // adds responseOverloaded
+	keepaliveVersion           // adds pingMessage and pongMessage
 )
 
-const currentVersion = initialVersion
+const currentVersion = keepaliveVersion
 
 const hdrLenLen = uint32(4) // size of the header length included in each message
 
+const maxMessageSize = 100 << 20 // maximum size of a message payload
+
+// maxPooledBufferSize is the size of the largest buffer kept in bufferPool.
+// Larger messages use freshly allocated buffers.
+const maxPooledBufferSize = 64 << 10
+
+// bufferPool holds the buffers used to read requests and write messages.
+var bufferPool = sync.Pool{
+	New: func() any { return new([]byte) },
+}
+
+// getBuffer returns an empty buffer from bufferPool.
+func getBuffer() *[]byte {
+	return bufferPool.Get().(*[]byte)
+}
+
+// putBuffer returns a buffer obtained from getBuffer to bufferPool. The
+// buffer must not be used after the call.
+func putBuffer(buf *[]byte) {
+	if cap(*buf) <= maxPooledBufferSize {
+		*buf = (*buf)[:0]
+		bufferPool.Put(buf)
+	}
+}
+
+// sizeBuffer returns *buf resized to n bytes, reallocating *buf if it is too
+// small.
+func sizeBuffer(buf *[]byte, n int) []byte {
+	if cap(*buf) < n {
+		*buf = make([]byte, n)
+	}
+	*buf = (*buf)[:n]
+	return *buf
+}
+
 // # Message formats
 //
 // All messages have the following format:
@@ -58,8 +106,19 @@ const hdrLenLen = uint32(4) // size of the header length included in each messag
 //
 // The format of payload depends on the message type.
 //
+// If the compressedFlag bit of type is set, the payload is compressed. A
+// compressed payload has the following format:
+//    algorithm  [1]byte  -- Compression used to compress the payload
+//    data                -- compressed payload
+//
+// A sender only compresses messages if the receiver has advertised, in its
+// versionMessage, that it can decompress them.
+//
 // versionMessage: this is the first message sent on a connection by both sides.
-//    version  [4]byte
+//    version       [4]byte
+//    compressions  [1]byte  -- bitmask of the Compressions the sender can
+//                              decompress; bit i is set iff Compression(i)
+//                              is supported. Sent since compressionVersion.
 //
 // requestMessage:
 //    headerLen         [4]byte         -- length of the encoded header
@@ -74,6 +133,11 @@ const hdrLenLen = uint32(4) // size of the header length included in each messag
 //   Deadline        int64
 //   TraceContext    [25]byte
 //   MetadataContext map[string]string
+//   Priority        uint8  -- optional; omitted for priority.Interactive
+//                             if Caller is omitted
+//   Caller          string -- optional; the name of the calling component,
+//                             omitted if empty and Token is omitted
+//   Token           string -- optional; the caller's bearer token
 // }
 //
 // responseMessage:
@@ -82,8 +146,45 @@ const hdrLenLen = uint32(4) // size of the header length included in each messag
 // responseError:
 //    payload holds error serialization
 //
+// responseOverloaded: sent instead of a response if the server rejected the
+// request without running it, because it is overloaded. Sent since
+// admissionVersion.
+//    payload is empty
+//
 // cancelMessage:
 //    payload is empty
+//
+// pingMessage: sent by the client to check that the server is alive. Sent
+// since keepaliveVersion.
+//    payload is empty
+//
+// pongMessage: sent by the server in reply to a pingMessage, with the id of
+// the pingMessage.
+//    payload is empty
+//
+// streamValueMessage:
+//    payload holds the serialization of a single value in a stream
+//
+// streamEndMessage:
+//    payload is empty if the stream ended successfully, and holds an error
+//    serialization otherwise
+//
+// streamCreditMessage:
+//    credit   [4]byte -- number of additional stream values the sender of the
+//                        message is willing to receive
+//
+// # Streams
+//
+// A request for a streaming method is followed by the two streams of the
+// call, one in each direction, that share the request id. The client sends
+// the values of its stream (if any) after the request message, and the server
+// sends the values of its stream after a successful response message. Both
+// streams are terminated by a streamEndMessage.
+//
+// Streams are flow controlled. A sender may only send as many values as the
+// receiver has granted it credits via streamCreditMessages. The receiver
+// grants an initial window of credits when the stream starts, and grants
+// more credits as its values are consumed.
 
 // writeMessage formats and sends a message over w.
 //
@@ -91,8 +192,14 @@ const hdrLenLen = uint32(4) // size of the header length included in each messag
 // (Allowing two arguments to form the payload avoids unnecessary allocation
 // and copying when we want to prepend some data to application supplied data).
 //
+// If comp is not nil, the message payload may be compressed using comp.
+//
 // The write is guarded by wlock, which must not be locked when passed in.
-func writeMessage(w io.Writer, wlock *sync.Mutex, mt messageType, id uint64, extraHdr []byte, payload []byte, flattenLimit int) error {
+func writeMessage(w io.Writer, wlock *sync.Mutex, comp *compressor, mt messageType, id uint64, extraHdr []byte, payload []byte, flattenLimit int) error {
+	if compressed, ok := comp.compress(extraHdr, payload); ok {
+		mt |= compressedFlag
+		extraHdr, payload = nil, compressed
+	}
 	nh, np := len(extraHdr), len(payload)
 	size := 16 + nh + np
 	if size > flattenLimit {
@@ -133,7 +240,9 @@ func writeChunked(w io.Writer, wlock *sync.Mutex, mt messageType, id uint64, ext
 // a single flat byte slice, and writes it into w using a single w.Write() call.
 func writeFlat(w io.Writer, wlock *sync.Mutex, mt messageType, id uint64, extraHdr []byte, payload []byte) error {
 	nh, np := len(extraHdr), len(payload)
-	data := make([]byte, 16+nh+np)
+	buf := getBuffer()
+	defer putBuffer(buf)
+	data := sizeBuffer(buf, 16+nh+np)
 	binary.LittleEndian.PutUint64(data[0:], id)
 	val := uint64(mt) | (uint64(nh+np) << 8)
 	binary.LittleEndian.PutUint64(data[8:], val)
@@ -153,8 +262,12 @@ func writeFlat(w io.Writer, wlock *sync.Mutex, mt messageType, id uint64, extraH
 	return err
 }
 
-// readMessage reads, parses, and returns the next message from r.
-func readMessage(r io.Reader) (messageType, uint64, []byte, error) {
+// readMessage reads, parses, and returns the next message from r. If the
+// message is compressed, readMessage returns the decompressed payload.
+//
+// If buf is not nil, the payload may be read into *buf, which is grown if
+// needed. The returned payload is then only valid until *buf is reused.
+func readMessage(r io.Reader, buf *[]byte) (messageType, uint64, []byte, error) {
 	// Read the header.
 	const headerSize = 16
 	var hdr [headerSize]byte
@@ -167,41 +280,61 @@ func readMessage(r io.Reader) (messageType, uint64, []byte, error) {
 	w2 := binary.LittleEndian.Uint64(hdr[8:])
 	mt := messageType(w2 & 0xff)
 	dataLen := w2 >> 8
-	const maxSize = 100 << 20
-	if dataLen > maxSize {
+	if dataLen > maxMessageSize {
 		return 0, 0, nil, fmt.Errorf("overly large message length %d", dataLen)
 	}
 
 	// Read the payload.
-	msg := make([]byte, int(dataLen))
+	var msg []byte
+	if buf != nil && dataLen <= maxPooledBufferSize {
+		msg = sizeBuffer(buf, int(dataLen))
+	} else {
+		msg = make([]byte, int(dataLen))
+	}
 	if _, err := io.ReadFull(r, msg); err != nil {
 		return 0, 0, nil, err
 	}
+	if mt&compressedFlag != 0 {
+		mt &^= compressedFlag
+		var err error
+		if msg, err = decompress(msg); err != nil {
+			return 0, 0, nil, err
+		}
+	}
 	return mt, id, msg, nil
 }
 
-// writeVersion sends my version number to the peer.
+// writeVersion sends my version number, along with the compression
+// algorithms I can decompress, to the peer.
 func writeVersion(w io.Writer, wlock *sync.Mutex) error {
-	var msg [4]byte
+	var msg [5]byte
 	binary.LittleEndian.PutUint32(msg[:], uint32(currentVersion))
+	msg[4] = supportedCompressions
 	return writeFlat(w, wlock, versionMessage, 0, nil, msg[:])
 }
 
 // getVersion extracts the version number sent by the peer and picks the
-// appropriate version number to use for communicating with the peer.
-func getVersion(id uint64, msg []byte) (version, error) {
+// appropriate version number to use for communicating with the peer. It also
+// returns the bitmask of compression algorithms the peer can decompress.
+func getVersion(id uint64, msg []byte) (version, uint8, error) {
 	if id != 0 {
-		return 0, fmt.Errorf("invalid ID %d in handshake", id)
+		return 0, 0, fmt.Errorf("invalid ID %d in handshake", id)
 	}
 	// Allow messages longer than needed so that future updates can send more info.
 	if len(msg) < 4 {
-		return 0, fmt.Errorf("bad version message length %d, must be >= 4", len(msg))
+		return 0, 0, fmt.Errorf("bad version message length %d, must be >= 4", len(msg))
 	}
 	v := binary.LittleEndian.Uint32(msg)
 
+	// Peers older than compressionVersion can't decompress messages.
+	var compressions uint8
+	if v >= uint32(compressionVersion) && len(msg) >= 5 {
+		compressions = msg[4]
+	}
+
 	// We use the minimum of the peer and my version numbers.
 	if v < uint32(currentVersion) {
-		return version(v), nil
+		return version(v), compressions, nil
 	}
-	return currentVersion, nil
+	return currentVersion, compressions, nil
 }
@@ -16,6 +16,7 @@ package call
 
 import (
 	"bytes"
+	"encoding/binary"
 	"fmt"
 	"math/rand"
 	"net"
@@ -50,7 +51,7 @@ func TestConcurrentWrites(t *testing.T) {
 			if rand.Int()%2 == 0 {
 				flattenLimit = 9999999
 			}
-			if err := writeMessage(client, &wlock, requestMessage, uint64(id), extraHdr, payload, flattenLimit); err != nil {
+			if err := writeMessage(client, &wlock, nil, requestMessage, uint64(id), extraHdr, payload, flattenLimit); err != nil {
 				return err
 			}
 			id += numWriters
@@ -60,7 +61,7 @@ func TestConcurrentWrites(t *testing.T) {
 
 	reader := func() error {
 		for i := 0; i < numWriters*numWrites; i++ {
-			mt, id, payload, err := readMessage(server)
+			mt, id, payload, err := readMessage(server, nil)
 			if err != nil {
 				return err
 			}
@@ -100,6 +101,95 @@ func TestConcurrentWrites(t *testing.T) {
 	}
 }
 
+func TestCompressedWrites(t *testing.T) {
+	extraHdr := []byte("header")
+	compressible := bytes.Repeat([]byte("compressible "), 100)
+	incompressible := make([]byte, 1000)
+	rand.New(rand.NewSource(0)).Read(incompressible)
+
+	for _, test := range []struct {
+		name       string
+		comp       *compressor
+		payload    []byte
+		compressed bool
+	}{
+		{"Zstd", newCompressor(Zstd, 100, supportedCompressions), compressible, true},
+		{"Snappy", newCompressor(Snappy, 100, supportedCompressions), compressible, true},
+		{"BelowThreshold", newCompressor(Zstd, 10000, supportedCompressions), compressible, false},
+		{"Incompressible", newCompressor(Zstd, 100, supportedCompressions), incompressible, false},
+		{"NoCompressor", nil, compressible, false},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			var wlock sync.Mutex
+			if err := writeMessage(&buf, &wlock, test.comp, responseMessage, 42, extraHdr, test.payload, 0); err != nil {
+				t.Fatal(err)
+			}
+			wire := buf.Bytes()
+			if got := messageType(wire[8])&compressedFlag != 0; got != test.compressed {
+				t.Errorf("compressed: got %t, want %t", got, test.compressed)
+			}
+			if test.compressed && len(wire) >= 16+len(extraHdr)+len(test.payload) {
+				t.Errorf("compressed message is %d bytes, want fewer than %d", len(wire), 16+len(extraHdr)+len(test.payload))
+			}
+
+			mt, id, msg, err := readMessage(&buf, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if mt != responseMessage || id != 42 {
+				t.Errorf("got (%d, %d), want (%d, 42)", mt, id, responseMessage)
+			}
+			if want := append(append([]byte{}, extraHdr...), test.payload...); !bytes.Equal(msg, want) {
+				t.Errorf("bad payload: got %d bytes, want %d", len(msg), len(want))
+			}
+		})
+	}
+}
+
+func TestCompressionNegotiation(t *testing.T) {
+	// A version message sent by a peer that predates compression.
+	old := make([]byte, 4)
+	binary.LittleEndian.PutUint32(old, uint32(streamVersion))
+
+	// A version message sent by the current version.
+	var buf bytes.Buffer
+	var wlock sync.Mutex
+	if err := writeVersion(&buf, &wlock); err != nil {
+		t.Fatal(err)
+	}
+	_, _, current, err := readMessage(&buf, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range []struct {
+		name        string
+		msg         []byte
+		wantVersion version
+		compression bool
+	}{
+		{"Old", old, streamVersion, false},
+		{"Current", current, currentVersion, true},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			v, compressions, err := getVersion(0, test.msg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if v != test.wantVersion {
+				t.Errorf("version: got %d, want %d", v, test.wantVersion)
+			}
+			for _, algo := range []Compression{Zstd, Snappy} {
+				comp := newCompressor(algo, 1, compressions)
+				if got := comp != nil; got != test.compression {
+					t.Errorf("%v compression: got %t, want %t", algo, got, test.compression)
+				}
+			}
+		})
+	}
+}
+
 func BenchmarkReadWrite(b *testing.B) {
 	for _, network := range []string{"tcp"} {
 		out, in := net.Pipe()
@@ -115,7 +205,7 @@ func BenchmarkReadWrite(b *testing.B) {
 					done := make(chan bool)
 					go func() {
 						for n := 0; n < numIters; n++ {
-							if _, _, _, err := readMessage(in); err != nil {
+							if _, _, _, err := readMessage(in, nil); err != nil {
 								panic(fmt.Sprint(err))
 							}
 						}
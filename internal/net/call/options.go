@@ -15,6 +15,7 @@
 package call
 
 import (
+	"context"
 	"log/slog"
 	"time"
 
@@ -26,6 +27,9 @@ import (
 const (
 	defaultWriteFlattenLimit     = 4 << 10
 	defaultInlineHandlerDuration = 20 * time.Microsecond
+	defaultHedgePercentile       = 0.95
+	defaultKeepaliveInterval     = 3 * time.Second
+	defaultKeepaliveTimeout      = 3 * time.Second
 )
 
 // ClientOptions are the options to configure an RPC client.
@@ -44,6 +48,43 @@ type ClientOptions struct {
 	// buffer before being written on the connection. If zero, an appropriate
 	// value is picked automatically. If negative, no flattening is done.
 	WriteFlattenLimit int
+
+	// If positive, requests and streamed values larger than this many bytes
+	// are compressed, provided the server can decompress them. If zero or
+	// negative, nothing is compressed. Compressed replies are always
+	// accepted.
+	CompressionThreshold int
+
+	// Compression algorithm used to compress requests. Defaults to Zstd.
+	Compression Compression
+
+	// A hedged call (see CallOptions.Hedge) is hedged if it hasn't returned
+	// after this percentile of the recent latencies of its method, between 0
+	// and 1. Defaults to 0.95.
+	HedgePercentile float64
+
+	// Name of the component the calls are sent to, used to label metrics.
+	Component string
+
+	// Circuit breaker of the calls. See CircuitBreakerOptions.
+	CircuitBreaker CircuitBreakerOptions
+
+	// Retry budget of the calls that are retried. See RetryBudgetOptions.
+	RetryBudget RetryBudgetOptions
+
+	// The client pings every server it is connected to every
+	// KeepaliveInterval. If a server doesn't reply within KeepaliveTimeout,
+	// the client closes the connection to the server, and stops sending
+	// calls to it until it reconnects. KeepaliveTimeout also bounds the
+	// handshake of new connections. Both default to 3 seconds. If
+	// KeepaliveInterval is negative, servers are not pinged.
+	KeepaliveInterval time.Duration
+	KeepaliveTimeout  time.Duration
+
+	// If not nil, Token returns the bearer token sent with every call, which
+	// the server can use to authenticate the client. See Token. If Token
+	// returns an error, the call fails without being sent.
+	Token func(context.Context) (string, error)
 }
 
 // ServerOption are the options to configure an RPC server.
@@ -65,6 +106,20 @@ type ServerOptions struct {
 	// buffer before being written on the connection. If zero, an appropriate
 	// value is picked automatically. If negative, no flattening is done.
 	WriteFlattenLimit int
+
+	// If positive, replies and streamed values larger than this many bytes
+	// are compressed, provided the client can decompress them. If zero or
+	// negative, nothing is compressed. Compressed requests are always
+	// accepted.
+	CompressionThreshold int
+
+	// Compression algorithm used to compress replies. Defaults to Zstd.
+	Compression Compression
+
+	// Admission returns the admission control options of the provided method
+	// of the provided component. It is called at most once per method. If
+	// nil, calls are never rejected. See AdmissionOptions.
+	Admission func(component, method string) AdmissionOptions
 }
 
 // CallOptions are call-specific options.
@@ -73,12 +128,27 @@ type CallOptions struct {
 	// errors should be retried.
 	Retry bool
 
+	// Hedge indicates whether or not the call may be hedged: if it hasn't
+	// returned after a while, a second call is sent to a different replica,
+	// and the first reply to arrive is used. Calls that are hedged may
+	// execute more than once. See ClientOptions.HedgePercentile.
+	Hedge bool
+
 	// ShardKey, if not 0, is the shard key that a Balancer can use to route a
 	// call. A Balancer can always choose to ignore the ShardKey.
 	//
 	// TODO(mwhittaker): Figure out a way to have 0 be a valid shard key. Could
 	// change to *uint64 for example.
 	ShardKey uint64
+
+	// Caller, if not empty, is the name of the component making the call. It
+	// is sent to the server, which can use it to decide whether to accept
+	// the call. See Caller.
+	Caller string
+
+	// Method, if not empty, is the name of the called method, used to label
+	// metrics.
+	Method string
 }
 
 // withDefaults returns a copy of the ClientOptions with zero values replaced
@@ -93,6 +163,18 @@ func (c ClientOptions) withDefaults() ClientOptions {
 	if c.WriteFlattenLimit == 0 {
 		c.WriteFlattenLimit = defaultWriteFlattenLimit
 	}
+	if c.Compression == 0 {
+		c.Compression = Zstd
+	}
+	if c.HedgePercentile == 0 {
+		c.HedgePercentile = defaultHedgePercentile
+	}
+	if c.KeepaliveInterval == 0 {
+		c.KeepaliveInterval = defaultKeepaliveInterval
+	}
+	if c.KeepaliveTimeout <= 0 {
+		c.KeepaliveTimeout = defaultKeepaliveTimeout
+	}
 	return c
 }
 
@@ -111,5 +193,8 @@ func (s ServerOptions) withDefaults() ServerOptions {
 	if s.WriteFlattenLimit == 0 {
 		s.WriteFlattenLimit = defaultWriteFlattenLimit
 	}
+	if s.Compression == 0 {
+		s.Compression = Zstd
+	}
 	return s
 }
@@ -0,0 +1,625 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux || darwin || freebsd || netbsd || openbsd || dragonfly
+
+package call
+
+// Shared-memory connections.
+//
+// A shared-memory connection consists of two single-producer single-consumer
+// ring buffers, one per direction, that live in a memory mapping shared by the
+// two processes. The bytes written to a connection are exactly the bytes that
+// would have been written to a TCP or Unix socket, so the message framing of
+// the call protocol is unchanged.
+//
+// A connection is established over a Unix socket. The dialer creates an
+// unlinked file big enough to hold both rings and sends its file descriptor
+// to the listener, and both processes map the file. The Unix socket stays open
+// for the lifetime of the connection and is used for two things:
+//
+//   - Wakeups. A process that runs out of data to read (or of space to write)
+//     sets a flag in the ring and blocks. The peer, after writing data (or
+//     freeing space), clears the flag and writes a byte to the socket.
+//   - Closing. A process that closes its end of the socket causes the other
+//     process to read EOF.
+//
+// Every ring has the following layout, where head and tail increase
+// monotonically and are taken modulo the (power of two) size of the data:
+//
+//	+------+---------------+------+---------------+
+//	| head | writerWaiting | tail | readerWaiting |
+//	+------+---------------+------+---------------+
+//	|<-- producer's cache line ->|<- consumer's ->|
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+	"unsafe"
+)
+
+const (
+	// shmMagic starts the handshake sent by a dialer to a listener.
+	shmMagic = "mx-shm01"
+
+	// shmRingSize is the number of data bytes in every ring.
+	shmRingSize = 1 << 20
+
+	// shmHeaderSize is the size of the header of every ring. It spans two
+	// cache lines, one written by the producer and one by the consumer.
+	shmHeaderSize = 128
+
+	// shmSpinDuration is how long a reader spins, waiting for data, before
+	// blocking. Spinning avoids the cost of a wakeup when the peer replies
+	// quickly.
+	shmSpinDuration = 50 * time.Microsecond
+
+	// shmHandshakeTimeout bounds the time a listener waits for a dialer to
+	// complete the handshake.
+	shmHandshakeTimeout = 5 * time.Second
+
+	// Wakeup bytes sent over the Unix socket.
+	shmReadable byte = 'r' // the receiver's incoming ring has data
+	shmWritable byte = 'w' // the receiver's outgoing ring has space
+)
+
+var (
+	shmReadableMsg = []byte{shmReadable}
+	shmWritableMsg = []byte{shmWritable}
+	shmAckMsg      = []byte{'k'}
+)
+
+// shmMappingSize returns the size of a mapping that holds two rings with the
+// provided data size.
+func shmMappingSize(ringSize int) int {
+	return 2 * (shmHeaderSize + ringSize)
+}
+
+// dialSharedMemory dials a shared-memory connection to the listener
+// listening on the provided Unix socket.
+func dialSharedMemory(ctx context.Context, filename string) (net.Conn, error) {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "unix", filename)
+	if err != nil {
+		return nil, err
+	}
+	sock := conn.(*net.UnixConn)
+	c, err := dialHandshake(ctx, sock)
+	if err != nil {
+		sock.Close()
+		return nil, fmt.Errorf("shared memory handshake with %s: %w", filename, err)
+	}
+	return c, nil
+}
+
+// dialHandshake creates the memory shared by a new connection and sends it to
+// the listener on the other end of sock.
+func dialHandshake(ctx context.Context, sock *net.UnixConn) (*shmConn, error) {
+	f, err := os.CreateTemp(shmDir(), "mx-shm")
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	if err := os.Remove(f.Name()); err != nil {
+		return nil, err
+	}
+	size := shmMappingSize(shmRingSize)
+	if err := f.Truncate(int64(size)); err != nil {
+		return nil, err
+	}
+	mem, err := syscall.Mmap(int(f.Fd()), 0, size, syscall.PROT_READ|syscall.PROT_WRITE, syscall.MAP_SHARED)
+	if err != nil {
+		return nil, err
+	}
+
+	handshake := func() error {
+		if deadline, ok := ctx.Deadline(); ok {
+			if err := sock.SetDeadline(deadline); err != nil {
+				return err
+			}
+		}
+		hdr := make([]byte, len(shmMagic)+8)
+		copy(hdr, shmMagic)
+		binary.LittleEndian.PutUint64(hdr[len(shmMagic):], shmRingSize)
+		if _, _, err := sock.WriteMsgUnix(hdr, syscall.UnixRights(int(f.Fd())), nil); err != nil {
+			return err
+		}
+		ack := make([]byte, 1)
+		if _, err := io.ReadFull(sock, ack); err != nil {
+			return err
+		}
+		return sock.SetDeadline(time.Time{})
+	}
+	if err := handshake(); err != nil {
+		syscall.Munmap(mem)
+		return nil, err
+	}
+	return newShmConn(sock, mem, shmRingSize, true), nil
+}
+
+// shmDir returns the directory in which to create shared memory.
+func shmDir() string {
+	// Prefer a memory-backed file system when there is one.
+	if info, err := os.Stat("/dev/shm"); err == nil && info.IsDir() {
+		return "/dev/shm"
+	}
+	return os.TempDir()
+}
+
+// ListenSharedMemory returns a listener that accepts the shared-memory
+// connections dialed by SharedMemory(filename). The listener listens on the
+// Unix socket filename.
+func ListenSharedMemory(filename string) (net.Listener, error) {
+	lis, err := net.ListenUnix("unix", &net.UnixAddr{Name: filename, Net: "unix"})
+	if err != nil {
+		return nil, err
+	}
+	return &shmListener{lis: lis}, nil
+}
+
+// shmListener is a net.Listener for shared-memory connections.
+type shmListener struct {
+	lis *net.UnixListener
+}
+
+var _ net.Listener = &shmListener{}
+
+// Accept implements the net.Listener interface.
+func (l *shmListener) Accept() (net.Conn, error) {
+	for {
+		sock, err := l.lis.AcceptUnix()
+		if err != nil {
+			return nil, err
+		}
+		c, err := acceptHandshake(sock)
+		if err != nil {
+			// A broken handshake is the dialer's problem. Keep listening.
+			sock.Close()
+			continue
+		}
+		return c, nil
+	}
+}
+
+// Close implements the net.Listener interface.
+func (l *shmListener) Close() error {
+	return l.lis.Close()
+}
+
+// Addr implements the net.Listener interface.
+func (l *shmListener) Addr() net.Addr {
+	return l.lis.Addr()
+}
+
+// acceptHandshake receives and maps the memory shared by a new connection
+// from the dialer on the other end of sock.
+func acceptHandshake(sock *net.UnixConn) (*shmConn, error) {
+	if err := sock.SetDeadline(time.Now().Add(shmHandshakeTimeout)); err != nil {
+		return nil, err
+	}
+	hdr := make([]byte, len(shmMagic)+8)
+	oob := make([]byte, syscall.CmsgSpace(4))
+	n, oobn, _, _, err := sock.ReadMsgUnix(hdr, oob)
+	if err != nil {
+		return nil, err
+	}
+	msgs, err := syscall.ParseSocketControlMessage(oob[:oobn])
+	if err != nil {
+		return nil, err
+	}
+	if len(msgs) != 1 {
+		return nil, fmt.Errorf("got %d control messages, want 1", len(msgs))
+	}
+	fds, err := syscall.ParseUnixRights(&msgs[0])
+	if err != nil {
+		return nil, err
+	}
+	if len(fds) != 1 {
+		for _, fd := range fds {
+			syscall.Close(fd)
+		}
+		return nil, fmt.Errorf("got %d file descriptors, want 1", len(fds))
+	}
+	f := os.NewFile(uintptr(fds[0]), "shm")
+	defer f.Close()
+
+	if n != len(hdr) || string(hdr[:len(shmMagic)]) != shmMagic {
+		return nil, fmt.Errorf("bad handshake %q", hdr[:n])
+	}
+	ringSize := binary.LittleEndian.Uint64(hdr[len(shmMagic):])
+	if ringSize < 4<<10 || ringSize > 1<<30 || ringSize&(ringSize-1) != 0 {
+		return nil, fmt.Errorf("bad ring size %d", ringSize)
+	}
+	size := shmMappingSize(int(ringSize))
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if info.Size() != int64(size) {
+		return nil, fmt.Errorf("shared memory has size %d, want %d", info.Size(), size)
+	}
+	mem, err := syscall.Mmap(int(f.Fd()), 0, size, syscall.PROT_READ|syscall.PROT_WRITE, syscall.MAP_SHARED)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := sock.Write(shmAckMsg); err != nil {
+		syscall.Munmap(mem)
+		return nil, err
+	}
+	if err := sock.SetDeadline(time.Time{}); err != nil {
+		syscall.Munmap(mem)
+		return nil, err
+	}
+	return newShmConn(sock, mem, int(ringSize), false), nil
+}
+
+// shmRing is a single-producer single-consumer ring buffer in shared memory.
+type shmRing struct {
+	head          *atomic.Uint64 // total number of bytes written
+	writerWaiting *atomic.Uint32 // is the producer waiting for space?
+	tail          *atomic.Uint64 // total number of bytes read
+	readerWaiting *atomic.Uint32 // is the consumer waiting for data?
+	data          []byte         // len(data) is a power of two
+}
+
+// newShmRing returns the ring at the start of mem.
+func newShmRing(mem []byte, ringSize int) shmRing {
+	return shmRing{
+		head:          (*atomic.Uint64)(unsafe.Pointer(&mem[0])),
+		writerWaiting: (*atomic.Uint32)(unsafe.Pointer(&mem[8])),
+		tail:          (*atomic.Uint64)(unsafe.Pointer(&mem[64])),
+		readerWaiting: (*atomic.Uint32)(unsafe.Pointer(&mem[72])),
+		data:          mem[shmHeaderSize : shmHeaderSize+ringSize],
+	}
+}
+
+// buffered returns the number of bytes available for reading.
+func (r *shmRing) buffered() int {
+	// Don't trust the peer to keep head and tail consistent.
+	return int(min(r.head.Load()-r.tail.Load(), uint64(len(r.data))))
+}
+
+// write copies as much of p into the ring as fits, returning the number of
+// bytes copied. It must only be called by the producer.
+func (r *shmRing) write(p []byte) int {
+	head := r.head.Load()
+	n := min(len(p), len(r.data)-r.buffered())
+	if n == 0 {
+		return 0
+	}
+	i := int(head & uint64(len(r.data)-1))
+	m := copy(r.data[i:], p[:n])
+	copy(r.data, p[m:n])
+	r.head.Store(head + uint64(n))
+	return n
+}
+
+// read copies as many buffered bytes into p as fit, returning the number of
+// bytes copied. It must only be called by the consumer.
+func (r *shmRing) read(p []byte) int {
+	tail := r.tail.Load()
+	n := min(len(p), r.buffered())
+	if n == 0 {
+		return 0
+	}
+	i := int(tail & uint64(len(r.data)-1))
+	m := copy(p[:n], r.data[i:])
+	copy(p[m:n], r.data)
+	r.tail.Store(tail + uint64(n))
+	return n
+}
+
+// shmConn is a net.Conn over shared memory.
+type shmConn struct {
+	sock     *net.UnixConn // used for wakeups and to detect closing
+	mem      []byte        // shared memory
+	in       shmRing       // ring to read from
+	out      shmRing       // ring to write to
+	readable chan struct{} // notified when the peer wrote data
+	writable chan struct{} // notified when the peer freed space
+	peerDone chan struct{} // closed when the peer closes the connection
+	done     chan struct{} // closed when the connection is closed
+
+	readMu        sync.Mutex // serializes reads
+	writeMu       sync.Mutex // serializes writes
+	readDeadline  shmDeadline
+	writeDeadline shmDeadline
+
+	mu     sync.Mutex     // guards closed
+	closed bool           // has Close been called?
+	ops    sync.WaitGroup // pending reads and writes, which use mem
+}
+
+var _ net.Conn = &shmConn{}
+
+// newShmConn returns a new connection over the provided shared memory. The
+// dialer writes to the first ring and the listener writes to the second.
+func newShmConn(sock *net.UnixConn, mem []byte, ringSize int, dialer bool) *shmConn {
+	first := newShmRing(mem, ringSize)
+	second := newShmRing(mem[shmHeaderSize+ringSize:], ringSize)
+	c := &shmConn{
+		sock:          sock,
+		mem:           mem,
+		in:            second,
+		out:           first,
+		readable:      make(chan struct{}, 1),
+		writable:      make(chan struct{}, 1),
+		peerDone:      make(chan struct{}),
+		done:          make(chan struct{}),
+		readDeadline:  makeShmDeadline(),
+		writeDeadline: makeShmDeadline(),
+	}
+	if !dialer {
+		c.in, c.out = first, second
+	}
+	go c.watch()
+	return c
+}
+
+// watch receives the wakeups sent by the peer, until the peer closes the
+// connection.
+func (c *shmConn) watch() {
+	buf := make([]byte, 64)
+	for {
+		n, err := c.sock.Read(buf)
+		for _, b := range buf[:n] {
+			switch b {
+			case shmReadable:
+				notify(c.readable)
+			case shmWritable:
+				notify(c.writable)
+			}
+		}
+		if err != nil {
+			close(c.peerDone)
+			return
+		}
+	}
+}
+
+// notify performs a non-blocking send on ch.
+func notify(ch chan struct{}) {
+	select {
+	case ch <- struct{}{}:
+	default:
+	}
+}
+
+// wake sends a wakeup to the peer. Errors are ignored: if the peer has closed
+// the connection, watch notices.
+func (c *shmConn) wake(msg []byte) {
+	c.sock.Write(msg)
+}
+
+// enter registers a pending read or write. It returns false if the connection
+// is closed. If enter returns true, the caller must call c.ops.Done().
+func (c *shmConn) enter() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return false
+	}
+	c.ops.Add(1)
+	return true
+}
+
+// Read implements the net.Conn interface.
+func (c *shmConn) Read(p []byte) (int, error) {
+	c.readMu.Lock()
+	defer c.readMu.Unlock()
+	if !c.enter() {
+		return 0, net.ErrClosed
+	}
+	defer c.ops.Done()
+	if len(p) == 0 {
+		return 0, nil
+	}
+
+	r := &c.in
+	for {
+		if n := r.read(p); n > 0 {
+			if r.writerWaiting.Swap(0) == 1 {
+				c.wake(shmWritableMsg)
+			}
+			return n, nil
+		}
+
+		// Spin briefly, in case the peer is about to write.
+		for start := time.Now(); r.buffered() == 0 && time.Since(start) < shmSpinDuration; {
+			runtime.Gosched()
+		}
+		if r.buffered() > 0 {
+			continue
+		}
+
+		// Block. The flag must be set before checking for data one last time
+		// to avoid missing a wakeup.
+		r.readerWaiting.Store(1)
+		if r.buffered() > 0 {
+			continue
+		}
+		select {
+		case <-c.readable:
+		case <-c.peerDone:
+			if r.buffered() == 0 {
+				return 0, io.EOF
+			}
+		case <-c.done:
+			return 0, net.ErrClosed
+		case <-c.readDeadline.wait():
+			return 0, os.ErrDeadlineExceeded
+		}
+	}
+}
+
+// Write implements the net.Conn interface.
+func (c *shmConn) Write(p []byte) (int, error) {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	if !c.enter() {
+		return 0, net.ErrClosed
+	}
+	defer c.ops.Done()
+
+	w := &c.out
+	var n int
+	for n < len(p) {
+		select {
+		case <-c.peerDone:
+			return n, io.ErrClosedPipe
+		default:
+		}
+
+		if m := w.write(p[n:]); m > 0 {
+			n += m
+			if w.readerWaiting.Swap(0) == 1 {
+				c.wake(shmReadableMsg)
+			}
+			continue
+		}
+
+		// Block. The flag must be set before checking for space one last
+		// time to avoid missing a wakeup.
+		w.writerWaiting.Store(1)
+		if w.buffered() < len(w.data) {
+			continue
+		}
+		select {
+		case <-c.writable:
+		case <-c.peerDone:
+			return n, io.ErrClosedPipe
+		case <-c.done:
+			return n, net.ErrClosed
+		case <-c.writeDeadline.wait():
+			return n, os.ErrDeadlineExceeded
+		}
+	}
+	return n, nil
+}
+
+// Close implements the net.Conn interface.
+func (c *shmConn) Close() error {
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		return net.ErrClosed
+	}
+	c.closed = true
+	c.mu.Unlock()
+
+	close(c.done)
+	err := c.sock.Close()
+	c.ops.Wait() // don't unmap memory that is still in use
+	if merr := syscall.Munmap(c.mem); err == nil {
+		err = merr
+	}
+	return err
+}
+
+// LocalAddr implements the net.Conn interface.
+func (c *shmConn) LocalAddr() net.Addr {
+	return c.sock.LocalAddr()
+}
+
+// RemoteAddr implements the net.Conn interface.
+func (c *shmConn) RemoteAddr() net.Addr {
+	return c.sock.RemoteAddr()
+}
+
+// SetDeadline implements the net.Conn interface.
+func (c *shmConn) SetDeadline(t time.Time) error {
+	c.readDeadline.set(t)
+	c.writeDeadline.set(t)
+	return nil
+}
+
+// SetReadDeadline implements the net.Conn interface.
+func (c *shmConn) SetReadDeadline(t time.Time) error {
+	c.readDeadline.set(t)
+	return nil
+}
+
+// SetWriteDeadline implements the net.Conn interface.
+func (c *shmConn) SetWriteDeadline(t time.Time) error {
+	c.writeDeadline.set(t)
+	return nil
+}
+
+// shmDeadline is a deadline for reads or writes on a shmConn. The
+// implementation follows the one used by net.Pipe.
+type shmDeadline struct {
+	mu      sync.Mutex    // guards timer and expired
+	timer   *time.Timer   // closes expired when the deadline passes
+	expired chan struct{} // closed when the deadline has passed
+}
+
+func makeShmDeadline() shmDeadline {
+	return shmDeadline{expired: make(chan struct{})}
+}
+
+// set sets the deadline. A zero t disables the deadline.
+func (d *shmDeadline) set(t time.Time) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.timer != nil && !d.timer.Stop() {
+		<-d.expired // wait for the timer to close expired
+	}
+	d.timer = nil
+
+	closed := isClosed(d.expired)
+	if t.IsZero() {
+		if closed {
+			d.expired = make(chan struct{})
+		}
+		return
+	}
+	if dur := time.Until(t); dur > 0 {
+		if closed {
+			d.expired = make(chan struct{})
+		}
+		expired := d.expired
+		d.timer = time.AfterFunc(dur, func() { close(expired) })
+		return
+	}
+	if !closed {
+		close(d.expired)
+	}
+}
+
+// wait returns a channel that is closed when the deadline passes.
+func (d *shmDeadline) wait() chan struct{} {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.expired
+}
+
+// isClosed returns whether ch is closed.
+func isClosed(ch chan struct{}) bool {
+	select {
+	case <-ch:
+		return true
+	default:
+		return false
+	}
+}
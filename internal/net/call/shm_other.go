@@ -0,0 +1,38 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !(linux || darwin || freebsd || netbsd || openbsd || dragonfly)
+
+package call
+
+import (
+	"context"
+	"errors"
+	"net"
+)
+
+var errNoSharedMemory = errors.New("shared memory connections are not supported on this platform")
+
+// dialSharedMemory dials a shared-memory connection to the listener
+// listening on the provided Unix socket.
+func dialSharedMemory(context.Context, string) (net.Conn, error) {
+	return nil, errNoSharedMemory
+}
+
+// ListenSharedMemory returns a listener that accepts the shared-memory
+// connections dialed by SharedMemory(filename). The listener listens on the
+// Unix socket filename.
+func ListenSharedMemory(string) (net.Listener, error) {
+	return nil, errNoSharedMemory
+}
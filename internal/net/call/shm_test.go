@@ -0,0 +1,183 @@
+// Copyright 2022 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux || darwin || freebsd || netbsd || openbsd || dragonfly
+
+package call_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sh3lk/mx/internal/net/call"
+)
+
+// shmPipe returns the two ends of a new shared-memory connection.
+func shmPipe(t *testing.T) (client net.Conn, server net.Conn) {
+	t.Helper()
+	lis, err := call.ListenSharedMemory(filepath.Join(t.TempDir(), "shm.sock"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { lis.Close() })
+
+	accepted := make(chan net.Conn, 1)
+	go func() {
+		conn, err := lis.Accept()
+		if err != nil {
+			t.Error(err)
+		}
+		accepted <- conn
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+	client, err = call.SharedMemory(lis.Addr().String()).Dial(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server = <-accepted
+	if server == nil {
+		t.FailNow()
+	}
+	t.Cleanup(func() {
+		client.Close()
+		server.Close()
+	})
+	return client, server
+}
+
+func TestSharedMemoryLargeWrites(t *testing.T) {
+	// Write more bytes than fit in a ring, so that the writer has to wait for
+	// the reader and the data wraps around the ring.
+	client, server := shmPipe(t)
+	want := make([]byte, 5<<20+17)
+	for i := range want {
+		want[i] = byte(i % 251)
+	}
+	errs := make(chan error, 1)
+	go func() {
+		_, err := client.Write(want)
+		errs <- err
+	}()
+	got := make([]byte, len(want))
+	if _, err := io.ReadFull(server, got); err != nil {
+		t.Fatal(err)
+	}
+	if err := <-errs; err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Fatal("bad data")
+	}
+}
+
+func TestSharedMemoryPingPong(t *testing.T) {
+	client, server := shmPipe(t)
+	go func() {
+		buf := make([]byte, 4)
+		for {
+			if _, err := io.ReadFull(server, buf); err != nil {
+				return
+			}
+			if _, err := server.Write(buf); err != nil {
+				return
+			}
+		}
+	}()
+	for i := 0; i < 1000; i++ {
+		want := []byte{byte(i), byte(i >> 8), 1, 2}
+		if _, err := client.Write(want); err != nil {
+			t.Fatal(err)
+		}
+		got := make([]byte, 4)
+		if _, err := io.ReadFull(client, got); err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+	}
+}
+
+func TestSharedMemoryClose(t *testing.T) {
+	client, server := shmPipe(t)
+
+	// Data written before closing is still delivered.
+	if _, err := client.Write([]byte("bye")); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.Close(); err != nil {
+		t.Fatal(err)
+	}
+	got, err := io.ReadAll(server)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != "bye" {
+		t.Fatalf("got %q, want %q", got, "bye")
+	}
+
+	if _, err := client.Read(make([]byte, 1)); !errors.Is(err, net.ErrClosed) {
+		t.Fatalf("read after close: got %v, want %v", err, net.ErrClosed)
+	}
+}
+
+func TestSharedMemoryReadDeadline(t *testing.T) {
+	client, _ := shmPipe(t)
+	if err := client.SetReadDeadline(time.Now().Add(shortDelay)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Read(make([]byte, 1)); !errors.Is(err, os.ErrDeadlineExceeded) {
+		t.Fatalf("got %v, want %v", err, os.ErrDeadlineExceeded)
+	}
+}
+
+func TestSharedMemoryCalls(t *testing.T) {
+	// Run the call protocol, with and without mTLS, over shared memory.
+	for _, test := range []struct {
+		name      string
+		tlsConfig *tls.Config
+	}{
+		{"Plain", nil},
+		{"MTLS", tlsConfig},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			lis, err := call.ListenSharedMemory(filepath.Join(t.TempDir(), "shm.sock"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			go call.Serve(ctx, testListener{Listener: lis, tlsConfig: test.tlsConfig}, call.ServerOptions{Logger: logger(t)})
+
+			endpoint := call.SharedMemory(lis.Addr().String())
+			if test.tlsConfig != nil {
+				endpoint = call.MTLS(test.tlsConfig, endpoint)
+			}
+			client := getClientConn(t, "shm", endpoint, resolverMakers["Constant"])
+			testCall(ctx, t, client)
+			testConcurrentCalls(t, client)
+			testServerStream(t, client)
+		})
+	}
+}
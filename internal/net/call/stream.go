@@ -0,0 +1,244 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package call
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/sh3lk/mx/runtime/codegen"
+)
+
+// streamWindow is the maximum number of values that the receiver of a stream
+// buffers before they are consumed. See msg.go for details on flow control.
+const streamWindow = 64
+
+// errStreamClosed is returned by recvStream.Recv after the stream is closed.
+var errStreamClosed = errors.New("stream closed")
+
+// recvStream is the receiving end of a stream of values sent over a
+// connection.
+type recvStream struct {
+	credit  func(n uint32) // grants the sender n more credits
+	onClose func()         // called when the stream is closed
+
+	mu       sync.Mutex
+	values   [][]byte      // received values that have not been consumed
+	err      error         // non-nil once the stream has ended
+	consumed uint32        // values consumed since the last credit grant
+	closed   bool          // has Close been called?
+	notify   chan struct{} // receives an element when values or err change
+}
+
+var _ codegen.ByteStream = &recvStream{}
+
+func newRecvStream() *recvStream {
+	return &recvStream{notify: make(chan struct{}, 1)}
+}
+
+// deliver adds a value sent by the peer to the stream.
+func (s *recvStream) deliver(v []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed || s.err != nil {
+		// Nobody is interested in the value.
+		return nil
+	}
+	if len(s.values) >= streamWindow {
+		return fmt.Errorf("stream flow control violation: more than %d values in flight", streamWindow)
+	}
+	s.values = append(s.values, v)
+	s.signal()
+	return nil
+}
+
+// finish ends the stream. err is io.EOF if the stream ended successfully.
+// Values delivered before finish is called can still be received.
+func (s *recvStream) finish(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err == nil {
+		s.err = err
+		s.signal()
+	}
+}
+
+// signal wakes up a pending Recv call, if any.
+// REQUIRES: s.mu is held.
+func (s *recvStream) signal() {
+	select {
+	case s.notify <- struct{}{}:
+	default:
+	}
+}
+
+// Recv implements the codegen.ByteStream interface.
+func (s *recvStream) Recv(ctx context.Context) ([]byte, error) {
+	for {
+		s.mu.Lock()
+		if s.closed {
+			s.mu.Unlock()
+			return nil, errStreamClosed
+		}
+		if len(s.values) > 0 {
+			v := s.values[0]
+			s.values[0] = nil
+			s.values = s.values[1:]
+
+			// Grant more credits once half of the window has been consumed.
+			var grant uint32
+			s.consumed++
+			if s.consumed >= streamWindow/2 && s.err == nil {
+				grant, s.consumed = s.consumed, 0
+			}
+			s.mu.Unlock()
+			if grant > 0 && s.credit != nil {
+				s.credit(grant)
+			}
+			return v, nil
+		}
+		if s.err != nil {
+			err := s.err
+			s.mu.Unlock()
+			return nil, err
+		}
+		s.mu.Unlock()
+
+		select {
+		case <-s.notify:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// Close implements the codegen.ByteStream interface.
+func (s *recvStream) Close() {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return
+	}
+	s.closed = true
+	s.values = nil
+	s.mu.Unlock()
+	if s.onClose != nil {
+		s.onClose()
+	}
+}
+
+// sendWindow tracks the credits granted to the sender of a stream.
+type sendWindow struct {
+	mu      sync.Mutex
+	credits uint32
+	notify  chan struct{} // receives an element when credits are granted
+}
+
+func newSendWindow() *sendWindow {
+	return &sendWindow{notify: make(chan struct{}, 1)}
+}
+
+// grant grants n more credits to the sender.
+func (w *sendWindow) grant(n uint32) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.credits += n
+	select {
+	case w.notify <- struct{}{}:
+	default:
+	}
+}
+
+// acquire blocks until a credit is available, and consumes it.
+func (w *sendWindow) acquire(ctx context.Context) error {
+	for {
+		w.mu.Lock()
+		if w.credits > 0 {
+			w.credits--
+			w.mu.Unlock()
+			return nil
+		}
+		w.mu.Unlock()
+
+		select {
+		case <-w.notify:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// sendStream sends the values received from src to the peer, followed by the
+// end of the stream, using write to send individual messages. Values are only
+// sent when credits are available in w. sendStream returns when the stream
+// ends or when ctx is canceled, and it closes src before returning.
+func sendStream(ctx context.Context, src codegen.ByteStream, w *sendWindow, write func(messageType, []byte) error) error {
+	defer src.Close()
+	for {
+		v, err := src.Recv(ctx)
+		if ctx.Err() != nil {
+			// The peer is no longer interested in the stream.
+			return ctx.Err()
+		}
+		if errors.Is(err, io.EOF) {
+			return write(streamEndMessage, nil)
+		} else if err != nil {
+			return write(streamEndMessage, encodeError(err))
+		}
+		if err := w.acquire(ctx); err != nil {
+			return err
+		}
+		if err := write(streamValueMessage, v); err != nil {
+			return err
+		}
+	}
+}
+
+// encodeCredit returns the payload of a streamCreditMessage that grants n
+// credits.
+func encodeCredit(n uint32) []byte {
+	var msg [4]byte
+	binary.LittleEndian.PutUint32(msg[:], n)
+	return msg[:]
+}
+
+// decodeCredit returns the number of credits granted by the provided
+// streamCreditMessage payload.
+func decodeCredit(msg []byte) (uint32, error) {
+	if len(msg) != 4 {
+		return 0, fmt.Errorf("bad stream credit message length %d, must be 4", len(msg))
+	}
+	return binary.LittleEndian.Uint32(msg), nil
+}
+
+// decodeStreamEnd returns the error held by the provided streamEndMessage
+// payload, or io.EOF if the stream ended successfully.
+func decodeStreamEnd(msg []byte) error {
+	if len(msg) == 0 {
+		return io.EOF
+	}
+	err, ok := decodeError(msg)
+	if !ok {
+		return fmt.Errorf("%w: could not decode stream error", CommunicationError)
+	}
+	if err == nil {
+		return io.EOF
+	}
+	return err
+}
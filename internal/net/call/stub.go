@@ -27,11 +27,14 @@ type stub struct {
 	methods       []stubMethod // per method info
 	tracer        trace.Tracer // component tracer
 	injectRetries int          // Number of artificial retries per retriable call
+	caller        string       // calling component, if known
 }
 
 type stubMethod struct {
+	name  string    // method name
 	key   MethodKey // key for remote component method
 	retry bool      // Whether or not the method should be retred
+	hedge bool      // Whether or not calls to the method may be hedged
 }
 
 var _ codegen.Stub = &stub{}
@@ -47,6 +50,15 @@ func NewStub(name string, reg *codegen.Registration, conn Connection, tracer tra
 	}
 }
 
+// WithCaller returns a copy of the provided stub, which must have been created
+// by NewStub, that sends the provided calling component name along with every
+// call. See CallOptions.Caller.
+func WithCaller(s codegen.Stub, caller string) codegen.Stub {
+	clone := *s.(*stub)
+	clone.caller = caller
+	return &clone
+}
+
 // Tracer implements the codegen.Stub interface.
 func (s *stub) Tracer() trace.Tracer {
 	return s.tracer
@@ -57,7 +69,10 @@ func (s *stub) Run(ctx context.Context, method int, args []byte, shardKey uint64
 	m := s.methods[method]
 	opts := CallOptions{
 		Retry:    m.retry,
+		Hedge:    m.hedge,
 		ShardKey: shardKey,
+		Caller:   s.caller,
+		Method:   m.name,
 	}
 	n := 1
 	if m.retry {
@@ -70,6 +85,13 @@ func (s *stub) Run(ctx context.Context, method int, args []byte, shardKey uint64
 	return
 }
 
+// RunStream implements the codegen.Stub interface.
+func (s *stub) RunStream(ctx context.Context, method int, args []byte, in codegen.ByteStream, shardKey uint64) ([]byte, codegen.ByteStream, error) {
+	m := s.methods[method]
+	opts := CallOptions{ShardKey: shardKey, Caller: s.caller, Method: m.name}
+	return s.conn.Stream(ctx, m.key, args, in, opts)
+}
+
 // makeStubMethods returns a slice of stub methods for the component methods of reg.
 func makeStubMethods(fullName string, reg *codegen.Registration) []stubMethod {
 	// Construct method info slice.
@@ -77,11 +99,15 @@ func makeStubMethods(fullName string, reg *codegen.Registration) []stubMethod {
 	methods := make([]stubMethod, n)
 	for i := 0; i < n; i++ {
 		mname := reg.Iface.Method(i).Name
+		methods[i].name = mname
 		methods[i].key = MakeMethodKey(fullName, mname)
 		methods[i].retry = true // Retry by default
 	}
 	for _, m := range reg.NoRetry {
 		methods[m].retry = false
 	}
+	for _, m := range reg.Hedged {
+		methods[m].hedge = true
+	}
 	return methods
 }
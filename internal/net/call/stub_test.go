@@ -37,6 +37,10 @@ func (c *localClient) Call(ctx context.Context, _ MethodKey, args []byte, _ Call
 	return handleCall(ctx, reflect.ValueOf(c.fn), args)
 }
 
+func (c *localClient) Stream(context.Context, MethodKey, []byte, codegen.ByteStream, CallOptions) ([]byte, codegen.ByteStream, error) {
+	return nil, nil, fmt.Errorf("streaming calls not supported")
+}
+
 func (c *localClient) Close() {}
 
 func TestCall(t *testing.T) {
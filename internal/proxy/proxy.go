@@ -20,20 +20,36 @@ import (
 	"math/rand"
 	"net/http"
 	"net/http/httputil"
+	"slices"
+	"sort"
 	"sync"
+
+	"golang.org/x/exp/maps"
 )
 
 // Proxy is an HTTP proxy that forwards traffic to a set of backends.
+//
+// Every backend belongs to a version of an application. During a rollout, the
+// old and new versions of an application run side by side, and the proxy
+// splits traffic between them based on the weight of every version. A
+// request is forwarded to a version picked with probability proportional to
+// its weight, among the versions that have at least one backend, and then to
+// a backend of that version picked uniformly at random.
 type Proxy struct {
 	logger   *slog.Logger          // logger
 	reverse  httputil.ReverseProxy // underlying proxy
-	mu       sync.Mutex            // guards backends
-	backends []string              // backend addresses
+	mu       sync.Mutex            // guards the following
+	backends map[string][]string   // backend addresses, by version
+	weights  map[string]float64    // traffic weights, by version
 }
 
 // NewProxy returns a new proxy.
 func NewProxy(logger *slog.Logger) *Proxy {
-	p := &Proxy{logger: logger}
+	p := &Proxy{
+		logger:   logger,
+		backends: map[string][]string{},
+		weights:  map[string]float64{},
+	}
 	p.reverse = httputil.ReverseProxy{Director: p.director}
 	return p
 }
@@ -43,12 +59,82 @@ func (p *Proxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	p.reverse.ServeHTTP(w, r)
 }
 
-// AddBackend adds a backend to the proxy. Note that backends cannot be
-// removed.
+// AddBackend adds a backend to the proxy. The backend belongs to the
+// unnamed version "".
 func (p *Proxy) AddBackend(backend string) {
+	p.AddVersionBackend("", backend)
+}
+
+// AddVersionBackend adds a backend that belongs to the provided version to
+// the proxy.
+func (p *Proxy) AddVersionBackend(version, backend string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.backends[version] = append(p.backends[version], backend)
+}
+
+// RemoveBackend removes a backend from the proxy. It is a no-op if the
+// backend was never added.
+func (p *Proxy) RemoveBackend(backend string) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
-	p.backends = append(p.backends, backend)
+	for version, backends := range p.backends {
+		if i := slices.Index(backends, backend); i >= 0 {
+			backends = slices.Delete(backends, i, i+1)
+			if len(backends) == 0 {
+				delete(p.backends, version)
+			} else {
+				p.backends[version] = backends
+			}
+			return
+		}
+	}
+}
+
+// SetWeight sets the fraction of traffic sent to the provided version,
+// relative to the weights of the other versions. The weight of a version
+// defaults to 1. A version with a weight of 0 receives traffic only if no
+// other version has a positive weight and a backend.
+func (p *Proxy) SetWeight(version string, weight float64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.weights[version] = weight
+}
+
+// pick picks a version with at least one backend, with probability
+// proportional to its weight. It returns false if there are no backends.
+//
+// REQUIRES: p.mu is held.
+func (p *Proxy) pick() (string, bool) {
+	versions := maps.Keys(p.backends)
+	if len(versions) == 0 {
+		return "", false
+	}
+	sort.Strings(versions)
+
+	weight := func(version string) float64 {
+		if w, ok := p.weights[version]; ok {
+			return w
+		}
+		return 1
+	}
+	var total float64
+	for _, v := range versions {
+		total += weight(v)
+	}
+	if total <= 0 {
+		// No version should receive traffic, but dropping requests is worse
+		// than sending them to any version.
+		return versions[rand.Intn(len(versions))], true
+	}
+	x := rand.Float64() * total
+	for _, v := range versions {
+		x -= weight(v)
+		if x < 0 {
+			return v, true
+		}
+	}
+	return versions[len(versions)-1], true
 }
 
 // director implements a ReverseProxy.Director function [1].
@@ -57,10 +143,12 @@ func (p *Proxy) AddBackend(backend string) {
 func (p *Proxy) director(r *http.Request) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
-	if len(p.backends) == 0 {
+	version, ok := p.pick()
+	if !ok {
 		p.logger.Error("director", "err", errors.New("no backends"), "url", r.URL)
 		return
 	}
+	backends := p.backends[version]
 	r.URL.Scheme = "http" // TODO(mwhittaker): Support HTTPS.
-	r.URL.Host = p.backends[rand.Intn(len(p.backends))]
+	r.URL.Host = backends[rand.Intn(len(backends))]
 }
@@ -86,6 +86,52 @@ func TestProxyOneBackend(t *testing.T) {
 	}
 }
 
+// TestProxyRemoveBackend verifies that the proxy stops forwarding traffic to
+// a backend once it has been removed.
+func TestProxyRemoveBackend(t *testing.T) {
+	backend := func(response string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(response))
+		}))
+	}
+	kept, removed := backend("kept"), backend("removed")
+	defer kept.Close()
+	defer removed.Close()
+
+	proxy := NewProxy(slog.Default())
+	for _, s := range []*httptest.Server{kept, removed} {
+		u, err := url.Parse(s.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		proxy.AddBackend(u.Host)
+	}
+	u, err := url.Parse(removed.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	proxy.RemoveBackend(u.Host)
+
+	frontend := httptest.NewServer(proxy)
+	defer frontend.Close()
+
+	// Every request should reach the remaining backend.
+	for i := 0; i < 10; i++ {
+		res, err := http.Get(frontend.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := io.ReadAll(res.Body)
+		res.Body.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if g, e := string(b), "kept"; g != e {
+			t.Fatalf("got body %q; expected %q", g, e)
+		}
+	}
+}
+
 // TestProxyConcurrentAddBackend assesses the proxy's ability to handle
 // concurrent addition of multiple backend servers.
 // It creates multiple backend servers, adds them to the proxy concurrently,
@@ -161,3 +207,67 @@ func TestProxyConcurrentAddBackend(t *testing.T) {
 		t.Fatalf("unexpected response body got: %s", string(b))
 	}
 }
+
+// TestProxyVersionWeights verifies that the proxy splits traffic between
+// versions based on their weights.
+func TestProxyVersionWeights(t *testing.T) {
+	backend := func(response string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(response))
+		}))
+	}
+	v1, v2 := backend("v1"), backend("v2")
+	defer v1.Close()
+	defer v2.Close()
+
+	proxy := NewProxy(slog.Default())
+	for version, s := range map[string]*httptest.Server{"v1": v1, "v2": v2} {
+		u, err := url.Parse(s.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		proxy.AddVersionBackend(version, u.Host)
+	}
+
+	frontend := httptest.NewServer(proxy)
+	defer frontend.Close()
+
+	// get returns the bodies of n requests sent through the proxy.
+	get := func(n int) map[string]int {
+		bodies := map[string]int{}
+		for i := 0; i < n; i++ {
+			res, err := http.Get(frontend.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+			b, err := io.ReadAll(res.Body)
+			res.Body.Close()
+			if err != nil {
+				t.Fatal(err)
+			}
+			bodies[string(b)]++
+		}
+		return bodies
+	}
+
+	for _, test := range []struct {
+		w1, w2 float64
+		want   string
+	}{
+		{1, 0, "v1"},
+		{0, 1, "v2"},
+		{0, 0.5, "v2"},
+	} {
+		proxy.SetWeight("v1", test.w1)
+		proxy.SetWeight("v2", test.w2)
+		if got := get(20); got[test.want] != 20 {
+			t.Errorf("weights (%v, %v): got %v, want all requests on %s", test.w1, test.w2, got, test.want)
+		}
+	}
+
+	// Traffic still flows if every version has a weight of 0.
+	proxy.SetWeight("v2", 0)
+	if got := get(20); got["v1"]+got["v2"] != 20 {
+		t.Errorf("zero weights: got %v, want 20 responses", got)
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"log/slog"
+	"net"
+	"net/http"
+	"sync"
+)
+
+// A Set contains the proxies of the listeners of an application, one per
+// listener. The proxies outlive any single version of the application: during
+// a rollout, the old and new versions share a Set, and the traffic received
+// by a listener is split between the versions based on their weights.
+type Set struct {
+	ctx context.Context // stops the proxies when canceled
+
+	mu      sync.Mutex         // guards the following
+	proxies map[string]*entry  // proxies, by listener name
+	weights map[string]float64 // traffic weights, by version
+}
+
+// entry is a running proxy in a Set.
+type entry struct {
+	proxy *Proxy // the proxy
+	addr  string // dialable address of the proxy
+}
+
+// NewSet returns a new, empty Set. The proxies are stopped when the provided
+// context is canceled.
+func NewSet(ctx context.Context) *Set {
+	return &Set{
+		ctx:     ctx,
+		proxies: map[string]*entry{},
+		weights: map[string]float64{},
+	}
+}
+
+// Export adds the provided backend, which belongs to the provided version, to
+// the proxy of the provided listener, and returns the address of the proxy.
+// If the listener doesn't have a proxy yet, Export starts one that listens on
+// addr and logs to the provided logger. If the proxy can't listen on addr,
+// Export returns the error returned by net.Listen.
+func (s *Set) Export(logger *slog.Logger, listener, addr, version, backend string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if e, ok := s.proxies[listener]; ok {
+		e.proxy.AddVersionBackend(version, backend)
+		return e.addr, nil
+	}
+
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return "", err
+	}
+	addr = lis.Addr().String() // actual proxy address
+	logger.Info("Proxy listening", "address", addr)
+	proxy := NewProxy(logger)
+	for version, weight := range s.weights {
+		proxy.SetWeight(version, weight)
+	}
+	proxy.AddVersionBackend(version, backend)
+	s.proxies[listener] = &entry{proxy: proxy, addr: addr}
+	go func() {
+		if err := serve(s.ctx, lis, proxy); err != nil {
+			logger.Error("Proxy", "err", err)
+		}
+	}()
+	return addr, nil
+}
+
+// Remove stops sending the traffic of the provided listener to the provided
+// backend. It is a no-op if the backend was never exported.
+func (s *Set) Remove(listener, backend string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if e, ok := s.proxies[listener]; ok {
+		e.proxy.RemoveBackend(backend)
+	}
+}
+
+// SetWeight sets the weight of the provided version in every proxy,
+// including the proxies that are started later. See Proxy.SetWeight.
+func (s *Set) SetWeight(version string, weight float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.weights[version] = weight
+	for _, e := range s.proxies {
+		e.proxy.SetWeight(version, weight)
+	}
+}
+
+// Listeners returns the addresses of the proxies, by listener name.
+func (s *Set) Listeners() map[string]string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	addrs := make(map[string]string, len(s.proxies))
+	for listener, e := range s.proxies {
+		addrs[listener] = e.addr
+	}
+	return addrs
+}
+
+// serve serves HTTP traffic on the provided listener using the provided
+// handler. The server is shut down when the provided context is canceled.
+func serve(ctx context.Context, lis net.Listener, handler http.Handler) error {
+	server := http.Server{Handler: handler}
+	errs := make(chan error, 1)
+	go func() { errs <- server.Serve(lis) }()
+	select {
+	case err := <-errs:
+		return err
+	case <-ctx.Done():
+		return server.Shutdown(ctx)
+	}
+}
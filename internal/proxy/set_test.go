@@ -0,0 +1,86 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+// TestSetRollout verifies that the versions that export the same listener
+// share its proxy, and that traffic follows the weights of the versions.
+func TestSetRollout(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	set := NewSet(ctx)
+
+	// export starts a backend of the provided version and exports it.
+	export := func(version string) string {
+		backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(version))
+		}))
+		t.Cleanup(backend.Close)
+		u, err := url.Parse(backend.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		addr, err := set.Export(slog.Default(), "lis", "localhost:0", version, u.Host)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return addr
+	}
+
+	// get returns the body of a request sent to the provided address.
+	get := func(addr string) string {
+		res, err := http.Get("http://" + addr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer res.Body.Close()
+		b, err := io.ReadAll(res.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(b)
+	}
+
+	addr := export("v1")
+	set.SetWeight("v2", 0)
+	if got := export("v2"); got != addr {
+		t.Fatalf("proxy address of v2: got %q, want %q", got, addr)
+	}
+	if got, want := set.Listeners(), map[string]string{"lis": addr}; len(got) != 1 || got["lis"] != want["lis"] {
+		t.Fatalf("Listeners: got %v, want %v", got, want)
+	}
+	for i := 0; i < 10; i++ {
+		if got := get(addr); got != "v1" {
+			t.Fatalf("before rollout: got %q, want v1", got)
+		}
+	}
+
+	set.SetWeight("v1", 0)
+	set.SetWeight("v2", 1)
+	for i := 0; i < 10; i++ {
+		if got := get(addr); got != "v2" {
+			t.Fatalf("after rollout: got %q, want v2", got)
+		}
+	}
+}
@@ -0,0 +1,386 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routing
+
+import (
+	"math"
+	"slices"
+	"sort"
+
+	"github.com/sh3lk/mx/runtime/protos"
+)
+
+const (
+	// Two adjacent slices assigned to the same replica are merged if their
+	// combined load is below this fraction of the target load of a replica.
+	mergeFraction = 0.05
+
+	// The maximum number of slices per replica.
+	maxSlicesPerReplica = 32
+
+	// The balancer doesn't move slices once the load of every replica is
+	// within this fraction of the target load.
+	balanceTolerance = 0.1
+)
+
+// TotalLoad returns the total load reported for the assignment with the
+// provided version. Reports for other versions are ignored.
+func TotalLoad(version uint64, loads []*protos.LoadReport_ComponentLoad) float64 {
+	var total float64
+	for _, load := range loads {
+		if load.GetVersion() != version {
+			continue
+		}
+		for _, slice := range load.Load {
+			total += slice.Load
+		}
+	}
+	return total
+}
+
+// BalanceLoad returns an assignment of the key space to the provided replicas
+// that balances the load reported for the current assignment. loads contains
+// the load reported by the replicas of the component; reports for assignments
+// other than current are ignored. If no load was reported, BalanceLoad
+// instead balances the fraction of the key space assigned to every replica.
+//
+// To keep the number of keys that move between replicas small, BalanceLoad
+// starts from the current assignment. Slices assigned to replicas that are
+// gone are reassigned to the least loaded replicas. Then, slices are moved
+// from the most to the least loaded replicas until every replica is within a
+// small tolerance of the average load, splitting slices that are too hot to
+// be moved as a whole. Finally, cold adjacent slices assigned to the same
+// replica are merged.
+//
+// If the current assignment is already balanced, BalanceLoad returns current.
+// Otherwise, the returned assignment has the version of current plus one.
+func BalanceLoad(current *protos.Assignment, replicas []string, loads []*protos.LoadReport_ComponentLoad) *protos.Assignment {
+	if len(replicas) == 0 {
+		if len(current.GetSlices()) == 0 {
+			return current
+		}
+		return &protos.Assignment{Version: current.GetVersion() + 1}
+	}
+	if len(current.GetSlices()) == 0 {
+		// There is no assignment to start from.
+		assignment := EqualSlices(replicas)
+		assignment.Version = current.GetVersion() + 1
+		return assignment
+	}
+
+	replicas = slices.Clone(replicas)
+	sort.Strings(replicas)
+	b := newBalancer(current, replicas, loads)
+	b.reassign()
+	b.move()
+	b.merge()
+	next := b.assignment()
+	if sameSlices(current, next) {
+		return current
+	}
+	next.Version = current.Version + 1
+	return next
+}
+
+// balancer balances the load of an assignment.
+type balancer struct {
+	replicas []string           // sorted replicas
+	live     map[string]bool    // replicas, as a set
+	spans    []*span            // spans, sorted by start
+	loads    map[string]float64 // total load, by replica
+	counts   map[string]int     // number of spans, by replica
+	target   float64            // target load per replica
+}
+
+// span is the segment [start, end) of the key space, assigned to a single
+// replica. The last span of an assignment includes math.MaxUint64.
+type span struct {
+	start   uint64
+	end     uint64
+	replica string                            // assigned replica, or "" if the replica is gone
+	load    float64                           // measured load, or the fraction of the key space
+	uniform bool                              // is load the fraction of the key space?
+	splits  []*protos.LoadReport_SubsliceLoad // breakdown of the measured load
+}
+
+// newBalancer returns a balancer for the provided assignment and load.
+func newBalancer(current *protos.Assignment, replicas []string, loads []*protos.LoadReport_ComponentLoad) *balancer {
+	b := &balancer{
+		replicas: replicas,
+		live:     map[string]bool{},
+		loads:    map[string]float64{},
+		counts:   map[string]int{},
+	}
+	for _, replica := range replicas {
+		b.live[replica] = true
+	}
+
+	// Form a span for every slice of the assignment.
+	byStart := map[uint64]*span{}
+	for i, slice := range current.Slices {
+		s := &span{start: slice.Start, end: math.MaxUint64}
+		if i < len(current.Slices)-1 {
+			s.end = current.Slices[i+1].Start
+		}
+		for _, replica := range slice.Replicas {
+			if b.live[replica] {
+				s.replica = replica
+				break
+			}
+		}
+		b.spans = append(b.spans, s)
+		byStart[s.start] = s
+	}
+
+	// Attribute the reported load to the spans. If no load was reported, the
+	// load of a span is the fraction of the key space it covers.
+	total := TotalLoad(current.Version, loads)
+	if total > 0 {
+		for _, load := range loads {
+			if load.GetVersion() != current.Version {
+				continue
+			}
+			for _, slice := range load.Load {
+				if s, ok := byStart[slice.Start]; ok {
+					s.load += slice.Load
+					s.splits = append(s.splits, slice.Splits...)
+				}
+			}
+		}
+		for _, s := range b.spans {
+			sort.SliceStable(s.splits, func(i, j int) bool {
+				return s.splits[i].Start < s.splits[j].Start
+			})
+		}
+	} else {
+		total = 1
+		for _, s := range b.spans {
+			s.load = s.width()
+			s.uniform = true
+		}
+	}
+	b.target = total / float64(len(replicas))
+
+	for _, s := range b.spans {
+		if s.replica != "" {
+			b.loads[s.replica] += s.load
+			b.counts[s.replica]++
+		}
+	}
+	return b
+}
+
+// width returns the fraction of the key space covered by the span.
+func (s *span) width() float64 {
+	return float64(s.end-s.start) / math.MaxUint64
+}
+
+// reassign assigns the spans of replicas that are gone to the least loaded
+// replicas, hottest spans first.
+func (b *balancer) reassign() {
+	var orphans []*span
+	for _, s := range b.spans {
+		if s.replica == "" {
+			orphans = append(orphans, s)
+		}
+	}
+	sort.SliceStable(orphans, func(i, j int) bool {
+		return orphans[i].load > orphans[j].load
+	})
+	for _, s := range orphans {
+		b.assign(s, b.coldest())
+	}
+}
+
+// split splits the span in two halves of roughly equal load. It shrinks s to
+// the left half and returns the right half. It returns false if the span
+// cannot be split.
+func (s *span) split() (*span, bool) {
+	if s.uniform {
+		// The load is spread uniformly, so split the span in the middle.
+		if s.end-s.start < 2 {
+			return nil, false
+		}
+		mid := s.start + (s.end-s.start)/2
+		right := &span{start: mid, end: s.end, replica: s.replica, load: s.load / 2, uniform: true}
+		s.end, s.load = mid, s.load/2
+		return right, true
+	}
+
+	// Split at the subslice boundary that best halves the load.
+	best, bestLoad := -1, 0.0
+	var cum float64
+	for i, sub := range s.splits {
+		if i > 0 && sub.Start > s.start && sub.Start < s.end {
+			if best < 0 || math.Abs(cum-s.load/2) < math.Abs(bestLoad-s.load/2) {
+				best, bestLoad = i, cum
+			}
+		}
+		cum += sub.Load
+	}
+	if best < 0 {
+		// The load is concentrated on a single subslice (e.g., a hot key), or
+		// there is no breakdown of the load.
+		return nil, false
+	}
+	right := &span{
+		start:   s.splits[best].Start,
+		end:     s.end,
+		replica: s.replica,
+		load:    s.load - bestLoad,
+		splits:  s.splits[best:],
+	}
+	s.end, s.load, s.splits = right.start, bestLoad, s.splits[:best]
+	return right, true
+}
+
+// move moves spans from the most to the least loaded replicas, until the
+// load of every replica is within the tolerance of the target. If no span of
+// an overloaded replica is small enough to be moved, one is split in two.
+func (b *balancer) move() {
+	// Every move strictly decreases the imbalance between two replicas, and
+	// the number of splits is bounded, but we bound the number of iterations
+	// to be safe.
+	maxSpans := maxSlicesPerReplica * len(b.replicas)
+	slack := balanceTolerance * b.target
+	for n := 0; n < 2*maxSpans; n++ {
+		hot, cold := b.hottest(), b.coldest()
+		if b.loads[hot] <= b.target+slack {
+			return
+		}
+
+		// Move the largest span that doesn't overshoot the load needed to
+		// bring either replica to the target.
+		need := math.Min(b.loads[hot]-b.target, b.target-b.loads[cold])
+		var best *span
+		for _, s := range b.spans {
+			if s.replica != hot || s.load <= 0 || s.load > need+slack {
+				continue
+			}
+			if best == nil || s.load > best.load {
+				best = s
+			}
+		}
+		if best != nil {
+			b.assign(best, cold)
+			continue
+		}
+
+		// Every span is too hot to be moved. Split the smallest one.
+		if len(b.spans) >= maxSpans {
+			return
+		}
+		split := false
+		for _, i := range b.bySize(hot) {
+			if right, ok := b.spans[i].split(); ok {
+				b.spans = slices.Insert(b.spans, i+1, right)
+				b.counts[hot]++
+				split = true
+				break
+			}
+		}
+		if !split {
+			return
+		}
+	}
+}
+
+// bySize returns the indices of the spans assigned to the provided replica
+// that have a positive load, sorted by increasing load.
+func (b *balancer) bySize(replica string) []int {
+	var indices []int
+	for i, s := range b.spans {
+		if s.replica == replica && s.load > 0 {
+			indices = append(indices, i)
+		}
+	}
+	sort.SliceStable(indices, func(i, j int) bool {
+		return b.spans[indices[i]].load < b.spans[indices[j]].load
+	})
+	return indices
+}
+
+// merge merges adjacent spans assigned to the same replica whose combined
+// load is below the merge threshold.
+func (b *balancer) merge() {
+	threshold := mergeFraction * b.target
+	merged := b.spans[:1]
+	for _, s := range b.spans[1:] {
+		last := merged[len(merged)-1]
+		if last.replica == s.replica && last.load+s.load < threshold {
+			last.end = s.end
+			last.load += s.load
+			last.splits = append(last.splits, s.splits...)
+			b.counts[s.replica]--
+			continue
+		}
+		merged = append(merged, s)
+	}
+	b.spans = merged
+}
+
+// assign assigns the provided span to the provided replica.
+func (b *balancer) assign(s *span, replica string) {
+	if s.replica != "" {
+		b.loads[s.replica] -= s.load
+		b.counts[s.replica]--
+	}
+	s.replica = replica
+	b.loads[replica] += s.load
+	b.counts[replica]++
+}
+
+// coldest returns the least loaded replica, breaking ties by the number of
+// assigned spans.
+func (b *balancer) coldest() string {
+	coldest := b.replicas[0]
+	for _, r := range b.replicas[1:] {
+		if b.loads[r] < b.loads[coldest] || (b.loads[r] == b.loads[coldest] && b.counts[r] < b.counts[coldest]) {
+			coldest = r
+		}
+	}
+	return coldest
+}
+
+// hottest returns the most loaded replica.
+func (b *balancer) hottest() string {
+	hottest := b.replicas[0]
+	for _, r := range b.replicas[1:] {
+		if b.loads[r] > b.loads[hottest] {
+			hottest = r
+		}
+	}
+	return hottest
+}
+
+// assignment returns the assignment formed by the spans. The returned
+// assignment has a version of 0.
+func (b *balancer) assignment() *protos.Assignment {
+	assignment := &protos.Assignment{}
+	for _, s := range b.spans {
+		assignment.Slices = append(assignment.Slices, &protos.Assignment_Slice{
+			Start:    s.start,
+			Replicas: []string{s.replica},
+		})
+	}
+	return assignment
+}
+
+// sameSlices returns whether the two assignments have the same slices.
+func sameSlices(a, b *protos.Assignment) bool {
+	return slices.EqualFunc(a.Slices, b.Slices, func(x, y *protos.Assignment_Slice) bool {
+		return x.Start == y.Start && slices.Equal(x.Replicas, y.Replicas)
+	})
+}
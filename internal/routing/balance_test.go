@@ -0,0 +1,238 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routing
+
+import (
+	"math"
+	"testing"
+
+	"github.com/sh3lk/mx/runtime/protos"
+)
+
+// owner returns the replica assigned to the provided key.
+func owner(a *protos.Assignment, key uint64) string {
+	var replica string
+	for _, slice := range a.Slices {
+		if slice.Start > key {
+			break
+		}
+		replica = slice.Replicas[0]
+	}
+	return replica
+}
+
+// widths returns the fraction of the key space assigned to every replica.
+func widths(a *protos.Assignment) map[string]float64 {
+	widths := map[string]float64{}
+	for i, slice := range a.Slices {
+		end := uint64(math.MaxUint64)
+		if i < len(a.Slices)-1 {
+			end = a.Slices[i+1].Start
+		}
+		widths[slice.Replicas[0]] += float64(end-slice.Start) / math.MaxUint64
+	}
+	return widths
+}
+
+// moved returns the fraction of sampled keys assigned to different replicas
+// by the two assignments.
+func moved(a, b *protos.Assignment) float64 {
+	const n = 10000
+	var count int
+	for i := uint64(0); i < n; i++ {
+		key := i * (math.MaxUint64 / n)
+		if owner(a, key) != owner(b, key) {
+			count++
+		}
+	}
+	return float64(count) / n
+}
+
+func TestBalanceLoadInitialAssignment(t *testing.T) {
+	got := BalanceLoad(&protos.Assignment{Version: 3}, []string{"a", "b"}, nil)
+	if got.Version != 4 {
+		t.Errorf("version: got %d, want 4", got.Version)
+	}
+	if w := widths(got); math.Abs(w["a"]-0.5) > 0.01 || math.Abs(w["b"]-0.5) > 0.01 {
+		t.Errorf("widths: got %v, want a and b to own half of the key space", w)
+	}
+}
+
+func TestBalanceLoadNoChange(t *testing.T) {
+	current := BalanceLoad(&protos.Assignment{}, []string{"a", "b"}, nil)
+	if got := BalanceLoad(current, []string{"b", "a"}, nil); got != current {
+		t.Fatalf("BalanceLoad: got new assignment\n%s", FormatAssignment(got))
+	}
+}
+
+func TestBalanceLoadAddReplica(t *testing.T) {
+	current := BalanceLoad(&protos.Assignment{}, []string{"a", "b"}, nil)
+	got := BalanceLoad(current, []string{"a", "b", "c"}, nil)
+	w := widths(got)
+	for _, r := range []string{"a", "b", "c"} {
+		if w[r] < 1.0/3*(1-balanceTolerance) || w[r] > 1.0/3*(1+balanceTolerance) {
+			t.Errorf("width of %s: got %v, want roughly 1/3", r, w[r])
+		}
+	}
+	// Only the keys that move to c should move.
+	if m := moved(current, got); m > 0.4 {
+		t.Errorf("moved: got %v of the keys, want at most 0.4", m)
+	}
+}
+
+func TestBalanceLoadRemoveReplica(t *testing.T) {
+	current := BalanceLoad(&protos.Assignment{}, []string{"a", "b", "c"}, nil)
+	current = BalanceLoad(current, []string{"a", "b", "c"}, nil)
+	got := BalanceLoad(current, []string{"a", "b"}, nil)
+	w := widths(got)
+	if _, ok := w["c"]; ok {
+		t.Fatalf("removed replica c still assigned\n%s", FormatAssignment(got))
+	}
+	for _, r := range []string{"a", "b"} {
+		if w[r] < 0.5*(1-balanceTolerance) || w[r] > 0.5*(1+balanceTolerance) {
+			t.Errorf("width of %s: got %v, want roughly 1/2", r, w[r])
+		}
+	}
+	// Mostly c's keys should move.
+	if m := moved(current, got); m > 0.4 {
+		t.Errorf("moved: got %v of the keys, want at most 0.4", m)
+	}
+}
+
+func TestBalanceLoadHotSlice(t *testing.T) {
+	// a owns [0, half), b owns [half, max]. All of the load is on a, but
+	// spread evenly over four subslices.
+	const half = math.MaxUint64 / 2
+	const quarter = math.MaxUint64 / 4
+	current := &protos.Assignment{
+		Version: 7,
+		Slices: []*protos.Assignment_Slice{
+			{Start: 0, Replicas: []string{"a"}},
+			{Start: half, Replicas: []string{"b"}},
+		},
+	}
+	loads := []*protos.LoadReport_ComponentLoad{
+		{
+			Version: 7,
+			Load: []*protos.LoadReport_SliceLoad{{
+				Start: 0,
+				End:   half,
+				Load:  100,
+				Splits: []*protos.LoadReport_SubsliceLoad{
+					{Start: 0, Load: 25},
+					{Start: quarter / 4, Load: 25},
+					{Start: quarter / 2, Load: 25},
+					{Start: quarter, Load: 25},
+				},
+			}},
+		},
+		{Version: 7},
+	}
+
+	got := BalanceLoad(current, []string{"a", "b"}, loads)
+	if got.Version != 8 {
+		t.Errorf("version: got %d, want 8", got.Version)
+	}
+	// The hot slice should be split, and half of its load moved to b.
+	load := map[string]float64{}
+	for _, split := range loads[0].Load[0].Splits {
+		load[owner(got, split.Start)] += split.Load
+	}
+	if load["a"] != 50 || load["b"] != 50 {
+		t.Errorf("load: got %v, want 50 on a and b\n%s", load, FormatAssignment(got))
+	}
+	// b's cold slice should stay on b.
+	if owner(got, half) != "b" || owner(got, math.MaxUint64) != "b" {
+		t.Errorf("cold slice moved\n%s", FormatAssignment(got))
+	}
+}
+
+func TestBalanceLoadHotKey(t *testing.T) {
+	// All of the load is on a single key, so there is nothing to balance.
+	current := &protos.Assignment{
+		Version: 1,
+		Slices: []*protos.Assignment_Slice{
+			{Start: 0, Replicas: []string{"a"}},
+			{Start: math.MaxUint64 / 2, Replicas: []string{"b"}},
+		},
+	}
+	loads := []*protos.LoadReport_ComponentLoad{{
+		Version: 1,
+		Load: []*protos.LoadReport_SliceLoad{{
+			Start:  0,
+			End:    math.MaxUint64 / 2,
+			Load:   100,
+			Splits: []*protos.LoadReport_SubsliceLoad{{Start: 0, Load: 100}},
+		}},
+	}}
+	if got := BalanceLoad(current, []string{"a", "b"}, loads); got != current {
+		t.Fatalf("BalanceLoad: got new assignment\n%s", FormatAssignment(got))
+	}
+}
+
+func TestBalanceLoadIgnoresStaleLoad(t *testing.T) {
+	current := BalanceLoad(&protos.Assignment{}, []string{"a", "b"}, nil)
+	loads := []*protos.LoadReport_ComponentLoad{{
+		Version: current.Version - 1,
+		Load: []*protos.LoadReport_SliceLoad{{
+			Start: 0,
+			Load:  100,
+		}},
+	}}
+	if got := BalanceLoad(current, []string{"a", "b"}, loads); got != current {
+		t.Fatalf("BalanceLoad: got new assignment\n%s", FormatAssignment(got))
+	}
+}
+
+func TestBalanceLoadMergesColdSlices(t *testing.T) {
+	const n = 16
+	current := &protos.Assignment{Version: 1}
+	for i := uint64(0); i < n; i++ {
+		replica := "a"
+		if i >= n/2 {
+			replica = "b"
+		}
+		current.Slices = append(current.Slices, &protos.Assignment_Slice{
+			Start:    i * (math.MaxUint64 / n),
+			Replicas: []string{replica},
+		})
+	}
+	// The first slice of every replica is hot; the others are cold.
+	loads := []*protos.LoadReport_ComponentLoad{{
+		Version: 1,
+		Load: []*protos.LoadReport_SliceLoad{
+			{Start: 0, Load: 10},
+			{Start: n / 2 * (math.MaxUint64 / n), Load: 10},
+		},
+	}}
+	got := BalanceLoad(current, []string{"a", "b"}, loads)
+	if len(got.Slices) != 4 {
+		t.Errorf("slices: got %d, want 4\n%s", len(got.Slices), FormatAssignment(got))
+	}
+	if m := moved(current, got); m != 0 {
+		t.Errorf("moved: got %v of the keys, want 0", m)
+	}
+}
+
+func TestTotalLoad(t *testing.T) {
+	loads := []*protos.LoadReport_ComponentLoad{
+		{Version: 2, Load: []*protos.LoadReport_SliceLoad{{Load: 1}, {Load: 2}}},
+		{Version: 1, Load: []*protos.LoadReport_SliceLoad{{Load: 4}}},
+		{Version: 2, Load: []*protos.LoadReport_SliceLoad{{Load: 8}}},
+	}
+	if got, want := TotalLoad(2, loads), 11.0; got != want {
+		t.Fatalf("TotalLoad: got %v, want %v", got, want)
+	}
+}
@@ -70,6 +70,19 @@ var (
 			return strings.Join(s, ", ")
 
 		},
+		"restartjoin": func(replicas []*Replica) string {
+			s := make([]string, len(replicas))
+			for i, x := range replicas {
+				s[i] = fmt.Sprint(x.Restarts)
+			}
+			return strings.Join(s, ", ")
+		},
+		"breakers": func(c *Component) string {
+			if c.OpenBreakers == 0 && c.HalfOpenBreakers == 0 {
+				return "closed"
+			}
+			return fmt.Sprintf("%d open, %d half-open", c.OpenBreakers, c.HalfOpenBreakers)
+		},
 		"age": func(t *timestamppb.Timestamp) string {
 			return time.Since(t.AsTime()).Truncate(time.Second).String()
 		},
@@ -23,8 +23,10 @@ import (
 	"strings"
 	"time"
 
+	"github.com/sh3lk/mx/internal/net/call"
 	"github.com/sh3lk/mx/runtime/colors"
 	"github.com/sh3lk/mx/runtime/logging"
+	"github.com/sh3lk/mx/runtime/metrics"
 	dtool "github.com/sh3lk/mx/runtime/tool"
 )
 
@@ -79,6 +81,7 @@ func format(statuses []*Status) string {
 	var b strings.Builder
 	formatDeployments(&b, statuses)
 	formatComponents(&b, statuses)
+	formatCircuitBreakers(&b, statuses)
 	formatListeners(&b, statuses)
 	return b.String()
 }
@@ -111,7 +114,7 @@ func formatComponents(w io.Writer, statuses []*Status) {
 	title := []colors.Text{{{S: "COMPONENTS", Bold: true}}}
 	t := colors.NewTabularizer(w, title, colors.PrefixDim)
 	defer t.Flush()
-	t.Row("APP", "DEPLOYMENT", "COMPONENT", "REPLICA PIDS", "MXN IDS")
+	t.Row("APP", "DEPLOYMENT", "COMPONENT", "REPLICA PIDS", "MXN IDS", "RESTARTS")
 	for _, status := range statuses {
 		sort.Slice(status.Components, func(i, j int) bool {
 			return status.Components[i].Name < status.Components[j].Name
@@ -124,11 +127,58 @@ func formatComponents(w io.Writer, statuses []*Status) {
 			})
 			pids := make([]string, len(component.Replicas))
 			mxnIds := make([]string, len(component.Replicas))
+			restarts := make([]string, len(component.Replicas))
 			for i, replica := range component.Replicas {
 				pids[i] = fmt.Sprint(replica.Pid)
 				mxnIds[i] = replica.MXNId[0:8]
+				restarts[i] = fmt.Sprint(replica.Restarts)
 			}
-			t.Row(status.App, prefix, c, strings.Join(pids, ", "), strings.Join(mxnIds, ", "))
+			t.Row(status.App, prefix, c, strings.Join(pids, ", "), strings.Join(mxnIds, ", "), strings.Join(restarts, ", "))
+		}
+	}
+}
+
+// formatCircuitBreakers pretty-prints the components with tripped circuit
+// breakers.
+func formatCircuitBreakers(w io.Writer, statuses []*Status) {
+	title := []colors.Text{{{S: "CIRCUIT BREAKERS", Bold: true}}}
+	t := colors.NewTabularizer(w, title, colors.PrefixDim)
+	defer t.Flush()
+	t.Row("APP", "DEPLOYMENT", "COMPONENT", "OPEN", "HALF-OPEN")
+	for _, status := range statuses {
+		for _, component := range status.Components {
+			if component.OpenBreakers == 0 && component.HalfOpenBreakers == 0 {
+				continue
+			}
+			prefix, _ := formatId(status.DeploymentId)
+			c := logging.ShortenComponent(component.Name)
+			t.Row(status.App, prefix, c, component.OpenBreakers, component.HalfOpenBreakers)
+		}
+	}
+}
+
+// SetCircuitBreakers sets the number of open and half-open circuit breakers
+// of the provided components, using the circuit breaker state metrics in the
+// provided snapshots.
+func SetCircuitBreakers(components []*Component, snapshots []*metrics.MetricSnapshot) {
+	byName := map[string]*Component{}
+	for _, c := range components {
+		c.OpenBreakers, c.HalfOpenBreakers = 0, 0
+		byName[c.Name] = c
+	}
+	for _, snap := range snapshots {
+		if snap.Name != call.CircuitBreakerStateMetric {
+			continue
+		}
+		c, ok := byName[snap.Labels["component"]]
+		if !ok {
+			continue
+		}
+		switch snap.Value {
+		case 1:
+			c.HalfOpenBreakers++
+		case 2:
+			c.OpenBreakers++
 		}
 	}
 }
@@ -0,0 +1,116 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package supervisor restarts crashed and unhealthy mxn replicas.
+package supervisor
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/sh3lk/mx/runtime/protos"
+	"github.com/sh3lk/mx/runtime/retry"
+)
+
+var (
+	// RestartBackoff is the backoff between two consecutive restarts of a
+	// replica.
+	RestartBackoff = retry.Options{
+		BackoffMultiplier:  2,
+		BackoffMinDuration: 100 * time.Millisecond,
+	}
+
+	// HealthyAfter is how long a replica must run before it is considered
+	// stable, in which case the restart backoff is reset.
+	HealthyAfter = time.Minute
+
+	// HealthInterval is the time between two health checks of a replica.
+	HealthInterval = 5 * time.Second
+
+	// MaxHealthFailures is the number of consecutive failed health checks
+	// after which a replica is considered unhealthy.
+	MaxHealthFailures = 3
+)
+
+// Supervise runs a replica by calling run, and calls run again to restart the
+// replica every time it fails, with exponential backoff. run is passed the
+// number of times the replica has been restarted. It should block until the
+// replica exits, and return a non-nil error if the replica failed and should
+// be restarted, or nil if the replica was stopped on purpose.
+//
+// Supervise returns when run returns nil or when ctx is done.
+func Supervise(ctx context.Context, logger *slog.Logger, run func(restarts int) error) {
+	restarts := 0
+	for r := retry.BeginWithOptions(RestartBackoff); r.Continue(ctx); {
+		started := time.Now()
+		err := run(restarts)
+		if err == nil || ctx.Err() != nil {
+			return
+		}
+		restarts++
+		logger.Error("Replica failed; restarting", "err", err, "restarts", restarts)
+		if time.Since(started) >= HealthyAfter {
+			// The replica ran for a while before failing, so we restart it
+			// without backing off.
+			r.Reset()
+		}
+	}
+}
+
+// Checker is the interface implemented by envelope.Envelope that reports the
+// health of a mxn.
+type Checker interface {
+	GetHealth() *protos.GetHealthReply
+}
+
+// WatchHealth periodically checks the health of a replica until ctx is done.
+// If the replica fails MaxHealthFailures consecutive health checks, WatchHealth
+// calls kill and returns. A health check fails if the replica reports itself
+// as not healthy, or if it doesn't respond within HealthInterval.
+func WatchHealth(ctx context.Context, logger *slog.Logger, c Checker, kill func()) {
+	ticker := time.NewTicker(HealthInterval)
+	defer ticker.Stop()
+	failures := 0
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		// GetHealth doesn't take a context, so we bound it ourselves.
+		replies := make(chan protos.HealthStatus, 1)
+		go func() { replies <- c.GetHealth().Status }()
+		status := protos.HealthStatus_UNKNOWN
+		select {
+		case <-ctx.Done():
+			return
+		case status = <-replies:
+		case <-time.After(HealthInterval):
+		}
+
+		if status == protos.HealthStatus_HEALTHY {
+			failures = 0
+			continue
+		}
+		failures++
+		logger.Debug("Replica failed health check", "status", status, "failures", failures)
+		if failures >= MaxHealthFailures {
+			logger.Error("Replica unhealthy; killing", "status", status)
+			kill()
+			return
+		}
+	}
+}
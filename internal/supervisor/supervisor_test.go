@@ -0,0 +1,96 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package supervisor
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sh3lk/mx/runtime/protos"
+	"github.com/sh3lk/mx/runtime/retry"
+)
+
+var logger = slog.New(slog.NewTextHandler(os.Stderr, nil))
+
+func init() {
+	RestartBackoff = retry.Options{BackoffMultiplier: 1, BackoffMinDuration: time.Millisecond}
+	HealthInterval = time.Millisecond
+}
+
+func TestSuperviseRestarts(t *testing.T) {
+	var got []int
+	Supervise(context.Background(), logger, func(restarts int) error {
+		got = append(got, restarts)
+		if restarts < 3 {
+			return errors.New("crash")
+		}
+		return nil
+	})
+	if want := []int{0, 1, 2, 3}; !slices.Equal(got, want) {
+		t.Fatalf("run calls: got %v, want %v", got, want)
+	}
+}
+
+func TestSuperviseStopsWhenCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	Supervise(ctx, logger, func(int) error {
+		calls++
+		cancel()
+		return errors.New("crash")
+	})
+	if calls != 1 {
+		t.Fatalf("run calls: got %d, want 1", calls)
+	}
+}
+
+// checker is a fake Checker that reports a fixed status.
+type checker struct {
+	status atomic.Int32
+}
+
+func (c *checker) GetHealth() *protos.GetHealthReply {
+	return &protos.GetHealthReply{Status: protos.HealthStatus(c.status.Load())}
+}
+
+func TestWatchHealthKillsUnhealthy(t *testing.T) {
+	c := &checker{}
+	c.status.Store(int32(protos.HealthStatus_UNHEALTHY))
+	killed := make(chan struct{})
+	go WatchHealth(context.Background(), logger, c, func() { close(killed) })
+	select {
+	case <-killed:
+	case <-time.After(10 * time.Second):
+		t.Fatal("unhealthy replica not killed")
+	}
+}
+
+func TestWatchHealthSparesHealthy(t *testing.T) {
+	c := &checker{}
+	c.status.Store(int32(protos.HealthStatus_HEALTHY))
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	var killed atomic.Bool
+	WatchHealth(ctx, logger, c, func() { killed.Store(true) })
+	if killed.Load() {
+		t.Fatal("healthy replica killed")
+	}
+}
@@ -31,7 +31,7 @@ func init() {
 		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
 			return a_reflect_stub{caller: caller}
 		},
-		RefData: "⟦d473cf51:MxEdge:github.com/sh3lk/mx/internal/testdeployer/a→github.com/sh3lk/mx/internal/testdeployer/b⟧\n⟦83f71f4e:wEaVeRlIsTeNeRs:github.com/sh3lk/mx/internal/testdeployer/a→lis⟧\n",
+		RefData: "⟦d18cfbfb:MxEdge:github.com/sh3lk/mx/internal/testdeployer/a→github.com/sh3lk/mx/internal/testdeployer/b⟧\n⟦4b6fce9a:wEaVeRlIsTeNeRs:github.com/sh3lk/mx/internal/testdeployer/a→lis⟧\n⟦36aaec7c:MxSchema:github.com/sh3lk/mx/internal/testdeployer/a→[{\"name\":\"A\",\"args\":[{\"kind\":\"int64\"}],\"results\":[{\"kind\":\"int64\"}]}]⟧\n",
 	})
 	codegen.Register(codegen.Registration{
 		Name:  "github.com/sh3lk/mx/internal/testdeployer/b",
@@ -49,7 +49,7 @@ func init() {
 		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
 			return b_reflect_stub{caller: caller}
 		},
-		RefData: "⟦54fc5958:MxEdge:github.com/sh3lk/mx/internal/testdeployer/b→github.com/sh3lk/mx/internal/testdeployer/c⟧\n",
+		RefData: "⟦e519f9bd:MxEdge:github.com/sh3lk/mx/internal/testdeployer/b→github.com/sh3lk/mx/internal/testdeployer/c⟧\n⟦4e9e38e6:MxSchema:github.com/sh3lk/mx/internal/testdeployer/b→[{\"name\":\"B\",\"args\":[{\"kind\":\"int64\"}],\"results\":[{\"kind\":\"int64\"}]}]⟧\n",
 	})
 	codegen.Register(codegen.Registration{
 		Name:  "github.com/sh3lk/mx/internal/testdeployer/c",
@@ -67,7 +67,7 @@ func init() {
 		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
 			return c_reflect_stub{caller: caller}
 		},
-		RefData: "",
+		RefData: "⟦09b9f570:MxSchema:github.com/sh3lk/mx/internal/testdeployer/c→[{\"name\":\"C\",\"args\":[{\"kind\":\"int64\"}],\"results\":[{\"kind\":\"int64\"}]}]⟧\n",
 	})
 	codegen.Register(codegen.Registration{
 		Name:  "github.com/sh3lk/mx/internal/testdeployer/d",
@@ -85,7 +85,7 @@ func init() {
 		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
 			return d_reflect_stub{caller: caller}
 		},
-		RefData: "",
+		RefData: "⟦bafd32ca:MxSchema:github.com/sh3lk/mx/internal/testdeployer/d→[{\"name\":\"D\",\"results\":[{\"kind\":\"string\"}]}]⟧\n",
 	})
 }
 
@@ -261,7 +261,8 @@ func (s a_client_stub) A(ctx context.Context, a0 int) (r0 int, err error) {
 	// Preallocate a buffer of the right size.
 	size := 0
 	size += 8
-	enc := codegen.NewEncoder()
+	enc := codegen.GetEncoder()
+	defer codegen.PutEncoder(enc)
 	enc.Reset(size)
 
 	// Encode arguments.
@@ -279,7 +280,7 @@ func (s a_client_stub) A(ctx context.Context, a0 int) (r0 int, err error) {
 	}
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewZeroCopyDecoder(results)
 	r0 = dec.Int()
 	err = dec.Error()
 	return
@@ -325,7 +326,8 @@ func (s b_client_stub) B(ctx context.Context, a0 int) (r0 int, err error) {
 	// Preallocate a buffer of the right size.
 	size := 0
 	size += 8
-	enc := codegen.NewEncoder()
+	enc := codegen.GetEncoder()
+	defer codegen.PutEncoder(enc)
 	enc.Reset(size)
 
 	// Encode arguments.
@@ -343,7 +345,7 @@ func (s b_client_stub) B(ctx context.Context, a0 int) (r0 int, err error) {
 	}
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewZeroCopyDecoder(results)
 	r0 = dec.Int()
 	err = dec.Error()
 	return
@@ -389,7 +391,8 @@ func (s c_client_stub) C(ctx context.Context, a0 int) (r0 int, err error) {
 	// Preallocate a buffer of the right size.
 	size := 0
 	size += 8
-	enc := codegen.NewEncoder()
+	enc := codegen.GetEncoder()
+	defer codegen.PutEncoder(enc)
 	enc.Reset(size)
 
 	// Encode arguments.
@@ -407,7 +410,7 @@ func (s c_client_stub) C(ctx context.Context, a0 int) (r0 int, err error) {
 	}
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewZeroCopyDecoder(results)
 	r0 = dec.Int()
 	err = dec.Error()
 	return
@@ -462,7 +465,7 @@ func (s d_client_stub) D(ctx context.Context) (r0 string, err error) {
 	}
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewZeroCopyDecoder(results)
 	r0 = dec.String()
 	err = dec.Error()
 	return
@@ -471,10 +474,10 @@ func (s d_client_stub) D(ctx context.Context) (r0 string, err error) {
 // Note that "mx generate" will always generate the error message below.
 // Everything is okay. The error message is only relevant if you see it when
 // you run "go build" or "go run".
-var _ codegen.LatestVersion = codegen.Version[[0][24]struct{}](`
+var _ codegen.LatestVersion = codegen.Version[[0][25]struct{}](`
 
-ERROR: You generated this file with 'mx generate' v0.24.7-0.20250401231336-b01860e0378a+dirty (codegen
-version v0.24.0). The generated code is incompatible with the version of the
+ERROR: You generated this file with 'mx generate' (devel) (codegen
+version v0.25.0). The generated code is incompatible with the version of the
 github.com/sh3lk/mx module that you're using. The mx module
 version can be found in your go.mod file or by running the following command.
 
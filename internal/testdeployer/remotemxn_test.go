@@ -16,6 +16,7 @@ package testdeployer
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net"
@@ -27,6 +28,7 @@ import (
 	"github.com/google/pprof/profile"
 	"github.com/google/uuid"
 	"github.com/sh3lk/mx/internal/mx"
+	"github.com/sh3lk/mx/internal/net/call"
 	"github.com/sh3lk/mx/internal/reflection"
 	"github.com/sh3lk/mx/runtime"
 	"github.com/sh3lk/mx/runtime/codegen"
@@ -142,6 +144,8 @@ type deployer struct {
 	activateComponent  func(context.Context, *protos.ActivateComponentRequest) (*protos.ActivateComponentReply, error)
 	getListenerAddress func(context.Context, *protos.GetListenerAddressRequest) (*protos.GetListenerAddressReply, error)
 	exportListener     func(context.Context, *protos.ExportListenerRequest) (*protos.ExportListenerReply, error)
+	getSelfToken       func(context.Context, *protos.GetSelfTokenRequest) (*protos.GetSelfTokenReply, error)
+	verifyClientToken  func(context.Context, *protos.VerifyClientTokenRequest) (*protos.VerifyClientTokenReply, error)
 }
 
 // deploy creates a new test deployer.
@@ -260,22 +264,22 @@ func (d *deployer) ActivateComponent(ctx context.Context, req *protos.ActivateCo
 		replicas = append(replicas, mxn.env.MXNAddress())
 	}
 
-	// For simplicity, route locally if there is a single mxn, and route
-	// remotely otherwise. We also report routing info to all mxns, not
-	// just those that called ActivateComponent.
-	routing := &protos.UpdateRoutingInfoRequest{}
-	if len(d.placement) == 1 {
-		routing.RoutingInfo = &protos.RoutingInfo{
-			Component: req.Component,
-			Local:     true,
-		}
-	} else {
-		routing.RoutingInfo = &protos.RoutingInfo{
-			Component: req.Component,
-			Replicas:  replicas,
+	// For simplicity, route locally in the mxn that hosts the only replica of
+	// the component, and route remotely otherwise. We also report routing
+	// info to all mxns, not just those that called ActivateComponent.
+	for name, mxn := range d.mxns {
+		routing := &protos.UpdateRoutingInfoRequest{}
+		if placed := d.placedAt[req.Component]; len(placed) == 1 && placed[0] == name {
+			routing.RoutingInfo = &protos.RoutingInfo{
+				Component: req.Component,
+				Local:     true,
+			}
+		} else {
+			routing.RoutingInfo = &protos.RoutingInfo{
+				Component: req.Component,
+				Replicas:  replicas,
+			}
 		}
-	}
-	for _, mxn := range d.mxns {
 		if _, err := mxn.wlet.UpdateRoutingInfo(ctx, routing); err != nil {
 			return nil, err
 		}
@@ -326,6 +330,28 @@ func (d *deployer) VerifyServerCertificate(context.Context, *protos.VerifyServer
 	return nil, nil
 }
 
+// GetSelfToken implements the EnvelopeHandler interface.
+func (d *deployer) GetSelfToken(ctx context.Context, req *protos.GetSelfTokenRequest) (*protos.GetSelfTokenReply, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.getSelfToken != nil {
+		return d.getSelfToken(ctx, req)
+	}
+	d.t.Fatal("unimplemented")
+	return nil, nil
+}
+
+// VerifyClientToken implements the EnvelopeHandler interface.
+func (d *deployer) VerifyClientToken(ctx context.Context, req *protos.VerifyClientTokenRequest) (*protos.VerifyClientTokenReply, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.verifyClientToken != nil {
+		return d.verifyClientToken(ctx, req)
+	}
+	d.t.Fatal("unimplemented")
+	return nil, nil
+}
+
 // testComponents tests that the components spawned by d are working properly.
 func testComponents(dep *deployer) {
 	dep.t.Helper()
@@ -420,6 +446,116 @@ func TestErrorFreeDistributedExecution(t *testing.T) {
 	testComponents(d)
 }
 
+// deployWithTokens deploys the provided placement with token authentication
+// enabled. Every mxn gets the same token, which is valid for a minute, and
+// the tokens are verified by the provided function. A verified token names
+// the provided callers as the components hosted by the client.
+func deployWithTokens(t *testing.T, placement map[string][]string, callers []string, verify func(token string) error) *deployer {
+	d := deployWithInfo(t, context.Background(), placement, &protos.MXNArgs{
+		App:             "remotemxn_test.go",
+		DeploymentId:    fmt.Sprint(os.Getpid()),
+		InternalAddress: "localhost:0",
+		TokenAuth:       true,
+	})
+	expires := time.Now().Add(time.Minute).UnixMicro()
+	d.getSelfToken = func(context.Context, *protos.GetSelfTokenRequest) (*protos.GetSelfTokenReply, error) {
+		return &protos.GetSelfTokenReply{Token: "token", ExpiresMicros: expires}, nil
+	}
+	d.verifyClientToken = func(_ context.Context, req *protos.VerifyClientTokenRequest) (*protos.VerifyClientTokenReply, error) {
+		if err := verify(req.Token); err != nil {
+			return nil, err
+		}
+		components := []string{componenta, componentb, componentc, componentd}
+		return &protos.VerifyClientTokenReply{Components: components, Callers: callers, ExpiresMicros: expires}, nil
+	}
+	return d
+}
+
+func TestTokenAuth(t *testing.T) {
+	// a is hosted by a single mxn, so that the calls to it, made on behalf of
+	// no component, are local. Remote clients may not make such calls.
+	placement := map[string][]string{
+		"1": {componenta, componentb},
+		"2": {componentb, componentc},
+		"3": {componentc},
+	}
+	verified := 0
+	d := deployWithTokens(t, placement, []string{componenta, componentb, componentc, componentd}, func(token string) error {
+		verified++
+		if token != "token" {
+			return fmt.Errorf("bad token %q", token)
+		}
+		return nil
+	})
+	defer d.shutdown()
+	testComponents(d)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if verified == 0 {
+		t.Error("no tokens were verified")
+	}
+}
+
+func TestTokenAuthRejected(t *testing.T) {
+	placement := map[string][]string{
+		"1": {componenta, componentd},
+		"2": {componentb, componentc},
+	}
+	d := deployWithTokens(t, placement, []string{componenta, componentb, componentc, componentd}, func(string) error {
+		return fmt.Errorf("rejected")
+	})
+	defer d.shutdown()
+
+	x, err := d.mxns["1"].wlet.GetIntf(reflection.Type[a]())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := x.(a).A(d.ctx, 42); !errors.Is(err, call.PermissionDenied) {
+		t.Fatalf("A: got %v, want %v", err, call.PermissionDenied)
+	}
+}
+
+func TestSpoofedCaller(t *testing.T) {
+	placement := map[string][]string{
+		"1": {componenta, componentd},
+		"2": {componentb, componentc},
+	}
+	ok := func(string) error { return nil }
+
+	t.Run("NotHosted", func(t *testing.T) {
+		// The token of the client is valid, but the client is only verified to
+		// host d. Its calls on behalf of a, which has an mx.Ref to b, are
+		// rejected.
+		d := deployWithTokens(t, placement, []string{componentd}, ok)
+		defer d.shutdown()
+
+		x, err := d.mxns["1"].wlet.GetIntf(reflection.Type[a]())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := x.(a).A(d.ctx, 42); !errors.Is(err, call.PermissionDenied) {
+			t.Fatalf("A: got %v, want %v", err, call.PermissionDenied)
+		}
+	})
+
+	t.Run("Root", func(t *testing.T) {
+		// The client is verified to host every component, but calls from
+		// components obtained with GetIntf, rather than with an mx.Ref, are
+		// not accepted from remote clients.
+		d := deployWithTokens(t, placement, []string{componenta, componentb, componentc, componentd}, ok)
+		defer d.shutdown()
+
+		x, err := d.mxns["1"].wlet.GetIntf(reflection.Type[b]())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := x.(b).B(d.ctx, 42); !errors.Is(err, call.PermissionDenied) {
+			t.Fatalf("B: got %v, want %v", err, call.PermissionDenied)
+		}
+	})
+}
+
 func TestFailActivateComponent(t *testing.T) {
 	d := deploy(t, context.Background(), colocated)
 	defer d.shutdown()
@@ -19,12 +19,14 @@ import (
 	"crypto"
 	"crypto/rand"
 	"crypto/rsa"
+	"crypto/tls"
 	"crypto/x509"
 	"crypto/x509/pkix"
 	"encoding/pem"
 	"errors"
 	"fmt"
 	"math/big"
+	"os"
 	"time"
 )
 
@@ -60,27 +62,86 @@ func GenerateSignedCert(ca *x509.Certificate, caKey crypto.PrivateKey, names ...
 	return cert, certKey, nil
 }
 
+// GenerateCertRequest generates a private key and a certificate signing
+// request for it. The request can be signed by SignCertRequest, so that the
+// private key never leaves the process that generated it.
+func GenerateCertRequest() ([]byte, crypto.PrivateKey, error) {
+	priv, err := rsa.GenerateKey(rand.Reader, 4096)
+	if err != nil {
+		return nil, nil, err
+	}
+	template := x509.CertificateRequest{
+		Subject: pkix.Name{Organization: []string{"ACME Co."}},
+	}
+	csrDER, err := x509.CreateCertificateRequest(rand.Reader, &template, priv)
+	if err != nil {
+		return nil, nil, err
+	}
+	return csrDER, priv, nil
+}
+
+// SignCertRequest generates a certificate for the given DNS names and the
+// public key of the given certificate signing request, signed by the given
+// Certificate Authority. The returned certificate is valid for the given
+// duration. The names in the request, if any, are ignored.
+func SignCertRequest(ca *x509.Certificate, caKey crypto.PrivateKey, csrDER []byte, validity time.Duration, names ...string) (*x509.Certificate, error) {
+	csr, err := x509.ParseCertificateRequest(csrDER)
+	if err != nil {
+		return nil, fmt.Errorf("bad certificate request: %w", err)
+	}
+	if err := csr.CheckSignature(); err != nil {
+		return nil, fmt.Errorf("bad certificate request signature: %w", err)
+	}
+	template, err := certTemplate(false /*isCA*/, validity, names...)
+	if err != nil {
+		return nil, err
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, ca, csr.PublicKey, caKey)
+	if err != nil {
+		return nil, err
+	}
+	return x509.ParseCertificate(certDER)
+}
+
 func generateLeafCert(isCA bool, names ...string) (*x509.Certificate, crypto.PrivateKey, error) {
 	priv, err := rsa.GenerateKey(rand.Reader, 4096)
 	if err != nil {
 		return nil, nil, err
 	}
+	template, err := certTemplate(isCA, 365*24*time.Hour, names...)
+	if err != nil {
+		return nil, nil, err
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, &priv.PublicKey, priv)
+	if err != nil {
+		return nil, nil, err
+	}
+	cert, err := x509.ParseCertificate(certDER)
+	if err != nil {
+		return nil, nil, err
+	}
+	return cert, priv, nil
+}
 
+// certTemplate returns the template of a certificate for the given DNS names
+// that is valid for the given duration.
+func certTemplate(isCA bool, validity time.Duration, names ...string) (*x509.Certificate, error) {
 	serialNumberLimit := new(big.Int).Lsh(big.NewInt(1), 128)
 	serialNumber, err := rand.Int(rand.Reader, serialNumberLimit)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
 	keyUsage := x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment
 	if isCA {
 		keyUsage |= x509.KeyUsageCertSign
 	}
-	template := x509.Certificate{
+	now := time.Now()
+	return &x509.Certificate{
 		SerialNumber: serialNumber,
 		Subject:      pkix.Name{Organization: []string{"ACME Co."}},
-		NotBefore:    time.Now(),
-		NotAfter:     time.Now().Add(365 * 24 * time.Hour),
+		NotBefore:    now,
+		NotAfter:     now.Add(validity),
 		KeyUsage:     keyUsage,
 		ExtKeyUsage: []x509.ExtKeyUsage{
 			x509.ExtKeyUsageClientAuth,
@@ -89,16 +150,7 @@ func generateLeafCert(isCA bool, names ...string) (*x509.Certificate, crypto.Pri
 		BasicConstraintsValid: true,
 		IsCA:                  isCA,
 		DNSNames:              names,
-	}
-	certDER, err := x509.CreateCertificate(rand.Reader, &template, &template, &priv.PublicKey, priv)
-	if err != nil {
-		return nil, nil, err
-	}
-	cert, err := x509.ParseCertificate(certDER)
-	if err != nil {
-		return nil, nil, err
-	}
-	return cert, priv, nil
+	}, nil
 }
 
 // PEMEncode returns the PEM-encoded blocks for the given certificate and
@@ -125,6 +177,40 @@ func PEMEncode(cert *x509.Certificate, key crypto.PrivateKey) ([]byte, []byte, e
 	return certOut.Bytes(), keyOut.Bytes(), nil
 }
 
+// LoadCACert loads a Certificate Authority certificate and its private key
+// from the given PEM-encoded files, like the ones written by PEMEncode.
+func LoadCACert(certFile, keyFile string) (*x509.Certificate, crypto.PrivateKey, error) {
+	certPEM, err := os.ReadFile(certFile)
+	if err != nil {
+		return nil, nil, err
+	}
+	keyPEM, err := os.ReadFile(keyFile)
+	if err != nil {
+		return nil, nil, err
+	}
+	cert, err := ParseCert(certPEM)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", certFile, err)
+	}
+	if !cert.IsCA {
+		return nil, nil, fmt.Errorf("%s: not a CA certificate", certFile)
+	}
+	tlsCert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", keyFile, err)
+	}
+	return cert, tlsCert.PrivateKey, nil
+}
+
+// ParseCert parses the given PEM-encoded certificate.
+func ParseCert(certPEM []byte) (*x509.Certificate, error) {
+	block, _ := pem.Decode(certPEM)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, errors.New("no PEM-encoded certificate")
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
+
 // VerifySignedCert verifies the given signed certificate using the given
 // root CA, returning the DNS names stored in the leaf certificate.
 func VerifySignedCert(certDER []byte, ca *x509.Certificate) ([]string, error) {
@@ -15,7 +15,10 @@
 package certs_test
 
 import (
+	"crypto/tls"
 	"crypto/x509"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -74,3 +77,91 @@ func TestVerifySignedCert(t *testing.T) {
 		t.Errorf("unexpected certificate names. (-want +got): %s", diff)
 	}
 }
+
+func TestSignCertRequest(t *testing.T) {
+	caCert, caKey, err := certs.GenerateCACert()
+	if err != nil {
+		t.Fatal(err)
+	}
+	csr, key, err := certs.GenerateCertRequest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := certs.SignCertRequest(caCert, caKey, csr, time.Hour, "name")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := cert.NotAfter.Sub(cert.NotBefore), time.Hour; got != want {
+		t.Errorf("certificate validity: got %v, want %v", got, want)
+	}
+
+	// The certificate is signed by the CA, and matches the private key.
+	names, err := certs.VerifySignedCert(cert.Raw, caCert)
+	if err != nil {
+		t.Fatalf("cannot verify certificate: %v", err)
+	}
+	if diff := cmp.Diff([]string{"name"}, names); diff != "" {
+		t.Errorf("unexpected certificate names. (-want +got): %s", diff)
+	}
+	certPEM, keyPEM, err := certs.PEMEncode(cert, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tls.X509KeyPair(certPEM, keyPEM); err != nil {
+		t.Errorf("certificate doesn't match the private key: %v", err)
+	}
+
+	// Corrupted requests are rejected.
+	csr[len(csr)-1] ^= 0xff
+	if _, err := certs.SignCertRequest(caCert, caKey, csr, time.Hour, "name"); err == nil {
+		t.Error("unexpected success signing a corrupted request")
+	}
+}
+
+func TestLoadCACert(t *testing.T) {
+	caCert, caKey, err := certs.GenerateCACert()
+	if err != nil {
+		t.Fatal(err)
+	}
+	certPEM, keyPEM, err := certs.PEMEncode(caCert, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "ca.pem")
+	keyFile := filepath.Join(dir, "ca-key.pem")
+	if err := os.WriteFile(certFile, certPEM, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(keyFile, keyPEM, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	// Certificates signed by the loaded CA are verified by the original CA.
+	loadedCert, loadedKey, err := certs.LoadCACert(certFile, keyFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, _, err := certs.GenerateSignedCert(loadedCert, loadedKey, "name")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := certs.VerifySignedCert(cert.Raw, caCert); err != nil {
+		t.Errorf("cannot verify certificate: %v", err)
+	}
+
+	// Leaf certificates are not CA certificates.
+	leafPEM, leafKeyPEM, err := certs.PEMEncode(cert, loadedKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(certFile, leafPEM, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(keyFile, leafKeyPEM, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := certs.LoadCACert(certFile, keyFile); err == nil {
+		t.Error("unexpected success loading a leaf certificate")
+	}
+}
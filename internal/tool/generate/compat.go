@@ -0,0 +1,239 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generate
+
+import (
+	"fmt"
+	"go/types"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/sh3lk/mx/runtime/codegen"
+)
+
+// This file computes the wire schemas of components, which "mx generate"
+// embeds in the generated code, and checks that the schemas of two versions of
+// a component are compatible. The encoding produced by codegen.Encoder holds
+// no field names or type information---a struct, for example, is encoded as
+// the concatenation of its fields---so a value encoded by one version of a
+// component is decoded correctly by another version only if the types match
+// exactly, field by field.
+
+// schema returns the wire schema of the provided component.
+func (g *generator) schema(comp *component) codegen.ComponentSchema {
+	schema := codegen.ComponentSchema{Component: comp.fullIntfName()}
+	for _, m := range comp.methods() {
+		sig := m.Type().(*types.Signature)
+		if isStreaming(sig) {
+			continue
+		}
+		method := codegen.MethodSchema{Name: m.Name()}
+		for i := 1; i < sig.Params().Len(); i++ {
+			method.Args = append(method.Args, g.wireType(sig.Params().At(i).Type(), nil))
+		}
+		for i := 0; i < sig.Results().Len()-1; i++ {
+			method.Results = append(method.Results, g.wireType(sig.Results().At(i).Type(), nil))
+		}
+		schema.Methods = append(schema.Methods, method)
+	}
+	return schema
+}
+
+// wireType returns the description of how values of type t are encoded. It
+// mirrors the encoding implemented by the encode method. stack holds the named
+// struct types being described, to cut recursive types short.
+func (g *generator) wireType(t types.Type, stack []*types.Named) *codegen.WireType {
+	switch x := t.(type) {
+	case *types.Basic:
+		kind := x.Kind()
+		switch kind {
+		case types.Int:
+			kind = types.Int64
+		case types.Uint:
+			kind = types.Uint64
+		}
+		return &codegen.WireType{Kind: types.Typ[kind].Name()}
+
+	case *types.Pointer:
+		if g.tset.isProto(x) {
+			return &codegen.WireType{Kind: "proto", Name: g.wireTypeName(x.Elem())}
+		}
+		if g.tset.hasMarshalBinary(x) {
+			return &codegen.WireType{Kind: "binary", Name: g.wireTypeName(x.Elem())}
+		}
+		return &codegen.WireType{Kind: "pointer", Elem: g.wireType(x.Elem(), stack)}
+
+	case *types.Array:
+		return &codegen.WireType{Kind: "array", Len: int(x.Len()), Elem: g.wireType(x.Elem(), stack)}
+
+	case *types.Slice:
+		return &codegen.WireType{Kind: "slice", Elem: g.wireType(x.Elem(), stack)}
+
+	case *types.Map:
+		return &codegen.WireType{Kind: "map", Key: g.wireType(x.Key(), stack), Elem: g.wireType(x.Elem(), stack)}
+
+	case *types.Struct:
+		w := &codegen.WireType{Kind: "struct"}
+		for i := 0; i < x.NumFields(); i++ {
+			f := x.Field(i)
+			if isMXAutoMarshal(f.Type()) {
+				continue
+			}
+			w.Fields = append(w.Fields, codegen.WireField{Name: f.Name(), Type: g.wireType(f.Type(), stack)})
+		}
+		return w
+
+	case *types.Named:
+		name := g.wireTypeName(x)
+		switch {
+		case isError(x):
+			return &codegen.WireType{Kind: "error"}
+		case g.tset.isProto(x):
+			return &codegen.WireType{Kind: "proto", Name: name}
+		case g.tset.automarshals.At(x) != nil:
+			// Types whose AutoMarshal methods are generated are encoded field
+			// by field, like structs.
+		case g.tset.implementsAutoMarshal(x):
+			return &codegen.WireType{Kind: "automarshal", Name: name}
+		case g.tset.hasMarshalBinary(x):
+			return &codegen.WireType{Kind: "binary", Name: name}
+		}
+		for _, n := range stack {
+			if types.Identical(n, x) {
+				return &codegen.WireType{Kind: "struct", Name: name}
+			}
+		}
+		w := g.wireType(x.Underlying(), append(stack, x))
+		w.Name = name
+		return w
+
+	case *types.Alias:
+		return g.wireType(types.Unalias(x), stack)
+
+	default:
+		panic(fmt.Sprintf("wireType: unexpected type %v (type %T)", t, t))
+	}
+}
+
+// wireTypeName returns the name of t used in wire schemas, e.g., foo.Point.
+func (g *generator) wireTypeName(t types.Type) string {
+	return types.TypeString(t, func(pkg *types.Package) string { return pkg.Name() })
+}
+
+// checkCompat returns the changes that make the new schemas of the components
+// incompatible with their old schemas. Old components whose package is not in
+// pkgs are ignored, since their new schemas are unknown.
+func checkCompat(old, new []codegen.ComponentSchema, pkgs map[string]bool) []string {
+	byName := map[string]codegen.ComponentSchema{}
+	for _, schema := range new {
+		byName[schema.Component] = schema
+	}
+	var problems []string
+	for _, o := range old {
+		n, ok := byName[o.Component]
+		if !ok {
+			if pkgs[path.Dir(o.Component)] {
+				problems = append(problems, fmt.Sprintf("%s: component removed", o.Component))
+			}
+			continue
+		}
+		methods := map[string]codegen.MethodSchema{}
+		for _, m := range n.Methods {
+			methods[m.Name] = m
+		}
+		for _, om := range o.Methods {
+			report := func(format string, args ...any) {
+				problems = append(problems, fmt.Sprintf("%s.%s: %s", o.Component, om.Name, fmt.Sprintf(format, args...)))
+			}
+			nm, ok := methods[om.Name]
+			if !ok {
+				report("method removed")
+				continue
+			}
+			compareList("argument", om.Args, nm.Args, report)
+			compareList("result", om.Results, nm.Results, report)
+		}
+	}
+	return problems
+}
+
+// compareList reports the incompatible changes between the old and new types
+// of the arguments or results of a method.
+func compareList(what string, old, new []*codegen.WireType, report func(string, ...any)) {
+	if len(old) != len(new) {
+		report("number of %ss changed from %d to %d", what, len(old), len(new))
+		return
+	}
+	for i := range old {
+		compareTypes(fmt.Sprintf("%s %d", what, i), old[i], new[i], report)
+	}
+}
+
+// compareTypes reports the incompatible changes between the old and new types
+// of the value at the provided path.
+func compareTypes(path string, old, new *codegen.WireType, report func(string, ...any)) {
+	changed := func() {
+		report("%s: type changed from %v to %v", path, old, new)
+	}
+	if old.Kind != new.Kind {
+		changed()
+		return
+	}
+	switch old.Kind {
+	case "pointer", "slice":
+		compareTypes(path, old.Elem, new.Elem, report)
+	case "array":
+		if old.Len != new.Len {
+			changed()
+			return
+		}
+		compareTypes(path, old.Elem, new.Elem, report)
+	case "map":
+		compareTypes(path+" (map key)", old.Key, new.Key, report)
+		compareTypes(path, old.Elem, new.Elem, report)
+	case "struct":
+		if len(old.Fields) != len(new.Fields) {
+			report("%s: %v has %d fields, was %d (fields are encoded in order)", path, new, len(new.Fields), len(old.Fields))
+			return
+		}
+		// A field renamed in place is decoded correctly, but reordered fields
+		// are silently decoded into each other if their types match.
+		var oldNames, newNames []string
+		for i := range old.Fields {
+			oldNames = append(oldNames, old.Fields[i].Name)
+			newNames = append(newNames, new.Fields[i].Name)
+		}
+		if !slices.Equal(oldNames, newNames) && isPermutation(oldNames, newNames) {
+			report("%s: fields of %v reordered from (%s) to (%s) (fields are encoded in order)", path, new, strings.Join(oldNames, ", "), strings.Join(newNames, ", "))
+			return
+		}
+		for i := range old.Fields {
+			compareTypes(path+"."+new.Fields[i].Name, old.Fields[i].Type, new.Fields[i].Type, report)
+		}
+	case "proto", "binary", "automarshal":
+		if old.Name != new.Name {
+			changed()
+		}
+	}
+}
+
+// isPermutation returns whether a and b hold the same strings.
+func isPermutation(a, b []string) bool {
+	a, b = slices.Clone(a), slices.Clone(b)
+	slices.Sort(a)
+	slices.Sort(b)
+	return slices.Equal(a, b)
+}
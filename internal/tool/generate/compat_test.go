@@ -0,0 +1,110 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generate
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sh3lk/mx/runtime/codegen"
+)
+
+func TestWireType(t *testing.T) {
+	for _, test := range []struct {
+		label    string
+		contents string
+		want     string
+	}{
+		{"int", "type target = int", "int64"},
+		{"named", "type target uint", "foo.target"},
+		{"map", "type target = map[string][]*int32", "map[string][]*int32"},
+		{"array", "type target = [4]byte", "[4]uint8"},
+		{"struct", "type target = struct{ A int; B error }", "struct{A int64; B error}"},
+		{"time", `
+import "time"
+type target = time.Time
+`, "time.Time"},
+	} {
+		t.Run(test.label, func(t *testing.T) {
+			tset, target := compile(t, test.contents)
+			g := &generator{tset: tset}
+			if got := g.wireType(target, nil).String(); got != test.want {
+				t.Errorf("wireType: got %s, want %s", got, test.want)
+			}
+		})
+	}
+}
+
+func TestCheckCompat(t *testing.T) {
+	basic := func(kind string) *codegen.WireType { return &codegen.WireType{Kind: kind} }
+	point := func(fields ...codegen.WireField) *codegen.WireType {
+		return &codegen.WireType{Kind: "struct", Name: "foo.Point", Fields: fields}
+	}
+	x := codegen.WireField{Name: "X", Type: basic("int64")}
+	y := codegen.WireField{Name: "Y", Type: basic("int64")}
+	label := codegen.WireField{Name: "Label", Type: basic("string")}
+	schema := func(methods ...codegen.MethodSchema) []codegen.ComponentSchema {
+		return []codegen.ComponentSchema{{Component: "foo/Store", Methods: methods}}
+	}
+	get := func(args []*codegen.WireType, results ...*codegen.WireType) codegen.MethodSchema {
+		return codegen.MethodSchema{Name: "Get", Args: args, Results: results}
+	}
+	keys := []*codegen.WireType{basic("string")}
+	old := schema(get(keys, point(x, y, label)))
+
+	for _, test := range []struct {
+		name string
+		new  []codegen.ComponentSchema
+		want []string
+	}{
+		{"Same", schema(get(keys, point(x, y, label))), nil},
+		{"NewMethod", schema(get(keys, point(x, y, label)), codegen.MethodSchema{Name: "Put"}), nil},
+		{"RenamedField", schema(get(keys, point(x, y, codegen.WireField{Name: "Name", Type: basic("string")}))), nil},
+		{"RemovedComponent", nil, []string{"foo/Store: component removed"}},
+		{"RemovedMethod", schema(), []string{"foo/Store.Get: method removed"}},
+		{"NewArgument", schema(get([]*codegen.WireType{basic("string"), basic("bool")}, point(x, y, label))), []string{
+			"foo/Store.Get: number of arguments changed from 1 to 2",
+		}},
+		{"ChangedArgument", schema(get([]*codegen.WireType{basic("int64")}, point(x, y, label))), []string{
+			"foo/Store.Get: argument 0: type changed from string to int64",
+		}},
+		{"ReorderedFields", schema(get(keys, point(y, x, label))), []string{
+			"foo/Store.Get: result 0: fields of foo.Point reordered from (X, Y, Label) to (Y, X, Label) (fields are encoded in order)",
+		}},
+		{"NewField", schema(get(keys, point(x, y, label, label))), []string{
+			"foo/Store.Get: result 0: foo.Point has 4 fields, was 3 (fields are encoded in order)",
+		}},
+		{"ChangedField", schema(get(keys, point(x, y, codegen.WireField{Name: "Label", Type: basic("bool")}))), []string{
+			"foo/Store.Get: result 0.Label: type changed from string to bool",
+		}},
+		{"Slice", schema(get(keys, &codegen.WireType{Kind: "slice", Elem: point(x, y, label)})), []string{
+			"foo/Store.Get: result 0: type changed from foo.Point to []foo.Point",
+		}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			got := checkCompat(old, test.new, map[string]bool{"foo": true})
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("checkCompat (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCheckCompatOtherPackages(t *testing.T) {
+	old := []codegen.ComponentSchema{{Component: "bar/Cache", Methods: []codegen.MethodSchema{{Name: "Get"}}}}
+	if got := checkCompat(old, nil, map[string]bool{"foo": true}); len(got) != 0 {
+		t.Errorf("checkCompat: got %q, want nothing", got)
+	}
+}
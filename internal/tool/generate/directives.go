@@ -0,0 +1,297 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generate
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+	"time"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// This file parses the //mx: directives on the methods of component
+// interfaces. A directive is a line comment in the doc comment of a method:
+//
+//	type Cache interface {
+//	    // Get returns the value of the provided key.
+//	    //mx:timeout=2s
+//	    //mx:hedge
+//	    Get(context.Context, string) (string, error)
+//	}
+//
+// The supported directives are:
+//
+//   - //mx:timeout=d: calls fail with context.DeadlineExceeded after d.
+//   - //mx:cache=d: callers reuse the successful results of a call for d.
+//   - //mx:idempotent: the method can safely execute more than once. This is
+//     a marker checked by the generator, which rejects methods that are both
+//     idempotent and mx.NotRetriable; it has no effect at runtime.
+//   - //mx:hedge: calls may be hedged, like with mx.Hedged.
+//   - //mx:deprecated: callers log a warning when they call the method.
+//
+// Directives are only recognized on the methods declared in a component
+// interface, not on the methods of embedded interfaces.
+
+// methodDirectives holds the //mx: directives on a component method.
+type methodDirectives struct {
+	timeout    time.Duration
+	cache      time.Duration
+	idempotent bool
+	deprecated bool
+	pos        map[string]token.Pos // the position of every directive, by name
+}
+
+// findMethodDirectives parses the //mx: directives on the methods of the
+// component interfaces declared in the provided file.
+func findMethodDirectives(pkg *packages.Package, f *ast.File, components map[string]*component) error {
+	var errs []error
+	for _, decl := range f.Decls {
+		gendecl, ok := decl.(*ast.GenDecl)
+		if !ok || gendecl.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gendecl.Specs {
+			ts, ok := spec.(*ast.TypeSpec)
+			if !ok {
+				continue
+			}
+			intf, ok := ts.Type.(*ast.InterfaceType)
+			if !ok {
+				continue
+			}
+			var comp *component
+			if def, ok := pkg.TypesInfo.Defs[ts.Name]; ok {
+				if named, ok := def.Type().(*types.Named); ok {
+					comp = components[fullName(named)]
+				}
+			}
+			for _, field := range intf.Methods.List {
+				for _, c := range directiveComments(field.Doc) {
+					switch {
+					case comp == nil:
+						errs = append(errs, errorf(pkg.Fset, c.Pos(), "%s: directives are only allowed on the methods of a component interface implemented by this package", c.Text))
+					case len(field.Names) == 0:
+						errs = append(errs, errorf(pkg.Fset, c.Pos(), "%s: directives are not allowed on embedded interfaces", c.Text))
+					default:
+						if err := comp.addDirective(pkg.Fset, field.Names[0].Name, c); err != nil {
+							errs = append(errs, err)
+						}
+					}
+				}
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// directiveComments returns the //mx: comments in the provided doc comment.
+func directiveComments(doc *ast.CommentGroup) []*ast.Comment {
+	if doc == nil {
+		return nil
+	}
+	var comments []*ast.Comment
+	for _, c := range doc.List {
+		if strings.HasPrefix(c.Text, "//mx:") {
+			comments = append(comments, c)
+		}
+	}
+	return comments
+}
+
+// addDirective parses the provided //mx: directive on the named method of c.
+func (c *component) addDirective(fset *token.FileSet, method string, comment *ast.Comment) error {
+	text := strings.TrimSpace(comment.Text)
+	name, value, hasValue := strings.Cut(strings.TrimPrefix(text, "//mx:"), "=")
+	report := func(format string, args ...any) error {
+		return errorf(fset, comment.Pos(), "method %s.%s: %s", c.intfName(), method, fmt.Sprintf(format, args...))
+	}
+
+	if c.directives == nil {
+		c.directives = map[string]*methodDirectives{}
+	}
+	d, ok := c.directives[method]
+	if !ok {
+		d = &methodDirectives{pos: map[string]token.Pos{}}
+		c.directives[method] = d
+	}
+	if _, ok := d.pos[name]; ok {
+		return report("duplicate //mx:%s directive", name)
+	}
+
+	switch name {
+	case "timeout", "cache":
+		if !hasValue {
+			return report("//mx:%s needs a duration, e.g., //mx:%s=2s", name, name)
+		}
+		duration, err := time.ParseDuration(value)
+		if err != nil || duration <= 0 {
+			return report("invalid duration %q in //mx:%s; want a positive duration, e.g., 2s", value, name)
+		}
+		if name == "timeout" {
+			d.timeout = duration
+		} else {
+			d.cache = duration
+		}
+	case "idempotent", "hedge", "deprecated":
+		if hasValue {
+			return report("//mx:%s takes no value", name)
+		}
+		switch name {
+		case "idempotent":
+			d.idempotent = true
+		case "hedge":
+			if c.hedged == nil {
+				c.hedged = map[string]token.Pos{}
+			}
+			c.hedged[method] = comment.Pos()
+		case "deprecated":
+			d.deprecated = true
+		}
+	default:
+		return report("unknown directive //mx:%s; want one of timeout, cache, idempotent, hedge, or deprecated", name)
+	}
+	d.pos[name] = comment.Pos()
+	return nil
+}
+
+// checkMethodDirectives checks that the //mx: directives of the provided
+// components apply to their methods. The //mx:hedge directive is checked by
+// checkHedgedMethods.
+func checkMethodDirectives(fset *token.FileSet, components []*component) error {
+	var errs []error
+	for _, comp := range components {
+		for _, m := range comp.methods() {
+			d, ok := comp.directives[m.Name()]
+			if !ok {
+				continue
+			}
+			report := func(directive, format string, args ...any) {
+				errs = append(errs, errorf(fset, d.pos[directive], "method %s.%s: %s", comp.intfName(), m.Name(), fmt.Sprintf(format, args...)))
+			}
+			sig := m.Type().(*types.Signature)
+			if _, ok := comp.noretry[m.Name()]; ok && d.idempotent {
+				report("idempotent", "method is both idempotent and not retriable")
+			}
+			if isStreaming(sig) {
+				for _, directive := range []string{"timeout", "cache"} {
+					if _, ok := d.pos[directive]; ok {
+						report(directive, "//mx:%s cannot be applied to a streaming method", directive)
+					}
+				}
+				continue
+			}
+			if d.cache > 0 {
+				if sig.Results().Len() == 1 {
+					report("cache", "//mx:cache cannot be applied to a method that only returns an error")
+				}
+				for i := 1; i < sig.Params().Len(); i++ {
+					if t := sig.Params().At(i).Type(); !types.Comparable(t) {
+						report("cache", "//mx:cache requires comparable arguments, but argument %d has type %s", i, t)
+					}
+				}
+				for i := 0; i < sig.Results().Len()-1; i++ {
+					if t := sig.Results().At(i).Type(); hasReferences(t) {
+						report("cache", "//mx:cache requires results without pointers, slices, maps, or interfaces, since callers share cached results, but result %d has type %s", i, t)
+					}
+				}
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// hasReferences returns whether values of type t refer to memory that is
+// shared when the values are copied, i.e., whether t contains pointers,
+// slices, maps, or interfaces.
+func hasReferences(t types.Type) bool {
+	if n, ok := types.Unalias(t).(*types.Named); ok {
+		if obj := n.Obj(); obj.Pkg() != nil && obj.Pkg().Path() == "time" && obj.Name() == "Time" {
+			// A time.Time refers to an immutable *time.Location.
+			return false
+		}
+	}
+	switch x := t.Underlying().(type) {
+	case *types.Basic:
+		return false
+	case *types.Array:
+		return hasReferences(x.Elem())
+	case *types.Struct:
+		for i := 0; i < x.NumFields(); i++ {
+			if hasReferences(x.Field(i).Type()) {
+				return true
+			}
+		}
+		return false
+	default:
+		return true
+	}
+}
+
+// directivesString generates a string of the form "{...}, {...}, ..., {...}"
+// where the individual elements are the codegen.MethodDirectives of the
+// methods of comp. It returns the empty string if no method of comp has
+// directives, besides //mx:hedge.
+func (g *generator) directivesString(comp *component) string {
+	var elems []string
+	found := false
+	for _, m := range comp.methods() {
+		var fields []string
+		if d, ok := comp.directives[m.Name()]; ok {
+			if d.timeout > 0 {
+				fields = append(fields, "Timeout: "+g.durationString(d.timeout))
+			}
+			if d.cache > 0 {
+				fields = append(fields, "Cache: "+g.durationString(d.cache))
+			}
+			if d.idempotent {
+				fields = append(fields, "Idempotent: true")
+			}
+			if d.deprecated {
+				fields = append(fields, "Deprecated: true")
+			}
+		}
+		found = found || len(fields) > 0
+		elems = append(elems, "{"+strings.Join(fields, ", ")+"}")
+	}
+	if !found {
+		return ""
+	}
+	return strings.Join(elems, ", ")
+}
+
+// durationString returns a Go expression for d, e.g., "2 * time.Second".
+func (g *generator) durationString(d time.Duration) string {
+	timePkg := g.tset.importPackage("time", "time")
+	for _, unit := range []struct {
+		name string
+		d    time.Duration
+	}{
+		{"Hour", time.Hour},
+		{"Minute", time.Minute},
+		{"Second", time.Second},
+		{"Millisecond", time.Millisecond},
+		{"Microsecond", time.Microsecond},
+	} {
+		if d%unit.d == 0 {
+			return fmt.Sprintf("%d * %s", d/unit.d, timePkg.qualify(unit.name))
+		}
+	}
+	return fmt.Sprintf("%d * %s", d, timePkg.qualify("Nanosecond"))
+}
@@ -33,7 +33,7 @@ func init() {
 		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
 			return a_reflect_stub{caller: caller}
 		},
-		RefData: "⟦627f661b:MxEdge:github.com/sh3lk/mx/internal/tool/generate/example/A→github.com/sh3lk/mx/internal/tool/generate/example/B⟧\n⟦26168bd7:wEaVeRlIsTeNeRs:github.com/sh3lk/mx/internal/tool/generate/example/A→lis2,renamed_listener⟧\n",
+		RefData: "⟦ce413b45:MxEdge:github.com/sh3lk/mx/internal/tool/generate/example/A→github.com/sh3lk/mx/internal/tool/generate/example/B⟧\n⟦b90b0a76:wEaVeRlIsTeNeRs:github.com/sh3lk/mx/internal/tool/generate/example/A→lis2,renamed_listener⟧\n⟦94583cea:MxSchema:github.com/sh3lk/mx/internal/tool/generate/example/A→[{\"name\":\"M1\",\"args\":[{\"kind\":\"int64\"},{\"kind\":\"string\"},{\"kind\":\"bool\"},{\"kind\":\"array\",\"len\":10,\"elem\":{\"kind\":\"int64\"}},{\"kind\":\"slice\",\"elem\":{\"kind\":\"string\"}},{\"kind\":\"map\",\"key\":{\"kind\":\"bool\"},\"elem\":{\"kind\":\"int64\"}},{\"kind\":\"struct\",\"name\":\"main.message\",\"fields\":[{\"name\":\"a\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"b\",\"type\":{\"kind\":\"string\"}},{\"name\":\"c\",\"type\":{\"kind\":\"bool\"}},{\"name\":\"d\",\"type\":{\"kind\":\"array\",\"len\":10,\"elem\":{\"kind\":\"int64\"}}},{\"name\":\"e\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"string\"}}},{\"name\":\"f\",\"type\":{\"kind\":\"map\",\"key\":{\"kind\":\"bool\"},\"elem\":{\"kind\":\"int64\"}}}]}],\"results\":[{\"kind\":\"struct\",\"name\":\"main.pair\",\"fields\":[{\"name\":\"a\",\"type\":{\"kind\":\"struct\",\"name\":\"main.message\",\"fields\":[{\"name\":\"a\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"b\",\"type\":{\"kind\":\"string\"}},{\"name\":\"c\",\"type\":{\"kind\":\"bool\"}},{\"name\":\"d\",\"type\":{\"kind\":\"array\",\"len\":10,\"elem\":{\"kind\":\"int64\"}}},{\"name\":\"e\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"string\"}}},{\"name\":\"f\",\"type\":{\"kind\":\"map\",\"key\":{\"kind\":\"bool\"},\"elem\":{\"kind\":\"int64\"}}}]}},{\"name\":\"b\",\"type\":{\"kind\":\"struct\",\"name\":\"main.message\",\"fields\":[{\"name\":\"a\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"b\",\"type\":{\"kind\":\"string\"}},{\"name\":\"c\",\"type\":{\"kind\":\"bool\"}},{\"name\":\"d\",\"type\":{\"kind\":\"array\",\"len\":10,\"elem\":{\"kind\":\"int64\"}}},{\"name\":\"e\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"string\"}}},{\"name\":\"f\",\"type\":{\"kind\":\"map\",\"key\":{\"kind\":\"bool\"},\"elem\":{\"kind\":\"int64\"}}}]}}]}]},{\"name\":\"M2\",\"args\":[{\"kind\":\"int64\"},{\"kind\":\"string\"},{\"kind\":\"bool\"},{\"kind\":\"array\",\"len\":10,\"elem\":{\"kind\":\"int64\"}},{\"kind\":\"slice\",\"elem\":{\"kind\":\"string\"}},{\"kind\":\"map\",\"key\":{\"kind\":\"bool\"},\"elem\":{\"kind\":\"int64\"}},{\"kind\":\"struct\",\"name\":\"main.message\",\"fields\":[{\"name\":\"a\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"b\",\"type\":{\"kind\":\"string\"}},{\"name\":\"c\",\"type\":{\"kind\":\"bool\"}},{\"name\":\"d\",\"type\":{\"kind\":\"array\",\"len\":10,\"elem\":{\"kind\":\"int64\"}}},{\"name\":\"e\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"string\"}}},{\"name\":\"f\",\"type\":{\"kind\":\"map\",\"key\":{\"kind\":\"bool\"},\"elem\":{\"kind\":\"int64\"}}}]}],\"results\":[{\"kind\":\"struct\",\"name\":\"main.pair\",\"fields\":[{\"name\":\"a\",\"type\":{\"kind\":\"struct\",\"name\":\"main.message\",\"fields\":[{\"name\":\"a\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"b\",\"type\":{\"kind\":\"string\"}},{\"name\":\"c\",\"type\":{\"kind\":\"bool\"}},{\"name\":\"d\",\"type\":{\"kind\":\"array\",\"len\":10,\"elem\":{\"kind\":\"int64\"}}},{\"name\":\"e\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"string\"}}},{\"name\":\"f\",\"type\":{\"kind\":\"map\",\"key\":{\"kind\":\"bool\"},\"elem\":{\"kind\":\"int64\"}}}]}},{\"name\":\"b\",\"type\":{\"kind\":\"struct\",\"name\":\"main.message\",\"fields\":[{\"name\":\"a\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"b\",\"type\":{\"kind\":\"string\"}},{\"name\":\"c\",\"type\":{\"kind\":\"bool\"}},{\"name\":\"d\",\"type\":{\"kind\":\"array\",\"len\":10,\"elem\":{\"kind\":\"int64\"}}},{\"name\":\"e\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"string\"}}},{\"name\":\"f\",\"type\":{\"kind\":\"map\",\"key\":{\"kind\":\"bool\"},\"elem\":{\"kind\":\"int64\"}}}]}}]}]}]⟧\n",
 	})
 	codegen.Register(codegen.Registration{
 		Name:      "github.com/sh3lk/mx/internal/tool/generate/example/B",
@@ -53,7 +53,7 @@ func init() {
 		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
 			return b_reflect_stub{caller: caller}
 		},
-		RefData: "⟦6971bce2:MxEdge:github.com/sh3lk/mx/internal/tool/generate/example/B→github.com/sh3lk/mx/internal/tool/generate/example/A⟧\n⟦c9c43570:wEaVeRlIsTeNeRs:github.com/sh3lk/mx/internal/tool/generate/example/B→lis2,renamed_listener⟧\n",
+		RefData: "⟦edc506c8:MxEdge:github.com/sh3lk/mx/internal/tool/generate/example/B→github.com/sh3lk/mx/internal/tool/generate/example/A⟧\n⟦c7282667:wEaVeRlIsTeNeRs:github.com/sh3lk/mx/internal/tool/generate/example/B→lis2,renamed_listener⟧\n⟦949076c1:MxSchema:github.com/sh3lk/mx/internal/tool/generate/example/B→[{\"name\":\"M1\",\"args\":[{\"kind\":\"int64\"},{\"kind\":\"string\"},{\"kind\":\"bool\"},{\"kind\":\"array\",\"len\":10,\"elem\":{\"kind\":\"int64\"}},{\"kind\":\"slice\",\"elem\":{\"kind\":\"string\"}},{\"kind\":\"map\",\"key\":{\"kind\":\"bool\"},\"elem\":{\"kind\":\"int64\"}},{\"kind\":\"struct\",\"name\":\"main.message\",\"fields\":[{\"name\":\"a\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"b\",\"type\":{\"kind\":\"string\"}},{\"name\":\"c\",\"type\":{\"kind\":\"bool\"}},{\"name\":\"d\",\"type\":{\"kind\":\"array\",\"len\":10,\"elem\":{\"kind\":\"int64\"}}},{\"name\":\"e\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"string\"}}},{\"name\":\"f\",\"type\":{\"kind\":\"map\",\"key\":{\"kind\":\"bool\"},\"elem\":{\"kind\":\"int64\"}}}]}],\"results\":[{\"kind\":\"struct\",\"name\":\"main.pair\",\"fields\":[{\"name\":\"a\",\"type\":{\"kind\":\"struct\",\"name\":\"main.message\",\"fields\":[{\"name\":\"a\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"b\",\"type\":{\"kind\":\"string\"}},{\"name\":\"c\",\"type\":{\"kind\":\"bool\"}},{\"name\":\"d\",\"type\":{\"kind\":\"array\",\"len\":10,\"elem\":{\"kind\":\"int64\"}}},{\"name\":\"e\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"string\"}}},{\"name\":\"f\",\"type\":{\"kind\":\"map\",\"key\":{\"kind\":\"bool\"},\"elem\":{\"kind\":\"int64\"}}}]}},{\"name\":\"b\",\"type\":{\"kind\":\"struct\",\"name\":\"main.message\",\"fields\":[{\"name\":\"a\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"b\",\"type\":{\"kind\":\"string\"}},{\"name\":\"c\",\"type\":{\"kind\":\"bool\"}},{\"name\":\"d\",\"type\":{\"kind\":\"array\",\"len\":10,\"elem\":{\"kind\":\"int64\"}}},{\"name\":\"e\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"string\"}}},{\"name\":\"f\",\"type\":{\"kind\":\"map\",\"key\":{\"kind\":\"bool\"},\"elem\":{\"kind\":\"int64\"}}}]}}]}]},{\"name\":\"M2\",\"args\":[{\"kind\":\"int64\"},{\"kind\":\"string\"},{\"kind\":\"bool\"},{\"kind\":\"array\",\"len\":10,\"elem\":{\"kind\":\"int64\"}},{\"kind\":\"slice\",\"elem\":{\"kind\":\"string\"}},{\"kind\":\"map\",\"key\":{\"kind\":\"bool\"},\"elem\":{\"kind\":\"int64\"}},{\"kind\":\"struct\",\"name\":\"main.message\",\"fields\":[{\"name\":\"a\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"b\",\"type\":{\"kind\":\"string\"}},{\"name\":\"c\",\"type\":{\"kind\":\"bool\"}},{\"name\":\"d\",\"type\":{\"kind\":\"array\",\"len\":10,\"elem\":{\"kind\":\"int64\"}}},{\"name\":\"e\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"string\"}}},{\"name\":\"f\",\"type\":{\"kind\":\"map\",\"key\":{\"kind\":\"bool\"},\"elem\":{\"kind\":\"int64\"}}}]}],\"results\":[{\"kind\":\"struct\",\"name\":\"main.pair\",\"fields\":[{\"name\":\"a\",\"type\":{\"kind\":\"struct\",\"name\":\"main.message\",\"fields\":[{\"name\":\"a\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"b\",\"type\":{\"kind\":\"string\"}},{\"name\":\"c\",\"type\":{\"kind\":\"bool\"}},{\"name\":\"d\",\"type\":{\"kind\":\"array\",\"len\":10,\"elem\":{\"kind\":\"int64\"}}},{\"name\":\"e\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"string\"}}},{\"name\":\"f\",\"type\":{\"kind\":\"map\",\"key\":{\"kind\":\"bool\"},\"elem\":{\"kind\":\"int64\"}}}]}},{\"name\":\"b\",\"type\":{\"kind\":\"struct\",\"name\":\"main.message\",\"fields\":[{\"name\":\"a\",\"type\":{\"kind\":\"int64\"}},{\"name\":\"b\",\"type\":{\"kind\":\"string\"}},{\"name\":\"c\",\"type\":{\"kind\":\"bool\"}},{\"name\":\"d\",\"type\":{\"kind\":\"array\",\"len\":10,\"elem\":{\"kind\":\"int64\"}}},{\"name\":\"e\",\"type\":{\"kind\":\"slice\",\"elem\":{\"kind\":\"string\"}}},{\"name\":\"f\",\"type\":{\"kind\":\"map\",\"key\":{\"kind\":\"bool\"},\"elem\":{\"kind\":\"int64\"}}}]}}]}]}]⟧\n",
 	})
 }
 
@@ -215,7 +215,8 @@ func (s a_client_stub) M1(ctx context.Context, a0 int, a1 string, a2 bool, a3 [1
 	}()
 
 	// Encode arguments.
-	enc := codegen.NewEncoder()
+	enc := codegen.GetEncoder()
+	defer codegen.PutEncoder(enc)
 	enc.Int(a0)
 	enc.String(a1)
 	enc.Bool(a2)
@@ -239,7 +240,7 @@ func (s a_client_stub) M1(ctx context.Context, a0 int, a1 string, a2 bool, a3 [1
 	}
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewZeroCopyDecoder(results)
 	(&r0).MXUnmarshal(dec)
 	err = dec.Error()
 	return
@@ -275,7 +276,8 @@ func (s a_client_stub) M2(ctx context.Context, a0 int, a1 string, a2 bool, a3 [1
 	}()
 
 	// Encode arguments.
-	enc := codegen.NewEncoder()
+	enc := codegen.GetEncoder()
+	defer codegen.PutEncoder(enc)
 	enc.Int(a0)
 	enc.String(a1)
 	enc.Bool(a2)
@@ -299,7 +301,7 @@ func (s a_client_stub) M2(ctx context.Context, a0 int, a1 string, a2 bool, a3 [1
 	}
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewZeroCopyDecoder(results)
 	(&r0).MXUnmarshal(dec)
 	err = dec.Error()
 	return
@@ -344,7 +346,8 @@ func (s b_client_stub) M1(ctx context.Context, a0 int, a1 string, a2 bool, a3 [1
 	}()
 
 	// Encode arguments.
-	enc := codegen.NewEncoder()
+	enc := codegen.GetEncoder()
+	defer codegen.PutEncoder(enc)
 	enc.Int(a0)
 	enc.String(a1)
 	enc.Bool(a2)
@@ -368,7 +371,7 @@ func (s b_client_stub) M1(ctx context.Context, a0 int, a1 string, a2 bool, a3 [1
 	}
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewZeroCopyDecoder(results)
 	(&r0).MXUnmarshal(dec)
 	err = dec.Error()
 	return
@@ -404,7 +407,8 @@ func (s b_client_stub) M2(ctx context.Context, a0 int, a1 string, a2 bool, a3 [1
 	}()
 
 	// Encode arguments.
-	enc := codegen.NewEncoder()
+	enc := codegen.GetEncoder()
+	defer codegen.PutEncoder(enc)
 	enc.Int(a0)
 	enc.String(a1)
 	enc.Bool(a2)
@@ -428,7 +432,7 @@ func (s b_client_stub) M2(ctx context.Context, a0 int, a1 string, a2 bool, a3 [1
 	}
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewZeroCopyDecoder(results)
 	(&r0).MXUnmarshal(dec)
 	err = dec.Error()
 	return
@@ -437,10 +441,10 @@ func (s b_client_stub) M2(ctx context.Context, a0 int, a1 string, a2 bool, a3 [1
 // Note that "mx generate" will always generate the error message below.
 // Everything is okay. The error message is only relevant if you see it when
 // you run "go build" or "go run".
-var _ codegen.LatestVersion = codegen.Version[[0][24]struct{}](`
+var _ codegen.LatestVersion = codegen.Version[[0][25]struct{}](`
 
-ERROR: You generated this file with 'mx generate' v0.24.7-0.20250401231336-b01860e0378a+dirty (codegen
-version v0.24.0). The generated code is incompatible with the version of the
+ERROR: You generated this file with 'mx generate' (devel) (codegen
+version v0.25.0). The generated code is incompatible with the version of the
 github.com/sh3lk/mx module that you're using. The mx module
 version can be found in your go.mod file or by running the following command.
 
@@ -29,6 +29,7 @@ import (
 	"path"
 	"path/filepath"
 	"reflect"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -36,12 +37,14 @@ import (
 
 	"github.com/sh3lk/mx/internal/files"
 	"github.com/sh3lk/mx/internal/tool"
+	"github.com/sh3lk/mx/runtime/bin"
 	"github.com/sh3lk/mx/runtime/codegen"
 	"github.com/sh3lk/mx/runtime/colors"
 	"github.com/sh3lk/mx/runtime/version"
 	"golang.org/x/exp/maps"
 	"golang.org/x/tools/go/packages"
 	"golang.org/x/tools/go/types/typeutil"
+	"google.golang.org/protobuf/proto"
 )
 
 // TODO(rgrandl): Modify the generator code to use only the types package. Right
@@ -54,7 +57,7 @@ const (
 	Usage = `Generate code for a MX application.
 
 Usage:
-  mx generate [-tags taglist] [packages]
+  mx generate [-tags taglist] [-gateway] [-mocks] [-grpc components] [-check-compat binary] [packages]
 
 Description:
   "mx generate" generates code for the MX applications in the
@@ -67,6 +70,28 @@ Description:
   You specify build tags for "mx generate" in the same way you specify build
   tags for go build. See "go help build" for more information.
 
+  The -grpc flag takes a comma-separated list of component interfaces. For
+  each of them, "mx generate" generates a gRPC service in a mx_gen.proto file
+  in the package's directory. See the grpcbridge package for details.
+
+  If the -gateway flag is set, "mx generate" also generates an HTTP+JSON
+  gateway for every component, which lets programs not written in Go call the
+  component's methods. See mx.NewGateway for details.
+
+  If the -mocks flag is set, "mx generate" also generates a MockFoo type for
+  every component interface Foo, which records the calls made to it and returns
+  scripted results. Mocks can be passed to mxtest.Fake and sim.Fake. See the
+  mxtest/mock package for details.
+
+  The -check-compat flag takes the binary of a previous version of the
+  application. "mx generate" compares the wire schemas of the components'
+  methods---the types of their arguments and results---embedded in the binary
+  with those in the provided packages, and fails if a change would make the
+  two versions misdecode each other's calls: a removed method, a changed
+  argument or result type, a struct field added, removed, or reordered, and so
+  on. Only binaries built with code generated by this version of
+  "mx generate" or a later one embed wire schemas.
+
   You specify packages for "mx generate" in the same way you specify
   packages for go build, go test, go vet, etc. See "go help packages" for more
   information.
@@ -97,13 +122,33 @@ Examples:
 
   # Generate code for all files that have a "//go:build good,prod" line at the
   top of the file.
-  mx generate -tags good,prod`
+  mx generate -tags good,prod
+
+  # Generate code, including HTTP+JSON gateways, for the package in the current
+  directory.
+  mx generate -gateway
+
+  # Generate code, including mock implementations of the components, for the
+  package in the current directory.
+  mx generate -mocks
+
+  # Generate code, including gRPC services for the Cache and Store components,
+  for the package in the current directory.
+  mx generate -grpc Cache,Store
+
+  # Generate code for the package in the current directory, and check that it
+  can be rolled out alongside the previous version of the application.
+  mx generate -check-compat ./old/app`
 )
 
 // Options controls the operation of Generate.
 type Options struct {
-	Warn      func(error) // If non-nil, use the specified function to report warnings
-	BuildTags string
+	Warn        func(error) // If non-nil, use the specified function to report warnings
+	BuildTags   string
+	Gateway     bool     // If true, generate HTTP+JSON gateways for components
+	Mocks       bool     // If true, generate mock implementations of components
+	GRPC        []string // Names of the component interfaces to generate gRPC services for
+	CheckCompat string   // If non-empty, the binary to check wire compatibility against
 }
 
 // Generate generates MX code for the specified packages.
@@ -130,6 +175,8 @@ func Generate(dir string, pkgs []string, opt Options) error {
 
 	var automarshals typeutil.Map
 	var errs []error
+	grpc := map[string]bool{} // component interfaces with a gRPC service
+	var schemas []codegen.ComponentSchema
 	for _, pkg := range pkgList {
 		g, err := newGenerator(opt, pkg, fset, &automarshals)
 		if err != nil {
@@ -139,10 +186,47 @@ func Generate(dir string, pkgs []string, opt Options) error {
 		if err := g.generate(); err != nil {
 			errs = append(errs, err)
 		}
+		for _, comp := range g.components {
+			if g.hasGRPCService(comp) {
+				grpc[comp.intfName()] = true
+			}
+		}
+		schemas = append(schemas, g.schemas...)
+	}
+	for _, name := range opt.GRPC {
+		if !grpc[name] && len(errs) == 0 {
+			errs = append(errs, fmt.Errorf("-grpc: component %s not found", name))
+		}
+	}
+	if opt.CheckCompat != "" && len(errs) == 0 {
+		if err := checkCompatWith(opt.CheckCompat, pkgList, schemas); err != nil {
+			errs = append(errs, err)
+		}
 	}
 	return errors.Join(errs...)
 }
 
+// checkCompatWith returns an error if the provided schemas of the components in
+// pkgs are not wire-compatible with the schemas embedded in the binary.
+func checkCompatWith(binary string, pkgs []*packages.Package, schemas []codegen.ComponentSchema) error {
+	old, err := bin.ReadSchemas(binary)
+	if err != nil {
+		return fmt.Errorf("-check-compat: read %s: %w", binary, err)
+	}
+	if len(old) == 0 {
+		return fmt.Errorf("-check-compat: %s has no component wire schemas; was its code generated by an older \"mx generate\"?", binary)
+	}
+	paths := map[string]bool{}
+	for _, pkg := range pkgs {
+		paths[pkg.PkgPath] = true
+	}
+	problems := checkCompat(old, schemas, paths)
+	if len(problems) == 0 {
+		return nil
+	}
+	return fmt.Errorf("-check-compat: incompatible with %s:\n  %s", binary, strings.Join(problems, "\n  "))
+}
+
 // parseNonMXGenFile parses a Go file, except for mx_gen.go files whose
 // contents are ignored since those contents may reference types that no longer
 // exist.
@@ -154,12 +238,15 @@ func parseNonMXGenFile(fset *token.FileSet, filename string, src []byte) (*ast.F
 }
 
 type generator struct {
+	opt            Options
 	pkg            *packages.Package
 	tset           *typeSet
 	fileset        *token.FileSet
 	components     []*component
-	sizeFuncNeeded typeutil.Map // types that need a mx_size_* function
-	generated      typeutil.Map // memo cache for generateEncDecMethodsFor
+	sizeFuncNeeded typeutil.Map              // types that need a mx_size_* function
+	grpc           *grpcFile                 // gRPC services, or nil if there are none
+	schemas        []codegen.ComponentSchema // wire schemas of the components
+	generated      typeutil.Map              // memo cache for generateEncDecMethodsFor
 }
 
 // errorf is like fmt.Errorf but prefixes the error with the provided position.
@@ -272,6 +359,15 @@ func newGenerator(opt Options, pkg *packages.Package, fset *token.FileSet, autom
 		if err := findMethodAttributes(pkg, file, components); err != nil {
 			errs = append(errs, err)
 		}
+		if err := findMethodDirectives(pkg, file, components); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if err := checkHedgedMethods(fset, maps.Values(components)); err != nil {
+		errs = append(errs, err)
+	}
+	if err := checkMethodDirectives(fset, maps.Values(components)); err != nil {
+		errs = append(errs, err)
 	}
 
 	if err := errors.Join(errs...); err != nil {
@@ -279,6 +375,7 @@ func newGenerator(opt Options, pkg *packages.Package, fset *token.FileSet, autom
 	}
 
 	return &generator{
+		opt:        opt,
 		pkg:        pkg,
 		tset:       tset,
 		fileset:    fset,
@@ -322,6 +419,7 @@ func findComponents(opt Options, pkg *packages.Package, f *ast.File, tset *typeS
 func findMethodAttributes(pkg *packages.Package, f *ast.File, components map[string]*component) error {
 	// Look for declarations of the form:
 	//	var _ mx.NotRetriable = Component.Method
+	//	var _ mx.Hedged = Component.Method
 	var errs []error
 	for _, decl := range f.Decls {
 		gendecl, ok := decl.(*ast.GenDecl)
@@ -338,20 +436,54 @@ func findMethodAttributes(pkg *packages.Package, f *ast.File, components map[str
 				continue
 			}
 			t := typeAndValue.Type
-			if !isMXNotRetriable(t) {
-				continue
-			}
-			for _, val := range valspec.Values {
-				// We allow non-blank vars for uniformity.
-				comp, method, ok := findComponentMethod(pkg, components, val)
-				if !ok {
-					errs = append(errs, errorf(pkg.Fset, valspec.Pos(), "mx.NonRetriable should only be assigned a value that identifies a method of a component implemented by this package"))
-					continue
+			switch {
+			case isMXNotRetriable(t):
+				for _, val := range valspec.Values {
+					// We allow non-blank vars for uniformity.
+					comp, method, ok := findComponentMethod(pkg, components, val)
+					if !ok {
+						errs = append(errs, errorf(pkg.Fset, valspec.Pos(), "mx.NonRetriable should only be assigned a value that identifies a method of a component implemented by this package"))
+						continue
+					}
+					if comp.noretry == nil {
+						comp.noretry = map[string]struct{}{}
+					}
+					comp.noretry[method] = struct{}{}
 				}
-				if comp.noretry == nil {
-					comp.noretry = map[string]struct{}{}
+			case isMXHedged(t):
+				for _, val := range valspec.Values {
+					comp, method, ok := findComponentMethod(pkg, components, val)
+					if !ok {
+						errs = append(errs, errorf(pkg.Fset, valspec.Pos(), "mx.Hedged should only be assigned a value that identifies a method of a component implemented by this package"))
+						continue
+					}
+					if comp.hedged == nil {
+						comp.hedged = map[string]token.Pos{}
+					}
+					comp.hedged[method] = valspec.Pos()
 				}
-				comp.noretry[method] = struct{}{}
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// checkHedgedMethods checks that the hedged methods of the provided
+// components can be hedged. A hedged call may execute more than once, so
+// hedged methods must be retriable. Streaming calls are never hedged.
+func checkHedgedMethods(fset *token.FileSet, components []*component) error {
+	var errs []error
+	for _, comp := range components {
+		for _, m := range comp.methods() {
+			pos, ok := comp.hedged[m.Name()]
+			if !ok {
+				continue
+			}
+			if _, ok := comp.noretry[m.Name()]; ok {
+				errs = append(errs, errorf(fset, pos, "method %s.%s is both hedged and not retriable", comp.intfName(), m.Name()))
+			}
+			if isStreaming(m.Type().(*types.Signature)) {
+				errs = append(errs, errorf(fset, pos, "streaming method %s.%s cannot be hedged", comp.intfName(), m.Name()))
 			}
 		}
 	}
@@ -690,15 +822,17 @@ func getListenerNamesFromStructField(pkg *packages.Package, f *ast.Field) ([]str
 //	}
 //	type router struct{}
 type component struct {
-	intf          *types.Named        // component interface
-	impl          *types.Named        // component implementation
-	router        *types.Named        // router, or nil if there is no router
-	routingKey    types.Type          // routing key, or nil if there is no router
-	routedMethods map[string]bool     // the set of methods with a routing function
-	isMain        bool                // intf is mx.Main
-	refs          []*types.Named      // List of T where a mx.Ref[T] field is in impl struct
-	listeners     []string            // Names of listener fields declared in impl struct
-	noretry       map[string]struct{} // Methods that should not be retried
+	intf          *types.Named                 // component interface
+	impl          *types.Named                 // component implementation
+	router        *types.Named                 // router, or nil if there is no router
+	routingKey    types.Type                   // routing key, or nil if there is no router
+	routedMethods map[string]bool              // the set of methods with a routing function
+	isMain        bool                         // intf is mx.Main
+	refs          []*types.Named               // List of T where a mx.Ref[T] field is in impl struct
+	listeners     []string                     // Names of listener fields declared in impl struct
+	noretry       map[string]struct{}          // Methods that should not be retried
+	hedged        map[string]token.Pos         // Methods whose calls may be hedged
+	directives    map[string]*methodDirectives // //mx: directives, by method
 }
 
 func fullName(t *types.Named) string {
@@ -770,9 +904,25 @@ func validateMethods(pkg *packages.Package, tset *typeSet, intf *types.Named) er
 			errs = append(errs, bad("argument", "The first argument must have type context.Context."))
 		}
 
-		// All arguments but context.Context must be serializable.
+		// All arguments but context.Context must be serializable, except for
+		// at most one mx.Stream[T] argument, where T must be serializable.
+		streams := 0
 		for i := 1; i < t.Params().Len(); i++ {
 			arg := t.Params().At(i)
+			if isMXStream(arg.Type()) {
+				streams++
+				if streams > 1 {
+					errs = append(errs, bad("argument",
+						"Argument %d has type %s, but a method can accept at most one mx.Stream argument.",
+						i, formatType(pkg, arg.Type())))
+				}
+				if err := errors.Join(tset.checkSerializable(streamElem(arg.Type()))...); err != nil {
+					errs = append(errs, bad("argument",
+						"Argument %d has type %s, whose values are not serializable. The values of a mx.Stream must be serializable.\n%w",
+						i, formatType(pkg, arg.Type()), err))
+				}
+				continue
+			}
 			if err := errors.Join(tset.checkSerializable(arg.Type())...); err != nil {
 				// TODO(mwhittaker): Print a link to documentation on which types are serializable.
 				errs = append(errs, bad("argument",
@@ -788,9 +938,28 @@ func validateMethods(pkg *packages.Package, tset *typeSet, intf *types.Named) er
 			errs = append(errs, bad("return", "The last return must have type error."))
 		}
 
-		// All results but error must be serializable.
+		// All results but error must be serializable. A method may instead
+		// return a mx.Stream[T] and an error, where T must be serializable.
 		for i := 0; i < t.Results().Len()-1; i++ {
 			res := t.Results().At(i)
+			if isMXStream(res.Type()) {
+				if t.Results().Len() != 2 {
+					errs = append(errs, bad("return",
+						"Return %d has type %s, but a method that returns a mx.Stream must return exactly a mx.Stream and an error.",
+						i, formatType(pkg, res.Type())))
+				}
+				if streams > 0 {
+					errs = append(errs, bad("return",
+						"Return %d has type %s, but a method cannot both accept and return a mx.Stream.",
+						i, formatType(pkg, res.Type())))
+				}
+				if err := errors.Join(tset.checkSerializable(streamElem(res.Type()))...); err != nil {
+					errs = append(errs, bad("return",
+						"Return %d has type %s, whose values are not serializable. The values of a mx.Stream must be serializable.\n%w",
+						i, formatType(pkg, res.Type()), err))
+				}
+				continue
+			}
 			if err := errors.Join(tset.checkSerializable(res.Type())...); err != nil {
 				// TODO(mwhittaker): Print a link to documentation on which types are serializable.
 				errs = append(errs, bad("return",
@@ -802,6 +971,29 @@ func validateMethods(pkg *packages.Package, tset *typeSet, intf *types.Named) er
 	return errors.Join(errs...)
 }
 
+// streamArg returns the index of the mx.Stream argument of the provided
+// method signature, or -1 if there is none.
+func streamArg(sig *types.Signature) int {
+	for i := 1; i < sig.Params().Len(); i++ {
+		if isMXStream(sig.Params().At(i).Type()) {
+			return i
+		}
+	}
+	return -1
+}
+
+// returnsStream returns whether the provided method signature returns a
+// mx.Stream.
+func returnsStream(sig *types.Signature) bool {
+	return sig.Results().Len() == 2 && isMXStream(sig.Results().At(0).Type())
+}
+
+// isStreaming returns whether the provided method signature accepts or
+// returns a mx.Stream.
+func isStreaming(sig *types.Signature) bool {
+	return streamArg(sig) >= 0 || returnsStream(sig)
+}
+
 // checkMistypedInitOrShutdown returns an error if the provided component implementation
 // has an Init or a Shutdown method that does not have type "func(context.Context) error".
 func checkMistypedInitOrShutdown(pkg *packages.Package, tset *typeSet, impl *types.Named) error {
@@ -922,6 +1114,17 @@ func (g *generator) generate() error {
 		return g.components[i].intfName() < g.components[j].intfName()
 	})
 
+	// Build the gRPC services of the selected components.
+	for _, comp := range g.components {
+		if !slices.Contains(g.opt.GRPC, comp.intfName()) || comp.isMain {
+			continue
+		}
+		if g.grpc == nil {
+			g.grpc = newGRPCFile(g.tset, g.fileset)
+		}
+		g.grpc.addService(comp.intf, comp.methods(), g.opt.Warn)
+	}
+
 	// Generate the file body.
 	var body bytes.Buffer
 	{
@@ -938,9 +1141,18 @@ func (g *generator) generate() error {
 		}
 		g.generateServerStubs(fn)
 		g.generateReflectStubs(fn)
+		if g.opt.Gateway {
+			g.generateGateways(fn)
+		}
+		if g.opt.Mocks {
+			g.generateMocks(fn)
+		}
 		g.generateAutoMarshalMethods(fn)
 		g.generateRouterMethods(fn)
 		g.generateEncDecMethods(fn)
+		if err := g.generateGRPCFile(fn); err != nil {
+			return err
+		}
 
 		// append the size methods
 		if g.sizeFuncNeeded.Len() > 0 {
@@ -1245,6 +1457,11 @@ func (g *generator) generateRegisteredComponents(p printFn) {
 		if len(comp.listeners) > 0 {
 			refData.WriteString(codegen.MakeListenersString(myName, comp.listeners))
 		}
+		if !comp.isMain {
+			schema := g.schema(comp)
+			g.schemas = append(g.schemas, schema)
+			refData.WriteString(codegen.MakeSchemaString(schema))
+		}
 
 		// E.g.,
 		//	mx.Register(mx.Registration{
@@ -1271,10 +1488,25 @@ func (g *generator) generateRegisteredComponents(p printFn) {
 		if len(comp.noretry) > 0 {
 			p(`		NoRetry: []int{%s},`, noRetryString(comp))
 		}
+		if hedged := hedgedString(comp); hedged != "" {
+			p(`		Hedged: []int{%s},`, hedged)
+		}
+		if streaming := streamingString(comp); streaming != "" {
+			p(`		Streaming: []int{%s},`, streaming)
+		}
+		if directives := g.directivesString(comp); directives != "" {
+			p(`		Directives: []%s{%s},`, g.codegen().qualify("MethodDirectives"), directives)
+		}
 		p(`		LocalStubFn: %s,`, localStubFn)
 		p(`		ClientStubFn: %s,`, clientStubFn)
 		p(`		ServerStubFn: %s,`, serverStubFn)
 		p(`		ReflectStubFn: %s,`, reflectStubFn)
+		if g.hasGRPCService(comp) {
+			p(`		GRPCFile: mx_grpc_file,`)
+		}
+		if g.opt.Gateway {
+			p(`		GatewayFn: func(impl any) []%s { return %s_gateway_methods(impl.(%s)) },`, g.codegen().qualify("GatewayMethod"), notExported(name), g.componentRef(comp))
+		}
 		p(`		RefData: %s,`, strconv.Quote(refData.String()))
 		p(`	})`)
 	}
@@ -1299,6 +1531,30 @@ func noRetryString(comp *component) string {
 	return strings.Join(strs, ", ")
 }
 
+// hedgedString generates a string of the form "i_1, i_2, ... i_n" where the
+// individual elements are the indices of the hedged methods of comp.
+func hedgedString(comp *component) string {
+	var strs []string
+	for i, m := range comp.methods() {
+		if _, ok := comp.hedged[m.Name()]; ok {
+			strs = append(strs, strconv.Itoa(i))
+		}
+	}
+	return strings.Join(strs, ", ")
+}
+
+// streamingString generates a string of the form "i_1, i_2, ... i_n" where the
+// individual elements are the indices of the streaming methods of comp.
+func streamingString(comp *component) string {
+	var strs []string
+	for i, m := range comp.methods() {
+		if isStreaming(m.Type().(*types.Signature)) {
+			strs = append(strs, strconv.Itoa(i))
+		}
+	}
+	return strings.Join(strs, ", ")
+}
+
 // generateLocalStubs generates code that creates stubs for the local components.
 func (g *generator) generateLocalStubs(p printFn) {
 	p(``)
@@ -1434,11 +1690,20 @@ func (g *generator) generateClientStubs(p printFn) {
 			p(`	}()`)
 			p(``)
 
+			// The indices of the arguments to encode. A mx.Stream argument
+			// is streamed rather than encoded.
+			var encoded []int
+			for i := 1; i < mt.Params().Len(); i++ { // Skip initial context.Context
+				if !isMXStream(mt.Params().At(i).Type()) {
+					encoded = append(encoded, i)
+				}
+			}
+
 			preallocated := false
-			if mt.Params().Len() > 1 {
+			if len(encoded) > 0 {
 				// Preallocate a perfectly sized buffer if possible.
 				canPreallocate := true
-				for i := 1; i < mt.Params().Len(); i++ { // Skip initial context.Context
+				for _, i := range encoded {
 					if !g.preallocatable(mt.Params().At(i).Type()) {
 						canPreallocate = false
 						break
@@ -1448,11 +1713,12 @@ func (g *generator) generateClientStubs(p printFn) {
 					p("")
 					p("	// Preallocate a buffer of the right size.")
 					p("	size := 0")
-					for i := 1; i < mt.Params().Len(); i++ {
+					for _, i := range encoded {
 						at := mt.Params().At(i).Type()
 						p("	size += %s", g.size(fmt.Sprintf("a%d", i-1), at))
 					}
-					p("	enc := %s", g.codegen().qualify("NewEncoder()"))
+					p("	enc := %s()", g.codegen().qualify("GetEncoder"))
+					p("	defer %s(enc)", g.codegen().qualify("PutEncoder"))
 					p("	enc.Reset(size)")
 					preallocated = true
 				}
@@ -1460,14 +1726,15 @@ func (g *generator) generateClientStubs(p printFn) {
 
 			// Invoke call.Encode.
 			b.Reset()
-			if mt.Params().Len() > 1 {
+			if len(encoded) > 0 {
 				p(``)
 				p(`	// Encode arguments.`)
 				if !preallocated {
-					p("	enc := %s", g.codegen().qualify("NewEncoder()"))
+					p("	enc := %s()", g.codegen().qualify("GetEncoder"))
+					p("	defer %s(enc)", g.codegen().qualify("PutEncoder"))
 				}
 			}
-			for i := 1; i < mt.Params().Len(); i++ { // Skip initial context.Context
+			for _, i := range encoded {
 				at := mt.Params().At(i).Type()
 				arg := fmt.Sprintf("a%d", i-1)
 				p(`	%s`, g.encode("enc", arg, at))
@@ -1493,10 +1760,15 @@ func (g *generator) generateClientStubs(p printFn) {
 			p(``)
 			p(`	// Call the remote method.`)
 			data := "nil"
-			if mt.Params().Len() > 1 {
+			if len(encoded) > 0 {
 				data = "enc.Data()"
 				p(`	requestBytes = len(enc.Data())`)
 			}
+			if isStreaming(mt) {
+				g.generateClientStreamCall(p, mt, methodIndex[m.Name()], data)
+				p(`}`)
+				continue
+			}
 			p(`	var results []byte`)
 			p(`	results, err = s.stub.Run(ctx, %d, %s, shardKey)`, methodIndex[m.Name()], data)
 			p(`	replyBytes = len(results)`)
@@ -1509,7 +1781,7 @@ func (g *generator) generateClientStubs(p printFn) {
 			b.Reset()
 			p(``)
 			p(`	// Decode the results.`)
-			p(`	dec := %s(results)`, g.codegen().qualify("NewDecoder"))
+			p(`	dec := %s(results)`, g.codegen().qualify("NewZeroCopyDecoder"))
 			for i := 0; i < mt.Results().Len()-1; i++ { // Skip final error
 				rt := mt.Results().At(i).Type()
 				res := fmt.Sprintf("r%d", i)
@@ -1534,6 +1806,95 @@ func (g *generator) generateClientStubs(p printFn) {
 	}
 }
 
+// generateClientStreamCall generates the code that calls and decodes the
+// results of the streaming method with the provided signature and index, in a
+// client stub. data is the expression holding the encoded arguments.
+func (g *generator) generateClientStreamCall(p printFn, mt *types.Signature, index int, data string) {
+	byteStream := g.codegen().qualify("ByteStream")
+	if i := streamArg(mt); i >= 0 {
+		// Stream the argument to the server.
+		arg := fmt.Sprintf("a%d", i-1)
+		elem := streamElem(mt.Params().At(i).Type())
+		p(`	var in %s = %s()`, byteStream, g.codegen().qualify("EmptyStream"))
+		p(`	if %s != nil {`, arg)
+		p(`		in = %s[%s](%s, %s)`, g.codegen().qualify("EncodeStream"), g.tset.genTypeString(elem), arg, g.streamEncoder(elem))
+		p(`	}`)
+	} else {
+		p(`	var in %s`, byteStream)
+	}
+	p(`	var results []byte`)
+	p(`	var out %s`, byteStream)
+	p(`	results, out, err = s.stub.RunStream(ctx, %d, %s, in, shardKey)`, index, data)
+	p(`	replyBytes = len(results)`)
+	p(`	if err != nil {`)
+	p(`		err = %s(%s, err)`, g.errorsPackage().qualify("Join"), g.mx().qualify("RemoteCallError"))
+	p(`		return`)
+	p(`	}`)
+
+	if !returnsStream(mt) {
+		// The server streams nothing back.
+		p(`	defer out.Close()`)
+		p(``)
+		p(`	// Decode the results.`)
+		p(`	dec := %s(results)`, g.codegen().qualify("NewZeroCopyDecoder"))
+		for i := 0; i < mt.Results().Len()-1; i++ { // Skip final error
+			res := fmt.Sprintf("r%d", i)
+			for _, stmt := range g.decodeValue("dec", res, fmt.Sprintf("tmp%d", i), mt.Results().At(i).Type()) {
+				p(`	%s`, stmt)
+			}
+		}
+		p(`	err = dec.Error()`)
+		p(`	return`)
+		return
+	}
+
+	p(`	defer func() {`)
+	p(`		if r0 == nil {`)
+	p(`			out.Close()`)
+	p(`		}`)
+	p(`	}()`)
+	p(``)
+	p(`	// Decode the results.`)
+	p(`	dec := %s(results)`, g.codegen().qualify("NewZeroCopyDecoder"))
+	p(`	err = dec.Error()`)
+	p(`	if err != nil {`)
+	p(`		return`)
+	p(`	}`)
+	elem := streamElem(mt.Results().At(0).Type())
+	p(`	r0 = %s[%s](out, %s)`, g.codegen().qualify("DecodeStream"), g.tset.genTypeString(elem), g.streamDecoder(elem))
+	p(`	return`)
+}
+
+// streamEncoder returns a function literal that encodes a value of type t,
+// suitable for codegen.EncodeStream.
+func (g *generator) streamEncoder(t types.Type) string {
+	return fmt.Sprintf("func(enc *%s, v %s) { %s }", g.codegen().qualify("Encoder"), g.tset.genTypeString(t), g.encode("enc", "v", t))
+}
+
+// streamDecoder returns a function literal that decodes a value of type t,
+// suitable for codegen.DecodeStream.
+func (g *generator) streamDecoder(t types.Type) string {
+	stmts := g.decodeValue("dec", "v", "tmp", t)
+	return fmt.Sprintf("func(dec *%s) (v %s) {\n%s\nreturn\n}", g.codegen().qualify("Decoder"), g.tset.genTypeString(t), strings.Join(stmts, "\n"))
+}
+
+// decodeValue returns the statements that decode a value of type t from the
+// decoder dec into the variable v. tmp is the name of a temporary variable
+// that the statements may declare.
+func (g *generator) decodeValue(dec, v, tmp string, t types.Type) []string {
+	if x, ok := t.(*types.Pointer); ok && (g.tset.isProto(x) || g.tset.hasMarshalBinary(x)) {
+		// To decode a pointer *t where t is a proto or BinaryUnmarshaler, we
+		// need to instantiate a zero value of type t before calling the
+		// appropriate decoding function.
+		return []string{
+			fmt.Sprintf("var %s %s", tmp, g.tset.genTypeString(x.Elem())),
+			g.decode(dec, ref(tmp), x.Elem()),
+			fmt.Sprintf("%s = %s", v, ref(tmp)),
+		}
+	}
+	return []string{g.decode(dec, ref(v), t)}
+}
+
 // args returns a textual representation of the arguments of the provided
 // signature. The first argument must be a context.Context. The returned code
 // names the first argument ctx and all subsequent arguments a0, a1, and so on.
@@ -1618,6 +1979,9 @@ func (g *generator) isMXEncoded(t types.Type) bool {
 		return true
 
 	case *types.Named:
+		if isError(x) {
+			return true
+		}
 		if s, ok := x.Underlying().(*types.Struct); ok {
 			for i := 0; i < s.NumFields(); i++ {
 				f := s.Field(i)
@@ -1864,7 +2228,12 @@ func (g *generator) generateServerStubs(p printFn) {
 		p(`// GetStubFn implements the codegen.Server interface.`)
 		p(`func (s %s) GetStubFn(method string) func(ctx context.Context, args []byte) ([]byte, error) {`, stub)
 		p(`	switch method {`)
+		streaming := false
 		for _, m := range comp.methods() {
+			if isStreaming(m.Type().(*types.Signature)) {
+				streaming = true
+				continue
+			}
 			p(`	case "%s":`, m.Name())
 			p(`		return s.%s`, notExported(m.Name()))
 		}
@@ -1873,9 +2242,35 @@ func (g *generator) generateServerStubs(p printFn) {
 		p(`	}`)
 		p(`}`)
 
+		if streaming {
+			byteStream := g.codegen().qualify("ByteStream")
+			p(``)
+			p(`// Check that %s implements the %s interface.`, stub, g.codegen().qualify("StreamServer"))
+			p(`var _ %s = (*%s)(nil)`, g.codegen().qualify("StreamServer"), stub)
+			p(``)
+			p(`// GetStreamStubFn implements the codegen.StreamServer interface.`)
+			p(`func (s %s) GetStreamStubFn(method string) func(ctx context.Context, args []byte, in %s) ([]byte, %s, error) {`, stub, byteStream, byteStream)
+			p(`	switch method {`)
+			for _, m := range comp.methods() {
+				if !isStreaming(m.Type().(*types.Signature)) {
+					continue
+				}
+				p(`	case "%s":`, m.Name())
+				p(`		return s.%s`, notExported(m.Name()))
+			}
+			p(`	default:`)
+			p(`		return nil`)
+			p(`	}`)
+			p(`}`)
+		}
+
 		// Generate server stub implementation for the methods exported by the component.
 		for _, m := range comp.methods() {
 			mt := m.Type().(*types.Signature)
+			if isStreaming(mt) {
+				g.generateServerStreamStub(p, comp, stub, m)
+				continue
+			}
 
 			p(``)
 			p(`func (s %s) %s(ctx context.Context, args []byte) (res []byte, err error) {`,
@@ -1969,6 +2364,87 @@ func (g *generator) generateServerStubs(p printFn) {
 	}
 }
 
+// generateServerStreamStub generates the server stub implementation of the
+// provided streaming method m of comp.
+func (g *generator) generateServerStreamStub(p printFn, comp *component, stub string, m *types.Func) {
+	mt := m.Type().(*types.Signature)
+	byteStream := g.codegen().qualify("ByteStream")
+
+	p(``)
+	p(`func (s %s) %s(ctx context.Context, args []byte, in %s) (res []byte, out %s, err error) {`,
+		stub, notExported(m.Name()), byteStream, byteStream)
+
+	// Handle errors triggered during execution.
+	p(`	// Catch and return any panics detected during encoding/decoding/rpc.`)
+	p(`	defer func() {`)
+	p(`		if err == nil {`)
+	p(`			err = %s(recover())`, g.codegen().qualify("CatchPanics"))
+	p(`		}`)
+	p(`	}()`)
+
+	if mt.Params().Len() > 1 {
+		p(``)
+		p(`	// Decode arguments.`)
+		if streamArg(mt) < 0 || mt.Params().Len() > 2 {
+			p(`	dec := %s(args)`, g.codegen().qualify("NewDecoder"))
+		}
+	}
+	args := []string{"ctx"}
+	for i := 1; i < mt.Params().Len(); i++ { // Skip initial context.Context
+		at := mt.Params().At(i).Type()
+		arg := fmt.Sprintf("a%d", i-1)
+		if isMXStream(at) {
+			elem := streamElem(at)
+			p(`	%s := %s[%s](in, %s)`, arg, g.codegen().qualify("DecodeStream"), g.tset.genTypeString(elem), g.streamDecoder(elem))
+		} else {
+			p(`	var %s %s`, arg, g.tset.genTypeString(at))
+			for _, stmt := range g.decodeValue("dec", arg, fmt.Sprintf("tmp%d", i), at) {
+				p(`	%s`, stmt)
+			}
+		}
+		if mt.Variadic() && i == mt.Params().Len()-1 {
+			arg += "..."
+		}
+		args = append(args, arg)
+	}
+	argList := strings.Join(args, ", ")
+
+	// Add load, if needed.
+	if comp.routedMethods[m.Name()] {
+		p(`     var r %s`, g.tset.genTypeString(comp.router))
+		p(`	s.addLoad(_hash%s(r.%s(%s)), 1.0)`, exported(comp.intfName()), m.Name(), argList)
+	}
+
+	var results []string
+	for i := 0; i < mt.Results().Len()-1; i++ { // Skip final error
+		results = append(results, fmt.Sprintf("r%d", i))
+	}
+	results = append(results, "appErr")
+
+	p(``)
+	p(`	// Call the local method.`)
+	p(`	%s := s.impl.%s(%s)`, strings.Join(results, ", "), m.Name(), argList)
+
+	p(``)
+	p(`	// Encode the results.`)
+	p(`	enc := %s()`, g.codegen().qualify("NewEncoder"))
+	if returnsStream(mt) {
+		elem := streamElem(mt.Results().At(0).Type())
+		p(`	if appErr == nil && r0 != nil {`)
+		p(`		out = %s[%s](r0, %s)`, g.codegen().qualify("EncodeStream"), g.tset.genTypeString(elem), g.streamEncoder(elem))
+		p(`	} else if r0 != nil {`)
+		p(`		r0.Close()`)
+		p(`	}`)
+	} else {
+		for i := 0; i < mt.Results().Len()-1; i++ { // Skip final error
+			p(`	%s`, g.encode("enc", fmt.Sprintf("r%d", i), mt.Results().At(i).Type()))
+		}
+	}
+	p(`	enc.Error(appErr)`)
+	p(`	return enc.Data(), out, nil`)
+	p(`}`)
+}
+
 // generateReflectStubs generates code for reflect stubs. A reflect stub
 // represents all component method arguments and results as type any and uses a
 // provided caller function to execute the method call.
@@ -2013,6 +2489,240 @@ func (g *generator) generateReflectStubs(p printFn) {
 	}
 }
 
+// generateGateways generates the methods of the HTTP+JSON gateways of the
+// components (see mx.NewGateway). Streaming methods, and methods whose
+// arguments or results cannot be encoded as JSON, are not served.
+func (g *generator) generateGateways(p printFn) {
+	p(``)
+	p(``)
+	p(`// Gateway implementations.`)
+
+	ts := g.tset.genTypeString
+	context := g.tset.importPackage("context", "context")
+	for _, comp := range g.components {
+		p(``)
+		p(`func %s_gateway_methods(impl %s) []%s {`, notExported(comp.intfName()), g.componentRef(comp), g.codegen().qualify("GatewayMethod"))
+		p(`	return []%s{`, g.codegen().qualify("GatewayMethod"))
+		for _, m := range comp.methods() {
+			mt := m.Type().(*types.Signature)
+			if isStreaming(mt) {
+				continue
+			}
+			args, result, err := gatewaySchemas(mt)
+			if err != nil {
+				g.opt.Warn(errorf(g.fileset, m.Pos(), "method %s.%s is not served by the gateway: %v", comp.intfName(), m.Name(), err))
+				continue
+			}
+
+			p(`		{`)
+			p(`			Name: %q,`, m.Name())
+			p(`			Args: %s,`, quoteRaw(args))
+			p(`			Result: %s,`, quoteRaw(result))
+			p(`			Call: func(ctx %s, data []byte) (any, error) {`, context.qualify("Context"))
+			p(`				var args struct {`)
+			callArgs := []string{"ctx"}
+			for i := 1; i < mt.Params().Len(); i++ {
+				param := mt.Params().At(i)
+				p("					A%d %s `json:%q`", i-1, ts(param.Type()), gatewayArgName(param, i-1))
+				arg := fmt.Sprintf("args.A%d", i-1)
+				if mt.Variadic() && i == mt.Params().Len()-1 {
+					arg += "..."
+				}
+				callArgs = append(callArgs, arg)
+			}
+			p(`				}`)
+			p(`				if err := %s(data, &args); err != nil {`, g.codegen().qualify("DecodeGatewayArgs"))
+			p(`					return nil, err`)
+			p(`				}`)
+			call := fmt.Sprintf("impl.%s(%s)", m.Name(), strings.Join(callArgs, ", "))
+			switch n := mt.Results().Len() - 1; n {
+			case 0:
+				p(`				return nil, %s`, call)
+			case 1:
+				p(`				r0, err := %s`, call)
+				p(`				return r0, err`)
+			default:
+				results := make([]string, n)
+				for i := range results {
+					results[i] = fmt.Sprintf("r%d", i)
+				}
+				p(`				%s, err := %s`, strings.Join(results, ", "), call)
+				p(`				return []any{%s}, err`, strings.Join(results, ", "))
+			}
+			p(`			},`)
+			p(`		},`)
+		}
+		p(`	}`)
+		p(`}`)
+	}
+}
+
+// generateMocks generates the mock implementations of the components.
+func (g *generator) generateMocks(p printFn) {
+	p(``)
+	p(``)
+	p(`// Mock implementations.`)
+
+	ts := g.tset.genTypeString
+	mock := g.tset.importPackage(fmt.Sprintf("%s/mxtest/mock", mxPackagePath), "mock")
+	for _, comp := range g.components {
+		if comp.isMain {
+			continue
+		}
+		name := mockName(comp)
+		p(``)
+		p(`// %s is a mock implementation of the %s component, generated by`, name, comp.intfName())
+		p(`// "mx generate -mocks". See the mxtest/mock package for details.`)
+		p(`type %s struct {`, name)
+		p(`	%s`, mock.qualify("Mock"))
+		p(`}`)
+		p(``)
+		p(`var _ %s = (*%s)(nil)`, g.componentRef(comp), name)
+
+		for _, m := range comp.methods() {
+			mt := m.Type().(*types.Signature)
+			var args, callArgs []string
+			for i := 1; i < mt.Params().Len(); i++ {
+				args = append(args, fmt.Sprintf("a%d", i-1))
+				arg := fmt.Sprintf("a%d", i-1)
+				if mt.Variadic() && i == mt.Params().Len()-1 {
+					arg += "..."
+				}
+				callArgs = append(callArgs, arg)
+			}
+			var results []string
+			for i := 0; i < mt.Results().Len()-1; i++ {
+				results = append(results, fmt.Sprintf("%s[%s](res, %d)", mock.qualify("Value"), ts(mt.Results().At(i).Type()), i))
+			}
+			results = append(results, "res.Err()")
+
+			p(``)
+			p(`func (m *%s) %s(%s) (%s) {`, name, m.Name(), g.args(mt), g.returns(mt))
+			p(`	res, ok := m.Mock.Called(%s)`, strings.Join(append([]string{strconv.Quote(m.Name()), strconv.Itoa(mt.Results().Len())}, args...), ", "))
+			p(`	if impl, spied := m.Mock.Spied().(%s); !ok && spied {`, g.componentRef(comp))
+			p(`		return impl.%s(%s)`, m.Name(), strings.Join(append([]string{"ctx"}, callArgs...), ", "))
+			p(`	}`)
+			p(`	return %s`, strings.Join(results, ", "))
+			p(`}`)
+		}
+	}
+}
+
+// mockName returns the name of the mock implementation of the provided
+// component, e.g., MockFoo for component Foo and mockFoo for component foo.
+func mockName(comp *component) string {
+	if comp.intf.Obj().Exported() {
+		return "Mock" + comp.intfName()
+	}
+	return "mock" + exported(comp.intfName())
+}
+
+// gatewaySchemas returns the JSON schemas of the arguments and results of a
+// component method, as served by its gateway. The arguments are encoded as an
+// object with a field per argument. A single result is encoded as is, several
+// results are encoded as an array, and no results are encoded as null.
+func gatewaySchemas(sig *types.Signature) (string, string, error) {
+	schemas := newJSONSchemas()
+	props := map[string]any{}
+	for i := 1; i < sig.Params().Len(); i++ {
+		param := sig.Params().At(i)
+		schema, err := schemas.schema(param.Type())
+		if err != nil {
+			return "", "", fmt.Errorf("argument %d: %w", i, err)
+		}
+		props[gatewayArgName(param, i-1)] = schema
+	}
+	args, err := schemas.document(map[string]any{"type": "object", "properties": props})
+	if err != nil {
+		return "", "", err
+	}
+
+	schemas = newJSONSchemas()
+	var root map[string]any
+	switch n := sig.Results().Len() - 1; n {
+	case 0:
+		root = map[string]any{"type": "null"}
+	case 1:
+		root, err = schemas.schema(sig.Results().At(0).Type())
+		if err != nil {
+			return "", "", fmt.Errorf("result: %w", err)
+		}
+	default:
+		items := make([]any, n)
+		for i := range items {
+			items[i], err = schemas.schema(sig.Results().At(i).Type())
+			if err != nil {
+				return "", "", fmt.Errorf("result %d: %w", i, err)
+			}
+		}
+		root = map[string]any{"type": "array", "prefixItems": items, "items": false}
+	}
+	result, err := schemas.document(root)
+	if err != nil {
+		return "", "", err
+	}
+	return args, result, nil
+}
+
+// gatewayArgName returns the name of the JSON field that holds the provided
+// method argument, the i-th after the context, in a gateway call.
+func gatewayArgName(param *types.Var, i int) string {
+	if name := param.Name(); name != "" && name != "_" {
+		return name
+	}
+	return fmt.Sprintf("arg%d", i)
+}
+
+// quoteRaw returns s as a Go raw string literal, or as an interpreted string
+// literal if s contains a backquote.
+func quoteRaw(s string) string {
+	if strings.Contains(s, "`") {
+		return strconv.Quote(s)
+	}
+	return "`" + s + "`"
+}
+
+// hasGRPCService returns whether the provided component has a gRPC service.
+func (g *generator) hasGRPCService(comp *component) bool {
+	return g.grpc != nil && slices.Contains(g.opt.GRPC, comp.intfName()) && !comp.isMain
+}
+
+// generateGRPCFile writes the .proto file with the gRPC services of the
+// components, if any, and generates the serialized file descriptor used by the
+// grpcbridge package.
+func (g *generator) generateGRPCFile(p printFn) error {
+	if g.grpc == nil {
+		return nil
+	}
+	raw, err := proto.MarshalOptions{Deterministic: true}.Marshal(g.grpc.file)
+	if err != nil {
+		return err
+	}
+	p(``)
+	p(``)
+	p(`// gRPC service implementations.`)
+	p(``)
+	p(`// mx_grpc_file is the serialized FileDescriptorProto of %s.`, grpcFileName)
+	p(`var mx_grpc_file = []byte{`)
+	for len(raw) > 0 {
+		n := min(16, len(raw))
+		line := make([]string, n)
+		for i, b := range raw[:n] {
+			line[i] = fmt.Sprintf("0x%02x,", b)
+		}
+		p(`	%s`, strings.Join(line, " "))
+		raw = raw[n:]
+	}
+	p(`}`)
+
+	dst := files.NewWriter(filepath.Join(g.pkgDir(), grpcFileName))
+	defer dst.Cleanup()
+	if _, err := io.WriteString(dst, g.grpc.text()); err != nil {
+		return err
+	}
+	return dst.Close()
+}
+
 // generateAutoMarshalMethods generates MXMarshal and MXUnmarshal methods
 // for any types that declares itself as mx.AutoMarshal.
 func (g *generator) generateAutoMarshalMethods(p printFn) {
@@ -2203,6 +2913,7 @@ func (g *generator) encode(stub, e string, t types.Type) string {
 	// enc(stub, e: []t) = mx_enc_[[]t](&stub, e)
 	// enc(stub, e: map[k]v) = mx_enc_[map[k]v](&stub, e)
 	// enc(stub, e: struct{...}) = mx_enc_[struct{...}](&stub, &e)
+	// enc(stub, e: error) = stub.Error(e)
 	// enc(stub, e: type t u) = stub.EncodeProto(&e)           // t implements proto.Message
 	// enc(stub, e: type t u) = (e).MXMarshal(stub)         // t implements AutoMarshal
 	// enc(stub, e: type t u) = stub.EncodeBinaryMarshaler(&e) // t implements BinaryMarshaler
@@ -2238,6 +2949,9 @@ func (g *generator) encode(stub, e string, t types.Type) string {
 		return fmt.Sprintf("%s(%s, %s)", f(x), stub, ref(e))
 
 	case *types.Named:
+		if isError(x) {
+			return fmt.Sprintf("%s.Error(%s)", stub, e)
+		}
 		if g.tset.isProto(x) {
 			return fmt.Sprintf("%s.EncodeProto(%s)", stub, ref(e))
 		}
@@ -2276,6 +2990,7 @@ func (g *generator) decode(stub, v string, t types.Type) string {
 	// dec(stub, v: []t) = v := *v = mx_dec_[[]t](stub)
 	// dec(stub, v: map[k]v) = *v := mx_dec_[map[k]v](stub)
 	// dec(stub, v: struct{...}) = mx_dec_[struct{...}](stub, &v)
+	// dec(stub, v: error) = *v = stub.Error()
 	// dec(stub, v: type t u) = stub.DecodeProto(v)             // t implements proto.Message
 	// dec(stub, v: type t u) = (v).MXUnmarshal(stub)        // t implements AutoMarshal
 	// dec(stub, v: type t u) = stub.DecodeBinaryUnmarshaler(v) // t implements BinaryUnmarshaler
@@ -2311,6 +3026,9 @@ func (g *generator) decode(stub, v string, t types.Type) string {
 		return fmt.Sprintf("%s(%s, %s)", f(x), stub, v)
 
 	case *types.Named:
+		if isError(x) {
+			return fmt.Sprintf("%s = %s.Error()", deref(v), stub)
+		}
 		if g.tset.isProto(x) {
 			return fmt.Sprintf("%s.DecodeProto(%s)", stub, v)
 		}
@@ -2331,6 +3049,16 @@ func (g *generator) decode(stub, v string, t types.Type) string {
 	}
 }
 
+// encodedType returns the type that is encoded when passing a component
+// method argument or result of type t: the type of the values of a stream, or
+// t itself otherwise.
+func encodedType(t types.Type) types.Type {
+	if isMXStream(t) {
+		return streamElem(t)
+	}
+	return t
+}
+
 // generateEncDecMethods generates all necessary encoding and decoding methods.
 func (g *generator) generateEncDecMethods(p printFn) {
 	printedHeader := false
@@ -2347,12 +3075,12 @@ func (g *generator) generateEncDecMethods(p printFn) {
 
 			// Generate for argument types, skipping the context.Context.
 			for j := 1; j < sig.Params().Len(); j++ {
-				g.generateEncDecMethodsFor(printer, sig.Params().At(j).Type())
+				g.generateEncDecMethodsFor(printer, encodedType(sig.Params().At(j).Type()))
 			}
 
 			// Generate for result types, skipping the error.
 			for j := 0; j < sig.Results().Len()-1; j++ {
-				g.generateEncDecMethodsFor(printer, sig.Results().At(j).Type())
+				g.generateEncDecMethodsFor(printer, encodedType(sig.Results().At(j).Type()))
 			}
 		}
 	}
@@ -2493,12 +3221,12 @@ func (g *generator) generateEncDecMethodsFor(p printFn, t types.Type) {
 		panic(fmt.Sprintf("generateEncDecFor: unexpected type: %v", t))
 
 	case *types.Named:
-		if g.tset.isProto(x) || g.tset.automarshals.At(x) != nil || g.tset.implementsAutoMarshal(x) || g.tset.hasMarshalBinary(x) {
-			// Types implementing proto.Marshal, mx.AutoMarshal, or
+		if isError(x) || g.tset.isProto(x) || g.tset.automarshals.At(x) != nil || g.tset.implementsAutoMarshal(x) || g.tset.hasMarshalBinary(x) {
+			// Errors and types implementing proto.Marshal, mx.AutoMarshal, or
 			// encoding.BinaryMarshaler and encoding.BinaryUnmarshaler don't
 			// need encoding or decoding methods. Instead, we call methods
 			// directly on a codegen.Encoder or codegen.Decoder (e.g.,
-			// enc.EncodeProto(x), dec.DecodeBinaryUnmarshaler(x)).
+			// enc.Error(x), dec.DecodeBinaryUnmarshaler(x)).
 			return
 		}
 		// If a named type t is not a struct, e.g. `type t int`, then we
@@ -2660,6 +3388,10 @@ func uniqueName(t types.Type) string {
 		return fmt.Sprintf("map[%s]%s", keyName, valName)
 
 	case *types.Named:
+		if x.Obj().Pkg() == nil {
+			// This is a predeclared type (i.e. error).
+			return fmt.Sprintf("Named(%s)", x.Obj().Name())
+		}
 		n := x.TypeArgs().Len()
 		if n == 0 {
 			// This is a plain type.
@@ -64,7 +64,7 @@ replace github.com/sh3lk/mx => %s
 // If "mx generate" succeeds, the produced mx_gen.go file is written in
 // the provided directory with name ${filename}_mx_gen.go.
 func runGenerator(t *testing.T, directory, filename, contents string, subdirs []string,
-	buildTags []string) (string, error) {
+	buildTags []string, opt Options) (string, error) {
 	// runGenerator creates a temporary directory, copies the file and all
 	// subdirs into it, writes a go.mod file, runs "go mod tidy", and finally
 	// runs "mx generate".
@@ -103,10 +103,8 @@ func runGenerator(t *testing.T, directory, filename, contents string, subdirs []
 	}
 
 	// Run "mx generate".
-	opt := Options{
-		Warn:      func(err error) { t.Log(err) },
-		BuildTags: "ignoreMXGen" + "," + strings.Join(buildTags, ","),
-	}
+	opt.Warn = func(err error) { t.Log(err) }
+	opt.BuildTags = "ignoreMXGen" + "," + strings.Join(buildTags, ",")
 	if err := Generate(tmp, []string{tmp}, opt); err != nil {
 		return "", err
 	}
@@ -172,6 +170,13 @@ func printOutput(t *testing.T, output []byte) {
 // This test runs "mx generate" on the file and checks that every expected
 // string appears in the generated mx_gen.go file and that every unexpected
 // string doesn't.
+//
+// The header may also contain an OPTIONS block that enables the optional
+// outputs of "mx generate":
+//
+//	// OPTIONS
+//	// mocks
+//	// gateway
 func TestGenerator(t *testing.T) {
 	const dir = "testdata"
 	files, err := os.ReadDir(dir)
@@ -194,11 +199,13 @@ func TestGenerator(t *testing.T) {
 			}
 			contents := string(bits)
 
-			// Parse the "EXPECTED" and "UNEXPECTED" blocks.
+			// Parse the "EXPECTED", "UNEXPECTED", and "OPTIONS" blocks.
 			var expected []string
 			var unexpected []string
+			var opt Options
 			parsingExpected := false
 			parsingUnexpected := false
+			parsingOptions := false
 			scanner := bufio.NewScanner(bytes.NewBuffer(bits))
 			for scanner.Scan() {
 				line := scanner.Text()
@@ -206,14 +213,26 @@ func TestGenerator(t *testing.T) {
 				case !strings.HasPrefix(line, "//"):
 					parsingExpected = false
 					parsingUnexpected = false
+					parsingOptions = false
 				case parsingExpected:
 					expected = append(expected, strings.TrimPrefix(line, "// "))
 				case parsingUnexpected:
 					unexpected = append(unexpected, strings.TrimPrefix(line, "// "))
+				case parsingOptions:
+					switch option := strings.TrimPrefix(line, "// "); option {
+					case "mocks":
+						opt.Mocks = true
+					case "gateway":
+						opt.Gateway = true
+					default:
+						t.Fatalf("unknown option %q in %q", option, filename)
+					}
 				case line == "// EXPECTED":
 					parsingExpected = true
 				case line == "// UNEXPECTED":
 					parsingUnexpected = true
+				case line == "// OPTIONS":
+					parsingOptions = true
 				}
 			}
 			if err := scanner.Err(); err != nil {
@@ -221,7 +240,7 @@ func TestGenerator(t *testing.T) {
 			}
 
 			// Run "mx generate".
-			output, err := runGenerator(t, dir, filename, contents, []string{"sub1", "sub2"}, nil)
+			output, err := runGenerator(t, dir, filename, contents, []string{"sub1", "sub2"}, nil, opt)
 			if err != nil {
 				t.Fatalf("error running generator: %v", err)
 			}
@@ -264,7 +283,7 @@ func TestGeneratorBuildWithTags(t *testing.T) {
 			}
 			contents := string(bits)
 			// Run "mx generate".
-			output, err := runGenerator(t, dir, filename, contents, nil, []string{"good"})
+			output, err := runGenerator(t, dir, filename, contents, nil, []string{"good"}, Options{})
 
 			if filename == "good.go" {
 				// Verify that the error is nil and the mx_gen.go contains generated code for the good service.
@@ -330,7 +349,7 @@ func TestGeneratorErrors(t *testing.T) {
 			}
 
 			// Run "mx generate".
-			output, err := runGenerator(t, dir, filename, contents, nil, nil)
+			output, err := runGenerator(t, dir, filename, contents, nil, nil, Options{})
 			errfile := strings.TrimSuffix(filename, ".go") + "_error.txt"
 			if err == nil {
 				os.Remove(filepath.Join(dir, errfile))
@@ -503,7 +522,7 @@ func TestExampleVersion(t *testing.T) {
 	got := fmt.Sprintf("%x", h.Sum(nil))
 
 	// If mx_gen.go has changed, the codegen version may need updating.
-	const want = "3e626ef7daa6ee0f51bc0aa8a792c5aed5137e53e127b04c41cc47053f437dc3"
+	const want = "39a01cfc5cb41b9b0250424e7bae6fd40e9ea5cf8efa1a8c041ce67ac3fd564b"
 	if got != want {
 		t.Fatalf(`Unexpected SHA-256 hash of examples/mx_gen.go: got %s, want %s. If this change is meaningful, REMEMBER TO UPDATE THE CODEGEN VERSION in runtime/version/version.go.`, got, want)
 	}
@@ -0,0 +1,598 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generate
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// The gRPC service of a component, generated by "mx generate -grpc", has a
+// method for every non-streaming method of the component. The request of a
+// method is a message with a field per argument, named like the argument, and
+// numbered in order, starting at 1. The reply is a message with a field per
+// result, other than the error, named r0, r1, etc. A method that takes or
+// returns a single proto.Message uses the message as its request or reply.
+//
+// Go types map to protobuf types as follows:
+//
+//   - bool, string, and the integer and floating point types map to the
+//     corresponding scalar types.
+//   - []byte and [N]byte map to bytes.
+//   - Slices and arrays map to repeated fields, and maps map to map fields.
+//   - Named structs, and pointers to them, map to messages with a field per
+//     exported field, named like the Go field. Unset message fields decode
+//     into nil pointers.
+//   - time.Time maps to google.protobuf.Timestamp.
+//   - Types that implement proto.Message map to their own messages.
+//
+// The bridge in the grpcbridge package relies on these conventions to convert
+// between protobuf messages and Go values.
+
+// grpcFileName is the name of the generated .proto file.
+const grpcFileName = "mx_gen.proto"
+
+// grpcFile builds the .proto file with the gRPC services of the components of
+// a package.
+type grpcFile struct {
+	tset    *typeSet
+	fset    *token.FileSet
+	file    *descriptorpb.FileDescriptorProto
+	structs map[*types.TypeName]string // message names of the structs, by type
+	names   map[string]bool            // names of the messages in the file
+}
+
+// newGRPCFile returns a grpcFile, without services, for the package in tset.
+func newGRPCFile(tset *typeSet, fset *token.FileSet) *grpcFile {
+	path := tset.pkg.PkgPath
+	return &grpcFile{
+		tset: tset,
+		fset: fset,
+		file: &descriptorpb.FileDescriptorProto{
+			Name:    proto.String(path + "/" + grpcFileName),
+			Package: proto.String(protoPackage(path)),
+			Syntax:  proto.String("proto3"),
+			Options: &descriptorpb.FileOptions{GoPackage: proto.String(path)},
+		},
+		structs: map[*types.TypeName]string{},
+		names:   map[string]bool{},
+	}
+}
+
+// protoPackage returns the name of the protobuf package for the Go package
+// with the provided path, e.g., github_com.foo.bar for github.com/foo/bar.
+func protoPackage(path string) string {
+	parts := strings.Split(path, "/")
+	for i, part := range parts {
+		part = strings.Map(func(r rune) rune {
+			if r == '_' || ('a' <= r && r <= 'z') || ('A' <= r && r <= 'Z') || ('0' <= r && r <= '9') {
+				return r
+			}
+			return '_'
+		}, part)
+		if part == "" || ('0' <= part[0] && part[0] <= '9') {
+			part = "_" + part
+		}
+		parts[i] = part
+	}
+	return strings.Join(parts, ".")
+}
+
+// addService adds a service for the component with the provided interface and
+// methods. Methods that cannot be served are skipped, and reported to warn.
+func (f *grpcFile) addService(intf *types.Named, methods []*types.Func, warn func(error)) {
+	name := intf.Obj().Name()
+	service := &descriptorpb.ServiceDescriptorProto{Name: proto.String(name)}
+	for _, m := range methods {
+		sig := m.Type().(*types.Signature)
+		if isStreaming(sig) {
+			continue
+		}
+
+		// Roll back the messages added for a method that cannot be served.
+		messages := len(f.file.MessageType)
+		deps := len(f.file.Dependency)
+		structs := make(map[*types.TypeName]string, len(f.structs))
+		for k, v := range f.structs {
+			structs[k] = v
+		}
+		md, err := f.method(name, m.Name(), sig)
+		if err != nil {
+			for _, msg := range f.file.MessageType[messages:] {
+				delete(f.names, msg.GetName())
+			}
+			f.file.MessageType = f.file.MessageType[:messages]
+			f.file.Dependency = f.file.Dependency[:deps]
+			f.structs = structs
+			warn(errorf(f.fset, m.Pos(), "method %s.%s is not served by the gRPC service: %v", name, m.Name(), err))
+			continue
+		}
+		service.Method = append(service.Method, md)
+	}
+	f.file.Service = append(f.file.Service, service)
+}
+
+// method returns the descriptor of the provided method of the named service,
+// adding its request and reply messages to the file.
+func (f *grpcFile) method(service, name string, sig *types.Signature) (*descriptorpb.MethodDescriptorProto, error) {
+	var args, results []*types.Var
+	for i := 1; i < sig.Params().Len(); i++ {
+		args = append(args, sig.Params().At(i))
+	}
+	for i := 0; i < sig.Results().Len()-1; i++ {
+		results = append(results, sig.Results().At(i))
+	}
+
+	message := func(suffix string, vars []*types.Var, fieldName func(int, *types.Var) string) (string, error) {
+		if len(vars) == 1 && f.isProto(vars[0].Type()) {
+			return f.protoMessage(vars[0].Type())
+		}
+		msg := &descriptorpb.DescriptorProto{Name: proto.String(service + name + suffix)}
+		for i, v := range vars {
+			field, err := f.field(msg, fieldName(i, v), i+1, v.Type())
+			if err != nil {
+				return "", err
+			}
+			msg.Field = append(msg.Field, field)
+		}
+		if err := f.addMessage(msg); err != nil {
+			return "", err
+		}
+		return "." + f.file.GetPackage() + "." + msg.GetName(), nil
+	}
+	input, err := message("Request", args, func(i int, v *types.Var) string {
+		if name := v.Name(); name != "" && name != "_" {
+			return name
+		}
+		return fmt.Sprintf("arg%d", i)
+	})
+	if err != nil {
+		return nil, err
+	}
+	output, err := message("Reply", results, func(i int, _ *types.Var) string {
+		return fmt.Sprintf("r%d", i)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &descriptorpb.MethodDescriptorProto{
+		Name:       proto.String(name),
+		InputType:  proto.String(input),
+		OutputType: proto.String(output),
+	}, nil
+}
+
+// addMessage adds the provided top-level message to the file.
+func (f *grpcFile) addMessage(msg *descriptorpb.DescriptorProto) error {
+	if f.names[msg.GetName()] {
+		return fmt.Errorf("duplicate message %s", msg.GetName())
+	}
+	f.names[msg.GetName()] = true
+	f.file.MessageType = append(f.file.MessageType, msg)
+	return nil
+}
+
+// field returns the descriptor of a field of type t, adding the messages it
+// uses to the file. Map fields add their entry message to msg.
+func (f *grpcFile) field(msg *descriptorpb.DescriptorProto, name string, number int, t types.Type) (*descriptorpb.FieldDescriptorProto, error) {
+	field := &descriptorpb.FieldDescriptorProto{
+		Name:     proto.String(name),
+		Number:   proto.Int32(int32(number)),
+		Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+		JsonName: proto.String(name),
+	}
+	var elem types.Type
+	switch x := t.Underlying().(type) {
+	case *types.Slice:
+		if !isByteSlice(x) {
+			elem = x.Elem()
+		}
+	case *types.Array:
+		if b, ok := x.Elem().(*types.Basic); !ok || b.Kind() != types.Byte {
+			elem = x.Elem()
+		}
+	case *types.Map:
+		if k, ok := x.Key().Underlying().(*types.Basic); !ok || k.Info()&(types.IsBoolean|types.IsInteger|types.IsString) == 0 {
+			return nil, fmt.Errorf("map key type %v has no protobuf equivalent", x.Key())
+		}
+		entry := &descriptorpb.DescriptorProto{
+			Name:    proto.String(exported(name) + "Entry"),
+			Options: &descriptorpb.MessageOptions{MapEntry: proto.Bool(true)},
+		}
+		k, err := f.singular("key", 1, x.Key())
+		if err != nil {
+			return nil, err
+		}
+		v, err := f.singular("value", 2, x.Elem())
+		if err != nil {
+			return nil, err
+		}
+		entry.Field = []*descriptorpb.FieldDescriptorProto{k, v}
+		msg.NestedType = append(msg.NestedType, entry)
+		field.Label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+		field.Type = descriptorpb.FieldDescriptorProto_TYPE_MESSAGE.Enum()
+		field.TypeName = proto.String("." + f.file.GetPackage() + "." + msg.GetName() + "." + entry.GetName())
+		return field, nil
+	}
+	if elem == nil {
+		return f.singular(name, number, t)
+	}
+	field, err := f.singular(name, number, elem)
+	if err != nil {
+		return nil, err
+	}
+	field.Label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+	return field, nil
+}
+
+// singular returns the descriptor of a non-repeated field of type t.
+func (f *grpcFile) singular(name string, number int, t types.Type) (*descriptorpb.FieldDescriptorProto, error) {
+	field := &descriptorpb.FieldDescriptorProto{
+		Name:     proto.String(name),
+		Number:   proto.Int32(int32(number)),
+		Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+		JsonName: proto.String(name),
+	}
+	typ, typeName, err := f.scalar(t)
+	if err != nil {
+		return nil, err
+	}
+	field.Type = typ.Enum()
+	if typeName != "" {
+		field.TypeName = proto.String(typeName)
+	}
+	return field, nil
+}
+
+// scalar returns the protobuf type of a non-repeated value of type t, and the
+// full name of its message, if any.
+func (f *grpcFile) scalar(t types.Type) (descriptorpb.FieldDescriptorProto_Type, string, error) {
+	if f.isProto(t) {
+		name, err := f.protoMessage(t)
+		return descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, name, err
+	}
+	if n, ok := types.Unalias(t).(*types.Named); ok {
+		if obj := n.Obj(); obj.Pkg() != nil && obj.Pkg().Path() == "time" && obj.Name() == "Time" {
+			f.addDependency("google/protobuf/timestamp.proto")
+			return descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, ".google.protobuf.Timestamp", nil
+		}
+	}
+
+	switch x := t.Underlying().(type) {
+	case *types.Basic:
+		switch x.Kind() {
+		case types.Bool:
+			return descriptorpb.FieldDescriptorProto_TYPE_BOOL, "", nil
+		case types.Int, types.Int64:
+			return descriptorpb.FieldDescriptorProto_TYPE_INT64, "", nil
+		case types.Int8, types.Int16, types.Int32:
+			return descriptorpb.FieldDescriptorProto_TYPE_INT32, "", nil
+		case types.Uint, types.Uint64, types.Uintptr:
+			return descriptorpb.FieldDescriptorProto_TYPE_UINT64, "", nil
+		case types.Uint8, types.Uint16, types.Uint32:
+			return descriptorpb.FieldDescriptorProto_TYPE_UINT32, "", nil
+		case types.Float32:
+			return descriptorpb.FieldDescriptorProto_TYPE_FLOAT, "", nil
+		case types.Float64:
+			return descriptorpb.FieldDescriptorProto_TYPE_DOUBLE, "", nil
+		case types.String:
+			return descriptorpb.FieldDescriptorProto_TYPE_STRING, "", nil
+		}
+
+	case *types.Slice:
+		if isByteSlice(x) {
+			return descriptorpb.FieldDescriptorProto_TYPE_BYTES, "", nil
+		}
+
+	case *types.Array:
+		if b, ok := x.Elem().(*types.Basic); ok && b.Kind() == types.Byte {
+			return descriptorpb.FieldDescriptorProto_TYPE_BYTES, "", nil
+		}
+
+	case *types.Pointer:
+		if n, ok := x.Elem().(*types.Named); ok {
+			if _, ok := n.Underlying().(*types.Struct); ok {
+				name, err := f.structMessage(n)
+				return descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, name, err
+			}
+		}
+
+	case *types.Struct:
+		if n, ok := types.Unalias(t).(*types.Named); ok {
+			name, err := f.structMessage(n)
+			return descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, name, err
+		}
+	}
+	return 0, "", fmt.Errorf("type %v has no protobuf equivalent", t)
+}
+
+// structMessage returns the full name of the message for the provided named
+// struct type, adding the message to the file if needed.
+func (f *grpcFile) structMessage(n *types.Named) (string, error) {
+	if n.TypeArgs().Len() > 0 {
+		return "", fmt.Errorf("generic type %v has no protobuf equivalent", n)
+	}
+	if name, ok := f.structs[n.Obj()]; ok {
+		return "." + f.file.GetPackage() + "." + name, nil
+	}
+	name := n.Obj().Name()
+	if pkg := n.Obj().Pkg(); pkg != nil && pkg != f.tset.pkg.Types {
+		name = exported(pkg.Name()) + name
+	}
+	f.structs[n.Obj()] = name // added before the fields, for recursive types
+
+	msg := &descriptorpb.DescriptorProto{Name: proto.String(name)}
+	s := n.Underlying().(*types.Struct)
+	for i := 0; i < s.NumFields(); i++ {
+		fv := s.Field(i)
+		if !fv.Exported() || isMXAutoMarshal(fv.Type()) {
+			continue
+		}
+		field, err := f.field(msg, fv.Name(), len(msg.Field)+1, fv.Type())
+		if err != nil {
+			return "", fmt.Errorf("field %s.%s: %w", n.Obj().Name(), fv.Name(), err)
+		}
+		msg.Field = append(msg.Field, field)
+	}
+	if err := f.addMessage(msg); err != nil {
+		return "", err
+	}
+	return "." + f.file.GetPackage() + "." + name, nil
+}
+
+// isProto returns whether t is a pointer to a type generated by protoc-gen-go.
+func (f *grpcFile) isProto(t types.Type) bool {
+	p, ok := t.(*types.Pointer)
+	return ok && f.tset.isProto(p.Elem())
+}
+
+// protoMessage returns the full name of the message of the provided
+// proto.Message type, adding the file that defines it to the dependencies of
+// the file. The message is found in the descriptor embedded in the .pb.go file
+// that declares the type.
+func (f *grpcFile) protoMessage(t types.Type) (string, error) {
+	if p, ok := t.(*types.Pointer); ok {
+		t = p.Elem()
+	}
+	n, ok := types.Unalias(t).(*types.Named)
+	if !ok {
+		return "", fmt.Errorf("unnamed proto.Message %v", t)
+	}
+	filename := f.fset.Position(n.Obj().Pos()).Filename
+	if filename == "" {
+		return "", fmt.Errorf("cannot find the file that declares %v", t)
+	}
+	fd, err := readProtoDescriptor(filename)
+	if err != nil {
+		return "", fmt.Errorf("%v: %w", t, err)
+	}
+	var find func(prefix, goPrefix string, msgs []*descriptorpb.DescriptorProto) string
+	find = func(prefix, goPrefix string, msgs []*descriptorpb.DescriptorProto) string {
+		for _, msg := range msgs {
+			name, goName := prefix+"."+msg.GetName(), goPrefix+goCamelCase(msg.GetName())
+			if goName == n.Obj().Name() {
+				return name
+			}
+			if name := find(name, goName+"_", msg.NestedType); name != "" {
+				return name
+			}
+		}
+		return ""
+	}
+	prefix := ""
+	if pkg := fd.GetPackage(); pkg != "" {
+		prefix = "." + pkg
+	}
+	name := find(prefix, "", fd.MessageType)
+	if name == "" {
+		return "", fmt.Errorf("message for %v not found in %s", t, fd.GetName())
+	}
+	f.addDependency(fd.GetName())
+	return name, nil
+}
+
+// addDependency adds the named file to the dependencies of the file.
+func (f *grpcFile) addDependency(name string) {
+	for _, dep := range f.file.Dependency {
+		if dep == name {
+			return
+		}
+	}
+	f.file.Dependency = append(f.file.Dependency, name)
+}
+
+// readProtoDescriptor returns the file descriptor embedded in the provided
+// .pb.go file, generated by protoc-gen-go.
+func readProtoDescriptor(filename string) (*descriptorpb.FileDescriptorProto, error) {
+	src, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	file, err := parser.ParseFile(token.NewFileSet(), filename, src, 0)
+	if err != nil {
+		return nil, err
+	}
+	for _, decl := range file.Decls {
+		gd, ok := decl.(*ast.GenDecl)
+		if !ok || (gd.Tok != token.VAR && gd.Tok != token.CONST) {
+			continue
+		}
+		for _, spec := range gd.Specs {
+			vs, ok := spec.(*ast.ValueSpec)
+			if !ok || len(vs.Names) != 1 || len(vs.Values) != 1 || !strings.HasSuffix(vs.Names[0].Name, "_rawDesc") {
+				continue
+			}
+			raw, err := rawDescriptor(vs.Values[0])
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", vs.Names[0].Name, err)
+			}
+			fd := &descriptorpb.FileDescriptorProto{}
+			if err := proto.Unmarshal(raw, fd); err != nil {
+				return nil, err
+			}
+			return fd, nil
+		}
+	}
+	return nil, fmt.Errorf("%s: no protobuf file descriptor", filename)
+}
+
+// rawDescriptor evaluates the expression that holds a raw file descriptor in a
+// .pb.go file: either a []byte literal or a concatenation of string literals.
+func rawDescriptor(e ast.Expr) ([]byte, error) {
+	switch x := e.(type) {
+	case *ast.CompositeLit:
+		raw := make([]byte, 0, len(x.Elts))
+		for _, elt := range x.Elts {
+			lit, ok := elt.(*ast.BasicLit)
+			if !ok || lit.Kind != token.INT {
+				return nil, fmt.Errorf("unexpected element %T", elt)
+			}
+			b, err := strconv.ParseUint(lit.Value, 0, 8)
+			if err != nil {
+				return nil, err
+			}
+			raw = append(raw, byte(b))
+		}
+		return raw, nil
+	case *ast.BinaryExpr:
+		if x.Op != token.ADD {
+			return nil, fmt.Errorf("unexpected operator %v", x.Op)
+		}
+		lhs, err := rawDescriptor(x.X)
+		if err != nil {
+			return nil, err
+		}
+		rhs, err := rawDescriptor(x.Y)
+		if err != nil {
+			return nil, err
+		}
+		return append(lhs, rhs...), nil
+	case *ast.BasicLit:
+		if x.Kind != token.STRING {
+			return nil, fmt.Errorf("unexpected literal %s", x.Value)
+		}
+		s, err := strconv.Unquote(x.Value)
+		return []byte(s), err
+	case *ast.ParenExpr:
+		return rawDescriptor(x.X)
+	}
+	return nil, fmt.Errorf("unexpected expression %T", e)
+}
+
+// goCamelCase returns the Go name protoc-gen-go generates for the provided
+// protobuf name.
+func goCamelCase(s string) string {
+	isLower := func(c byte) bool { return 'a' <= c && c <= 'z' }
+	var b []byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '.' && i+1 < len(s) && isLower(s[i+1]):
+			// Skip over '.' in ".{{lowercase}}".
+		case c == '.':
+			b = append(b, '_')
+		case c == '_' && (i == 0 || s[i-1] == '.'):
+			// Convert initial '_' to ensure we start with a capital letter.
+			b = append(b, 'X')
+		case c == '_' && i+1 < len(s) && isLower(s[i+1]):
+			// Skip over '_' in "_{{lowercase}}".
+		case '0' <= c && c <= '9':
+			b = append(b, c)
+		default:
+			// Assume we have a letter now; if not, it's a bogus identifier.
+			if isLower(c) {
+				c -= 'a' - 'A'
+			}
+			b = append(b, c)
+			for ; i+1 < len(s) && isLower(s[i+1]); i++ {
+				b = append(b, s[i+1])
+			}
+		}
+	}
+	return string(b)
+}
+
+// text returns the contents of the .proto file.
+func (f *grpcFile) text() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "// Code generated by \"mx generate\". DO NOT EDIT.\n\n")
+	fmt.Fprintf(&b, "syntax = \"proto3\";\n\n")
+	fmt.Fprintf(&b, "package %s;\n\n", f.file.GetPackage())
+	if deps := f.file.Dependency; len(deps) > 0 {
+		sorted := append([]string(nil), deps...)
+		sort.Strings(sorted)
+		for _, dep := range sorted {
+			fmt.Fprintf(&b, "import %q;\n", dep)
+		}
+		b.WriteString("\n")
+	}
+	fmt.Fprintf(&b, "option go_package = %q;\n", f.file.GetOptions().GetGoPackage())
+
+	for _, s := range f.file.Service {
+		fmt.Fprintf(&b, "\nservice %s {\n", s.GetName())
+		for _, m := range s.Method {
+			fmt.Fprintf(&b, "  rpc %s(%s) returns (%s);\n", m.GetName(), f.typeName(m.GetInputType()), f.typeName(m.GetOutputType()))
+		}
+		b.WriteString("}\n")
+	}
+	for _, msg := range f.file.MessageType {
+		fmt.Fprintf(&b, "\nmessage %s {\n", msg.GetName())
+		entries := map[string]*descriptorpb.DescriptorProto{}
+		for _, nested := range msg.NestedType {
+			entries["."+f.file.GetPackage()+"."+msg.GetName()+"."+nested.GetName()] = nested
+		}
+		for _, field := range msg.Field {
+			if entry, ok := entries[field.GetTypeName()]; ok {
+				fmt.Fprintf(&b, "  map<%s, %s> %s = %d;\n", f.fieldType(entry.Field[0]), f.fieldType(entry.Field[1]), field.GetName(), field.GetNumber())
+				continue
+			}
+			label := ""
+			if field.GetLabel() == descriptorpb.FieldDescriptorProto_LABEL_REPEATED {
+				label = "repeated "
+			}
+			fmt.Fprintf(&b, "  %s%s %s = %d;\n", label, f.fieldType(field), field.GetName(), field.GetNumber())
+		}
+		b.WriteString("}\n")
+	}
+	return b.String()
+}
+
+// fieldType returns the type of the provided field, as written in a .proto
+// file.
+func (f *grpcFile) fieldType(field *descriptorpb.FieldDescriptorProto) string {
+	if field.GetType() == descriptorpb.FieldDescriptorProto_TYPE_MESSAGE {
+		return f.typeName(field.GetTypeName())
+	}
+	return strings.ToLower(strings.TrimPrefix(field.GetType().String(), "TYPE_"))
+}
+
+// typeName returns the provided fully qualified message name, as written in
+// the file: relative to the package of the file if the message is in it.
+func (f *grpcFile) typeName(name string) string {
+	if rest, ok := strings.CutPrefix(name, "."+f.file.GetPackage()+"."); ok {
+		return rest
+	}
+	return strings.TrimPrefix(name, ".")
+}
@@ -0,0 +1,113 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generate
+
+import (
+	"go/types"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestGRPCFile(t *testing.T) {
+	tset, target := compile(t, `
+import (
+	"context"
+	"time"
+)
+
+type Point struct {
+	X, Y int
+	Tags []string
+	hidden bool
+}
+
+type target interface {
+	Add(ctx context.Context, x, y int) (int, error)
+	Sum(ctx context.Context, points []Point, scale map[string]float64) (*Point, error)
+	Later(context.Context, time.Time, int32) (time.Time, bool, error)
+	Bad(ctx context.Context, c complex64) error
+}
+`)
+	named := target.(*types.Named)
+	intf := named.Underlying().(*types.Interface)
+	var methods []*types.Func
+	for i := 0; i < intf.NumMethods(); i++ {
+		methods = append(methods, intf.Method(i))
+	}
+	var warnings []string
+	f := newGRPCFile(tset, tset.pkg.Fset)
+	f.addService(named, methods, func(err error) {
+		warnings = append(warnings, err.Error())
+	})
+
+	const want = `// Code generated by "mx generate". DO NOT EDIT.
+
+syntax = "proto3";
+
+package foo;
+
+import "google/protobuf/timestamp.proto";
+
+option go_package = "foo";
+
+service target {
+  rpc Add(targetAddRequest) returns (targetAddReply);
+  rpc Later(targetLaterRequest) returns (targetLaterReply);
+  rpc Sum(targetSumRequest) returns (targetSumReply);
+}
+
+message targetAddRequest {
+  int64 x = 1;
+  int64 y = 2;
+}
+
+message targetAddReply {
+  int64 r0 = 1;
+}
+
+message targetLaterRequest {
+  google.protobuf.Timestamp arg0 = 1;
+  int32 arg1 = 2;
+}
+
+message targetLaterReply {
+  google.protobuf.Timestamp r0 = 1;
+  bool r1 = 2;
+}
+
+message Point {
+  int64 X = 1;
+  int64 Y = 2;
+  repeated string Tags = 3;
+}
+
+message targetSumRequest {
+  repeated Point points = 1;
+  map<string, double> scale = 2;
+}
+
+message targetSumReply {
+  Point r0 = 1;
+}
+`
+	if diff := cmp.Diff(want, f.text()); diff != "" {
+		t.Errorf("text (-want +got):\n%s", diff)
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "method target.Bad is not served") {
+		t.Errorf("warnings: got %q, want a warning for target.Bad", warnings)
+	}
+}
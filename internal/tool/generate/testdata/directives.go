@@ -0,0 +1,48 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// EXPECTED
+// []int{0, 3}
+// []codegen.MethodDirectives{{Timeout: 2 * time.Second, Idempotent: true}, {Cache: 1500 * time.Millisecond}, {Deprecated: true}, {}},
+
+// Package foo contains a component with //mx: directives on its methods.
+package foo
+
+import (
+	"context"
+
+	"github.com/sh3lk/mx"
+)
+
+type foo interface {
+	// A returns an int.
+	//mx:timeout=2s
+	//mx:idempotent
+	//mx:hedge
+	A(context.Context) (int, error)
+	//mx:cache=1.5s
+	B(context.Context, string, int) (string, error)
+	//mx:deprecated
+	C(context.Context) error
+	D(context.Context) (string, error)
+}
+
+type impl struct{ mx.Implements[foo] }
+
+func (l *impl) A(context.Context) (int, error)                 { return 0, nil }
+func (l *impl) B(context.Context, string, int) (string, error) { return "", nil }
+func (l *impl) C(context.Context) error                        { return nil }
+func (l *impl) D(context.Context) (string, error)              { return "", nil }
+
+var _ mx.Hedged = foo.D
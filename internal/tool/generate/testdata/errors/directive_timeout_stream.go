@@ -0,0 +1,33 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// ERROR: //mx:timeout cannot be applied to a streaming method
+
+// Method 'M' is a streaming method with a timeout.
+package foo
+
+import (
+	"context"
+
+	"github.com/sh3lk/mx"
+)
+
+type foo interface {
+	//mx:timeout=1s
+	M(context.Context) (mx.Stream[int], error)
+}
+
+type impl struct{ mx.Implements[foo] }
+
+func (l *impl) M(context.Context) (mx.Stream[int], error) { return nil, nil }
@@ -0,0 +1,50 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// EXPECTED
+// enc.Error(a0)
+// enc.Error(x.err)
+// x.err = dec.Error()
+// enc.Error(arg[i])
+// res[i] = dec.Error()
+// RegisterSerializable[*wrapError]()
+
+// Verify that errors are serializable, including as the fields of custom
+// error types.
+package foo
+
+import (
+	"context"
+
+	"github.com/sh3lk/mx"
+)
+
+type wrapError struct {
+	mx.AutoMarshal
+	code int
+	err  error
+}
+
+func (w wrapError) Error() string { return w.err.Error() }
+func (w wrapError) Unwrap() error { return w.err }
+
+type foo interface {
+	M(context.Context, error) error
+	N(context.Context, []error) (wrapError, error)
+}
+
+type impl struct{ mx.Implements[foo] }
+
+func (impl) M(context.Context, error) error                { return nil }
+func (impl) N(context.Context, []error) (wrapError, error) { return wrapError{}, nil }
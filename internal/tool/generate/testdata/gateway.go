@@ -0,0 +1,75 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// OPTIONS
+// gateway
+
+// EXPECTED
+// GatewayFn: func(impl any) []codegen.GatewayMethod { return calc_gateway_methods(impl.(Calc)) },
+// func calc_gateway_methods(impl Calc) []codegen.GatewayMethod {
+// `{"properties":{"x":{"type":"integer"},"y":{"type":"integer"}},"type":"object"}`
+// A0 int `json:"x"`
+// r0, err := impl.Add(ctx, args.A0, args.A1)
+// `{"items":false,"prefixItems":[{"type":"integer"},{"type":"integer"}],"type":"array"}`
+// r0, r1, err := impl.DivMod(ctx, args.A0, args.A1)
+// return []any{r0, r1}, err
+// `{"properties":{"xs":{"items":{"type":"number"},"type":"array"}},"type":"object"}`
+// r0, err := impl.Sum(ctx, args.A0...)
+// `{"$defs":{"foo.point":{"properties":{"X":{"type":"number"},"lat":{"type":"number"}},"type":"object"}},"properties":{"arg0":{"$ref":"#/$defs/foo.point"}},"type":"object"}`
+// A0 point `json:"arg0"`
+// return nil, impl.Reset(ctx)
+
+// UNEXPECTED
+// Name:   "Complex",
+// Name:   "Numbers",
+
+// Package foo contains a component for which "mx generate -gateway" generates
+// a gateway. Streaming methods and methods whose arguments or results cannot
+// be encoded as JSON are not served.
+package foo
+
+import (
+	"context"
+
+	"github.com/sh3lk/mx"
+)
+
+type point struct {
+	mx.AutoMarshal
+	X float64
+	Y float64 `json:"lat"`
+	z float64
+}
+
+type Calc interface {
+	Add(ctx context.Context, x, y int) (int, error)
+	DivMod(ctx context.Context, x, y int) (int, int, error)
+	Sum(ctx context.Context, xs ...float64) (float64, error)
+	Move(context.Context, point) (point, error)
+	Reset(context.Context) error
+	Complex(ctx context.Context, c complex128) (complex128, error)
+	Numbers(context.Context) (mx.Stream[int], error)
+}
+
+type calc struct {
+	mx.Implements[Calc]
+}
+
+func (*calc) Add(_ context.Context, x, y int) (int, error)                { return x + y, nil }
+func (*calc) DivMod(_ context.Context, x, y int) (int, int, error)        { return x / y, x % y, nil }
+func (*calc) Sum(context.Context, ...float64) (float64, error)            { return 0, nil }
+func (*calc) Move(_ context.Context, p point) (point, error)              { return p, nil }
+func (*calc) Reset(context.Context) error                                 { return nil }
+func (*calc) Complex(_ context.Context, c complex128) (complex128, error) { return c, nil }
+func (*calc) Numbers(context.Context) (mx.Stream[int], error)             { return nil, nil }
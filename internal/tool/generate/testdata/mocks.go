@@ -0,0 +1,70 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// OPTIONS
+// mocks
+
+// EXPECTED
+// type MockStore struct {
+// var _ Store = (*MockStore)(nil)
+// func (m *MockStore) Get(ctx context.Context, a0 string) (r0 []byte, r1 bool, err error) {
+// res, ok := m.Mock.Called("Get", 3, a0)
+// return mock.Value[[]byte](res, 0), mock.Value[bool](res, 1), res.Err()
+// func (m *MockStore) Put(ctx context.Context, a0 string, a1 ...[]byte) (err error) {
+// return impl.Put(ctx, a0, a1...)
+// type mockCache struct {
+// var _ cache = (*mockCache)(nil)
+// func (m *mockCache) Clear(ctx context.Context) (err error) {
+
+// UNEXPECTED
+// MockMain
+
+// Package foo contains components for which "mx generate -mocks" generates
+// mocks. Exported components get an exported mock, and unexported components
+// get an unexported one.
+package foo
+
+import (
+	"context"
+
+	"github.com/sh3lk/mx"
+)
+
+type Store interface {
+	Get(context.Context, string) ([]byte, bool, error)
+	Put(context.Context, string, ...[]byte) error
+}
+
+type cache interface {
+	Clear(context.Context) error
+}
+
+type store struct {
+	mx.Implements[Store]
+}
+
+func (*store) Get(context.Context, string) ([]byte, bool, error) { return nil, false, nil }
+func (*store) Put(context.Context, string, ...[]byte) error      { return nil }
+
+type cacheImpl struct {
+	mx.Implements[cache]
+}
+
+func (*cacheImpl) Clear(context.Context) error { return nil }
+
+type app struct {
+	mx.Implements[mx.Main]
+}
+
+func (*app) Main(context.Context) error { return nil }
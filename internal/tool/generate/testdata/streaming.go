@@ -0,0 +1,50 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// EXPECTED
+// Streaming: []int{0, 1}
+// RunStream(ctx, 0, enc.Data(), in, shardKey)
+// RunStream(ctx, 1, nil, in, shardKey)
+// GetStreamStubFn(method string)
+// codegen.DecodeStream[pair](out,
+// codegen.EncodeStream[pair](r0,
+// codegen.EncodeStream[int](a0,
+// codegen.DecodeStream[int](in,
+// func (x *pair) MXMarshal(enc *codegen.Encoder)
+
+// Package foo contains a component with streaming methods.
+package foo
+
+import (
+	"context"
+
+	"github.com/sh3lk/mx"
+)
+
+type pair struct {
+	mx.AutoMarshal
+	x, y int
+}
+
+type foo interface {
+	Pairs(context.Context, int) (mx.Stream[pair], error)
+	Unary(context.Context, int) (int, error)
+	Sum(context.Context, mx.Stream[int]) (int, error)
+}
+
+type impl struct{ mx.Implements[foo] }
+
+func (l *impl) Pairs(context.Context, int) (mx.Stream[pair], error) { return nil, nil }
+func (l *impl) Unary(context.Context, int) (int, error)             { return 0, nil }
+func (l *impl) Sum(context.Context, mx.Stream[int]) (int, error)    { return 0, nil }
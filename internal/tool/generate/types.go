@@ -15,9 +15,12 @@
 package generate
 
 import (
+	"encoding/json"
 	"fmt"
 	"go/types"
 	"path"
+	"reflect"
+	"slices"
 	"sort"
 	"strings"
 
@@ -290,6 +293,13 @@ func (tset *typeSet) checkSerializable(t types.Type) []error {
 			// No need to check if x is an unexported type from another package
 			// since the Go compiler takes care of that.
 
+			// Errors are serialized using codegen.Encoder.Error, which
+			// preserves wrapped errors and registered error types.
+			if isError(x) {
+				tset.checked.Set(t, true)
+				break
+			}
+
 			// Check if the type implements one of the marshaler interfaces.
 			if tset.isProto(x) || tset.automarshals.At(t) != nil || tset.implementsAutoMarshal(x) || tset.hasMarshalBinary(x) {
 				tset.checked.Set(t, true)
@@ -893,6 +903,21 @@ func isMXNotRetriable(t types.Type) bool {
 	return isMXType(t, "NotRetriable", 0)
 }
 
+func isMXHedged(t types.Type) bool {
+	return isMXType(t, "Hedged", 0)
+}
+
+func isMXStream(t types.Type) bool {
+	return isMXType(t, "Stream", 1)
+}
+
+// streamElem returns the type T of the values in the stream type mx.Stream[T].
+//
+// REQUIRES: isMXStream(t).
+func streamElem(t types.Type) types.Type {
+	return t.(*types.Named).TypeArgs().At(0)
+}
+
 func isString(t types.Type) bool {
 	b, ok := t.(*types.Basic)
 	return ok && b.Kind() == types.String
@@ -953,3 +978,190 @@ func isValidRouterType(t types.Type) bool {
 	}
 	return true
 }
+
+// jsonSchemas derives JSON schemas from Go types. The schemas describe the
+// JSON encoding of values produced by the encoding/json package. Named types
+// are described once, in the "$defs" of the schema document, and referenced
+// wherever they are used, so that recursive types can be described.
+type jsonSchemas struct {
+	defs map[string]any // schemas of named types, by name
+}
+
+// newJSONSchemas returns a new, empty jsonSchemas.
+func newJSONSchemas() *jsonSchemas {
+	return &jsonSchemas{defs: map[string]any{}}
+}
+
+// document returns the JSON encoding of a schema document with the provided
+// root schema and the definitions of all named types used so far.
+func (s *jsonSchemas) document(root map[string]any) (string, error) {
+	doc := map[string]any{}
+	for k, v := range root {
+		doc[k] = v
+	}
+	if len(s.defs) > 0 {
+		doc["$defs"] = s.defs
+	}
+	b, err := json.Marshal(doc)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// schema returns the JSON schema of t, or an error if values of type t cannot
+// be encoded as JSON.
+func (s *jsonSchemas) schema(t types.Type) (map[string]any, error) {
+	if n, ok := types.Unalias(t).(*types.Named); ok {
+		if obj := n.Obj(); obj.Pkg() != nil && obj.Pkg().Path() == "time" && obj.Name() == "Time" {
+			return map[string]any{"type": "string", "format": "date-time"}, nil
+		}
+		if hasMethod(n, "MarshalJSON") {
+			// The encoding is up to the type.
+			return map[string]any{}, nil
+		}
+		if hasMethod(n, "MarshalText") {
+			return map[string]any{"type": "string"}, nil
+		}
+		if _, ok := n.Underlying().(*types.Basic); !ok {
+			name := types.TypeString(n, func(pkg *types.Package) string { return pkg.Name() })
+			ref := map[string]any{"$ref": "#/$defs/" + name}
+			if _, ok := s.defs[name]; ok {
+				return ref, nil
+			}
+			s.defs[name] = map[string]any{} // placeholder for recursive types
+			def, err := s.schema(n.Underlying())
+			if err != nil {
+				delete(s.defs, name)
+				return nil, err
+			}
+			s.defs[name] = def
+			return ref, nil
+		}
+	}
+
+	switch x := t.Underlying().(type) {
+	case *types.Basic:
+		switch {
+		case x.Info()&types.IsBoolean != 0:
+			return map[string]any{"type": "boolean"}, nil
+		case x.Info()&types.IsInteger != 0:
+			return map[string]any{"type": "integer"}, nil
+		case x.Info()&types.IsFloat != 0:
+			return map[string]any{"type": "number"}, nil
+		case x.Info()&types.IsString != 0:
+			return map[string]any{"type": "string"}, nil
+		}
+		return nil, fmt.Errorf("type %v cannot be encoded as JSON", t)
+
+	case *types.Pointer:
+		return s.schema(x.Elem())
+
+	case *types.Slice:
+		if isByteSlice(x) {
+			return map[string]any{"type": "string", "contentEncoding": "base64"}, nil
+		}
+		items, err := s.schema(x.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{"type": "array", "items": items}, nil
+
+	case *types.Array:
+		items, err := s.schema(x.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{"type": "array", "items": items, "minItems": x.Len(), "maxItems": x.Len()}, nil
+
+	case *types.Map:
+		key := x.Key()
+		b, ok := key.Underlying().(*types.Basic)
+		if !hasMethod(key, "MarshalText") && (!ok || b.Info()&(types.IsString|types.IsInteger) == 0) {
+			return nil, fmt.Errorf("map key type %v cannot be encoded as JSON", key)
+		}
+		values, err := s.schema(x.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{"type": "object", "additionalProperties": values}, nil
+
+	case *types.Struct:
+		props := map[string]any{}
+		if err := s.addFields(props, x); err != nil {
+			return nil, err
+		}
+		return map[string]any{"type": "object", "properties": props}, nil
+
+	case *types.Interface:
+		// Any JSON value.
+		return map[string]any{}, nil
+
+	default:
+		return nil, fmt.Errorf("type %v cannot be encoded as JSON", t)
+	}
+}
+
+// addFields adds the schemas of the JSON-encoded fields of the provided struct
+// to props. Like encoding/json, it honors json struct tags and promotes the
+// fields of embedded structs, which are shadowed by the fields of the outer
+// struct.
+func (s *jsonSchemas) addFields(props map[string]any, x *types.Struct) error {
+	var embedded []*types.Struct
+	for i := 0; i < x.NumFields(); i++ {
+		f := x.Field(i)
+		tag := reflect.StructTag(x.Tag(i)).Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if f.Embedded() && name == "" {
+			t := f.Type()
+			if p, ok := t.(*types.Pointer); ok {
+				t = p.Elem()
+			}
+			if st, ok := t.Underlying().(*types.Struct); ok {
+				embedded = append(embedded, st)
+				continue
+			}
+		}
+		if !f.Exported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name()
+		}
+		if slices.Contains(strings.Split(opts, ","), "string") {
+			props[name] = map[string]any{"type": "string"}
+			continue
+		}
+		schema, err := s.schema(f.Type())
+		if err != nil {
+			return fmt.Errorf("field %s: %w", f.Name(), err)
+		}
+		props[name] = schema
+	}
+	for _, st := range embedded {
+		promoted := map[string]any{}
+		if err := s.addFields(promoted, st); err != nil {
+			return err
+		}
+		for name, schema := range promoted {
+			if _, ok := props[name]; !ok {
+				props[name] = schema
+			}
+		}
+	}
+	return nil
+}
+
+// hasMethod returns whether t or *t has an exported method with the provided
+// name.
+func hasMethod(t types.Type, name string) bool {
+	if _, ok := t.Underlying().(*types.Interface); ok {
+		return false
+	}
+	obj, _, _ := types.LookupFieldOrMethod(t, true, nil, name)
+	_, ok := obj.(*types.Func)
+	return ok
+}
@@ -301,6 +301,70 @@ type target struct {
 	}
 }
 
+func TestJSONSchema(t *testing.T) {
+	type testCase struct {
+		label    string
+		contents string
+		want     string // If empty, must fail
+	}
+	for _, c := range []testCase{
+		{"int", "type target = int", `{"type":"integer"}`},
+		{"float", "type target = float32", `{"type":"number"}`},
+		{"named basic", "type target string", `{"type":"string"}`},
+		{"bytes", "type target = []byte", `{"contentEncoding":"base64","type":"string"}`},
+		{"slice", "type target = []bool", `{"items":{"type":"boolean"},"type":"array"}`},
+		{"array", "type target = [2]int", `{"items":{"type":"integer"},"maxItems":2,"minItems":2,"type":"array"}`},
+		{"map", "type target = map[int]string", `{"additionalProperties":{"type":"string"},"type":"object"}`},
+		{"pointer", "type target = *int", `{"type":"integer"}`},
+		{"time", `
+import "time"
+type target = time.Time
+`, `{"format":"date-time","type":"string"}`},
+		{"struct", `
+type inner struct {
+	A int
+}
+type target struct {
+	inner
+	X   int
+	Y   string ` + "`json:\"y\"`" + `
+	Z   bool   ` + "`json:\"-\"`" + `
+	A   string
+	n   int
+	In  *inner
+}
+`, `{"$defs":{"foo.inner":{"properties":{"A":{"type":"integer"}},"type":"object"},"foo.target":{"properties":{"A":{"type":"string"},"In":{"$ref":"#/$defs/foo.inner"},"X":{"type":"integer"},"y":{"type":"string"}},"type":"object"}},"$ref":"#/$defs/foo.target"}`},
+		{"recursive", "type target struct { Next *target }", `{"$defs":{"foo.target":{"properties":{"Next":{"$ref":"#/$defs/foo.target"}},"type":"object"}},"$ref":"#/$defs/foo.target"}`},
+
+		// Types that cannot be encoded as JSON:
+		{"complex", "type target = complex64", ""},
+		{"map key", "type target = map[[2]int]int", ""},
+		{"field", "type target struct { C complex128 }", ""},
+	} {
+		t.Run(c.label, func(t *testing.T) {
+			_, target := compile(t, c.contents)
+			schemas := newJSONSchemas()
+			root, err := schemas.schema(target)
+			if c.want == "" {
+				if err == nil {
+					t.Fatalf("schema: unexpected success")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := schemas.document(root)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != c.want {
+				t.Fatalf("schema:\ngot  %s\nwant %s", got, c.want)
+			}
+		})
+	}
+}
+
 func compile(t *testing.T, contents string) (*typeSet, types.Type) {
 	t.Helper()
 
@@ -0,0 +1,54 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kube
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/sh3lk/mx/internal/tool/kube/impl"
+	"github.com/sh3lk/mx/runtime/tool"
+)
+
+var (
+	babysitterFlags   = flag.NewFlagSet("babysitter", flag.ContinueOnError)
+	babysitterConfig  = babysitterFlags.String("config", "", "Path of the config of the application version.")
+	babysitterGroup   = babysitterFlags.String("group", "", "Colocation group to run.")
+	babysitterManager = babysitterFlags.String("manager", "", "Address of the manager.")
+
+	babysitterCmd = tool.Command{
+		Name:        "babysitter",
+		Description: "The mx kube babysitter",
+		Help: `Usage:
+  mx kube babysitter --config=<file> --group=<group> --manager=<address>
+
+Flags:
+  -h, --help	Print this help message.
+` + tool.FlagsHelp(babysitterFlags),
+		Flags:  babysitterFlags,
+		Hidden: true,
+		Fn: func(ctx context.Context, _ []string) error {
+			config, err := readConfig(*babysitterConfig)
+			if err != nil {
+				return err
+			}
+			if *babysitterGroup == "" || *babysitterManager == "" {
+				return fmt.Errorf("--group and --manager must be provided")
+			}
+			return impl.RunBabysitter(ctx, config, *babysitterGroup, *babysitterManager)
+		},
+	}
+)
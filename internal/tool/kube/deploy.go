@@ -0,0 +1,199 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kube
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	itool "github.com/sh3lk/mx/internal/tool"
+	"github.com/sh3lk/mx/internal/tool/config"
+	"github.com/sh3lk/mx/internal/tool/kube/impl"
+	"github.com/sh3lk/mx/runtime"
+	"github.com/sh3lk/mx/runtime/bin"
+	"github.com/sh3lk/mx/runtime/codegen"
+	"github.com/sh3lk/mx/runtime/tool"
+	"github.com/sh3lk/mx/runtime/version"
+)
+
+const (
+	configKey      = "github.com/sh3lk/mx/kube"
+	shortConfigKey = "kube"
+)
+
+var (
+	deployFlags = flag.NewFlagSet("deploy", flag.ContinueOnError)
+	outputFile  = deployFlags.String("o", "", "Write the manifests to this file, instead of stdout.")
+
+	deployCmd = tool.Command{
+		Name:        "deploy",
+		Description: "Generate the Kubernetes manifests of a MX app",
+		Help: `Usage:
+  mx kube deploy [-o <file>] <configfile>
+
+Flags:
+  -h, --help	Print this help message.
+` + tool.FlagsHelp(deployFlags) + `
+
+Description:
+  "mx kube deploy" generates the Kubernetes manifests that deploy the
+  application in the provided config, and prints them in YAML. The manifests
+  are generated offline, without contacting a cluster, so they can be checked
+  in and reviewed before being applied with "kubectl apply -f".
+
+  The config must contain a [kube] section with the container image of the
+  application. The image must contain the mx-kube binary at /mx/mx-kube
+  and the application binary at /mx/app. For example:
+
+    [mx]
+    binary = "./collatz"
+
+    [kube]
+    image = "registry.example.com/collatz:v1"
+    namespace = "collatz"
+    listeners.collatz = {public = true}
+    replicas."github.com/sh3lk/mx/examples/collatz/Odd" = {min = 2, max = 10}
+
+  Generating the manifests of the same binary and config twice yields the
+  same manifests.`,
+		Flags: deployFlags,
+		Fn:    deploy,
+	}
+)
+
+// deploy generates the Kubernetes manifests of an application.
+func deploy(_ context.Context, args []string) error {
+	// Validate command line arguments.
+	if len(args) == 0 {
+		return fmt.Errorf("no config file provided")
+	}
+	if len(args) > 1 {
+		return fmt.Errorf("too many arguments")
+	}
+
+	// Load the config file.
+	cfgFile := args[0]
+	cfg, err := os.ReadFile(cfgFile)
+	if err != nil {
+		return fmt.Errorf("load config file %q: %w", cfgFile, err)
+	}
+	config, err := loadConfig(cfgFile, cfg)
+	if err != nil {
+		return err
+	}
+
+	// Read the components and listeners of the application.
+	binary, err := os.ReadFile(config.App.Binary)
+	if err != nil {
+		return fmt.Errorf("read binary: %w", err)
+	}
+	components, _, err := bin.ReadComponentGraph(config.App.Binary)
+	if err != nil {
+		return fmt.Errorf("read component graph: %w", err)
+	}
+	binListeners, err := bin.ReadListeners(config.App.Binary)
+	if err != nil {
+		return fmt.Errorf("read listeners: %w", err)
+	}
+	listeners := map[string]string{} // exporting component, by listener
+	var names []string
+	for _, c := range binListeners {
+		for _, lis := range c.Listeners {
+			listeners[lis] = c.Component
+			names = append(names, lis)
+		}
+	}
+
+	// Generate the manifests.
+	config.DepId = impl.Version(binary, cfg)
+	if err := impl.Finalize(config, names); err != nil {
+		return err
+	}
+	manifests, err := impl.GenerateYAML(config, components, listeners)
+	if err != nil {
+		return fmt.Errorf("generate manifests: %w", err)
+	}
+	if *outputFile == "" {
+		_, err := os.Stdout.Write(manifests)
+		return err
+	}
+	if err := os.WriteFile(*outputFile, manifests, 0644); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Wrote the manifests of version %s to %s. To deploy it, run:\n\n    kubectl apply -f %s\n", config.DepId, *outputFile, *outputFile)
+	return nil
+}
+
+// loadConfig loads and validates the Kubernetes deployer config in the
+// provided file, with the provided contents, and checks that the application
+// binary is compatible with the deployer.
+func loadConfig(cfgFile string, cfg []byte) (*impl.KubeConfig, error) {
+	// Parse and sanity-check the app config.
+	app, err := runtime.ParseConfig(cfgFile, string(cfg), codegen.ComponentConfigValidator)
+	if err != nil {
+		return nil, fmt.Errorf("load config file %q: %w", cfgFile, err)
+	}
+	if _, err := os.Stat(app.Binary); errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("binary %q doesn't exist", app.Binary)
+	}
+
+	// Parse the Kubernetes config.
+	config, err := config.GetDeployerConfig[impl.KubeConfig, impl.KubeConfig_ListenerOptions](configKey, shortConfigKey, app)
+	if err != nil {
+		return nil, err
+	}
+	config.App = app
+
+	// Check version compatibility.
+	versions, err := bin.ReadVersions(app.Binary)
+	if err != nil {
+		return nil, fmt.Errorf("read versions: %w", err)
+	}
+	if versions.DeployerVersion != version.DeployerVersion {
+		// Try to relativize the binary, defaulting to the absolute path if
+		// there are any errors..
+		binary := app.Binary
+		if cwd, err := os.Getwd(); err == nil {
+			if rel, err := filepath.Rel(cwd, app.Binary); err == nil {
+				binary = rel
+			}
+		}
+		selfVersion, err := itool.SelfVersion()
+		if err != nil {
+			return nil, fmt.Errorf("read self version: %w", err)
+		}
+		return nil, fmt.Errorf(`
+ERROR: The binary you're trying to deploy (%q) was built with
+github.com/sh3lk/mx module version %s. However, the 'mx
+kube' binary you're using was built with mx module version %s.
+These versions are incompatible.
+
+We recommend updating both the mx module your application is built with and
+updating the 'mx kube' command by running the following.
+
+    go get github.com/sh3lk/mx@latest
+    go install github.com/sh3lk/mx/cmd/mx-kube@latest
+
+Then, re-build your code and re-run 'mx kube deploy'. If the problem
+persists, please file an issue at https://github.com/sh3lk/mx/issues.`,
+			binary, versions.ModuleVersion, selfVersion)
+	}
+
+	return config, nil
+}
@@ -0,0 +1,377 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package impl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sh3lk/mx/internal/supervisor"
+	"github.com/sh3lk/mx/runtime"
+	"github.com/sh3lk/mx/runtime/colors"
+	"github.com/sh3lk/mx/runtime/envelope"
+	"github.com/sh3lk/mx/runtime/logging"
+	"github.com/sh3lk/mx/runtime/protomsg"
+	"github.com/sh3lk/mx/runtime/protos"
+	"github.com/sh3lk/mx/runtime/retry"
+	"golang.org/x/exp/maps"
+	"google.golang.org/protobuf/proto"
+)
+
+// babysitter starts and manages the mxn of a replica of a colocation group,
+// in a Kubernetes pod.
+type babysitter struct {
+	ctx         context.Context
+	config      *KubeConfig
+	group       string
+	managerAddr string
+	pod         string // name of the pod
+	podIP       string // IP address of the pod
+	logger      *slog.Logger
+	printer     *logging.PrettyPrinter
+	draining    atomic.Bool // is the pod being deleted?
+
+	// The following fields describe the current replica. They are reset
+	// every time the replica is restarted.
+	mu         sync.Mutex
+	replicaCtx context.Context                             // canceled when the replica stops
+	envelope   *envelope.Envelope                          // envelope of the replica
+	activated  map[string]*protos.ActivateComponentRequest // activated components, by name
+}
+
+var _ envelope.EnvelopeHandler = &babysitter{}
+
+// RunBabysitter runs the mxn of a replica of the provided colocation group,
+// in the current pod. The mxn talks to the manager at the provided address.
+// If the mxn crashes or becomes unhealthy, RunBabysitter restarts it.
+//
+// The logs of the mxn are printed to stderr, where Kubernetes collects them.
+func RunBabysitter(ctx context.Context, config *KubeConfig, group, managerAddr string) error {
+	pod, podIP := os.Getenv(podNameKey), os.Getenv(podIPKey)
+	if pod == "" || podIP == "" {
+		return fmt.Errorf("%s and %s must be set", podNameKey, podIPKey)
+	}
+
+	b := &babysitter{
+		ctx:         ctx,
+		config:      config,
+		group:       group,
+		managerAddr: managerAddr,
+		pod:         pod,
+		podIP:       podIP,
+		logger: logging.StderrLogger(logging.Options{
+			App:        config.App.Name,
+			Deployment: config.DepId,
+			Component:  "Babysitter",
+			MXN:        pod,
+			Attrs:      []string{"mx/system", ""},
+		}),
+		printer: logging.NewPrettyPrinter(colors.Enabled()),
+	}
+
+	// Kubernetes sends a SIGTERM to the pod before deleting it. A mxn runs
+	// the Shutdown methods of its components before exiting, so keep running
+	// until it exits, without restarting it.
+	sigterm := make(chan os.Signal, 1)
+	signal.Notify(sigterm, syscall.SIGTERM)
+	go func() {
+		<-sigterm
+		b.logger.Info("Draining")
+		b.draining.Store(true)
+	}()
+
+	var err error
+	supervisor.Supervise(ctx, b.logger, func(restarts int) error {
+		var restart bool
+		restart, err = b.runReplica(uuid.New().String(), restarts)
+		if restart {
+			return err
+		}
+		return nil
+	})
+	return err
+}
+
+// runReplica starts a mxn and blocks until it exits. It returns true, along
+// with the cause of the failure, if the mxn failed and should be restarted.
+func (b *babysitter) runReplica(id string, restarts int) (bool, error) {
+	// Start the envelope.
+	wlet := &protos.MXNArgs{
+		App:             b.config.App.Name,
+		DeploymentId:    b.config.DepId,
+		Id:              id,
+		RunMain:         b.group == b.mainGroup(),
+		InternalAddress: net.JoinHostPort(b.podIP, strconv.Itoa(internalPort)),
+	}
+	ctx, cancel := context.WithCancel(b.ctx)
+	defer cancel()
+	e, err := envelope.NewEnvelope(ctx, wlet, b.config.App, envelope.Options{
+		Logger: b.logger,
+	})
+	if err != nil {
+		// Only retry if the mxn started successfully before.
+		return restarts > 0, err
+	}
+
+	b.mu.Lock()
+	b.replicaCtx = ctx
+	b.envelope = e
+	b.activated = map[string]*protos.ActivateComponentRequest{}
+	b.mu.Unlock()
+
+	if err := b.registerReplica(ctx, e, id, restarts); err != nil {
+		return restarts > 0, err
+	}
+	go supervisor.WatchHealth(ctx, b.logger, e, cancel)
+	err = e.Serve(b)
+
+	if b.ctx.Err() != nil || b.draining.Load() {
+		// The babysitter is stopping, or the pod is being deleted.
+		return false, err
+	}
+	if e.ExitError() == nil && ctx.Err() == nil {
+		// The mxn exited successfully (e.g., because main returned).
+		return false, err
+	}
+
+	// The mxn crashed or was killed because it was unhealthy. Stop routing
+	// traffic to it before restarting it, and stop re-registering it.
+	cancel()
+	if uerr := b.unregisterReplica(e.MXNAddress()); uerr != nil {
+		b.logger.Error("Unable to unregister failed replica", "err", uerr)
+	}
+	if exitErr := e.ExitError(); exitErr != nil {
+		err = exitErr
+	}
+	if err == nil {
+		err = errors.New("mxn exited")
+	}
+	return true, err
+}
+
+// mainGroup returns the name of the colocation group of the main component.
+func (b *babysitter) mainGroup() string {
+	if name, ok := colocation(b.config.App)[runtime.Main]; ok {
+		return name
+	}
+	return runtime.Main
+}
+
+// call calls the manager endpoint at the provided path.
+func (b *babysitter) call(ctx context.Context, path string, req, reply proto.Message) error {
+	return protomsg.Call(ctx, protomsg.CallArgs{
+		Client:  http.DefaultClient,
+		Addr:    b.managerAddr,
+		URLPath: path,
+		Request: req,
+		Reply:   reply,
+	})
+}
+
+// ActivateComponent implements the protos.EnvelopeHandler interface.
+func (b *babysitter) ActivateComponent(_ context.Context, req *protos.ActivateComponentRequest) (*protos.ActivateComponentReply, error) {
+	b.mu.Lock()
+	ctx, e := b.replicaCtx, b.envelope
+	b.mu.Unlock()
+	if err := b.call(ctx, startComponentURL, req, nil); err != nil {
+		return nil, err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, ok := b.activated[req.Component]; !ok {
+		b.activated[req.Component] = protomsg.Clone(req)
+		go b.watchRoutingInfo(ctx, e, req.Component, req.Routed)
+	}
+	return &protos.ActivateComponentReply{}, nil
+}
+
+// registerReplica registers the information about a colocation group replica
+// (i.e., a mxn).
+func (b *babysitter) registerReplica(ctx context.Context, e *envelope.Envelope, mxnId string, restarts int) error {
+	req := &ReplicaToRegister{
+		Group:    b.group,
+		Address:  e.MXNAddress(),
+		Pod:      b.pod,
+		MXNId:    mxnId,
+		Restarts: int32(restarts),
+	}
+	if err := b.call(ctx, registerReplicaURL, req, nil); err != nil {
+		return err
+	}
+
+	go b.watchComponents(ctx, e)
+	go b.heartbeat(ctx, req, registerInterval)
+	return nil
+}
+
+// heartbeat re-registers the replica, and re-activates the components that
+// the replica activated, every interval, until ctx is canceled or the pod is
+// being deleted. The manager keeps its state in memory only, so this lets a
+// restarted manager recover it.
+func (b *babysitter) heartbeat(ctx context.Context, req *ReplicaToRegister, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if b.draining.Load() {
+			// Let the manager unregister the replica once its pod is gone.
+			return
+		}
+		if err := b.call(ctx, registerReplicaURL, req, nil); err != nil {
+			b.logger.Error("Unable to re-register replica", "err", err)
+			continue
+		}
+		b.mu.Lock()
+		activated := maps.Values(b.activated)
+		b.mu.Unlock()
+		for _, a := range activated {
+			if err := b.call(ctx, startComponentURL, a, nil); err != nil {
+				b.logger.Error("Unable to re-activate component", "err", err, "component", a.Component)
+			}
+		}
+	}
+}
+
+// unregisterReplica unregisters a colocation group replica (i.e., a mxn) that
+// has failed.
+func (b *babysitter) unregisterReplica(replicaAddr string) error {
+	return b.call(b.ctx, unregisterReplicaURL, &ReplicaToUnregister{
+		Group:   b.group,
+		Address: replicaAddr,
+	}, nil)
+}
+
+// GetListenerAddress implements the protos.EnvelopeHandler interface.
+func (b *babysitter) GetListenerAddress(_ context.Context, req *protos.GetListenerAddressRequest) (*protos.GetListenerAddressReply, error) {
+	opts, ok := b.config.Listeners[req.Name]
+	if !ok {
+		return nil, fmt.Errorf("listener %q not found in the config; re-run 'mx kube deploy'", req.Name)
+	}
+	return &protos.GetListenerAddressReply{Address: fmt.Sprintf(":%d", opts.Port)}, nil
+}
+
+// ExportListener implements the protos.EnvelopeHandler interface.
+func (b *babysitter) ExportListener(_ context.Context, req *protos.ExportListenerRequest) (*protos.ExportListenerReply, error) {
+	// The listener is exported by the Kubernetes service of the listener.
+	addr := fmt.Sprintf("%s.%s:80", objectName(b.config.App.Name, req.Listener), b.config.Namespace)
+	return &protos.ExportListenerReply{ProxyAddress: addr}, nil
+}
+
+// GetSelfCertificate implements the envelope.EnvelopeHandler interface.
+func (b *babysitter) GetSelfCertificate(context.Context, *protos.GetSelfCertificateRequest) (*protos.GetSelfCertificateReply, error) {
+	// TODO(spetrovic): Implement this functionality.
+	panic("unimplemented")
+}
+
+// VerifyClientCertificate implements the envelope.EnvelopeHandler interface.
+func (b *babysitter) VerifyClientCertificate(context.Context, *protos.VerifyClientCertificateRequest) (*protos.VerifyClientCertificateReply, error) {
+	// TODO(spetrovic): Implement this functionality.
+	panic("unimplemented")
+}
+
+// VerifyServerCertificate implements the envelope.EnvelopeHandler interface.
+func (b *babysitter) VerifyServerCertificate(context.Context, *protos.VerifyServerCertificateRequest) (*protos.VerifyServerCertificateReply, error) {
+	// TODO(spetrovic): Implement this functionality.
+	panic("unimplemented")
+}
+
+// GetSelfToken implements the envelope.EnvelopeHandler interface.
+func (b *babysitter) GetSelfToken(context.Context, *protos.GetSelfTokenRequest) (*protos.GetSelfTokenReply, error) {
+	// The kube deployer doesn't support token authentication.
+	return nil, fmt.Errorf("token authentication is not enabled")
+}
+
+// VerifyClientToken implements the envelope.EnvelopeHandler interface.
+func (b *babysitter) VerifyClientToken(context.Context, *protos.VerifyClientTokenRequest) (*protos.VerifyClientTokenReply, error) {
+	// The kube deployer doesn't support token authentication.
+	return nil, fmt.Errorf("token authentication is not enabled")
+}
+
+func (b *babysitter) watchRoutingInfo(ctx context.Context, e *envelope.Envelope, component string, routed bool) {
+	version := ""
+	for r := retry.Begin(); r.Continue(ctx); {
+		req := &GetRoutingInfoRequest{
+			RequestingGroup: b.group,
+			Component:       component,
+			Routed:          routed,
+			Version:         version,
+		}
+		reply := &GetRoutingInfoReply{}
+		if err := b.call(ctx, getRoutingInfoURL, req, reply); err != nil {
+			b.logger.Error("cannot get routing info; will retry", "err", err, "component", component)
+			continue
+		}
+		version = reply.Version
+		if err := e.UpdateRoutingInfo(reply.RoutingInfo); err != nil {
+			b.logger.Error("cannot update routing info; will retry", "err", err, "component", component)
+			continue
+		}
+		if reply.RoutingInfo.Local {
+			// If the routing is local, it will never change. There is no need
+			// to watch.
+			return
+		}
+		r.Reset()
+	}
+}
+
+func (b *babysitter) watchComponents(ctx context.Context, e *envelope.Envelope) {
+	version := ""
+	for r := retry.Begin(); r.Continue(ctx); {
+		req := &GetComponentsRequest{Group: b.group, Version: version}
+		reply := &GetComponentsReply{}
+		if err := b.call(ctx, getComponentsToStartURL, req, reply); err != nil {
+			b.logger.Error("cannot get components to start; will retry", "err", err)
+			continue
+		}
+		version = reply.Version
+		if err := e.UpdateComponents(reply.Components); err != nil {
+			b.logger.Error("cannot update components to start; will retry", "err", err)
+			continue
+		}
+		r.Reset()
+	}
+}
+
+// LogBatch implements the protos.EnvelopeHandler interface.
+func (b *babysitter) LogBatch(_ context.Context, req *protos.LogEntryBatch) error {
+	for _, entry := range req.Entries {
+		fmt.Fprintln(os.Stderr, b.printer.Format(entry))
+	}
+	return nil
+}
+
+// HandleTraceSpans implements the protos.EnvelopeHandler interface.
+func (b *babysitter) HandleTraceSpans(context.Context, *protos.TraceSpans) error {
+	// Traces are not collected by the Kubernetes deployer yet.
+	return nil
+}
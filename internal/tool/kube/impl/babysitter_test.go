@@ -0,0 +1,79 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package impl
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sh3lk/mx/runtime"
+	"github.com/sh3lk/mx/runtime/logging"
+	"github.com/sh3lk/mx/runtime/protos"
+)
+
+func TestHeartbeatRestoresManager(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// A manager that was just restarted knows nothing about the replicas.
+	m, _ := newTestManager(t)
+	mux := http.NewServeMux()
+	m.addHTTPHandlers(mux)
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	// The babysitter of a replica that activated a.
+	b := &babysitter{
+		ctx:         ctx,
+		group:       a,
+		managerAddr: server.URL,
+		logger:      logging.NewTestSlogger(t, testing.Verbose()),
+		activated: map[string]*protos.ActivateComponentRequest{
+			a: {Component: a, Routed: true},
+		},
+	}
+	req := &ReplicaToRegister{Group: a, Address: "tcp://1.1.1.1:10000", Pod: "a-1"}
+	go b.heartbeat(ctx, req, 10*time.Millisecond)
+
+	// The manager learns about the replica and the component again.
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		info := routingInfo(t, m, runtime.Main, a, true)
+		if len(info.Replicas) > 0 {
+			if diff := cmp.Diff([]string{"tcp://1.1.1.1:10000"}, info.Replicas); diff != "" {
+				t.Fatalf("replicas (-want +got):\n%s", diff)
+			}
+			if info.Assignment == nil {
+				t.Fatal("routed component has no assignment")
+			}
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("replica never re-registered")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	reply, err := m.getComponentsToStart(ctx, &GetComponentsRequest{Group: a})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{a}, reply.Components); diff != "" {
+		t.Errorf("components (-want +got):\n%s", diff)
+	}
+}
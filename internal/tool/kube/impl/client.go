@@ -0,0 +1,110 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package impl
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+)
+
+// The directory where Kubernetes mounts the credentials of the service
+// account of a pod.
+const serviceAccountDir = "/var/run/secrets/kubernetes.io/serviceaccount"
+
+// apiClient is a minimal client of the Kubernetes API server. It only
+// supports the requests issued by the manager.
+type apiClient struct {
+	addr   string       // API server address (e.g., "https://10.0.0.1:443")
+	token  string       // bearer token, if any
+	client *http.Client // HTTP client
+}
+
+// newAPIClient returns a client of the API server at the provided address,
+// that authenticates with the provided bearer token, if not empty.
+func newAPIClient(addr, token string, client *http.Client) *apiClient {
+	return &apiClient{addr: addr, token: token, client: client}
+}
+
+// inClusterClient returns a client of the API server of the cluster the
+// caller runs in, authenticated as the service account of the caller's pod.
+func inClusterClient() (*apiClient, error) {
+	host, port := os.Getenv("KUBERNETES_SERVICE_HOST"), os.Getenv("KUBERNETES_SERVICE_PORT")
+	if host == "" || port == "" {
+		return nil, fmt.Errorf("not running in a Kubernetes cluster")
+	}
+	token, err := os.ReadFile(filepath.Join(serviceAccountDir, "token"))
+	if err != nil {
+		return nil, fmt.Errorf("read service account token: %w", err)
+	}
+	ca, err := os.ReadFile(filepath.Join(serviceAccountDir, "ca.crt"))
+	if err != nil {
+		return nil, fmt.Errorf("read service account CA: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(ca) {
+		return nil, fmt.Errorf("invalid service account CA")
+	}
+	client := &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{RootCAs: pool},
+		},
+	}
+	addr := "https://" + net.JoinHostPort(host, port)
+	return newAPIClient(addr, string(token), client), nil
+}
+
+// listPods returns the pods in the provided namespace that match the provided
+// label selector (e.g., "app=foo,version=bar").
+func (c *apiClient) listPods(ctx context.Context, namespace, selector string) ([]pod, error) {
+	path := fmt.Sprintf("/api/v1/namespaces/%s/pods", url.PathEscape(namespace))
+	query := url.Values{"labelSelector": []string{selector}}
+	var pods podList
+	if err := c.get(ctx, path+"?"+query.Encode(), &pods); err != nil {
+		return nil, fmt.Errorf("list pods: %w", err)
+	}
+	return pods.Items, nil
+}
+
+// get issues a GET request for the provided path, and decodes the JSON reply
+// into out.
+func (c *apiClient) get(ctx context.Context, path string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.addr+path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("%s: %s", resp.Status, body)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
@@ -0,0 +1,996 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.26.0
+// 	protoc        v5.29.3
+// source: internal/tool/kube/impl/kube.proto
+
+package impl
+
+import (
+	protos "github.com/sh3lk/mx/runtime/protos"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// KubeConfig stores the configuration information for one version of a
+// MX application deployed using the Kubernetes deployer.
+type KubeConfig struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Information about the application deployment.
+	App   *protos.AppConfig `protobuf:"bytes,1,opt,name=app,proto3" json:"app,omitempty"`
+	DepId string            `protobuf:"bytes,2,opt,name=dep_id,json=depId,proto3" json:"dep_id,omitempty"`
+	// Container image that contains the mx-kube binary at /mx/mx-kube and
+	// the application binary at /mx/app.
+	Image string `protobuf:"bytes,3,opt,name=image,proto3" json:"image,omitempty"`
+	// Kubernetes namespace of the application. If empty, it defaults to
+	// "default".
+	Namespace string                                 `protobuf:"bytes,4,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Listeners map[string]*KubeConfig_ListenerOptions `protobuf:"bytes,5,rep,name=listeners,proto3" json:"listeners,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Replication options for co-location groups, keyed by the name of any
+	// component in the group.
+	Replicas  map[string]*KubeConfig_ReplicaOptions `protobuf:"bytes,6,rep,name=replicas,proto3" json:"replicas,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Resources *KubeConfig_Resources                 `protobuf:"bytes,7,opt,name=resources,proto3" json:"resources,omitempty"`
+	// Target average CPU utilization of the replicas of an autoscaled group,
+	// as a percentage of the requested CPU. If unset, it defaults to 80.
+	Cpu int32 `protobuf:"varint,8,opt,name=cpu,proto3" json:"cpu,omitempty"`
+}
+
+func (x *KubeConfig) Reset() {
+	*x = KubeConfig{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_tool_kube_impl_kube_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KubeConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KubeConfig) ProtoMessage() {}
+
+func (x *KubeConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_tool_kube_impl_kube_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KubeConfig.ProtoReflect.Descriptor instead.
+func (*KubeConfig) Descriptor() ([]byte, []int) {
+	return file_internal_tool_kube_impl_kube_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *KubeConfig) GetApp() *protos.AppConfig {
+	if x != nil {
+		return x.App
+	}
+	return nil
+}
+
+func (x *KubeConfig) GetDepId() string {
+	if x != nil {
+		return x.DepId
+	}
+	return ""
+}
+
+func (x *KubeConfig) GetImage() string {
+	if x != nil {
+		return x.Image
+	}
+	return ""
+}
+
+func (x *KubeConfig) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *KubeConfig) GetListeners() map[string]*KubeConfig_ListenerOptions {
+	if x != nil {
+		return x.Listeners
+	}
+	return nil
+}
+
+func (x *KubeConfig) GetReplicas() map[string]*KubeConfig_ReplicaOptions {
+	if x != nil {
+		return x.Replicas
+	}
+	return nil
+}
+
+func (x *KubeConfig) GetResources() *KubeConfig_Resources {
+	if x != nil {
+		return x.Resources
+	}
+	return nil
+}
+
+func (x *KubeConfig) GetCpu() int32 {
+	if x != nil {
+		return x.Cpu
+	}
+	return 0
+}
+
+// A request from the babysitter to the manager to get the latest set of
+// components to run.
+type GetComponentsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Group   string `protobuf:"bytes,1,opt,name=group,proto3" json:"group,omitempty"`
+	Version string `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+}
+
+func (x *GetComponentsRequest) Reset() {
+	*x = GetComponentsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_tool_kube_impl_kube_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetComponentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetComponentsRequest) ProtoMessage() {}
+
+func (x *GetComponentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_tool_kube_impl_kube_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetComponentsRequest.ProtoReflect.Descriptor instead.
+func (*GetComponentsRequest) Descriptor() ([]byte, []int) {
+	return file_internal_tool_kube_impl_kube_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetComponentsRequest) GetGroup() string {
+	if x != nil {
+		return x.Group
+	}
+	return ""
+}
+
+func (x *GetComponentsRequest) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+type GetComponentsReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Components []string `protobuf:"bytes,1,rep,name=components,proto3" json:"components,omitempty"`
+	Version    string   `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+}
+
+func (x *GetComponentsReply) Reset() {
+	*x = GetComponentsReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_tool_kube_impl_kube_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetComponentsReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetComponentsReply) ProtoMessage() {}
+
+func (x *GetComponentsReply) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_tool_kube_impl_kube_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetComponentsReply.ProtoReflect.Descriptor instead.
+func (*GetComponentsReply) Descriptor() ([]byte, []int) {
+	return file_internal_tool_kube_impl_kube_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetComponentsReply) GetComponents() []string {
+	if x != nil {
+		return x.Components
+	}
+	return nil
+}
+
+func (x *GetComponentsReply) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+// A request from the babysitter to the manager to get the latest routing info
+// for a component.
+type GetRoutingInfoRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Note that requesting group is the group requesting the routing info, not
+	// the group of the component.
+	RequestingGroup string `protobuf:"bytes,1,opt,name=requesting_group,json=requestingGroup,proto3" json:"requesting_group,omitempty"`
+	Component       string `protobuf:"bytes,2,opt,name=component,proto3" json:"component,omitempty"`
+	Routed          bool   `protobuf:"varint,3,opt,name=routed,proto3" json:"routed,omitempty"` // is the component routed?
+	Version         string `protobuf:"bytes,4,opt,name=version,proto3" json:"version,omitempty"`
+}
+
+func (x *GetRoutingInfoRequest) Reset() {
+	*x = GetRoutingInfoRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_tool_kube_impl_kube_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetRoutingInfoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRoutingInfoRequest) ProtoMessage() {}
+
+func (x *GetRoutingInfoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_tool_kube_impl_kube_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRoutingInfoRequest.ProtoReflect.Descriptor instead.
+func (*GetRoutingInfoRequest) Descriptor() ([]byte, []int) {
+	return file_internal_tool_kube_impl_kube_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetRoutingInfoRequest) GetRequestingGroup() string {
+	if x != nil {
+		return x.RequestingGroup
+	}
+	return ""
+}
+
+func (x *GetRoutingInfoRequest) GetComponent() string {
+	if x != nil {
+		return x.Component
+	}
+	return ""
+}
+
+func (x *GetRoutingInfoRequest) GetRouted() bool {
+	if x != nil {
+		return x.Routed
+	}
+	return false
+}
+
+func (x *GetRoutingInfoRequest) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+type GetRoutingInfoReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RoutingInfo *protos.RoutingInfo `protobuf:"bytes,1,opt,name=routing_info,json=routingInfo,proto3" json:"routing_info,omitempty"`
+	Version     string              `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+}
+
+func (x *GetRoutingInfoReply) Reset() {
+	*x = GetRoutingInfoReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_tool_kube_impl_kube_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetRoutingInfoReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRoutingInfoReply) ProtoMessage() {}
+
+func (x *GetRoutingInfoReply) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_tool_kube_impl_kube_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRoutingInfoReply.ProtoReflect.Descriptor instead.
+func (*GetRoutingInfoReply) Descriptor() ([]byte, []int) {
+	return file_internal_tool_kube_impl_kube_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetRoutingInfoReply) GetRoutingInfo() *protos.RoutingInfo {
+	if x != nil {
+		return x.RoutingInfo
+	}
+	return nil
+}
+
+func (x *GetRoutingInfoReply) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+// ReplicaToRegister is a request to the manager to register a replica of
+// a given colocation group (i.e., a mxn).
+type ReplicaToRegister struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Group    string `protobuf:"bytes,1,opt,name=group,proto3" json:"group,omitempty"`
+	Address  string `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`    // Replica internal address.
+	Pod      string `protobuf:"bytes,3,opt,name=pod,proto3" json:"pod,omitempty"`            // Name of the pod that runs the replica.
+	MXNId    string `protobuf:"bytes,4,opt,name=mxnId,proto3" json:"mxnId,omitempty"`        // Replica mxn id
+	Restarts int32  `protobuf:"varint,5,opt,name=restarts,proto3" json:"restarts,omitempty"` // Number of times the replica has been restarted.
+}
+
+func (x *ReplicaToRegister) Reset() {
+	*x = ReplicaToRegister{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_tool_kube_impl_kube_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReplicaToRegister) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplicaToRegister) ProtoMessage() {}
+
+func (x *ReplicaToRegister) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_tool_kube_impl_kube_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplicaToRegister.ProtoReflect.Descriptor instead.
+func (*ReplicaToRegister) Descriptor() ([]byte, []int) {
+	return file_internal_tool_kube_impl_kube_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ReplicaToRegister) GetGroup() string {
+	if x != nil {
+		return x.Group
+	}
+	return ""
+}
+
+func (x *ReplicaToRegister) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *ReplicaToRegister) GetPod() string {
+	if x != nil {
+		return x.Pod
+	}
+	return ""
+}
+
+func (x *ReplicaToRegister) GetMXNId() string {
+	if x != nil {
+		return x.MXNId
+	}
+	return ""
+}
+
+func (x *ReplicaToRegister) GetRestarts() int32 {
+	if x != nil {
+		return x.Restarts
+	}
+	return 0
+}
+
+// ReplicaToUnregister is a request to the manager to unregister a replica of
+// a given colocation group (i.e., a mxn) that has failed.
+type ReplicaToUnregister struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Group   string `protobuf:"bytes,1,opt,name=group,proto3" json:"group,omitempty"`
+	Address string `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"` // Replica internal address.
+}
+
+func (x *ReplicaToUnregister) Reset() {
+	*x = ReplicaToUnregister{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_tool_kube_impl_kube_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReplicaToUnregister) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplicaToUnregister) ProtoMessage() {}
+
+func (x *ReplicaToUnregister) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_tool_kube_impl_kube_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplicaToUnregister.ProtoReflect.Descriptor instead.
+func (*ReplicaToUnregister) Descriptor() ([]byte, []int) {
+	return file_internal_tool_kube_impl_kube_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ReplicaToUnregister) GetGroup() string {
+	if x != nil {
+		return x.Group
+	}
+	return ""
+}
+
+func (x *ReplicaToUnregister) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+// Options for the application listeners, keyed by listener name.
+// If a listener isn't specified in the map, default options will be used.
+type KubeConfig_ListenerOptions struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Container port the listener listens on. If unset, every listener is
+	// assigned a distinct port, starting at 20000.
+	Port int32 `protobuf:"varint,1,opt,name=port,proto3" json:"port,omitempty"`
+	// Should the listener be exposed outside of the cluster, using a
+	// LoadBalancer service? If false, the listener is only reachable from
+	// within the cluster.
+	Public bool `protobuf:"varint,2,opt,name=public,proto3" json:"public,omitempty"`
+}
+
+func (x *KubeConfig_ListenerOptions) Reset() {
+	*x = KubeConfig_ListenerOptions{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_tool_kube_impl_kube_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KubeConfig_ListenerOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KubeConfig_ListenerOptions) ProtoMessage() {}
+
+func (x *KubeConfig_ListenerOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_tool_kube_impl_kube_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KubeConfig_ListenerOptions.ProtoReflect.Descriptor instead.
+func (*KubeConfig_ListenerOptions) Descriptor() ([]byte, []int) {
+	return file_internal_tool_kube_impl_kube_proto_rawDescGZIP(), []int{0, 0}
+}
+
+func (x *KubeConfig_ListenerOptions) GetPort() int32 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+func (x *KubeConfig_ListenerOptions) GetPublic() bool {
+	if x != nil {
+		return x.Public
+	}
+	return false
+}
+
+// Replication options for a co-location group. A group has between min and
+// max replicas, inclusive. If max is larger than min, the number of
+// replicas is adjusted by a HorizontalPodAutoscaler. If min is unset, it
+// defaults to 1. If max is unset, it defaults to min.
+type KubeConfig_ReplicaOptions struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Min int32 `protobuf:"varint,1,opt,name=min,proto3" json:"min,omitempty"`
+	Max int32 `protobuf:"varint,2,opt,name=max,proto3" json:"max,omitempty"`
+}
+
+func (x *KubeConfig_ReplicaOptions) Reset() {
+	*x = KubeConfig_ReplicaOptions{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_tool_kube_impl_kube_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KubeConfig_ReplicaOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KubeConfig_ReplicaOptions) ProtoMessage() {}
+
+func (x *KubeConfig_ReplicaOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_tool_kube_impl_kube_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KubeConfig_ReplicaOptions.ProtoReflect.Descriptor instead.
+func (*KubeConfig_ReplicaOptions) Descriptor() ([]byte, []int) {
+	return file_internal_tool_kube_impl_kube_proto_rawDescGZIP(), []int{0, 2}
+}
+
+func (x *KubeConfig_ReplicaOptions) GetMin() int32 {
+	if x != nil {
+		return x.Min
+	}
+	return 0
+}
+
+func (x *KubeConfig_ReplicaOptions) GetMax() int32 {
+	if x != nil {
+		return x.Max
+	}
+	return 0
+}
+
+// Compute resources requested by every replica of a co-location group,
+// as Kubernetes quantities (e.g., "500m", "256Mi").
+type KubeConfig_Resources struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Cpu    string `protobuf:"bytes,1,opt,name=cpu,proto3" json:"cpu,omitempty"` // If empty, it defaults to "100m".
+	Memory string `protobuf:"bytes,2,opt,name=memory,proto3" json:"memory,omitempty"`
+}
+
+func (x *KubeConfig_Resources) Reset() {
+	*x = KubeConfig_Resources{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_tool_kube_impl_kube_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KubeConfig_Resources) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KubeConfig_Resources) ProtoMessage() {}
+
+func (x *KubeConfig_Resources) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_tool_kube_impl_kube_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KubeConfig_Resources.ProtoReflect.Descriptor instead.
+func (*KubeConfig_Resources) Descriptor() ([]byte, []int) {
+	return file_internal_tool_kube_impl_kube_proto_rawDescGZIP(), []int{0, 4}
+}
+
+func (x *KubeConfig_Resources) GetCpu() string {
+	if x != nil {
+		return x.Cpu
+	}
+	return ""
+}
+
+func (x *KubeConfig_Resources) GetMemory() string {
+	if x != nil {
+		return x.Memory
+	}
+	return ""
+}
+
+var File_internal_tool_kube_impl_kube_proto protoreflect.FileDescriptor
+
+var file_internal_tool_kube_impl_kube_proto_rawDesc = []byte{
+	0x0a, 0x22, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x74, 0x6f, 0x6f, 0x6c, 0x2f,
+	0x6b, 0x75, 0x62, 0x65, 0x2f, 0x69, 0x6d, 0x70, 0x6c, 0x2f, 0x6b, 0x75, 0x62, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x12, 0x04, 0x69, 0x6d, 0x70, 0x6c, 0x1a, 0x1b, 0x72, 0x75, 0x6e, 0x74,
+	0x69, 0x6d, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1c, 0x72, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65,
+	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2f, 0x72, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xae, 0x05, 0x0a, 0x0a, 0x4b, 0x75, 0x62, 0x65, 0x43, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x12, 0x24, 0x0a, 0x03, 0x61, 0x70, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x12, 0x2e, 0x72, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x2e, 0x41, 0x70, 0x70, 0x43,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x03, 0x61, 0x70, 0x70, 0x12, 0x15, 0x0a, 0x06, 0x64, 0x65,
+	0x70, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x64, 0x65, 0x70, 0x49,
+	0x64, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73,
+	0x70, 0x61, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6e, 0x61, 0x6d, 0x65,
+	0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x3d, 0x0a, 0x09, 0x6c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65,
+	0x72, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x69, 0x6d, 0x70, 0x6c, 0x2e,
+	0x4b, 0x75, 0x62, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x65,
+	0x6e, 0x65, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x09, 0x6c, 0x69, 0x73, 0x74, 0x65,
+	0x6e, 0x65, 0x72, 0x73, 0x12, 0x3a, 0x0a, 0x08, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x73,
+	0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x69, 0x6d, 0x70, 0x6c, 0x2e, 0x4b, 0x75,
+	0x62, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61,
+	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x73,
+	0x12, 0x38, 0x0a, 0x09, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x69, 0x6d, 0x70, 0x6c, 0x2e, 0x4b, 0x75, 0x62, 0x65, 0x43,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x52,
+	0x09, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x63, 0x70,
+	0x75, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x63, 0x70, 0x75, 0x1a, 0x3d, 0x0a, 0x0f,
+	0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12,
+	0x12, 0x0a, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x70,
+	0x6f, 0x72, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x06, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x1a, 0x5e, 0x0a, 0x0e, 0x4c,
+	0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
+	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
+	0x36, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20,
+	0x2e, 0x69, 0x6d, 0x70, 0x6c, 0x2e, 0x4b, 0x75, 0x62, 0x65, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x2e, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x34, 0x0a, 0x0e, 0x52,
+	0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x10, 0x0a,
+	0x03, 0x6d, 0x69, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x6d, 0x69, 0x6e, 0x12,
+	0x10, 0x0a, 0x03, 0x6d, 0x61, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x6d, 0x61,
+	0x78, 0x1a, 0x5c, 0x0a, 0x0d, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x73, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x6b, 0x65, 0x79, 0x12, 0x35, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x69, 0x6d, 0x70, 0x6c, 0x2e, 0x4b, 0x75, 0x62, 0x65, 0x43,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x4f, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a,
+	0x35, 0x0a, 0x09, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x12, 0x10, 0x0a, 0x03,
+	0x63, 0x70, 0x75, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x63, 0x70, 0x75, 0x12, 0x16,
+	0x0a, 0x06, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x22, 0x46, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6d,
+	0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14,
+	0x0a, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x67,
+	0x72, 0x6f, 0x75, 0x70, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x4e,
+	0x0a, 0x12, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x73, 0x52,
+	0x65, 0x70, 0x6c, 0x79, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e,
+	0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x6e,
+	0x65, 0x6e, 0x74, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x92,
+	0x01, 0x0a, 0x15, 0x47, 0x65, 0x74, 0x52, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x49, 0x6e, 0x66,
+	0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x29, 0x0a, 0x10, 0x72, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x69, 0x6e, 0x67, 0x5f, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x67, 0x47, 0x72,
+	0x6f, 0x75, 0x70, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e,
+	0x74, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x06, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x22, 0x68, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x52, 0x6f, 0x75, 0x74, 0x69, 0x6e,
+	0x67, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x37, 0x0a, 0x0c, 0x72, 0x6f,
+	0x75, 0x74, 0x69, 0x6e, 0x67, 0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x14, 0x2e, 0x72, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x2e, 0x52, 0x6f, 0x75, 0x74, 0x69,
+	0x6e, 0x67, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x0b, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x49,
+	0x6e, 0x66, 0x6f, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x87, 0x01,
+	0x0a, 0x11, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x54, 0x6f, 0x52, 0x65, 0x67, 0x69, 0x73,
+	0x74, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64,
+	0x72, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72,
+	0x65, 0x73, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x70, 0x6f, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x70, 0x6f, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x6d, 0x78, 0x6e, 0x49, 0x64, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6d, 0x78, 0x6e, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x72,
+	0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x72,
+	0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x73, 0x22, 0x45, 0x0a, 0x13, 0x52, 0x65, 0x70, 0x6c, 0x69,
+	0x63, 0x61, 0x54, 0x6f, 0x55, 0x6e, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x12, 0x14,
+	0x0a, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x67,
+	0x72, 0x6f, 0x75, 0x70, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x42, 0x2d,
+	0x5a, 0x2b, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x73, 0x68, 0x33,
+	0x6c, 0x6b, 0x2f, 0x6d, 0x78, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x74,
+	0x6f, 0x6f, 0x6c, 0x2f, 0x6b, 0x75, 0x62, 0x65, 0x2f, 0x69, 0x6d, 0x70, 0x6c, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_internal_tool_kube_impl_kube_proto_rawDescOnce sync.Once
+	file_internal_tool_kube_impl_kube_proto_rawDescData = file_internal_tool_kube_impl_kube_proto_rawDesc
+)
+
+func file_internal_tool_kube_impl_kube_proto_rawDescGZIP() []byte {
+	file_internal_tool_kube_impl_kube_proto_rawDescOnce.Do(func() {
+		file_internal_tool_kube_impl_kube_proto_rawDescData = protoimpl.X.CompressGZIP(file_internal_tool_kube_impl_kube_proto_rawDescData)
+	})
+	return file_internal_tool_kube_impl_kube_proto_rawDescData
+}
+
+var file_internal_tool_kube_impl_kube_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_internal_tool_kube_impl_kube_proto_goTypes = []interface{}{
+	(*KubeConfig)(nil),                 // 0: impl.KubeConfig
+	(*GetComponentsRequest)(nil),       // 1: impl.GetComponentsRequest
+	(*GetComponentsReply)(nil),         // 2: impl.GetComponentsReply
+	(*GetRoutingInfoRequest)(nil),      // 3: impl.GetRoutingInfoRequest
+	(*GetRoutingInfoReply)(nil),        // 4: impl.GetRoutingInfoReply
+	(*ReplicaToRegister)(nil),          // 5: impl.ReplicaToRegister
+	(*ReplicaToUnregister)(nil),        // 6: impl.ReplicaToUnregister
+	(*KubeConfig_ListenerOptions)(nil), // 7: impl.KubeConfig.ListenerOptions
+	nil,                                // 8: impl.KubeConfig.ListenersEntry
+	(*KubeConfig_ReplicaOptions)(nil),  // 9: impl.KubeConfig.ReplicaOptions
+	nil,                                // 10: impl.KubeConfig.ReplicasEntry
+	(*KubeConfig_Resources)(nil),       // 11: impl.KubeConfig.Resources
+	(*protos.AppConfig)(nil),           // 12: runtime.AppConfig
+	(*protos.RoutingInfo)(nil),         // 13: runtime.RoutingInfo
+}
+var file_internal_tool_kube_impl_kube_proto_depIdxs = []int32{
+	12, // 0: impl.KubeConfig.app:type_name -> runtime.AppConfig
+	8,  // 1: impl.KubeConfig.listeners:type_name -> impl.KubeConfig.ListenersEntry
+	10, // 2: impl.KubeConfig.replicas:type_name -> impl.KubeConfig.ReplicasEntry
+	11, // 3: impl.KubeConfig.resources:type_name -> impl.KubeConfig.Resources
+	13, // 4: impl.GetRoutingInfoReply.routing_info:type_name -> runtime.RoutingInfo
+	7,  // 5: impl.KubeConfig.ListenersEntry.value:type_name -> impl.KubeConfig.ListenerOptions
+	9,  // 6: impl.KubeConfig.ReplicasEntry.value:type_name -> impl.KubeConfig.ReplicaOptions
+	7,  // [7:7] is the sub-list for method output_type
+	7,  // [7:7] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_internal_tool_kube_impl_kube_proto_init() }
+func file_internal_tool_kube_impl_kube_proto_init() {
+	if File_internal_tool_kube_impl_kube_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_internal_tool_kube_impl_kube_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KubeConfig); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_tool_kube_impl_kube_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetComponentsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_tool_kube_impl_kube_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetComponentsReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_tool_kube_impl_kube_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetRoutingInfoRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_tool_kube_impl_kube_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetRoutingInfoReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_tool_kube_impl_kube_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReplicaToRegister); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_tool_kube_impl_kube_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReplicaToUnregister); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_tool_kube_impl_kube_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KubeConfig_ListenerOptions); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_tool_kube_impl_kube_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KubeConfig_ReplicaOptions); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_tool_kube_impl_kube_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KubeConfig_Resources); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_internal_tool_kube_impl_kube_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   12,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_internal_tool_kube_impl_kube_proto_goTypes,
+		DependencyIndexes: file_internal_tool_kube_impl_kube_proto_depIdxs,
+		MessageInfos:      file_internal_tool_kube_impl_kube_proto_msgTypes,
+	}.Build()
+	File_internal_tool_kube_impl_kube_proto = out.File
+	file_internal_tool_kube_impl_kube_proto_rawDesc = nil
+	file_internal_tool_kube_impl_kube_proto_goTypes = nil
+	file_internal_tool_kube_impl_kube_proto_depIdxs = nil
+}
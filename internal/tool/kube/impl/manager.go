@@ -0,0 +1,388 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package impl
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/sh3lk/mx/internal/routing"
+	"github.com/sh3lk/mx/internal/versioned"
+	"github.com/sh3lk/mx/runtime"
+	"github.com/sh3lk/mx/runtime/logging"
+	"github.com/sh3lk/mx/runtime/protomsg"
+	"github.com/sh3lk/mx/runtime/protos"
+	"golang.org/x/exp/maps"
+)
+
+const (
+	// URL suffixes for various Kubernetes manager handlers.
+	getComponentsToStartURL = "/manager/get_components_to_start"
+	registerReplicaURL      = "/manager/register_replica"
+	unregisterReplicaURL    = "/manager/unregister_replica"
+	startComponentURL       = "/manager/start_component"
+	getRoutingInfoURL       = "/manager/get_routing_info"
+
+	// How often the manager checks that the pods of the registered replicas
+	// are still running.
+	podsInterval = 10 * time.Second
+
+	// How often babysitters re-register their replicas, and re-activate the
+	// components that their replicas activated (see babysitter.heartbeat).
+	registerInterval = 10 * time.Second
+)
+
+// manager manages a version of an application deployed on Kubernetes. It
+// runs in its own pod, and tells the babysitters of the colocation groups
+// which components to start and how to route to other components.
+//
+// Kubernetes, not the manager, starts and replicates the colocation groups.
+// The manager uses the Kubernetes API server to detect replicas whose pods
+// were deleted, and stops routing traffic to them.
+//
+// The manager keeps its state in memory only. If its pod is restarted, the
+// manager recovers the state from the babysitters, which periodically
+// re-register their replicas and re-activate their components.
+type manager struct {
+	ctx    context.Context
+	config *KubeConfig
+	logger *slog.Logger
+	api    *apiClient
+
+	// colocation maps a component to the name of its colocation group. If a
+	// component is missing in the map, then it is in a colocation group by
+	// itself.
+	colocation map[string]string
+
+	mu     sync.Mutex        // guards groups, but not its contents
+	groups map[string]*group // groups, by group name
+}
+
+type group struct {
+	name       string
+	components *versioned.Versioned[map[string]bool] // started components
+
+	mu       sync.Mutex                                           // guards the following
+	replicas map[string]string                                    // pod names, by mxn address
+	routings map[string]*versioned.Versioned[*protos.RoutingInfo] // routing info, by component
+}
+
+// RunManager runs the manager of the version of the application in the
+// provided config, until the provided context is canceled. The manager
+// listens on the provided address, and talks to the API server of the
+// cluster it runs in.
+func RunManager(ctx context.Context, config *KubeConfig, addr string) error {
+	api, err := inClusterClient()
+	if err != nil {
+		return err
+	}
+	m := newManager(ctx, config, api)
+	if err := m.startComponent(ctx, &protos.ActivateComponentRequest{Component: runtime.Main}); err != nil {
+		return err
+	}
+
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("listen: %w", err)
+	}
+	m.logger.Info("Manager listening", "address", lis.Addr())
+	mux := http.NewServeMux()
+	m.addHTTPHandlers(mux)
+	go m.watchPods()
+	return serveHTTP(ctx, lis, mux)
+}
+
+// newManager returns a new manager that uses the provided API server client.
+func newManager(ctx context.Context, config *KubeConfig, api *apiClient) *manager {
+	return &manager{
+		ctx:    ctx,
+		config: config,
+		logger: logging.StderrLogger(logging.Options{
+			App:        config.App.Name,
+			Deployment: config.DepId,
+			Component:  "manager",
+			MXN:        os.Getenv(podNameKey),
+			Attrs:      []string{"mx/system", ""},
+		}),
+		api:        api,
+		colocation: colocation(config.App),
+		groups:     map[string]*group{},
+	}
+}
+
+// addHTTPHandlers adds handlers for the HTTP endpoints exposed by the manager.
+func (m *manager) addHTTPHandlers(mux *http.ServeMux) {
+	mux.HandleFunc(getComponentsToStartURL, protomsg.HandlerFunc(m.logger, m.getComponentsToStart))
+	mux.HandleFunc(registerReplicaURL, protomsg.HandlerDo(m.logger, m.registerReplica))
+	mux.HandleFunc(unregisterReplicaURL, protomsg.HandlerDo(m.logger, m.unregisterReplica))
+	mux.HandleFunc(startComponentURL, protomsg.HandlerDo(m.logger, m.startComponent))
+	mux.HandleFunc(getRoutingInfoURL, protomsg.HandlerFunc(m.logger, m.getRoutingInfo))
+}
+
+// group returns the co-location group of the provided component.
+//
+// REQUIRES: m.mu is not held.
+func (m *manager) group(component string) *group {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	name, ok := m.colocation[component]
+	if !ok {
+		name = component
+	}
+
+	g, ok := m.groups[name]
+	if !ok {
+		g = &group{
+			name:       name,
+			components: versioned.Version(map[string]bool{}),
+			replicas:   map[string]string{},
+			routings:   map[string]*versioned.Versioned[*protos.RoutingInfo]{},
+		}
+		m.groups[name] = g
+	}
+	return g
+}
+
+// allGroups returns all of the managed colocation groups.
+func (m *manager) allGroups() []*group {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return maps.Values(m.groups) // creates a new slice
+}
+
+// allAddresses returns the sorted addresses of the replicas of the group.
+//
+// REQUIRES: g.mu is NOT held.
+func (g *group) allAddresses() []string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	addrs := maps.Keys(g.replicas) // creates a new slice
+	sort.Strings(addrs)
+	return addrs
+}
+
+// routing returns the RoutingInfo for the provided component.
+//
+// REQUIRES: g.mu is NOT held.
+func (g *group) routing(component string) *versioned.Versioned[*protos.RoutingInfo] {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	routing, ok := g.routings[component]
+	if !ok {
+		routing = versioned.Version(&protos.RoutingInfo{Component: component})
+		g.routings[component] = routing
+	}
+	return routing
+}
+
+// updateRoutings updates the routing info of every component in the group to
+// reflect the group's current set of replicas.
+//
+// REQUIRES: g.mu is NOT held.
+func (g *group) updateRoutings() {
+	replicas := g.allAddresses()
+	g.mu.Lock()
+	routings := maps.Clone(g.routings)
+	g.mu.Unlock()
+	for _, info := range routings {
+		info.Lock()
+		info.Val.Replicas = replicas
+		if info.Val.Assignment != nil {
+			info.Val.Assignment = routing.BalanceLoad(info.Val.Assignment, replicas, nil)
+		}
+		info.Unlock()
+	}
+}
+
+func (m *manager) getComponentsToStart(_ context.Context, req *GetComponentsRequest) (*GetComponentsReply, error) {
+	g := m.group(req.Group)
+	version := g.components.RLock(req.Version)
+	defer g.components.RUnlock()
+	return &GetComponentsReply{
+		Components: maps.Keys(g.components.Val),
+		Version:    version,
+	}, nil
+}
+
+func (m *manager) registerReplica(_ context.Context, req *ReplicaToRegister) error {
+	g := m.group(req.Group)
+	record := func() bool {
+		g.mu.Lock()
+		defer g.mu.Unlock()
+		if _, ok := g.replicas[req.Address]; ok {
+			// Replica already registered.
+			return false
+		}
+		g.replicas[req.Address] = req.Pod
+		return true
+	}
+	if record() {
+		m.logger.Info("Registered replica", "group", g.name, "address", req.Address, "pod", req.Pod)
+		g.updateRoutings()
+	}
+	return nil
+}
+
+// unregisterReplica unregisters a replica of a colocation group that has
+// failed, so that traffic is no longer routed to it.
+func (m *manager) unregisterReplica(_ context.Context, req *ReplicaToUnregister) error {
+	g := m.group(req.Group)
+	remove := func() bool {
+		g.mu.Lock()
+		defer g.mu.Unlock()
+		if _, ok := g.replicas[req.Address]; !ok {
+			// Replica already unregistered.
+			return false
+		}
+		delete(g.replicas, req.Address)
+		return true
+	}
+	if remove() {
+		m.logger.Info("Unregistered replica", "group", g.name, "address", req.Address)
+		g.updateRoutings()
+	}
+	return nil
+}
+
+// startComponent handles an ActivateComponent request. It records the
+// component, so that the babysitters of the component's colocation group
+// start it, and initializes the routing info of the component.
+func (m *manager) startComponent(_ context.Context, req *protos.ActivateComponentRequest) error {
+	g := m.group(req.Component)
+
+	// Record the component.
+	record := func() bool {
+		g.components.Lock()
+		defer g.components.Unlock()
+		if g.components.Val[req.Component] {
+			// Component already started, or is in the process of being started.
+			return false
+		}
+		g.components.Val[req.Component] = true
+		return true
+	}
+	if !record() {
+		return nil
+	}
+	m.logger.Info("Activated component", "component", req.Component, "group", g.name)
+
+	// Update the routing info.
+	info := g.routing(req.Component)
+	addresses := g.allAddresses()
+	info.Lock()
+	defer info.Unlock()
+	info.Val.Replicas = addresses
+	if req.Routed {
+		info.Val.Assignment = routing.BalanceLoad(&protos.Assignment{}, addresses, nil)
+	}
+	return nil
+}
+
+func (m *manager) getRoutingInfo(_ context.Context, req *GetRoutingInfoRequest) (*GetRoutingInfoReply, error) {
+	g := m.group(req.RequestingGroup)
+	target := m.group(req.Component)
+
+	if !req.Routed && g.name == target.name {
+		// Route locally.
+		return &GetRoutingInfoReply{
+			RoutingInfo: &protos.RoutingInfo{
+				Component: req.Component,
+				Local:     true,
+			},
+		}, nil
+	}
+
+	routing := target.routing(req.Component)
+	version := routing.RLock(req.Version)
+	defer routing.RUnlock()
+	return &GetRoutingInfoReply{
+		RoutingInfo: protomsg.Clone(routing.Val),
+		Version:     version,
+	}, nil
+}
+
+// watchPods periodically unregisters the replicas whose pods are no longer
+// running, until the manager is stopped. Pods can be deleted without their
+// babysitters unregistering their replicas (e.g., when a node fails or when
+// a group is scaled down).
+func (m *manager) watchPods() {
+	ticker := time.NewTicker(podsInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-m.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if err := m.collectReplicas(m.ctx); err != nil {
+			m.logger.Error("Unable to check replica pods", "err", err)
+		}
+	}
+}
+
+// collectReplicas unregisters the replicas whose pods are no longer running.
+func (m *manager) collectReplicas(ctx context.Context) error {
+	selector := fmt.Sprintf("%s=%s,%s=%s", appLabel, objectName(m.config.App.Name), versionLabel, m.config.DepId)
+	pods, err := m.api.listPods(ctx, m.config.Namespace, selector)
+	if err != nil {
+		return err
+	}
+	running := map[string]bool{}
+	for _, p := range pods {
+		if p.Status.Phase == "Running" && p.Metadata.DeletionTimestamp == nil {
+			running[p.Metadata.Name] = true
+		}
+	}
+
+	for _, g := range m.allGroups() {
+		g.mu.Lock()
+		var removed []string
+		for addr, pod := range g.replicas {
+			if !running[pod] {
+				delete(g.replicas, addr)
+				removed = append(removed, addr)
+			}
+		}
+		g.mu.Unlock()
+		if len(removed) == 0 {
+			continue
+		}
+		m.logger.Info("Unregistered replicas of stopped pods", "group", g.name, "addresses", removed)
+		g.updateRoutings()
+	}
+	return nil
+}
+
+// serveHTTP serves HTTP traffic on the provided listener using the provided
+// handler. The server is shut down when the provided context is canceled.
+func serveHTTP(ctx context.Context, lis net.Listener, handler http.Handler) error {
+	server := http.Server{Handler: handler}
+	errs := make(chan error, 1)
+	go func() { errs <- server.Serve(lis) }()
+	select {
+	case err := <-errs:
+		return err
+	case <-ctx.Done():
+		return server.Shutdown(ctx)
+	}
+}
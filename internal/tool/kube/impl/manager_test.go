@@ -0,0 +1,183 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package impl
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sh3lk/mx/runtime"
+	"github.com/sh3lk/mx/runtime/protos"
+)
+
+// fakeAPIServer is a fake Kubernetes API server that serves a list of pods.
+type fakeAPIServer struct {
+	t *testing.T
+
+	mu   sync.Mutex
+	pods []pod
+}
+
+func (f *fakeAPIServer) setPods(pods ...pod) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.pods = pods
+}
+
+func (f *fakeAPIServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if got, want := r.URL.Path, "/api/v1/namespaces/default/pods"; got != want {
+		f.t.Errorf("path: got %q, want %q", got, want)
+		http.NotFound(w, r)
+		return
+	}
+	if got, want := r.URL.Query().Get("labelSelector"), "mx/app=app,mx/version=v1"; got != want {
+		f.t.Errorf("label selector: got %q, want %q", got, want)
+	}
+	if got, want := r.Header.Get("Authorization"), "Bearer token"; got != want {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	json.NewEncoder(w).Encode(podList{Items: f.pods})
+}
+
+// runningPod returns a running pod with the provided name.
+func runningPod(name string) pod {
+	var p pod
+	p.Metadata.Name = name
+	p.Status.Phase = "Running"
+	return p
+}
+
+// newTestManager returns a manager that talks to a fake API server.
+func newTestManager(t *testing.T) (*manager, *fakeAPIServer) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	fake := &fakeAPIServer{t: t}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+	config := testConfig(t)
+	config.App.Name = "app"
+	m := newManager(ctx, config, newAPIClient(server.URL, "token", server.Client()))
+	return m, fake
+}
+
+// routingInfo returns the routing info of the provided component, as seen
+// by the provided group.
+func routingInfo(t *testing.T, m *manager, group, component string, routed bool) *protos.RoutingInfo {
+	t.Helper()
+	reply, err := m.getRoutingInfo(context.Background(), &GetRoutingInfoRequest{
+		RequestingGroup: group,
+		Component:       component,
+		Routed:          routed,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return reply.RoutingInfo
+}
+
+func TestManagerRouting(t *testing.T) {
+	ctx := context.Background()
+	m, _ := newTestManager(t)
+
+	// Activate a, and register two replicas of it.
+	if err := m.startComponent(ctx, &protos.ActivateComponentRequest{Component: a, Routed: true}); err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range []struct{ addr, pod string }{{"tcp://1.1.1.1:10000", "a-1"}, {"tcp://2.2.2.2:10000", "a-2"}} {
+		if err := m.registerReplica(ctx, &ReplicaToRegister{Group: a, Address: r.addr, Pod: r.pod}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The components to start.
+	reply, err := m.getComponentsToStart(ctx, &GetComponentsRequest{Group: a})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{a}, reply.Components); diff != "" {
+		t.Errorf("components (-want +got):\n%s", diff)
+	}
+
+	// Calls from main are routed to the replicas of a.
+	info := routingInfo(t, m, runtime.Main, a, true)
+	if diff := cmp.Diff([]string{"tcp://1.1.1.1:10000", "tcp://2.2.2.2:10000"}, info.Replicas); diff != "" {
+		t.Errorf("replicas (-want +got):\n%s", diff)
+	}
+	if info.Assignment == nil {
+		t.Errorf("routed component has no assignment")
+	}
+
+	// Calls between colocated components are local.
+	if info := routingInfo(t, m, b, c, false); !info.Local {
+		t.Errorf("b -> c: got %v, want local routing", info)
+	}
+
+	// Unregister a replica.
+	if err := m.unregisterReplica(ctx, &ReplicaToUnregister{Group: a, Address: "tcp://1.1.1.1:10000"}); err != nil {
+		t.Fatal(err)
+	}
+	info = routingInfo(t, m, runtime.Main, a, true)
+	if diff := cmp.Diff([]string{"tcp://2.2.2.2:10000"}, info.Replicas); diff != "" {
+		t.Errorf("replicas (-want +got):\n%s", diff)
+	}
+}
+
+func TestManagerCollectReplicas(t *testing.T) {
+	ctx := context.Background()
+	m, fake := newTestManager(t)
+
+	if err := m.startComponent(ctx, &protos.ActivateComponentRequest{Component: a}); err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range []struct{ addr, pod string }{
+		{"tcp://1.1.1.1:10000", "a-1"},
+		{"tcp://2.2.2.2:10000", "a-2"},
+		{"tcp://3.3.3.3:10000", "a-3"},
+	} {
+		if err := m.registerReplica(ctx, &ReplicaToRegister{Group: a, Address: r.addr, Pod: r.pod}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Pod a-1 is running, pod a-2 is being deleted, and pod a-3 is gone.
+	deleting := runningPod("a-2")
+	now := "2024-01-01T00:00:00Z"
+	deleting.Metadata.DeletionTimestamp = &now
+	fake.setPods(runningPod("a-1"), deleting)
+	if err := m.collectReplicas(ctx); err != nil {
+		t.Fatal(err)
+	}
+	info := routingInfo(t, m, runtime.Main, a, false)
+	if diff := cmp.Diff([]string{"tcp://1.1.1.1:10000"}, info.Replicas); diff != "" {
+		t.Errorf("replicas (-want +got):\n%s", diff)
+	}
+}
+
+func TestManagerCollectReplicasError(t *testing.T) {
+	m, _ := newTestManager(t)
+	m.api.token = "wrong"
+	if err := m.collectReplicas(context.Background()); err == nil {
+		t.Fatal("unexpected success with an invalid token")
+	}
+}
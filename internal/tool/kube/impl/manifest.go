@@ -0,0 +1,570 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package impl
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/sh3lk/mx/runtime/logging"
+	"github.com/sh3lk/mx/runtime/protos"
+	"google.golang.org/protobuf/encoding/protojson"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// Paths of the mx-kube binary and of the application binary inside the
+	// container image, and of the directory where the config is mounted.
+	toolPath   = "/mx/mx-kube"
+	binaryPath = "/mx/app"
+	configDir  = "/etc/mx"
+	configFile = "config.json"
+
+	// Container port on which the manager serves the babysitters.
+	managerPort = 8000
+
+	// Container port on which every mxn listens for calls from other mxns.
+	internalPort = 10000
+
+	// First container port assigned to the listeners that don't specify one.
+	firstListenerPort = 20000
+
+	// Labels attached to every object of an application version.
+	appLabel     = "mx/app"
+	versionLabel = "mx/version"
+	groupLabel   = "mx/group"
+
+	// Names of the environment variables that contain the name and the IP
+	// address of the pod of a babysitter.
+	podNameKey = "MX_POD_NAME"
+	podIPKey   = "MX_POD_IP"
+)
+
+// Validate validates the config. It is called when the config is parsed.
+func (c *KubeConfig) Validate() error {
+	for component, opts := range c.Replicas {
+		if opts.GetMin() < 0 {
+			return fmt.Errorf("replicas for %q: negative min %d", component, opts.GetMin())
+		}
+		if opts.GetMax() < 0 {
+			return fmt.Errorf("replicas for %q: negative max %d", component, opts.GetMax())
+		}
+		if min, max := replicaBounds(opts); max < min {
+			return fmt.Errorf("replicas for %q: max %d smaller than min %d", component, max, min)
+		}
+	}
+	for name, opts := range c.Listeners {
+		switch port := opts.GetPort(); {
+		case port < 0 || port > 65535:
+			return fmt.Errorf("listener %q: invalid port %d", name, port)
+		case port == managerPort || port == internalPort:
+			return fmt.Errorf("listener %q: port %d is reserved", name, port)
+		}
+	}
+	if c.Cpu < 0 {
+		return fmt.Errorf("negative cpu %d", c.Cpu)
+	}
+	return nil
+}
+
+// replicaBounds returns the minimum and maximum number of replicas of a
+// co-location group with the provided options, which may be nil.
+func replicaBounds(opts *KubeConfig_ReplicaOptions) (int32, int32) {
+	min := opts.GetMin()
+	if min == 0 {
+		min = 1
+	}
+	max := opts.GetMax()
+	if max == 0 {
+		max = min
+	}
+	return min, max
+}
+
+// Finalize fills in the defaults of the provided config, and assigns a port
+// to every listener in listeners that doesn't have one. It also points the
+// application binary at its location in the container image.
+func Finalize(config *KubeConfig, listeners []string) error {
+	if config.Image == "" {
+		return fmt.Errorf("no container image specified")
+	}
+	if config.Namespace == "" {
+		config.Namespace = "default"
+	}
+	if config.Cpu == 0 {
+		config.Cpu = 80
+	}
+	if config.Resources == nil {
+		config.Resources = &KubeConfig_Resources{}
+	}
+	if config.Resources.Cpu == "" {
+		config.Resources.Cpu = "100m"
+	}
+	if config.Listeners == nil {
+		config.Listeners = map[string]*KubeConfig_ListenerOptions{}
+	}
+
+	// Assign ports to listeners, in a deterministic order.
+	used := map[int32]string{}
+	for name, opts := range config.Listeners {
+		if opts.Port == 0 {
+			continue
+		}
+		if other, ok := used[opts.Port]; ok {
+			return fmt.Errorf("listeners %q and %q both use port %d", name, other, opts.Port)
+		}
+		used[opts.Port] = name
+	}
+	sort.Strings(listeners)
+	port := int32(firstListenerPort)
+	for _, name := range listeners {
+		opts, ok := config.Listeners[name]
+		if !ok {
+			opts = &KubeConfig_ListenerOptions{}
+			config.Listeners[name] = opts
+		}
+		if opts.Port != 0 {
+			continue
+		}
+		for used[port] != "" {
+			port++
+		}
+		opts.Port = port
+		used[port] = name
+	}
+
+	config.App.Binary = binaryPath
+	return nil
+}
+
+// Version returns a deployment id for an application, derived from the
+// contents of its binary and of its config. Generating the manifests of the
+// same binary and config twice yields the same deployment id, and thus the
+// same manifests.
+func Version(binary, config []byte) string {
+	h := sha256.New()
+	h.Write(binary)
+	h.Write(config)
+	return fmt.Sprintf("%x", h.Sum(nil))[:12]
+}
+
+// Groups returns the colocation groups of the provided components, keyed by
+// group name. A group is named after its first component.
+func Groups(app *protos.AppConfig, components []string) map[string][]string {
+	colocation := colocation(app)
+	groups := map[string][]string{}
+	for _, c := range components {
+		name, ok := colocation[c]
+		if !ok {
+			name = c
+		}
+		groups[name] = append(groups[name], c)
+	}
+	return groups
+}
+
+// colocation maps every colocated component to the name of its colocation
+// group. If a component is missing in the map, then it is in a colocation
+// group by itself.
+func colocation(app *protos.AppConfig) map[string]string {
+	colocation := map[string]string{}
+	for _, group := range app.Colocate {
+		for _, c := range group.Components {
+			colocation[c] = group.Components[0]
+		}
+	}
+	return colocation
+}
+
+// GenerateYAML returns the Kubernetes manifests, in YAML, that deploy the
+// version of the application described by the provided finalized config.
+// components are the components of the application, and listeners maps the
+// name of every listener to the component that exports it.
+//
+// The manifests contain:
+//
+//   - a ConfigMap with the config;
+//   - a Deployment and a Service for the manager, along with the
+//     ServiceAccount, Role, and RoleBinding that let the manager list the
+//     pods of the application;
+//   - a Deployment per colocation group, along with a
+//     HorizontalPodAutoscaler if the group is autoscaled; and
+//   - a Service per listener.
+//
+// GenerateYAML doesn't contact a Kubernetes cluster.
+func GenerateYAML(config *KubeConfig, components []string, listeners map[string]string) ([]byte, error) {
+	g := generator{config: config}
+	objects, err := g.objects(components, listeners)
+	if err != nil {
+		return nil, err
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "# Kubernetes manifests for version %s of app %q.\n", config.DepId, config.App.Name)
+	fmt.Fprintf(&b, "# Generated by \"mx kube deploy\". DO NOT EDIT.\n")
+	for _, obj := range objects {
+		b.WriteString("---\n")
+		enc := yaml.NewEncoder(&b)
+		enc.SetIndent(2)
+		if err := enc.Encode(obj); err != nil {
+			return nil, fmt.Errorf("encode %s %s: %w", obj.Kind, obj.Metadata.Name, err)
+		}
+		if err := enc.Close(); err != nil {
+			return nil, err
+		}
+	}
+	return b.Bytes(), nil
+}
+
+// generator generates the Kubernetes objects of an application version.
+type generator struct {
+	config *KubeConfig
+}
+
+func (g *generator) objects(components []string, listeners map[string]string) ([]object, error) {
+	// Validate the replication options.
+	known := map[string]bool{}
+	for _, c := range components {
+		known[c] = true
+	}
+	for c := range g.config.Replicas {
+		if !known[c] {
+			return nil, fmt.Errorf("replicas specified for unknown component %q", c)
+		}
+	}
+
+	// Serialize the config, to be stored in a ConfigMap.
+	// protojson deliberately randomizes its whitespace; reindent the config
+	// to get the same manifests every time.
+	data, err := protojson.Marshal(g.config)
+	if err != nil {
+		return nil, fmt.Errorf("encode config: %w", err)
+	}
+	var cfg bytes.Buffer
+	if err := json.Indent(&cfg, data, "", "  "); err != nil {
+		return nil, fmt.Errorf("encode config: %w", err)
+	}
+
+	objects := []object{
+		{
+			APIVersion: "v1",
+			Kind:       "ConfigMap",
+			Metadata:   g.metadata(g.name("config"), nil),
+			Data:       map[string]string{configFile: cfg.String() + "\n"},
+		},
+	}
+	objects = append(objects, g.manager()...)
+
+	// Generate the objects of every colocation group, in a deterministic
+	// order.
+	groups := Groups(g.config.App, components)
+	names := make([]string, 0, len(groups))
+	for name := range groups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	colocation := colocation(g.config.App)
+	groupOf := func(c string) string {
+		if name, ok := colocation[c]; ok {
+			return name
+		}
+		return c
+	}
+	exported := map[string][]string{} // listeners, by group
+	for lis, c := range listeners {
+		exported[groupOf(c)] = append(exported[groupOf(c)], lis)
+	}
+	labels := map[string]string{}
+	for _, name := range names {
+		label := objectName(logging.ShortenComponent(name))
+		if other, ok := labels[label]; ok {
+			return nil, fmt.Errorf("colocation groups %q and %q have the same Kubernetes name %q", name, other, label)
+		}
+		labels[label] = name
+		sort.Strings(exported[name])
+		objects = append(objects, g.group(name, label, groups[name], exported[name])...)
+	}
+
+	// Generate the listener services.
+	lisNames := make([]string, 0, len(listeners))
+	for lis := range listeners {
+		lisNames = append(lisNames, lis)
+	}
+	sort.Strings(lisNames)
+	for _, lis := range lisNames {
+		group := objectName(logging.ShortenComponent(groupOf(listeners[lis])))
+		objects = append(objects, g.listener(lis, group))
+	}
+	return objects, nil
+}
+
+// name returns the name of an object of the application version.
+func (g *generator) name(parts ...string) string {
+	parts = append([]string{g.config.App.Name}, parts...)
+	return objectName(append(parts, g.config.DepId)...)
+}
+
+// labels returns the labels of the objects of the application version. If
+// group is not empty, the labels also include the colocation group.
+func (g *generator) labels(group string) map[string]string {
+	labels := map[string]string{
+		appLabel:     objectName(g.config.App.Name),
+		versionLabel: g.config.DepId,
+	}
+	if group != "" {
+		labels[groupLabel] = group
+	}
+	return labels
+}
+
+func (g *generator) metadata(name string, labels map[string]string) metadata {
+	if labels == nil {
+		labels = g.labels("")
+	}
+	return metadata{Name: name, Namespace: g.config.Namespace, Labels: labels}
+}
+
+// managerAddr returns the address of the manager service.
+func (g *generator) managerAddr() string {
+	return fmt.Sprintf("http://%s:%d", g.name("manager"), managerPort)
+}
+
+// manager returns the objects that run the manager.
+func (g *generator) manager() []object {
+	name := g.name("manager")
+	labels := g.labels("manager")
+	c := container{
+		Name:    "manager",
+		Image:   g.config.Image,
+		Command: []string{toolPath, "manager", "--config=" + filepath.Join(configDir, configFile)},
+		Ports:   []containerPort{{Name: "manager", ContainerPort: managerPort}},
+		Env: []envVar{
+			{Name: podNameKey, ValueFrom: fieldRef("metadata.name")},
+		},
+		VolumeMounts: []volumeMount{{Name: "config", MountPath: configDir}},
+	}
+	return []object{
+		{
+			APIVersion: "v1",
+			Kind:       "ServiceAccount",
+			Metadata:   g.metadata(name, nil),
+		},
+		{
+			APIVersion: "rbac.authorization.k8s.io/v1",
+			Kind:       "Role",
+			Metadata:   g.metadata(name, nil),
+			Rules: []policyRule{{
+				APIGroups: []string{""},
+				Resources: []string{"pods"},
+				Verbs:     []string{"get", "list", "watch"},
+			}},
+		},
+		{
+			APIVersion: "rbac.authorization.k8s.io/v1",
+			Kind:       "RoleBinding",
+			Metadata:   g.metadata(name, nil),
+			RoleRef:    &roleRef{APIGroup: "rbac.authorization.k8s.io", Kind: "Role", Name: name},
+			Subjects:   []subject{{Kind: "ServiceAccount", Name: name, Namespace: g.config.Namespace}},
+		},
+		{
+			APIVersion: "apps/v1",
+			Kind:       "Deployment",
+			Metadata:   g.metadata(name, labels),
+			Spec: deploymentSpec{
+				Replicas: 1,
+				Selector: labelSelector{MatchLabels: labels},
+				Template: podTemplate{
+					Metadata: metadata{Labels: labels},
+					Spec: podSpec{
+						ServiceAccountName: name,
+						Containers:         []container{c},
+						Volumes:            []volume{g.configVolume()},
+					},
+				},
+			},
+		},
+		{
+			APIVersion: "v1",
+			Kind:       "Service",
+			Metadata:   g.metadata(name, labels),
+			Spec: serviceSpec{
+				Type:     "ClusterIP",
+				Selector: labels,
+				Ports:    []servicePort{{Name: "manager", Port: managerPort, TargetPort: managerPort}},
+			},
+		},
+	}
+}
+
+// group returns the objects that run the provided colocation group, named
+// label, that exports the provided listeners.
+func (g *generator) group(group, label string, components, listeners []string) []object {
+	var opts *KubeConfig_ReplicaOptions
+	for _, c := range components {
+		if o, ok := g.config.Replicas[c]; ok {
+			opts = o
+		}
+	}
+	min, max := replicaBounds(opts)
+
+	name := g.name(label)
+	labels := g.labels(label)
+	ports := []containerPort{{Name: "internal", ContainerPort: internalPort}}
+	for _, lis := range listeners {
+		ports = append(ports, containerPort{
+			Name:          portName(lis),
+			ContainerPort: g.config.Listeners[lis].Port,
+		})
+	}
+	requests := map[string]string{"cpu": g.config.Resources.Cpu}
+	if g.config.Resources.Memory != "" {
+		requests["memory"] = g.config.Resources.Memory
+	}
+	c := container{
+		Name:  "mxn",
+		Image: g.config.Image,
+		Command: []string{
+			toolPath, "babysitter",
+			"--config=" + filepath.Join(configDir, configFile),
+			"--group=" + group,
+			"--manager=" + g.managerAddr(),
+		},
+		Ports: ports,
+		Env: []envVar{
+			{Name: podNameKey, ValueFrom: fieldRef("metadata.name")},
+			{Name: podIPKey, ValueFrom: fieldRef("status.podIP")},
+		},
+		Resources:    &resources{Requests: requests},
+		VolumeMounts: []volumeMount{{Name: "config", MountPath: configDir}},
+	}
+	objects := []object{{
+		APIVersion: "apps/v1",
+		Kind:       "Deployment",
+		Metadata:   g.metadata(name, labels),
+		Spec: deploymentSpec{
+			Replicas: min,
+			Selector: labelSelector{MatchLabels: labels},
+			Template: podTemplate{
+				Metadata: metadata{Labels: labels},
+				Spec: podSpec{
+					Containers: []container{c},
+					Volumes:    []volume{g.configVolume()},
+				},
+			},
+		},
+	}}
+	if max > min {
+		objects = append(objects, object{
+			APIVersion: "autoscaling/v2",
+			Kind:       "HorizontalPodAutoscaler",
+			Metadata:   g.metadata(name, labels),
+			Spec: hpaSpec{
+				ScaleTargetRef: crossVersionObjectReference{APIVersion: "apps/v1", Kind: "Deployment", Name: name},
+				MinReplicas:    min,
+				MaxReplicas:    max,
+				Metrics: []metricSpec{{
+					Type: "Resource",
+					Resource: resourceMetricSource{
+						Name:   "cpu",
+						Target: metricTarget{Type: "Utilization", AverageUtilization: g.config.Cpu},
+					},
+				}},
+			},
+		})
+	}
+	return objects
+}
+
+// listener returns the service of the provided listener, which is exported
+// by the colocation group named group.
+func (g *generator) listener(lis, group string) object {
+	opts := g.config.Listeners[lis]
+	typ := "ClusterIP"
+	if opts.Public {
+		typ = "LoadBalancer"
+	}
+	return object{
+		APIVersion: "v1",
+		Kind:       "Service",
+		Metadata:   g.metadata(objectName(g.config.App.Name, lis), nil),
+		Spec: serviceSpec{
+			Type:     typ,
+			Selector: g.labels(group),
+			Ports:    []servicePort{{Name: portName(lis), Port: 80, TargetPort: opts.Port}},
+		},
+	}
+}
+
+func (g *generator) configVolume() volume {
+	return volume{Name: "config", ConfigMap: &configMapVolume{Name: g.name("config")}}
+}
+
+// objectName returns a valid Kubernetes object name (i.e., a DNS-1123 label)
+// made of the provided parts. Invalid characters are replaced with dashes.
+func objectName(parts ...string) string {
+	var b strings.Builder
+	for _, part := range parts {
+		for _, r := range strings.ToLower(part) {
+			switch {
+			case 'a' <= r && r <= 'z', '0' <= r && r <= '9':
+				b.WriteRune(r)
+			default:
+				b.WriteByte('-')
+			}
+		}
+		b.WriteByte('-')
+	}
+	name := strings.Trim(b.String(), "-")
+	for strings.Contains(name, "--") {
+		name = strings.ReplaceAll(name, "--", "-")
+	}
+
+	// Names are limited to 63 characters. Shorten longer names, and keep
+	// them unique by appending a hash of the full name.
+	const maxLen = 63
+	if len(name) > maxLen {
+		h := fmt.Sprintf("%x", sha256.Sum256([]byte(name)))[:8]
+		name = strings.TrimRight(name[:maxLen-len(h)-1], "-") + "-" + h
+	}
+	return name
+}
+
+// portName returns the name of the container port of the provided listener.
+// Port names are limited to 15 characters.
+func portName(lis string) string {
+	name := objectName(lis)
+	if len(name) > 15 {
+		name = strings.TrimRight(name[:15], "-")
+	}
+	return name
+}
+
+// LoadConfig parses a config stored in the ConfigMap of an application
+// version.
+func LoadConfig(data []byte) (*KubeConfig, error) {
+	config := &KubeConfig{}
+	if err := protojson.Unmarshal(data, config); err != nil {
+		return nil, fmt.Errorf("parse config: %w", err)
+	}
+	if config.App == nil {
+		return nil, fmt.Errorf("parse config: no app config")
+	}
+	return config, nil
+}
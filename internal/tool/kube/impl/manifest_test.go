@@ -0,0 +1,244 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package impl
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sh3lk/mx/runtime"
+	"github.com/sh3lk/mx/runtime/protos"
+	"google.golang.org/protobuf/testing/protocmp"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	a = "github.com/foo/a/A"
+	b = "github.com/foo/b/B"
+	c = "github.com/foo/c/C"
+)
+
+// testConfig returns a finalized config for an app with components Main, A,
+// B, and C, where B and C are colocated, and Main exports listener "lis".
+func testConfig(t *testing.T) *KubeConfig {
+	t.Helper()
+	config := &KubeConfig{
+		App: &protos.AppConfig{
+			Name:     "App",
+			Binary:   "./app",
+			Colocate: []*protos.ComponentGroup{{Components: []string{b, c}}},
+		},
+		DepId: "v1",
+		Image: "example.com/app:v1",
+		Listeners: map[string]*KubeConfig_ListenerOptions{
+			"lis": {Public: true},
+		},
+		Replicas: map[string]*KubeConfig_ReplicaOptions{
+			c: {Min: 2, Max: 5},
+		},
+	}
+	if err := Finalize(config, []string{"lis"}); err != nil {
+		t.Fatal(err)
+	}
+	return config
+}
+
+// decode decodes the provided YAML manifests.
+func decode(t *testing.T, manifests []byte) []map[string]any {
+	t.Helper()
+	var objects []map[string]any
+	dec := yaml.NewDecoder(bytes.NewReader(manifests))
+	for {
+		var obj map[string]any
+		err := dec.Decode(&obj)
+		if errors.Is(err, io.EOF) {
+			return objects
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		objects = append(objects, obj)
+	}
+}
+
+// lookup returns the value at the provided path of the provided object.
+func lookup(obj any, path ...string) any {
+	for _, key := range path {
+		m, ok := obj.(map[string]any)
+		if !ok {
+			return nil
+		}
+		obj = m[key]
+	}
+	return obj
+}
+
+func TestGenerateYAML(t *testing.T) {
+	config := testConfig(t)
+	components := []string{runtime.Main, a, b, c}
+	listeners := map[string]string{"lis": runtime.Main}
+	manifests, err := GenerateYAML(config, components, listeners)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	objects := map[string]map[string]any{}
+	for _, obj := range decode(t, manifests) {
+		key := obj["kind"].(string) + "/" + lookup(obj, "metadata", "name").(string)
+		got = append(got, key)
+		objects[key] = obj
+		if ns := lookup(obj, "metadata", "namespace"); ns != "default" {
+			t.Errorf("%s: got namespace %v, want default", key, ns)
+		}
+	}
+	want := []string{
+		"ConfigMap/app-config-v1",
+		"ServiceAccount/app-manager-v1",
+		"Role/app-manager-v1",
+		"RoleBinding/app-manager-v1",
+		"Deployment/app-manager-v1",
+		"Service/app-manager-v1",
+		"Deployment/app-a-a-v1",
+		"Deployment/app-b-b-v1",
+		"HorizontalPodAutoscaler/app-b-b-v1",
+		"Deployment/app-mx-main-v1",
+		"Service/app-lis",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("objects (-want +got):\n%s", diff)
+	}
+
+	// Check the replicas and autoscaling of the groups.
+	for name, want := range map[string]int{"app-a-a-v1": 1, "app-b-b-v1": 2, "app-mx-main-v1": 1} {
+		if got := lookup(objects["Deployment/"+name], "spec", "replicas"); got != want {
+			t.Errorf("%s: got %v replicas, want %d", name, got, want)
+		}
+	}
+	hpa := objects["HorizontalPodAutoscaler/app-b-b-v1"]
+	if min, max := lookup(hpa, "spec", "minReplicas"), lookup(hpa, "spec", "maxReplicas"); min != 2 || max != 5 {
+		t.Errorf("hpa: got (%v, %v) replicas, want (2, 5)", min, max)
+	}
+
+	// Check that the listener is served by the main group.
+	svc := objects["Service/app-lis"]
+	if got := lookup(svc, "spec", "type"); got != "LoadBalancer" {
+		t.Errorf("listener service: got type %v, want LoadBalancer", got)
+	}
+	if got := lookup(svc, "spec", "selector", groupLabel); got != "mx-main" {
+		t.Errorf("listener service: got group %v, want mx-main", got)
+	}
+
+	// Check that the config round trips through the ConfigMap.
+	data := lookup(objects["ConfigMap/app-config-v1"], "data", configFile).(string)
+	loaded, err := LoadConfig([]byte(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(config, loaded, protocmp.Transform()); diff != "" {
+		t.Errorf("config (-want +got):\n%s", diff)
+	}
+	if got := loaded.App.Binary; got != binaryPath {
+		t.Errorf("binary: got %q, want %q", got, binaryPath)
+	}
+}
+
+func TestGenerateYAMLIsDeterministic(t *testing.T) {
+	components := []string{runtime.Main, a, b, c}
+	listeners := map[string]string{"lis": runtime.Main}
+	want, err := GenerateYAML(testConfig(t), components, listeners)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 10; i++ {
+		got, err := GenerateYAML(testConfig(t), components, listeners)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Fatalf("manifests differ:\n%s", cmp.Diff(string(want), string(got)))
+		}
+	}
+}
+
+func TestFinalizeListenerPorts(t *testing.T) {
+	config := &KubeConfig{
+		App:   &protos.AppConfig{Name: "app"},
+		Image: "app:v1",
+		Listeners: map[string]*KubeConfig_ListenerOptions{
+			"b": {Port: firstListenerPort},
+		},
+	}
+	if err := Finalize(config, []string{"c", "a", "b"}); err != nil {
+		t.Fatal(err)
+	}
+	got := map[string]int32{}
+	for name, opts := range config.Listeners {
+		got[name] = opts.Port
+	}
+	want := map[string]int32{
+		"a": firstListenerPort + 1,
+		"b": firstListenerPort,
+		"c": firstListenerPort + 2,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ports (-want +got):\n%s", diff)
+	}
+}
+
+func TestInvalidConfig(t *testing.T) {
+	for _, test := range []struct {
+		section string
+		want    string
+	}{
+		{`replicas = { "a" = { min = -1 } }`, "negative min"},
+		{`replicas = { "a" = { min = 3, max = 2 } }`, "smaller than min"},
+		{`listeners = { "a" = { port = 10000 } }`, "reserved"},
+		{`listeners = { "a" = { port = 70000 } }`, "invalid port"},
+		{`cpu = -1`, "negative cpu"},
+	} {
+		t.Run(test.want, func(t *testing.T) {
+			var config KubeConfig
+			sections := map[string]string{"kube": test.section}
+			err := runtime.ParseConfigSection("github.com/sh3lk/mx/kube", "kube", sections, &config)
+			if err == nil || !strings.Contains(err.Error(), test.want) {
+				t.Fatalf("got error %v, want %q", err, test.want)
+			}
+		})
+	}
+}
+
+func TestObjectName(t *testing.T) {
+	for _, test := range []struct {
+		parts []string
+		want  string
+	}{
+		{[]string{"app", "main.Main", "v1"}, "app-main-main-v1"},
+		{[]string{"My_App", "--x--"}, "my-app-x"},
+		{[]string{strings.Repeat("a", 100)}, strings.Repeat("a", 54) + "-28165978"},
+	} {
+		got := objectName(test.parts...)
+		if got != test.want {
+			t.Errorf("objectName(%q): got %q, want %q", test.parts, got, test.want)
+		}
+		if len(got) > 63 {
+			t.Errorf("objectName(%q): %q longer than 63 characters", test.parts, got)
+		}
+	}
+}
@@ -0,0 +1,187 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package impl
+
+// This file contains the subset of the Kubernetes API types [1] used by the
+// generated manifests. We declare the types here, rather than depend on the
+// Kubernetes client libraries, to keep manifest generation lightweight.
+//
+// [1]: https://kubernetes.io/docs/reference/kubernetes-api/
+
+// object is a Kubernetes object. Only the fields relevant to the object's
+// kind are set.
+type object struct {
+	APIVersion string            `yaml:"apiVersion"`
+	Kind       string            `yaml:"kind"`
+	Metadata   metadata          `yaml:"metadata"`
+	Spec       any               `yaml:"spec,omitempty"`
+	Data       map[string]string `yaml:"data,omitempty"`     // ConfigMap
+	Rules      []policyRule      `yaml:"rules,omitempty"`    // Role
+	RoleRef    *roleRef          `yaml:"roleRef,omitempty"`  // RoleBinding
+	Subjects   []subject         `yaml:"subjects,omitempty"` // RoleBinding
+}
+
+type metadata struct {
+	Name      string            `yaml:"name,omitempty"`
+	Namespace string            `yaml:"namespace,omitempty"`
+	Labels    map[string]string `yaml:"labels,omitempty"`
+}
+
+type policyRule struct {
+	APIGroups []string `yaml:"apiGroups"`
+	Resources []string `yaml:"resources"`
+	Verbs     []string `yaml:"verbs"`
+}
+
+type roleRef struct {
+	APIGroup string `yaml:"apiGroup"`
+	Kind     string `yaml:"kind"`
+	Name     string `yaml:"name"`
+}
+
+type subject struct {
+	Kind      string `yaml:"kind"`
+	Name      string `yaml:"name"`
+	Namespace string `yaml:"namespace"`
+}
+
+type deploymentSpec struct {
+	Replicas int32         `yaml:"replicas"`
+	Selector labelSelector `yaml:"selector"`
+	Template podTemplate   `yaml:"template"`
+}
+
+type labelSelector struct {
+	MatchLabels map[string]string `yaml:"matchLabels"`
+}
+
+type podTemplate struct {
+	Metadata metadata `yaml:"metadata"`
+	Spec     podSpec  `yaml:"spec"`
+}
+
+type podSpec struct {
+	ServiceAccountName string      `yaml:"serviceAccountName,omitempty"`
+	Containers         []container `yaml:"containers"`
+	Volumes            []volume    `yaml:"volumes,omitempty"`
+}
+
+type container struct {
+	Name         string          `yaml:"name"`
+	Image        string          `yaml:"image"`
+	Command      []string        `yaml:"command"`
+	Ports        []containerPort `yaml:"ports,omitempty"`
+	Env          []envVar        `yaml:"env,omitempty"`
+	Resources    *resources      `yaml:"resources,omitempty"`
+	VolumeMounts []volumeMount   `yaml:"volumeMounts,omitempty"`
+}
+
+type containerPort struct {
+	Name          string `yaml:"name"`
+	ContainerPort int32  `yaml:"containerPort"`
+}
+
+type envVar struct {
+	Name      string        `yaml:"name"`
+	ValueFrom *envVarSource `yaml:"valueFrom,omitempty"`
+}
+
+type envVarSource struct {
+	FieldRef objectFieldSelector `yaml:"fieldRef"`
+}
+
+type objectFieldSelector struct {
+	FieldPath string `yaml:"fieldPath"`
+}
+
+// fieldRef returns an environment variable source that exposes the provided
+// field of the pod.
+func fieldRef(path string) *envVarSource {
+	return &envVarSource{FieldRef: objectFieldSelector{FieldPath: path}}
+}
+
+type resources struct {
+	Requests map[string]string `yaml:"requests"`
+}
+
+type volumeMount struct {
+	Name      string `yaml:"name"`
+	MountPath string `yaml:"mountPath"`
+}
+
+type volume struct {
+	Name      string           `yaml:"name"`
+	ConfigMap *configMapVolume `yaml:"configMap,omitempty"`
+}
+
+type configMapVolume struct {
+	Name string `yaml:"name"`
+}
+
+type serviceSpec struct {
+	Type     string            `yaml:"type"`
+	Selector map[string]string `yaml:"selector"`
+	Ports    []servicePort     `yaml:"ports"`
+}
+
+type servicePort struct {
+	Name       string `yaml:"name"`
+	Port       int32  `yaml:"port"`
+	TargetPort int32  `yaml:"targetPort"`
+}
+
+type hpaSpec struct {
+	ScaleTargetRef crossVersionObjectReference `yaml:"scaleTargetRef"`
+	MinReplicas    int32                       `yaml:"minReplicas"`
+	MaxReplicas    int32                       `yaml:"maxReplicas"`
+	Metrics        []metricSpec                `yaml:"metrics"`
+}
+
+type crossVersionObjectReference struct {
+	APIVersion string `yaml:"apiVersion"`
+	Kind       string `yaml:"kind"`
+	Name       string `yaml:"name"`
+}
+
+type metricSpec struct {
+	Type     string               `yaml:"type"`
+	Resource resourceMetricSource `yaml:"resource"`
+}
+
+type resourceMetricSource struct {
+	Name   string       `yaml:"name"`
+	Target metricTarget `yaml:"target"`
+}
+
+type metricTarget struct {
+	Type               string `yaml:"type"`
+	AverageUtilization int32  `yaml:"averageUtilization"`
+}
+
+// podList is a list of pods, as returned by the Kubernetes API server.
+type podList struct {
+	Items []pod `json:"items"`
+}
+
+// pod is a pod, as returned by the Kubernetes API server.
+type pod struct {
+	Metadata struct {
+		Name              string  `json:"name"`
+		DeletionTimestamp *string `json:"deletionTimestamp,omitempty"`
+	} `json:"metadata"`
+	Status struct {
+		Phase string `json:"phase"`
+	} `json:"status"`
+}
@@ -0,0 +1,34 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package kube implements the "mx kube" deployer, which deploys MX
+// applications on vanilla Kubernetes clusters. The commands are run by the
+// mx-kube binary, to which "mx kube" dispatches.
+package kube
+
+import (
+	itool "github.com/sh3lk/mx/internal/tool"
+	"github.com/sh3lk/mx/runtime/tool"
+)
+
+var (
+	Commands = map[string]*tool.Command{
+		"deploy":  &deployCmd,
+		"version": itool.VersionCmd("mx kube"),
+
+		// Hidden commands.
+		"manager":    &managerCmd,
+		"babysitter": &babysitterCmd,
+	}
+)
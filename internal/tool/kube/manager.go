@@ -0,0 +1,64 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kube
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/sh3lk/mx/internal/tool/kube/impl"
+	"github.com/sh3lk/mx/runtime/tool"
+)
+
+var (
+	managerFlags   = flag.NewFlagSet("manager", flag.ContinueOnError)
+	managerConfig  = managerFlags.String("config", "", "Path of the config of the application version.")
+	managerAddress = managerFlags.String("address", ":8000", "Address to listen on.")
+
+	managerCmd = tool.Command{
+		Name:        "manager",
+		Description: "The mx kube manager",
+		Help: `Usage:
+  mx kube manager --config=<file> [--address=<address>]
+
+Flags:
+  -h, --help	Print this help message.
+` + tool.FlagsHelp(managerFlags),
+		Flags:  managerFlags,
+		Hidden: true,
+		Fn: func(ctx context.Context, _ []string) error {
+			config, err := readConfig(*managerConfig)
+			if err != nil {
+				return err
+			}
+			return impl.RunManager(ctx, config, *managerAddress)
+		},
+	}
+)
+
+// readConfig reads the config of an application version, as written by "mx
+// kube deploy" in the ConfigMap of the version.
+func readConfig(file string) (*impl.KubeConfig, error) {
+	if file == "" {
+		return nil, fmt.Errorf("no config file provided")
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("read config: %w", err)
+	}
+	return impl.LoadConfig(data)
+}
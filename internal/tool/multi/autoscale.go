@@ -0,0 +1,260 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package multi
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sh3lk/mx/runtime/envelope"
+)
+
+const (
+	// The default target CPU usage of a replica, in cores.
+	defaultTargetCPU = 0.5
+
+	// The default interval between two runs of the autoscaler.
+	defaultAutoscaleInterval = 10 * time.Second
+
+	// The autoscaler doesn't change the number of replicas if the measured
+	// usage is within this fraction of the target usage.
+	autoscaleTolerance = 0.1
+
+	// The number of clock ticks per second used by /proc/<pid>/stat. This is
+	// the value of USER_HZ, which is 100 on all supported Linux platforms.
+	clockTicks = 100
+)
+
+// Validate validates the multi section of a config. It is called when the
+// section is parsed.
+func (c *MultiConfig) Validate() error {
+	for component, opts := range c.Replicas {
+		if opts.GetMin() < 0 {
+			return fmt.Errorf("replicas for %q: negative min %d", component, opts.GetMin())
+		}
+		if opts.GetMax() < 0 {
+			return fmt.Errorf("replicas for %q: negative max %d", component, opts.GetMax())
+		}
+		if min, max := replicaBounds(opts); max < min {
+			return fmt.Errorf("replicas for %q: max %d smaller than min %d", component, max, min)
+		}
+	}
+	if a := c.Autoscale; a != nil {
+		if math.IsNaN(a.Cpu) || math.IsInf(a.Cpu, 0) {
+			return fmt.Errorf("autoscale: invalid cpu %v", a.Cpu)
+		}
+		if math.IsNaN(a.Load) || math.IsInf(a.Load, 0) || a.Load < 0 {
+			return fmt.Errorf("autoscale: invalid load %v", a.Load)
+		}
+		if a.Interval != "" {
+			d, err := time.ParseDuration(a.Interval)
+			if err != nil {
+				return fmt.Errorf("autoscale: invalid interval: %w", err)
+			}
+			if d <= 0 {
+				return fmt.Errorf("autoscale: non-positive interval %v", d)
+			}
+		}
+	}
+	return nil
+}
+
+// replicaBounds returns the minimum and maximum number of replicas of a
+// co-location group with the provided options, which may be nil.
+func replicaBounds(opts *MultiConfig_ReplicaOptions) (int, int) {
+	min := int(opts.GetMin())
+	if min == 0 {
+		min = defaultReplication
+	}
+	max := int(opts.GetMax())
+	if max == 0 {
+		max = min
+	}
+	return min, max
+}
+
+// autoscaler picks the number of replicas of every autoscaled co-location
+// group, based on the measured usage of the group's replicas.
+type autoscaler struct {
+	targetCPU  float64       // target cores per replica, or <= 0
+	targetLoad float64       // target load per replica, or <= 0
+	interval   time.Duration // time between two runs
+
+	mu  sync.Mutex                       // guards cpu
+	cpu map[*envelope.Envelope]cpuSample // last CPU sample, by replica
+}
+
+// A cpuSample is a measurement of the CPU time used by a process.
+type cpuSample struct {
+	at  time.Time     // when the sample was taken
+	cpu time.Duration // total CPU time used by the process at time at
+}
+
+// usage is the average usage of the replicas of a co-location group.
+type usage struct {
+	cpu     float64 // cores per replica
+	hasCPU  bool    // is cpu known?
+	load    float64 // load per replica
+	hasLoad bool    // is load known?
+}
+
+// newAutoscaler returns a new autoscaler with the provided options, which may
+// be nil.
+func newAutoscaler(opts *MultiConfig_AutoscaleOptions) *autoscaler {
+	a := &autoscaler{
+		targetCPU:  opts.GetCpu(),
+		targetLoad: opts.GetLoad(),
+		interval:   defaultAutoscaleInterval,
+		cpu:        map[*envelope.Envelope]cpuSample{},
+	}
+	if a.targetCPU == 0 {
+		a.targetCPU = defaultTargetCPU
+	}
+	if opts.GetInterval() != "" {
+		// The interval was checked by MultiConfig.Validate.
+		a.interval, _ = time.ParseDuration(opts.GetInterval())
+	}
+	return a
+}
+
+// measure returns the average usage of the provided replicas. Replicas whose
+// usage cannot be measured are ignored.
+func (a *autoscaler) measure(envelopes []*envelope.Envelope) usage {
+	var cores, load float64
+	var numCPU, numLoad int
+	for _, e := range envelopes {
+		if c, ok := a.cores(e); ok {
+			cores += c
+			numCPU++
+		}
+		if report, err := e.GetLoad(); err == nil {
+			for _, c := range report.Loads {
+				for _, slice := range c.Load {
+					load += slice.Load
+				}
+			}
+			numLoad++
+		}
+	}
+
+	var u usage
+	if numCPU > 0 {
+		u.cpu = cores / float64(numCPU)
+		u.hasCPU = true
+	}
+	if numLoad > 0 {
+		u.load = load / float64(numLoad)
+		u.hasLoad = true
+	}
+	return u
+}
+
+// cores returns the number of cores used by the provided replica since it
+// was last measured. It returns false if the replica hasn't been measured
+// before, or if its CPU usage is not available.
+func (a *autoscaler) cores(e *envelope.Envelope) (float64, bool) {
+	pid, ok := e.Pid()
+	if !ok {
+		return 0, false
+	}
+	cpu, err := cpuTime(pid)
+	if err != nil {
+		return 0, false
+	}
+	now := time.Now()
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	prev, ok := a.cpu[e]
+	a.cpu[e] = cpuSample{at: now, cpu: cpu}
+	if !ok || !now.After(prev.at) {
+		return 0, false
+	}
+	return float64(cpu-prev.cpu) / float64(now.Sub(prev.at)), true
+}
+
+// forget discards the state kept for the provided replica.
+func (a *autoscaler) forget(e *envelope.Envelope) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	delete(a.cpu, e)
+}
+
+// desiredReplicas returns the number of replicas, between min and max, that
+// brings the usage of a group with n replicas closest to the targets.
+//
+// Like the Kubernetes horizontal pod autoscaler, desiredReplicas scales the
+// number of replicas in proportion to the ratio between the measured and the
+// target usage. To avoid flapping, the number of replicas shrinks by at most
+// one at a time.
+func (a *autoscaler) desiredReplicas(n, min, max int, u usage) int {
+	ratio := -1.0
+	if a.targetCPU > 0 && u.hasCPU {
+		ratio = math.Max(ratio, u.cpu/a.targetCPU)
+	}
+	if a.targetLoad > 0 && u.hasLoad {
+		ratio = math.Max(ratio, u.load/a.targetLoad)
+	}
+
+	desired := n
+	if ratio >= 0 && math.Abs(ratio-1) > autoscaleTolerance {
+		desired = int(math.Ceil(float64(n) * ratio))
+	}
+	if desired < n-1 {
+		desired = n - 1
+	}
+	if desired < min {
+		desired = min
+	}
+	if desired > max {
+		desired = max
+	}
+	return desired
+}
+
+// cpuTime returns the total CPU time used by the process with the provided
+// pid. It is only supported on Linux.
+func cpuTime(pid int) (time.Duration, error) {
+	data, err := os.ReadFile(fmt.Sprintf("/proc/%d/stat", pid))
+	if err != nil {
+		return 0, err
+	}
+
+	// The second field of /proc/<pid>/stat is the parenthesized command name,
+	// which may contain spaces. The fields after it are space separated, with
+	// utime and stime being the 14th and 15th fields overall.
+	stat := string(data)
+	i := strings.LastIndexByte(stat, ')')
+	if i < 0 {
+		return 0, fmt.Errorf("invalid /proc/%d/stat", pid)
+	}
+	fields := strings.Fields(stat[i+1:])
+	if len(fields) < 13 {
+		return 0, fmt.Errorf("invalid /proc/%d/stat", pid)
+	}
+	var ticks int64
+	for _, field := range fields[11:13] {
+		t, err := strconv.ParseInt(field, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid /proc/%d/stat: %w", pid, err)
+		}
+		ticks += t
+	}
+	return time.Duration(ticks) * time.Second / clockTicks, nil
+}
@@ -0,0 +1,123 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package multi
+
+import (
+	"os"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	mxruntime "github.com/sh3lk/mx/runtime"
+)
+
+func TestParseReplicaConfig(t *testing.T) {
+	const section = `
+replicas = { "a" = { min = 1, max = 5 }, "b" = { min = 3 } }
+autoscale = { cpu = 0.8, load = 100, interval = "5s" }
+`
+	var config MultiConfig
+	sections := map[string]string{shortConfigKey: section}
+	if err := mxruntime.ParseConfigSection(configKey, shortConfigKey, sections, &config); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range []struct {
+		component string
+		min, max  int
+	}{
+		{"a", 1, 5},
+		{"b", 3, 3},
+		{"c", defaultReplication, defaultReplication},
+	} {
+		min, max := replicaBounds(config.Replicas[test.component])
+		if min != test.min || max != test.max {
+			t.Errorf("replicaBounds(%q): got (%d, %d), want (%d, %d)", test.component, min, max, test.min, test.max)
+		}
+	}
+
+	a := newAutoscaler(config.Autoscale)
+	if a.targetCPU != 0.8 || a.targetLoad != 100 || a.interval != 5*time.Second {
+		t.Errorf("newAutoscaler: got (%v, %v, %v), want (0.8, 100, 5s)", a.targetCPU, a.targetLoad, a.interval)
+	}
+}
+
+func TestInvalidReplicaConfig(t *testing.T) {
+	for _, test := range []struct {
+		section string
+		want    string
+	}{
+		{`replicas = { "a" = { min = -1 } }`, "negative min"},
+		{`replicas = { "a" = { min = 3, max = 2 } }`, "smaller than min"},
+		{`autoscale = { interval = "soon" }`, "invalid interval"},
+		{`autoscale = { interval = "-1s" }`, "non-positive interval"},
+		{`autoscale = { load = -1.0 }`, "invalid load"},
+	} {
+		t.Run(test.want, func(t *testing.T) {
+			var config MultiConfig
+			sections := map[string]string{shortConfigKey: test.section}
+			err := mxruntime.ParseConfigSection(configKey, shortConfigKey, sections, &config)
+			if err == nil || !strings.Contains(err.Error(), test.want) {
+				t.Fatalf("got error %v, want %q", err, test.want)
+			}
+		})
+	}
+}
+
+func TestDesiredReplicas(t *testing.T) {
+	a := &autoscaler{targetCPU: 0.5, targetLoad: 100}
+	for _, test := range []struct {
+		name     string
+		n        int
+		u        usage
+		min, max int
+		want     int
+	}{
+		{"NoMetrics", 3, usage{}, 1, 10, 3},
+		{"OnTarget", 3, usage{cpu: 0.5, hasCPU: true, load: 100, hasLoad: true}, 1, 10, 3},
+		{"WithinTolerance", 3, usage{cpu: 0.54, hasCPU: true}, 1, 10, 3},
+		{"CPUBound", 2, usage{cpu: 1.0, hasCPU: true, load: 10, hasLoad: true}, 1, 10, 4},
+		{"LoadBound", 2, usage{cpu: 0.1, hasCPU: true, load: 300, hasLoad: true}, 1, 10, 6},
+		{"UnknownCPU", 2, usage{cpu: 5, load: 150, hasLoad: true}, 1, 10, 3},
+		{"Max", 2, usage{cpu: 10, hasCPU: true}, 1, 5, 5},
+		{"ShrinkByOne", 5, usage{cpu: 0.01, hasCPU: true}, 1, 10, 4},
+		{"Min", 2, usage{cpu: 0.01, hasCPU: true}, 2, 10, 2},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			if got := a.desiredReplicas(test.n, test.min, test.max, test.u); got != test.want {
+				t.Fatalf("desiredReplicas(%d, %d, %d, %+v): got %d, want %d", test.n, test.min, test.max, test.u, got, test.want)
+			}
+		})
+	}
+}
+
+func TestCPUTime(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("CPU time is only available on Linux")
+	}
+
+	// Burn some CPU.
+	deadline := time.Now().Add(50 * time.Millisecond)
+	for time.Now().Before(deadline) {
+	}
+	cpu, err := cpuTime(os.Getpid())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cpu <= 0 {
+		t.Fatalf("cpuTime: got %v, want > 0", cpu)
+	}
+}
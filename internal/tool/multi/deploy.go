@@ -19,8 +19,6 @@ import (
 	"errors"
 	"flag"
 	"fmt"
-	"net"
-	"net/http"
 	"os"
 	"path/filepath"
 
@@ -31,7 +29,6 @@ import (
 	"github.com/sh3lk/mx/runtime"
 	"github.com/sh3lk/mx/runtime/bin"
 	"github.com/sh3lk/mx/runtime/codegen"
-	"github.com/sh3lk/mx/runtime/retry"
 	"github.com/sh3lk/mx/runtime/tool"
 	"github.com/sh3lk/mx/runtime/version"
 )
@@ -61,32 +58,61 @@ func deploy(ctx context.Context, args []string) error {
 	}
 
 	// Load the config file.
-	configFile := args[0]
+	multiConfig, err := loadConfig(args[0])
+	if err != nil {
+		return err
+	}
+
+	// Make temporary directory.
+	tmpDir, err := runtime.NewTempDir()
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpDir)
+	runtime.OnExitSignal(func() { os.RemoveAll(tmpDir) })
+
+	// Start the first version of the application.
+	registry, err := defaultRegistry(ctx)
+	if err != nil {
+		return fmt.Errorf("create registry: %w", err)
+	}
+	m := newManager(ctx, tmpDir, registry)
+	if _, err := m.start(uuid.New().String(), multiConfig); err != nil {
+		return err
+	}
+	return m.wait()
+}
+
+// loadConfig loads and validates the multiprocess deployer config in the
+// provided file, and checks that the application binary is compatible with
+// the deployer.
+func loadConfig(configFile string) (*MultiConfig, error) {
+	// Load the config file.
 	bytes, err := os.ReadFile(configFile)
 	if err != nil {
-		return fmt.Errorf("load config file %q: %w\n", configFile, err)
+		return nil, fmt.Errorf("load config file %q: %w\n", configFile, err)
 	}
 
 	// Parse and sanity-check the application section of the config.
 	appConfig, err := runtime.ParseConfig(configFile, string(bytes), codegen.ComponentConfigValidator)
 	if err != nil {
-		return fmt.Errorf("load config file %q: %w\n", configFile, err)
+		return nil, fmt.Errorf("load config file %q: %w\n", configFile, err)
 	}
 	if _, err := os.Stat(appConfig.Binary); errors.Is(err, os.ErrNotExist) {
-		return fmt.Errorf("binary %q doesn't exist", appConfig.Binary)
+		return nil, fmt.Errorf("binary %q doesn't exist", appConfig.Binary)
 	}
 
 	// Parse the multi section of the config.
 	multiConfig, err := config.GetDeployerConfig[MultiConfig, MultiConfig_ListenerOptions](configKey, shortConfigKey, appConfig)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	multiConfig.App = appConfig
 
 	// Check version compatibility.
 	versions, err := bin.ReadVersions(appConfig.Binary)
 	if err != nil {
-		return fmt.Errorf("read versions: %w", err)
+		return nil, fmt.Errorf("read versions: %w", err)
 	}
 	if versions.DeployerVersion != version.DeployerVersion {
 		// Try to relativize the binary, defaulting to the absolute path if
@@ -99,9 +125,9 @@ func deploy(ctx context.Context, args []string) error {
 		}
 		selfVersion, err := itool.SelfVersion()
 		if err != nil {
-			return fmt.Errorf("read self version: %w", err)
+			return nil, fmt.Errorf("read self version: %w", err)
 		}
-		return fmt.Errorf(`
+		return nil, fmt.Errorf(`
 ERROR: The binary you're trying to deploy (%q) was built with
 github.com/sh3lk/mx module version %s. However, the 'mx
 multi' binary you're using was built with mx module version %s.
@@ -118,68 +144,7 @@ persists, please file an issue at https://github.com/sh3lk/mx/issues.`,
 			binary, versions.ModuleVersion, selfVersion)
 	}
 
-	// Make temporary directory.
-	tmpDir, err := runtime.NewTempDir()
-	if err != nil {
-		return err
-	}
-	defer os.RemoveAll(tmpDir)
-	runtime.OnExitSignal(func() { os.RemoveAll(tmpDir) })
-
-	// Create the deployer.
-	deploymentId := uuid.New().String()
-	d, err := newDeployer(ctx, deploymentId, multiConfig, tmpDir)
-	if err != nil {
-		return fmt.Errorf("create deployer: %w", err)
-	}
-
-	// Run a status server.
-	lis, err := net.Listen("tcp", "localhost:0")
-	if err != nil {
-		return fmt.Errorf("listen: %w", err)
-	}
-	mux := http.NewServeMux()
-	status.RegisterServer(mux, d, d.logger)
-	go func() {
-		if err := serveHTTP(ctx, lis, mux); err != nil {
-			fmt.Fprintf(os.Stderr, "status server: %v\n", err)
-		}
-	}()
-
-	// Deploy main.
-	if err := d.startMain(); err != nil {
-		return fmt.Errorf("start main process: %w", err)
-	}
-
-	// Wait for the status server to become active.
-	client := status.NewClient(lis.Addr().String())
-	for r := retry.Begin(); r.Continue(ctx); {
-		_, err := client.Status(ctx)
-		if err == nil {
-			break
-		}
-		fmt.Fprintf(os.Stderr, "status server %q unavailable: %#v\n", lis.Addr(), err)
-	}
-
-	// Register the deployment.
-	registry, err := defaultRegistry(ctx)
-	if err != nil {
-		return fmt.Errorf("create registry: %w", err)
-	}
-	reg := status.Registration{
-		DeploymentId: deploymentId,
-		App:          appConfig.Name,
-		Addr:         lis.Addr().String(),
-	}
-	fmt.Fprint(os.Stderr, reg.Rolodex())
-	if err := registry.Register(ctx, reg); err != nil {
-		return fmt.Errorf("register deployment: %w", err)
-	}
-	unregister := func() { registry.Unregister(ctx, deploymentId) }
-	defer unregister()
-	runtime.OnExitSignal(unregister)
-
-	return d.wait()
+	return multiConfig, nil
 }
 
 // defaultRegistry returns a registry in defaultRegistryDir().
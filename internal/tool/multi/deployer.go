@@ -17,6 +17,9 @@ package multi
 import (
 	"context"
 	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
 	"crypto/x509"
 	"errors"
 	"fmt"
@@ -34,6 +37,7 @@ import (
 	"github.com/sh3lk/mx/internal/proxy"
 	"github.com/sh3lk/mx/internal/routing"
 	"github.com/sh3lk/mx/internal/status"
+	"github.com/sh3lk/mx/internal/supervisor"
 	"github.com/sh3lk/mx/internal/tool/certs"
 	"github.com/sh3lk/mx/runtime"
 	"github.com/sh3lk/mx/runtime/bin"
@@ -50,8 +54,14 @@ import (
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
-// The default number of times a component is replicated.
-const defaultReplication = 2
+const (
+	// The default number of times a component is replicated.
+	defaultReplication = 2
+
+	// The interval between two rebalancings of the assignments of routed
+	// components.
+	loadBalanceInterval = 30 * time.Second
+)
 
 // A deployer manages an application deployment.
 type deployer struct {
@@ -64,6 +74,7 @@ type deployer struct {
 	logger       *slog.Logger
 	caCert       *x509.Certificate
 	caKey        crypto.PrivateKey
+	tokenKey     *ecdsa.PrivateKey // signs bearer tokens, if enabled
 	running      errgroup.Group
 	logsDB       *logging.FileStore
 	printer      *logging.PrettyPrinter
@@ -72,31 +83,37 @@ type deployer struct {
 	// statsProcessor tracks and computes stats to be rendered on the /statusz page.
 	statsProcessor *imetrics.StatsProcessor
 
-	mu      sync.Mutex            // guards the following
-	err     error                 // error that stopped the babysitter
-	groups  map[string]*group     // groups, by component name
-	proxies map[string]*proxyInfo // proxies, by listener name
+	// autoscaler picks the number of replicas of autoscaled groups.
+	autoscaler *autoscaler
+
+	// proxies contains the proxies of the application listeners. They are
+	// shared with the other versions of the application during a rollout.
+	proxies *proxy.Set
+
+	mu       sync.Mutex        // guards the following
+	err      error             // error that stopped the babysitter
+	draining bool              // is the deployer being drained?
+	groups   map[string]*group // groups, by component name
 }
 
 // A group contains information about a co-location group.
 type group struct {
-	name        string                          // group name
-	envelopes   []*envelope.Envelope            // envelopes, one per mxn
-	replicas    []*status.Replica               // stores replica info such as pid, mxn id
-	started     map[string]bool                 // started components
-	addresses   map[string]bool                 // mxn addresses
-	assignments map[string]*protos.Assignment   // assignment, by component
-	subscribers map[string][]*envelope.Envelope // routing info subscribers, by component
-	callable    []string                        // callable components for group
-	certPEM     []byte                          // group certificate
-	keyPEM      []byte                          // group private key
-}
-
-// A proxyInfo contains information about a proxy.
-type proxyInfo struct {
-	listener string       // listener associated with the proxy
-	proxy    *proxy.Proxy // the proxy
-	addr     string       // dialable address of the proxy
+	name        string                                        // group name
+	minReplicas int                                           // minimum number of replicas
+	maxReplicas int                                           // maximum number of replicas
+	envelopes   []*envelope.Envelope                          // envelopes, one per mxn
+	handlers    []*handler                                    // handlers, one per mxn
+	replicas    []*status.Replica                             // stores replica info such as pid, mxn id
+	started     map[string]bool                               // started components
+	addresses   map[string]bool                               // mxn addresses
+	sockets     map[string]string                             // shared-memory sockets, by mxn address
+	assignments map[string]*protos.Assignment                 // assignment, by component
+	loads       map[string][]*protos.LoadReport_ComponentLoad // latest load reports, by component
+	subscribers map[string][]*envelope.Envelope               // routing info subscribers, by component
+	callable    []string                                      // callable components for group
+	hosted      []string                                      // components hosted by the group
+	certPEM     []byte                                        // group certificate
+	keyPEM      []byte                                        // group private key
 }
 
 // handler handles a connection to a mxn.
@@ -104,14 +121,21 @@ type handler struct {
 	*deployer
 	g          *group
 	envelope   *envelope.Envelope
-	subscribed map[string]bool // routing info subscriptions, by component
+	ctx        context.Context    // canceled when the mxn is stopped
+	stop       context.CancelFunc // stops the mxn
+	served     chan error         // receives the result of envelope.Serve
+	exited     chan struct{}      // closed when envelope.Serve returns
+	subscribed map[string]bool    // routing info subscriptions, by component
+	listeners  map[string]string  // exported listener addresses, by listener name
+	stopped    bool               // stopped on purpose? guarded by d.mu
 }
 
 var _ envelope.EnvelopeHandler = &handler{}
 
 // newDeployer creates a new deployer. The deployer can be stopped at any
-// time by canceling the passed-in context.
-func newDeployer(ctx context.Context, deploymentId string, config *MultiConfig, tmpDir string) (*deployer, error) {
+// time by canceling the passed-in context. The application listeners are
+// exported through the provided proxies.
+func newDeployer(ctx context.Context, deploymentId string, config *MultiConfig, tmpDir string, proxies *proxy.Set) (*deployer, error) {
 	// Create the log saver.
 	logsDB, err := logging.NewFileStore(logDir)
 	if err != nil {
@@ -135,6 +159,13 @@ func newDeployer(ctx context.Context, deploymentId string, config *MultiConfig,
 			return nil, fmt.Errorf("cannot generate signing certificate: %w", err)
 		}
 	}
+	var tokenKey *ecdsa.PrivateKey
+	if config.Tokens {
+		tokenKey, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			return nil, fmt.Errorf("cannot generate token signing key: %w", err)
+		}
+	}
 
 	// Create the trace saver.
 	traceDB, err := traces.OpenDB(ctx, perfettoFile)
@@ -150,14 +181,16 @@ func newDeployer(ctx context.Context, deploymentId string, config *MultiConfig,
 		logger:         logger,
 		caCert:         caCert,
 		caKey:          caKey,
+		tokenKey:       tokenKey,
 		logsDB:         logsDB,
 		printer:        printer,
 		traceDB:        traceDB,
 		statsProcessor: imetrics.NewStatsProcessor(),
+		autoscaler:     newAutoscaler(config.Autoscale),
 		deploymentId:   deploymentId,
 		config:         config,
 		started:        time.Now(),
-		proxies:        map[string]*proxyInfo{},
+		proxies:        proxies,
 	}
 
 	// Form co-location groups.
@@ -172,6 +205,23 @@ func newDeployer(ctx context.Context, deploymentId string, config *MultiConfig,
 		return err
 	})
 
+	// Start a goroutine that autoscales groups, if any group is autoscaled.
+	for _, g := range d.groups {
+		if g.maxReplicas > g.minReplicas {
+			d.running.Go(func() error {
+				d.autoscale()
+				return nil
+			})
+			break
+		}
+	}
+
+	// Start a goroutine that rebalances the assignments of routed components.
+	d.running.Go(func() error {
+		d.balance()
+		return nil
+	})
+
 	// Start a goroutine that watches for context cancelation.
 	d.running.Go(func() error {
 		<-d.ctx.Done()
@@ -218,9 +268,13 @@ func (d *deployer) computeGroups() error {
 			// TODO(spetrovic): ensure a consistent name is picked for
 			// colocation groups across versions.
 			name:        component,
+			minReplicas: defaultReplication,
+			maxReplicas: defaultReplication,
 			started:     map[string]bool{},
 			addresses:   map[string]bool{},
+			sockets:     map[string]string{},
 			assignments: map[string]*protos.Assignment{},
+			loads:       map[string][]*protos.LoadReport_ComponentLoad{},
 			subscribers: map[string][]*envelope.Envelope{},
 			certPEM:     certPEM,
 			keyPEM:      keyPEM,
@@ -260,6 +314,21 @@ func (d *deployer) computeGroups() error {
 		srcGroup := groups[src]
 		srcGroup.callable = append(srcGroup.callable, dst)
 	})
+	for component, g := range groups {
+		g.hosted = append(g.hosted, component)
+	}
+	for _, g := range groups {
+		slices.Sort(g.hosted)
+	}
+
+	// Apply the replication options.
+	for component, opts := range d.config.Replicas {
+		g, ok := groups[component]
+		if !ok {
+			return fmt.Errorf("replicas: unknown component %q", component)
+		}
+		g.minReplicas, g.maxReplicas = replicaBounds(opts)
+	}
 
 	d.groups = groups
 	return nil
@@ -291,14 +360,99 @@ func (d *deployer) stop(err error) {
 	d.ctxCancel()
 }
 
-// routing returns the RoutingInfo for the provided component.
+// ready returns whether every started colocation group has its minimum
+// number of replicas, and every replica is healthy.
+//
+// REQUIRES: d.mu is NOT held.
+func (d *deployer) ready() bool {
+	d.mu.Lock()
+	var envelopes []*envelope.Envelope
+	for _, g := range d.groups {
+		if len(g.started) == 0 {
+			continue
+		}
+		if len(g.envelopes) < g.minReplicas {
+			d.mu.Unlock()
+			return false
+		}
+		envelopes = append(envelopes, g.envelopes...)
+	}
+	d.mu.Unlock()
+
+	// Checking the health of a replica involves an RPC, so we don't hold the
+	// lock.
+	for _, e := range envelopes {
+		if e.GetHealth().Status != protos.HealthStatus_HEALTHY {
+			return false
+		}
+	}
+	return len(envelopes) > 0
+}
+
+// drain gracefully stops the deployer. It stops sending the traffic of the
+// application listeners to the deployer's replicas and sends them a SIGTERM,
+// which runs the Shutdown methods of their components. The deployer is
+// stopped with errDrained once every replica has exited, or once the provided
+// timeout expires, whichever comes first.
+//
+// REQUIRES: d.mu is NOT held.
+func (d *deployer) drain(timeout time.Duration) {
+	d.proxies.SetWeight(d.deploymentId, 0)
+
+	d.mu.Lock()
+	d.draining = true
+	var handlers []*handler
+	for _, g := range d.groups {
+		for _, h := range g.handlers {
+			if slices.Contains(handlers, h) {
+				// Components in the same colocation group share handlers.
+				continue
+			}
+			h.stopped = true
+			for name, addr := range h.listeners {
+				d.proxies.Remove(name, addr)
+			}
+			handlers = append(handlers, h)
+		}
+	}
+	d.mu.Unlock()
+
+	for _, h := range handlers {
+		pid, ok := h.envelope.Pid()
+		if !ok {
+			continue
+		}
+		if p, err := os.FindProcess(pid); err == nil {
+			if err := p.Signal(syscall.SIGTERM); err != nil {
+				d.logger.Error("Signal replica", "pid", pid, "err", err)
+			}
+		}
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	for _, h := range handlers {
+		select {
+		case <-h.exited:
+		case <-timer.C:
+			d.logger.Error("Drain timed out", "timeout", timeout)
+			d.stop(errDrained)
+			return
+		}
+	}
+	d.stop(errDrained)
+}
+
+// routing returns the RoutingInfo for the provided component. All replicas
+// run on this machine, so they are all reached through shared memory.
 //
 // REQUIRES: d.mu is held.
 func (g *group) routing(component string) *protos.RoutingInfo {
 	return &protos.RoutingInfo{
-		Component:  component,
-		Replicas:   maps.Keys(g.addresses),
-		Assignment: g.assignments[component],
+		Component:    component,
+		Replicas:     maps.Keys(g.addresses),
+		Assignment:   g.assignments[component],
+		SharedMemory: maps.Clone(g.sockets),
 	}
 }
 
@@ -313,57 +467,198 @@ func (d *deployer) startColocationGroup(g *group) error {
 	if d.err != nil {
 		return d.err
 	}
-	if len(g.envelopes) == defaultReplication {
-		// Already started.
-		return nil
+	for len(g.envelopes) < g.minReplicas {
+		if err := d.startReplica(g); err != nil {
+			return err
+		}
 	}
+	return nil
+}
 
-	components := maps.Keys(g.started)
-	for r := 0; r < defaultReplication; r++ {
-		// Start the mxn and capture its logs, traces, and metrics.
-		info := &protos.MXNArgs{
-			App:             d.config.App.Name,
-			DeploymentId:    d.deploymentId,
-			Id:              uuid.New().String(),
-			RunMain:         g.started[runtime.Main],
-			Mtls:            d.config.Mtls,
-			InternalAddress: "localhost:0",
-		}
-		e, err := envelope.NewEnvelope(d.ctx, info, d.config.App, envelope.Options{
-			Logger: d.logger,
+// startReplica starts a new replica of the provided colocation group, along
+// with a goroutine that restarts the replica whenever it fails.
+//
+// REQUIRES: d.mu is held.
+func (d *deployer) startReplica(g *group) error {
+	h, err := d.newReplica(g, 0)
+	if err != nil {
+		return err
+	}
+	d.running.Go(func() error {
+		supervisor.Supervise(d.ctx, d.logger, func(restarts int) error {
+			if restarts > 0 {
+				d.mu.Lock()
+				var err error
+				h, err = d.restartReplica(g, restarts)
+				d.mu.Unlock()
+				if err != nil || h == nil {
+					return err
+				}
+			}
+			return d.waitReplica(g, h)
 		})
-		if err != nil {
-			return err
-		}
+		return nil
+	})
+	return nil
+}
 
-		h := &handler{
-			deployer:   d,
-			g:          g,
-			subscribed: map[string]bool{},
-			envelope:   e,
-		}
+// restartReplica starts a replica of the provided colocation group to replace
+// a replica that failed. It returns a nil handler if the replica should not be
+// replaced, e.g., because the deployer has been stopped.
+//
+// REQUIRES: d.mu is held.
+func (d *deployer) restartReplica(g *group, restarts int) (*handler, error) {
+	if d.err != nil || d.draining {
+		// The deployer has been stopped.
+		return nil, nil
+	}
+	if len(g.envelopes) >= g.maxReplicas {
+		// The autoscaler has already replaced the replica.
+		return nil, nil
+	}
+	return d.newReplica(g, restarts)
+}
 
-		d.running.Go(func() error {
-			err := e.Serve(h)
-			d.stop(err)
-			return err
-		})
-		pid, ok := e.Pid()
-		if !ok {
-			panic("multi deployer child must be a real process")
-		}
-		// Add replica info to group
-		g.replicas = append(g.replicas, &status.Replica{Pid: int64(pid), MXNId: info.Id})
-		// Register replica
-		if err := d.registerReplica(g, e.MXNAddress()); err != nil {
-			return err
-		}
-		if err := e.UpdateComponents(components); err != nil {
-			return err
+// newReplica starts and registers a new replica of the provided colocation
+// group. restarts is the number of times the replica has been restarted.
+//
+// REQUIRES: d.mu is held.
+func (d *deployer) newReplica(g *group, restarts int) (*handler, error) {
+	// Start the mxn and capture its logs, traces, and metrics.
+	info := &protos.MXNArgs{
+		App:             d.config.App.Name,
+		DeploymentId:    d.deploymentId,
+		Id:              uuid.New().String(),
+		RunMain:         g.started[runtime.Main],
+		Mtls:            d.config.Mtls,
+		TokenAuth:       d.config.Tokens,
+		InternalAddress: "localhost:0",
+	}
+	ctx, cancel := context.WithCancel(d.ctx)
+	e, err := envelope.NewEnvelope(ctx, info, d.config.App, envelope.Options{
+		Logger:       d.logger,
+		SharedMemory: true,
+	})
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	h := &handler{
+		deployer:   d,
+		g:          g,
+		subscribed: map[string]bool{},
+		listeners:  map[string]string{},
+		envelope:   e,
+		ctx:        ctx,
+		stop:       cancel,
+		served:     make(chan error, 1),
+		exited:     make(chan struct{}),
+	}
+
+	d.running.Go(func() error {
+		h.served <- e.Serve(h)
+		close(h.exited)
+		return nil
+	})
+	pid, ok := e.Pid()
+	if !ok {
+		panic("multi deployer child must be a real process")
+	}
+	// Add replica info to group
+	g.envelopes = append(g.envelopes, e)
+	g.handlers = append(g.handlers, h)
+	g.replicas = append(g.replicas, &status.Replica{Pid: int64(pid), MXNId: info.Id, Restarts: int32(restarts)})
+	// Register replica
+	g.sockets[e.MXNAddress()] = e.SharedMemorySocket()
+	if err := d.registerReplica(g, e.MXNAddress()); err == nil {
+		err = e.UpdateComponents(maps.Keys(g.started))
+	}
+	if err != nil {
+		d.removeReplica(g, h)
+		cancel()
+		return nil, err
+	}
+	return h, nil
+}
+
+// waitReplica waits for the provided replica to exit, killing it if it
+// becomes unhealthy. It returns a non-nil error if the replica failed and
+// should be restarted.
+//
+// REQUIRES: d.mu is NOT held.
+func (d *deployer) waitReplica(g *group, h *handler) error {
+	go supervisor.WatchHealth(h.ctx, d.logger, h.envelope, h.stop)
+	err := <-h.served
+
+	d.mu.Lock()
+	if h.stopped || d.ctx.Err() != nil {
+		// The replica was stopped by the autoscaler, or the deployer is
+		// stopping.
+		d.mu.Unlock()
+		return nil
+	}
+	if h.envelope.ExitError() == nil && h.ctx.Err() == nil {
+		// The mxn exited successfully (e.g., because main returned), so
+		// the application is done.
+		d.mu.Unlock()
+		d.stop(err)
+		return nil
+	}
+
+	// The replica crashed or was killed because it was unhealthy. Stop
+	// routing traffic to it before restarting it.
+	if rerr := d.removeReplica(g, h); rerr != nil {
+		d.logger.Error("Remove failed replica", "group", g.name, "err", rerr)
+	}
+	d.mu.Unlock()
+	if exitErr := h.envelope.ExitError(); exitErr != nil {
+		err = exitErr
+	}
+	if err == nil {
+		err = errors.New("mxn exited")
+	}
+	return fmt.Errorf("group %s: %w", logging.ShortenComponent(g.name), err)
+}
+
+// stopReplica stops the most recently started replica of the provided
+// colocation group.
+//
+// REQUIRES: d.mu is held.
+func (d *deployer) stopReplica(g *group) error {
+	h := g.handlers[len(g.handlers)-1]
+	h.stopped = true
+	err := d.removeReplica(g, h)
+	h.stop()
+	return err
+}
+
+// removeReplica removes the provided replica from its colocation group and
+// stops sending traffic to it. It is a no-op if the replica was already
+// removed.
+//
+// REQUIRES: d.mu is held.
+func (d *deployer) removeReplica(g *group, h *handler) error {
+	i := slices.Index(g.handlers, h)
+	if i < 0 {
+		return nil
+	}
+	g.envelopes = slices.Delete(g.envelopes, i, i+1)
+	g.handlers = slices.Delete(g.handlers, i, i+1)
+	g.replicas = slices.Delete(g.replicas, i, i+1)
+	d.autoscaler.forget(h.envelope)
+
+	for name, addr := range h.listeners {
+		d.proxies.Remove(name, addr)
+	}
+	for _, other := range d.groups {
+		for component, subs := range other.subscribers {
+			other.subscribers[component] = slices.DeleteFunc(subs, func(e *envelope.Envelope) bool {
+				return e == h.envelope
+			})
 		}
-		g.envelopes = append(g.envelopes, e)
 	}
-	return nil
+	return d.unregisterReplica(g, h.envelope.MXNAddress())
 }
 
 func (d *deployer) startMain() error {
@@ -425,7 +720,7 @@ func (h *handler) VerifyClientCertificate(_ context.Context, req *protos.VerifyC
 	if !ok {
 		return nil, fmt.Errorf("unknown client group %q", groupName)
 	}
-	return &protos.VerifyClientCertificateReply{Components: g.callable}, nil
+	return &protos.VerifyClientCertificateReply{Components: g.callable, Callers: g.hosted}, nil
 }
 
 // VerifyServerCertificate implements the control.DeployerControl interface.
@@ -490,7 +785,7 @@ func (d *deployer) activateComponent(req *protos.ActivateComponentRequest) error
 		// Create an initial assignment.
 		if req.Routed {
 			replicas := maps.Keys(target.addresses)
-			assignment := routingAlgo(&protos.Assignment{}, replicas)
+			assignment := routing.BalanceLoad(&protos.Assignment{}, replicas, nil)
 			target.assignments[req.Component] = assignment
 			d.logger.Debug(fmt.Sprintf("Initial assignment for component %s:\n%s", req.Component, routing.FormatAssignment(assignment)))
 		}
@@ -520,9 +815,12 @@ func (d *deployer) registerReplica(g *group, replicaAddr string) error {
 	// Update all assignments.
 	replicas := maps.Keys(g.addresses)
 	for component, assignment := range g.assignments {
-		assignment = routingAlgo(assignment, replicas)
-		g.assignments[component] = assignment
-		d.logger.Debug(fmt.Sprintf("Updated assignment for component %s:\n%s", component, routing.FormatAssignment(assignment)))
+		next := routing.BalanceLoad(assignment, replicas, g.loads[component])
+		if next == assignment {
+			continue
+		}
+		g.assignments[component] = next
+		d.logger.Debug(fmt.Sprintf("Updated assignment for component %s:\n%s", component, routing.FormatAssignment(next)))
 	}
 
 	// Notify subscribers.
@@ -538,6 +836,197 @@ func (d *deployer) registerReplica(g *group, replicaAddr string) error {
 	return nil
 }
 
+// unregisterReplica unregisters the information about a colocation group
+// replica (i.e., a mxn) that was registered with registerReplica.
+//
+// REQUIRES: d.mu is held.
+func (d *deployer) unregisterReplica(g *group, replicaAddr string) error {
+	if !g.addresses[replicaAddr] {
+		// Replica not registered.
+		return nil
+	}
+	delete(g.addresses, replicaAddr)
+	delete(g.sockets, replicaAddr)
+	// Update all assignments.
+	replicas := maps.Keys(g.addresses)
+	for component, assignment := range g.assignments {
+		next := routing.BalanceLoad(assignment, replicas, g.loads[component])
+		if next == assignment {
+			continue
+		}
+		g.assignments[component] = next
+		d.logger.Debug(fmt.Sprintf("Updated assignment for component %s:\n%s", component, routing.FormatAssignment(next)))
+	}
+
+	// Notify subscribers.
+	for component := range g.started {
+		routing := g.routing(component)
+		for _, sub := range g.subscribers[component] {
+			if err := sub.UpdateRoutingInfo(routing); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// autoscale periodically adjusts the number of replicas of every autoscaled
+// colocation group, until the deployer is stopped.
+//
+// REQUIRES: d.mu is NOT held.
+func (d *deployer) autoscale() {
+	ticker := time.NewTicker(d.autoscaler.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-d.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		// Collect the replicas of the running autoscaled groups.
+		d.mu.Lock()
+		replicas := map[*group][]*envelope.Envelope{}
+		for _, g := range d.groups {
+			if g.maxReplicas > g.minReplicas && len(g.envelopes) > 0 {
+				replicas[g] = slices.Clone(g.envelopes)
+			}
+		}
+		d.mu.Unlock()
+
+		// Measure the usage of the replicas. Measuring involves RPCs to the
+		// replicas, so we don't hold the lock.
+		usages := map[*group]usage{}
+		for g, envelopes := range replicas {
+			usages[g] = d.autoscaler.measure(envelopes)
+		}
+
+		d.mu.Lock()
+		for g, u := range usages {
+			if err := d.scale(g, u); err != nil {
+				d.logger.Error("autoscale", "group", g.name, "err", err)
+			}
+		}
+		d.mu.Unlock()
+	}
+}
+
+// balance periodically rebalances the assignments of the routed components of
+// every colocation group, based on the load reported by the group's replicas,
+// until the deployer is stopped.
+//
+// REQUIRES: d.mu is NOT held.
+func (d *deployer) balance() {
+	ticker := time.NewTicker(loadBalanceInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-d.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		// Collect the replicas of the groups with routed components.
+		d.mu.Lock()
+		replicas := map[*group][]*envelope.Envelope{}
+		for _, g := range d.groups {
+			if len(g.assignments) > 0 && len(g.envelopes) > 0 {
+				replicas[g] = slices.Clone(g.envelopes)
+			}
+		}
+		d.mu.Unlock()
+
+		// Collect the load of the replicas. Collecting the load involves RPCs
+		// to the replicas, so we don't hold the lock.
+		loads := map[*group]map[string][]*protos.LoadReport_ComponentLoad{}
+		for g, envelopes := range replicas {
+			byComponent := map[string][]*protos.LoadReport_ComponentLoad{}
+			for _, e := range envelopes {
+				report, err := e.GetLoad()
+				if err != nil {
+					d.logger.Error("Unable to collect load", "group", g.name, "err", err)
+					continue
+				}
+				for component, load := range report.Loads {
+					byComponent[component] = append(byComponent[component], load)
+				}
+			}
+			loads[g] = byComponent
+		}
+
+		d.mu.Lock()
+		for g, byComponent := range loads {
+			if err := d.rebalance(g, byComponent); err != nil {
+				d.logger.Error("rebalance", "group", g.name, "err", err)
+			}
+		}
+		d.mu.Unlock()
+	}
+}
+
+// rebalance rebalances the assignments of the routed components of the
+// provided colocation group, based on the provided load reports.
+//
+// REQUIRES: d.mu is held.
+func (d *deployer) rebalance(g *group, loads map[string][]*protos.LoadReport_ComponentLoad) error {
+	if d.err != nil {
+		// The deployer has been stopped.
+		return nil
+	}
+	replicas := maps.Keys(g.addresses)
+	for component, assignment := range g.assignments {
+		g.loads[component] = loads[component]
+		if routing.TotalLoad(assignment.Version, loads[component]) == 0 {
+			// There is no load to balance, or the assignment changed while
+			// the load was being collected.
+			continue
+		}
+		next := routing.BalanceLoad(assignment, replicas, loads[component])
+		if next == assignment {
+			continue
+		}
+		g.assignments[component] = next
+		d.logger.Debug(fmt.Sprintf("Rebalanced assignment for component %s:\n%s", component, routing.FormatAssignment(next)))
+
+		// Notify subscribers.
+		info := g.routing(component)
+		for _, sub := range g.subscribers[component] {
+			if err := sub.UpdateRoutingInfo(info); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// scale adjusts the number of replicas of the provided colocation group,
+// based on the measured usage of its replicas.
+//
+// REQUIRES: d.mu is held.
+func (d *deployer) scale(g *group, u usage) error {
+	if d.err != nil || d.draining {
+		// The deployer has been stopped.
+		return nil
+	}
+	n := len(g.envelopes)
+	desired := d.autoscaler.desiredReplicas(n, g.minReplicas, g.maxReplicas, u)
+	if desired == n {
+		return nil
+	}
+	d.logger.Info("Autoscaling", "group", logging.ShortenComponent(g.name), "from", n, "to", desired, "cpu", u.cpu, "load", u.load)
+	for len(g.envelopes) < desired {
+		if err := d.startReplica(g); err != nil {
+			return err
+		}
+	}
+	for len(g.envelopes) > desired {
+		if err := d.stopReplica(g); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // LogBatch implements the control.DeployerControl interface.
 func (d *deployer) LogBatch(ctx context.Context, batch *protos.LogEntryBatch) error {
 	for _, entry := range batch.Entries {
@@ -557,16 +1046,22 @@ func (d *deployer) GetListenerAddress(context.Context, *protos.GetListenerAddres
 }
 
 // ExportListener implements the control.DeployerControl interface.
-func (d *deployer) ExportListener(_ context.Context, req *protos.ExportListenerRequest) (*protos.ExportListenerReply, error) {
-	d.mu.Lock()
-	defer d.mu.Unlock()
-
-	// Update the proxy.
-	if p, ok := d.proxies[req.Listener]; ok {
-		p.proxy.AddBackend(req.Address)
-		return &protos.ExportListenerReply{ProxyAddress: p.addr}, nil
+func (h *handler) ExportListener(ctx context.Context, req *protos.ExportListenerRequest) (*protos.ExportListenerReply, error) {
+	reply, err := h.deployer.ExportListener(ctx, req)
+	if err != nil || reply.Error != "" {
+		return reply, err
 	}
 
+	// Remember the listener address, so that it can be removed from the
+	// proxy if the replica is stopped.
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.listeners[req.Listener] = req.Address
+	return reply, nil
+}
+
+// ExportListener implements the control.DeployerControl interface.
+func (d *deployer) ExportListener(_ context.Context, req *protos.ExportListenerRequest) (*protos.ExportListenerReply, error) {
 	// Get the proxy address. It should be the same as the Address field
 	// in the options for this listener, if any was specified.
 	var proxyAddr string
@@ -574,7 +1069,7 @@ func (d *deployer) ExportListener(_ context.Context, req *protos.ExportListenerR
 		proxyAddr = opts.Address
 	}
 
-	lis, err := net.Listen("tcp", proxyAddr)
+	addr, err := d.proxies.Export(d.logger, req.Listener, proxyAddr, d.deploymentId, req.Address)
 	if errors.Is(err, syscall.EADDRINUSE) {
 		// Don't retry if this address is already in use.
 		return &protos.ExportListenerReply{Error: err.Error()}, nil
@@ -582,20 +1077,6 @@ func (d *deployer) ExportListener(_ context.Context, req *protos.ExportListenerR
 	if err != nil {
 		return nil, fmt.Errorf("proxy listen: %w", err)
 	}
-	addr := lis.Addr().String() // actual proxy address
-	d.logger.Info("Proxy listening", "address", addr)
-	proxy := proxy.NewProxy(d.logger)
-	proxy.AddBackend(req.Address)
-	d.proxies[req.Listener] = &proxyInfo{
-		listener: req.Listener,
-		proxy:    proxy,
-		addr:     addr,
-	}
-	go func() {
-		if err := serveHTTP(d.ctx, lis, proxy); err != nil {
-			d.logger.Error("proxy", "err", err)
-		}
-	}()
 	return &protos.ExportListenerReply{ProxyAddress: addr}, nil
 }
 
@@ -636,6 +1117,9 @@ func (d *deployer) Profile(_ context.Context, req *protos.GetProfileRequest) (*p
 
 // Status implements the status.Server interface.
 func (d *deployer) Status(context.Context) (*status.Status, error) {
+	// Read the metrics before acquiring d.mu, since readMetrics acquires it.
+	snapshots := d.readMetrics()
+
 	d.mu.Lock()
 	defer d.mu.Unlock()
 
@@ -680,11 +1164,13 @@ func (d *deployer) Status(context.Context) (*status.Status, error) {
 		}
 	}
 
+	status.SetCircuitBreakers(components, snapshots)
+
 	var listeners []*status.Listener
-	for _, proxy := range d.proxies {
+	for name, addr := range d.proxies.Listeners() {
 		listeners = append(listeners, &status.Listener{
-			Name: proxy.listener,
-			Addr: proxy.addr,
+			Name: name,
+			Addr: addr,
 		})
 	}
 
@@ -707,12 +1193,6 @@ func (d *deployer) Metrics(context.Context) (*status.Metrics, error) {
 	return m, nil
 }
 
-func routingAlgo(currAssignment *protos.Assignment, candidates []string) *protos.Assignment {
-	assignment := routing.EqualSlices(candidates)
-	assignment.Version = currAssignment.Version + 1
-	return assignment
-}
-
 // serveHTTP serves HTTP traffic on the provided listener using the provided
 // handler. The server is shut down when then provided context is cancelled.
 func serveHTTP(ctx context.Context, lis net.Listener, handler http.Handler) error {
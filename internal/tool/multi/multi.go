@@ -50,12 +50,13 @@ var (
 
 	purgeSpec = &tool.PurgeSpec{
 		Tool:  "mx multi",
-		Kill:  "mx multi (dashboard|deploy|logs|profile)",
+		Kill:  "mx multi (dashboard|deploy|logs|profile|rollout)",
 		Paths: []string{logDir, dataDir},
 	}
 
 	Commands = map[string]*tool.Command{
-		"deploy": &deployCmd,
+		"deploy":  &deployCmd,
+		"rollout": &rolloutCmd,
 		"logs": tool.LogsCmd(&tool.LogsSpec{
 			Tool: "mx multi",
 			Source: func(context.Context) (logging.Source, error) {
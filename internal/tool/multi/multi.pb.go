@@ -46,8 +46,17 @@ type MultiConfig struct {
 	App *protos.AppConfig `protobuf:"bytes,1,opt,name=app,proto3" json:"app,omitempty"`
 	// Should the components use the mTLS protocol to communicate with
 	// one another?
-	Mtls      bool                                    `protobuf:"varint,2,opt,name=mtls,proto3" json:"mtls,omitempty"`
+	Mtls bool `protobuf:"varint,2,opt,name=mtls,proto3" json:"mtls,omitempty"`
+	// Should the components authenticate the calls they make to one another
+	// with bearer tokens? This is an alternative to mtls for environments where
+	// TLS is terminated outside of the application.
+	Tokens    bool                                    `protobuf:"varint,6,opt,name=tokens,proto3" json:"tokens,omitempty"`
 	Listeners map[string]*MultiConfig_ListenerOptions `protobuf:"bytes,3,rep,name=listeners,proto3" json:"listeners,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Replication options for co-location groups, keyed by the name of any
+	// component in the group. A group that isn't specified in the map is
+	// replicated twice.
+	Replicas  map[string]*MultiConfig_ReplicaOptions `protobuf:"bytes,4,rep,name=replicas,proto3" json:"replicas,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Autoscale *MultiConfig_AutoscaleOptions          `protobuf:"bytes,5,opt,name=autoscale,proto3" json:"autoscale,omitempty"`
 }
 
 func (x *MultiConfig) Reset() {
@@ -96,6 +105,13 @@ func (x *MultiConfig) GetMtls() bool {
 	return false
 }
 
+func (x *MultiConfig) GetTokens() bool {
+	if x != nil {
+		return x.Tokens
+	}
+	return false
+}
+
 func (x *MultiConfig) GetListeners() map[string]*MultiConfig_ListenerOptions {
 	if x != nil {
 		return x.Listeners
@@ -103,6 +119,71 @@ func (x *MultiConfig) GetListeners() map[string]*MultiConfig_ListenerOptions {
 	return nil
 }
 
+func (x *MultiConfig) GetReplicas() map[string]*MultiConfig_ReplicaOptions {
+	if x != nil {
+		return x.Replicas
+	}
+	return nil
+}
+
+func (x *MultiConfig) GetAutoscale() *MultiConfig_AutoscaleOptions {
+	if x != nil {
+		return x.Autoscale
+	}
+	return nil
+}
+
+// RolloutReply is the reply to a request to roll out a new version of an
+// application, sent by "mx multi rollout" to the deployer of the application.
+// The request is the MultiConfig of the new version.
+type RolloutReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The deployment id of the new version.
+	DeploymentId string `protobuf:"bytes,1,opt,name=deployment_id,json=deploymentId,proto3" json:"deployment_id,omitempty"`
+}
+
+func (x *RolloutReply) Reset() {
+	*x = RolloutReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_tool_multi_multi_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RolloutReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RolloutReply) ProtoMessage() {}
+
+func (x *RolloutReply) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_tool_multi_multi_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RolloutReply.ProtoReflect.Descriptor instead.
+func (*RolloutReply) Descriptor() ([]byte, []int) {
+	return file_internal_tool_multi_multi_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *RolloutReply) GetDeploymentId() string {
+	if x != nil {
+		return x.DeploymentId
+	}
+	return ""
+}
+
 // Options for the application listeners, keyed by listener name.
 // If a listener isn't specified in the map, default options will be used.
 type MultiConfig_ListenerOptions struct {
@@ -118,7 +199,7 @@ type MultiConfig_ListenerOptions struct {
 func (x *MultiConfig_ListenerOptions) Reset() {
 	*x = MultiConfig_ListenerOptions{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_tool_multi_multi_proto_msgTypes[1]
+		mi := &file_internal_tool_multi_multi_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -131,7 +212,7 @@ func (x *MultiConfig_ListenerOptions) String() string {
 func (*MultiConfig_ListenerOptions) ProtoMessage() {}
 
 func (x *MultiConfig_ListenerOptions) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_tool_multi_multi_proto_msgTypes[1]
+	mi := &file_internal_tool_multi_multi_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -154,6 +235,137 @@ func (x *MultiConfig_ListenerOptions) GetAddress() string {
 	return ""
 }
 
+// Replication options for a co-location group. A group has between min and
+// max replicas, inclusive. If max is larger than min, the number of
+// replicas is adjusted at runtime by the autoscaler. If min is unset, it
+// defaults to 2. If max is unset, it defaults to min.
+type MultiConfig_ReplicaOptions struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Min int32 `protobuf:"varint,1,opt,name=min,proto3" json:"min,omitempty"`
+	Max int32 `protobuf:"varint,2,opt,name=max,proto3" json:"max,omitempty"`
+}
+
+func (x *MultiConfig_ReplicaOptions) Reset() {
+	*x = MultiConfig_ReplicaOptions{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_tool_multi_multi_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MultiConfig_ReplicaOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MultiConfig_ReplicaOptions) ProtoMessage() {}
+
+func (x *MultiConfig_ReplicaOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_tool_multi_multi_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MultiConfig_ReplicaOptions.ProtoReflect.Descriptor instead.
+func (*MultiConfig_ReplicaOptions) Descriptor() ([]byte, []int) {
+	return file_internal_tool_multi_multi_proto_rawDescGZIP(), []int{0, 2}
+}
+
+func (x *MultiConfig_ReplicaOptions) GetMin() int32 {
+	if x != nil {
+		return x.Min
+	}
+	return 0
+}
+
+func (x *MultiConfig_ReplicaOptions) GetMax() int32 {
+	if x != nil {
+		return x.Max
+	}
+	return 0
+}
+
+// Options for the autoscaler. The autoscaler periodically measures the CPU
+// usage and load of every autoscaled co-location group and picks the number
+// of replicas that brings the per-replica usage closest to its target.
+type MultiConfig_AutoscaleOptions struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Target CPU usage of a replica, measured in cores. If unset, it defaults
+	// to 0.5. If negative, CPU usage is ignored.
+	Cpu float64 `protobuf:"fixed64,1,opt,name=cpu,proto3" json:"cpu,omitempty"`
+	// Target load of a replica, measured in requests per second received by
+	// its routed components. If unset, load is ignored.
+	Load float64 `protobuf:"fixed64,2,opt,name=load,proto3" json:"load,omitempty"`
+	// How often the autoscaler runs (e.g., "10s"). If unset, it defaults to
+	// 10 seconds.
+	Interval string `protobuf:"bytes,3,opt,name=interval,proto3" json:"interval,omitempty"`
+}
+
+func (x *MultiConfig_AutoscaleOptions) Reset() {
+	*x = MultiConfig_AutoscaleOptions{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_tool_multi_multi_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MultiConfig_AutoscaleOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MultiConfig_AutoscaleOptions) ProtoMessage() {}
+
+func (x *MultiConfig_AutoscaleOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_tool_multi_multi_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MultiConfig_AutoscaleOptions.ProtoReflect.Descriptor instead.
+func (*MultiConfig_AutoscaleOptions) Descriptor() ([]byte, []int) {
+	return file_internal_tool_multi_multi_proto_rawDescGZIP(), []int{0, 4}
+}
+
+func (x *MultiConfig_AutoscaleOptions) GetCpu() float64 {
+	if x != nil {
+		return x.Cpu
+	}
+	return 0
+}
+
+func (x *MultiConfig_AutoscaleOptions) GetLoad() float64 {
+	if x != nil {
+		return x.Load
+	}
+	return 0
+}
+
+func (x *MultiConfig_AutoscaleOptions) GetInterval() string {
+	if x != nil {
+		return x.Interval
+	}
+	return ""
+}
+
 var File_internal_tool_multi_multi_proto protoreflect.FileDescriptor
 
 var file_internal_tool_multi_multi_proto_rawDesc = []byte{
@@ -161,28 +373,55 @@ var file_internal_tool_multi_multi_proto_rawDesc = []byte{
 	0x6d, 0x75, 0x6c, 0x74, 0x69, 0x2f, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x2e, 0x70, 0x72, 0x6f, 0x74,
 	0x6f, 0x12, 0x05, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x1a, 0x1b, 0x72, 0x75, 0x6e, 0x74, 0x69, 0x6d,
 	0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x97, 0x02, 0x0a, 0x0b, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x43,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x9c, 0x05, 0x0a, 0x0b, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x43,
 	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x24, 0x0a, 0x03, 0x61, 0x70, 0x70, 0x18, 0x01, 0x20, 0x01,
 	0x28, 0x0b, 0x32, 0x12, 0x2e, 0x72, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x2e, 0x41, 0x70, 0x70,
 	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x03, 0x61, 0x70, 0x70, 0x12, 0x12, 0x0a, 0x04, 0x6d,
 	0x74, 0x6c, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x6d, 0x74, 0x6c, 0x73, 0x12,
-	0x3f, 0x0a, 0x09, 0x6c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x73, 0x18, 0x03, 0x20, 0x03,
-	0x28, 0x0b, 0x32, 0x21, 0x2e, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x2e, 0x4d, 0x75, 0x6c, 0x74, 0x69,
-	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x73,
-	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x09, 0x6c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x73,
-	0x1a, 0x2b, 0x0a, 0x0f, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x4f, 0x70, 0x74, 0x69,
-	0x6f, 0x6e, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x1a, 0x60, 0x0a,
-	0x0e, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
+	0x16, 0x0a, 0x06, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x06, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x12, 0x3f, 0x0a, 0x09, 0x6c, 0x69, 0x73, 0x74, 0x65,
+	0x6e, 0x65, 0x72, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x6d, 0x75, 0x6c,
+	0x74, 0x69, 0x2e, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x4c,
+	0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x09, 0x6c,
+	0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x73, 0x12, 0x3c, 0x0a, 0x08, 0x72, 0x65, 0x70, 0x6c,
+	0x69, 0x63, 0x61, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x6d, 0x75, 0x6c,
+	0x74, 0x69, 0x2e, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x52,
+	0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x72, 0x65,
+	0x70, 0x6c, 0x69, 0x63, 0x61, 0x73, 0x12, 0x41, 0x0a, 0x09, 0x61, 0x75, 0x74, 0x6f, 0x73, 0x63,
+	0x61, 0x6c, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x6d, 0x75, 0x6c, 0x74,
+	0x69, 0x2e, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x41, 0x75,
+	0x74, 0x6f, 0x73, 0x63, 0x61, 0x6c, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x09,
+	0x61, 0x75, 0x74, 0x6f, 0x73, 0x63, 0x61, 0x6c, 0x65, 0x1a, 0x2b, 0x0a, 0x0f, 0x4c, 0x69, 0x73,
+	0x74, 0x65, 0x6e, 0x65, 0x72, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x18, 0x0a, 0x07,
+	0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61,
+	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x1a, 0x60, 0x0a, 0x0e, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e,
+	0x65, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x38, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x6d, 0x75, 0x6c, 0x74,
+	0x69, 0x2e, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x4c, 0x69,
+	0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x34, 0x0a, 0x0e, 0x52, 0x65, 0x70, 0x6c,
+	0x69, 0x63, 0x61, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x69,
+	0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x6d, 0x69, 0x6e, 0x12, 0x10, 0x0a, 0x03,
+	0x6d, 0x61, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x6d, 0x61, 0x78, 0x1a, 0x5e,
+	0x0a, 0x0d, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
 	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
-	0x79, 0x12, 0x38, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x22, 0x2e, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x2e, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x43, 0x6f,
-	0x6e, 0x66, 0x69, 0x67, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x4f, 0x70, 0x74,
-	0x69, 0x6f, 0x6e, 0x73, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x42,
-	0x35, 0x5a, 0x33, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x53, 0x65,
-	0x72, 0x76, 0x69, 0x63, 0x65, 0x57, 0x65, 0x61, 0x76, 0x65, 0x72, 0x2f, 0x77, 0x65, 0x61, 0x76,
-	0x65, 0x72, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x74, 0x6f, 0x6f, 0x6c,
-	0x2f, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x79, 0x12, 0x37, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x21, 0x2e, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x2e, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x43, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x2e, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x4f, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x54,
+	0x0a, 0x10, 0x41, 0x75, 0x74, 0x6f, 0x73, 0x63, 0x61, 0x6c, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x63, 0x70, 0x75, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x03, 0x63, 0x70, 0x75, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x04, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x69, 0x6e, 0x74, 0x65,
+	0x72, 0x76, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x69, 0x6e, 0x74, 0x65,
+	0x72, 0x76, 0x61, 0x6c, 0x22, 0x33, 0x0a, 0x0c, 0x52, 0x6f, 0x6c, 0x6c, 0x6f, 0x75, 0x74, 0x52,
+	0x65, 0x70, 0x6c, 0x79, 0x12, 0x23, 0x0a, 0x0d, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x6d, 0x65,
+	0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x64, 0x65, 0x70,
+	0x6c, 0x6f, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x42, 0x29, 0x5a, 0x27, 0x67, 0x69, 0x74,
+	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x73, 0x68, 0x33, 0x6c, 0x6b, 0x2f, 0x6d, 0x78,
+	0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x74, 0x6f, 0x6f, 0x6c, 0x2f, 0x6d,
+	0x75, 0x6c, 0x74, 0x69, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -197,22 +436,29 @@ func file_internal_tool_multi_multi_proto_rawDescGZIP() []byte {
 	return file_internal_tool_multi_multi_proto_rawDescData
 }
 
-var file_internal_tool_multi_multi_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_internal_tool_multi_multi_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
 var file_internal_tool_multi_multi_proto_goTypes = []interface{}{
-	(*MultiConfig)(nil),                 // 0: multi.MultiConfig
-	(*MultiConfig_ListenerOptions)(nil), // 1: multi.MultiConfig.ListenerOptions
-	nil,                                 // 2: multi.MultiConfig.ListenersEntry
-	(*protos.AppConfig)(nil),            // 3: runtime.AppConfig
+	(*MultiConfig)(nil),                  // 0: multi.MultiConfig
+	(*RolloutReply)(nil),                 // 1: multi.RolloutReply
+	(*MultiConfig_ListenerOptions)(nil),  // 2: multi.MultiConfig.ListenerOptions
+	nil,                                  // 3: multi.MultiConfig.ListenersEntry
+	(*MultiConfig_ReplicaOptions)(nil),   // 4: multi.MultiConfig.ReplicaOptions
+	nil,                                  // 5: multi.MultiConfig.ReplicasEntry
+	(*MultiConfig_AutoscaleOptions)(nil), // 6: multi.MultiConfig.AutoscaleOptions
+	(*protos.AppConfig)(nil),             // 7: runtime.AppConfig
 }
 var file_internal_tool_multi_multi_proto_depIdxs = []int32{
-	3, // 0: multi.MultiConfig.app:type_name -> runtime.AppConfig
-	2, // 1: multi.MultiConfig.listeners:type_name -> multi.MultiConfig.ListenersEntry
-	1, // 2: multi.MultiConfig.ListenersEntry.value:type_name -> multi.MultiConfig.ListenerOptions
-	3, // [3:3] is the sub-list for method output_type
-	3, // [3:3] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	7, // 0: multi.MultiConfig.app:type_name -> runtime.AppConfig
+	3, // 1: multi.MultiConfig.listeners:type_name -> multi.MultiConfig.ListenersEntry
+	5, // 2: multi.MultiConfig.replicas:type_name -> multi.MultiConfig.ReplicasEntry
+	6, // 3: multi.MultiConfig.autoscale:type_name -> multi.MultiConfig.AutoscaleOptions
+	2, // 4: multi.MultiConfig.ListenersEntry.value:type_name -> multi.MultiConfig.ListenerOptions
+	4, // 5: multi.MultiConfig.ReplicasEntry.value:type_name -> multi.MultiConfig.ReplicaOptions
+	6, // [6:6] is the sub-list for method output_type
+	6, // [6:6] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
 }
 
 func init() { file_internal_tool_multi_multi_proto_init() }
@@ -234,6 +480,18 @@ func file_internal_tool_multi_multi_proto_init() {
 			}
 		}
 		file_internal_tool_multi_multi_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RolloutReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_tool_multi_multi_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*MultiConfig_ListenerOptions); i {
 			case 0:
 				return &v.state
@@ -245,6 +503,30 @@ func file_internal_tool_multi_multi_proto_init() {
 				return nil
 			}
 		}
+		file_internal_tool_multi_multi_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MultiConfig_ReplicaOptions); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_tool_multi_multi_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MultiConfig_AutoscaleOptions); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -252,7 +534,7 @@ func file_internal_tool_multi_multi_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_internal_tool_multi_multi_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   3,
+			NumMessages:   7,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -0,0 +1,329 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package multi
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sh3lk/mx/internal/proxy"
+	"github.com/sh3lk/mx/internal/status"
+	"github.com/sh3lk/mx/runtime"
+	"github.com/sh3lk/mx/runtime/protomsg"
+	"github.com/sh3lk/mx/runtime/retry"
+	"github.com/sh3lk/mx/runtime/tool"
+)
+
+const (
+	// The URL path of the rollout endpoint of the deployer.
+	rolloutURL = "/rollout"
+
+	// The number of steps in which traffic is shifted from the old to the new
+	// version of an application during a rollout.
+	rolloutSteps = 10
+
+	// How long a rollout waits for the new version to become healthy.
+	readyTimeout = 2 * time.Minute
+
+	// How long the replicas of the old version have to run their Shutdown
+	// methods before they are killed.
+	drainTimeout = 30 * time.Second
+)
+
+// errDrained is the error that stops a deployer once it has been drained.
+var errDrained = errors.New("version drained")
+
+var rolloutCmd = tool.Command{
+	Name:        "rollout",
+	Description: "Roll out a new version of a deployed MX app",
+	Help: `Usage:
+  mx multi rollout <configfile>
+
+Flags:
+  -h, --help	Print this help message.
+
+Description:
+  "mx multi rollout" replaces a running "mx multi deploy" deployment of an
+  application with the binary in the provided config, without downtime. The
+  new version is started next to the old one, and once it is healthy, the
+  traffic of the application listeners is gradually shifted to it over the
+  rollout duration in the config. Finally, the old version is drained: its
+  components' Shutdown methods run, and its processes exit.
+
+  The two versions never call each other: every component of the old version
+  only calls components of the old version, and every component of the new
+  version only calls components of the new version.`,
+	Flags: flag.NewFlagSet("rollout", flag.ContinueOnError),
+	Fn:    rollout,
+}
+
+// rollout rolls out a new version of an application deployed with the
+// multiprocess deployer.
+func rollout(ctx context.Context, args []string) error {
+	// Validate command line arguments.
+	if len(args) == 0 {
+		return fmt.Errorf("no config file provided")
+	}
+	if len(args) > 1 {
+		return fmt.Errorf("too many arguments")
+	}
+	config, err := loadConfig(args[0])
+	if err != nil {
+		return err
+	}
+
+	// Find the running deployment of the application.
+	registry, err := defaultRegistry(ctx)
+	if err != nil {
+		return fmt.Errorf("create registry: %w", err)
+	}
+	regs, err := registry.List(ctx)
+	if err != nil {
+		return fmt.Errorf("list deployments: %w", err)
+	}
+	var found []status.Registration
+	for _, reg := range regs {
+		if reg.App == config.App.Name {
+			found = append(found, reg)
+		}
+	}
+	switch len(found) {
+	case 0:
+		return fmt.Errorf("no deployment of app %q found; use 'mx multi deploy' to deploy it", config.App.Name)
+	case 1:
+	default:
+		return fmt.Errorf("found %d deployments of app %q; a rollout needs exactly one", len(found), config.App.Name)
+	}
+
+	fmt.Fprintf(os.Stderr, "Rolling out a new version of app %q (deployment %s)...\n", config.App.Name, found[0].DeploymentId)
+	reply := &RolloutReply{}
+	if err := protomsg.Call(ctx, protomsg.CallArgs{
+		Client:  http.DefaultClient,
+		Addr:    "http://" + found[0].Addr,
+		URLPath: rolloutURL,
+		Request: config,
+		Reply:   reply,
+	}); err != nil {
+		return fmt.Errorf("rollout: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "Rolled out deployment %s\n", reply.DeploymentId)
+	return nil
+}
+
+// A manager manages the versions of an application deployment. Normally,
+// a single version of the application runs. During a rollout, the new
+// version runs next to the old one until the old one is drained.
+//
+// Every version is run by its own deployer, with its own colocation groups,
+// routing information, and certificates, so a version only ever calls
+// itself. The versions share the proxies of the application listeners.
+type manager struct {
+	ctx      context.Context
+	tmpDir   string
+	registry *status.Registry
+	proxies  *proxy.Set
+
+	mu      sync.Mutex // guards the following
+	current *deployer  // the version that receives traffic once rollouts end
+	rolling bool       // is a rollout in progress?
+}
+
+// newManager returns a new manager. The deployments are stopped when the
+// provided context is canceled.
+func newManager(ctx context.Context, tmpDir string, registry *status.Registry) *manager {
+	return &manager{
+		ctx:      ctx,
+		tmpDir:   tmpDir,
+		registry: registry,
+		proxies:  proxy.NewSet(ctx),
+	}
+}
+
+// start starts a new version of the application with the provided config,
+// along with a status server for the version, and registers the version.
+func (m *manager) start(deploymentId string, config *MultiConfig) (*deployer, error) {
+	// Create the deployer.
+	d, err := newDeployer(m.ctx, deploymentId, config, m.tmpDir, m.proxies)
+	if err != nil {
+		return nil, fmt.Errorf("create deployer: %w", err)
+	}
+
+	// Run a status server.
+	lis, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		d.stop(err)
+		return nil, fmt.Errorf("listen: %w", err)
+	}
+	mux := http.NewServeMux()
+	status.RegisterServer(mux, d, d.logger)
+	mux.Handle(rolloutURL, protomsg.HandlerFunc(d.logger, m.rollout))
+	go func() {
+		if err := serveHTTP(d.ctx, lis, mux); err != nil {
+			fmt.Fprintf(os.Stderr, "status server: %v\n", err)
+		}
+	}()
+
+	// Deploy main.
+	if err := d.startMain(); err != nil {
+		d.stop(err)
+		return nil, fmt.Errorf("start main process: %w", err)
+	}
+
+	// Wait for the status server to become active.
+	client := status.NewClient(lis.Addr().String())
+	for r := retry.Begin(); r.Continue(d.ctx); {
+		_, err := client.Status(d.ctx)
+		if err == nil {
+			break
+		}
+		fmt.Fprintf(os.Stderr, "status server %q unavailable: %#v\n", lis.Addr(), err)
+	}
+
+	// Register the deployment, and unregister it once it stops.
+	reg := status.Registration{
+		DeploymentId: deploymentId,
+		App:          config.App.Name,
+		Addr:         lis.Addr().String(),
+	}
+	fmt.Fprint(os.Stderr, reg.Rolodex())
+	if err := m.registry.Register(m.ctx, reg); err != nil {
+		d.stop(err)
+		return nil, fmt.Errorf("register deployment: %w", err)
+	}
+	unregister := func() { m.registry.Unregister(m.ctx, deploymentId) }
+	runtime.OnExitSignal(unregister)
+	go func() {
+		<-d.ctx.Done()
+		unregister()
+	}()
+
+	m.mu.Lock()
+	if m.current == nil {
+		m.current = d
+	}
+	m.mu.Unlock()
+	return d, nil
+}
+
+// wait waits for the application to stop, and returns the error that stopped
+// it. Versions that are replaced by a rollout don't stop the application.
+func (m *manager) wait() error {
+	for {
+		m.mu.Lock()
+		d := m.current
+		m.mu.Unlock()
+
+		err := d.wait()
+
+		m.mu.Lock()
+		replaced := m.current != d
+		m.mu.Unlock()
+		if !replaced {
+			m.registry.Unregister(m.ctx, d.deploymentId)
+			return err
+		}
+	}
+}
+
+// rollout rolls out a new version of the application with the provided
+// config. It starts the new version, waits for it to become healthy, shifts
+// the traffic of the application listeners to it over the rollout duration
+// of the config, and finally drains the old version. If the rollout fails,
+// or is canceled before all of the traffic is shifted, the traffic is moved
+// back to the old version and the new version is stopped.
+func (m *manager) rollout(ctx context.Context, config *MultiConfig) (*RolloutReply, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	old := m.current
+	if m.rolling {
+		m.mu.Unlock()
+		return nil, fmt.Errorf("a rollout of app %q is already in progress", old.config.App.Name)
+	}
+	if got, want := config.App.Name, old.config.App.Name; got != want {
+		m.mu.Unlock()
+		return nil, fmt.Errorf("cannot roll out app %q over app %q", got, want)
+	}
+	m.rolling = true
+	m.mu.Unlock()
+	defer func() {
+		m.mu.Lock()
+		m.rolling = false
+		m.mu.Unlock()
+	}()
+
+	// Start the new version, without sending it any traffic.
+	deploymentId := uuid.New().String()
+	m.proxies.SetWeight(deploymentId, 0)
+	next, err := m.start(deploymentId, config)
+	if err != nil {
+		return nil, err
+	}
+	abort := func(err error) (*RolloutReply, error) {
+		old.logger.Error("Rollout failed", "version", deploymentId, "err", err)
+		m.proxies.SetWeight(old.deploymentId, 1)
+		m.proxies.SetWeight(deploymentId, 0)
+		next.stop(fmt.Errorf("rollout aborted: %w", err))
+		return nil, err
+	}
+
+	// Wait for the new version to become healthy.
+	readyCtx, cancel := context.WithTimeout(ctx, readyTimeout)
+	defer cancel()
+	for r := retry.Begin(); !next.ready(); {
+		if !r.Continue(readyCtx) {
+			return abort(fmt.Errorf("version %s not healthy after %v", deploymentId, readyTimeout))
+		}
+		if next.ctx.Err() != nil {
+			return abort(fmt.Errorf("version %s stopped", deploymentId))
+		}
+	}
+
+	// Gradually shift traffic to the new version.
+	duration := time.Duration(config.App.RolloutNanos)
+	for i := 1; i <= rolloutSteps; i++ {
+		fraction := float64(i) / rolloutSteps
+		m.proxies.SetWeight(deploymentId, fraction)
+		m.proxies.SetWeight(old.deploymentId, 1-fraction)
+		old.logger.Info("Rolling out", "version", deploymentId, "traffic", fraction)
+		if i == rolloutSteps {
+			break
+		}
+		select {
+		case <-time.After(duration / rolloutSteps):
+		case <-ctx.Done():
+			return abort(ctx.Err())
+		case <-next.ctx.Done():
+			return abort(fmt.Errorf("version %s stopped", deploymentId))
+		}
+	}
+
+	// Drain the old version.
+	m.mu.Lock()
+	m.current = next
+	m.mu.Unlock()
+	old.drain(drainTimeout)
+	return &RolloutReply{DeploymentId: deploymentId}, nil
+}
@@ -0,0 +1,101 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package multi
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/sh3lk/mx/runtime/protos"
+)
+
+// tokenLifetime is the lifetime of the bearer tokens of the co-location
+// groups. The mxns request a new token before their current one expires.
+const tokenLifetime = time.Hour
+
+// tokenClaims are the claims of the bearer token of a co-location group,
+// signed with the deployer's key. The subject of the token is the name of the
+// group, and its audience is the deployment id.
+type tokenClaims struct {
+	jwt.StandardClaims
+	Components []string `json:"components"` // the components in the group
+}
+
+// GetSelfToken implements the control.DeployerControl interface.
+func (h *handler) GetSelfToken(context.Context, *protos.GetSelfTokenRequest) (*protos.GetSelfTokenReply, error) {
+	if h.tokenKey == nil {
+		return nil, fmt.Errorf("token authentication is not enabled")
+	}
+	var components []string
+	for component, g := range h.groups {
+		if g == h.g {
+			components = append(components, component)
+		}
+	}
+	slices.Sort(components)
+
+	now := time.Now()
+	claims := tokenClaims{
+		StandardClaims: jwt.StandardClaims{
+			Audience:  h.deploymentId,
+			Subject:   h.g.name,
+			IssuedAt:  now.Unix(),
+			ExpiresAt: now.Add(tokenLifetime).Unix(),
+		},
+		Components: components,
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodES256, claims).SignedString(h.tokenKey)
+	if err != nil {
+		return nil, fmt.Errorf("cannot sign token: %w", err)
+	}
+	return &protos.GetSelfTokenReply{
+		Token:         token,
+		ExpiresMicros: time.Unix(claims.ExpiresAt, 0).UnixMicro(),
+	}, nil
+}
+
+// VerifyClientToken implements the control.DeployerControl interface.
+func (h *handler) VerifyClientToken(_ context.Context, req *protos.VerifyClientTokenRequest) (*protos.VerifyClientTokenReply, error) {
+	if h.tokenKey == nil {
+		return nil, fmt.Errorf("token authentication is not enabled")
+	}
+	var claims tokenClaims
+	_, err := jwt.ParseWithClaims(req.Token, &claims, func(t *jwt.Token) (any, error) {
+		if t.Method != jwt.SigningMethodES256 {
+			return nil, fmt.Errorf("unexpected signing method %v", t.Header["alg"])
+		}
+		return &h.tokenKey.PublicKey, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("invalid token: %w", err)
+	}
+	if !claims.VerifyAudience(h.deploymentId, true) {
+		return nil, fmt.Errorf("invalid token: audience %q is not deployment %q", claims.Audience, h.deploymentId)
+	}
+
+	// Find which mxn components the client is allowed to call.
+	g, ok := h.groups[claims.Subject]
+	if !ok || g.name != claims.Subject {
+		return nil, fmt.Errorf("unknown client group %q", claims.Subject)
+	}
+	return &protos.VerifyClientTokenReply{
+		Components:    g.callable,
+		Callers:       g.hosted,
+		ExpiresMicros: time.Unix(claims.ExpiresAt, 0).UnixMicro(),
+	}, nil
+}
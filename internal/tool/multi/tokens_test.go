@@ -0,0 +1,87 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package multi
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	mxruntime "github.com/sh3lk/mx/runtime"
+	"github.com/sh3lk/mx/runtime/protos"
+)
+
+func TestParseTokensConfig(t *testing.T) {
+	var config MultiConfig
+	sections := map[string]string{shortConfigKey: "tokens = true"}
+	if err := mxruntime.ParseConfigSection(configKey, shortConfigKey, sections, &config); err != nil {
+		t.Fatal(err)
+	}
+	if !config.Tokens {
+		t.Error("tokens = true not parsed")
+	}
+}
+
+func TestTokens(t *testing.T) {
+	newDeployer := func(id string) *deployer {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// Group "a" holds components "a" and "b", and may call "c".
+		a := &group{name: "a", callable: []string{"c"}}
+		c := &group{name: "c"}
+		return &deployer{
+			deploymentId: id,
+			tokenKey:     key,
+			groups:       map[string]*group{"a": a, "b": a, "c": c},
+		}
+	}
+	ctx := context.Background()
+	d := newDeployer("1")
+	client := &handler{deployer: d, g: d.groups["a"]}
+	server := &handler{deployer: d, g: d.groups["c"]}
+
+	reply, err := client.GetSelfToken(ctx, &protos.GetSelfTokenRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	verified, err := server.VerifyClientToken(ctx, &protos.VerifyClientTokenRequest{Token: reply.Token})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"c"}, verified.Components); diff != "" {
+		t.Errorf("components (-want +got):\n%s", diff)
+	}
+	if verified.ExpiresMicros != reply.ExpiresMicros {
+		t.Errorf("expiration: got %d, want %d", verified.ExpiresMicros, reply.ExpiresMicros)
+	}
+
+	// Tokens issued by other deployers, and malformed tokens, are rejected.
+	other := newDeployer("2")
+	otherClient := &handler{deployer: other, g: other.groups["a"]}
+	otherReply, err := otherClient.GetSelfToken(ctx, &protos.GetSelfTokenRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, token := range []string{otherReply.Token, reply.Token + "x", ""} {
+		if _, err := server.VerifyClientToken(ctx, &protos.VerifyClientTokenRequest{Token: token}); err == nil {
+			t.Errorf("VerifyClientToken(%q): unexpected success", token)
+		}
+	}
+}
@@ -20,7 +20,6 @@ import (
 	"errors"
 	"flag"
 	"fmt"
-	"io"
 	"os"
 	"os/exec"
 	"os/signal"
@@ -38,8 +37,6 @@ import (
 	"github.com/sh3lk/mx/runtime"
 	"github.com/sh3lk/mx/runtime/bin"
 	"github.com/sh3lk/mx/runtime/codegen"
-	"github.com/sh3lk/mx/runtime/colors"
-	"github.com/sh3lk/mx/runtime/logging"
 	"github.com/sh3lk/mx/runtime/tool"
 	"github.com/sh3lk/mx/runtime/version"
 )
@@ -69,33 +66,67 @@ func deploy(ctx context.Context, args []string) error {
 	}
 
 	// Load the config file.
-	cfgFile := args[0]
+	config, err := loadConfig(args[0])
+	if err != nil {
+		return err
+	}
+
+	// Retrieve the list of locations to deploy.
+	locs, err := getLocations(config)
+	if err != nil {
+		return err
+	}
+
+	// Deploy the first version of the application.
+	d := newDeployment(ctx, locs)
+	config.DepId = uuid.New().String()
+	if _, err := d.start(config); err != nil {
+		return err
+	}
+
+	// Wait for the user to kill the app.
+	done := make(chan os.Signal, 1)
+	signal.Notify(done, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		<-done // Will block here until user hits ctrl+c
+		d.terminate()
+		fmt.Fprintf(os.Stderr, "Application %s terminated\n", config.App.Name)
+		os.Exit(1)
+	}()
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+// loadConfig loads and validates the SSH deployer config in the provided
+// file, and checks that the application binary is compatible with the
+// deployer.
+func loadConfig(cfgFile string) (*impl.SshConfig, error) {
+	// Load the config file.
 	cfg, err := os.ReadFile(cfgFile)
 	if err != nil {
-		return fmt.Errorf("load config file %q: %w", cfgFile, err)
+		return nil, fmt.Errorf("load config file %q: %w", cfgFile, err)
 	}
 
 	// Parse and sanity-check the app config.
 	app, err := runtime.ParseConfig(cfgFile, string(cfg), codegen.ComponentConfigValidator)
 	if err != nil {
-		return fmt.Errorf("load config file %q: %w", cfgFile, err)
+		return nil, fmt.Errorf("load config file %q: %w", cfgFile, err)
 	}
 	if _, err := os.Stat(app.Binary); errors.Is(err, os.ErrNotExist) {
-		return fmt.Errorf("binary %q doesn't exist", app.Binary)
+		return nil, fmt.Errorf("binary %q doesn't exist", app.Binary)
 	}
 
 	// Parse and finalize the SSH config.
 	config, err := config.GetDeployerConfig[impl.SshConfig, impl.SshConfig_ListenerOptions](configKey, shortConfigKey, app)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	config.App = app
-	config.DepId = uuid.New().String()
 
 	// Check version compatibility.
 	versions, err := bin.ReadVersions(app.Binary)
 	if err != nil {
-		return fmt.Errorf("read versions: %w", err)
+		return nil, fmt.Errorf("read versions: %w", err)
 	}
 	if versions.DeployerVersion != version.DeployerVersion {
 		// Try to relativize the binary, defaulting to the absolute path if
@@ -108,9 +139,9 @@ func deploy(ctx context.Context, args []string) error {
 		}
 		selfVersion, err := itool.SelfVersion()
 		if err != nil {
-			return fmt.Errorf("read self version: %w", err)
+			return nil, fmt.Errorf("read self version: %w", err)
 		}
-		return fmt.Errorf(`
+		return nil, fmt.Errorf(`
 ERROR: The binary you're trying to deploy (%q) was built with
 github.com/sh3lk/mx module version %s. However, the 'mx
 ssh' binary you're using was built with mx module version %s.
@@ -127,56 +158,7 @@ persists, please file an issue at https://github.com/sh3lk/mx/issues.`,
 			binary, versions.ModuleVersion, selfVersion)
 	}
 
-	// Retrieve the list of locations to deploy.
-	locs, err := getLocations(config)
-	if err != nil {
-		return err
-	}
-
-	// Copy the binaries to each location.
-	locations, err := copyBinaries(locs, app.Binary, config.DepId)
-	if err != nil {
-		return err
-	}
-
-	// Run the manager.
-	stopFn, err := impl.RunManager(ctx, config, locations)
-	if err != nil {
-		return fmt.Errorf("cannot instantiate the manager: %w", err)
-	}
-
-	// Wait for the user to kill the app.
-	done := make(chan os.Signal, 1)
-	signal.Notify(done, syscall.SIGINT, syscall.SIGTERM)
-	go func() {
-		<-done // Will block here until user hits ctrl+c
-		if err := terminateDeployment(locs, config.DepId); err != nil {
-			fmt.Fprintf(os.Stderr, "failed to terminate deployment: %v\n", err)
-		}
-		fmt.Fprintf(os.Stderr, "Application %s terminated\n", app.Name)
-		if err := stopFn(); err != nil {
-			fmt.Fprintf(os.Stderr, "stop the manager: %v\n", err)
-		}
-		os.Exit(1)
-	}()
-
-	// Follow the logs.
-	source := logging.FileSource(impl.LogDir)
-	query := fmt.Sprintf(`full_version == %q && !("mx/system" in attrs)`, config.DepId)
-	r, err := source.Query(ctx, query, true)
-	if err != nil {
-		return err
-	}
-	pp := logging.NewPrettyPrinter(colors.Enabled())
-	for {
-		entry, err := r.Read(ctx)
-		if errors.Is(err, io.EOF) {
-			return nil
-		} else if err != nil {
-			return err
-		}
-		fmt.Println(pp.Format(entry))
-	}
+	return config, nil
 }
 
 // copyBinaries copies the tool and the application binary
@@ -224,6 +206,18 @@ func terminateDeployment(locs []string, depId string) error {
 	return nil
 }
 
+// killDeployment kills all the processes corresponding to the deployment at
+// all locations, without giving them a chance to shut down.
+func killDeployment(locs []string, depId string) error {
+	for _, loc := range locs {
+		cmd := exec.Command("ssh", loc, "pkill", "-KILL", "-f", depId)
+		if err := cmd.Run(); err != nil {
+			return fmt.Errorf("unable to kill deployment at location %s: %w", loc, err)
+		}
+	}
+	return nil
+}
+
 // getLocations returns the list of locations at which to deploy the application.
 func getLocations(config *impl.SshConfig) ([]string, error) {
 	file, err := getAbsoluteFilePath(config.Locations)
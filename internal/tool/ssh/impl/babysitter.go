@@ -16,15 +16,21 @@ package impl
 
 import (
 	"context"
+	"crypto/x509"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
 	"os"
+	"os/signal"
 	"sync"
+	"sync/atomic"
+	"syscall"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/sh3lk/mx/internal/proto"
+	"github.com/sh3lk/mx/internal/supervisor"
 	"github.com/sh3lk/mx/runtime/envelope"
 	"github.com/sh3lk/mx/runtime/logging"
 	"github.com/sh3lk/mx/runtime/metrics"
@@ -40,16 +46,28 @@ type babysitter struct {
 	info         *BabysitterInfo
 	logger       *slog.Logger
 	exportTraces func(spans *protos.TraceSpans) error // exports to the manager
-	envelope     *envelope.Envelope
+	draining     atomic.Bool                          // is the deployment being drained?
 
+	// The following fields are set only if mTLS is enabled (see mtls.go).
+	caCert *x509.Certificate // certificate of the certificate authority
+	signer *http.Client      // client of the manager's signing endpoint
+	certMu sync.Mutex        // guards cert
+	cert   *selfCert         // current certificate of the colocation group
+
+	// The following fields describe the current replica. They are reset
+	// every time the replica is restarted.
 	mu                  sync.Mutex
-	watchingRoutingInfo map[string]bool
+	replicaCtx          context.Context    // canceled when the replica stops
+	envelope            *envelope.Envelope // envelope of the replica
+	watchingRoutingInfo map[string]bool    // watched components
+	listeners           map[string]string  // exported listener addresses, by listener name
 }
 
 var _ envelope.EnvelopeHandler = &babysitter{}
 
 // RunBabysitter creates and runs an envelope.Envelope and a metrics collector for a
-// mxn deployed with SSH.
+// mxn deployed with SSH. If the mxn crashes or becomes unhealthy,
+// RunBabysitter restarts it.
 func RunBabysitter(ctx context.Context) error {
 	// Retrieve the deployment information.
 	info := &BabysitterInfo{}
@@ -86,34 +104,106 @@ func RunBabysitter(ctx context.Context) error {
 				Request: spans,
 			})
 		},
-		watchingRoutingInfo: map[string]bool{},
 	}
 
+	if info.Mtls {
+		if err := b.initMTLS(); err != nil {
+			return fmt.Errorf("unable to set up mTLS: %w", err)
+		}
+	}
+
+	// The deployment is drained by sending a SIGTERM to its babysitters and
+	// mxns. A mxn runs the Shutdown methods of its components before exiting,
+	// so keep running until it exits, without restarting it.
+	sigterm := make(chan os.Signal, 1)
+	signal.Notify(sigterm, syscall.SIGTERM)
+	go func() {
+		<-sigterm
+		b.logger.Info("Draining")
+		b.draining.Store(true)
+	}()
+
+	supervisor.Supervise(ctx, b.logger, func(restarts int) error {
+		var restart bool
+		mxnId := id
+		if restarts > 0 {
+			mxnId = uuid.New().String()
+		}
+		restart, err = b.runReplica(mxnId, restarts)
+		if restart {
+			return err
+		}
+		return nil
+	})
+	return err
+}
+
+// runReplica starts a mxn and blocks until it exits. It returns true, along
+// with the cause of the failure, if the mxn failed and should be restarted.
+func (b *babysitter) runReplica(id string, restarts int) (bool, error) {
 	// Start the envelope.
 	wlet := &protos.MXNArgs{
-		App:          info.App.Name,
-		DeploymentId: info.DepId,
+		App:          b.info.App.Name,
+		DeploymentId: b.info.DepId,
 		Id:           id,
-		RunMain:      info.RunMain,
+		RunMain:      b.info.RunMain,
+		Mtls:         b.info.Mtls,
 	}
-	e, err := envelope.NewEnvelope(ctx, wlet, info.App, envelope.Options{
-		Logger: b.logger,
+	ctx, cancel := context.WithCancel(b.ctx)
+	defer cancel()
+	e, err := envelope.NewEnvelope(ctx, wlet, b.info.App, envelope.Options{
+		Logger:       b.logger,
+		SharedMemory: true,
 	})
 	if err != nil {
-		return err
+		// Only retry if the mxn started successfully before.
+		return restarts > 0, err
 	}
+
+	b.mu.Lock()
+	b.replicaCtx = ctx
 	b.envelope = e
+	b.watchingRoutingInfo = map[string]bool{}
+	b.listeners = map[string]string{}
+	b.mu.Unlock()
 
 	pid, ok := e.Pid()
 	if !ok {
 		panic("ssh deployer child must be a real process")
 	}
-	if err := b.registerReplica(e.MXNAddress(), pid, id); err != nil {
-		return err
+	if err := b.registerReplica(ctx, e, pid, id, restarts); err != nil {
+		return restarts > 0, err
 	}
-	c := metricsCollector{logger: b.logger, envelope: e, info: info}
+	c := metricsCollector{logger: b.logger, envelope: e, info: b.info}
 	go c.run(ctx)
-	return e.Serve(b)
+	go b.reportLoad(ctx, e)
+	go supervisor.WatchHealth(ctx, b.logger, e, cancel)
+	err = e.Serve(b)
+
+	if b.ctx.Err() != nil || b.draining.Load() {
+		// The babysitter is stopping, or the deployment is being drained.
+		return false, err
+	}
+	if e.ExitError() == nil && ctx.Err() == nil {
+		// The mxn exited successfully (e.g., because main returned).
+		return false, err
+	}
+
+	// The mxn crashed or was killed because it was unhealthy. Stop routing
+	// traffic to it before restarting it.
+	b.mu.Lock()
+	listeners := b.listeners
+	b.mu.Unlock()
+	if uerr := b.unregisterReplica(e.MXNAddress(), listeners); uerr != nil {
+		b.logger.Error("Unable to unregister failed replica", "err", uerr)
+	}
+	if exitErr := e.ExitError(); exitErr != nil {
+		err = exitErr
+	}
+	if err == nil {
+		err = errors.New("mxn exited")
+	}
+	return true, err
 }
 
 type metricsCollector struct {
@@ -158,9 +248,43 @@ func (m *metricsCollector) run(ctx context.Context) {
 	}
 }
 
+// reportLoad periodically reports the load of the replica to the manager,
+// until ctx is done.
+func (b *babysitter) reportLoad(ctx context.Context, e *envelope.Envelope) {
+	ticker := time.NewTicker(loadBalanceInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			load, err := e.GetLoad()
+			if err != nil {
+				b.logger.Error("Unable to collect load", "err", err)
+				continue
+			}
+			if err := protomsg.Call(ctx, protomsg.CallArgs{
+				Client:  http.DefaultClient,
+				Addr:    b.info.ManagerAddr,
+				URLPath: recvLoadURL,
+				Request: &BabysitterLoad{
+					Group:   b.info.Group,
+					Address: e.MXNAddress(),
+					Load:    load,
+				},
+			}); err != nil {
+				b.logger.Error("Error reporting load", "err", err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
 // ActivateComponent implements the protos.EnvelopeHandler interface.
 func (b *babysitter) ActivateComponent(_ context.Context, req *protos.ActivateComponentRequest) (*protos.ActivateComponentReply, error) {
-	if err := protomsg.Call(b.ctx, protomsg.CallArgs{
+	b.mu.Lock()
+	ctx, e := b.replicaCtx, b.envelope
+	b.mu.Unlock()
+	if err := protomsg.Call(ctx, protomsg.CallArgs{
 		Client:  http.DefaultClient,
 		Addr:    b.info.ManagerAddr,
 		URLPath: startComponentURL,
@@ -173,32 +297,50 @@ func (b *babysitter) ActivateComponent(_ context.Context, req *protos.ActivateCo
 	defer b.mu.Unlock()
 	if !b.watchingRoutingInfo[req.Component] {
 		b.watchingRoutingInfo[req.Component] = true
-		go b.watchRoutingInfo(req.Component, req.Routed)
+		go b.watchRoutingInfo(ctx, e, req.Component, req.Routed)
 	}
 	return &protos.ActivateComponentReply{}, nil
 }
 
 // registerReplica registers the information about a colocation group replica
 // (i.e., a mxn).
-func (b *babysitter) registerReplica(replicaAddr string, pid int, mxnId string) error {
-	if err := protomsg.Call(b.ctx, protomsg.CallArgs{
+func (b *babysitter) registerReplica(ctx context.Context, e *envelope.Envelope, pid int, mxnId string, restarts int) error {
+	if err := protomsg.Call(ctx, protomsg.CallArgs{
 		Client:  http.DefaultClient,
 		Addr:    b.info.ManagerAddr,
 		URLPath: registerReplicaURL,
 		Request: &ReplicaToRegister{
-			Group:   b.info.Group,
-			Address: replicaAddr,
-			Pid:     int64(pid),
-			MXNId:   mxnId,
+			Group:              b.info.Group,
+			Address:            e.MXNAddress(),
+			Pid:                int64(pid),
+			MXNId:              mxnId,
+			Restarts:           int32(restarts),
+			Location:           b.info.Location,
+			SharedMemorySocket: e.SharedMemorySocket(),
 		},
 	}); err != nil {
 		return err
 	}
 
-	go b.watchComponents()
+	go b.watchComponents(ctx, e)
 	return nil
 }
 
+// unregisterReplica unregisters a colocation group replica (i.e., a mxn) that
+// has failed.
+func (b *babysitter) unregisterReplica(replicaAddr string, listeners map[string]string) error {
+	return protomsg.Call(b.ctx, protomsg.CallArgs{
+		Client:  http.DefaultClient,
+		Addr:    b.info.ManagerAddr,
+		URLPath: unregisterReplicaURL,
+		Request: &ReplicaToUnregister{
+			Group:     b.info.Group,
+			Address:   replicaAddr,
+			Listeners: listeners,
+		},
+	})
+}
+
 // GetListenerAddress implements the protos.EnvelopeHandler interface.
 func (b *babysitter) GetListenerAddress(context.Context, *protos.GetListenerAddressRequest) (*protos.GetListenerAddressReply, error) {
 	host, err := os.Hostname()
@@ -220,36 +362,34 @@ func (b *babysitter) ExportListener(_ context.Context, req *protos.ExportListene
 	}); err != nil {
 		return nil, err
 	}
+	b.mu.Lock()
+	b.listeners[req.Listener] = req.Address
+	b.mu.Unlock()
 	return reply, nil
 }
 
-// GetSelfCertificate implements the envelope.EnvelopeHandler interface.
-func (b *babysitter) GetSelfCertificate(context.Context, *protos.GetSelfCertificateRequest) (*protos.GetSelfCertificateReply, error) {
-	// TODO(spetrovic): Implement this functionality.
-	panic("unimplemented")
-}
-
-// VerifyClientCertificate implements the envelope.EnvelopeHandler interface.
-func (b *babysitter) VerifyClientCertificate(context.Context, *protos.VerifyClientCertificateRequest) (*protos.VerifyClientCertificateReply, error) {
-	// TODO(spetrovic): Implement this functionality.
-	panic("unimplemented")
+// GetSelfToken implements the envelope.EnvelopeHandler interface.
+func (b *babysitter) GetSelfToken(context.Context, *protos.GetSelfTokenRequest) (*protos.GetSelfTokenReply, error) {
+	// The ssh deployer doesn't support token authentication.
+	return nil, fmt.Errorf("token authentication is not enabled")
 }
 
-// VerifyServerCertificate implements the envelope.EnvelopeHandler interface.
-func (b *babysitter) VerifyServerCertificate(context.Context, *protos.VerifyServerCertificateRequest) (*protos.VerifyServerCertificateReply, error) {
-	// TODO(spetrovic): Implement this functionality.
-	panic("unimplemented")
+// VerifyClientToken implements the envelope.EnvelopeHandler interface.
+func (b *babysitter) VerifyClientToken(context.Context, *protos.VerifyClientTokenRequest) (*protos.VerifyClientTokenReply, error) {
+	// The ssh deployer doesn't support token authentication.
+	return nil, fmt.Errorf("token authentication is not enabled")
 }
 
-func (b *babysitter) getRoutingInfo(component string, routed bool, version string) (*protos.RoutingInfo, string, error) {
+func (b *babysitter) getRoutingInfo(ctx context.Context, component string, routed bool, version string) (*protos.RoutingInfo, string, error) {
 	req := &GetRoutingInfoRequest{
 		RequestingGroup: b.info.Group,
 		Component:       component,
 		Routed:          routed,
 		Version:         version,
+		Location:        b.info.Location,
 	}
 	reply := &GetRoutingInfoReply{}
-	if err := protomsg.Call(b.ctx, protomsg.CallArgs{
+	if err := protomsg.Call(ctx, protomsg.CallArgs{
 		Client:  http.DefaultClient,
 		Addr:    b.info.ManagerAddr,
 		URLPath: getRoutingInfoURL,
@@ -261,16 +401,16 @@ func (b *babysitter) getRoutingInfo(component string, routed bool, version strin
 	return reply.RoutingInfo, reply.Version, nil
 }
 
-func (b *babysitter) watchRoutingInfo(component string, routed bool) {
+func (b *babysitter) watchRoutingInfo(ctx context.Context, e *envelope.Envelope, component string, routed bool) {
 	version := ""
-	for r := retry.Begin(); r.Continue(b.ctx); {
-		routing, newVersion, err := b.getRoutingInfo(component, routed, version)
+	for r := retry.Begin(); r.Continue(ctx); {
+		routing, newVersion, err := b.getRoutingInfo(ctx, component, routed, version)
 		if err != nil {
 			b.logger.Error("cannot get routing info; will retry", "err", err, "component", component)
 			continue
 		}
 		version = newVersion
-		if err := b.envelope.UpdateRoutingInfo(routing); err != nil {
+		if err := e.UpdateRoutingInfo(routing); err != nil {
 			b.logger.Error("cannot update routing info; will retry", "err", err, "component", component)
 			continue
 		}
@@ -283,10 +423,10 @@ func (b *babysitter) watchRoutingInfo(component string, routed bool) {
 	}
 }
 
-func (b *babysitter) getComponentsToStart(version string) ([]string, string, error) {
+func (b *babysitter) getComponentsToStart(ctx context.Context, version string) ([]string, string, error) {
 	req := &GetComponentsRequest{Group: b.info.Group, Version: version}
 	reply := &GetComponentsReply{}
-	if err := protomsg.Call(b.ctx, protomsg.CallArgs{
+	if err := protomsg.Call(ctx, protomsg.CallArgs{
 		Client:  http.DefaultClient,
 		Addr:    b.info.ManagerAddr,
 		URLPath: getComponentsToStartURL,
@@ -298,16 +438,16 @@ func (b *babysitter) getComponentsToStart(version string) ([]string, string, err
 	return reply.Components, reply.Version, nil
 }
 
-func (b *babysitter) watchComponents() {
+func (b *babysitter) watchComponents(ctx context.Context, e *envelope.Envelope) {
 	version := ""
-	for r := retry.Begin(); r.Continue(b.ctx); {
-		components, newVersion, err := b.getComponentsToStart(version)
+	for r := retry.Begin(); r.Continue(ctx); {
+		components, newVersion, err := b.getComponentsToStart(ctx, version)
 		if err != nil {
 			b.logger.Error("cannot get components to start; will retry", "err", err)
 			continue
 		}
 		version = newVersion
-		if err := b.envelope.UpdateComponents(components); err != nil {
+		if err := e.UpdateComponents(components); err != nil {
 			b.logger.Error("cannot update components to start; will retry", "err", err)
 			continue
 		}
@@ -16,6 +16,7 @@ package impl
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"log/slog"
@@ -24,6 +25,8 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"sort"
+	"strings"
 	"sync"
 	"syscall"
 	"time"
@@ -52,16 +55,24 @@ const (
 	// URL suffixes for various SSH manager handlers.
 	getComponentsToStartURL = "/manager/get_components_to_start"
 	registerReplicaURL      = "/manager/register_replica"
+	unregisterReplicaURL    = "/manager/unregister_replica"
 	exportListenerURL       = "/manager/export_listener"
 	startComponentURL       = "/manager/start_component"
 	getRoutingInfoURL       = "/manager/get_routing_info"
 	recvLogEntryURL         = "/manager/recv_log_entry"
 	recvTraceSpansURL       = "/manager/recv_trace_spans"
 	recvMetricsURL          = "/manager/recv_metrics"
+	recvLoadURL             = "/manager/recv_load"
+	rolloutURL              = "/manager/rollout"
+	signCertificateURL      = "/manager/sign_certificate"
 
 	// babysitterInfoKey is the name of the env variable that contains deployment
 	// information for a babysitter deployed using SSH.
 	babysitterInfoKey = "MX_BABYSITTER_INFO"
+
+	// The interval between two load reports of a babysitter, and between two
+	// rebalancings of the assignments of routed components.
+	loadBalanceInterval = 30 * time.Second
 )
 
 var (
@@ -82,9 +93,11 @@ var (
 // duplicated code.
 type manager struct {
 	ctx        context.Context
+	cancel     context.CancelFunc // stops the manager
 	config     *SshConfig
 	logger     *slog.Logger
 	mgrAddress string // manager address
+	caAddress  string // address of the certificate signing endpoint, if any
 	registry   *status.Registry
 	started    time.Time
 
@@ -116,9 +129,19 @@ type manager struct {
 	// itself.
 	colocation map[string]string
 
+	// ca, if not nil, is the certificate authority that signs the
+	// certificates of the colocation groups. It is set iff mTLS is enabled.
+	ca *certAuthority
+
+	// proxies contains the proxies of the application listeners. They are
+	// shared with the other versions of the application during a rollout.
+	proxies *proxy.Set
+
+	// rollout, if not nil, rolls out a new version of the application.
+	rollout func(context.Context, *SshConfig) (*RolloutReply, error)
+
 	mu      sync.Mutex                                    // guards following structures, but not contents
 	groups  map[string]*group                             // groups, by group name
-	proxies map[string]*proxyInfo                         // proxies, by listener name
 	metrics map[groupReplicaInfo][]*protos.MetricSnapshot // latest metrics, by group name and replica id
 }
 
@@ -130,13 +153,10 @@ type group struct {
 	started   bool                                                 // has this group been started?
 	addresses map[string]bool                                      // mxn addresses
 	routings  map[string]*versioned.Versioned[*protos.RoutingInfo] // routing info, by component
-	replicas  []*status.Replica                                    // stores replica info such as pid, mxn id
-}
-
-type proxyInfo struct {
-	listener string       // listener name
-	proxy    *proxy.Proxy // the proxy
-	addr     string       // dialable address of the proxy
+	loads     map[string]*protos.LoadReport                        // latest load report, by replica address
+	replicas  map[string]*status.Replica                           // replica info such as pid, mxn id, by address
+	locations map[string]string                                    // replica locations, by address
+	sockets   map[string]string                                    // replica shared-memory sockets, by address
 }
 
 type groupReplicaInfo struct {
@@ -146,8 +166,17 @@ type groupReplicaInfo struct {
 
 var _ status.Server = &manager{}
 
-// RunManager creates and runs a new manager.
-func RunManager(ctx context.Context, config *SshConfig, locations map[string]string) (func() error, error) {
+// A Deployment is a version of an application, run by a manager.
+type Deployment struct {
+	m *manager
+}
+
+// RunManager creates and runs a new manager for the version of the
+// application in the provided config. The application listeners are exported
+// through the provided proxies, which are shared by all of the versions of
+// the application. If rollout is not nil, the manager serves requests to roll
+// out a new version of the application, sent by Rollout, by calling rollout.
+func RunManager(ctx context.Context, config *SshConfig, locations map[string]string, proxies *proxy.Set, rollout func(context.Context, *SshConfig) (*RolloutReply, error)) (*Deployment, error) {
 	app := config.App
 	// Create log saver.
 	fs, err := logging.NewFileStore(LogDir)
@@ -183,9 +212,20 @@ func RunManager(ctx context.Context, config *SshConfig, locations map[string]str
 		}
 	}
 
+	// Create the certificate authority, if mTLS is enabled.
+	var ca *certAuthority
+	if config.Mtls {
+		ca, err = newCertAuthority(config, colocation)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Create the manager.
+	ctx, cancel := context.WithCancel(ctx)
 	m := &manager{
 		ctx:            ctx,
+		cancel:         cancel,
 		config:         config,
 		locations:      locations,
 		logger:         logger,
@@ -194,8 +234,10 @@ func RunManager(ctx context.Context, config *SshConfig, locations map[string]str
 		statsProcessor: imetrics.NewStatsProcessor(),
 		started:        time.Now(),
 		colocation:     colocation,
+		ca:             ca,
+		proxies:        proxies,
+		rollout:        rollout,
 		groups:         map[string]*group{},
-		proxies:        map[string]*proxyInfo{},
 		metrics:        map[groupReplicaInfo][]*protos.MetricSnapshot{},
 	}
 
@@ -226,9 +268,50 @@ func RunManager(ctx context.Context, config *SshConfig, locations map[string]str
 		}
 	}()
 
-	return func() error {
-		return m.registry.Unregister(m.ctx, config.DepId)
-	}, nil
+	return &Deployment{m: m}, nil
+}
+
+// Ready returns whether every started colocation group of the deployment has
+// a registered replica at every location.
+func (d *Deployment) Ready() bool {
+	var started int
+	for _, g := range d.m.allGroups() {
+		g.mu.Lock()
+		ready := !g.started || len(g.replicas) >= len(d.m.locations)
+		if g.started {
+			started++
+		}
+		g.mu.Unlock()
+		if !ready {
+			return false
+		}
+	}
+	return started > 0
+}
+
+// Stop unregisters the deployment and stops its manager. Stop doesn't stop
+// the babysitters and mxns of the deployment.
+func (d *Deployment) Stop() error {
+	defer d.m.cancel()
+	registry, err := DefaultRegistry(d.m.ctx)
+	if err != nil {
+		return err
+	}
+	return registry.Unregister(d.m.ctx, d.m.config.DepId)
+}
+
+// Rollout asks the manager listening on the provided address to roll out a
+// new version of its application, with the provided config.
+func Rollout(ctx context.Context, addr string, config *SshConfig) (*RolloutReply, error) {
+	reply := &RolloutReply{}
+	err := protomsg.Call(ctx, protomsg.CallArgs{
+		Client:  http.DefaultClient,
+		Addr:    "http://" + addr,
+		URLPath: rolloutURL,
+		Request: config,
+		Reply:   reply,
+	})
+	return reply, err
 }
 
 func (m *manager) run() error {
@@ -253,6 +336,12 @@ func (m *manager) run() error {
 			m.logger.Error("Unable to start HTTP server", "err", err)
 		}
 	}()
+	if m.ca != nil {
+		if err := m.serveCA(host); err != nil {
+			return err
+		}
+	}
+	go m.balance()
 
 	// Start the main process.
 	if err := m.startComponent(m.ctx, &protos.ActivateComponentRequest{
@@ -290,12 +379,41 @@ func (m *manager) run() error {
 func (m *manager) addHTTPHandlers(mux *http.ServeMux) {
 	mux.HandleFunc(getComponentsToStartURL, protomsg.HandlerFunc(m.logger, m.getComponentsToStart))
 	mux.HandleFunc(registerReplicaURL, protomsg.HandlerDo(m.logger, m.registerReplica))
+	mux.HandleFunc(unregisterReplicaURL, protomsg.HandlerDo(m.logger, m.unregisterReplica))
 	mux.HandleFunc(exportListenerURL, protomsg.HandlerFunc(m.logger, m.exportListener))
 	mux.HandleFunc(startComponentURL, protomsg.HandlerDo(m.logger, m.startComponent))
 	mux.HandleFunc(getRoutingInfoURL, protomsg.HandlerFunc(m.logger, m.getRoutingInfo))
 	mux.HandleFunc(recvLogEntryURL, protomsg.HandlerDo(m.logger, m.handleLogEntry))
 	mux.HandleFunc(recvTraceSpansURL, protomsg.HandlerDo(m.logger, m.handleTraceSpans))
 	mux.HandleFunc(recvMetricsURL, protomsg.HandlerDo(m.logger, m.handleRecvMetrics))
+	mux.HandleFunc(recvLoadURL, protomsg.HandlerDo(m.logger, m.handleRecvLoad))
+	if m.rollout != nil {
+		mux.HandleFunc(rolloutURL, protomsg.HandlerFunc(m.logger, m.rollout))
+	}
+}
+
+// serveCA serves the certificate signing endpoint over TLS, on a listener of
+// its own, since the other endpoints are served over plain HTTP.
+func (m *manager) serveCA(host string) error {
+	config, err := m.ca.tlsConfig()
+	if err != nil {
+		return fmt.Errorf("certificate signing endpoint: %w", err)
+	}
+	lis, err := net.Listen("tcp", fmt.Sprintf("%s:0", host))
+	if err != nil {
+		return fmt.Errorf("listen: %w", err)
+	}
+	m.caAddress = fmt.Sprintf("https://%s", lis.Addr())
+	m.logger.Info("Certificate signing endpoint listening", "address", m.caAddress)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(signCertificateURL, protomsg.HandlerFunc(m.logger, m.signCertificate))
+	go func() {
+		if err := serveHTTP(m.ctx, tls.NewListener(lis, config), mux); err != nil {
+			m.logger.Error("Unable to start certificate signing endpoint", "err", err)
+		}
+	}()
+	return nil
 }
 
 // registerStatusPages registers the status pages with the provided mux.
@@ -317,7 +435,7 @@ func (m *manager) Status(ctx context.Context) (*status.Status, error) {
 		for _, component := range cs {
 			c := &status.Component{
 				Name:     component,
-				Replicas: g.replicas,
+				Replicas: g.allReplicas(),
 			}
 			components = append(components, c)
 
@@ -352,13 +470,11 @@ func (m *manager) Status(ctx context.Context) (*status.Status, error) {
 		}
 	}
 
-	m.mu.Lock()
-	defer m.mu.Unlock()
 	var listeners []*status.Listener
-	for name, proxy := range m.proxies {
+	for name, addr := range m.proxies.Listeners() {
 		listeners = append(listeners, &status.Listener{
 			Name: name,
-			Addr: proxy.addr,
+			Addr: addr,
 		})
 	}
 	app := m.config.App
@@ -407,7 +523,10 @@ func (m *manager) group(component string) *group {
 			addresses:  map[string]bool{},
 			components: versioned.Version(map[string]bool{}),
 			routings:   map[string]*versioned.Versioned[*protos.RoutingInfo]{},
-			replicas:   []*status.Replica{},
+			replicas:   map[string]*status.Replica{},
+			locations:  map[string]string{},
+			sockets:    map[string]string{},
+			loads:      map[string]*protos.LoadReport{},
 		}
 		m.groups[name] = g
 	}
@@ -423,6 +542,20 @@ func (g *group) allAddresses() []string {
 	return maps.Keys(g.addresses) // creates a new slice.
 }
 
+// allReplicas returns a copy of the info of all current replicas in the
+// group, sorted by pid.
+//
+// REQUIRES: g.mu is NOT held.
+func (g *group) allReplicas() []*status.Replica {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	replicas := maps.Values(g.replicas) // creates a new slice.
+	sort.Slice(replicas, func(i, j int) bool {
+		return replicas[i].Pid < replicas[j].Pid
+	})
+	return replicas
+}
+
 // routing returns the RoutingInfo for the provided component.
 //
 // REQUIRES: g.mu is NOT held.
@@ -468,36 +601,137 @@ func (m *manager) registerReplica(_ context.Context, req *ReplicaToRegister) err
 			return true
 		}
 		g.addresses[req.Address] = true
-		g.replicas = append(g.replicas, &status.Replica{Pid: req.Pid, MXNId: req.MXNId})
+		g.replicas[req.Address] = &status.Replica{
+			Pid:      req.Pid,
+			MXNId:    req.MXNId,
+			Restarts: req.Restarts,
+		}
+		g.locations[req.Address] = req.Location
+		g.sockets[req.Address] = req.SharedMemorySocket
 		return false
 	}
 	if record() {
 		return nil
 	}
+	g.updateRoutings()
+	return nil
+}
 
-	// Update routing.
-	replicas := g.allAddresses()
-	for _, routing := range g.routings {
-		routing.Lock()
-		routing.Val.Replicas = replicas
-		if routing.Val.Assignment != nil {
-			routing.Val.Assignment = routingAlgo(routing.Val.Assignment, replicas)
+// unregisterReplica unregisters a replica of a colocation group that has
+// failed, so that traffic is no longer routed to it.
+func (m *manager) unregisterReplica(_ context.Context, req *ReplicaToUnregister) error {
+	// Stop proxying traffic to the replica's listeners.
+	for name, addr := range req.Listeners {
+		m.proxies.Remove(name, addr)
+	}
+
+	g := m.group(req.Group)
+	remove := func() bool {
+		g.mu.Lock()
+		defer g.mu.Unlock()
+		if !g.addresses[req.Address] {
+			// Replica already unregistered.
+			return false
 		}
-		routing.Unlock()
+		delete(g.addresses, req.Address)
+		delete(g.replicas, req.Address)
+		delete(g.locations, req.Address)
+		delete(g.sockets, req.Address)
+		delete(g.loads, req.Address)
+		return true
+	}
+	if remove() {
+		g.updateRoutings()
 	}
 	return nil
 }
 
-func (m *manager) exportListener(_ context.Context, req *protos.ExportListenerRequest) (*protos.ExportListenerReply, error) {
-	m.mu.Lock()
-	defer m.mu.Unlock()
+// updateRoutings updates the routing info of every component in the group to
+// reflect the group's current set of replicas.
+//
+// REQUIRES: g.mu is NOT held.
+func (g *group) updateRoutings() {
+	replicas := g.allAddresses()
+	g.mu.Lock()
+	routings := maps.Clone(g.routings)
+	g.mu.Unlock()
+	for component, info := range routings {
+		loads := g.componentLoads(component)
+		info.Lock()
+		info.Val.Replicas = replicas
+		if info.Val.Assignment != nil {
+			info.Val.Assignment = routing.BalanceLoad(info.Val.Assignment, replicas, loads)
+		}
+		info.Unlock()
+	}
+}
 
-	// Update the proxy.
-	if p, ok := m.proxies[req.Listener]; ok {
-		p.proxy.AddBackend(req.Address)
-		return &protos.ExportListenerReply{ProxyAddress: p.addr}, nil
+// componentLoads returns the latest load reported for the provided component
+// by the replicas of the group.
+//
+// REQUIRES: g.mu is NOT held.
+func (g *group) componentLoads(component string) []*protos.LoadReport_ComponentLoad {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	var loads []*protos.LoadReport_ComponentLoad
+	for _, report := range g.loads {
+		if load, ok := report.Loads[component]; ok {
+			loads = append(loads, load)
+		}
+	}
+	return loads
+}
+
+// balance periodically rebalances the assignments of the routed components of
+// every colocation group, based on the load reported by the group's replicas,
+// until the manager is stopped.
+func (m *manager) balance() {
+	ticker := time.NewTicker(loadBalanceInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-m.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		for _, g := range m.allGroups() {
+			g.rebalance()
+		}
+	}
+}
+
+// rebalance rebalances the assignments of the routed components of the group,
+// based on the latest load reported by the group's replicas.
+//
+// REQUIRES: g.mu is NOT held.
+func (g *group) rebalance() {
+	replicas := g.allAddresses()
+	g.mu.Lock()
+	routings := maps.Clone(g.routings)
+	g.mu.Unlock()
+	for component, info := range routings {
+		loads := g.componentLoads(component)
+		info.RLock("")
+		current := info.Val.Assignment
+		info.RUnlock()
+		if current == nil || routing.TotalLoad(current.Version, loads) == 0 {
+			// The component is not routed, there is no load to balance, or
+			// the load is stale.
+			continue
+		}
+		next := routing.BalanceLoad(current, replicas, loads)
+		if next == current {
+			continue
+		}
+		info.Lock()
+		if info.Val.Assignment == current {
+			info.Val.Assignment = next
+		}
+		info.Unlock()
 	}
+}
 
+func (m *manager) exportListener(_ context.Context, req *protos.ExportListenerRequest) (*protos.ExportListenerReply, error) {
 	// Get the proxy address. It should be the same as the LocalAddress field
 	// in the options for this listener, if any was specified.
 	var proxyAddr string
@@ -505,7 +739,7 @@ func (m *manager) exportListener(_ context.Context, req *protos.ExportListenerRe
 		proxyAddr = opts.Address
 	}
 
-	lis, err := net.Listen("tcp", proxyAddr)
+	addr, err := m.proxies.Export(m.logger, req.Listener, proxyAddr, m.config.DepId, req.Address)
 	if errors.Is(err, syscall.EADDRINUSE) {
 		// Don't retry if the address is already in use.
 		return &protos.ExportListenerReply{Error: err.Error()}, nil
@@ -513,20 +747,6 @@ func (m *manager) exportListener(_ context.Context, req *protos.ExportListenerRe
 	if err != nil {
 		return nil, fmt.Errorf("proxy listen: %w", err)
 	}
-	addr := lis.Addr().String() // actual proxy address
-	m.logger.Info("Proxy listening", "address", addr)
-	proxy := proxy.NewProxy(m.logger)
-	proxy.AddBackend(req.Address)
-	m.proxies[req.Listener] = &proxyInfo{
-		listener: req.Listener,
-		proxy:    proxy,
-		addr:     addr,
-	}
-	go func() {
-		if err := serveHTTP(m.ctx, lis, proxy); err != nil {
-			m.logger.Error("Proxy", "err", err)
-		}
-	}()
 	return &protos.ExportListenerReply{ProxyAddress: addr}, nil
 }
 
@@ -549,15 +769,15 @@ func (m *manager) startComponent(ctx context.Context, req *protos.ActivateCompon
 	}
 
 	// Update the routing info.
-	routing := g.routing(req.Component)
+	info := g.routing(req.Component)
 	addresses := g.allAddresses()
 	update := func() {
-		routing.Lock()
-		defer routing.Unlock()
+		info.Lock()
+		defer info.Unlock()
 
-		routing.Val.Replicas = addresses
+		info.Val.Replicas = addresses
 		if req.Routed {
-			routing.Val.Assignment = routingAlgo(&protos.Assignment{}, routing.Val.Replicas)
+			info.Val.Assignment = routing.BalanceLoad(&protos.Assignment{}, info.Val.Replicas, nil)
 		}
 	}
 	update()
@@ -591,6 +811,15 @@ func (m *manager) startColocationGroup(g *group, runMain bool) error {
 			ReplicaId:   int32(replicaId),
 			LogDir:      LogDir,
 			RunMain:     runMain,
+			Location:    loc,
+		}
+		if m.ca != nil {
+			info.Mtls = true
+			info.CaCert = m.ca.certPEM
+			info.Token = m.ca.tokens[g.name]
+			info.CaAddr = m.caAddress
+			info.Groups = m.ca.groups
+			info.Callable = m.ca.callable
 		}
 		if err := m.startBabysitter(loc, info); err != nil {
 			return fmt.Errorf("unable to start babysitter for group %s at location %s: %w\n", g.name, loc, err)
@@ -601,6 +830,16 @@ func (m *manager) startColocationGroup(g *group, runMain bool) error {
 	return nil
 }
 
+// signCertificate signs a certificate for a colocation group.
+func (m *manager) signCertificate(_ context.Context, req *CertificateRequest) (*CertificateReply, error) {
+	reply, err := m.ca.sign(req)
+	if err != nil {
+		m.logger.Error("Unable to sign certificate", "group", req.Group, "err", err)
+		return nil, err
+	}
+	return reply, nil
+}
+
 func (m *manager) handleLogEntry(_ context.Context, entry *protos.LogEntry) error {
 	m.logSaver(entry)
 	return nil
@@ -620,8 +859,27 @@ func (m *manager) handleRecvMetrics(_ context.Context, metrics *BabysitterMetric
 	return nil
 }
 
+// handleRecvLoad records the load reported by a replica of a group, if the
+// replica is still one of the group's replicas.
+func (m *manager) handleRecvLoad(_ context.Context, load *BabysitterLoad) error {
+	g := m.group(load.Group)
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.addresses[load.Address] {
+		g.loads[load.Address] = load.Load
+	}
+	return nil
+}
+
 // startBabysitter starts a new babysitter that manages a colocation group using SSH.
+//
+// The info is passed in an environment variable on the ssh command line,
+// where other users of the machines can see it, except for the token of the
+// group, which is written to the stdin of the babysitter instead.
 func (m *manager) startBabysitter(loc string, info *BabysitterInfo) error {
+	token := info.Token
+	info = protomsg.Clone(info)
+	info.Token = ""
 	input, err := proto.ToEnv(info)
 	if err != nil {
 		return err
@@ -630,6 +888,7 @@ func (m *manager) startBabysitter(loc string, info *BabysitterInfo) error {
 	env := fmt.Sprintf("%s=%s", babysitterInfoKey, input)
 	binaryPath := filepath.Join(m.locations[loc], "mx")
 	cmd := exec.Command("ssh", loc, env, binaryPath, "ssh", "babysitter")
+	cmd.Stdin = strings.NewReader(token)
 	return cmd.Start()
 }
 
@@ -650,16 +909,28 @@ func (m *manager) getRoutingInfo(_ context.Context, req *GetRoutingInfoRequest)
 	routing := target.routing(req.Component)
 	version := routing.RLock(req.Version)
 	defer routing.RUnlock()
+	info := protomsg.Clone(routing.Val)
+	info.SharedMemory = target.sharedMemory(info.Replicas, req.Location)
 	return &GetRoutingInfoReply{
-		RoutingInfo: protomsg.Clone(routing.Val),
+		RoutingInfo: info,
 		Version:     version,
 	}, nil
 }
 
-func routingAlgo(currAssignment *protos.Assignment, candidates []string) *protos.Assignment {
-	assignment := routing.EqualSlices(candidates)
-	assignment.Version = currAssignment.Version + 1
-	return assignment
+// sharedMemory returns the shared-memory sockets of the provided replicas
+// that run at the provided location, by replica address.
+//
+// REQUIRES: g.mu is NOT held.
+func (g *group) sharedMemory(replicas []string, location string) map[string]string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	sockets := map[string]string{}
+	for _, addr := range replicas {
+		if socket := g.sockets[addr]; socket != "" && g.locations[addr] == location {
+			sockets[addr] = socket
+		}
+	}
+	return sockets
 }
 
 // serveHTTP serves HTTP traffic on the provided listener using the provided
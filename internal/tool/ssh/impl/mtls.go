@@ -0,0 +1,388 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package impl
+
+import (
+	"context"
+	"crypto"
+	"crypto/subtle"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sh3lk/mx/internal/tool/certs"
+	"github.com/sh3lk/mx/runtime/bin"
+	"github.com/sh3lk/mx/runtime/graph"
+	"github.com/sh3lk/mx/runtime/protomsg"
+	"github.com/sh3lk/mx/runtime/protos"
+	"github.com/sh3lk/mx/runtime/retry"
+)
+
+// With mTLS enabled, every colocation group has a certificate, signed by the
+// deployment's certificate authority, that names the group. The manager holds
+// the certificate authority. Every babysitter generates its own private key,
+// and asks the manager to sign a certificate for it, so that private keys
+// never leave the machines where they are used. The requests are
+// authenticated with a token that the manager issues to every group, and are
+// sent over TLS, to an endpoint whose certificate, signed by the certificate
+// authority, names the manager (see signerName). Babysitters verify the
+// certificates of their peers on their own, using the certificate of the
+// certificate authority and the component call graph, which they receive in
+// their BabysitterInfo.
+//
+// Group certificates are short-lived. A babysitter requests a new certificate
+// once two thirds of the lifetime of its current one have elapsed. mxns
+// fetch the certificate from their babysitter on every TLS handshake, so new
+// connections use the new certificate, and established connections, which
+// are authenticated only once, keep working.
+
+// certLifetime is the lifetime of the certificates of the colocation groups.
+const certLifetime = 24 * time.Hour
+
+// maxTokenSize is the maximum size of the token of a colocation group.
+const maxTokenSize = 1 << 10
+
+// signerName is the name in the certificate of the manager's certificate
+// signing endpoint. No colocation group may have this name.
+const signerName = "mx-manager"
+
+// certAuthority is the certificate authority of a deployment with mTLS
+// enabled, held by the deployment's manager.
+type certAuthority struct {
+	cert     *x509.Certificate
+	key      crypto.PrivateKey
+	certPEM  string                    // PEM-encoded cert
+	tokens   map[string]string         // authenticate CertificateRequests, by group name
+	groups   map[string]string         // colocation group names, by component
+	callable map[string]*ComponentList // callable components, by group name
+}
+
+// newCertAuthority returns the certificate authority of the deployment with
+// the provided config and colocation groups (see manager.colocation). The
+// authority is loaded from the files in config.Ca, if any, or generated.
+func newCertAuthority(config *SshConfig, colocation map[string]string) (*certAuthority, error) {
+	var cert *x509.Certificate
+	var key crypto.PrivateKey
+	var err error
+	if ca := config.Ca; ca != nil && (ca.Cert != "" || ca.Key != "") {
+		if ca.Cert == "" || ca.Key == "" {
+			return nil, fmt.Errorf("ca: both cert and key must be set")
+		}
+		cert, key, err = certs.LoadCACert(ca.Cert, ca.Key)
+	} else {
+		cert, key, err = certs.GenerateCACert()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot create certificate authority: %w", err)
+	}
+	certPEM, _, err := certs.PEMEncode(cert, key)
+	if err != nil {
+		return nil, err
+	}
+
+	// Use the call graph to compute the set of components that every
+	// colocation group is allowed to call.
+	components, g, err := bin.ReadComponentGraph(config.App.Binary)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read the call graph from the application binary: %w", err)
+	}
+	groupOf := func(component string) string {
+		if name, ok := colocation[component]; ok {
+			return name
+		}
+		return component
+	}
+	groups := map[string]string{}
+	callable := map[string]*ComponentList{}
+	g.PerNode(func(n graph.Node) {
+		group := groupOf(components[n])
+		groups[components[n]] = group
+		if callable[group] == nil {
+			callable[group] = &ComponentList{}
+		}
+	})
+	graph.PerEdge(g, func(e graph.Edge) {
+		src := callable[groupOf(components[e.Src])]
+		src.Components = append(src.Components, components[e.Dst])
+	})
+
+	// Issue a token to every group, so that a group can't obtain the
+	// certificate of another one.
+	tokens := map[string]string{}
+	for group := range callable {
+		if group == signerName {
+			return nil, fmt.Errorf("colocation group name %q is reserved", group)
+		}
+		tokens[group] = uuid.New().String()
+	}
+
+	return &certAuthority{
+		cert:     cert,
+		key:      key,
+		certPEM:  string(certPEM),
+		tokens:   tokens,
+		groups:   groups,
+		callable: callable,
+	}, nil
+}
+
+// tlsConfig returns the TLS config of the manager's certificate signing
+// endpoint, with a certificate for signerName signed by the authority.
+func (a *certAuthority) tlsConfig() (*tls.Config, error) {
+	cert, key, err := certs.GenerateSignedCert(a.cert, a.key, signerName)
+	if err != nil {
+		return nil, err
+	}
+	certPEM, keyPEM, err := certs.PEMEncode(cert, key)
+	if err != nil {
+		return nil, err
+	}
+	tlsCert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return nil, err
+	}
+	return &tls.Config{
+		Certificates: []tls.Certificate{tlsCert},
+		MinVersion:   tls.VersionTLS13,
+	}, nil
+}
+
+// sign signs a certificate for the colocation group in the provided request.
+func (a *certAuthority) sign(req *CertificateRequest) (*CertificateReply, error) {
+	token, ok := a.tokens[req.Group]
+	if !ok {
+		return nil, fmt.Errorf("unknown group %q", req.Group)
+	}
+	if subtle.ConstantTimeCompare([]byte(req.Token), []byte(token)) != 1 {
+		return nil, fmt.Errorf("invalid token for group %q", req.Group)
+	}
+	cert, err := certs.SignCertRequest(a.cert, a.key, req.Csr, certLifetime, req.Group)
+	if err != nil {
+		return nil, err
+	}
+	return &CertificateReply{Cert: cert.Raw}, nil
+}
+
+// selfCert is the certificate of a babysitter's colocation group.
+type selfCert struct {
+	cert    *x509.Certificate
+	certPEM []byte
+	keyPEM  []byte
+}
+
+// renewAt returns when the certificate should be replaced by a new one.
+func (c *selfCert) renewAt() time.Time {
+	lifetime := c.cert.NotAfter.Sub(c.cert.NotBefore)
+	return c.cert.NotBefore.Add(lifetime * 2 / 3)
+}
+
+// requestCert returns a new certificate for the babysitter's colocation
+// group, signed by the manager.
+func (b *babysitter) requestCert(ctx context.Context) (*selfCert, error) {
+	csr, key, err := certs.GenerateCertRequest()
+	if err != nil {
+		return nil, err
+	}
+	reply := &CertificateReply{}
+	if err := protomsg.Call(ctx, protomsg.CallArgs{
+		Client:  b.signer,
+		Addr:    b.info.CaAddr,
+		URLPath: signCertificateURL,
+		Request: &CertificateRequest{
+			Group: b.info.Group,
+			Token: b.info.Token,
+			Csr:   csr,
+		},
+		Reply: reply,
+	}); err != nil {
+		return nil, err
+	}
+	cert, err := x509.ParseCertificate(reply.Cert)
+	if err != nil {
+		return nil, err
+	}
+	certPEM, keyPEM, err := certs.PEMEncode(cert, key)
+	if err != nil {
+		return nil, err
+	}
+	return &selfCert{cert: cert, certPEM: certPEM, keyPEM: keyPEM}, nil
+}
+
+// signerClient returns a client of the manager's certificate signing
+// endpoint. It only trusts an endpoint that the provided certificate
+// authority vouches for, since the requests carry the group's token.
+func signerClient(ca *x509.Certificate) *http.Client {
+	pool := x509.NewCertPool()
+	pool.AddCert(ca)
+	return &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				RootCAs:    pool,
+				ServerName: signerName,
+				MinVersion: tls.VersionTLS13,
+			},
+		},
+	}
+}
+
+// initMTLS sets up the babysitter's side of mTLS: it parses the certificate
+// of the certificate authority, obtains the first certificate of the
+// babysitter's colocation group, and starts rotating it.
+func (b *babysitter) initMTLS() error {
+	ca, err := certs.ParseCert([]byte(b.info.CaCert))
+	if err != nil {
+		return fmt.Errorf("bad CA certificate: %w", err)
+	}
+	b.caCert = ca
+	b.signer = signerClient(ca)
+
+	// The manager writes the token of the group to our stdin, to keep it out
+	// of the ssh command line (see manager.startBabysitter).
+	token, err := io.ReadAll(io.LimitReader(os.Stdin, maxTokenSize))
+	if err != nil {
+		return fmt.Errorf("cannot read the token: %w", err)
+	}
+	if len(token) == 0 {
+		return fmt.Errorf("no token")
+	}
+	b.info.Token = string(token)
+
+	for r := retry.Begin(); r.Continue(b.ctx); {
+		cert, err := b.requestCert(b.ctx)
+		if err != nil {
+			b.logger.Error("Unable to get certificate; will retry", "err", err)
+			continue
+		}
+		b.certMu.Lock()
+		b.cert = cert
+		b.certMu.Unlock()
+		go b.rotateCert(b.ctx, cert)
+		return nil
+	}
+	return b.ctx.Err()
+}
+
+// rotateCert replaces the certificate of the babysitter's colocation group,
+// starting with the provided one, before it expires, until ctx is done.
+func (b *babysitter) rotateCert(ctx context.Context, cert *selfCert) {
+	for {
+		timer := time.NewTimer(time.Until(cert.renewAt()))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		// Keep using the current certificate until a new one is obtained.
+		for r := retry.Begin(); r.Continue(ctx); {
+			next, err := b.requestCert(ctx)
+			if err != nil {
+				b.logger.Error("Unable to renew certificate; will retry", "err", err, "expires", cert.cert.NotAfter)
+				continue
+			}
+			cert = next
+			break
+		}
+		b.certMu.Lock()
+		b.cert = cert
+		b.certMu.Unlock()
+		b.logger.Debug("Renewed certificate", "expires", cert.cert.NotAfter)
+	}
+}
+
+// verifyCert verifies the provided certificate chain, signed by the
+// deployment's certificate authority, and returns the name of the colocation
+// group stored in it.
+func (b *babysitter) verifyCert(certChain [][]byte) (string, error) {
+	if b.caCert == nil {
+		return "", fmt.Errorf("mTLS is not enabled")
+	}
+	if n := len(certChain); n != 1 {
+		return "", fmt.Errorf("invalid cert chain length: want 1, got %d", n)
+	}
+	names, err := certs.VerifySignedCert(certChain[0], b.caCert)
+	if err != nil {
+		return "", fmt.Errorf("cannot verify the cert: %w", err)
+	}
+	if len(names) != 1 {
+		return "", fmt.Errorf("invalid cert: expected a single name, got %v", names)
+	}
+	name := names[0]
+	if name == "" {
+		return "", fmt.Errorf("empty group name %q in cert", name)
+	}
+	return name, nil
+}
+
+// GetSelfCertificate implements the envelope.EnvelopeHandler interface.
+func (b *babysitter) GetSelfCertificate(context.Context, *protos.GetSelfCertificateRequest) (*protos.GetSelfCertificateReply, error) {
+	b.certMu.Lock()
+	defer b.certMu.Unlock()
+	if b.cert == nil {
+		return nil, fmt.Errorf("mTLS is not enabled")
+	}
+	return &protos.GetSelfCertificateReply{
+		Cert: b.cert.certPEM,
+		Key:  b.cert.keyPEM,
+	}, nil
+}
+
+// VerifyClientCertificate implements the envelope.EnvelopeHandler interface.
+func (b *babysitter) VerifyClientCertificate(_ context.Context, req *protos.VerifyClientCertificateRequest) (*protos.VerifyClientCertificateReply, error) {
+	group, err := b.verifyCert(req.CertChain)
+	if err != nil {
+		return nil, err
+	}
+
+	// Find which components the client is allowed to call, and on behalf of
+	// which components.
+	callable, ok := b.info.Callable[group]
+	if !ok {
+		return nil, fmt.Errorf("unknown client group %q", group)
+	}
+	var hosted []string
+	for component, g := range b.info.Groups {
+		if g == group {
+			hosted = append(hosted, component)
+		}
+	}
+	sort.Strings(hosted)
+	return &protos.VerifyClientCertificateReply{Components: callable.Components, Callers: hosted}, nil
+}
+
+// VerifyServerCertificate implements the envelope.EnvelopeHandler interface.
+func (b *babysitter) VerifyServerCertificate(_ context.Context, req *protos.VerifyServerCertificateRequest) (*protos.VerifyServerCertificateReply, error) {
+	actual, err := b.verifyCert(req.CertChain)
+	if err != nil {
+		return nil, err
+	}
+
+	// Find the expected group name for the target component.
+	expected, ok := b.info.Groups[req.TargetComponent]
+	if !ok {
+		return nil, fmt.Errorf("unknown group for component %q", req.TargetComponent)
+	}
+	if expected != actual {
+		return nil, fmt.Errorf("invalid server identity for target component %s: want %q, got %q", req.TargetComponent, expected, actual)
+	}
+	return &protos.VerifyServerCertificateReply{}, nil
+}
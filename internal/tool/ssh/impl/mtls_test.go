@@ -0,0 +1,147 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package impl
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sh3lk/mx/internal/tool/certs"
+	"github.com/sh3lk/mx/runtime/logging"
+	"github.com/sh3lk/mx/runtime/protomsg"
+)
+
+// testAuthority returns a certificate authority for the groups "a" and "b",
+// whose tokens are "token-a" and "token-b".
+func testAuthority(t *testing.T) *certAuthority {
+	t.Helper()
+	cert, key, err := certs.GenerateCACert()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &certAuthority{
+		cert:     cert,
+		key:      key,
+		tokens:   map[string]string{"a": "token-a", "b": "token-b"},
+		groups:   map[string]string{"A": "a", "B": "b"},
+		callable: map[string]*ComponentList{"a": {Components: []string{"B"}}, "b": {}},
+	}
+}
+
+func TestSign(t *testing.T) {
+	ca := testAuthority(t)
+	csr, _, err := certs.GenerateCertRequest()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reply, err := ca.sign(&CertificateRequest{Group: "a", Token: "token-a", Csr: csr})
+	if err != nil {
+		t.Fatal(err)
+	}
+	names, err := certs.VerifySignedCert(reply.Cert, ca.cert)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"a"}, names); diff != "" {
+		t.Errorf("unexpected certificate names. (-want +got): %s", diff)
+	}
+
+	// The token of a group doesn't let other groups obtain certificates.
+	for _, req := range []*CertificateRequest{
+		{Group: "a", Token: "token-b", Csr: csr},
+		{Group: "b", Token: "token-a", Csr: csr},
+		{Group: "c", Token: "token-a", Csr: csr},
+		{Group: "a", Csr: csr},
+	} {
+		if _, err := ca.sign(req); err == nil {
+			t.Errorf("sign(group %q, token %q): unexpected success", req.Group, req.Token)
+		}
+	}
+}
+
+func TestSignerEndpoint(t *testing.T) {
+	ctx := context.Background()
+	ca := testAuthority(t)
+	csr, _, err := certs.GenerateCertRequest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	req := &CertificateRequest{Group: "a", Token: "token-a", Csr: csr}
+
+	// serve serves the certificate signing endpoint with the provided TLS
+	// certificate, signed by ca.
+	logger := logging.NewTestSlogger(t, testing.Verbose())
+	serve := func(t *testing.T, name string) *httptest.Server {
+		config, err := ca.tlsConfig()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if name != signerName {
+			cert, key, err := certs.GenerateSignedCert(ca.cert, ca.key, name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			config.Certificates[0].Certificate = [][]byte{cert.Raw}
+			config.Certificates[0].PrivateKey = key
+			config.Certificates[0].Leaf = nil
+		}
+		mux := http.NewServeMux()
+		mux.HandleFunc(signCertificateURL, protomsg.HandlerFunc(logger, func(_ context.Context, req *CertificateRequest) (*CertificateReply, error) {
+			return ca.sign(req)
+		}))
+		server := httptest.NewUnstartedServer(mux)
+		server.TLS = config
+		server.StartTLS()
+		t.Cleanup(server.Close)
+		return server
+	}
+	call := func(client *http.Client, server *httptest.Server) error {
+		return protomsg.Call(ctx, protomsg.CallArgs{
+			Client:  client,
+			Addr:    server.URL,
+			URLPath: signCertificateURL,
+			Request: req,
+			Reply:   &CertificateReply{},
+		})
+	}
+
+	t.Run("Trusted", func(t *testing.T) {
+		if err := call(signerClient(ca.cert), serve(t, signerName)); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("OtherName", func(t *testing.T) {
+		// A group certificate, although signed by ca, doesn't identify the
+		// manager.
+		if err := call(signerClient(ca.cert), serve(t, "b")); err == nil {
+			t.Fatal("unexpected success")
+		}
+	})
+
+	t.Run("OtherAuthority", func(t *testing.T) {
+		other, _, err := certs.GenerateCACert()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := call(signerClient(other), serve(t, signerName)); err == nil {
+			t.Fatal("unexpected success")
+		}
+	})
+}
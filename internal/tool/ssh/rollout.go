@@ -0,0 +1,350 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ssh
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sh3lk/mx/internal/proxy"
+	"github.com/sh3lk/mx/internal/status"
+	"github.com/sh3lk/mx/internal/tool/ssh/impl"
+	"github.com/sh3lk/mx/runtime/colors"
+	"github.com/sh3lk/mx/runtime/logging"
+	"github.com/sh3lk/mx/runtime/retry"
+	"github.com/sh3lk/mx/runtime/tool"
+)
+
+const (
+	// The number of steps in which traffic is shifted from the old to the new
+	// version of an application during a rollout.
+	rolloutSteps = 10
+
+	// How long a rollout waits for the new version to become ready.
+	readyTimeout = 2 * time.Minute
+
+	// How long the processes of the old version have to run their Shutdown
+	// methods before they are killed.
+	drainTimeout = 30 * time.Second
+)
+
+var rolloutCmd = tool.Command{
+	Name:        "rollout",
+	Description: "Roll out a new version of a deployed MX app",
+	Help: `Usage:
+  mx ssh rollout <configfile>
+
+Flags:
+  -h, --help	Print this help message.
+
+Description:
+  "mx ssh rollout" replaces a running "mx ssh deploy" deployment of an
+  application with the binary in the provided config, without downtime. The
+  new version is started next to the old one, at the same locations, and
+  once it is ready, the traffic of the application listeners is gradually
+  shifted to it over the rollout duration in the config. Finally, the old
+  version is drained: its components' Shutdown methods run, and its processes
+  exit.
+
+  The two versions never call each other: every component of the old version
+  only calls components of the old version, and every component of the new
+  version only calls components of the new version.`,
+	Flags: flag.NewFlagSet("rollout", flag.ContinueOnError),
+	Fn:    rollout,
+}
+
+// rollout rolls out a new version of an application deployed with the SSH
+// deployer.
+func rollout(ctx context.Context, args []string) error {
+	// Validate command line arguments.
+	if len(args) == 0 {
+		return fmt.Errorf("no config file provided")
+	}
+	if len(args) > 1 {
+		return fmt.Errorf("too many arguments")
+	}
+	config, err := loadConfig(args[0])
+	if err != nil {
+		return err
+	}
+
+	// Find the running deployment of the application.
+	registry, err := impl.DefaultRegistry(ctx)
+	if err != nil {
+		return fmt.Errorf("create registry: %w", err)
+	}
+	regs, err := registry.List(ctx)
+	if err != nil {
+		return fmt.Errorf("list deployments: %w", err)
+	}
+	var found []status.Registration
+	for _, reg := range regs {
+		if reg.App == config.App.Name {
+			found = append(found, reg)
+		}
+	}
+	switch len(found) {
+	case 0:
+		return fmt.Errorf("no deployment of app %q found; use 'mx ssh deploy' to deploy it", config.App.Name)
+	case 1:
+	default:
+		return fmt.Errorf("found %d deployments of app %q; a rollout needs exactly one", len(found), config.App.Name)
+	}
+
+	fmt.Fprintf(os.Stderr, "Rolling out a new version of app %q (deployment %s)...\n", config.App.Name, found[0].DeploymentId)
+	reply, err := impl.Rollout(ctx, found[0].Addr, config)
+	if err != nil {
+		return fmt.Errorf("rollout: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "Rolled out deployment %s\n", reply.DepId)
+	return nil
+}
+
+// A deployment is a deployment of an application across a set of locations.
+// Normally, a single version of the application runs. During a rollout, the
+// new version runs next to the old one until the old one is drained.
+//
+// Every version is run by its own manager, with its own colocation groups and
+// routing information, so a version only ever calls itself. The versions
+// share the proxies of the application listeners.
+type deployment struct {
+	ctx     context.Context
+	locs    []string
+	proxies *proxy.Set
+
+	mu       sync.Mutex             // guards the following
+	current  *appVersion            // the version that receives traffic once rollouts end
+	versions map[string]*appVersion // running versions, by deployment id
+	rolling  bool                   // is a rollout in progress?
+}
+
+// An appVersion is a running version of the application.
+type appVersion struct {
+	config *impl.SshConfig
+	dep    *impl.Deployment
+	cancel context.CancelFunc // stops following the logs of the version
+}
+
+// newDeployment returns a new deployment at the provided locations, with no
+// running versions.
+func newDeployment(ctx context.Context, locs []string) *deployment {
+	return &deployment{
+		ctx:      ctx,
+		locs:     locs,
+		proxies:  proxy.NewSet(ctx),
+		versions: map[string]*appVersion{},
+	}
+}
+
+// start starts a new version of the application with the provided config,
+// and prints its logs. config.DepId must be set.
+func (d *deployment) start(config *impl.SshConfig) (*appVersion, error) {
+	// Copy the binaries to each location.
+	locations, err := copyBinaries(d.locs, config.App.Binary, config.DepId)
+	if err != nil {
+		return nil, err
+	}
+
+	// Run the manager.
+	dep, err := impl.RunManager(d.ctx, config, locations, d.proxies, d.rollout)
+	if err != nil {
+		return nil, fmt.Errorf("cannot instantiate the manager: %w", err)
+	}
+
+	// Follow the logs.
+	ctx, cancel := context.WithCancel(d.ctx)
+	go func() {
+		if err := follow(ctx, config.DepId); err != nil && ctx.Err() == nil {
+			fmt.Fprintf(os.Stderr, "follow logs: %v\n", err)
+		}
+	}()
+
+	v := &appVersion{config: config, dep: dep, cancel: cancel}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.versions[config.DepId] = v
+	if d.current == nil {
+		d.current = v
+	}
+	return v, nil
+}
+
+// follow prints the logs of the version with the provided deployment id,
+// until the provided context is canceled.
+func follow(ctx context.Context, depId string) error {
+	source := logging.FileSource(impl.LogDir)
+	query := fmt.Sprintf(`full_version == %q && !("mx/system" in attrs)`, depId)
+	r, err := source.Query(ctx, query, true)
+	if err != nil {
+		return err
+	}
+	pp := logging.NewPrettyPrinter(colors.Enabled())
+	for {
+		entry, err := r.Read(ctx)
+		if errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		fmt.Println(pp.Format(entry))
+	}
+}
+
+// rollout rolls out a new version of the application with the provided
+// config. It starts the new version, waits for it to become ready, shifts
+// the traffic of the application listeners to it over the rollout duration
+// of the config, and finally drains the old version. If the rollout fails,
+// or is canceled before all of the traffic is shifted, the traffic is moved
+// back to the old version and the new version is drained.
+func (d *deployment) rollout(ctx context.Context, config *impl.SshConfig) (*impl.RolloutReply, error) {
+	d.mu.Lock()
+	old := d.current
+	if d.rolling {
+		d.mu.Unlock()
+		return nil, fmt.Errorf("a rollout of app %q is already in progress", old.config.App.Name)
+	}
+	if got, want := config.App.Name, old.config.App.Name; got != want {
+		d.mu.Unlock()
+		return nil, fmt.Errorf("cannot roll out app %q over app %q", got, want)
+	}
+	d.rolling = true
+	d.mu.Unlock()
+	defer func() {
+		d.mu.Lock()
+		d.rolling = false
+		d.mu.Unlock()
+	}()
+
+	// Start the new version, without sending it any traffic.
+	config.DepId = uuid.New().String()
+	d.proxies.SetWeight(config.DepId, 0)
+	fmt.Fprintf(os.Stderr, "Starting version %s\n", config.DepId)
+	next, err := d.start(config)
+	if err != nil {
+		return nil, err
+	}
+	abort := func(err error) (*impl.RolloutReply, error) {
+		fmt.Fprintf(os.Stderr, "Rollout of version %s failed: %v\n", config.DepId, err)
+		d.proxies.SetWeight(old.config.DepId, 1)
+		d.drain(next)
+		return nil, err
+	}
+
+	// Wait for the new version to become ready.
+	readyCtx, cancel := context.WithTimeout(ctx, readyTimeout)
+	defer cancel()
+	for r := retry.Begin(); !next.dep.Ready(); {
+		if !r.Continue(readyCtx) {
+			return abort(fmt.Errorf("version %s not ready after %v", config.DepId, readyTimeout))
+		}
+	}
+
+	// Gradually shift traffic to the new version.
+	duration := time.Duration(config.App.RolloutNanos)
+	for i := 1; i <= rolloutSteps; i++ {
+		fraction := float64(i) / rolloutSteps
+		d.proxies.SetWeight(config.DepId, fraction)
+		d.proxies.SetWeight(old.config.DepId, 1-fraction)
+		fmt.Fprintf(os.Stderr, "Sending %.0f%% of traffic to version %s\n", 100*fraction, config.DepId)
+		if i == rolloutSteps {
+			break
+		}
+		select {
+		case <-time.After(duration / rolloutSteps):
+		case <-ctx.Done():
+			return abort(ctx.Err())
+		}
+	}
+
+	// Drain the old version.
+	d.mu.Lock()
+	d.current = next
+	d.mu.Unlock()
+	d.drain(old)
+	return &impl.RolloutReply{DepId: config.DepId}, nil
+}
+
+// drain stops sending traffic to the provided version and stops it. The
+// processes of the version receive a SIGTERM, which runs the Shutdown
+// methods of their components, and are killed if they don't exit within
+// drainTimeout.
+func (d *deployment) drain(v *appVersion) {
+	depId := v.config.DepId
+	fmt.Fprintf(os.Stderr, "Draining version %s\n", depId)
+	d.proxies.SetWeight(depId, 0)
+	if err := terminateDeployment(d.locs, depId); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to drain version %s: %v\n", depId, err)
+	}
+	if !waitDeployment(d.locs, depId, drainTimeout) {
+		fmt.Fprintf(os.Stderr, "version %s not drained after %v; killing it\n", depId, drainTimeout)
+		if err := killDeployment(d.locs, depId); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to kill version %s: %v\n", depId, err)
+		}
+	}
+	d.stop(v)
+}
+
+// stop stops the manager of the provided version and stops following its
+// logs.
+func (d *deployment) stop(v *appVersion) {
+	if err := v.dep.Stop(); err != nil {
+		fmt.Fprintf(os.Stderr, "stop the manager: %v\n", err)
+	}
+	v.cancel()
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	delete(d.versions, v.config.DepId)
+}
+
+// terminate terminates every running version of the application.
+func (d *deployment) terminate() {
+	d.mu.Lock()
+	versions := make([]*appVersion, 0, len(d.versions))
+	for _, v := range d.versions {
+		versions = append(versions, v)
+	}
+	d.mu.Unlock()
+	for _, v := range versions {
+		if err := terminateDeployment(d.locs, v.config.DepId); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to terminate deployment: %v\n", err)
+		}
+		d.stop(v)
+	}
+}
+
+// waitDeployment waits until none of the processes corresponding to the
+// deployment run at any location, or until the timeout expires. It returns
+// whether all of the processes exited.
+func waitDeployment(locs []string, depId string, timeout time.Duration) bool {
+	deadline := time.Now().Add(timeout)
+	for _, loc := range locs {
+		// pgrep exits with a non-zero status if no process matches.
+		for exec.Command("ssh", loc, "pgrep", "-f", depId).Run() == nil {
+			if time.Now().After(deadline) {
+				return false
+			}
+			time.Sleep(time.Second)
+		}
+	}
+	return true
+}
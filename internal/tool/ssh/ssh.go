@@ -23,6 +23,7 @@ import (
 var (
 	Commands = map[string]*tool.Command{
 		"deploy":    &deployCmd,
+		"rollout":   &rolloutCmd,
 		"logs":      tool.LogsCmd(&logsSpec),
 		"dashboard": status.DashboardCommand(dashboardSpec),
 		"version":   itool.VersionCmd("mx ssh"),
@@ -572,6 +572,35 @@ var _ Unrouted = (*implementsImpl)(nil)
 //	    f func()   // functions are not serializable
 //	    c chan int // chans are not serializable
 //	}
+//
+// # Errors
+//
+// An error returned by a remote component method is, by default, received by
+// the caller as an opaque error that preserves the error's message and the
+// errors it wraps, but not its type. As a result, errors.As cannot extract a
+// custom error type from the error. An error type that embeds AutoMarshal,
+// however, is registered by "mx generate" and is received by the caller as a
+// value of its original type, with all of its fields. For example:
+//
+//	type NotFoundError struct {
+//	    mx.AutoMarshal
+//	    Key string
+//	    Err error // the wrapped error, if any
+//	}
+//
+//	func (e NotFoundError) Error() string { return "not found: " + e.Key }
+//	func (e NotFoundError) Unwrap() error { return e.Err }
+//
+// A caller can then write the following, no matter whether the component
+// that returned the error runs in the same process or not.
+//
+//	var nf NotFoundError
+//	if errors.As(err, &nf) {
+//	    // Use nf.Key...
+//	}
+//
+// Fields of type error are serialized recursively, so typed errors wrapped
+// inside other errors are reconstructed as well.
 type AutoMarshal struct{}
 
 // TODO(mwhittaker): The following methods have AutoMarshal implement
@@ -582,3 +611,24 @@ func (AutoMarshal) MXMarshal(*codegen.Encoder)   {}
 func (AutoMarshal) MXUnmarshal(*codegen.Decoder) {}
 
 type NotRetriable interface{}
+
+// Hedged marks a component method whose calls may be hedged. If a call to a
+// hedged method hasn't returned after a high percentile of the method's
+// recent latencies (the 95th by default), a second call is sent to a
+// different replica. The first reply to arrive is returned, and the other
+// call is canceled.
+//
+// A hedged call may execute more than once, so only read-only or idempotent
+// methods should be hedged. For example:
+//
+//	type Cache interface {
+//	    Get(context.Context, string) (string, error)
+//	}
+//
+//	var _ mx.Hedged = Cache.Get
+//
+// Equivalently, the method can be marked with a //mx:hedge directive in the
+// component interface.
+//
+// Streaming methods and methods marked NotRetriable cannot be hedged.
+type Hedged interface{}
@@ -19,10 +19,10 @@ func init() {
 		Iface: reflect.TypeOf((*deployerControl)(nil)).Elem(),
 		Impl:  reflect.TypeOf(localDeployerControl{}),
 		LocalStubFn: func(impl any, caller string, tracer trace.Tracer) any {
-			return deployerControl_local_stub{impl: impl.(deployerControl), tracer: tracer, activateComponentMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/sh3lk/mx/deployerControl", Method: "ActivateComponent", Remote: false, Generated: true}), exportListenerMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/sh3lk/mx/deployerControl", Method: "ExportListener", Remote: false, Generated: true}), getListenerAddressMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/sh3lk/mx/deployerControl", Method: "GetListenerAddress", Remote: false, Generated: true}), getSelfCertificateMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/sh3lk/mx/deployerControl", Method: "GetSelfCertificate", Remote: false, Generated: true}), handleTraceSpansMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/sh3lk/mx/deployerControl", Method: "HandleTraceSpans", Remote: false, Generated: true}), logBatchMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/sh3lk/mx/deployerControl", Method: "LogBatch", Remote: false, Generated: true}), verifyClientCertificateMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/sh3lk/mx/deployerControl", Method: "VerifyClientCertificate", Remote: false, Generated: true}), verifyServerCertificateMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/sh3lk/mx/deployerControl", Method: "VerifyServerCertificate", Remote: false, Generated: true})}
+			return deployerControl_local_stub{impl: impl.(deployerControl), tracer: tracer, activateComponentMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/sh3lk/mx/deployerControl", Method: "ActivateComponent", Remote: false, Generated: true}), exportListenerMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/sh3lk/mx/deployerControl", Method: "ExportListener", Remote: false, Generated: true}), getListenerAddressMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/sh3lk/mx/deployerControl", Method: "GetListenerAddress", Remote: false, Generated: true}), getSelfCertificateMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/sh3lk/mx/deployerControl", Method: "GetSelfCertificate", Remote: false, Generated: true}), getSelfTokenMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/sh3lk/mx/deployerControl", Method: "GetSelfToken", Remote: false, Generated: true}), handleTraceSpansMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/sh3lk/mx/deployerControl", Method: "HandleTraceSpans", Remote: false, Generated: true}), logBatchMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/sh3lk/mx/deployerControl", Method: "LogBatch", Remote: false, Generated: true}), verifyClientCertificateMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/sh3lk/mx/deployerControl", Method: "VerifyClientCertificate", Remote: false, Generated: true}), verifyClientTokenMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/sh3lk/mx/deployerControl", Method: "VerifyClientToken", Remote: false, Generated: true}), verifyServerCertificateMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/sh3lk/mx/deployerControl", Method: "VerifyServerCertificate", Remote: false, Generated: true})}
 		},
 		ClientStubFn: func(stub codegen.Stub, caller string) any {
-			return deployerControl_client_stub{stub: stub, activateComponentMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/sh3lk/mx/deployerControl", Method: "ActivateComponent", Remote: true, Generated: true}), exportListenerMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/sh3lk/mx/deployerControl", Method: "ExportListener", Remote: true, Generated: true}), getListenerAddressMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/sh3lk/mx/deployerControl", Method: "GetListenerAddress", Remote: true, Generated: true}), getSelfCertificateMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/sh3lk/mx/deployerControl", Method: "GetSelfCertificate", Remote: true, Generated: true}), handleTraceSpansMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/sh3lk/mx/deployerControl", Method: "HandleTraceSpans", Remote: true, Generated: true}), logBatchMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/sh3lk/mx/deployerControl", Method: "LogBatch", Remote: true, Generated: true}), verifyClientCertificateMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/sh3lk/mx/deployerControl", Method: "VerifyClientCertificate", Remote: true, Generated: true}), verifyServerCertificateMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/sh3lk/mx/deployerControl", Method: "VerifyServerCertificate", Remote: true, Generated: true})}
+			return deployerControl_client_stub{stub: stub, activateComponentMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/sh3lk/mx/deployerControl", Method: "ActivateComponent", Remote: true, Generated: true}), exportListenerMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/sh3lk/mx/deployerControl", Method: "ExportListener", Remote: true, Generated: true}), getListenerAddressMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/sh3lk/mx/deployerControl", Method: "GetListenerAddress", Remote: true, Generated: true}), getSelfCertificateMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/sh3lk/mx/deployerControl", Method: "GetSelfCertificate", Remote: true, Generated: true}), getSelfTokenMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/sh3lk/mx/deployerControl", Method: "GetSelfToken", Remote: true, Generated: true}), handleTraceSpansMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/sh3lk/mx/deployerControl", Method: "HandleTraceSpans", Remote: true, Generated: true}), logBatchMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/sh3lk/mx/deployerControl", Method: "LogBatch", Remote: true, Generated: true}), verifyClientCertificateMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/sh3lk/mx/deployerControl", Method: "VerifyClientCertificate", Remote: true, Generated: true}), verifyClientTokenMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/sh3lk/mx/deployerControl", Method: "VerifyClientToken", Remote: true, Generated: true}), verifyServerCertificateMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/sh3lk/mx/deployerControl", Method: "VerifyServerCertificate", Remote: true, Generated: true})}
 		},
 		ServerStubFn: func(impl any, addLoad func(uint64, float64)) codegen.Server {
 			return deployerControl_server_stub{impl: impl.(deployerControl), addLoad: addLoad}
@@ -30,7 +30,7 @@ func init() {
 		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
 			return deployerControl_reflect_stub{caller: caller}
 		},
-		RefData: "",
+		RefData: "⟦339eb8e2:MxSchema:github.com/sh3lk/mx/deployerControl→[{\"name\":\"ActivateComponent\",\"args\":[{\"kind\":\"pointer\",\"elem\":{\"kind\":\"proto\",\"name\":\"protos.ActivateComponentRequest\"}}],\"results\":[{\"kind\":\"pointer\",\"elem\":{\"kind\":\"proto\",\"name\":\"protos.ActivateComponentReply\"}}]},{\"name\":\"ExportListener\",\"args\":[{\"kind\":\"pointer\",\"elem\":{\"kind\":\"proto\",\"name\":\"protos.ExportListenerRequest\"}}],\"results\":[{\"kind\":\"pointer\",\"elem\":{\"kind\":\"proto\",\"name\":\"protos.ExportListenerReply\"}}]},{\"name\":\"GetListenerAddress\",\"args\":[{\"kind\":\"pointer\",\"elem\":{\"kind\":\"proto\",\"name\":\"protos.GetListenerAddressRequest\"}}],\"results\":[{\"kind\":\"pointer\",\"elem\":{\"kind\":\"proto\",\"name\":\"protos.GetListenerAddressReply\"}}]},{\"name\":\"GetSelfCertificate\",\"args\":[{\"kind\":\"pointer\",\"elem\":{\"kind\":\"proto\",\"name\":\"protos.GetSelfCertificateRequest\"}}],\"results\":[{\"kind\":\"pointer\",\"elem\":{\"kind\":\"proto\",\"name\":\"protos.GetSelfCertificateReply\"}}]},{\"name\":\"GetSelfToken\",\"args\":[{\"kind\":\"pointer\",\"elem\":{\"kind\":\"proto\",\"name\":\"protos.GetSelfTokenRequest\"}}],\"results\":[{\"kind\":\"pointer\",\"elem\":{\"kind\":\"proto\",\"name\":\"protos.GetSelfTokenReply\"}}]},{\"name\":\"HandleTraceSpans\",\"args\":[{\"kind\":\"pointer\",\"elem\":{\"kind\":\"proto\",\"name\":\"protos.TraceSpans\"}}]},{\"name\":\"LogBatch\",\"args\":[{\"kind\":\"pointer\",\"elem\":{\"kind\":\"proto\",\"name\":\"protos.LogEntryBatch\"}}]},{\"name\":\"VerifyClientCertificate\",\"args\":[{\"kind\":\"pointer\",\"elem\":{\"kind\":\"proto\",\"name\":\"protos.VerifyClientCertificateRequest\"}}],\"results\":[{\"kind\":\"pointer\",\"elem\":{\"kind\":\"proto\",\"name\":\"protos.VerifyClientCertificateReply\"}}]},{\"name\":\"VerifyClientToken\",\"args\":[{\"kind\":\"pointer\",\"elem\":{\"kind\":\"proto\",\"name\":\"protos.VerifyClientTokenRequest\"}}],\"results\":[{\"kind\":\"pointer\",\"elem\":{\"kind\":\"proto\",\"name\":\"protos.VerifyClientTokenReply\"}}]},{\"name\":\"VerifyServerCertificate\",\"args\":[{\"kind\":\"pointer\",\"elem\":{\"kind\":\"proto\",\"name\":\"protos.VerifyServerCertificateRequest\"}}],\"results\":[{\"kind\":\"pointer\",\"elem\":{\"kind\":\"proto\",\"name\":\"protos.VerifyServerCertificateReply\"}}]}]⟧\n",
 	})
 	codegen.Register(codegen.Registration{
 		Name:  "github.com/sh3lk/mx/mxnControl",
@@ -48,7 +48,7 @@ func init() {
 		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
 			return mxnControl_reflect_stub{caller: caller}
 		},
-		RefData: "",
+		RefData: "⟦376f1840:MxSchema:github.com/sh3lk/mx/mxnControl→[{\"name\":\"GetHealth\",\"args\":[{\"kind\":\"pointer\",\"elem\":{\"kind\":\"proto\",\"name\":\"protos.GetHealthRequest\"}}],\"results\":[{\"kind\":\"pointer\",\"elem\":{\"kind\":\"proto\",\"name\":\"protos.GetHealthReply\"}}]},{\"name\":\"GetLoad\",\"args\":[{\"kind\":\"pointer\",\"elem\":{\"kind\":\"proto\",\"name\":\"protos.GetLoadRequest\"}}],\"results\":[{\"kind\":\"pointer\",\"elem\":{\"kind\":\"proto\",\"name\":\"protos.GetLoadReply\"}}]},{\"name\":\"GetMetrics\",\"args\":[{\"kind\":\"pointer\",\"elem\":{\"kind\":\"proto\",\"name\":\"protos.GetMetricsRequest\"}}],\"results\":[{\"kind\":\"pointer\",\"elem\":{\"kind\":\"proto\",\"name\":\"protos.GetMetricsReply\"}}]},{\"name\":\"GetProfile\",\"args\":[{\"kind\":\"pointer\",\"elem\":{\"kind\":\"proto\",\"name\":\"protos.GetProfileRequest\"}}],\"results\":[{\"kind\":\"pointer\",\"elem\":{\"kind\":\"proto\",\"name\":\"protos.GetProfileReply\"}}]},{\"name\":\"InitMXN\",\"args\":[{\"kind\":\"pointer\",\"elem\":{\"kind\":\"proto\",\"name\":\"protos.InitMXNRequest\"}}],\"results\":[{\"kind\":\"pointer\",\"elem\":{\"kind\":\"proto\",\"name\":\"protos.InitMXNReply\"}}]},{\"name\":\"UpdateComponents\",\"args\":[{\"kind\":\"pointer\",\"elem\":{\"kind\":\"proto\",\"name\":\"protos.UpdateComponentsRequest\"}}],\"results\":[{\"kind\":\"pointer\",\"elem\":{\"kind\":\"proto\",\"name\":\"protos.UpdateComponentsReply\"}}]},{\"name\":\"UpdateRoutingInfo\",\"args\":[{\"kind\":\"pointer\",\"elem\":{\"kind\":\"proto\",\"name\":\"protos.UpdateRoutingInfoRequest\"}}],\"results\":[{\"kind\":\"pointer\",\"elem\":{\"kind\":\"proto\",\"name\":\"protos.UpdateRoutingInfoReply\"}}]}]⟧\n",
 	})
 }
 
@@ -69,9 +69,11 @@ type deployerControl_local_stub struct {
 	exportListenerMetrics          *codegen.MethodMetrics
 	getListenerAddressMetrics      *codegen.MethodMetrics
 	getSelfCertificateMetrics      *codegen.MethodMetrics
+	getSelfTokenMetrics            *codegen.MethodMetrics
 	handleTraceSpansMetrics        *codegen.MethodMetrics
 	logBatchMetrics                *codegen.MethodMetrics
 	verifyClientCertificateMetrics *codegen.MethodMetrics
+	verifyClientTokenMetrics       *codegen.MethodMetrics
 	verifyServerCertificateMetrics *codegen.MethodMetrics
 }
 
@@ -158,6 +160,26 @@ func (s deployerControl_local_stub) GetSelfCertificate(ctx context.Context, a0 *
 	return s.impl.GetSelfCertificate(ctx, a0)
 }
 
+func (s deployerControl_local_stub) GetSelfToken(ctx context.Context, a0 *protos.GetSelfTokenRequest) (r0 *protos.GetSelfTokenReply, err error) {
+	// Update metrics.
+	begin := s.getSelfTokenMetrics.Begin()
+	defer func() { s.getSelfTokenMetrics.End(begin, err != nil, 0, 0) }()
+	span := trace.SpanFromContext(ctx)
+	if span.SpanContext().IsValid() {
+		// Create a child span for this method.
+		ctx, span = s.tracer.Start(ctx, "mx.deployerControl.GetSelfToken", trace.WithSpanKind(trace.SpanKindInternal))
+		defer func() {
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			}
+			span.End()
+		}()
+	}
+
+	return s.impl.GetSelfToken(ctx, a0)
+}
+
 func (s deployerControl_local_stub) HandleTraceSpans(ctx context.Context, a0 *protos.TraceSpans) (err error) {
 	// Update metrics.
 	begin := s.handleTraceSpansMetrics.Begin()
@@ -218,6 +240,26 @@ func (s deployerControl_local_stub) VerifyClientCertificate(ctx context.Context,
 	return s.impl.VerifyClientCertificate(ctx, a0)
 }
 
+func (s deployerControl_local_stub) VerifyClientToken(ctx context.Context, a0 *protos.VerifyClientTokenRequest) (r0 *protos.VerifyClientTokenReply, err error) {
+	// Update metrics.
+	begin := s.verifyClientTokenMetrics.Begin()
+	defer func() { s.verifyClientTokenMetrics.End(begin, err != nil, 0, 0) }()
+	span := trace.SpanFromContext(ctx)
+	if span.SpanContext().IsValid() {
+		// Create a child span for this method.
+		ctx, span = s.tracer.Start(ctx, "mx.deployerControl.VerifyClientToken", trace.WithSpanKind(trace.SpanKindInternal))
+		defer func() {
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			}
+			span.End()
+		}()
+	}
+
+	return s.impl.VerifyClientToken(ctx, a0)
+}
+
 func (s deployerControl_local_stub) VerifyServerCertificate(ctx context.Context, a0 *protos.VerifyServerCertificateRequest) (r0 *protos.VerifyServerCertificateReply, err error) {
 	// Update metrics.
 	begin := s.verifyServerCertificateMetrics.Begin()
@@ -401,9 +443,11 @@ type deployerControl_client_stub struct {
 	exportListenerMetrics          *codegen.MethodMetrics
 	getListenerAddressMetrics      *codegen.MethodMetrics
 	getSelfCertificateMetrics      *codegen.MethodMetrics
+	getSelfTokenMetrics            *codegen.MethodMetrics
 	handleTraceSpansMetrics        *codegen.MethodMetrics
 	logBatchMetrics                *codegen.MethodMetrics
 	verifyClientCertificateMetrics *codegen.MethodMetrics
+	verifyClientTokenMetrics       *codegen.MethodMetrics
 	verifyServerCertificateMetrics *codegen.MethodMetrics
 }
 
@@ -440,8 +484,9 @@ func (s deployerControl_client_stub) ActivateComponent(ctx context.Context, a0 *
 	}()
 
 	// Encode arguments.
-	enc := codegen.NewEncoder()
-	mx_enc_ptr_ActivateComponentRequest_73adf343(enc, a0)
+	enc := codegen.GetEncoder()
+	defer codegen.PutEncoder(enc)
+	mx_enc_ptr_ActivateComponentRequest_617edf99(enc, a0)
 	var shardKey uint64
 
 	// Call the remote method.
@@ -455,8 +500,8 @@ func (s deployerControl_client_stub) ActivateComponent(ctx context.Context, a0 *
 	}
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
-	r0 = mx_dec_ptr_ActivateComponentReply_5e57d605(dec)
+	dec := codegen.NewZeroCopyDecoder(results)
+	r0 = mx_dec_ptr_ActivateComponentReply_212dc527(dec)
 	err = dec.Error()
 	return
 }
@@ -491,8 +536,9 @@ func (s deployerControl_client_stub) ExportListener(ctx context.Context, a0 *pro
 	}()
 
 	// Encode arguments.
-	enc := codegen.NewEncoder()
-	mx_enc_ptr_ExportListenerRequest_b494514e(enc, a0)
+	enc := codegen.GetEncoder()
+	defer codegen.PutEncoder(enc)
+	mx_enc_ptr_ExportListenerRequest_1763d90a(enc, a0)
 	var shardKey uint64
 
 	// Call the remote method.
@@ -506,8 +552,8 @@ func (s deployerControl_client_stub) ExportListener(ctx context.Context, a0 *pro
 	}
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
-	r0 = mx_dec_ptr_ExportListenerReply_b0fc34d0(dec)
+	dec := codegen.NewZeroCopyDecoder(results)
+	r0 = mx_dec_ptr_ExportListenerReply_acd9f9ac(dec)
 	err = dec.Error()
 	return
 }
@@ -542,8 +588,9 @@ func (s deployerControl_client_stub) GetListenerAddress(ctx context.Context, a0
 	}()
 
 	// Encode arguments.
-	enc := codegen.NewEncoder()
-	mx_enc_ptr_GetListenerAddressRequest_5a58feb0(enc, a0)
+	enc := codegen.GetEncoder()
+	defer codegen.PutEncoder(enc)
+	mx_enc_ptr_GetListenerAddressRequest_a7b89ac1(enc, a0)
 	var shardKey uint64
 
 	// Call the remote method.
@@ -557,8 +604,8 @@ func (s deployerControl_client_stub) GetListenerAddress(ctx context.Context, a0
 	}
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
-	r0 = mx_dec_ptr_GetListenerAddressReply_8bfe2caa(dec)
+	dec := codegen.NewZeroCopyDecoder(results)
+	r0 = mx_dec_ptr_GetListenerAddressReply_f604bff2(dec)
 	err = dec.Error()
 	return
 }
@@ -593,8 +640,9 @@ func (s deployerControl_client_stub) GetSelfCertificate(ctx context.Context, a0
 	}()
 
 	// Encode arguments.
-	enc := codegen.NewEncoder()
-	mx_enc_ptr_GetSelfCertificateRequest_0de4e3b4(enc, a0)
+	enc := codegen.GetEncoder()
+	defer codegen.PutEncoder(enc)
+	mx_enc_ptr_GetSelfCertificateRequest_150ba37e(enc, a0)
 	var shardKey uint64
 
 	// Call the remote method.
@@ -608,8 +656,60 @@ func (s deployerControl_client_stub) GetSelfCertificate(ctx context.Context, a0
 	}
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
-	r0 = mx_dec_ptr_GetSelfCertificateReply_12277ec8(dec)
+	dec := codegen.NewZeroCopyDecoder(results)
+	r0 = mx_dec_ptr_GetSelfCertificateReply_3711e768(dec)
+	err = dec.Error()
+	return
+}
+
+func (s deployerControl_client_stub) GetSelfToken(ctx context.Context, a0 *protos.GetSelfTokenRequest) (r0 *protos.GetSelfTokenReply, err error) {
+	// Update metrics.
+	var requestBytes, replyBytes int
+	begin := s.getSelfTokenMetrics.Begin()
+	defer func() { s.getSelfTokenMetrics.End(begin, err != nil, requestBytes, replyBytes) }()
+
+	span := trace.SpanFromContext(ctx)
+	if span.SpanContext().IsValid() {
+		// Create a child span for this method.
+		ctx, span = s.stub.Tracer().Start(ctx, "mx.deployerControl.GetSelfToken", trace.WithSpanKind(trace.SpanKindClient))
+	}
+
+	defer func() {
+		// Catch and return any panics detected during encoding/decoding/rpc.
+		if err == nil {
+			err = codegen.CatchPanics(recover())
+			if err != nil {
+				err = errors.Join(RemoteCallError, err)
+			}
+		}
+
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+
+	}()
+
+	// Encode arguments.
+	enc := codegen.GetEncoder()
+	defer codegen.PutEncoder(enc)
+	mx_enc_ptr_GetSelfTokenRequest_29879204(enc, a0)
+	var shardKey uint64
+
+	// Call the remote method.
+	requestBytes = len(enc.Data())
+	var results []byte
+	results, err = s.stub.Run(ctx, 4, enc.Data(), shardKey)
+	replyBytes = len(results)
+	if err != nil {
+		err = errors.Join(RemoteCallError, err)
+		return
+	}
+
+	// Decode the results.
+	dec := codegen.NewZeroCopyDecoder(results)
+	r0 = mx_dec_ptr_GetSelfTokenReply_210a6538(dec)
 	err = dec.Error()
 	return
 }
@@ -644,14 +744,15 @@ func (s deployerControl_client_stub) HandleTraceSpans(ctx context.Context, a0 *p
 	}()
 
 	// Encode arguments.
-	enc := codegen.NewEncoder()
-	mx_enc_ptr_TraceSpans_af16efd0(enc, a0)
+	enc := codegen.GetEncoder()
+	defer codegen.PutEncoder(enc)
+	mx_enc_ptr_TraceSpans_0953855e(enc, a0)
 	var shardKey uint64
 
 	// Call the remote method.
 	requestBytes = len(enc.Data())
 	var results []byte
-	results, err = s.stub.Run(ctx, 4, enc.Data(), shardKey)
+	results, err = s.stub.Run(ctx, 5, enc.Data(), shardKey)
 	replyBytes = len(results)
 	if err != nil {
 		err = errors.Join(RemoteCallError, err)
@@ -659,7 +760,7 @@ func (s deployerControl_client_stub) HandleTraceSpans(ctx context.Context, a0 *p
 	}
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewZeroCopyDecoder(results)
 	err = dec.Error()
 	return
 }
@@ -694,14 +795,15 @@ func (s deployerControl_client_stub) LogBatch(ctx context.Context, a0 *protos.Lo
 	}()
 
 	// Encode arguments.
-	enc := codegen.NewEncoder()
-	mx_enc_ptr_LogEntryBatch_fec9a5d4(enc, a0)
+	enc := codegen.GetEncoder()
+	defer codegen.PutEncoder(enc)
+	mx_enc_ptr_LogEntryBatch_530f5f57(enc, a0)
 	var shardKey uint64
 
 	// Call the remote method.
 	requestBytes = len(enc.Data())
 	var results []byte
-	results, err = s.stub.Run(ctx, 5, enc.Data(), shardKey)
+	results, err = s.stub.Run(ctx, 6, enc.Data(), shardKey)
 	replyBytes = len(results)
 	if err != nil {
 		err = errors.Join(RemoteCallError, err)
@@ -709,7 +811,7 @@ func (s deployerControl_client_stub) LogBatch(ctx context.Context, a0 *protos.Lo
 	}
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewZeroCopyDecoder(results)
 	err = dec.Error()
 	return
 }
@@ -744,14 +846,15 @@ func (s deployerControl_client_stub) VerifyClientCertificate(ctx context.Context
 	}()
 
 	// Encode arguments.
-	enc := codegen.NewEncoder()
-	mx_enc_ptr_VerifyClientCertificateRequest_f8d21781(enc, a0)
+	enc := codegen.GetEncoder()
+	defer codegen.PutEncoder(enc)
+	mx_enc_ptr_VerifyClientCertificateRequest_41a5ec28(enc, a0)
 	var shardKey uint64
 
 	// Call the remote method.
 	requestBytes = len(enc.Data())
 	var results []byte
-	results, err = s.stub.Run(ctx, 6, enc.Data(), shardKey)
+	results, err = s.stub.Run(ctx, 7, enc.Data(), shardKey)
 	replyBytes = len(results)
 	if err != nil {
 		err = errors.Join(RemoteCallError, err)
@@ -759,8 +862,60 @@ func (s deployerControl_client_stub) VerifyClientCertificate(ctx context.Context
 	}
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
-	r0 = mx_dec_ptr_VerifyClientCertificateReply_c76e39ec(dec)
+	dec := codegen.NewZeroCopyDecoder(results)
+	r0 = mx_dec_ptr_VerifyClientCertificateReply_f8ae34d9(dec)
+	err = dec.Error()
+	return
+}
+
+func (s deployerControl_client_stub) VerifyClientToken(ctx context.Context, a0 *protos.VerifyClientTokenRequest) (r0 *protos.VerifyClientTokenReply, err error) {
+	// Update metrics.
+	var requestBytes, replyBytes int
+	begin := s.verifyClientTokenMetrics.Begin()
+	defer func() { s.verifyClientTokenMetrics.End(begin, err != nil, requestBytes, replyBytes) }()
+
+	span := trace.SpanFromContext(ctx)
+	if span.SpanContext().IsValid() {
+		// Create a child span for this method.
+		ctx, span = s.stub.Tracer().Start(ctx, "mx.deployerControl.VerifyClientToken", trace.WithSpanKind(trace.SpanKindClient))
+	}
+
+	defer func() {
+		// Catch and return any panics detected during encoding/decoding/rpc.
+		if err == nil {
+			err = codegen.CatchPanics(recover())
+			if err != nil {
+				err = errors.Join(RemoteCallError, err)
+			}
+		}
+
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+
+	}()
+
+	// Encode arguments.
+	enc := codegen.GetEncoder()
+	defer codegen.PutEncoder(enc)
+	mx_enc_ptr_VerifyClientTokenRequest_0577b69c(enc, a0)
+	var shardKey uint64
+
+	// Call the remote method.
+	requestBytes = len(enc.Data())
+	var results []byte
+	results, err = s.stub.Run(ctx, 8, enc.Data(), shardKey)
+	replyBytes = len(results)
+	if err != nil {
+		err = errors.Join(RemoteCallError, err)
+		return
+	}
+
+	// Decode the results.
+	dec := codegen.NewZeroCopyDecoder(results)
+	r0 = mx_dec_ptr_VerifyClientTokenReply_85c6d3c9(dec)
 	err = dec.Error()
 	return
 }
@@ -795,14 +950,15 @@ func (s deployerControl_client_stub) VerifyServerCertificate(ctx context.Context
 	}()
 
 	// Encode arguments.
-	enc := codegen.NewEncoder()
-	mx_enc_ptr_VerifyServerCertificateRequest_9c56ee67(enc, a0)
+	enc := codegen.GetEncoder()
+	defer codegen.PutEncoder(enc)
+	mx_enc_ptr_VerifyServerCertificateRequest_50397239(enc, a0)
 	var shardKey uint64
 
 	// Call the remote method.
 	requestBytes = len(enc.Data())
 	var results []byte
-	results, err = s.stub.Run(ctx, 7, enc.Data(), shardKey)
+	results, err = s.stub.Run(ctx, 9, enc.Data(), shardKey)
 	replyBytes = len(results)
 	if err != nil {
 		err = errors.Join(RemoteCallError, err)
@@ -810,8 +966,8 @@ func (s deployerControl_client_stub) VerifyServerCertificate(ctx context.Context
 	}
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
-	r0 = mx_dec_ptr_VerifyServerCertificateReply_c0d4bd3b(dec)
+	dec := codegen.NewZeroCopyDecoder(results)
+	r0 = mx_dec_ptr_VerifyServerCertificateReply_c5c21665(dec)
 	err = dec.Error()
 	return
 }
@@ -860,8 +1016,9 @@ func (s mxnControl_client_stub) GetHealth(ctx context.Context, a0 *protos.GetHea
 	}()
 
 	// Encode arguments.
-	enc := codegen.NewEncoder()
-	mx_enc_ptr_GetHealthRequest_fd6083fb(enc, a0)
+	enc := codegen.GetEncoder()
+	defer codegen.PutEncoder(enc)
+	mx_enc_ptr_GetHealthRequest_b525c608(enc, a0)
 	var shardKey uint64
 
 	// Call the remote method.
@@ -875,8 +1032,8 @@ func (s mxnControl_client_stub) GetHealth(ctx context.Context, a0 *protos.GetHea
 	}
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
-	r0 = mx_dec_ptr_GetHealthReply_b2d11423(dec)
+	dec := codegen.NewZeroCopyDecoder(results)
+	r0 = mx_dec_ptr_GetHealthReply_667747ab(dec)
 	err = dec.Error()
 	return
 }
@@ -911,8 +1068,9 @@ func (s mxnControl_client_stub) GetLoad(ctx context.Context, a0 *protos.GetLoadR
 	}()
 
 	// Encode arguments.
-	enc := codegen.NewEncoder()
-	mx_enc_ptr_GetLoadRequest_d733b2cf(enc, a0)
+	enc := codegen.GetEncoder()
+	defer codegen.PutEncoder(enc)
+	mx_enc_ptr_GetLoadRequest_bff33269(enc, a0)
 	var shardKey uint64
 
 	// Call the remote method.
@@ -926,8 +1084,8 @@ func (s mxnControl_client_stub) GetLoad(ctx context.Context, a0 *protos.GetLoadR
 	}
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
-	r0 = mx_dec_ptr_GetLoadReply_cf8279ad(dec)
+	dec := codegen.NewZeroCopyDecoder(results)
+	r0 = mx_dec_ptr_GetLoadReply_e51872d7(dec)
 	err = dec.Error()
 	return
 }
@@ -962,8 +1120,9 @@ func (s mxnControl_client_stub) GetMetrics(ctx context.Context, a0 *protos.GetMe
 	}()
 
 	// Encode arguments.
-	enc := codegen.NewEncoder()
-	mx_enc_ptr_GetMetricsRequest_010b3cd9(enc, a0)
+	enc := codegen.GetEncoder()
+	defer codegen.PutEncoder(enc)
+	mx_enc_ptr_GetMetricsRequest_1c7baa63(enc, a0)
 	var shardKey uint64
 
 	// Call the remote method.
@@ -977,8 +1136,8 @@ func (s mxnControl_client_stub) GetMetrics(ctx context.Context, a0 *protos.GetMe
 	}
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
-	r0 = mx_dec_ptr_GetMetricsReply_3c7180e4(dec)
+	dec := codegen.NewZeroCopyDecoder(results)
+	r0 = mx_dec_ptr_GetMetricsReply_7082e662(dec)
 	err = dec.Error()
 	return
 }
@@ -1013,8 +1172,9 @@ func (s mxnControl_client_stub) GetProfile(ctx context.Context, a0 *protos.GetPr
 	}()
 
 	// Encode arguments.
-	enc := codegen.NewEncoder()
-	mx_enc_ptr_GetProfileRequest_d1544fcf(enc, a0)
+	enc := codegen.GetEncoder()
+	defer codegen.PutEncoder(enc)
+	mx_enc_ptr_GetProfileRequest_cdd38ab9(enc, a0)
 	var shardKey uint64
 
 	// Call the remote method.
@@ -1028,8 +1188,8 @@ func (s mxnControl_client_stub) GetProfile(ctx context.Context, a0 *protos.GetPr
 	}
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
-	r0 = mx_dec_ptr_GetProfileReply_10a79dcc(dec)
+	dec := codegen.NewZeroCopyDecoder(results)
+	r0 = mx_dec_ptr_GetProfileReply_c4e87e01(dec)
 	err = dec.Error()
 	return
 }
@@ -1064,8 +1224,9 @@ func (s mxnControl_client_stub) InitMXN(ctx context.Context, a0 *protos.InitMXNR
 	}()
 
 	// Encode arguments.
-	enc := codegen.NewEncoder()
-	mx_enc_ptr_InitMXNRequest_d1f5204c(enc, a0)
+	enc := codegen.GetEncoder()
+	defer codegen.PutEncoder(enc)
+	mx_enc_ptr_InitMXNRequest_b354fdf5(enc, a0)
 	var shardKey uint64
 
 	// Call the remote method.
@@ -1079,8 +1240,8 @@ func (s mxnControl_client_stub) InitMXN(ctx context.Context, a0 *protos.InitMXNR
 	}
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
-	r0 = mx_dec_ptr_InitMXNReply_565d8c96(dec)
+	dec := codegen.NewZeroCopyDecoder(results)
+	r0 = mx_dec_ptr_InitMXNReply_79dd9ef2(dec)
 	err = dec.Error()
 	return
 }
@@ -1115,8 +1276,9 @@ func (s mxnControl_client_stub) UpdateComponents(ctx context.Context, a0 *protos
 	}()
 
 	// Encode arguments.
-	enc := codegen.NewEncoder()
-	mx_enc_ptr_UpdateComponentsRequest_d1b56e1f(enc, a0)
+	enc := codegen.GetEncoder()
+	defer codegen.PutEncoder(enc)
+	mx_enc_ptr_UpdateComponentsRequest_71f0c3b4(enc, a0)
 	var shardKey uint64
 
 	// Call the remote method.
@@ -1130,8 +1292,8 @@ func (s mxnControl_client_stub) UpdateComponents(ctx context.Context, a0 *protos
 	}
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
-	r0 = mx_dec_ptr_UpdateComponentsReply_93bebb77(dec)
+	dec := codegen.NewZeroCopyDecoder(results)
+	r0 = mx_dec_ptr_UpdateComponentsReply_8a27aae4(dec)
 	err = dec.Error()
 	return
 }
@@ -1166,8 +1328,9 @@ func (s mxnControl_client_stub) UpdateRoutingInfo(ctx context.Context, a0 *proto
 	}()
 
 	// Encode arguments.
-	enc := codegen.NewEncoder()
-	mx_enc_ptr_UpdateRoutingInfoRequest_e752cfad(enc, a0)
+	enc := codegen.GetEncoder()
+	defer codegen.PutEncoder(enc)
+	mx_enc_ptr_UpdateRoutingInfoRequest_b246cc08(enc, a0)
 	var shardKey uint64
 
 	// Call the remote method.
@@ -1181,8 +1344,8 @@ func (s mxnControl_client_stub) UpdateRoutingInfo(ctx context.Context, a0 *proto
 	}
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
-	r0 = mx_dec_ptr_UpdateRoutingInfoReply_d1854fd5(dec)
+	dec := codegen.NewZeroCopyDecoder(results)
+	r0 = mx_dec_ptr_UpdateRoutingInfoReply_2ae6ba4d(dec)
 	err = dec.Error()
 	return
 }
@@ -1190,10 +1353,10 @@ func (s mxnControl_client_stub) UpdateRoutingInfo(ctx context.Context, a0 *proto
 // Note that "mx generate" will always generate the error message below.
 // Everything is okay. The error message is only relevant if you see it when
 // you run "go build" or "go run".
-var _ codegen.LatestVersion = codegen.Version[[0][24]struct{}](`
+var _ codegen.LatestVersion = codegen.Version[[0][25]struct{}](`
 
-ERROR: You generated this file with 'mx generate' v0.24.7-0.20250401231336-b01860e0378a+dirty (codegen
-version v0.24.0). The generated code is incompatible with the version of the
+ERROR: You generated this file with 'mx generate' (devel) (codegen
+version v0.25.0). The generated code is incompatible with the version of the
 github.com/sh3lk/mx module that you're using. The mx module
 version can be found in your go.mod file or by running the following command.
 
@@ -1231,12 +1394,16 @@ func (s deployerControl_server_stub) GetStubFn(method string) func(ctx context.C
 		return s.getListenerAddress
 	case "GetSelfCertificate":
 		return s.getSelfCertificate
+	case "GetSelfToken":
+		return s.getSelfToken
 	case "HandleTraceSpans":
 		return s.handleTraceSpans
 	case "LogBatch":
 		return s.logBatch
 	case "VerifyClientCertificate":
 		return s.verifyClientCertificate
+	case "VerifyClientToken":
+		return s.verifyClientToken
 	case "VerifyServerCertificate":
 		return s.verifyServerCertificate
 	default:
@@ -1255,7 +1422,7 @@ func (s deployerControl_server_stub) activateComponent(ctx context.Context, args
 	// Decode arguments.
 	dec := codegen.NewDecoder(args)
 	var a0 *protos.ActivateComponentRequest
-	a0 = mx_dec_ptr_ActivateComponentRequest_73adf343(dec)
+	a0 = mx_dec_ptr_ActivateComponentRequest_617edf99(dec)
 
 	// TODO(rgrandl): The deferred function above will recover from panics in the
 	// user code: fix this.
@@ -1264,7 +1431,7 @@ func (s deployerControl_server_stub) activateComponent(ctx context.Context, args
 
 	// Encode the results.
 	enc := codegen.NewEncoder()
-	mx_enc_ptr_ActivateComponentReply_5e57d605(enc, r0)
+	mx_enc_ptr_ActivateComponentReply_212dc527(enc, r0)
 	enc.Error(appErr)
 	return enc.Data(), nil
 }
@@ -1280,7 +1447,7 @@ func (s deployerControl_server_stub) exportListener(ctx context.Context, args []
 	// Decode arguments.
 	dec := codegen.NewDecoder(args)
 	var a0 *protos.ExportListenerRequest
-	a0 = mx_dec_ptr_ExportListenerRequest_b494514e(dec)
+	a0 = mx_dec_ptr_ExportListenerRequest_1763d90a(dec)
 
 	// TODO(rgrandl): The deferred function above will recover from panics in the
 	// user code: fix this.
@@ -1289,7 +1456,7 @@ func (s deployerControl_server_stub) exportListener(ctx context.Context, args []
 
 	// Encode the results.
 	enc := codegen.NewEncoder()
-	mx_enc_ptr_ExportListenerReply_b0fc34d0(enc, r0)
+	mx_enc_ptr_ExportListenerReply_acd9f9ac(enc, r0)
 	enc.Error(appErr)
 	return enc.Data(), nil
 }
@@ -1305,7 +1472,7 @@ func (s deployerControl_server_stub) getListenerAddress(ctx context.Context, arg
 	// Decode arguments.
 	dec := codegen.NewDecoder(args)
 	var a0 *protos.GetListenerAddressRequest
-	a0 = mx_dec_ptr_GetListenerAddressRequest_5a58feb0(dec)
+	a0 = mx_dec_ptr_GetListenerAddressRequest_a7b89ac1(dec)
 
 	// TODO(rgrandl): The deferred function above will recover from panics in the
 	// user code: fix this.
@@ -1314,7 +1481,7 @@ func (s deployerControl_server_stub) getListenerAddress(ctx context.Context, arg
 
 	// Encode the results.
 	enc := codegen.NewEncoder()
-	mx_enc_ptr_GetListenerAddressReply_8bfe2caa(enc, r0)
+	mx_enc_ptr_GetListenerAddressReply_f604bff2(enc, r0)
 	enc.Error(appErr)
 	return enc.Data(), nil
 }
@@ -1330,7 +1497,7 @@ func (s deployerControl_server_stub) getSelfCertificate(ctx context.Context, arg
 	// Decode arguments.
 	dec := codegen.NewDecoder(args)
 	var a0 *protos.GetSelfCertificateRequest
-	a0 = mx_dec_ptr_GetSelfCertificateRequest_0de4e3b4(dec)
+	a0 = mx_dec_ptr_GetSelfCertificateRequest_150ba37e(dec)
 
 	// TODO(rgrandl): The deferred function above will recover from panics in the
 	// user code: fix this.
@@ -1339,7 +1506,32 @@ func (s deployerControl_server_stub) getSelfCertificate(ctx context.Context, arg
 
 	// Encode the results.
 	enc := codegen.NewEncoder()
-	mx_enc_ptr_GetSelfCertificateReply_12277ec8(enc, r0)
+	mx_enc_ptr_GetSelfCertificateReply_3711e768(enc, r0)
+	enc.Error(appErr)
+	return enc.Data(), nil
+}
+
+func (s deployerControl_server_stub) getSelfToken(ctx context.Context, args []byte) (res []byte, err error) {
+	// Catch and return any panics detected during encoding/decoding/rpc.
+	defer func() {
+		if err == nil {
+			err = codegen.CatchPanics(recover())
+		}
+	}()
+
+	// Decode arguments.
+	dec := codegen.NewDecoder(args)
+	var a0 *protos.GetSelfTokenRequest
+	a0 = mx_dec_ptr_GetSelfTokenRequest_29879204(dec)
+
+	// TODO(rgrandl): The deferred function above will recover from panics in the
+	// user code: fix this.
+	// Call the local method.
+	r0, appErr := s.impl.GetSelfToken(ctx, a0)
+
+	// Encode the results.
+	enc := codegen.NewEncoder()
+	mx_enc_ptr_GetSelfTokenReply_210a6538(enc, r0)
 	enc.Error(appErr)
 	return enc.Data(), nil
 }
@@ -1355,7 +1547,7 @@ func (s deployerControl_server_stub) handleTraceSpans(ctx context.Context, args
 	// Decode arguments.
 	dec := codegen.NewDecoder(args)
 	var a0 *protos.TraceSpans
-	a0 = mx_dec_ptr_TraceSpans_af16efd0(dec)
+	a0 = mx_dec_ptr_TraceSpans_0953855e(dec)
 
 	// TODO(rgrandl): The deferred function above will recover from panics in the
 	// user code: fix this.
@@ -1379,7 +1571,7 @@ func (s deployerControl_server_stub) logBatch(ctx context.Context, args []byte)
 	// Decode arguments.
 	dec := codegen.NewDecoder(args)
 	var a0 *protos.LogEntryBatch
-	a0 = mx_dec_ptr_LogEntryBatch_fec9a5d4(dec)
+	a0 = mx_dec_ptr_LogEntryBatch_530f5f57(dec)
 
 	// TODO(rgrandl): The deferred function above will recover from panics in the
 	// user code: fix this.
@@ -1403,7 +1595,7 @@ func (s deployerControl_server_stub) verifyClientCertificate(ctx context.Context
 	// Decode arguments.
 	dec := codegen.NewDecoder(args)
 	var a0 *protos.VerifyClientCertificateRequest
-	a0 = mx_dec_ptr_VerifyClientCertificateRequest_f8d21781(dec)
+	a0 = mx_dec_ptr_VerifyClientCertificateRequest_41a5ec28(dec)
 
 	// TODO(rgrandl): The deferred function above will recover from panics in the
 	// user code: fix this.
@@ -1412,7 +1604,32 @@ func (s deployerControl_server_stub) verifyClientCertificate(ctx context.Context
 
 	// Encode the results.
 	enc := codegen.NewEncoder()
-	mx_enc_ptr_VerifyClientCertificateReply_c76e39ec(enc, r0)
+	mx_enc_ptr_VerifyClientCertificateReply_f8ae34d9(enc, r0)
+	enc.Error(appErr)
+	return enc.Data(), nil
+}
+
+func (s deployerControl_server_stub) verifyClientToken(ctx context.Context, args []byte) (res []byte, err error) {
+	// Catch and return any panics detected during encoding/decoding/rpc.
+	defer func() {
+		if err == nil {
+			err = codegen.CatchPanics(recover())
+		}
+	}()
+
+	// Decode arguments.
+	dec := codegen.NewDecoder(args)
+	var a0 *protos.VerifyClientTokenRequest
+	a0 = mx_dec_ptr_VerifyClientTokenRequest_0577b69c(dec)
+
+	// TODO(rgrandl): The deferred function above will recover from panics in the
+	// user code: fix this.
+	// Call the local method.
+	r0, appErr := s.impl.VerifyClientToken(ctx, a0)
+
+	// Encode the results.
+	enc := codegen.NewEncoder()
+	mx_enc_ptr_VerifyClientTokenReply_85c6d3c9(enc, r0)
 	enc.Error(appErr)
 	return enc.Data(), nil
 }
@@ -1428,7 +1645,7 @@ func (s deployerControl_server_stub) verifyServerCertificate(ctx context.Context
 	// Decode arguments.
 	dec := codegen.NewDecoder(args)
 	var a0 *protos.VerifyServerCertificateRequest
-	a0 = mx_dec_ptr_VerifyServerCertificateRequest_9c56ee67(dec)
+	a0 = mx_dec_ptr_VerifyServerCertificateRequest_50397239(dec)
 
 	// TODO(rgrandl): The deferred function above will recover from panics in the
 	// user code: fix this.
@@ -1437,7 +1654,7 @@ func (s deployerControl_server_stub) verifyServerCertificate(ctx context.Context
 
 	// Encode the results.
 	enc := codegen.NewEncoder()
-	mx_enc_ptr_VerifyServerCertificateReply_c0d4bd3b(enc, r0)
+	mx_enc_ptr_VerifyServerCertificateReply_c5c21665(enc, r0)
 	enc.Error(appErr)
 	return enc.Data(), nil
 }
@@ -1483,7 +1700,7 @@ func (s mxnControl_server_stub) getHealth(ctx context.Context, args []byte) (res
 	// Decode arguments.
 	dec := codegen.NewDecoder(args)
 	var a0 *protos.GetHealthRequest
-	a0 = mx_dec_ptr_GetHealthRequest_fd6083fb(dec)
+	a0 = mx_dec_ptr_GetHealthRequest_b525c608(dec)
 
 	// TODO(rgrandl): The deferred function above will recover from panics in the
 	// user code: fix this.
@@ -1492,7 +1709,7 @@ func (s mxnControl_server_stub) getHealth(ctx context.Context, args []byte) (res
 
 	// Encode the results.
 	enc := codegen.NewEncoder()
-	mx_enc_ptr_GetHealthReply_b2d11423(enc, r0)
+	mx_enc_ptr_GetHealthReply_667747ab(enc, r0)
 	enc.Error(appErr)
 	return enc.Data(), nil
 }
@@ -1508,7 +1725,7 @@ func (s mxnControl_server_stub) getLoad(ctx context.Context, args []byte) (res [
 	// Decode arguments.
 	dec := codegen.NewDecoder(args)
 	var a0 *protos.GetLoadRequest
-	a0 = mx_dec_ptr_GetLoadRequest_d733b2cf(dec)
+	a0 = mx_dec_ptr_GetLoadRequest_bff33269(dec)
 
 	// TODO(rgrandl): The deferred function above will recover from panics in the
 	// user code: fix this.
@@ -1517,7 +1734,7 @@ func (s mxnControl_server_stub) getLoad(ctx context.Context, args []byte) (res [
 
 	// Encode the results.
 	enc := codegen.NewEncoder()
-	mx_enc_ptr_GetLoadReply_cf8279ad(enc, r0)
+	mx_enc_ptr_GetLoadReply_e51872d7(enc, r0)
 	enc.Error(appErr)
 	return enc.Data(), nil
 }
@@ -1533,7 +1750,7 @@ func (s mxnControl_server_stub) getMetrics(ctx context.Context, args []byte) (re
 	// Decode arguments.
 	dec := codegen.NewDecoder(args)
 	var a0 *protos.GetMetricsRequest
-	a0 = mx_dec_ptr_GetMetricsRequest_010b3cd9(dec)
+	a0 = mx_dec_ptr_GetMetricsRequest_1c7baa63(dec)
 
 	// TODO(rgrandl): The deferred function above will recover from panics in the
 	// user code: fix this.
@@ -1542,7 +1759,7 @@ func (s mxnControl_server_stub) getMetrics(ctx context.Context, args []byte) (re
 
 	// Encode the results.
 	enc := codegen.NewEncoder()
-	mx_enc_ptr_GetMetricsReply_3c7180e4(enc, r0)
+	mx_enc_ptr_GetMetricsReply_7082e662(enc, r0)
 	enc.Error(appErr)
 	return enc.Data(), nil
 }
@@ -1558,7 +1775,7 @@ func (s mxnControl_server_stub) getProfile(ctx context.Context, args []byte) (re
 	// Decode arguments.
 	dec := codegen.NewDecoder(args)
 	var a0 *protos.GetProfileRequest
-	a0 = mx_dec_ptr_GetProfileRequest_d1544fcf(dec)
+	a0 = mx_dec_ptr_GetProfileRequest_cdd38ab9(dec)
 
 	// TODO(rgrandl): The deferred function above will recover from panics in the
 	// user code: fix this.
@@ -1567,7 +1784,7 @@ func (s mxnControl_server_stub) getProfile(ctx context.Context, args []byte) (re
 
 	// Encode the results.
 	enc := codegen.NewEncoder()
-	mx_enc_ptr_GetProfileReply_10a79dcc(enc, r0)
+	mx_enc_ptr_GetProfileReply_c4e87e01(enc, r0)
 	enc.Error(appErr)
 	return enc.Data(), nil
 }
@@ -1583,7 +1800,7 @@ func (s mxnControl_server_stub) initMXN(ctx context.Context, args []byte) (res [
 	// Decode arguments.
 	dec := codegen.NewDecoder(args)
 	var a0 *protos.InitMXNRequest
-	a0 = mx_dec_ptr_InitMXNRequest_d1f5204c(dec)
+	a0 = mx_dec_ptr_InitMXNRequest_b354fdf5(dec)
 
 	// TODO(rgrandl): The deferred function above will recover from panics in the
 	// user code: fix this.
@@ -1592,7 +1809,7 @@ func (s mxnControl_server_stub) initMXN(ctx context.Context, args []byte) (res [
 
 	// Encode the results.
 	enc := codegen.NewEncoder()
-	mx_enc_ptr_InitMXNReply_565d8c96(enc, r0)
+	mx_enc_ptr_InitMXNReply_79dd9ef2(enc, r0)
 	enc.Error(appErr)
 	return enc.Data(), nil
 }
@@ -1608,7 +1825,7 @@ func (s mxnControl_server_stub) updateComponents(ctx context.Context, args []byt
 	// Decode arguments.
 	dec := codegen.NewDecoder(args)
 	var a0 *protos.UpdateComponentsRequest
-	a0 = mx_dec_ptr_UpdateComponentsRequest_d1b56e1f(dec)
+	a0 = mx_dec_ptr_UpdateComponentsRequest_71f0c3b4(dec)
 
 	// TODO(rgrandl): The deferred function above will recover from panics in the
 	// user code: fix this.
@@ -1617,7 +1834,7 @@ func (s mxnControl_server_stub) updateComponents(ctx context.Context, args []byt
 
 	// Encode the results.
 	enc := codegen.NewEncoder()
-	mx_enc_ptr_UpdateComponentsReply_93bebb77(enc, r0)
+	mx_enc_ptr_UpdateComponentsReply_8a27aae4(enc, r0)
 	enc.Error(appErr)
 	return enc.Data(), nil
 }
@@ -1633,7 +1850,7 @@ func (s mxnControl_server_stub) updateRoutingInfo(ctx context.Context, args []by
 	// Decode arguments.
 	dec := codegen.NewDecoder(args)
 	var a0 *protos.UpdateRoutingInfoRequest
-	a0 = mx_dec_ptr_UpdateRoutingInfoRequest_e752cfad(dec)
+	a0 = mx_dec_ptr_UpdateRoutingInfoRequest_b246cc08(dec)
 
 	// TODO(rgrandl): The deferred function above will recover from panics in the
 	// user code: fix this.
@@ -1642,7 +1859,7 @@ func (s mxnControl_server_stub) updateRoutingInfo(ctx context.Context, args []by
 
 	// Encode the results.
 	enc := codegen.NewEncoder()
-	mx_enc_ptr_UpdateRoutingInfoReply_d1854fd5(enc, r0)
+	mx_enc_ptr_UpdateRoutingInfoReply_2ae6ba4d(enc, r0)
 	enc.Error(appErr)
 	return enc.Data(), nil
 }
@@ -1676,6 +1893,11 @@ func (s deployerControl_reflect_stub) GetSelfCertificate(ctx context.Context, a0
 	return
 }
 
+func (s deployerControl_reflect_stub) GetSelfToken(ctx context.Context, a0 *protos.GetSelfTokenRequest) (r0 *protos.GetSelfTokenReply, err error) {
+	err = s.caller("GetSelfToken", ctx, []any{a0}, []any{&r0})
+	return
+}
+
 func (s deployerControl_reflect_stub) HandleTraceSpans(ctx context.Context, a0 *protos.TraceSpans) (err error) {
 	err = s.caller("HandleTraceSpans", ctx, []any{a0}, []any{})
 	return
@@ -1691,6 +1913,11 @@ func (s deployerControl_reflect_stub) VerifyClientCertificate(ctx context.Contex
 	return
 }
 
+func (s deployerControl_reflect_stub) VerifyClientToken(ctx context.Context, a0 *protos.VerifyClientTokenRequest) (r0 *protos.VerifyClientTokenReply, err error) {
+	err = s.caller("VerifyClientToken", ctx, []any{a0}, []any{&r0})
+	return
+}
+
 func (s deployerControl_reflect_stub) VerifyServerCertificate(ctx context.Context, a0 *protos.VerifyServerCertificateRequest) (r0 *protos.VerifyServerCertificateReply, err error) {
 	err = s.caller("VerifyServerCertificate", ctx, []any{a0}, []any{&r0})
 	return
@@ -1740,7 +1967,7 @@ func (s mxnControl_reflect_stub) UpdateRoutingInfo(ctx context.Context, a0 *prot
 
 // Encoding/decoding implementations.
 
-func mx_enc_ptr_ActivateComponentRequest_73adf343(enc *codegen.Encoder, arg *protos.ActivateComponentRequest) {
+func mx_enc_ptr_ActivateComponentRequest_617edf99(enc *codegen.Encoder, arg *protos.ActivateComponentRequest) {
 	if arg == nil {
 		enc.Bool(false)
 	} else {
@@ -1749,7 +1976,7 @@ func mx_enc_ptr_ActivateComponentRequest_73adf343(enc *codegen.Encoder, arg *pro
 	}
 }
 
-func mx_dec_ptr_ActivateComponentRequest_73adf343(dec *codegen.Decoder) *protos.ActivateComponentRequest {
+func mx_dec_ptr_ActivateComponentRequest_617edf99(dec *codegen.Decoder) *protos.ActivateComponentRequest {
 	if !dec.Bool() {
 		return nil
 	}
@@ -1758,7 +1985,7 @@ func mx_dec_ptr_ActivateComponentRequest_73adf343(dec *codegen.Decoder) *protos.
 	return &res
 }
 
-func mx_enc_ptr_ActivateComponentReply_5e57d605(enc *codegen.Encoder, arg *protos.ActivateComponentReply) {
+func mx_enc_ptr_ActivateComponentReply_212dc527(enc *codegen.Encoder, arg *protos.ActivateComponentReply) {
 	if arg == nil {
 		enc.Bool(false)
 	} else {
@@ -1767,7 +1994,7 @@ func mx_enc_ptr_ActivateComponentReply_5e57d605(enc *codegen.Encoder, arg *proto
 	}
 }
 
-func mx_dec_ptr_ActivateComponentReply_5e57d605(dec *codegen.Decoder) *protos.ActivateComponentReply {
+func mx_dec_ptr_ActivateComponentReply_212dc527(dec *codegen.Decoder) *protos.ActivateComponentReply {
 	if !dec.Bool() {
 		return nil
 	}
@@ -1776,7 +2003,7 @@ func mx_dec_ptr_ActivateComponentReply_5e57d605(dec *codegen.Decoder) *protos.Ac
 	return &res
 }
 
-func mx_enc_ptr_ExportListenerRequest_b494514e(enc *codegen.Encoder, arg *protos.ExportListenerRequest) {
+func mx_enc_ptr_ExportListenerRequest_1763d90a(enc *codegen.Encoder, arg *protos.ExportListenerRequest) {
 	if arg == nil {
 		enc.Bool(false)
 	} else {
@@ -1785,7 +2012,7 @@ func mx_enc_ptr_ExportListenerRequest_b494514e(enc *codegen.Encoder, arg *protos
 	}
 }
 
-func mx_dec_ptr_ExportListenerRequest_b494514e(dec *codegen.Decoder) *protos.ExportListenerRequest {
+func mx_dec_ptr_ExportListenerRequest_1763d90a(dec *codegen.Decoder) *protos.ExportListenerRequest {
 	if !dec.Bool() {
 		return nil
 	}
@@ -1794,7 +2021,7 @@ func mx_dec_ptr_ExportListenerRequest_b494514e(dec *codegen.Decoder) *protos.Exp
 	return &res
 }
 
-func mx_enc_ptr_ExportListenerReply_b0fc34d0(enc *codegen.Encoder, arg *protos.ExportListenerReply) {
+func mx_enc_ptr_ExportListenerReply_acd9f9ac(enc *codegen.Encoder, arg *protos.ExportListenerReply) {
 	if arg == nil {
 		enc.Bool(false)
 	} else {
@@ -1803,7 +2030,7 @@ func mx_enc_ptr_ExportListenerReply_b0fc34d0(enc *codegen.Encoder, arg *protos.E
 	}
 }
 
-func mx_dec_ptr_ExportListenerReply_b0fc34d0(dec *codegen.Decoder) *protos.ExportListenerReply {
+func mx_dec_ptr_ExportListenerReply_acd9f9ac(dec *codegen.Decoder) *protos.ExportListenerReply {
 	if !dec.Bool() {
 		return nil
 	}
@@ -1812,7 +2039,7 @@ func mx_dec_ptr_ExportListenerReply_b0fc34d0(dec *codegen.Decoder) *protos.Expor
 	return &res
 }
 
-func mx_enc_ptr_GetListenerAddressRequest_5a58feb0(enc *codegen.Encoder, arg *protos.GetListenerAddressRequest) {
+func mx_enc_ptr_GetListenerAddressRequest_a7b89ac1(enc *codegen.Encoder, arg *protos.GetListenerAddressRequest) {
 	if arg == nil {
 		enc.Bool(false)
 	} else {
@@ -1821,7 +2048,7 @@ func mx_enc_ptr_GetListenerAddressRequest_5a58feb0(enc *codegen.Encoder, arg *pr
 	}
 }
 
-func mx_dec_ptr_GetListenerAddressRequest_5a58feb0(dec *codegen.Decoder) *protos.GetListenerAddressRequest {
+func mx_dec_ptr_GetListenerAddressRequest_a7b89ac1(dec *codegen.Decoder) *protos.GetListenerAddressRequest {
 	if !dec.Bool() {
 		return nil
 	}
@@ -1830,7 +2057,7 @@ func mx_dec_ptr_GetListenerAddressRequest_5a58feb0(dec *codegen.Decoder) *protos
 	return &res
 }
 
-func mx_enc_ptr_GetListenerAddressReply_8bfe2caa(enc *codegen.Encoder, arg *protos.GetListenerAddressReply) {
+func mx_enc_ptr_GetListenerAddressReply_f604bff2(enc *codegen.Encoder, arg *protos.GetListenerAddressReply) {
 	if arg == nil {
 		enc.Bool(false)
 	} else {
@@ -1839,7 +2066,7 @@ func mx_enc_ptr_GetListenerAddressReply_8bfe2caa(enc *codegen.Encoder, arg *prot
 	}
 }
 
-func mx_dec_ptr_GetListenerAddressReply_8bfe2caa(dec *codegen.Decoder) *protos.GetListenerAddressReply {
+func mx_dec_ptr_GetListenerAddressReply_f604bff2(dec *codegen.Decoder) *protos.GetListenerAddressReply {
 	if !dec.Bool() {
 		return nil
 	}
@@ -1848,7 +2075,7 @@ func mx_dec_ptr_GetListenerAddressReply_8bfe2caa(dec *codegen.Decoder) *protos.G
 	return &res
 }
 
-func mx_enc_ptr_GetSelfCertificateRequest_0de4e3b4(enc *codegen.Encoder, arg *protos.GetSelfCertificateRequest) {
+func mx_enc_ptr_GetSelfCertificateRequest_150ba37e(enc *codegen.Encoder, arg *protos.GetSelfCertificateRequest) {
 	if arg == nil {
 		enc.Bool(false)
 	} else {
@@ -1857,7 +2084,7 @@ func mx_enc_ptr_GetSelfCertificateRequest_0de4e3b4(enc *codegen.Encoder, arg *pr
 	}
 }
 
-func mx_dec_ptr_GetSelfCertificateRequest_0de4e3b4(dec *codegen.Decoder) *protos.GetSelfCertificateRequest {
+func mx_dec_ptr_GetSelfCertificateRequest_150ba37e(dec *codegen.Decoder) *protos.GetSelfCertificateRequest {
 	if !dec.Bool() {
 		return nil
 	}
@@ -1866,7 +2093,7 @@ func mx_dec_ptr_GetSelfCertificateRequest_0de4e3b4(dec *codegen.Decoder) *protos
 	return &res
 }
 
-func mx_enc_ptr_GetSelfCertificateReply_12277ec8(enc *codegen.Encoder, arg *protos.GetSelfCertificateReply) {
+func mx_enc_ptr_GetSelfCertificateReply_3711e768(enc *codegen.Encoder, arg *protos.GetSelfCertificateReply) {
 	if arg == nil {
 		enc.Bool(false)
 	} else {
@@ -1875,7 +2102,7 @@ func mx_enc_ptr_GetSelfCertificateReply_12277ec8(enc *codegen.Encoder, arg *prot
 	}
 }
 
-func mx_dec_ptr_GetSelfCertificateReply_12277ec8(dec *codegen.Decoder) *protos.GetSelfCertificateReply {
+func mx_dec_ptr_GetSelfCertificateReply_3711e768(dec *codegen.Decoder) *protos.GetSelfCertificateReply {
 	if !dec.Bool() {
 		return nil
 	}
@@ -1884,7 +2111,7 @@ func mx_dec_ptr_GetSelfCertificateReply_12277ec8(dec *codegen.Decoder) *protos.G
 	return &res
 }
 
-func mx_enc_ptr_TraceSpans_af16efd0(enc *codegen.Encoder, arg *protos.TraceSpans) {
+func mx_enc_ptr_GetSelfTokenRequest_29879204(enc *codegen.Encoder, arg *protos.GetSelfTokenRequest) {
 	if arg == nil {
 		enc.Bool(false)
 	} else {
@@ -1893,7 +2120,43 @@ func mx_enc_ptr_TraceSpans_af16efd0(enc *codegen.Encoder, arg *protos.TraceSpans
 	}
 }
 
-func mx_dec_ptr_TraceSpans_af16efd0(dec *codegen.Decoder) *protos.TraceSpans {
+func mx_dec_ptr_GetSelfTokenRequest_29879204(dec *codegen.Decoder) *protos.GetSelfTokenRequest {
+	if !dec.Bool() {
+		return nil
+	}
+	var res protos.GetSelfTokenRequest
+	dec.DecodeProto(&res)
+	return &res
+}
+
+func mx_enc_ptr_GetSelfTokenReply_210a6538(enc *codegen.Encoder, arg *protos.GetSelfTokenReply) {
+	if arg == nil {
+		enc.Bool(false)
+	} else {
+		enc.Bool(true)
+		enc.EncodeProto(arg)
+	}
+}
+
+func mx_dec_ptr_GetSelfTokenReply_210a6538(dec *codegen.Decoder) *protos.GetSelfTokenReply {
+	if !dec.Bool() {
+		return nil
+	}
+	var res protos.GetSelfTokenReply
+	dec.DecodeProto(&res)
+	return &res
+}
+
+func mx_enc_ptr_TraceSpans_0953855e(enc *codegen.Encoder, arg *protos.TraceSpans) {
+	if arg == nil {
+		enc.Bool(false)
+	} else {
+		enc.Bool(true)
+		enc.EncodeProto(arg)
+	}
+}
+
+func mx_dec_ptr_TraceSpans_0953855e(dec *codegen.Decoder) *protos.TraceSpans {
 	if !dec.Bool() {
 		return nil
 	}
@@ -1902,7 +2165,7 @@ func mx_dec_ptr_TraceSpans_af16efd0(dec *codegen.Decoder) *protos.TraceSpans {
 	return &res
 }
 
-func mx_enc_ptr_LogEntryBatch_fec9a5d4(enc *codegen.Encoder, arg *protos.LogEntryBatch) {
+func mx_enc_ptr_LogEntryBatch_530f5f57(enc *codegen.Encoder, arg *protos.LogEntryBatch) {
 	if arg == nil {
 		enc.Bool(false)
 	} else {
@@ -1911,7 +2174,7 @@ func mx_enc_ptr_LogEntryBatch_fec9a5d4(enc *codegen.Encoder, arg *protos.LogEntr
 	}
 }
 
-func mx_dec_ptr_LogEntryBatch_fec9a5d4(dec *codegen.Decoder) *protos.LogEntryBatch {
+func mx_dec_ptr_LogEntryBatch_530f5f57(dec *codegen.Decoder) *protos.LogEntryBatch {
 	if !dec.Bool() {
 		return nil
 	}
@@ -1920,7 +2183,7 @@ func mx_dec_ptr_LogEntryBatch_fec9a5d4(dec *codegen.Decoder) *protos.LogEntryBat
 	return &res
 }
 
-func mx_enc_ptr_VerifyClientCertificateRequest_f8d21781(enc *codegen.Encoder, arg *protos.VerifyClientCertificateRequest) {
+func mx_enc_ptr_VerifyClientCertificateRequest_41a5ec28(enc *codegen.Encoder, arg *protos.VerifyClientCertificateRequest) {
 	if arg == nil {
 		enc.Bool(false)
 	} else {
@@ -1929,7 +2192,7 @@ func mx_enc_ptr_VerifyClientCertificateRequest_f8d21781(enc *codegen.Encoder, ar
 	}
 }
 
-func mx_dec_ptr_VerifyClientCertificateRequest_f8d21781(dec *codegen.Decoder) *protos.VerifyClientCertificateRequest {
+func mx_dec_ptr_VerifyClientCertificateRequest_41a5ec28(dec *codegen.Decoder) *protos.VerifyClientCertificateRequest {
 	if !dec.Bool() {
 		return nil
 	}
@@ -1938,7 +2201,7 @@ func mx_dec_ptr_VerifyClientCertificateRequest_f8d21781(dec *codegen.Decoder) *p
 	return &res
 }
 
-func mx_enc_ptr_VerifyClientCertificateReply_c76e39ec(enc *codegen.Encoder, arg *protos.VerifyClientCertificateReply) {
+func mx_enc_ptr_VerifyClientCertificateReply_f8ae34d9(enc *codegen.Encoder, arg *protos.VerifyClientCertificateReply) {
 	if arg == nil {
 		enc.Bool(false)
 	} else {
@@ -1947,7 +2210,7 @@ func mx_enc_ptr_VerifyClientCertificateReply_c76e39ec(enc *codegen.Encoder, arg
 	}
 }
 
-func mx_dec_ptr_VerifyClientCertificateReply_c76e39ec(dec *codegen.Decoder) *protos.VerifyClientCertificateReply {
+func mx_dec_ptr_VerifyClientCertificateReply_f8ae34d9(dec *codegen.Decoder) *protos.VerifyClientCertificateReply {
 	if !dec.Bool() {
 		return nil
 	}
@@ -1956,7 +2219,43 @@ func mx_dec_ptr_VerifyClientCertificateReply_c76e39ec(dec *codegen.Decoder) *pro
 	return &res
 }
 
-func mx_enc_ptr_VerifyServerCertificateRequest_9c56ee67(enc *codegen.Encoder, arg *protos.VerifyServerCertificateRequest) {
+func mx_enc_ptr_VerifyClientTokenRequest_0577b69c(enc *codegen.Encoder, arg *protos.VerifyClientTokenRequest) {
+	if arg == nil {
+		enc.Bool(false)
+	} else {
+		enc.Bool(true)
+		enc.EncodeProto(arg)
+	}
+}
+
+func mx_dec_ptr_VerifyClientTokenRequest_0577b69c(dec *codegen.Decoder) *protos.VerifyClientTokenRequest {
+	if !dec.Bool() {
+		return nil
+	}
+	var res protos.VerifyClientTokenRequest
+	dec.DecodeProto(&res)
+	return &res
+}
+
+func mx_enc_ptr_VerifyClientTokenReply_85c6d3c9(enc *codegen.Encoder, arg *protos.VerifyClientTokenReply) {
+	if arg == nil {
+		enc.Bool(false)
+	} else {
+		enc.Bool(true)
+		enc.EncodeProto(arg)
+	}
+}
+
+func mx_dec_ptr_VerifyClientTokenReply_85c6d3c9(dec *codegen.Decoder) *protos.VerifyClientTokenReply {
+	if !dec.Bool() {
+		return nil
+	}
+	var res protos.VerifyClientTokenReply
+	dec.DecodeProto(&res)
+	return &res
+}
+
+func mx_enc_ptr_VerifyServerCertificateRequest_50397239(enc *codegen.Encoder, arg *protos.VerifyServerCertificateRequest) {
 	if arg == nil {
 		enc.Bool(false)
 	} else {
@@ -1965,7 +2264,7 @@ func mx_enc_ptr_VerifyServerCertificateRequest_9c56ee67(enc *codegen.Encoder, ar
 	}
 }
 
-func mx_dec_ptr_VerifyServerCertificateRequest_9c56ee67(dec *codegen.Decoder) *protos.VerifyServerCertificateRequest {
+func mx_dec_ptr_VerifyServerCertificateRequest_50397239(dec *codegen.Decoder) *protos.VerifyServerCertificateRequest {
 	if !dec.Bool() {
 		return nil
 	}
@@ -1974,7 +2273,7 @@ func mx_dec_ptr_VerifyServerCertificateRequest_9c56ee67(dec *codegen.Decoder) *p
 	return &res
 }
 
-func mx_enc_ptr_VerifyServerCertificateReply_c0d4bd3b(enc *codegen.Encoder, arg *protos.VerifyServerCertificateReply) {
+func mx_enc_ptr_VerifyServerCertificateReply_c5c21665(enc *codegen.Encoder, arg *protos.VerifyServerCertificateReply) {
 	if arg == nil {
 		enc.Bool(false)
 	} else {
@@ -1983,7 +2282,7 @@ func mx_enc_ptr_VerifyServerCertificateReply_c0d4bd3b(enc *codegen.Encoder, arg
 	}
 }
 
-func mx_dec_ptr_VerifyServerCertificateReply_c0d4bd3b(dec *codegen.Decoder) *protos.VerifyServerCertificateReply {
+func mx_dec_ptr_VerifyServerCertificateReply_c5c21665(dec *codegen.Decoder) *protos.VerifyServerCertificateReply {
 	if !dec.Bool() {
 		return nil
 	}
@@ -1992,7 +2291,7 @@ func mx_dec_ptr_VerifyServerCertificateReply_c0d4bd3b(dec *codegen.Decoder) *pro
 	return &res
 }
 
-func mx_enc_ptr_GetHealthRequest_fd6083fb(enc *codegen.Encoder, arg *protos.GetHealthRequest) {
+func mx_enc_ptr_GetHealthRequest_b525c608(enc *codegen.Encoder, arg *protos.GetHealthRequest) {
 	if arg == nil {
 		enc.Bool(false)
 	} else {
@@ -2001,7 +2300,7 @@ func mx_enc_ptr_GetHealthRequest_fd6083fb(enc *codegen.Encoder, arg *protos.GetH
 	}
 }
 
-func mx_dec_ptr_GetHealthRequest_fd6083fb(dec *codegen.Decoder) *protos.GetHealthRequest {
+func mx_dec_ptr_GetHealthRequest_b525c608(dec *codegen.Decoder) *protos.GetHealthRequest {
 	if !dec.Bool() {
 		return nil
 	}
@@ -2010,7 +2309,7 @@ func mx_dec_ptr_GetHealthRequest_fd6083fb(dec *codegen.Decoder) *protos.GetHealt
 	return &res
 }
 
-func mx_enc_ptr_GetHealthReply_b2d11423(enc *codegen.Encoder, arg *protos.GetHealthReply) {
+func mx_enc_ptr_GetHealthReply_667747ab(enc *codegen.Encoder, arg *protos.GetHealthReply) {
 	if arg == nil {
 		enc.Bool(false)
 	} else {
@@ -2019,7 +2318,7 @@ func mx_enc_ptr_GetHealthReply_b2d11423(enc *codegen.Encoder, arg *protos.GetHea
 	}
 }
 
-func mx_dec_ptr_GetHealthReply_b2d11423(dec *codegen.Decoder) *protos.GetHealthReply {
+func mx_dec_ptr_GetHealthReply_667747ab(dec *codegen.Decoder) *protos.GetHealthReply {
 	if !dec.Bool() {
 		return nil
 	}
@@ -2028,7 +2327,7 @@ func mx_dec_ptr_GetHealthReply_b2d11423(dec *codegen.Decoder) *protos.GetHealthR
 	return &res
 }
 
-func mx_enc_ptr_GetLoadRequest_d733b2cf(enc *codegen.Encoder, arg *protos.GetLoadRequest) {
+func mx_enc_ptr_GetLoadRequest_bff33269(enc *codegen.Encoder, arg *protos.GetLoadRequest) {
 	if arg == nil {
 		enc.Bool(false)
 	} else {
@@ -2037,7 +2336,7 @@ func mx_enc_ptr_GetLoadRequest_d733b2cf(enc *codegen.Encoder, arg *protos.GetLoa
 	}
 }
 
-func mx_dec_ptr_GetLoadRequest_d733b2cf(dec *codegen.Decoder) *protos.GetLoadRequest {
+func mx_dec_ptr_GetLoadRequest_bff33269(dec *codegen.Decoder) *protos.GetLoadRequest {
 	if !dec.Bool() {
 		return nil
 	}
@@ -2046,7 +2345,7 @@ func mx_dec_ptr_GetLoadRequest_d733b2cf(dec *codegen.Decoder) *protos.GetLoadReq
 	return &res
 }
 
-func mx_enc_ptr_GetLoadReply_cf8279ad(enc *codegen.Encoder, arg *protos.GetLoadReply) {
+func mx_enc_ptr_GetLoadReply_e51872d7(enc *codegen.Encoder, arg *protos.GetLoadReply) {
 	if arg == nil {
 		enc.Bool(false)
 	} else {
@@ -2055,7 +2354,7 @@ func mx_enc_ptr_GetLoadReply_cf8279ad(enc *codegen.Encoder, arg *protos.GetLoadR
 	}
 }
 
-func mx_dec_ptr_GetLoadReply_cf8279ad(dec *codegen.Decoder) *protos.GetLoadReply {
+func mx_dec_ptr_GetLoadReply_e51872d7(dec *codegen.Decoder) *protos.GetLoadReply {
 	if !dec.Bool() {
 		return nil
 	}
@@ -2064,7 +2363,7 @@ func mx_dec_ptr_GetLoadReply_cf8279ad(dec *codegen.Decoder) *protos.GetLoadReply
 	return &res
 }
 
-func mx_enc_ptr_GetMetricsRequest_010b3cd9(enc *codegen.Encoder, arg *protos.GetMetricsRequest) {
+func mx_enc_ptr_GetMetricsRequest_1c7baa63(enc *codegen.Encoder, arg *protos.GetMetricsRequest) {
 	if arg == nil {
 		enc.Bool(false)
 	} else {
@@ -2073,7 +2372,7 @@ func mx_enc_ptr_GetMetricsRequest_010b3cd9(enc *codegen.Encoder, arg *protos.Get
 	}
 }
 
-func mx_dec_ptr_GetMetricsRequest_010b3cd9(dec *codegen.Decoder) *protos.GetMetricsRequest {
+func mx_dec_ptr_GetMetricsRequest_1c7baa63(dec *codegen.Decoder) *protos.GetMetricsRequest {
 	if !dec.Bool() {
 		return nil
 	}
@@ -2082,7 +2381,7 @@ func mx_dec_ptr_GetMetricsRequest_010b3cd9(dec *codegen.Decoder) *protos.GetMetr
 	return &res
 }
 
-func mx_enc_ptr_GetMetricsReply_3c7180e4(enc *codegen.Encoder, arg *protos.GetMetricsReply) {
+func mx_enc_ptr_GetMetricsReply_7082e662(enc *codegen.Encoder, arg *protos.GetMetricsReply) {
 	if arg == nil {
 		enc.Bool(false)
 	} else {
@@ -2091,7 +2390,7 @@ func mx_enc_ptr_GetMetricsReply_3c7180e4(enc *codegen.Encoder, arg *protos.GetMe
 	}
 }
 
-func mx_dec_ptr_GetMetricsReply_3c7180e4(dec *codegen.Decoder) *protos.GetMetricsReply {
+func mx_dec_ptr_GetMetricsReply_7082e662(dec *codegen.Decoder) *protos.GetMetricsReply {
 	if !dec.Bool() {
 		return nil
 	}
@@ -2100,7 +2399,7 @@ func mx_dec_ptr_GetMetricsReply_3c7180e4(dec *codegen.Decoder) *protos.GetMetric
 	return &res
 }
 
-func mx_enc_ptr_GetProfileRequest_d1544fcf(enc *codegen.Encoder, arg *protos.GetProfileRequest) {
+func mx_enc_ptr_GetProfileRequest_cdd38ab9(enc *codegen.Encoder, arg *protos.GetProfileRequest) {
 	if arg == nil {
 		enc.Bool(false)
 	} else {
@@ -2109,7 +2408,7 @@ func mx_enc_ptr_GetProfileRequest_d1544fcf(enc *codegen.Encoder, arg *protos.Get
 	}
 }
 
-func mx_dec_ptr_GetProfileRequest_d1544fcf(dec *codegen.Decoder) *protos.GetProfileRequest {
+func mx_dec_ptr_GetProfileRequest_cdd38ab9(dec *codegen.Decoder) *protos.GetProfileRequest {
 	if !dec.Bool() {
 		return nil
 	}
@@ -2118,7 +2417,7 @@ func mx_dec_ptr_GetProfileRequest_d1544fcf(dec *codegen.Decoder) *protos.GetProf
 	return &res
 }
 
-func mx_enc_ptr_GetProfileReply_10a79dcc(enc *codegen.Encoder, arg *protos.GetProfileReply) {
+func mx_enc_ptr_GetProfileReply_c4e87e01(enc *codegen.Encoder, arg *protos.GetProfileReply) {
 	if arg == nil {
 		enc.Bool(false)
 	} else {
@@ -2127,7 +2426,7 @@ func mx_enc_ptr_GetProfileReply_10a79dcc(enc *codegen.Encoder, arg *protos.GetPr
 	}
 }
 
-func mx_dec_ptr_GetProfileReply_10a79dcc(dec *codegen.Decoder) *protos.GetProfileReply {
+func mx_dec_ptr_GetProfileReply_c4e87e01(dec *codegen.Decoder) *protos.GetProfileReply {
 	if !dec.Bool() {
 		return nil
 	}
@@ -2136,7 +2435,7 @@ func mx_dec_ptr_GetProfileReply_10a79dcc(dec *codegen.Decoder) *protos.GetProfil
 	return &res
 }
 
-func mx_enc_ptr_InitMXNRequest_d1f5204c(enc *codegen.Encoder, arg *protos.InitMXNRequest) {
+func mx_enc_ptr_InitMXNRequest_b354fdf5(enc *codegen.Encoder, arg *protos.InitMXNRequest) {
 	if arg == nil {
 		enc.Bool(false)
 	} else {
@@ -2145,7 +2444,7 @@ func mx_enc_ptr_InitMXNRequest_d1f5204c(enc *codegen.Encoder, arg *protos.InitMX
 	}
 }
 
-func mx_dec_ptr_InitMXNRequest_d1f5204c(dec *codegen.Decoder) *protos.InitMXNRequest {
+func mx_dec_ptr_InitMXNRequest_b354fdf5(dec *codegen.Decoder) *protos.InitMXNRequest {
 	if !dec.Bool() {
 		return nil
 	}
@@ -2154,7 +2453,7 @@ func mx_dec_ptr_InitMXNRequest_d1f5204c(dec *codegen.Decoder) *protos.InitMXNReq
 	return &res
 }
 
-func mx_enc_ptr_InitMXNReply_565d8c96(enc *codegen.Encoder, arg *protos.InitMXNReply) {
+func mx_enc_ptr_InitMXNReply_79dd9ef2(enc *codegen.Encoder, arg *protos.InitMXNReply) {
 	if arg == nil {
 		enc.Bool(false)
 	} else {
@@ -2163,7 +2462,7 @@ func mx_enc_ptr_InitMXNReply_565d8c96(enc *codegen.Encoder, arg *protos.InitMXNR
 	}
 }
 
-func mx_dec_ptr_InitMXNReply_565d8c96(dec *codegen.Decoder) *protos.InitMXNReply {
+func mx_dec_ptr_InitMXNReply_79dd9ef2(dec *codegen.Decoder) *protos.InitMXNReply {
 	if !dec.Bool() {
 		return nil
 	}
@@ -2172,7 +2471,7 @@ func mx_dec_ptr_InitMXNReply_565d8c96(dec *codegen.Decoder) *protos.InitMXNReply
 	return &res
 }
 
-func mx_enc_ptr_UpdateComponentsRequest_d1b56e1f(enc *codegen.Encoder, arg *protos.UpdateComponentsRequest) {
+func mx_enc_ptr_UpdateComponentsRequest_71f0c3b4(enc *codegen.Encoder, arg *protos.UpdateComponentsRequest) {
 	if arg == nil {
 		enc.Bool(false)
 	} else {
@@ -2181,7 +2480,7 @@ func mx_enc_ptr_UpdateComponentsRequest_d1b56e1f(enc *codegen.Encoder, arg *prot
 	}
 }
 
-func mx_dec_ptr_UpdateComponentsRequest_d1b56e1f(dec *codegen.Decoder) *protos.UpdateComponentsRequest {
+func mx_dec_ptr_UpdateComponentsRequest_71f0c3b4(dec *codegen.Decoder) *protos.UpdateComponentsRequest {
 	if !dec.Bool() {
 		return nil
 	}
@@ -2190,7 +2489,7 @@ func mx_dec_ptr_UpdateComponentsRequest_d1b56e1f(dec *codegen.Decoder) *protos.U
 	return &res
 }
 
-func mx_enc_ptr_UpdateComponentsReply_93bebb77(enc *codegen.Encoder, arg *protos.UpdateComponentsReply) {
+func mx_enc_ptr_UpdateComponentsReply_8a27aae4(enc *codegen.Encoder, arg *protos.UpdateComponentsReply) {
 	if arg == nil {
 		enc.Bool(false)
 	} else {
@@ -2199,7 +2498,7 @@ func mx_enc_ptr_UpdateComponentsReply_93bebb77(enc *codegen.Encoder, arg *protos
 	}
 }
 
-func mx_dec_ptr_UpdateComponentsReply_93bebb77(dec *codegen.Decoder) *protos.UpdateComponentsReply {
+func mx_dec_ptr_UpdateComponentsReply_8a27aae4(dec *codegen.Decoder) *protos.UpdateComponentsReply {
 	if !dec.Bool() {
 		return nil
 	}
@@ -2208,7 +2507,7 @@ func mx_dec_ptr_UpdateComponentsReply_93bebb77(dec *codegen.Decoder) *protos.Upd
 	return &res
 }
 
-func mx_enc_ptr_UpdateRoutingInfoRequest_e752cfad(enc *codegen.Encoder, arg *protos.UpdateRoutingInfoRequest) {
+func mx_enc_ptr_UpdateRoutingInfoRequest_b246cc08(enc *codegen.Encoder, arg *protos.UpdateRoutingInfoRequest) {
 	if arg == nil {
 		enc.Bool(false)
 	} else {
@@ -2217,7 +2516,7 @@ func mx_enc_ptr_UpdateRoutingInfoRequest_e752cfad(enc *codegen.Encoder, arg *pro
 	}
 }
 
-func mx_dec_ptr_UpdateRoutingInfoRequest_e752cfad(dec *codegen.Decoder) *protos.UpdateRoutingInfoRequest {
+func mx_dec_ptr_UpdateRoutingInfoRequest_b246cc08(dec *codegen.Decoder) *protos.UpdateRoutingInfoRequest {
 	if !dec.Bool() {
 		return nil
 	}
@@ -2226,7 +2525,7 @@ func mx_dec_ptr_UpdateRoutingInfoRequest_e752cfad(dec *codegen.Decoder) *protos.
 	return &res
 }
 
-func mx_enc_ptr_UpdateRoutingInfoReply_d1854fd5(enc *codegen.Encoder, arg *protos.UpdateRoutingInfoReply) {
+func mx_enc_ptr_UpdateRoutingInfoReply_2ae6ba4d(enc *codegen.Encoder, arg *protos.UpdateRoutingInfoReply) {
 	if arg == nil {
 		enc.Bool(false)
 	} else {
@@ -2235,7 +2534,7 @@ func mx_enc_ptr_UpdateRoutingInfoReply_d1854fd5(enc *codegen.Encoder, arg *proto
 	}
 }
 
-func mx_dec_ptr_UpdateRoutingInfoReply_d1854fd5(dec *codegen.Decoder) *protos.UpdateRoutingInfoReply {
+func mx_dec_ptr_UpdateRoutingInfoReply_2ae6ba4d(dec *codegen.Decoder) *protos.UpdateRoutingInfoReply {
 	if !dec.Bool() {
 		return nil
 	}
@@ -272,6 +272,16 @@ func (*deployer) VerifyServerCertificate(context.Context, *protos.VerifyServerCe
 	panic("unused")
 }
 
+func (*deployer) GetSelfToken(context.Context, *protos.GetSelfTokenRequest) (*protos.GetSelfTokenReply, error) {
+	// This deployer doesn't enable token authentication.
+	panic("unused")
+}
+
+func (*deployer) VerifyClientToken(context.Context, *protos.VerifyClientTokenRequest) (*protos.VerifyClientTokenReply, error) {
+	// This deployer doesn't enable token authentication.
+	panic("unused")
+}
+
 // registerReplica registers the information about a colocation group replica
 // (i.e., a mxn).
 func (d *deployer) registerReplica(g *group, replicaAddr string) error {
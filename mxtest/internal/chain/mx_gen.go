@@ -30,7 +30,7 @@ func init() {
 		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
 			return a_reflect_stub{caller: caller}
 		},
-		RefData: "⟦d3d93f6e:MxEdge:github.com/sh3lk/mx/mxtest/internal/chain/A→github.com/sh3lk/mx/mxtest/internal/chain/B⟧\n",
+		RefData: "⟦deca8acb:MxEdge:github.com/sh3lk/mx/mxtest/internal/chain/A→github.com/sh3lk/mx/mxtest/internal/chain/B⟧\n⟦c5d0be9b:MxSchema:github.com/sh3lk/mx/mxtest/internal/chain/A→[{\"name\":\"Propagate\",\"args\":[{\"kind\":\"int64\"}]}]⟧\n",
 	})
 	codegen.Register(codegen.Registration{
 		Name:  "github.com/sh3lk/mx/mxtest/internal/chain/B",
@@ -48,7 +48,7 @@ func init() {
 		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
 			return b_reflect_stub{caller: caller}
 		},
-		RefData: "⟦08d612ad:MxEdge:github.com/sh3lk/mx/mxtest/internal/chain/B→github.com/sh3lk/mx/mxtest/internal/chain/C⟧\n",
+		RefData: "⟦5fd1dff7:MxEdge:github.com/sh3lk/mx/mxtest/internal/chain/B→github.com/sh3lk/mx/mxtest/internal/chain/C⟧\n⟦c1813169:MxSchema:github.com/sh3lk/mx/mxtest/internal/chain/B→[{\"name\":\"Propagate\",\"args\":[{\"kind\":\"int64\"}]}]⟧\n",
 	})
 	codegen.Register(codegen.Registration{
 		Name:  "github.com/sh3lk/mx/mxtest/internal/chain/C",
@@ -66,7 +66,7 @@ func init() {
 		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
 			return c_reflect_stub{caller: caller}
 		},
-		RefData: "",
+		RefData: "⟦c83d67ab:MxSchema:github.com/sh3lk/mx/mxtest/internal/chain/C→[{\"name\":\"Propagate\",\"args\":[{\"kind\":\"int64\"}]}]⟧\n",
 	})
 }
 
@@ -211,7 +211,8 @@ func (s a_client_stub) Propagate(ctx context.Context, a0 int) (err error) {
 	// Preallocate a buffer of the right size.
 	size := 0
 	size += 8
-	enc := codegen.NewEncoder()
+	enc := codegen.GetEncoder()
+	defer codegen.PutEncoder(enc)
 	enc.Reset(size)
 
 	// Encode arguments.
@@ -229,7 +230,7 @@ func (s a_client_stub) Propagate(ctx context.Context, a0 int) (err error) {
 	}
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewZeroCopyDecoder(results)
 	err = dec.Error()
 	return
 }
@@ -274,7 +275,8 @@ func (s b_client_stub) Propagate(ctx context.Context, a0 int) (err error) {
 	// Preallocate a buffer of the right size.
 	size := 0
 	size += 8
-	enc := codegen.NewEncoder()
+	enc := codegen.GetEncoder()
+	defer codegen.PutEncoder(enc)
 	enc.Reset(size)
 
 	// Encode arguments.
@@ -292,7 +294,7 @@ func (s b_client_stub) Propagate(ctx context.Context, a0 int) (err error) {
 	}
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewZeroCopyDecoder(results)
 	err = dec.Error()
 	return
 }
@@ -337,7 +339,8 @@ func (s c_client_stub) Propagate(ctx context.Context, a0 int) (err error) {
 	// Preallocate a buffer of the right size.
 	size := 0
 	size += 8
-	enc := codegen.NewEncoder()
+	enc := codegen.GetEncoder()
+	defer codegen.PutEncoder(enc)
 	enc.Reset(size)
 
 	// Encode arguments.
@@ -355,7 +358,7 @@ func (s c_client_stub) Propagate(ctx context.Context, a0 int) (err error) {
 	}
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewZeroCopyDecoder(results)
 	err = dec.Error()
 	return
 }
@@ -363,10 +366,10 @@ func (s c_client_stub) Propagate(ctx context.Context, a0 int) (err error) {
 // Note that "mx generate" will always generate the error message below.
 // Everything is okay. The error message is only relevant if you see it when
 // you run "go build" or "go run".
-var _ codegen.LatestVersion = codegen.Version[[0][24]struct{}](`
+var _ codegen.LatestVersion = codegen.Version[[0][25]struct{}](`
 
-ERROR: You generated this file with 'mx generate' v0.24.7-0.20250401231336-b01860e0378a+dirty (codegen
-version v0.24.0). The generated code is incompatible with the version of the
+ERROR: You generated this file with 'mx generate' (devel) (codegen
+version v0.25.0). The generated code is incompatible with the version of the
 github.com/sh3lk/mx module that you're using. The mx module
 version can be found in your go.mod file or by running the following command.
 
@@ -30,7 +30,7 @@ func init() {
 		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
 			return started_reflect_stub{caller: caller}
 		},
-		RefData: "",
+		RefData: "⟦c6e6e20f:MxSchema:github.com/sh3lk/mx/mxtest/internal/deploy/Started→[{\"name\":\"MarkStarted\",\"args\":[{\"kind\":\"string\"}]}]⟧\n",
 	})
 	codegen.Register(codegen.Registration{
 		Name:  "github.com/sh3lk/mx/mxtest/internal/deploy/Widget",
@@ -48,7 +48,7 @@ func init() {
 		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
 			return widget_reflect_stub{caller: caller}
 		},
-		RefData: "⟦f3fa3c18:MxEdge:github.com/sh3lk/mx/mxtest/internal/deploy/Widget→github.com/sh3lk/mx/mxtest/internal/deploy/Started⟧\n",
+		RefData: "⟦d0457848:MxEdge:github.com/sh3lk/mx/mxtest/internal/deploy/Widget→github.com/sh3lk/mx/mxtest/internal/deploy/Started⟧\n⟦0167358d:MxSchema:github.com/sh3lk/mx/mxtest/internal/deploy/Widget→[{\"name\":\"Use\",\"args\":[{\"kind\":\"string\"}]}]⟧\n",
 	})
 }
 
@@ -162,7 +162,8 @@ func (s started_client_stub) MarkStarted(ctx context.Context, a0 string) (err er
 	// Preallocate a buffer of the right size.
 	size := 0
 	size += (4 + len(a0))
-	enc := codegen.NewEncoder()
+	enc := codegen.GetEncoder()
+	defer codegen.PutEncoder(enc)
 	enc.Reset(size)
 
 	// Encode arguments.
@@ -180,7 +181,7 @@ func (s started_client_stub) MarkStarted(ctx context.Context, a0 string) (err er
 	}
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewZeroCopyDecoder(results)
 	err = dec.Error()
 	return
 }
@@ -225,7 +226,8 @@ func (s widget_client_stub) Use(ctx context.Context, a0 string) (err error) {
 	// Preallocate a buffer of the right size.
 	size := 0
 	size += (4 + len(a0))
-	enc := codegen.NewEncoder()
+	enc := codegen.GetEncoder()
+	defer codegen.PutEncoder(enc)
 	enc.Reset(size)
 
 	// Encode arguments.
@@ -243,7 +245,7 @@ func (s widget_client_stub) Use(ctx context.Context, a0 string) (err error) {
 	}
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewZeroCopyDecoder(results)
 	err = dec.Error()
 	return
 }
@@ -251,10 +253,10 @@ func (s widget_client_stub) Use(ctx context.Context, a0 string) (err error) {
 // Note that "mx generate" will always generate the error message below.
 // Everything is okay. The error message is only relevant if you see it when
 // you run "go build" or "go run".
-var _ codegen.LatestVersion = codegen.Version[[0][24]struct{}](`
+var _ codegen.LatestVersion = codegen.Version[[0][25]struct{}](`
 
-ERROR: You generated this file with 'mx generate' v0.24.7-0.20250401231336-b01860e0378a+dirty (codegen
-version v0.24.0). The generated code is incompatible with the version of the
+ERROR: You generated this file with 'mx generate' (devel) (codegen
+version v0.25.0). The generated code is incompatible with the version of the
 github.com/sh3lk/mx module that you're using. The mx module
 version can be found in your go.mod file or by running the following command.
 
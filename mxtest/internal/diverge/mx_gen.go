@@ -31,7 +31,7 @@ func init() {
 		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
 			return errer_reflect_stub{caller: caller}
 		},
-		RefData: "",
+		RefData: "⟦2ba50d85:MxSchema:github.com/sh3lk/mx/mxtest/internal/diverge/Errer→[{\"name\":\"Err\",\"args\":[{\"kind\":\"int64\"}]}]⟧\n",
 	})
 	codegen.Register(codegen.Registration{
 		Name:  "github.com/sh3lk/mx/mxtest/internal/diverge/Pointer",
@@ -49,7 +49,7 @@ func init() {
 		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
 			return pointer_reflect_stub{caller: caller}
 		},
-		RefData: "",
+		RefData: "⟦f14c513c:MxSchema:github.com/sh3lk/mx/mxtest/internal/diverge/Pointer→[{\"name\":\"Get\",\"results\":[{\"kind\":\"struct\",\"name\":\"diverge.Pair\",\"fields\":[{\"name\":\"X\",\"type\":{\"kind\":\"pointer\",\"elem\":{\"kind\":\"int64\"}}},{\"name\":\"Y\",\"type\":{\"kind\":\"pointer\",\"elem\":{\"kind\":\"int64\"}}}]}]}]⟧\n",
 	})
 }
 
@@ -163,7 +163,8 @@ func (s errer_client_stub) Err(ctx context.Context, a0 int) (err error) {
 	// Preallocate a buffer of the right size.
 	size := 0
 	size += 8
-	enc := codegen.NewEncoder()
+	enc := codegen.GetEncoder()
+	defer codegen.PutEncoder(enc)
 	enc.Reset(size)
 
 	// Encode arguments.
@@ -181,7 +182,7 @@ func (s errer_client_stub) Err(ctx context.Context, a0 int) (err error) {
 	}
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewZeroCopyDecoder(results)
 	err = dec.Error()
 	return
 }
@@ -235,7 +236,7 @@ func (s pointer_client_stub) Get(ctx context.Context) (r0 Pair, err error) {
 	}
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewZeroCopyDecoder(results)
 	(&r0).MXUnmarshal(dec)
 	err = dec.Error()
 	return
@@ -244,10 +245,10 @@ func (s pointer_client_stub) Get(ctx context.Context) (r0 Pair, err error) {
 // Note that "mx generate" will always generate the error message below.
 // Everything is okay. The error message is only relevant if you see it when
 // you run "go build" or "go run".
-var _ codegen.LatestVersion = codegen.Version[[0][24]struct{}](`
+var _ codegen.LatestVersion = codegen.Version[[0][25]struct{}](`
 
-ERROR: You generated this file with 'mx generate' v0.24.7-0.20250401231336-b01860e0378a+dirty (codegen
-version v0.24.0). The generated code is incompatible with the version of the
+ERROR: You generated this file with 'mx generate' (devel) (codegen
+version v0.25.0). The generated code is incompatible with the version of the
 github.com/sh3lk/mx module that you're using. The mx module
 version can be found in your go.mod file or by running the following command.
 
@@ -33,6 +33,7 @@ const (
 	panicError
 	customError
 	noError
+	wrappedError
 )
 
 type customErrorValue struct {
@@ -42,6 +43,15 @@ type customErrorValue struct {
 
 func (c customErrorValue) Error() string { return fmt.Sprintf("customError(%s)", c.key) }
 
+type wrappedErrorValue struct {
+	mx.AutoMarshal
+	key string
+	err error
+}
+
+func (w wrappedErrorValue) Error() string { return fmt.Sprintf("wrappedError(%s): %v", w.key, w.err) }
+func (w wrappedErrorValue) Unwrap() error { return w.err }
+
 type testApp interface {
 	Get(_ context.Context, key string, behavior behaviorType) (int, error)
 	IncPointer(_ context.Context, arg *int) (*int, error)
@@ -63,6 +73,8 @@ func (p *impl) Get(_ context.Context, key string, behavior behaviorType) (int, e
 		return 0, customErrorValue{key: key}
 	case noError:
 		return 42, nil
+	case wrappedError:
+		return 0, fmt.Errorf("get: %w", wrappedErrorValue{key: key, err: customErrorValue{key: key}})
 	}
 	return 42, fmt.Errorf("unknown behavior type: %v", behavior)
 }
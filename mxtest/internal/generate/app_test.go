@@ -74,6 +74,33 @@ func TestCustomError(t *testing.T) {
 	})
 }
 
+func TestWrappedCustomError(t *testing.T) {
+	ctx := context.Background()
+	for _, runner := range mxtest.AllRunners() {
+		runner.Test(t, func(t *testing.T, client testApp) {
+			_, err := client.Get(ctx, "wrapped", wrappedError)
+			if err == nil {
+				t.Fatal("unexpected success")
+			}
+			if got, want := err.Error(), "get: wrappedError(wrapped): customError(wrapped)"; got != want {
+				t.Errorf("got error %q, expecting %q", got, want)
+			}
+			var w wrappedErrorValue
+			if !errors.As(err, &w) {
+				t.Fatalf("did not get wrappedError, got error %v of type %T", err, err)
+			} else if w.key != "wrapped" {
+				t.Errorf("wrappedError contained wrong key %q, expecting %q", w.key, "wrapped")
+			}
+			var c customErrorValue
+			if !errors.As(err, &c) {
+				t.Errorf("did not get customError, got error %v of type %T", err, err)
+			} else if c.key != "wrapped" {
+				t.Errorf("customError contained wrong key %q, expecting %q", c.key, "wrapped")
+			}
+		})
+	}
+}
+
 func TestPanic(t *testing.T) {
 	t.Skip("mxtest crashes if any component panics, even in another process")
 	ctx := context.Background()
@@ -31,7 +31,7 @@ func init() {
 		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
 			return testApp_reflect_stub{caller: caller}
 		},
-		RefData: "",
+		RefData: "⟦c2be6b4c:MxSchema:github.com/sh3lk/mx/mxtest/internal/generate/testApp→[{\"name\":\"DivMod\",\"args\":[{\"kind\":\"int64\"},{\"kind\":\"int64\"}],\"results\":[{\"kind\":\"int64\"},{\"kind\":\"int64\"}]},{\"name\":\"Get\",\"args\":[{\"kind\":\"string\"},{\"kind\":\"int64\",\"name\":\"generate.behaviorType\"}],\"results\":[{\"kind\":\"int64\"}]},{\"name\":\"IncPointer\",\"args\":[{\"kind\":\"pointer\",\"elem\":{\"kind\":\"int64\"}}],\"results\":[{\"kind\":\"pointer\",\"elem\":{\"kind\":\"int64\"}}]}]⟧\n",
 	})
 }
 
@@ -159,7 +159,8 @@ func (s testApp_client_stub) DivMod(ctx context.Context, a0 int, a1 int) (r0 int
 	size := 0
 	size += 8
 	size += 8
-	enc := codegen.NewEncoder()
+	enc := codegen.GetEncoder()
+	defer codegen.PutEncoder(enc)
 	enc.Reset(size)
 
 	// Encode arguments.
@@ -178,7 +179,7 @@ func (s testApp_client_stub) DivMod(ctx context.Context, a0 int, a1 int) (r0 int
 	}
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewZeroCopyDecoder(results)
 	r0 = dec.Int()
 	r1 = dec.Int()
 	err = dec.Error()
@@ -218,7 +219,8 @@ func (s testApp_client_stub) Get(ctx context.Context, a0 string, a1 behaviorType
 	size := 0
 	size += (4 + len(a0))
 	size += 8
-	enc := codegen.NewEncoder()
+	enc := codegen.GetEncoder()
+	defer codegen.PutEncoder(enc)
 	enc.Reset(size)
 
 	// Encode arguments.
@@ -237,7 +239,7 @@ func (s testApp_client_stub) Get(ctx context.Context, a0 string, a1 behaviorType
 	}
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewZeroCopyDecoder(results)
 	r0 = dec.Int()
 	err = dec.Error()
 	return
@@ -275,7 +277,8 @@ func (s testApp_client_stub) IncPointer(ctx context.Context, a0 *int) (r0 *int,
 	// Preallocate a buffer of the right size.
 	size := 0
 	size += mx_size_ptr_int_98a2a745(a0)
-	enc := codegen.NewEncoder()
+	enc := codegen.GetEncoder()
+	defer codegen.PutEncoder(enc)
 	enc.Reset(size)
 
 	// Encode arguments.
@@ -293,7 +296,7 @@ func (s testApp_client_stub) IncPointer(ctx context.Context, a0 *int) (r0 *int,
 	}
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewZeroCopyDecoder(results)
 	r0 = mx_dec_ptr_int_98a2a745(dec)
 	err = dec.Error()
 	return
@@ -302,10 +305,10 @@ func (s testApp_client_stub) IncPointer(ctx context.Context, a0 *int) (r0 *int,
 // Note that "mx generate" will always generate the error message below.
 // Everything is okay. The error message is only relevant if you see it when
 // you run "go build" or "go run".
-var _ codegen.LatestVersion = codegen.Version[[0][24]struct{}](`
+var _ codegen.LatestVersion = codegen.Version[[0][25]struct{}](`
 
-ERROR: You generated this file with 'mx generate' v0.24.7-0.20250401231336-b01860e0378a+dirty (codegen
-version v0.24.0). The generated code is incompatible with the version of the
+ERROR: You generated this file with 'mx generate' (devel) (codegen
+version v0.25.0). The generated code is incompatible with the version of the
 github.com/sh3lk/mx module that you're using. The mx module
 version can be found in your go.mod file or by running the following command.
 
@@ -476,6 +479,33 @@ func (x *customErrorValue) MXUnmarshal(dec *codegen.Decoder) {
 }
 func init() { codegen.RegisterSerializable[*customErrorValue]() }
 
+var _ codegen.AutoMarshal = (*wrappedErrorValue)(nil)
+
+type __is_wrappedErrorValue[T ~struct {
+	mx.AutoMarshal
+	key string
+	err error
+}] struct{}
+
+var _ __is_wrappedErrorValue[wrappedErrorValue]
+
+func (x *wrappedErrorValue) MXMarshal(enc *codegen.Encoder) {
+	if x == nil {
+		panic(fmt.Errorf("wrappedErrorValue.MXMarshal: nil receiver"))
+	}
+	enc.String(x.key)
+	enc.Error(x.err)
+}
+
+func (x *wrappedErrorValue) MXUnmarshal(dec *codegen.Decoder) {
+	if x == nil {
+		panic(fmt.Errorf("wrappedErrorValue.MXUnmarshal: nil receiver"))
+	}
+	x.key = dec.String()
+	x.err = dec.Error()
+}
+func init() { codegen.RegisterSerializable[*wrappedErrorValue]() }
+
 // Encoding/decoding implementations.
 
 func mx_enc_ptr_int_98a2a745(enc *codegen.Encoder, arg *int) {
@@ -0,0 +1,68 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package intercept defines components used to test interceptors.
+//
+// Front calls Calc, so that calls to Calc are made both by the test and by
+// another component.
+package intercept
+
+import (
+	"context"
+	"errors"
+
+	"github.com/sh3lk/mx"
+)
+
+//go:generate ../../../cmd/mx/mx generate
+
+// ErrDivideByZero is returned by Calc.Div when dividing by zero.
+var ErrDivideByZero = errors.New("divide by zero")
+
+type Calc interface {
+	// Add returns a + b.
+	Add(ctx context.Context, a, b int) (int, error)
+
+	// Div returns a / b.
+	Div(ctx context.Context, a, b int) (int, error)
+}
+
+type Front interface {
+	// Double returns 2 * x, as computed by Calc.
+	Double(ctx context.Context, x int) (int, error)
+}
+
+type calc struct {
+	mx.Implements[Calc]
+}
+
+func (*calc) Add(_ context.Context, a, b int) (int, error) {
+	return a + b, nil
+}
+
+func (*calc) Div(_ context.Context, a, b int) (int, error) {
+	if b == 0 {
+		return 0, ErrDivideByZero
+	}
+	return a / b, nil
+}
+
+type front struct {
+	mx.Implements[Front]
+	calc mx.Ref[Calc]
+}
+
+func (f *front) Double(ctx context.Context, x int) (int, error) {
+	return f.calc.Get().Add(ctx, x, x)
+}
@@ -0,0 +1,200 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package intercept_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sh3lk/mx"
+	"github.com/sh3lk/mx/mxtest"
+	"github.com/sh3lk/mx/mxtest/internal/intercept"
+)
+
+const (
+	calcName  = "github.com/sh3lk/mx/mxtest/internal/intercept/Calc"
+	frontName = "github.com/sh3lk/mx/mxtest/internal/intercept/Front"
+)
+
+var (
+	// errDenied is returned by a client interceptor for denied calls.
+	errDenied = errors.New("denied")
+
+	// errTooLarge is returned by a server interceptor for denied calls.
+	errTooLarge = errors.New("too large")
+)
+
+// call is a component method call recorded by an interceptor.
+type call struct {
+	Component string
+	Method    string
+	Caller    string
+	Args      []any
+	Results   []any
+	Err       string
+}
+
+// recorder records the calls seen by an interceptor.
+type recorder struct {
+	mu    sync.Mutex
+	calls []call
+}
+
+func (r *recorder) intercept(ctx context.Context, c *mx.MethodCall, invoke func(context.Context) error) error {
+	err := invoke(ctx)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rec := call{
+		Component: c.Component,
+		Method:    c.Method,
+		Caller:    c.Caller,
+		Args:      c.Args,
+		Results:   c.Results,
+	}
+	if err != nil {
+		rec.Err = err.Error()
+	}
+	r.calls = append(r.calls, rec)
+	return err
+}
+
+func (r *recorder) reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = nil
+}
+
+func (r *recorder) get() []call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]call(nil), r.calls...)
+}
+
+var clientCalls recorder
+
+func init() {
+	mx.InterceptClient(clientCalls.intercept)
+
+	// Deny divisions of negative numbers before they reach Calc.
+	mx.InterceptClient(func(ctx context.Context, c *mx.MethodCall, invoke func(context.Context) error) error {
+		if c.Method == "Div" && c.Args[0].(int) < 0 {
+			return errDenied
+		}
+		return invoke(ctx)
+	})
+
+	// Deny additions of large numbers. Note that server interceptors run in
+	// the process hosting Calc, which may not be the test process.
+	mx.InterceptServer(func(ctx context.Context, c *mx.MethodCall, invoke func(context.Context) error) error {
+		if c.Method == "Add" && c.Args[0].(int) > 100 {
+			return errTooLarge
+		}
+		return invoke(ctx)
+	})
+
+	// Round Add results up to the nearest multiple of 10.
+	mx.InterceptServer(func(ctx context.Context, c *mx.MethodCall, invoke func(context.Context) error) error {
+		if err := invoke(ctx); err != nil {
+			return err
+		}
+		if c.Method == "Add" {
+			c.Results[0] = (c.Results[0].(int) + 9) / 10 * 10
+		}
+		return nil
+	})
+}
+
+func TestInterceptors(t *testing.T) {
+	ctx := context.Background()
+	for _, runner := range mxtest.AllRunners() {
+		runner.Test(t, func(t *testing.T, calc intercept.Calc) {
+			clientCalls.reset()
+
+			got, err := calc.Add(ctx, 1, 2)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := 10; got != want {
+				t.Fatalf("Add(1, 2): got %d, want %d", got, want)
+			}
+			if _, err := calc.Div(ctx, 1, 0); !errors.Is(err, intercept.ErrDivideByZero) {
+				t.Fatalf("Div(1, 0): got %v, want %v", err, intercept.ErrDivideByZero)
+			}
+
+			want := []call{
+				{Component: calcName, Method: "Add", Args: []any{1, 2}, Results: []any{10}},
+				{Component: calcName, Method: "Div", Args: []any{1, 0}, Results: []any{0}, Err: intercept.ErrDivideByZero.Error()},
+			}
+			calls := clientCalls.get()
+			for i := range calls {
+				calls[i].Caller = ""
+			}
+			if diff := cmp.Diff(want, calls); diff != "" {
+				t.Errorf("client calls (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestInterceptorDeniesCall(t *testing.T) {
+	ctx := context.Background()
+	for _, runner := range mxtest.AllRunners() {
+		runner.Test(t, func(t *testing.T, calc intercept.Calc) {
+			if _, err := calc.Div(ctx, -4, 2); !errors.Is(err, errDenied) {
+				t.Fatalf("Div(-4, 2): got %v, want %v", err, errDenied)
+			}
+			if _, err := calc.Add(ctx, 1000, 1); !errors.Is(err, errTooLarge) {
+				t.Fatalf("Add(1000, 1): got %v, want %v", err, errTooLarge)
+			}
+		})
+	}
+}
+
+func TestInterceptorCaller(t *testing.T) {
+	ctx := context.Background()
+	for _, runner := range mxtest.AllRunners() {
+		runner.Test(t, func(t *testing.T, front intercept.Front) {
+			clientCalls.reset()
+			got, err := front.Double(ctx, 3)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := 10; got != want {
+				t.Fatalf("Double(3): got %d, want %d", got, want)
+			}
+
+			if runner.Name == "Multi" {
+				// Front calls Calc from a different process.
+				return
+			}
+			n := 0
+			for _, c := range clientCalls.get() {
+				if c.Component != calcName {
+					continue
+				}
+				n++
+				if c.Caller != frontName {
+					t.Errorf("Calc.%s: got caller %q, want %q", c.Method, c.Caller, frontName)
+				}
+			}
+			if n == 0 {
+				t.Fatal("no calls to Calc intercepted")
+			}
+		})
+	}
+}
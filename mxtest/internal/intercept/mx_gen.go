@@ -0,0 +1,509 @@
+// Code generated by "mx generate". DO NOT EDIT.
+//go:build !ignoreMXGen
+
+package intercept
+
+import (
+	"context"
+	"errors"
+	"github.com/sh3lk/mx"
+	"github.com/sh3lk/mx/runtime/codegen"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"reflect"
+)
+
+func init() {
+	codegen.Register(codegen.Registration{
+		Name:  "github.com/sh3lk/mx/mxtest/internal/intercept/Calc",
+		Iface: reflect.TypeOf((*Calc)(nil)).Elem(),
+		Impl:  reflect.TypeOf(calc{}),
+		LocalStubFn: func(impl any, caller string, tracer trace.Tracer) any {
+			return calc_local_stub{impl: impl.(Calc), tracer: tracer, addMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/sh3lk/mx/mxtest/internal/intercept/Calc", Method: "Add", Remote: false, Generated: true}), divMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/sh3lk/mx/mxtest/internal/intercept/Calc", Method: "Div", Remote: false, Generated: true})}
+		},
+		ClientStubFn: func(stub codegen.Stub, caller string) any {
+			return calc_client_stub{stub: stub, addMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/sh3lk/mx/mxtest/internal/intercept/Calc", Method: "Add", Remote: true, Generated: true}), divMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/sh3lk/mx/mxtest/internal/intercept/Calc", Method: "Div", Remote: true, Generated: true})}
+		},
+		ServerStubFn: func(impl any, addLoad func(uint64, float64)) codegen.Server {
+			return calc_server_stub{impl: impl.(Calc), addLoad: addLoad}
+		},
+		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
+			return calc_reflect_stub{caller: caller}
+		},
+		RefData: "⟦8d1fec38:MxSchema:github.com/sh3lk/mx/mxtest/internal/intercept/Calc→[{\"name\":\"Add\",\"args\":[{\"kind\":\"int64\"},{\"kind\":\"int64\"}],\"results\":[{\"kind\":\"int64\"}]},{\"name\":\"Div\",\"args\":[{\"kind\":\"int64\"},{\"kind\":\"int64\"}],\"results\":[{\"kind\":\"int64\"}]}]⟧\n",
+	})
+	codegen.Register(codegen.Registration{
+		Name:  "github.com/sh3lk/mx/mxtest/internal/intercept/Front",
+		Iface: reflect.TypeOf((*Front)(nil)).Elem(),
+		Impl:  reflect.TypeOf(front{}),
+		LocalStubFn: func(impl any, caller string, tracer trace.Tracer) any {
+			return front_local_stub{impl: impl.(Front), tracer: tracer, doubleMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/sh3lk/mx/mxtest/internal/intercept/Front", Method: "Double", Remote: false, Generated: true})}
+		},
+		ClientStubFn: func(stub codegen.Stub, caller string) any {
+			return front_client_stub{stub: stub, doubleMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/sh3lk/mx/mxtest/internal/intercept/Front", Method: "Double", Remote: true, Generated: true})}
+		},
+		ServerStubFn: func(impl any, addLoad func(uint64, float64)) codegen.Server {
+			return front_server_stub{impl: impl.(Front), addLoad: addLoad}
+		},
+		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
+			return front_reflect_stub{caller: caller}
+		},
+		RefData: "⟦bd6a18c1:MxEdge:github.com/sh3lk/mx/mxtest/internal/intercept/Front→github.com/sh3lk/mx/mxtest/internal/intercept/Calc⟧\n⟦1d1291c0:MxSchema:github.com/sh3lk/mx/mxtest/internal/intercept/Front→[{\"name\":\"Double\",\"args\":[{\"kind\":\"int64\"}],\"results\":[{\"kind\":\"int64\"}]}]⟧\n",
+	})
+}
+
+// mx.InstanceOf checks.
+var _ mx.InstanceOf[Calc] = (*calc)(nil)
+var _ mx.InstanceOf[Front] = (*front)(nil)
+
+// mx.Router checks.
+var _ mx.Unrouted = (*calc)(nil)
+var _ mx.Unrouted = (*front)(nil)
+
+// Local stub implementations.
+
+type calc_local_stub struct {
+	impl       Calc
+	tracer     trace.Tracer
+	addMetrics *codegen.MethodMetrics
+	divMetrics *codegen.MethodMetrics
+}
+
+// Check that calc_local_stub implements the Calc interface.
+var _ Calc = (*calc_local_stub)(nil)
+
+func (s calc_local_stub) Add(ctx context.Context, a0 int, a1 int) (r0 int, err error) {
+	// Update metrics.
+	begin := s.addMetrics.Begin()
+	defer func() { s.addMetrics.End(begin, err != nil, 0, 0) }()
+	span := trace.SpanFromContext(ctx)
+	if span.SpanContext().IsValid() {
+		// Create a child span for this method.
+		ctx, span = s.tracer.Start(ctx, "intercept.Calc.Add", trace.WithSpanKind(trace.SpanKindInternal))
+		defer func() {
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			}
+			span.End()
+		}()
+	}
+
+	return s.impl.Add(ctx, a0, a1)
+}
+
+func (s calc_local_stub) Div(ctx context.Context, a0 int, a1 int) (r0 int, err error) {
+	// Update metrics.
+	begin := s.divMetrics.Begin()
+	defer func() { s.divMetrics.End(begin, err != nil, 0, 0) }()
+	span := trace.SpanFromContext(ctx)
+	if span.SpanContext().IsValid() {
+		// Create a child span for this method.
+		ctx, span = s.tracer.Start(ctx, "intercept.Calc.Div", trace.WithSpanKind(trace.SpanKindInternal))
+		defer func() {
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			}
+			span.End()
+		}()
+	}
+
+	return s.impl.Div(ctx, a0, a1)
+}
+
+type front_local_stub struct {
+	impl          Front
+	tracer        trace.Tracer
+	doubleMetrics *codegen.MethodMetrics
+}
+
+// Check that front_local_stub implements the Front interface.
+var _ Front = (*front_local_stub)(nil)
+
+func (s front_local_stub) Double(ctx context.Context, a0 int) (r0 int, err error) {
+	// Update metrics.
+	begin := s.doubleMetrics.Begin()
+	defer func() { s.doubleMetrics.End(begin, err != nil, 0, 0) }()
+	span := trace.SpanFromContext(ctx)
+	if span.SpanContext().IsValid() {
+		// Create a child span for this method.
+		ctx, span = s.tracer.Start(ctx, "intercept.Front.Double", trace.WithSpanKind(trace.SpanKindInternal))
+		defer func() {
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			}
+			span.End()
+		}()
+	}
+
+	return s.impl.Double(ctx, a0)
+}
+
+// Client stub implementations.
+
+type calc_client_stub struct {
+	stub       codegen.Stub
+	addMetrics *codegen.MethodMetrics
+	divMetrics *codegen.MethodMetrics
+}
+
+// Check that calc_client_stub implements the Calc interface.
+var _ Calc = (*calc_client_stub)(nil)
+
+func (s calc_client_stub) Add(ctx context.Context, a0 int, a1 int) (r0 int, err error) {
+	// Update metrics.
+	var requestBytes, replyBytes int
+	begin := s.addMetrics.Begin()
+	defer func() { s.addMetrics.End(begin, err != nil, requestBytes, replyBytes) }()
+
+	span := trace.SpanFromContext(ctx)
+	if span.SpanContext().IsValid() {
+		// Create a child span for this method.
+		ctx, span = s.stub.Tracer().Start(ctx, "intercept.Calc.Add", trace.WithSpanKind(trace.SpanKindClient))
+	}
+
+	defer func() {
+		// Catch and return any panics detected during encoding/decoding/rpc.
+		if err == nil {
+			err = codegen.CatchPanics(recover())
+			if err != nil {
+				err = errors.Join(mx.RemoteCallError, err)
+			}
+		}
+
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+
+	}()
+
+	// Preallocate a buffer of the right size.
+	size := 0
+	size += 8
+	size += 8
+	enc := codegen.GetEncoder()
+	defer codegen.PutEncoder(enc)
+	enc.Reset(size)
+
+	// Encode arguments.
+	enc.Int(a0)
+	enc.Int(a1)
+	var shardKey uint64
+
+	// Call the remote method.
+	requestBytes = len(enc.Data())
+	var results []byte
+	results, err = s.stub.Run(ctx, 0, enc.Data(), shardKey)
+	replyBytes = len(results)
+	if err != nil {
+		err = errors.Join(mx.RemoteCallError, err)
+		return
+	}
+
+	// Decode the results.
+	dec := codegen.NewZeroCopyDecoder(results)
+	r0 = dec.Int()
+	err = dec.Error()
+	return
+}
+
+func (s calc_client_stub) Div(ctx context.Context, a0 int, a1 int) (r0 int, err error) {
+	// Update metrics.
+	var requestBytes, replyBytes int
+	begin := s.divMetrics.Begin()
+	defer func() { s.divMetrics.End(begin, err != nil, requestBytes, replyBytes) }()
+
+	span := trace.SpanFromContext(ctx)
+	if span.SpanContext().IsValid() {
+		// Create a child span for this method.
+		ctx, span = s.stub.Tracer().Start(ctx, "intercept.Calc.Div", trace.WithSpanKind(trace.SpanKindClient))
+	}
+
+	defer func() {
+		// Catch and return any panics detected during encoding/decoding/rpc.
+		if err == nil {
+			err = codegen.CatchPanics(recover())
+			if err != nil {
+				err = errors.Join(mx.RemoteCallError, err)
+			}
+		}
+
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+
+	}()
+
+	// Preallocate a buffer of the right size.
+	size := 0
+	size += 8
+	size += 8
+	enc := codegen.GetEncoder()
+	defer codegen.PutEncoder(enc)
+	enc.Reset(size)
+
+	// Encode arguments.
+	enc.Int(a0)
+	enc.Int(a1)
+	var shardKey uint64
+
+	// Call the remote method.
+	requestBytes = len(enc.Data())
+	var results []byte
+	results, err = s.stub.Run(ctx, 1, enc.Data(), shardKey)
+	replyBytes = len(results)
+	if err != nil {
+		err = errors.Join(mx.RemoteCallError, err)
+		return
+	}
+
+	// Decode the results.
+	dec := codegen.NewZeroCopyDecoder(results)
+	r0 = dec.Int()
+	err = dec.Error()
+	return
+}
+
+type front_client_stub struct {
+	stub          codegen.Stub
+	doubleMetrics *codegen.MethodMetrics
+}
+
+// Check that front_client_stub implements the Front interface.
+var _ Front = (*front_client_stub)(nil)
+
+func (s front_client_stub) Double(ctx context.Context, a0 int) (r0 int, err error) {
+	// Update metrics.
+	var requestBytes, replyBytes int
+	begin := s.doubleMetrics.Begin()
+	defer func() { s.doubleMetrics.End(begin, err != nil, requestBytes, replyBytes) }()
+
+	span := trace.SpanFromContext(ctx)
+	if span.SpanContext().IsValid() {
+		// Create a child span for this method.
+		ctx, span = s.stub.Tracer().Start(ctx, "intercept.Front.Double", trace.WithSpanKind(trace.SpanKindClient))
+	}
+
+	defer func() {
+		// Catch and return any panics detected during encoding/decoding/rpc.
+		if err == nil {
+			err = codegen.CatchPanics(recover())
+			if err != nil {
+				err = errors.Join(mx.RemoteCallError, err)
+			}
+		}
+
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+
+	}()
+
+	// Preallocate a buffer of the right size.
+	size := 0
+	size += 8
+	enc := codegen.GetEncoder()
+	defer codegen.PutEncoder(enc)
+	enc.Reset(size)
+
+	// Encode arguments.
+	enc.Int(a0)
+	var shardKey uint64
+
+	// Call the remote method.
+	requestBytes = len(enc.Data())
+	var results []byte
+	results, err = s.stub.Run(ctx, 0, enc.Data(), shardKey)
+	replyBytes = len(results)
+	if err != nil {
+		err = errors.Join(mx.RemoteCallError, err)
+		return
+	}
+
+	// Decode the results.
+	dec := codegen.NewZeroCopyDecoder(results)
+	r0 = dec.Int()
+	err = dec.Error()
+	return
+}
+
+// Note that "mx generate" will always generate the error message below.
+// Everything is okay. The error message is only relevant if you see it when
+// you run "go build" or "go run".
+var _ codegen.LatestVersion = codegen.Version[[0][25]struct{}](`
+
+ERROR: You generated this file with 'mx generate' (devel) (codegen
+version v0.25.0). The generated code is incompatible with the version of the
+github.com/sh3lk/mx module that you're using. The mx module
+version can be found in your go.mod file or by running the following command.
+
+    go list -m github.com/sh3lk/mx
+
+We recommend updating the mx module and the 'mx generate' command by
+running the following.
+
+    go get github.com/sh3lk/mx@latest
+    go install github.com/sh3lk/mx/cmd/mx@latest
+
+Then, re-run 'mx generate' and re-build your code. If the problem persists,
+please file an issue at https://github.com/sh3lk/mx/issues.
+
+`)
+
+// Server stub implementations.
+
+type calc_server_stub struct {
+	impl    Calc
+	addLoad func(key uint64, load float64)
+}
+
+// Check that calc_server_stub implements the codegen.Server interface.
+var _ codegen.Server = (*calc_server_stub)(nil)
+
+// GetStubFn implements the codegen.Server interface.
+func (s calc_server_stub) GetStubFn(method string) func(ctx context.Context, args []byte) ([]byte, error) {
+	switch method {
+	case "Add":
+		return s.add
+	case "Div":
+		return s.div
+	default:
+		return nil
+	}
+}
+
+func (s calc_server_stub) add(ctx context.Context, args []byte) (res []byte, err error) {
+	// Catch and return any panics detected during encoding/decoding/rpc.
+	defer func() {
+		if err == nil {
+			err = codegen.CatchPanics(recover())
+		}
+	}()
+
+	// Decode arguments.
+	dec := codegen.NewDecoder(args)
+	var a0 int
+	a0 = dec.Int()
+	var a1 int
+	a1 = dec.Int()
+
+	// TODO(rgrandl): The deferred function above will recover from panics in the
+	// user code: fix this.
+	// Call the local method.
+	r0, appErr := s.impl.Add(ctx, a0, a1)
+
+	// Encode the results.
+	enc := codegen.NewEncoder()
+	enc.Int(r0)
+	enc.Error(appErr)
+	return enc.Data(), nil
+}
+
+func (s calc_server_stub) div(ctx context.Context, args []byte) (res []byte, err error) {
+	// Catch and return any panics detected during encoding/decoding/rpc.
+	defer func() {
+		if err == nil {
+			err = codegen.CatchPanics(recover())
+		}
+	}()
+
+	// Decode arguments.
+	dec := codegen.NewDecoder(args)
+	var a0 int
+	a0 = dec.Int()
+	var a1 int
+	a1 = dec.Int()
+
+	// TODO(rgrandl): The deferred function above will recover from panics in the
+	// user code: fix this.
+	// Call the local method.
+	r0, appErr := s.impl.Div(ctx, a0, a1)
+
+	// Encode the results.
+	enc := codegen.NewEncoder()
+	enc.Int(r0)
+	enc.Error(appErr)
+	return enc.Data(), nil
+}
+
+type front_server_stub struct {
+	impl    Front
+	addLoad func(key uint64, load float64)
+}
+
+// Check that front_server_stub implements the codegen.Server interface.
+var _ codegen.Server = (*front_server_stub)(nil)
+
+// GetStubFn implements the codegen.Server interface.
+func (s front_server_stub) GetStubFn(method string) func(ctx context.Context, args []byte) ([]byte, error) {
+	switch method {
+	case "Double":
+		return s.double
+	default:
+		return nil
+	}
+}
+
+func (s front_server_stub) double(ctx context.Context, args []byte) (res []byte, err error) {
+	// Catch and return any panics detected during encoding/decoding/rpc.
+	defer func() {
+		if err == nil {
+			err = codegen.CatchPanics(recover())
+		}
+	}()
+
+	// Decode arguments.
+	dec := codegen.NewDecoder(args)
+	var a0 int
+	a0 = dec.Int()
+
+	// TODO(rgrandl): The deferred function above will recover from panics in the
+	// user code: fix this.
+	// Call the local method.
+	r0, appErr := s.impl.Double(ctx, a0)
+
+	// Encode the results.
+	enc := codegen.NewEncoder()
+	enc.Int(r0)
+	enc.Error(appErr)
+	return enc.Data(), nil
+}
+
+// Reflect stub implementations.
+
+type calc_reflect_stub struct {
+	caller func(string, context.Context, []any, []any) error
+}
+
+// Check that calc_reflect_stub implements the Calc interface.
+var _ Calc = (*calc_reflect_stub)(nil)
+
+func (s calc_reflect_stub) Add(ctx context.Context, a0 int, a1 int) (r0 int, err error) {
+	err = s.caller("Add", ctx, []any{a0, a1}, []any{&r0})
+	return
+}
+
+func (s calc_reflect_stub) Div(ctx context.Context, a0 int, a1 int) (r0 int, err error) {
+	err = s.caller("Div", ctx, []any{a0, a1}, []any{&r0})
+	return
+}
+
+type front_reflect_stub struct {
+	caller func(string, context.Context, []any, []any) error
+}
+
+// Check that front_reflect_stub implements the Front interface.
+var _ Front = (*front_reflect_stub)(nil)
+
+func (s front_reflect_stub) Double(ctx context.Context, a0 int) (r0 int, err error) {
+	err = s.caller("Double", ctx, []any{a0}, []any{&r0})
+	return
+}
@@ -30,7 +30,7 @@ func init() {
 		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
 			return pingPonger_reflect_stub{caller: caller}
 		},
-		RefData: "",
+		RefData: "⟦a9399e3f:MxSchema:github.com/sh3lk/mx/mxtest/internal/protos/PingPonger→[{\"name\":\"Ping\",\"args\":[{\"kind\":\"pointer\",\"elem\":{\"kind\":\"proto\",\"name\":\"protos.Ping\"}}],\"results\":[{\"kind\":\"pointer\",\"elem\":{\"kind\":\"proto\",\"name\":\"protos.Pong\"}}]}]⟧\n",
 	})
 }
 
@@ -111,8 +111,9 @@ func (s pingPonger_client_stub) Ping(ctx context.Context, a0 *Ping) (r0 *Pong, e
 	}()
 
 	// Encode arguments.
-	enc := codegen.NewEncoder()
-	mx_enc_ptr_Ping_53efca65(enc, a0)
+	enc := codegen.GetEncoder()
+	defer codegen.PutEncoder(enc)
+	mx_enc_ptr_Ping_db73332e(enc, a0)
 	var shardKey uint64
 
 	// Call the remote method.
@@ -126,8 +127,8 @@ func (s pingPonger_client_stub) Ping(ctx context.Context, a0 *Ping) (r0 *Pong, e
 	}
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
-	r0 = mx_dec_ptr_Pong_10ae1a4e(dec)
+	dec := codegen.NewZeroCopyDecoder(results)
+	r0 = mx_dec_ptr_Pong_84392ba3(dec)
 	err = dec.Error()
 	return
 }
@@ -135,10 +136,10 @@ func (s pingPonger_client_stub) Ping(ctx context.Context, a0 *Ping) (r0 *Pong, e
 // Note that "mx generate" will always generate the error message below.
 // Everything is okay. The error message is only relevant if you see it when
 // you run "go build" or "go run".
-var _ codegen.LatestVersion = codegen.Version[[0][24]struct{}](`
+var _ codegen.LatestVersion = codegen.Version[[0][25]struct{}](`
 
-ERROR: You generated this file with 'mx generate' v0.24.7-0.20250401231336-b01860e0378a+dirty (codegen
-version v0.24.0). The generated code is incompatible with the version of the
+ERROR: You generated this file with 'mx generate' (devel) (codegen
+version v0.25.0). The generated code is incompatible with the version of the
 github.com/sh3lk/mx module that you're using. The mx module
 version can be found in your go.mod file or by running the following command.
 
@@ -186,7 +187,7 @@ func (s pingPonger_server_stub) ping(ctx context.Context, args []byte) (res []by
 	// Decode arguments.
 	dec := codegen.NewDecoder(args)
 	var a0 *Ping
-	a0 = mx_dec_ptr_Ping_53efca65(dec)
+	a0 = mx_dec_ptr_Ping_db73332e(dec)
 
 	// TODO(rgrandl): The deferred function above will recover from panics in the
 	// user code: fix this.
@@ -195,7 +196,7 @@ func (s pingPonger_server_stub) ping(ctx context.Context, args []byte) (res []by
 
 	// Encode the results.
 	enc := codegen.NewEncoder()
-	mx_enc_ptr_Pong_10ae1a4e(enc, r0)
+	mx_enc_ptr_Pong_84392ba3(enc, r0)
 	enc.Error(appErr)
 	return enc.Data(), nil
 }
@@ -216,7 +217,7 @@ func (s pingPonger_reflect_stub) Ping(ctx context.Context, a0 *Ping) (r0 *Pong,
 
 // Encoding/decoding implementations.
 
-func mx_enc_ptr_Ping_53efca65(enc *codegen.Encoder, arg *Ping) {
+func mx_enc_ptr_Ping_db73332e(enc *codegen.Encoder, arg *Ping) {
 	if arg == nil {
 		enc.Bool(false)
 	} else {
@@ -225,7 +226,7 @@ func mx_enc_ptr_Ping_53efca65(enc *codegen.Encoder, arg *Ping) {
 	}
 }
 
-func mx_dec_ptr_Ping_53efca65(dec *codegen.Decoder) *Ping {
+func mx_dec_ptr_Ping_db73332e(dec *codegen.Decoder) *Ping {
 	if !dec.Bool() {
 		return nil
 	}
@@ -234,7 +235,7 @@ func mx_dec_ptr_Ping_53efca65(dec *codegen.Decoder) *Ping {
 	return &res
 }
 
-func mx_enc_ptr_Pong_10ae1a4e(enc *codegen.Encoder, arg *Pong) {
+func mx_enc_ptr_Pong_84392ba3(enc *codegen.Encoder, arg *Pong) {
 	if arg == nil {
 		enc.Bool(false)
 	} else {
@@ -243,7 +244,7 @@ func mx_enc_ptr_Pong_10ae1a4e(enc *codegen.Encoder, arg *Pong) {
 	}
 }
 
-func mx_dec_ptr_Pong_10ae1a4e(dec *codegen.Decoder) *Pong {
+func mx_dec_ptr_Pong_84392ba3(dec *codegen.Decoder) *Pong {
 	if !dec.Bool() {
 		return nil
 	}
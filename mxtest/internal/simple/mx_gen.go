@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"github.com/sh3lk/mx"
+	"github.com/sh3lk/mx/mxtest/mock"
 	"github.com/sh3lk/mx/runtime/codegen"
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/trace"
@@ -32,7 +33,8 @@ func init() {
 		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
 			return destination_reflect_stub{caller: caller}
 		},
-		RefData: "",
+		GatewayFn: func(impl any) []codegen.GatewayMethod { return destination_gateway_methods(impl.(Destination)) },
+		RefData:   "⟦5cd801ba:MxSchema:github.com/sh3lk/mx/mxtest/internal/simple/Destination→[{\"name\":\"GetAll\",\"args\":[{\"kind\":\"string\"}],\"results\":[{\"kind\":\"slice\",\"elem\":{\"kind\":\"string\"}}]},{\"name\":\"GetMetadata\",\"results\":[{\"kind\":\"map\",\"key\":{\"kind\":\"string\"},\"elem\":{\"kind\":\"string\"}}]},{\"name\":\"Getpid\",\"results\":[{\"kind\":\"int64\"}]},{\"name\":\"Record\",\"args\":[{\"kind\":\"string\"},{\"kind\":\"string\"}]},{\"name\":\"RoutedRecord\",\"args\":[{\"kind\":\"string\"},{\"kind\":\"string\"}]},{\"name\":\"UpdateMetadata\"}]⟧\n",
 	})
 	codegen.Register(codegen.Registration{
 		Name:      "github.com/sh3lk/mx/mxtest/internal/simple/Server",
@@ -51,7 +53,8 @@ func init() {
 		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
 			return server_reflect_stub{caller: caller}
 		},
-		RefData: "⟦1e2dce71:wEaVeRlIsTeNeRs:github.com/sh3lk/mx/mxtest/internal/simple/Server→hello⟧\n",
+		GatewayFn: func(impl any) []codegen.GatewayMethod { return server_gateway_methods(impl.(Server)) },
+		RefData:   "⟦d1f1bd96:wEaVeRlIsTeNeRs:github.com/sh3lk/mx/mxtest/internal/simple/Server→hello⟧\n⟦c7e655ca:MxSchema:github.com/sh3lk/mx/mxtest/internal/simple/Server→[{\"name\":\"Address\",\"results\":[{\"kind\":\"string\"}]},{\"name\":\"ProxyAddress\",\"results\":[{\"kind\":\"string\"}]},{\"name\":\"Shutdown\"}]⟧\n",
 	})
 	codegen.Register(codegen.Registration{
 		Name:    "github.com/sh3lk/mx/mxtest/internal/simple/Source",
@@ -70,7 +73,8 @@ func init() {
 		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
 			return source_reflect_stub{caller: caller}
 		},
-		RefData: "⟦bf914175:MxEdge:github.com/sh3lk/mx/mxtest/internal/simple/Source→github.com/sh3lk/mx/mxtest/internal/simple/Destination⟧\n",
+		GatewayFn: func(impl any) []codegen.GatewayMethod { return source_gateway_methods(impl.(Source)) },
+		RefData:   "⟦d7368b6a:MxEdge:github.com/sh3lk/mx/mxtest/internal/simple/Source→github.com/sh3lk/mx/mxtest/internal/simple/Destination⟧\n⟦97cb1f94:MxSchema:github.com/sh3lk/mx/mxtest/internal/simple/Source→[{\"name\":\"Emit\",\"args\":[{\"kind\":\"string\"},{\"kind\":\"string\"}]}]⟧\n",
 	})
 }
 
@@ -388,7 +392,8 @@ func (s destination_client_stub) GetAll(ctx context.Context, a0 string) (r0 []st
 	// Preallocate a buffer of the right size.
 	size := 0
 	size += (4 + len(a0))
-	enc := codegen.NewEncoder()
+	enc := codegen.GetEncoder()
+	defer codegen.PutEncoder(enc)
 	enc.Reset(size)
 
 	// Encode arguments.
@@ -406,7 +411,7 @@ func (s destination_client_stub) GetAll(ctx context.Context, a0 string) (r0 []st
 	}
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewZeroCopyDecoder(results)
 	r0 = mx_dec_slice_string_4af10117(dec)
 	err = dec.Error()
 	return
@@ -453,7 +458,7 @@ func (s destination_client_stub) GetMetadata(ctx context.Context) (r0 map[string
 	}
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewZeroCopyDecoder(results)
 	r0 = mx_dec_map_string_string_219dd46d(dec)
 	err = dec.Error()
 	return
@@ -500,7 +505,7 @@ func (s destination_client_stub) Getpid(ctx context.Context) (r0 int, err error)
 	}
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewZeroCopyDecoder(results)
 	r0 = dec.Int()
 	err = dec.Error()
 	return
@@ -539,7 +544,8 @@ func (s destination_client_stub) Record(ctx context.Context, a0 string, a1 strin
 	size := 0
 	size += (4 + len(a0))
 	size += (4 + len(a1))
-	enc := codegen.NewEncoder()
+	enc := codegen.GetEncoder()
+	defer codegen.PutEncoder(enc)
 	enc.Reset(size)
 
 	// Encode arguments.
@@ -558,7 +564,7 @@ func (s destination_client_stub) Record(ctx context.Context, a0 string, a1 strin
 	}
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewZeroCopyDecoder(results)
 	err = dec.Error()
 	return
 }
@@ -596,7 +602,8 @@ func (s destination_client_stub) RoutedRecord(ctx context.Context, a0 string, a1
 	size := 0
 	size += (4 + len(a0))
 	size += (4 + len(a1))
-	enc := codegen.NewEncoder()
+	enc := codegen.GetEncoder()
+	defer codegen.PutEncoder(enc)
 	enc.Reset(size)
 
 	// Encode arguments.
@@ -618,7 +625,7 @@ func (s destination_client_stub) RoutedRecord(ctx context.Context, a0 string, a1
 	}
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewZeroCopyDecoder(results)
 	err = dec.Error()
 	return
 }
@@ -664,7 +671,7 @@ func (s destination_client_stub) UpdateMetadata(ctx context.Context) (err error)
 	}
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewZeroCopyDecoder(results)
 	err = dec.Error()
 	return
 }
@@ -720,7 +727,7 @@ func (s server_client_stub) Address(ctx context.Context) (r0 string, err error)
 	}
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewZeroCopyDecoder(results)
 	r0 = dec.String()
 	err = dec.Error()
 	return
@@ -767,7 +774,7 @@ func (s server_client_stub) ProxyAddress(ctx context.Context) (r0 string, err er
 	}
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewZeroCopyDecoder(results)
 	r0 = dec.String()
 	err = dec.Error()
 	return
@@ -814,7 +821,7 @@ func (s server_client_stub) Shutdown(ctx context.Context) (err error) {
 	}
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewZeroCopyDecoder(results)
 	err = dec.Error()
 	return
 }
@@ -860,7 +867,8 @@ func (s source_client_stub) Emit(ctx context.Context, a0 string, a1 string) (err
 	size := 0
 	size += (4 + len(a0))
 	size += (4 + len(a1))
-	enc := codegen.NewEncoder()
+	enc := codegen.GetEncoder()
+	defer codegen.PutEncoder(enc)
 	enc.Reset(size)
 
 	// Encode arguments.
@@ -879,7 +887,7 @@ func (s source_client_stub) Emit(ctx context.Context, a0 string, a1 string) (err
 	}
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewZeroCopyDecoder(results)
 	err = dec.Error()
 	return
 }
@@ -887,10 +895,10 @@ func (s source_client_stub) Emit(ctx context.Context, a0 string, a1 string) (err
 // Note that "mx generate" will always generate the error message below.
 // Everything is okay. The error message is only relevant if you see it when
 // you run "go build" or "go run".
-var _ codegen.LatestVersion = codegen.Version[[0][24]struct{}](`
+var _ codegen.LatestVersion = codegen.Version[[0][25]struct{}](`
 
-ERROR: You generated this file with 'mx generate' v0.24.7-0.20250401231336-b01860e0378a+dirty (codegen
-version v0.24.0). The generated code is incompatible with the version of the
+ERROR: You generated this file with 'mx generate' (devel) (codegen
+version v0.25.0). The generated code is incompatible with the version of the
 github.com/sh3lk/mx module that you're using. The mx module
 version can be found in your go.mod file or by running the following command.
 
@@ -1273,6 +1281,271 @@ func (s source_reflect_stub) Emit(ctx context.Context, a0 string, a1 string) (er
 	return
 }
 
+// Gateway implementations.
+
+func destination_gateway_methods(impl Destination) []codegen.GatewayMethod {
+	return []codegen.GatewayMethod{
+		{
+			Name:   "GetAll",
+			Args:   `{"properties":{"file":{"type":"string"}},"type":"object"}`,
+			Result: `{"items":{"type":"string"},"type":"array"}`,
+			Call: func(ctx context.Context, data []byte) (any, error) {
+				var args struct {
+					A0 string `json:"file"`
+				}
+				if err := codegen.DecodeGatewayArgs(data, &args); err != nil {
+					return nil, err
+				}
+				r0, err := impl.GetAll(ctx, args.A0)
+				return r0, err
+			},
+		},
+		{
+			Name:   "GetMetadata",
+			Args:   `{"properties":{},"type":"object"}`,
+			Result: `{"additionalProperties":{"type":"string"},"type":"object"}`,
+			Call: func(ctx context.Context, data []byte) (any, error) {
+				var args struct {
+				}
+				if err := codegen.DecodeGatewayArgs(data, &args); err != nil {
+					return nil, err
+				}
+				r0, err := impl.GetMetadata(ctx)
+				return r0, err
+			},
+		},
+		{
+			Name:   "Getpid",
+			Args:   `{"properties":{},"type":"object"}`,
+			Result: `{"type":"integer"}`,
+			Call: func(ctx context.Context, data []byte) (any, error) {
+				var args struct {
+				}
+				if err := codegen.DecodeGatewayArgs(data, &args); err != nil {
+					return nil, err
+				}
+				r0, err := impl.Getpid(ctx)
+				return r0, err
+			},
+		},
+		{
+			Name:   "Record",
+			Args:   `{"properties":{"file":{"type":"string"},"msg":{"type":"string"}},"type":"object"}`,
+			Result: `{"type":"null"}`,
+			Call: func(ctx context.Context, data []byte) (any, error) {
+				var args struct {
+					A0 string `json:"file"`
+					A1 string `json:"msg"`
+				}
+				if err := codegen.DecodeGatewayArgs(data, &args); err != nil {
+					return nil, err
+				}
+				return nil, impl.Record(ctx, args.A0, args.A1)
+			},
+		},
+		{
+			Name:   "RoutedRecord",
+			Args:   `{"properties":{"file":{"type":"string"},"msg":{"type":"string"}},"type":"object"}`,
+			Result: `{"type":"null"}`,
+			Call: func(ctx context.Context, data []byte) (any, error) {
+				var args struct {
+					A0 string `json:"file"`
+					A1 string `json:"msg"`
+				}
+				if err := codegen.DecodeGatewayArgs(data, &args); err != nil {
+					return nil, err
+				}
+				return nil, impl.RoutedRecord(ctx, args.A0, args.A1)
+			},
+		},
+		{
+			Name:   "UpdateMetadata",
+			Args:   `{"properties":{},"type":"object"}`,
+			Result: `{"type":"null"}`,
+			Call: func(ctx context.Context, data []byte) (any, error) {
+				var args struct {
+				}
+				if err := codegen.DecodeGatewayArgs(data, &args); err != nil {
+					return nil, err
+				}
+				return nil, impl.UpdateMetadata(ctx)
+			},
+		},
+	}
+}
+
+func server_gateway_methods(impl Server) []codegen.GatewayMethod {
+	return []codegen.GatewayMethod{
+		{
+			Name:   "Address",
+			Args:   `{"properties":{},"type":"object"}`,
+			Result: `{"type":"string"}`,
+			Call: func(ctx context.Context, data []byte) (any, error) {
+				var args struct {
+				}
+				if err := codegen.DecodeGatewayArgs(data, &args); err != nil {
+					return nil, err
+				}
+				r0, err := impl.Address(ctx)
+				return r0, err
+			},
+		},
+		{
+			Name:   "ProxyAddress",
+			Args:   `{"properties":{},"type":"object"}`,
+			Result: `{"type":"string"}`,
+			Call: func(ctx context.Context, data []byte) (any, error) {
+				var args struct {
+				}
+				if err := codegen.DecodeGatewayArgs(data, &args); err != nil {
+					return nil, err
+				}
+				r0, err := impl.ProxyAddress(ctx)
+				return r0, err
+			},
+		},
+		{
+			Name:   "Shutdown",
+			Args:   `{"properties":{},"type":"object"}`,
+			Result: `{"type":"null"}`,
+			Call: func(ctx context.Context, data []byte) (any, error) {
+				var args struct {
+				}
+				if err := codegen.DecodeGatewayArgs(data, &args); err != nil {
+					return nil, err
+				}
+				return nil, impl.Shutdown(ctx)
+			},
+		},
+	}
+}
+
+func source_gateway_methods(impl Source) []codegen.GatewayMethod {
+	return []codegen.GatewayMethod{
+		{
+			Name:   "Emit",
+			Args:   `{"properties":{"file":{"type":"string"},"msg":{"type":"string"}},"type":"object"}`,
+			Result: `{"type":"null"}`,
+			Call: func(ctx context.Context, data []byte) (any, error) {
+				var args struct {
+					A0 string `json:"file"`
+					A1 string `json:"msg"`
+				}
+				if err := codegen.DecodeGatewayArgs(data, &args); err != nil {
+					return nil, err
+				}
+				return nil, impl.Emit(ctx, args.A0, args.A1)
+			},
+		},
+	}
+}
+
+// Mock implementations.
+
+// MockDestination is a mock implementation of the Destination component, generated by
+// "mx generate -mocks". See the mxtest/mock package for details.
+type MockDestination struct {
+	mock.Mock
+}
+
+var _ Destination = (*MockDestination)(nil)
+
+func (m *MockDestination) GetAll(ctx context.Context, a0 string) (r0 []string, err error) {
+	res, ok := m.Mock.Called("GetAll", 2, a0)
+	if impl, spied := m.Mock.Spied().(Destination); !ok && spied {
+		return impl.GetAll(ctx, a0)
+	}
+	return mock.Value[[]string](res, 0), res.Err()
+}
+
+func (m *MockDestination) GetMetadata(ctx context.Context) (r0 map[string]string, err error) {
+	res, ok := m.Mock.Called("GetMetadata", 2)
+	if impl, spied := m.Mock.Spied().(Destination); !ok && spied {
+		return impl.GetMetadata(ctx)
+	}
+	return mock.Value[map[string]string](res, 0), res.Err()
+}
+
+func (m *MockDestination) Getpid(ctx context.Context) (r0 int, err error) {
+	res, ok := m.Mock.Called("Getpid", 2)
+	if impl, spied := m.Mock.Spied().(Destination); !ok && spied {
+		return impl.Getpid(ctx)
+	}
+	return mock.Value[int](res, 0), res.Err()
+}
+
+func (m *MockDestination) Record(ctx context.Context, a0 string, a1 string) (err error) {
+	res, ok := m.Mock.Called("Record", 1, a0, a1)
+	if impl, spied := m.Mock.Spied().(Destination); !ok && spied {
+		return impl.Record(ctx, a0, a1)
+	}
+	return res.Err()
+}
+
+func (m *MockDestination) RoutedRecord(ctx context.Context, a0 string, a1 string) (err error) {
+	res, ok := m.Mock.Called("RoutedRecord", 1, a0, a1)
+	if impl, spied := m.Mock.Spied().(Destination); !ok && spied {
+		return impl.RoutedRecord(ctx, a0, a1)
+	}
+	return res.Err()
+}
+
+func (m *MockDestination) UpdateMetadata(ctx context.Context) (err error) {
+	res, ok := m.Mock.Called("UpdateMetadata", 1)
+	if impl, spied := m.Mock.Spied().(Destination); !ok && spied {
+		return impl.UpdateMetadata(ctx)
+	}
+	return res.Err()
+}
+
+// MockServer is a mock implementation of the Server component, generated by
+// "mx generate -mocks". See the mxtest/mock package for details.
+type MockServer struct {
+	mock.Mock
+}
+
+var _ Server = (*MockServer)(nil)
+
+func (m *MockServer) Address(ctx context.Context) (r0 string, err error) {
+	res, ok := m.Mock.Called("Address", 2)
+	if impl, spied := m.Mock.Spied().(Server); !ok && spied {
+		return impl.Address(ctx)
+	}
+	return mock.Value[string](res, 0), res.Err()
+}
+
+func (m *MockServer) ProxyAddress(ctx context.Context) (r0 string, err error) {
+	res, ok := m.Mock.Called("ProxyAddress", 2)
+	if impl, spied := m.Mock.Spied().(Server); !ok && spied {
+		return impl.ProxyAddress(ctx)
+	}
+	return mock.Value[string](res, 0), res.Err()
+}
+
+func (m *MockServer) Shutdown(ctx context.Context) (err error) {
+	res, ok := m.Mock.Called("Shutdown", 1)
+	if impl, spied := m.Mock.Spied().(Server); !ok && spied {
+		return impl.Shutdown(ctx)
+	}
+	return res.Err()
+}
+
+// MockSource is a mock implementation of the Source component, generated by
+// "mx generate -mocks". See the mxtest/mock package for details.
+type MockSource struct {
+	mock.Mock
+}
+
+var _ Source = (*MockSource)(nil)
+
+func (m *MockSource) Emit(ctx context.Context, a0 string, a1 string) (err error) {
+	res, ok := m.Mock.Called("Emit", 1, a0, a1)
+	if impl, spied := m.Mock.Spied().(Source); !ok && spied {
+		return impl.Emit(ctx, a0, a1)
+	}
+	return res.Err()
+}
+
 // Router methods.
 
 // _hashDestination returns a 64 bit hash of the provided value.
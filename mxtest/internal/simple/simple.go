@@ -32,7 +32,7 @@ import (
 	"github.com/sh3lk/mx/metadata"
 )
 
-//go:generate ../../../cmd/mx/mx generate
+//go:generate ../../../cmd/mx/mx generate -mocks -gateway
 
 type Source interface {
 	Emit(ctx context.Context, file, msg string) error
@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"reflect"
@@ -27,10 +28,12 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/sh3lk/mx"
 	"github.com/sh3lk/mx/internal/traceio"
 	"github.com/sh3lk/mx/metadata"
 	"github.com/sh3lk/mx/mxtest"
 	"github.com/sh3lk/mx/mxtest/internal/simple"
+	"github.com/sh3lk/mx/mxtest/mock"
 )
 
 func TestOneComponent(t *testing.T) {
@@ -135,6 +138,70 @@ func TestFake(t *testing.T) {
 	}
 }
 
+func TestMock(t *testing.T) {
+	for _, runner := range mxtest.AllRunners() {
+		dst := &simple.MockDestination{}
+		dst.On("Record", mock.Any, mock.Any).Return(nil)
+		dst.On("Record", "full", mock.Any).Return(fmt.Errorf("disk full"))
+		runner.Fakes = append(runner.Fakes, mxtest.Fake[simple.Destination](dst))
+		runner.Test(t, func(t *testing.T, src simple.Source) {
+			ctx := context.Background()
+			if err := src.Emit(ctx, "file", "msg"); err != nil {
+				t.Fatal(err)
+			}
+			if err := src.Emit(ctx, "full", "msg"); err == nil || !strings.Contains(err.Error(), "disk full") {
+				t.Fatalf("Emit: got %v, want disk full error", err)
+			}
+			dst.AssertCalled(t, "Record", "file", "msg")
+			dst.AssertCallCount(t, 2, "Record")
+			dst.AssertNotCalled(t, "GetAll")
+		})
+	}
+}
+
+func TestGateway(t *testing.T) {
+	for _, runner := range mxtest.AllRunners() {
+		runner.Test(t, func(t *testing.T, dst simple.Destination) {
+			// dst is a client of the Destination component, like the one
+			// returned by an mx.Ref, so calls made through the gateway are
+			// remote calls in the RPC and Multi runners.
+			gateway, err := mx.NewGateway(dst)
+			if err != nil {
+				t.Fatal(err)
+			}
+			server := httptest.NewServer(gateway)
+			defer server.Close()
+
+			post := func(method, body string) string {
+				t.Helper()
+				resp, err := http.Post(server.URL+"/"+method, "application/json", strings.NewReader(body))
+				if err != nil {
+					t.Fatal(err)
+				}
+				defer resp.Body.Close()
+				reply, err := io.ReadAll(resp.Body)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if resp.StatusCode != http.StatusOK {
+					t.Fatalf("%s: got status %d, want %d (reply %s)", method, resp.StatusCode, http.StatusOK, reply)
+				}
+				return string(reply)
+			}
+
+			file := filepath.Join(t.TempDir(), "gateway")
+			for _, msg := range []string{"a", "b"} {
+				if got, want := post("Record", fmt.Sprintf(`{"file": %q, "msg": %q}`, file, msg)), "null"; got != want {
+					t.Fatalf("Record: got %s, want %s", got, want)
+				}
+			}
+			if got, want := post("GetAll", fmt.Sprintf(`{"file": %q}`, file)), `["a","b"]`; got != want {
+				t.Fatalf("GetAll: got %s, want %s", got, want)
+			}
+		})
+	}
+}
+
 func TestTwoComponents(t *testing.T) {
 	// Add a list of items to a component (dst) from another component (src). Verify that
 	// dst updates the state accordingly.
@@ -0,0 +1,808 @@
+// Code generated by "mx generate". DO NOT EDIT.
+//go:build !ignoreMXGen
+
+package streams
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/sh3lk/mx"
+	"github.com/sh3lk/mx/runtime/codegen"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"reflect"
+)
+
+func init() {
+	codegen.Register(codegen.Registration{
+		Name:      "github.com/sh3lk/mx/mxtest/internal/streams/Numbers",
+		Iface:     reflect.TypeOf((*Numbers)(nil)).Elem(),
+		Impl:      reflect.TypeOf(numbers{}),
+		Streaming: []int{0, 1, 2, 3},
+		LocalStubFn: func(impl any, caller string, tracer trace.Tracer) any {
+			return numbers_local_stub{impl: impl.(Numbers), tracer: tracer, failMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/sh3lk/mx/mxtest/internal/streams/Numbers", Method: "Fail", Remote: false, Generated: true}), pairsMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/sh3lk/mx/mxtest/internal/streams/Numbers", Method: "Pairs", Remote: false, Generated: true}), seqMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/sh3lk/mx/mxtest/internal/streams/Numbers", Method: "Seq", Remote: false, Generated: true}), sumMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/sh3lk/mx/mxtest/internal/streams/Numbers", Method: "Sum", Remote: false, Generated: true})}
+		},
+		ClientStubFn: func(stub codegen.Stub, caller string) any {
+			return numbers_client_stub{stub: stub, failMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/sh3lk/mx/mxtest/internal/streams/Numbers", Method: "Fail", Remote: true, Generated: true}), pairsMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/sh3lk/mx/mxtest/internal/streams/Numbers", Method: "Pairs", Remote: true, Generated: true}), seqMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/sh3lk/mx/mxtest/internal/streams/Numbers", Method: "Seq", Remote: true, Generated: true}), sumMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/sh3lk/mx/mxtest/internal/streams/Numbers", Method: "Sum", Remote: true, Generated: true})}
+		},
+		ServerStubFn: func(impl any, addLoad func(uint64, float64)) codegen.Server {
+			return numbers_server_stub{impl: impl.(Numbers), addLoad: addLoad}
+		},
+		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
+			return numbers_reflect_stub{caller: caller}
+		},
+		RefData: "⟦67967020:MxSchema:github.com/sh3lk/mx/mxtest/internal/streams/Numbers→null⟧\n",
+	})
+	codegen.Register(codegen.Registration{
+		Name:  "github.com/sh3lk/mx/mxtest/internal/streams/Relay",
+		Iface: reflect.TypeOf((*Relay)(nil)).Elem(),
+		Impl:  reflect.TypeOf(relay{}),
+		LocalStubFn: func(impl any, caller string, tracer trace.Tracer) any {
+			return relay_local_stub{impl: impl.(Relay), tracer: tracer, sumSeqMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/sh3lk/mx/mxtest/internal/streams/Relay", Method: "SumSeq", Remote: false, Generated: true})}
+		},
+		ClientStubFn: func(stub codegen.Stub, caller string) any {
+			return relay_client_stub{stub: stub, sumSeqMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/sh3lk/mx/mxtest/internal/streams/Relay", Method: "SumSeq", Remote: true, Generated: true})}
+		},
+		ServerStubFn: func(impl any, addLoad func(uint64, float64)) codegen.Server {
+			return relay_server_stub{impl: impl.(Relay), addLoad: addLoad}
+		},
+		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
+			return relay_reflect_stub{caller: caller}
+		},
+		RefData: "⟦58d6ec77:MxEdge:github.com/sh3lk/mx/mxtest/internal/streams/Relay→github.com/sh3lk/mx/mxtest/internal/streams/Numbers⟧\n⟦9af7a938:MxSchema:github.com/sh3lk/mx/mxtest/internal/streams/Relay→[{\"name\":\"SumSeq\",\"args\":[{\"kind\":\"int64\"}],\"results\":[{\"kind\":\"int64\"}]}]⟧\n",
+	})
+}
+
+// mx.InstanceOf checks.
+var _ mx.InstanceOf[Numbers] = (*numbers)(nil)
+var _ mx.InstanceOf[Relay] = (*relay)(nil)
+
+// mx.Router checks.
+var _ mx.Unrouted = (*numbers)(nil)
+var _ mx.Unrouted = (*relay)(nil)
+
+// Local stub implementations.
+
+type numbers_local_stub struct {
+	impl         Numbers
+	tracer       trace.Tracer
+	failMetrics  *codegen.MethodMetrics
+	pairsMetrics *codegen.MethodMetrics
+	seqMetrics   *codegen.MethodMetrics
+	sumMetrics   *codegen.MethodMetrics
+}
+
+// Check that numbers_local_stub implements the Numbers interface.
+var _ Numbers = (*numbers_local_stub)(nil)
+
+func (s numbers_local_stub) Fail(ctx context.Context, a0 int) (r0 mx.Stream[int], err error) {
+	// Update metrics.
+	begin := s.failMetrics.Begin()
+	defer func() { s.failMetrics.End(begin, err != nil, 0, 0) }()
+	span := trace.SpanFromContext(ctx)
+	if span.SpanContext().IsValid() {
+		// Create a child span for this method.
+		ctx, span = s.tracer.Start(ctx, "streams.Numbers.Fail", trace.WithSpanKind(trace.SpanKindInternal))
+		defer func() {
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			}
+			span.End()
+		}()
+	}
+
+	return s.impl.Fail(ctx, a0)
+}
+
+func (s numbers_local_stub) Pairs(ctx context.Context, a0 int, a1 string) (r0 mx.Stream[Pair], err error) {
+	// Update metrics.
+	begin := s.pairsMetrics.Begin()
+	defer func() { s.pairsMetrics.End(begin, err != nil, 0, 0) }()
+	span := trace.SpanFromContext(ctx)
+	if span.SpanContext().IsValid() {
+		// Create a child span for this method.
+		ctx, span = s.tracer.Start(ctx, "streams.Numbers.Pairs", trace.WithSpanKind(trace.SpanKindInternal))
+		defer func() {
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			}
+			span.End()
+		}()
+	}
+
+	return s.impl.Pairs(ctx, a0, a1)
+}
+
+func (s numbers_local_stub) Seq(ctx context.Context, a0 int) (r0 mx.Stream[int], err error) {
+	// Update metrics.
+	begin := s.seqMetrics.Begin()
+	defer func() { s.seqMetrics.End(begin, err != nil, 0, 0) }()
+	span := trace.SpanFromContext(ctx)
+	if span.SpanContext().IsValid() {
+		// Create a child span for this method.
+		ctx, span = s.tracer.Start(ctx, "streams.Numbers.Seq", trace.WithSpanKind(trace.SpanKindInternal))
+		defer func() {
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			}
+			span.End()
+		}()
+	}
+
+	return s.impl.Seq(ctx, a0)
+}
+
+func (s numbers_local_stub) Sum(ctx context.Context, a0 mx.Stream[int]) (r0 int, err error) {
+	// Update metrics.
+	begin := s.sumMetrics.Begin()
+	defer func() { s.sumMetrics.End(begin, err != nil, 0, 0) }()
+	span := trace.SpanFromContext(ctx)
+	if span.SpanContext().IsValid() {
+		// Create a child span for this method.
+		ctx, span = s.tracer.Start(ctx, "streams.Numbers.Sum", trace.WithSpanKind(trace.SpanKindInternal))
+		defer func() {
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			}
+			span.End()
+		}()
+	}
+
+	return s.impl.Sum(ctx, a0)
+}
+
+type relay_local_stub struct {
+	impl          Relay
+	tracer        trace.Tracer
+	sumSeqMetrics *codegen.MethodMetrics
+}
+
+// Check that relay_local_stub implements the Relay interface.
+var _ Relay = (*relay_local_stub)(nil)
+
+func (s relay_local_stub) SumSeq(ctx context.Context, a0 int) (r0 int, err error) {
+	// Update metrics.
+	begin := s.sumSeqMetrics.Begin()
+	defer func() { s.sumSeqMetrics.End(begin, err != nil, 0, 0) }()
+	span := trace.SpanFromContext(ctx)
+	if span.SpanContext().IsValid() {
+		// Create a child span for this method.
+		ctx, span = s.tracer.Start(ctx, "streams.Relay.SumSeq", trace.WithSpanKind(trace.SpanKindInternal))
+		defer func() {
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			}
+			span.End()
+		}()
+	}
+
+	return s.impl.SumSeq(ctx, a0)
+}
+
+// Client stub implementations.
+
+type numbers_client_stub struct {
+	stub         codegen.Stub
+	failMetrics  *codegen.MethodMetrics
+	pairsMetrics *codegen.MethodMetrics
+	seqMetrics   *codegen.MethodMetrics
+	sumMetrics   *codegen.MethodMetrics
+}
+
+// Check that numbers_client_stub implements the Numbers interface.
+var _ Numbers = (*numbers_client_stub)(nil)
+
+func (s numbers_client_stub) Fail(ctx context.Context, a0 int) (r0 mx.Stream[int], err error) {
+	// Update metrics.
+	var requestBytes, replyBytes int
+	begin := s.failMetrics.Begin()
+	defer func() { s.failMetrics.End(begin, err != nil, requestBytes, replyBytes) }()
+
+	span := trace.SpanFromContext(ctx)
+	if span.SpanContext().IsValid() {
+		// Create a child span for this method.
+		ctx, span = s.stub.Tracer().Start(ctx, "streams.Numbers.Fail", trace.WithSpanKind(trace.SpanKindClient))
+	}
+
+	defer func() {
+		// Catch and return any panics detected during encoding/decoding/rpc.
+		if err == nil {
+			err = codegen.CatchPanics(recover())
+			if err != nil {
+				err = errors.Join(mx.RemoteCallError, err)
+			}
+		}
+
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+
+	}()
+
+	// Preallocate a buffer of the right size.
+	size := 0
+	size += 8
+	enc := codegen.GetEncoder()
+	defer codegen.PutEncoder(enc)
+	enc.Reset(size)
+
+	// Encode arguments.
+	enc.Int(a0)
+	var shardKey uint64
+
+	// Call the remote method.
+	requestBytes = len(enc.Data())
+	var in codegen.ByteStream
+	var results []byte
+	var out codegen.ByteStream
+	results, out, err = s.stub.RunStream(ctx, 0, enc.Data(), in, shardKey)
+	replyBytes = len(results)
+	if err != nil {
+		err = errors.Join(mx.RemoteCallError, err)
+		return
+	}
+	defer func() {
+		if r0 == nil {
+			out.Close()
+		}
+	}()
+
+	// Decode the results.
+	dec := codegen.NewZeroCopyDecoder(results)
+	err = dec.Error()
+	if err != nil {
+		return
+	}
+	r0 = codegen.DecodeStream[int](out, func(dec *codegen.Decoder) (v int) {
+		v = dec.Int()
+		return
+	})
+	return
+}
+
+func (s numbers_client_stub) Pairs(ctx context.Context, a0 int, a1 string) (r0 mx.Stream[Pair], err error) {
+	// Update metrics.
+	var requestBytes, replyBytes int
+	begin := s.pairsMetrics.Begin()
+	defer func() { s.pairsMetrics.End(begin, err != nil, requestBytes, replyBytes) }()
+
+	span := trace.SpanFromContext(ctx)
+	if span.SpanContext().IsValid() {
+		// Create a child span for this method.
+		ctx, span = s.stub.Tracer().Start(ctx, "streams.Numbers.Pairs", trace.WithSpanKind(trace.SpanKindClient))
+	}
+
+	defer func() {
+		// Catch and return any panics detected during encoding/decoding/rpc.
+		if err == nil {
+			err = codegen.CatchPanics(recover())
+			if err != nil {
+				err = errors.Join(mx.RemoteCallError, err)
+			}
+		}
+
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+
+	}()
+
+	// Preallocate a buffer of the right size.
+	size := 0
+	size += 8
+	size += (4 + len(a1))
+	enc := codegen.GetEncoder()
+	defer codegen.PutEncoder(enc)
+	enc.Reset(size)
+
+	// Encode arguments.
+	enc.Int(a0)
+	enc.String(a1)
+	var shardKey uint64
+
+	// Call the remote method.
+	requestBytes = len(enc.Data())
+	var in codegen.ByteStream
+	var results []byte
+	var out codegen.ByteStream
+	results, out, err = s.stub.RunStream(ctx, 1, enc.Data(), in, shardKey)
+	replyBytes = len(results)
+	if err != nil {
+		err = errors.Join(mx.RemoteCallError, err)
+		return
+	}
+	defer func() {
+		if r0 == nil {
+			out.Close()
+		}
+	}()
+
+	// Decode the results.
+	dec := codegen.NewZeroCopyDecoder(results)
+	err = dec.Error()
+	if err != nil {
+		return
+	}
+	r0 = codegen.DecodeStream[Pair](out, func(dec *codegen.Decoder) (v Pair) {
+		(&v).MXUnmarshal(dec)
+		return
+	})
+	return
+}
+
+func (s numbers_client_stub) Seq(ctx context.Context, a0 int) (r0 mx.Stream[int], err error) {
+	// Update metrics.
+	var requestBytes, replyBytes int
+	begin := s.seqMetrics.Begin()
+	defer func() { s.seqMetrics.End(begin, err != nil, requestBytes, replyBytes) }()
+
+	span := trace.SpanFromContext(ctx)
+	if span.SpanContext().IsValid() {
+		// Create a child span for this method.
+		ctx, span = s.stub.Tracer().Start(ctx, "streams.Numbers.Seq", trace.WithSpanKind(trace.SpanKindClient))
+	}
+
+	defer func() {
+		// Catch and return any panics detected during encoding/decoding/rpc.
+		if err == nil {
+			err = codegen.CatchPanics(recover())
+			if err != nil {
+				err = errors.Join(mx.RemoteCallError, err)
+			}
+		}
+
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+
+	}()
+
+	// Preallocate a buffer of the right size.
+	size := 0
+	size += 8
+	enc := codegen.GetEncoder()
+	defer codegen.PutEncoder(enc)
+	enc.Reset(size)
+
+	// Encode arguments.
+	enc.Int(a0)
+	var shardKey uint64
+
+	// Call the remote method.
+	requestBytes = len(enc.Data())
+	var in codegen.ByteStream
+	var results []byte
+	var out codegen.ByteStream
+	results, out, err = s.stub.RunStream(ctx, 2, enc.Data(), in, shardKey)
+	replyBytes = len(results)
+	if err != nil {
+		err = errors.Join(mx.RemoteCallError, err)
+		return
+	}
+	defer func() {
+		if r0 == nil {
+			out.Close()
+		}
+	}()
+
+	// Decode the results.
+	dec := codegen.NewZeroCopyDecoder(results)
+	err = dec.Error()
+	if err != nil {
+		return
+	}
+	r0 = codegen.DecodeStream[int](out, func(dec *codegen.Decoder) (v int) {
+		v = dec.Int()
+		return
+	})
+	return
+}
+
+func (s numbers_client_stub) Sum(ctx context.Context, a0 mx.Stream[int]) (r0 int, err error) {
+	// Update metrics.
+	var requestBytes, replyBytes int
+	begin := s.sumMetrics.Begin()
+	defer func() { s.sumMetrics.End(begin, err != nil, requestBytes, replyBytes) }()
+
+	span := trace.SpanFromContext(ctx)
+	if span.SpanContext().IsValid() {
+		// Create a child span for this method.
+		ctx, span = s.stub.Tracer().Start(ctx, "streams.Numbers.Sum", trace.WithSpanKind(trace.SpanKindClient))
+	}
+
+	defer func() {
+		// Catch and return any panics detected during encoding/decoding/rpc.
+		if err == nil {
+			err = codegen.CatchPanics(recover())
+			if err != nil {
+				err = errors.Join(mx.RemoteCallError, err)
+			}
+		}
+
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+
+	}()
+
+	var shardKey uint64
+
+	// Call the remote method.
+	var in codegen.ByteStream = codegen.EmptyStream()
+	if a0 != nil {
+		in = codegen.EncodeStream[int](a0, func(enc *codegen.Encoder, v int) { enc.Int(v) })
+	}
+	var results []byte
+	var out codegen.ByteStream
+	results, out, err = s.stub.RunStream(ctx, 3, nil, in, shardKey)
+	replyBytes = len(results)
+	if err != nil {
+		err = errors.Join(mx.RemoteCallError, err)
+		return
+	}
+	defer out.Close()
+
+	// Decode the results.
+	dec := codegen.NewZeroCopyDecoder(results)
+	r0 = dec.Int()
+	err = dec.Error()
+	return
+}
+
+type relay_client_stub struct {
+	stub          codegen.Stub
+	sumSeqMetrics *codegen.MethodMetrics
+}
+
+// Check that relay_client_stub implements the Relay interface.
+var _ Relay = (*relay_client_stub)(nil)
+
+func (s relay_client_stub) SumSeq(ctx context.Context, a0 int) (r0 int, err error) {
+	// Update metrics.
+	var requestBytes, replyBytes int
+	begin := s.sumSeqMetrics.Begin()
+	defer func() { s.sumSeqMetrics.End(begin, err != nil, requestBytes, replyBytes) }()
+
+	span := trace.SpanFromContext(ctx)
+	if span.SpanContext().IsValid() {
+		// Create a child span for this method.
+		ctx, span = s.stub.Tracer().Start(ctx, "streams.Relay.SumSeq", trace.WithSpanKind(trace.SpanKindClient))
+	}
+
+	defer func() {
+		// Catch and return any panics detected during encoding/decoding/rpc.
+		if err == nil {
+			err = codegen.CatchPanics(recover())
+			if err != nil {
+				err = errors.Join(mx.RemoteCallError, err)
+			}
+		}
+
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+
+	}()
+
+	// Preallocate a buffer of the right size.
+	size := 0
+	size += 8
+	enc := codegen.GetEncoder()
+	defer codegen.PutEncoder(enc)
+	enc.Reset(size)
+
+	// Encode arguments.
+	enc.Int(a0)
+	var shardKey uint64
+
+	// Call the remote method.
+	requestBytes = len(enc.Data())
+	var results []byte
+	results, err = s.stub.Run(ctx, 0, enc.Data(), shardKey)
+	replyBytes = len(results)
+	if err != nil {
+		err = errors.Join(mx.RemoteCallError, err)
+		return
+	}
+
+	// Decode the results.
+	dec := codegen.NewZeroCopyDecoder(results)
+	r0 = dec.Int()
+	err = dec.Error()
+	return
+}
+
+// Note that "mx generate" will always generate the error message below.
+// Everything is okay. The error message is only relevant if you see it when
+// you run "go build" or "go run".
+var _ codegen.LatestVersion = codegen.Version[[0][25]struct{}](`
+
+ERROR: You generated this file with 'mx generate' (devel) (codegen
+version v0.25.0). The generated code is incompatible with the version of the
+github.com/sh3lk/mx module that you're using. The mx module
+version can be found in your go.mod file or by running the following command.
+
+    go list -m github.com/sh3lk/mx
+
+We recommend updating the mx module and the 'mx generate' command by
+running the following.
+
+    go get github.com/sh3lk/mx@latest
+    go install github.com/sh3lk/mx/cmd/mx@latest
+
+Then, re-run 'mx generate' and re-build your code. If the problem persists,
+please file an issue at https://github.com/sh3lk/mx/issues.
+
+`)
+
+// Server stub implementations.
+
+type numbers_server_stub struct {
+	impl    Numbers
+	addLoad func(key uint64, load float64)
+}
+
+// Check that numbers_server_stub implements the codegen.Server interface.
+var _ codegen.Server = (*numbers_server_stub)(nil)
+
+// GetStubFn implements the codegen.Server interface.
+func (s numbers_server_stub) GetStubFn(method string) func(ctx context.Context, args []byte) ([]byte, error) {
+	switch method {
+	default:
+		return nil
+	}
+}
+
+// Check that numbers_server_stub implements the codegen.StreamServer interface.
+var _ codegen.StreamServer = (*numbers_server_stub)(nil)
+
+// GetStreamStubFn implements the codegen.StreamServer interface.
+func (s numbers_server_stub) GetStreamStubFn(method string) func(ctx context.Context, args []byte, in codegen.ByteStream) ([]byte, codegen.ByteStream, error) {
+	switch method {
+	case "Fail":
+		return s.fail
+	case "Pairs":
+		return s.pairs
+	case "Seq":
+		return s.seq
+	case "Sum":
+		return s.sum
+	default:
+		return nil
+	}
+}
+
+func (s numbers_server_stub) fail(ctx context.Context, args []byte, in codegen.ByteStream) (res []byte, out codegen.ByteStream, err error) {
+	// Catch and return any panics detected during encoding/decoding/rpc.
+	defer func() {
+		if err == nil {
+			err = codegen.CatchPanics(recover())
+		}
+	}()
+
+	// Decode arguments.
+	dec := codegen.NewDecoder(args)
+	var a0 int
+	a0 = dec.Int()
+
+	// Call the local method.
+	r0, appErr := s.impl.Fail(ctx, a0)
+
+	// Encode the results.
+	enc := codegen.NewEncoder()
+	if appErr == nil && r0 != nil {
+		out = codegen.EncodeStream[int](r0, func(enc *codegen.Encoder, v int) { enc.Int(v) })
+	} else if r0 != nil {
+		r0.Close()
+	}
+	enc.Error(appErr)
+	return enc.Data(), out, nil
+}
+
+func (s numbers_server_stub) pairs(ctx context.Context, args []byte, in codegen.ByteStream) (res []byte, out codegen.ByteStream, err error) {
+	// Catch and return any panics detected during encoding/decoding/rpc.
+	defer func() {
+		if err == nil {
+			err = codegen.CatchPanics(recover())
+		}
+	}()
+
+	// Decode arguments.
+	dec := codegen.NewDecoder(args)
+	var a0 int
+	a0 = dec.Int()
+	var a1 string
+	a1 = dec.String()
+
+	// Call the local method.
+	r0, appErr := s.impl.Pairs(ctx, a0, a1)
+
+	// Encode the results.
+	enc := codegen.NewEncoder()
+	if appErr == nil && r0 != nil {
+		out = codegen.EncodeStream[Pair](r0, func(enc *codegen.Encoder, v Pair) { (v).MXMarshal(enc) })
+	} else if r0 != nil {
+		r0.Close()
+	}
+	enc.Error(appErr)
+	return enc.Data(), out, nil
+}
+
+func (s numbers_server_stub) seq(ctx context.Context, args []byte, in codegen.ByteStream) (res []byte, out codegen.ByteStream, err error) {
+	// Catch and return any panics detected during encoding/decoding/rpc.
+	defer func() {
+		if err == nil {
+			err = codegen.CatchPanics(recover())
+		}
+	}()
+
+	// Decode arguments.
+	dec := codegen.NewDecoder(args)
+	var a0 int
+	a0 = dec.Int()
+
+	// Call the local method.
+	r0, appErr := s.impl.Seq(ctx, a0)
+
+	// Encode the results.
+	enc := codegen.NewEncoder()
+	if appErr == nil && r0 != nil {
+		out = codegen.EncodeStream[int](r0, func(enc *codegen.Encoder, v int) { enc.Int(v) })
+	} else if r0 != nil {
+		r0.Close()
+	}
+	enc.Error(appErr)
+	return enc.Data(), out, nil
+}
+
+func (s numbers_server_stub) sum(ctx context.Context, args []byte, in codegen.ByteStream) (res []byte, out codegen.ByteStream, err error) {
+	// Catch and return any panics detected during encoding/decoding/rpc.
+	defer func() {
+		if err == nil {
+			err = codegen.CatchPanics(recover())
+		}
+	}()
+
+	// Decode arguments.
+	a0 := codegen.DecodeStream[int](in, func(dec *codegen.Decoder) (v int) {
+		v = dec.Int()
+		return
+	})
+
+	// Call the local method.
+	r0, appErr := s.impl.Sum(ctx, a0)
+
+	// Encode the results.
+	enc := codegen.NewEncoder()
+	enc.Int(r0)
+	enc.Error(appErr)
+	return enc.Data(), out, nil
+}
+
+type relay_server_stub struct {
+	impl    Relay
+	addLoad func(key uint64, load float64)
+}
+
+// Check that relay_server_stub implements the codegen.Server interface.
+var _ codegen.Server = (*relay_server_stub)(nil)
+
+// GetStubFn implements the codegen.Server interface.
+func (s relay_server_stub) GetStubFn(method string) func(ctx context.Context, args []byte) ([]byte, error) {
+	switch method {
+	case "SumSeq":
+		return s.sumSeq
+	default:
+		return nil
+	}
+}
+
+func (s relay_server_stub) sumSeq(ctx context.Context, args []byte) (res []byte, err error) {
+	// Catch and return any panics detected during encoding/decoding/rpc.
+	defer func() {
+		if err == nil {
+			err = codegen.CatchPanics(recover())
+		}
+	}()
+
+	// Decode arguments.
+	dec := codegen.NewDecoder(args)
+	var a0 int
+	a0 = dec.Int()
+
+	// TODO(rgrandl): The deferred function above will recover from panics in the
+	// user code: fix this.
+	// Call the local method.
+	r0, appErr := s.impl.SumSeq(ctx, a0)
+
+	// Encode the results.
+	enc := codegen.NewEncoder()
+	enc.Int(r0)
+	enc.Error(appErr)
+	return enc.Data(), nil
+}
+
+// Reflect stub implementations.
+
+type numbers_reflect_stub struct {
+	caller func(string, context.Context, []any, []any) error
+}
+
+// Check that numbers_reflect_stub implements the Numbers interface.
+var _ Numbers = (*numbers_reflect_stub)(nil)
+
+func (s numbers_reflect_stub) Fail(ctx context.Context, a0 int) (r0 mx.Stream[int], err error) {
+	err = s.caller("Fail", ctx, []any{a0}, []any{&r0})
+	return
+}
+
+func (s numbers_reflect_stub) Pairs(ctx context.Context, a0 int, a1 string) (r0 mx.Stream[Pair], err error) {
+	err = s.caller("Pairs", ctx, []any{a0, a1}, []any{&r0})
+	return
+}
+
+func (s numbers_reflect_stub) Seq(ctx context.Context, a0 int) (r0 mx.Stream[int], err error) {
+	err = s.caller("Seq", ctx, []any{a0}, []any{&r0})
+	return
+}
+
+func (s numbers_reflect_stub) Sum(ctx context.Context, a0 mx.Stream[int]) (r0 int, err error) {
+	err = s.caller("Sum", ctx, []any{a0}, []any{&r0})
+	return
+}
+
+type relay_reflect_stub struct {
+	caller func(string, context.Context, []any, []any) error
+}
+
+// Check that relay_reflect_stub implements the Relay interface.
+var _ Relay = (*relay_reflect_stub)(nil)
+
+func (s relay_reflect_stub) SumSeq(ctx context.Context, a0 int) (r0 int, err error) {
+	err = s.caller("SumSeq", ctx, []any{a0}, []any{&r0})
+	return
+}
+
+// AutoMarshal implementations.
+
+var _ codegen.AutoMarshal = (*Pair)(nil)
+
+type __is_Pair[T ~struct {
+	mx.AutoMarshal
+	Index int
+	Label string
+}] struct{}
+
+var _ __is_Pair[Pair]
+
+func (x *Pair) MXMarshal(enc *codegen.Encoder) {
+	if x == nil {
+		panic(fmt.Errorf("Pair.MXMarshal: nil receiver"))
+	}
+	enc.Int(x.Index)
+	enc.String(x.Label)
+}
+
+func (x *Pair) MXUnmarshal(dec *codegen.Decoder) {
+	if x == nil {
+		panic(fmt.Errorf("Pair.MXUnmarshal: nil receiver"))
+	}
+	x.Index = dec.Int()
+	x.Label = dec.String()
+}
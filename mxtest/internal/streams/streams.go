@@ -0,0 +1,121 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package streams defines components with streaming methods.
+//
+// Numbers streams values to and from its callers, and Relay forwards a stream
+// returned by Numbers back to Numbers.
+package streams
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/sh3lk/mx"
+)
+
+//go:generate ../../../cmd/mx/mx generate
+
+// ErrFailed is returned by the streams returned by Numbers.Fail.
+var ErrFailed = errors.New("failed")
+
+type Pair struct {
+	mx.AutoMarshal
+	Index int
+	Label string
+}
+
+type Numbers interface {
+	// Seq returns the stream 0, 1, ..., n-1.
+	Seq(ctx context.Context, n int) (mx.Stream[int], error)
+
+	// Pairs returns the stream {0, label}, {1, label}, ..., {n-1, label}.
+	Pairs(ctx context.Context, n int, label string) (mx.Stream[Pair], error)
+
+	// Fail returns a stream that fails with ErrFailed after n values.
+	Fail(ctx context.Context, n int) (mx.Stream[int], error)
+
+	// Sum returns the sum of the values in nums.
+	Sum(ctx context.Context, nums mx.Stream[int]) (int, error)
+}
+
+type Relay interface {
+	// SumSeq returns the sum of 0, 1, ..., n-1, as computed by Numbers.
+	SumSeq(ctx context.Context, n int) (int, error)
+}
+
+type numbers struct {
+	mx.Implements[Numbers]
+}
+
+func (*numbers) Seq(_ context.Context, n int) (mx.Stream[int], error) {
+	return mx.NewStream(func(ctx context.Context, send func(int) error) error {
+		for i := 0; i < n; i++ {
+			if err := send(i); err != nil {
+				return err
+			}
+		}
+		return nil
+	}), nil
+}
+
+func (*numbers) Pairs(_ context.Context, n int, label string) (mx.Stream[Pair], error) {
+	return mx.NewStream(func(ctx context.Context, send func(Pair) error) error {
+		for i := 0; i < n; i++ {
+			if err := send(Pair{Index: i, Label: label}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}), nil
+}
+
+func (*numbers) Fail(_ context.Context, n int) (mx.Stream[int], error) {
+	return mx.NewStream(func(ctx context.Context, send func(int) error) error {
+		for i := 0; i < n; i++ {
+			if err := send(i); err != nil {
+				return err
+			}
+		}
+		return ErrFailed
+	}), nil
+}
+
+func (*numbers) Sum(ctx context.Context, nums mx.Stream[int]) (int, error) {
+	defer nums.Close()
+	sum := 0
+	for {
+		x, err := nums.Recv(ctx)
+		if errors.Is(err, io.EOF) {
+			return sum, nil
+		} else if err != nil {
+			return 0, err
+		}
+		sum += x
+	}
+}
+
+type relay struct {
+	mx.Implements[Relay]
+	numbers mx.Ref[Numbers]
+}
+
+func (r *relay) SumSeq(ctx context.Context, n int) (int, error) {
+	nums, err := r.numbers.Get().Seq(ctx, n)
+	if err != nil {
+		return 0, err
+	}
+	return r.numbers.Get().Sum(ctx, nums)
+}
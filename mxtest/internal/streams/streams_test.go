@@ -0,0 +1,197 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package streams_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sh3lk/mx"
+	"github.com/sh3lk/mx/mxtest"
+	"github.com/sh3lk/mx/mxtest/internal/streams"
+)
+
+// recvAll receives all of the values in s.
+func recvAll[T any](ctx context.Context, s mx.Stream[T]) ([]T, error) {
+	defer s.Close()
+	var values []T
+	for {
+		v, err := s.Recv(ctx)
+		if errors.Is(err, io.EOF) {
+			return values, nil
+		} else if err != nil {
+			return values, err
+		}
+		values = append(values, v)
+	}
+}
+
+func TestServerStream(t *testing.T) {
+	ctx := context.Background()
+	for _, runner := range mxtest.AllRunners() {
+		runner.Test(t, func(t *testing.T, numbers streams.Numbers) {
+			// Stream more values than fit in a single flow control window.
+			const n = 1000
+			s, err := numbers.Seq(ctx, n)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := recvAll(ctx, s)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != n {
+				t.Fatalf("got %d values, want %d", len(got), n)
+			}
+			for i, x := range got {
+				if x != i {
+					t.Fatalf("value %d: got %d, want %d", i, x, i)
+				}
+			}
+		})
+	}
+}
+
+func TestServerStreamStructs(t *testing.T) {
+	ctx := context.Background()
+	for _, runner := range mxtest.AllRunners() {
+		runner.Test(t, func(t *testing.T, numbers streams.Numbers) {
+			s, err := numbers.Pairs(ctx, 3, "x")
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := recvAll(ctx, s)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := []streams.Pair{{Index: 0, Label: "x"}, {Index: 1, Label: "x"}, {Index: 2, Label: "x"}}
+			if diff := cmp.Diff(want, got, cmp.Comparer(func(a, b streams.Pair) bool {
+				return a.Index == b.Index && a.Label == b.Label
+			})); diff != "" {
+				t.Fatalf("Pairs (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestServerStreamError(t *testing.T) {
+	ctx := context.Background()
+	for _, runner := range mxtest.AllRunners() {
+		runner.Test(t, func(t *testing.T, numbers streams.Numbers) {
+			s, err := numbers.Fail(ctx, 5)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := recvAll(ctx, s)
+			if !errors.Is(err, streams.ErrFailed) {
+				t.Fatalf("got error %v, want %v", err, streams.ErrFailed)
+			}
+			if len(got) != 5 {
+				t.Fatalf("got %d values, want 5", len(got))
+			}
+		})
+	}
+}
+
+func TestServerStreamClose(t *testing.T) {
+	ctx := context.Background()
+	for _, runner := range mxtest.AllRunners() {
+		runner.Test(t, func(t *testing.T, numbers streams.Numbers) {
+			// Close a stream before it ends, and check that subsequent calls
+			// still succeed.
+			for i := 0; i < 10; i++ {
+				s, err := numbers.Seq(ctx, 1000000)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if _, err := s.Recv(ctx); err != nil {
+					t.Fatal(err)
+				}
+				s.Close()
+			}
+			s, err := numbers.Seq(ctx, 10)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := recvAll(ctx, s)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != 10 {
+				t.Fatalf("got %d values, want 10", len(got))
+			}
+		})
+	}
+}
+
+func TestClientStream(t *testing.T) {
+	ctx := context.Background()
+	for _, runner := range mxtest.AllRunners() {
+		runner.Test(t, func(t *testing.T, numbers streams.Numbers) {
+			const n = 1000
+			nums := mx.NewStream(func(ctx context.Context, send func(int) error) error {
+				for i := 0; i < n; i++ {
+					if err := send(i); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+			got, err := numbers.Sum(ctx, nums)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := n * (n - 1) / 2; got != want {
+				t.Fatalf("Sum: got %d, want %d", got, want)
+			}
+		})
+	}
+}
+
+func TestClientStreamError(t *testing.T) {
+	ctx := context.Background()
+	for _, runner := range mxtest.AllRunners() {
+		runner.Test(t, func(t *testing.T, numbers streams.Numbers) {
+			nums := mx.NewStream(func(ctx context.Context, send func(int) error) error {
+				if err := send(1); err != nil {
+					return err
+				}
+				return streams.ErrFailed
+			})
+			if _, err := numbers.Sum(ctx, nums); !errors.Is(err, streams.ErrFailed) {
+				t.Fatalf("Sum: got error %v, want %v", err, streams.ErrFailed)
+			}
+		})
+	}
+}
+
+func TestForwardStream(t *testing.T) {
+	ctx := context.Background()
+	for _, runner := range mxtest.AllRunners() {
+		runner.Test(t, func(t *testing.T, relay streams.Relay) {
+			const n = 100
+			got, err := relay.SumSeq(ctx, n)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := n * (n - 1) / 2; got != want {
+				t.Fatalf("SumSeq: got %d, want %d", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,301 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package mock implements the mocks of MX components generated by
+// "mx generate -mocks".
+//
+// For every component interface Foo, "mx generate -mocks" generates a MockFoo
+// type that implements Foo by embedding a Mock. A mock records every call made
+// to it, and returns the results scripted with On and Return:
+//
+//	func TestCache(t *testing.T) {
+//	    store := &MockStore{}
+//	    store.On("Get", "a").Return("apple", nil)
+//	    store.On("Get", mock.Any).Return("", ErrNotFound)
+//
+//	    runner := mxtest.Local
+//	    runner.Fakes = append(runner.Fakes, mxtest.Fake[Store](store))
+//	    runner.Test(t, func(t *testing.T, cache Cache) {
+//	        // Exercise cache, which calls store...
+//	        store.AssertCallCount(t, 1, "Get", "a")
+//	    })
+//	}
+//
+// A call that matches no scripted results is forwarded to the implementation
+// passed to Spy, if any, which makes the mock a spy of that implementation.
+// Otherwise, it returns zero values and an error.
+package mock
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// ErrUnexpectedCall is returned, wrapped, by calls that match no scripted
+// results on a mock that doesn't spy on an implementation.
+var ErrUnexpectedCall = errors.New("mock: unexpected call")
+
+// T is the subset of testing.TB used by the assertions of a Mock.
+type T interface {
+	Helper()
+	Errorf(format string, args ...any)
+}
+
+// A Matcher matches the arguments of a call. A value that is not a Matcher,
+// when passed as an argument to On or an assertion, matches the arguments
+// that are reflect.DeepEqual to it.
+type Matcher interface {
+	Match(arg any) bool
+}
+
+// Any matches any argument.
+var Any Matcher = matchFunc(func(any) bool { return true })
+
+// Match returns a Matcher that matches the arguments of type T for which f
+// returns true.
+func Match[T any](f func(T) bool) Matcher {
+	return matchFunc(func(arg any) bool {
+		t, ok := arg.(T)
+		return ok && f(t)
+	})
+}
+
+type matchFunc func(any) bool
+
+func (f matchFunc) Match(arg any) bool { return f(arg) }
+
+// Call is a call made to a mock.
+type Call struct {
+	Method string
+	Args   []any // the arguments, excluding the context; variadic arguments are a slice
+}
+
+// Mock records the calls made to a mock component and holds the results
+// scripted for its methods. The zero value is ready to use. A Mock is safe for
+// concurrent use.
+type Mock struct {
+	mu    sync.Mutex
+	calls []Call
+	stubs []*Stub
+	impl  any
+}
+
+// Stub holds the results scripted for the calls of a method whose arguments
+// match.
+type Stub struct {
+	m        *Mock
+	method   string
+	matchers []any
+	results  []any
+	set      bool // has Return been called?
+}
+
+// On scripts the results of the calls to the named method whose arguments
+// match, one by one, the provided matchers. Without matchers, it scripts the
+// results of every call to the method. If several stubs match a call, the most
+// recently added one is used.
+func (m *Mock) On(method string, matchers ...any) *Stub {
+	s := &Stub{m: m, method: method, matchers: matchers}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.stubs = append(m.stubs, s)
+	return s
+}
+
+// Return sets the results returned by the calls that match s, including the
+// final error, e.g., Return("apple", nil) for a method that returns a string
+// and an error.
+func (s *Stub) Return(results ...any) *Stub {
+	s.m.mu.Lock()
+	defer s.m.mu.Unlock()
+	s.results = results
+	s.set = true
+	return s
+}
+
+// Spy makes the calls that match no scripted results go to impl, which must
+// implement the mocked component interface.
+func (m *Mock) Spy(impl any) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.impl = impl
+}
+
+// Spied returns the implementation passed to Spy, if any.
+func (m *Mock) Spied() any {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.impl
+}
+
+// Reset forgets the recorded calls and the scripted results.
+func (m *Mock) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls = nil
+	m.stubs = nil
+}
+
+// Results holds the results of a call to a mock, returned by Called.
+type Results struct {
+	values []any
+	err    error
+}
+
+// Called records a call to the named method with the provided arguments, and
+// returns the n results, including the final error, scripted for the call. It
+// returns false if no results were scripted for the call. Called is used by
+// generated code; tests don't need to call it.
+func (m *Mock) Called(method string, n int, args ...any) (Results, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls = append(m.calls, Call{Method: method, Args: args})
+	for i := len(m.stubs) - 1; i >= 0; i-- {
+		s := m.stubs[i]
+		if s.method != method || !s.set || !matches(s.matchers, args) {
+			continue
+		}
+		if len(s.results) != n {
+			panic(fmt.Sprintf("mock: %s returns %d results, but %d were scripted", method, n, len(s.results)))
+		}
+		return Results{values: s.results}, true
+	}
+	return Results{err: fmt.Errorf("%w to %s(%s)", ErrUnexpectedCall, method, formatArgs(args))}, false
+}
+
+// Value returns the i-th result in r, or the zero value of T if the result is
+// nil or missing. Value is used by generated code; tests don't need to call it.
+func Value[T any](r Results, i int) T {
+	var zero T
+	if i >= len(r.values) || r.values[i] == nil {
+		return zero
+	}
+	v, ok := r.values[i].(T)
+	if !ok {
+		panic(fmt.Sprintf("mock: result %d has type %T, want %T", i, r.values[i], zero))
+	}
+	return v
+}
+
+// Err returns the error result in r.
+func (r Results) Err() error {
+	if r.err != nil || len(r.values) == 0 {
+		return r.err
+	}
+	return Value[error](r, len(r.values)-1)
+}
+
+// Calls returns the recorded calls to the named method, or all recorded calls
+// if method is empty.
+func (m *Mock) Calls(method string) []Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var calls []Call
+	for _, c := range m.calls {
+		if method == "" || c.Method == method {
+			calls = append(calls, c)
+		}
+	}
+	return calls
+}
+
+// CallCount returns the number of recorded calls to the named method whose
+// arguments match the provided matchers.
+func (m *Mock) CallCount(method string, matchers ...any) int {
+	n := 0
+	for _, c := range m.Calls(method) {
+		if matches(matchers, c.Args) {
+			n++
+		}
+	}
+	return n
+}
+
+// AssertCalled asserts that the named method was called at least once with
+// arguments that match the provided matchers.
+func (m *Mock) AssertCalled(t T, method string, matchers ...any) bool {
+	t.Helper()
+	if m.CallCount(method, matchers...) == 0 {
+		t.Errorf("mock: %s was not called with matching arguments; calls: %s", method, m.formatCalls(method))
+		return false
+	}
+	return true
+}
+
+// AssertNotCalled asserts that the named method was never called with
+// arguments that match the provided matchers.
+func (m *Mock) AssertNotCalled(t T, method string, matchers ...any) bool {
+	t.Helper()
+	if n := m.CallCount(method, matchers...); n != 0 {
+		t.Errorf("mock: %s was called %d times with matching arguments", method, n)
+		return false
+	}
+	return true
+}
+
+// AssertCallCount asserts that the named method was called exactly n times
+// with arguments that match the provided matchers.
+func (m *Mock) AssertCallCount(t T, n int, method string, matchers ...any) bool {
+	t.Helper()
+	if got := m.CallCount(method, matchers...); got != n {
+		t.Errorf("mock: %s was called %d times with matching arguments, want %d; calls: %s", method, got, n, m.formatCalls(method))
+		return false
+	}
+	return true
+}
+
+// matches returns whether the provided arguments match the matchers. No
+// matchers match any arguments.
+func matches(matchers, args []any) bool {
+	if len(matchers) == 0 {
+		return true
+	}
+	if len(matchers) != len(args) {
+		return false
+	}
+	for i, matcher := range matchers {
+		if m, ok := matcher.(Matcher); ok {
+			if !m.Match(args[i]) {
+				return false
+			}
+		} else if !reflect.DeepEqual(matcher, args[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// formatCalls returns a description of the recorded calls to the named method.
+func (m *Mock) formatCalls(method string) string {
+	calls := m.Calls(method)
+	if len(calls) == 0 {
+		return "none"
+	}
+	parts := make([]string, len(calls))
+	for i, c := range calls {
+		parts[i] = fmt.Sprintf("%s(%s)", c.Method, formatArgs(c.Args))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func formatArgs(args []any) string {
+	parts := make([]string, len(args))
+	for i, arg := range args {
+		parts[i] = fmt.Sprintf("%#v", arg)
+	}
+	return strings.Join(parts, ", ")
+}
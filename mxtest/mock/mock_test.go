@@ -0,0 +1,174 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mock_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/sh3lk/mx/mxtest/mock"
+)
+
+type store interface {
+	Get(ctx context.Context, key string) (string, error)
+	Put(ctx context.Context, key, value string) error
+}
+
+// mockStore is written the way "mx generate -mocks" writes mocks.
+type mockStore struct {
+	mock.Mock
+}
+
+var _ store = (*mockStore)(nil)
+
+func (m *mockStore) Get(ctx context.Context, a0 string) (string, error) {
+	res, ok := m.Mock.Called("Get", 2, a0)
+	if impl, spied := m.Mock.Spied().(store); !ok && spied {
+		return impl.Get(ctx, a0)
+	}
+	return mock.Value[string](res, 0), res.Err()
+}
+
+func (m *mockStore) Put(ctx context.Context, a0 string, a1 string) error {
+	res, ok := m.Mock.Called("Put", 1, a0, a1)
+	if impl, spied := m.Mock.Spied().(store); !ok && spied {
+		return impl.Put(ctx, a0, a1)
+	}
+	return res.Err()
+}
+
+type mapStore map[string]string
+
+func (s mapStore) Get(_ context.Context, key string) (string, error) { return s[key], nil }
+func (s mapStore) Put(_ context.Context, key, value string) error {
+	s[key] = value
+	return nil
+}
+
+// fakeT records the errors reported by assertions.
+type fakeT struct {
+	errors []string
+}
+
+func (t *fakeT) Helper() {}
+func (t *fakeT) Errorf(format string, args ...any) {
+	t.errors = append(t.errors, fmt.Sprintf(format, args...))
+}
+
+func TestReturn(t *testing.T) {
+	ctx := context.Background()
+	errNotFound := errors.New("not found")
+	m := &mockStore{}
+	m.On("Get", mock.Any).Return("", errNotFound)
+	m.On("Get", "a").Return("apple", nil)
+	m.On("Get", mock.Match(func(key string) bool { return strings.HasPrefix(key, "b") })).Return("banana", nil)
+
+	for _, test := range []struct {
+		key     string
+		want    string
+		wantErr error
+	}{
+		{"a", "apple", nil},
+		{"bb", "banana", nil},
+		{"c", "", errNotFound},
+	} {
+		got, err := m.Get(ctx, test.key)
+		if got != test.want || !errors.Is(err, test.wantErr) {
+			t.Errorf("Get(%q): got (%q, %v), want (%q, %v)", test.key, got, err, test.want, test.wantErr)
+		}
+	}
+}
+
+func TestUnexpectedCall(t *testing.T) {
+	m := &mockStore{}
+	m.On("Get", "a").Return("apple", nil)
+	if err := m.Put(context.Background(), "a", "avocado"); !errors.Is(err, mock.ErrUnexpectedCall) {
+		t.Errorf("Put: got %v, want %v", err, mock.ErrUnexpectedCall)
+	}
+	if got, err := m.Get(context.Background(), "b"); got != "" || !errors.Is(err, mock.ErrUnexpectedCall) {
+		t.Errorf("Get: got (%q, %v), want (\"\", %v)", got, err, mock.ErrUnexpectedCall)
+	}
+}
+
+func TestWrongNumberOfResults(t *testing.T) {
+	m := &mockStore{}
+	m.On("Get").Return("apple")
+	defer func() {
+		if recover() == nil {
+			t.Error("Get: unexpected success")
+		}
+	}()
+	m.Get(context.Background(), "a")
+}
+
+func TestSpy(t *testing.T) {
+	ctx := context.Background()
+	m := &mockStore{}
+	m.Spy(mapStore{"a": "apple"})
+	m.On("Get", "b").Return("banana", nil)
+
+	if err := m.Put(ctx, "c", "cherry"); err != nil {
+		t.Fatal(err)
+	}
+	for key, want := range map[string]string{"a": "apple", "b": "banana", "c": "cherry"} {
+		if got, err := m.Get(ctx, key); got != want || err != nil {
+			t.Errorf("Get(%q): got (%q, %v), want (%q, nil)", key, got, err, want)
+		}
+	}
+	m.AssertCallCount(t, 3, "Get")
+	m.AssertCalled(t, "Put", "c", "cherry")
+}
+
+func TestAssertions(t *testing.T) {
+	ctx := context.Background()
+	m := &mockStore{}
+	m.On("Put").Return(nil)
+	m.Put(ctx, "a", "apple")
+	m.Put(ctx, "a", "avocado")
+	m.Put(ctx, "b", "banana")
+
+	for _, test := range []struct {
+		name   string
+		assert func(t mock.T) bool
+		want   bool
+	}{
+		{"CalledAny", func(t mock.T) bool { return m.AssertCalled(t, "Put") }, true},
+		{"Called", func(t mock.T) bool { return m.AssertCalled(t, "Put", "a", mock.Any) }, true},
+		{"NotCalled", func(t mock.T) bool { return m.AssertCalled(t, "Put", "c", mock.Any) }, false},
+		{"NotCalledGet", func(t mock.T) bool { return m.AssertNotCalled(t, "Get") }, true},
+		{"NotCalledPut", func(t mock.T) bool { return m.AssertNotCalled(t, "Put", "b", "banana") }, false},
+		{"CallCount", func(t mock.T) bool { return m.AssertCallCount(t, 2, "Put", "a", mock.Any) }, true},
+		{"WrongCallCount", func(t mock.T) bool { return m.AssertCallCount(t, 1, "Put") }, false},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			ft := &fakeT{}
+			if got := test.assert(ft); got != test.want {
+				t.Errorf("got %v, want %v", got, test.want)
+			}
+			if got := len(ft.errors) == 0; got != test.want {
+				t.Errorf("errors: %q", ft.errors)
+			}
+		})
+	}
+
+	if got, want := len(m.Calls("")), 3; got != want {
+		t.Errorf("Calls: got %d calls, want %d", got, want)
+	}
+	m.Reset()
+	m.AssertNotCalled(t, "Put")
+}
@@ -0,0 +1,106 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package priority provides support for the propagation of the priority class
+// of a request from a component method caller to the callee. The priority is
+// propagated to the callee even if the caller and callee are not colocated in
+// the same process, and to all the calls made by the callee on behalf of the
+// request.
+//
+// Servers that limit the number of concurrent calls to a method run the calls
+// with a higher priority first, and reject the calls with a lower priority
+// first when they are overloaded.
+//
+// Example:
+//
+// To tag a request as batch work at the HTTP edge:
+//
+//	func (s *server) handle(w http.ResponseWriter, r *http.Request) {
+//		ctx := priority.NewContext(r.Context(), priority.Batch)
+//		s.backend.Get().Process(ctx, ...)
+//	}
+//
+// To read the priority of a request:
+//
+//	class := priority.FromContext(ctx)
+package priority
+
+import (
+	"context"
+	"fmt"
+)
+
+// Class is the priority class of a request.
+type Class uint8
+
+const (
+	// Interactive requests are requests that someone is waiting for. They
+	// have the highest priority. Requests are interactive unless tagged
+	// otherwise.
+	Interactive Class = iota
+
+	// Batch requests are requests that no one is waiting for, but that must
+	// eventually complete.
+	Batch
+
+	// BestEffort requests are requests that may be dropped. They have the
+	// lowest priority.
+	BestEffort
+)
+
+// NumClasses is the number of priority classes.
+const NumClasses = int(BestEffort) + 1
+
+// String implements the fmt.Stringer interface.
+func (c Class) String() string {
+	switch c {
+	case Interactive:
+		return "interactive"
+	case Batch:
+		return "batch"
+	case BestEffort:
+		return "best_effort"
+	default:
+		return fmt.Sprintf("Class(%d)", c)
+	}
+}
+
+// Parse returns the priority class with the provided name, as returned by
+// Class.String.
+func Parse(name string) (Class, error) {
+	for c := Interactive; c <= BestEffort; c++ {
+		if c.String() == name {
+			return c, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown priority class %q", name)
+}
+
+// classKey is an unexported type for the key that stores the priority class.
+type classKey struct{}
+
+// NewContext returns a new context that carries priority class c.
+func NewContext(ctx context.Context, c Class) context.Context {
+	return context.WithValue(ctx, classKey{}, c)
+}
+
+// FromContext returns the priority class stored in ctx, or Interactive if
+// there is none.
+func FromContext(ctx context.Context) Class {
+	c, ok := ctx.Value(classKey{}).(Class)
+	if !ok || c > BestEffort {
+		return Interactive
+	}
+	return c
+}
@@ -0,0 +1,47 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package priority
+
+import (
+	"context"
+	"testing"
+)
+
+func TestContextPriority(t *testing.T) {
+	ctx := context.Background()
+	if got, want := FromContext(ctx), Interactive; got != want {
+		t.Errorf("untagged context: got %v, want %v", got, want)
+	}
+	for c := Interactive; c <= BestEffort; c++ {
+		if got := FromContext(NewContext(ctx, c)); got != c {
+			t.Errorf("got %v, want %v", got, c)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	for c := Interactive; c <= BestEffort; c++ {
+		got, err := Parse(c.String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != c {
+			t.Errorf("Parse(%q): got %v, want %v", c.String(), got, c)
+		}
+	}
+	if _, err := Parse("urgent"); err == nil {
+		t.Error(`Parse("urgent"): unexpected success`)
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"os/exec"
 	"path/filepath"
 	"sort"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -138,6 +139,63 @@ func TestReadListeners(t *testing.T) {
 	}
 }
 
+func TestReadSchemas(t *testing.T) {
+	for _, test := range []struct{ os, arch string }{
+		{"linux", "amd64"},
+		{"windows", "amd64"},
+		{"darwin", "arm64"},
+	} {
+		t.Run(fmt.Sprintf("%s/%s", test.os, test.arch), func(t *testing.T) {
+			// Build the binary for os/arch.
+			d := t.TempDir()
+			binary := filepath.Join(d, "bin")
+			cmd := exec.Command("go", "build", "-o", binary, "./testprogram")
+			cmd.Env = append(os.Environ(), "GOOS="+test.os, "GOARCH="+test.arch)
+			if err := cmd.Run(); err != nil {
+				t.Fatal(err)
+			}
+
+			// Read schemas, ignoring the schemas of the internal components
+			// of mx, which are linked into every binary. The main component
+			// has no schema, since it has no methods.
+			schemas, err := ReadSchemas(binary)
+			if err != nil {
+				t.Fatal(err)
+			}
+			pkg := func(c string) string {
+				return fmt.Sprintf("github.com/sh3lk/mx/runtime/bin/testprogram/%s", c)
+			}
+			var actual []codegen.ComponentSchema
+			for _, schema := range schemas {
+				if strings.HasPrefix(schema.Component, pkg("")) {
+					actual = append(actual, schema)
+				}
+			}
+
+			// Check that expected schemas are found.
+			want := []codegen.ComponentSchema{
+				{Component: pkg("A")},
+				{Component: pkg("B")},
+				{
+					Component: pkg("C"),
+					Methods: []codegen.MethodSchema{{
+						Name: "Count",
+						Args: []*codegen.WireType{
+							{Kind: "slice", Elem: &codegen.WireType{Kind: "string"}},
+						},
+						Results: []*codegen.WireType{
+							{Kind: "map", Key: &codegen.WireType{Kind: "string"}, Elem: &codegen.WireType{Kind: "int64"}},
+						},
+					}},
+				},
+			}
+			if diff := cmp.Diff(want, actual); diff != "" {
+				t.Fatalf("unexpected schemas (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestExtractVersion(t *testing.T) {
 	for _, want := range []version.SemVer{
 		{Major: 4, Minor: 5, Patch: 6},
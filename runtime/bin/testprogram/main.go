@@ -25,7 +25,9 @@ import (
 
 type A interface{}
 type B interface{}
-type C interface{}
+type C interface {
+	Count(context.Context, []string) (map[string]int, error)
+}
 
 type app struct {
 	mx.Implements[mx.Main]
@@ -53,4 +55,12 @@ type c struct {
 	mx.Implements[C]
 }
 
+func (*c) Count(_ context.Context, words []string) (map[string]int, error) {
+	counts := map[string]int{}
+	for _, w := range words {
+		counts[w]++
+	}
+	return counts, nil
+}
+
 func main() {}
@@ -5,8 +5,10 @@ package main
 
 import (
 	"context"
+	"errors"
 	"github.com/sh3lk/mx"
 	"github.com/sh3lk/mx/runtime/codegen"
+	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/trace"
 	"reflect"
 )
@@ -27,7 +29,7 @@ func init() {
 		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
 			return a_reflect_stub{caller: caller}
 		},
-		RefData: "⟦193f6c94:MxEdge:github.com/sh3lk/mx/runtime/bin/testprogram/A→github.com/sh3lk/mx/runtime/bin/testprogram/B⟧\n⟦8cd483a3:MxEdge:github.com/sh3lk/mx/runtime/bin/testprogram/A→github.com/sh3lk/mx/runtime/bin/testprogram/C⟧\n⟦93cd9612:wEaVeRlIsTeNeRs:github.com/sh3lk/mx/runtime/bin/testprogram/A→aLis1,aLis2,aLis3⟧\n",
+		RefData: "⟦f5657d3b:MxEdge:github.com/sh3lk/mx/runtime/bin/testprogram/A→github.com/sh3lk/mx/runtime/bin/testprogram/B⟧\n⟦7d179e8b:MxEdge:github.com/sh3lk/mx/runtime/bin/testprogram/A→github.com/sh3lk/mx/runtime/bin/testprogram/C⟧\n⟦d8148fd4:wEaVeRlIsTeNeRs:github.com/sh3lk/mx/runtime/bin/testprogram/A→aLis1,aLis2,aLis3⟧\n⟦811a5ec5:MxSchema:github.com/sh3lk/mx/runtime/bin/testprogram/A→null⟧\n",
 	})
 	codegen.Register(codegen.Registration{
 		Name:      "github.com/sh3lk/mx/runtime/bin/testprogram/B",
@@ -44,7 +46,7 @@ func init() {
 		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
 			return b_reflect_stub{caller: caller}
 		},
-		RefData: "⟦7551e870:wEaVeRlIsTeNeRs:github.com/sh3lk/mx/runtime/bin/testprogram/B→Listener⟧\n",
+		RefData: "⟦95f730f9:wEaVeRlIsTeNeRs:github.com/sh3lk/mx/runtime/bin/testprogram/B→Listener⟧\n⟦0d7851e7:MxSchema:github.com/sh3lk/mx/runtime/bin/testprogram/B→null⟧\n",
 	})
 	codegen.Register(codegen.Registration{
 		Name:      "github.com/sh3lk/mx/runtime/bin/testprogram/C",
@@ -52,16 +54,18 @@ func init() {
 		Impl:      reflect.TypeOf(c{}),
 		Listeners: []string{"cLis"},
 		LocalStubFn: func(impl any, caller string, tracer trace.Tracer) any {
-			return c_local_stub{impl: impl.(C), tracer: tracer}
+			return c_local_stub{impl: impl.(C), tracer: tracer, countMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/sh3lk/mx/runtime/bin/testprogram/C", Method: "Count", Remote: false, Generated: true})}
+		},
+		ClientStubFn: func(stub codegen.Stub, caller string) any {
+			return c_client_stub{stub: stub, countMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/sh3lk/mx/runtime/bin/testprogram/C", Method: "Count", Remote: true, Generated: true})}
 		},
-		ClientStubFn: func(stub codegen.Stub, caller string) any { return c_client_stub{stub: stub} },
 		ServerStubFn: func(impl any, addLoad func(uint64, float64)) codegen.Server {
 			return c_server_stub{impl: impl.(C), addLoad: addLoad}
 		},
 		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
 			return c_reflect_stub{caller: caller}
 		},
-		RefData: "⟦105ddfd4:wEaVeRlIsTeNeRs:github.com/sh3lk/mx/runtime/bin/testprogram/C→cLis⟧\n",
+		RefData: "⟦8d79eac1:wEaVeRlIsTeNeRs:github.com/sh3lk/mx/runtime/bin/testprogram/C→cLis⟧\n⟦fa555c99:MxSchema:github.com/sh3lk/mx/runtime/bin/testprogram/C→[{\"name\":\"Count\",\"args\":[{\"kind\":\"slice\",\"elem\":{\"kind\":\"string\"}}],\"results\":[{\"kind\":\"map\",\"key\":{\"kind\":\"string\"},\"elem\":{\"kind\":\"int64\"}}]}]⟧\n",
 	})
 	codegen.Register(codegen.Registration{
 		Name:      "github.com/sh3lk/mx/Main",
@@ -78,7 +82,7 @@ func init() {
 		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
 			return main_reflect_stub{caller: caller}
 		},
-		RefData: "⟦d90475cb:MxEdge:github.com/sh3lk/mx/Main→github.com/sh3lk/mx/runtime/bin/testprogram/A⟧\n⟦b7bc7e7d:wEaVeRlIsTeNeRs:github.com/sh3lk/mx/Main→appLis⟧\n",
+		RefData: "⟦851b8ccc:MxEdge:github.com/sh3lk/mx/Main→github.com/sh3lk/mx/runtime/bin/testprogram/A⟧\n⟦44793126:wEaVeRlIsTeNeRs:github.com/sh3lk/mx/Main→appLis⟧\n",
 	})
 }
 
@@ -113,13 +117,34 @@ type b_local_stub struct {
 var _ B = (*b_local_stub)(nil)
 
 type c_local_stub struct {
-	impl   C
-	tracer trace.Tracer
+	impl         C
+	tracer       trace.Tracer
+	countMetrics *codegen.MethodMetrics
 }
 
 // Check that c_local_stub implements the C interface.
 var _ C = (*c_local_stub)(nil)
 
+func (s c_local_stub) Count(ctx context.Context, a0 []string) (r0 map[string]int, err error) {
+	// Update metrics.
+	begin := s.countMetrics.Begin()
+	defer func() { s.countMetrics.End(begin, err != nil, 0, 0) }()
+	span := trace.SpanFromContext(ctx)
+	if span.SpanContext().IsValid() {
+		// Create a child span for this method.
+		ctx, span = s.tracer.Start(ctx, "main.C.Count", trace.WithSpanKind(trace.SpanKindInternal))
+		defer func() {
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			}
+			span.End()
+		}()
+	}
+
+	return s.impl.Count(ctx, a0)
+}
+
 type main_local_stub struct {
 	impl   mx.Main
 	tracer trace.Tracer
@@ -145,12 +170,65 @@ type b_client_stub struct {
 var _ B = (*b_client_stub)(nil)
 
 type c_client_stub struct {
-	stub codegen.Stub
+	stub         codegen.Stub
+	countMetrics *codegen.MethodMetrics
 }
 
 // Check that c_client_stub implements the C interface.
 var _ C = (*c_client_stub)(nil)
 
+func (s c_client_stub) Count(ctx context.Context, a0 []string) (r0 map[string]int, err error) {
+	// Update metrics.
+	var requestBytes, replyBytes int
+	begin := s.countMetrics.Begin()
+	defer func() { s.countMetrics.End(begin, err != nil, requestBytes, replyBytes) }()
+
+	span := trace.SpanFromContext(ctx)
+	if span.SpanContext().IsValid() {
+		// Create a child span for this method.
+		ctx, span = s.stub.Tracer().Start(ctx, "main.C.Count", trace.WithSpanKind(trace.SpanKindClient))
+	}
+
+	defer func() {
+		// Catch and return any panics detected during encoding/decoding/rpc.
+		if err == nil {
+			err = codegen.CatchPanics(recover())
+			if err != nil {
+				err = errors.Join(mx.RemoteCallError, err)
+			}
+		}
+
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+
+	}()
+
+	// Encode arguments.
+	enc := codegen.GetEncoder()
+	defer codegen.PutEncoder(enc)
+	mx_enc_slice_string_4af10117(enc, a0)
+	var shardKey uint64
+
+	// Call the remote method.
+	requestBytes = len(enc.Data())
+	var results []byte
+	results, err = s.stub.Run(ctx, 0, enc.Data(), shardKey)
+	replyBytes = len(results)
+	if err != nil {
+		err = errors.Join(mx.RemoteCallError, err)
+		return
+	}
+
+	// Decode the results.
+	dec := codegen.NewZeroCopyDecoder(results)
+	r0 = mx_dec_map_string_int_c20ee031(dec)
+	err = dec.Error()
+	return
+}
+
 type main_client_stub struct {
 	stub codegen.Stub
 }
@@ -161,10 +239,10 @@ var _ mx.Main = (*main_client_stub)(nil)
 // Note that "mx generate" will always generate the error message below.
 // Everything is okay. The error message is only relevant if you see it when
 // you run "go build" or "go run".
-var _ codegen.LatestVersion = codegen.Version[[0][24]struct{}](`
+var _ codegen.LatestVersion = codegen.Version[[0][25]struct{}](`
 
-ERROR: You generated this file with 'mx generate' v0.24.7-0.20250401231336-b01860e0378a+dirty (codegen
-version v0.24.0). The generated code is incompatible with the version of the
+ERROR: You generated this file with 'mx generate' (devel) (codegen
+version v0.25.0). The generated code is incompatible with the version of the
 github.com/sh3lk/mx module that you're using. The mx module
 version can be found in your go.mod file or by running the following command.
 
@@ -226,11 +304,38 @@ var _ codegen.Server = (*c_server_stub)(nil)
 // GetStubFn implements the codegen.Server interface.
 func (s c_server_stub) GetStubFn(method string) func(ctx context.Context, args []byte) ([]byte, error) {
 	switch method {
+	case "Count":
+		return s.count
 	default:
 		return nil
 	}
 }
 
+func (s c_server_stub) count(ctx context.Context, args []byte) (res []byte, err error) {
+	// Catch and return any panics detected during encoding/decoding/rpc.
+	defer func() {
+		if err == nil {
+			err = codegen.CatchPanics(recover())
+		}
+	}()
+
+	// Decode arguments.
+	dec := codegen.NewDecoder(args)
+	var a0 []string
+	a0 = mx_dec_slice_string_4af10117(dec)
+
+	// TODO(rgrandl): The deferred function above will recover from panics in the
+	// user code: fix this.
+	// Call the local method.
+	r0, appErr := s.impl.Count(ctx, a0)
+
+	// Encode the results.
+	enc := codegen.NewEncoder()
+	mx_enc_map_string_int_c20ee031(enc, r0)
+	enc.Error(appErr)
+	return enc.Data(), nil
+}
+
 type main_server_stub struct {
 	impl    mx.Main
 	addLoad func(key uint64, load float64)
@@ -270,9 +375,67 @@ type c_reflect_stub struct {
 // Check that c_reflect_stub implements the C interface.
 var _ C = (*c_reflect_stub)(nil)
 
+func (s c_reflect_stub) Count(ctx context.Context, a0 []string) (r0 map[string]int, err error) {
+	err = s.caller("Count", ctx, []any{a0}, []any{&r0})
+	return
+}
+
 type main_reflect_stub struct {
 	caller func(string, context.Context, []any, []any) error
 }
 
 // Check that main_reflect_stub implements the mx.Main interface.
 var _ mx.Main = (*main_reflect_stub)(nil)
+
+// Encoding/decoding implementations.
+
+func mx_enc_slice_string_4af10117(enc *codegen.Encoder, arg []string) {
+	if arg == nil {
+		enc.Len(-1)
+		return
+	}
+	enc.Len(len(arg))
+	for i := 0; i < len(arg); i++ {
+		enc.String(arg[i])
+	}
+}
+
+func mx_dec_slice_string_4af10117(dec *codegen.Decoder) []string {
+	n := dec.Len()
+	if n == -1 {
+		return nil
+	}
+	res := make([]string, n)
+	for i := 0; i < n; i++ {
+		res[i] = dec.String()
+	}
+	return res
+}
+
+func mx_enc_map_string_int_c20ee031(enc *codegen.Encoder, arg map[string]int) {
+	if arg == nil {
+		enc.Len(-1)
+		return
+	}
+	enc.Len(len(arg))
+	for k, v := range arg {
+		enc.String(k)
+		enc.Int(v)
+	}
+}
+
+func mx_dec_map_string_int_c20ee031(dec *codegen.Decoder) map[string]int {
+	n := dec.Len()
+	if n == -1 {
+		return nil
+	}
+	res := make(map[string]int, n)
+	var k string
+	var v int
+	for i := 0; i < n; i++ {
+		k = dec.String()
+		v = dec.Int()
+		res[k] = v
+	}
+	return res
+}
@@ -0,0 +1,171 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runtime
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/BurntSushi/toml"
+)
+
+// The tables of a component's config section that configure the calls to the
+// component, rather than the component itself. For example:
+//
+//	["github.com/example/Cache"]
+//	size = 100 # the config of the component
+//	circuit_breaker = {failures = 5, timeout = "10s"}
+//	retry_budget = {ratio = 0.1}
+//	admission = {max_concurrency = 100, max_queue = 50}
+//	allowed_callers = ["github.com/example/Frontend"]
+const (
+	circuitBreakerKey = "circuit_breaker"
+	retryBudgetKey    = "retry_budget"
+	admissionKey      = "admission"
+	allowedCallersKey = "allowed_callers"
+)
+
+// CallConfig configures the calls to a component.
+type CallConfig struct {
+	CircuitBreaker *CircuitBreakerConfig `toml:"circuit_breaker"`
+	RetryBudget    *RetryBudgetConfig    `toml:"retry_budget"`
+	Admission      *AdmissionConfig      `toml:"admission"`
+
+	// If not nil, the full names of the only components allowed to call the
+	// component, among the components that have an mx.Ref to it.
+	AllowedCallers []string `toml:"allowed_callers"`
+}
+
+// CircuitBreakerConfig configures the circuit breaker of the calls to a
+// component. See call.CircuitBreakerOptions for details.
+type CircuitBreakerConfig struct {
+	Failures      int
+	Timeout       time.Duration
+	HalfOpenCalls int `toml:"half_open_calls"`
+}
+
+// RetryBudgetConfig configures the retry budget of the calls to a component.
+// See call.RetryBudgetOptions for details.
+type RetryBudgetConfig struct {
+	Ratio        float64
+	MinPerSecond float64 `toml:"min_per_second"`
+}
+
+// AdmissionConfig configures the admission control of the calls to the
+// methods of a component, by the replicas of the component. The limits apply
+// to every method separately, unless they are overridden for the method in
+// Methods. See call.AdmissionOptions for details.
+type AdmissionConfig struct {
+	AdmissionLimits
+	Methods map[string]AdmissionLimits // keyed by method name (e.g., "Get")
+}
+
+// AdmissionLimits are the admission control limits of a method.
+type AdmissionLimits struct {
+	MaxConcurrency int  `toml:"max_concurrency"`
+	MaxQueue       int  `toml:"max_queue"`
+	Adaptive       bool `toml:"adaptive"`
+}
+
+// Limits returns the admission control limits of the provided method.
+func (c *AdmissionConfig) Limits(method string) AdmissionLimits {
+	if c == nil {
+		return AdmissionLimits{}
+	}
+	if limits, ok := c.Methods[method]; ok {
+		return limits
+	}
+	return c.AdmissionLimits
+}
+
+// validate validates the limits.
+func (l AdmissionLimits) validate() error {
+	if l.MaxConcurrency < 0 {
+		return fmt.Errorf("negative max_concurrency %d", l.MaxConcurrency)
+	}
+	if l.MaxQueue < 0 {
+		return fmt.Errorf("negative max_queue %d", l.MaxQueue)
+	}
+	return nil
+}
+
+// Validate validates the config.
+func (c *CallConfig) Validate() error {
+	if b := c.CircuitBreaker; b != nil {
+		if b.Failures < 0 {
+			return fmt.Errorf("%s: negative failures %d", circuitBreakerKey, b.Failures)
+		}
+		if b.Timeout < 0 {
+			return fmt.Errorf("%s: negative timeout %v", circuitBreakerKey, b.Timeout)
+		}
+		if b.HalfOpenCalls < 0 {
+			return fmt.Errorf("%s: negative half_open_calls %d", circuitBreakerKey, b.HalfOpenCalls)
+		}
+	}
+	if b := c.RetryBudget; b != nil {
+		if b.MinPerSecond < 0 {
+			return fmt.Errorf("%s: negative min_per_second %v", retryBudgetKey, b.MinPerSecond)
+		}
+	}
+	if a := c.Admission; a != nil {
+		if err := a.validate(); err != nil {
+			return fmt.Errorf("%s: %w", admissionKey, err)
+		}
+		for method, limits := range a.Methods {
+			if err := limits.validate(); err != nil {
+				return fmt.Errorf("%s: method %s: %w", admissionKey, method, err)
+			}
+		}
+	}
+	for _, caller := range c.AllowedCallers {
+		if caller == "" {
+			return fmt.Errorf("%s: empty component name", allowedCallersKey)
+		}
+	}
+	return nil
+}
+
+// isCallKey returns whether k is a key of one of the tables of a component's
+// config section that configure the calls to the component.
+func isCallKey(k toml.Key) bool {
+	if len(k) == 0 {
+		return false
+	}
+	switch k[0] {
+	case circuitBreakerKey, retryBudgetKey, admissionKey, allowedCallersKey:
+		return true
+	}
+	return false
+}
+
+// ParseComponentConfigSection parses the config section of the component
+// with the provided name into dst, like ParseConfigSection. The tables of the
+// section that configure the calls to the component (see CallConfig) are
+// ignored.
+func ParseComponentConfigSection(name string, sections map[string]string, dst any) error {
+	return parseConfigSection(name, "", sections, dst, isCallKey)
+}
+
+// ParseCallConfig parses the tables of the config section of the component
+// with the provided name that configure the calls to the component. The rest
+// of the section is ignored.
+func ParseCallConfig(name string, sections map[string]string) (*CallConfig, error) {
+	config := &CallConfig{}
+	ignore := func(k toml.Key) bool { return !isCallKey(k) }
+	if err := parseConfigSection(name, "", sections, config, ignore); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
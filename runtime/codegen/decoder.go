@@ -17,10 +17,10 @@ package codegen
 import (
 	"encoding"
 	"encoding/binary"
-	"errors"
 	"fmt"
 	"math"
 	"reflect"
+	"unsafe"
 
 	"google.golang.org/protobuf/proto"
 )
@@ -43,12 +43,23 @@ func (d decoderError) Unwrap() error {
 
 // Decoder deserializes data from a byte slice data in the expected results.
 type Decoder struct {
-	data []byte
+	data     []byte
+	zeroCopy bool // decode strings and byte slices without copying data?
 }
 
-// NewDecoder instantiates a new Decoder for a given byte slice.
+// NewDecoder instantiates a new Decoder for a given byte slice. Decoded
+// strings and byte slices are copied out of data, so data may be reused once
+// decoding is done.
 func NewDecoder(data []byte) *Decoder {
-	return &Decoder{data}
+	return &Decoder{data: data}
+}
+
+// NewZeroCopyDecoder instantiates a new Decoder for a given byte slice.
+// Unlike NewDecoder, decoded strings and byte slices point into data rather
+// than being copied out of it. The caller must not modify or reuse data
+// after decoding.
+func NewZeroCopyDecoder(data []byte) *Decoder {
+	return &Decoder{data: data, zeroCopy: true}
 }
 
 // Empty returns true iff all bytes in d have been consumed.
@@ -63,7 +74,8 @@ func makeDecodeError(format string, args ...interface{}) decoderError {
 
 // DecodeProto deserializes the value from a byte slice using proto serialization.
 func (d *Decoder) DecodeProto(value proto.Message) {
-	if err := proto.Unmarshal(d.Bytes(), value); err != nil {
+	// proto.Unmarshal copies what it retains, so there is no need to copy.
+	if err := proto.Unmarshal(d.bytes(), value); err != nil {
 		panic(makeDecodeError("error decoding to proto %T: %w", value, err))
 	}
 }
@@ -71,13 +83,14 @@ func (d *Decoder) DecodeProto(value proto.Message) {
 // DecodeBinaryUnmarshaler deserializes the value from a byte slice using
 // UnmarshalBinary.
 func (d *Decoder) DecodeBinaryUnmarshaler(value encoding.BinaryUnmarshaler) {
-	if err := value.UnmarshalBinary(d.Bytes()); err != nil {
+	// UnmarshalBinary must copy what it retains, so there is no need to copy.
+	if err := value.UnmarshalBinary(d.bytes()); err != nil {
 		panic(makeDecodeError("error decoding BinaryUnmarshaler %T: %w", value, err))
 	}
 }
 
 // Read reads and returns n bytes from the decoder and advances the decode past
-// the read bytes.
+// the read bytes. The returned bytes are not copied out of the decoder's data.
 func (d *Decoder) Read(n int) []byte {
 	if len := len(d.data); len < n {
 		panic(makeDecodeError("unable to read #bytes: %d", n))
@@ -182,11 +195,24 @@ func (d *Decoder) Complex128() complex128 {
 
 // String decodes a value of type string.
 func (d *Decoder) String() string {
-	return string(d.Bytes())
+	b := d.bytes()
+	if d.zeroCopy && len(b) > 0 {
+		return unsafe.String(&b[0], len(b))
+	}
+	return string(b)
 }
 
 // Bytes decodes a value of type []byte.
 func (d *Decoder) Bytes() []byte {
+	b := d.bytes()
+	if d.zeroCopy || b == nil {
+		return b
+	}
+	return append(make([]byte, 0, len(b)), b...)
+}
+
+// bytes decodes a value of type []byte, without copying it out of d.data.
+func (d *Decoder) bytes() []byte {
 	n := d.Int32()
 
 	// n == -1 means a nil slice.
@@ -213,41 +239,45 @@ func (d *Decoder) Len() int {
 	return n
 }
 
-// Error decodes an error. We construct an instance of a special error value
-// that provides Is and Unwrap support.
+// Error decodes an error. Errors of registered serializable types are decoded
+// as values of their original types. Other errors are decoded as instances of
+// a special error value that provides Is and Unwrap support.
 func (d *Decoder) Error() error {
-	// Decode the list of errors produced by Encoder.Error().
-	var list []error
-	for {
-		tag := d.Uint8()
-		if tag == endOfErrors {
-			break
-		} else if tag == serializedErrorVal {
-			val := d.Interface()
-			if e, ok := val.(error); ok {
-				list = append(list, e)
-				continue
-			}
-			panic(fmt.Sprintf("received type %T which is not an error", val))
-		} else if tag == serializedErrorPtr {
-			val := d.Interface()
-			if e, ok := pointee(val).(error); ok {
-				list = append(list, e)
-				continue
+	tag := d.Uint8()
+	if tag == endOfErrors {
+		return nil
+	}
+	return d.errorNode(tag)
+}
+
+// errorNode decodes an error encoded by Encoder.Error, whose tag has already
+// been decoded.
+func (d *Decoder) errorNode(tag uint8) error {
+	switch tag {
+	case serializedErrorVal:
+		val := d.Interface()
+		if e, ok := val.(error); ok {
+			return e
+		}
+		panic(fmt.Sprintf("received type %T which is not an error", val))
+	case serializedErrorPtr:
+		val := d.Interface()
+		if e, ok := pointee(val).(error); ok {
+			return e
+		}
+		panic(fmt.Sprintf("received type %T which is not a pointer to error", val))
+	case emulatedError:
+		e := &decodedError{msg: d.String(), fmt: d.String()}
+		for {
+			tag := d.Uint8()
+			if tag == endOfErrors {
+				return e
 			}
-			panic(fmt.Sprintf("received type %T which is not a pointer to error", val))
-		} else if tag == emulatedError {
-			msg := d.String()
-			f := d.String()
-			list = append(list, decodedError{msg, f})
-		} else {
-			panic(fmt.Sprintf("invalid error list tag %d", tag))
+			e.wrapped = append(e.wrapped, d.errorNode(tag))
 		}
+	default:
+		panic(fmt.Sprintf("invalid error list tag %d", tag))
 	}
-	if len(list) == 1 {
-		return list[0] // Preserve original error instead of wrapping it via Join
-	}
-	return errors.Join(list...)
 }
 
 // Interface decodes a value encoded by Encoder.Interface.
@@ -256,8 +286,8 @@ func (d *Decoder) Error() error {
 func (d *Decoder) Interface() any {
 	key := d.String()
 	typesMu.Lock()
-	defer typesMu.Unlock()
 	t, ok := types[key]
+	typesMu.Unlock()
 	if !ok {
 		panic(fmt.Sprintf("received value for non-registered type %q", key))
 	}
@@ -273,7 +303,7 @@ func (d *Decoder) Interface() any {
 	if !ok {
 		panic(fmt.Sprintf("received value for non-serializable type %v", t))
 	}
-	am.MXUnmarshal(d)
+	am.MXUnmarshal(d) // may decode nested values, e.g., wrapped errors
 
 	result := ptr
 	if t.Kind() != reflect.Pointer {
@@ -285,24 +315,31 @@ func (d *Decoder) Interface() any {
 // decodedError is an error used for non-serializable decoded errors.
 // It supports Error() by returning the Error() string precomputed at
 // the send. It partially supports Is() by comparing the string
-// representation of the value.
+// representation of the value. It supports Unwrap() by returning the decoded
+// errors wrapped by the original error.
 type decodedError struct {
-	msg string // Error() result
-	fmt string // Result of fmtError
+	msg     string  // Error() result
+	fmt     string  // Result of fmtError
+	wrapped []error // Errors wrapped by the original error
 }
 
-var _ error = decodedError{}
+var _ error = &decodedError{}
 
 // Error implements error.Error.
-func (e decodedError) Error() string { return e.msg }
+func (e *decodedError) Error() string { return e.msg }
 
 // Is returns true if the representation of this error is the same as the
 // representation of the target error. Note that this is not the same
 // as the normal specification of errors.Is, but is the best we can do.
-func (e decodedError) Is(target error) bool {
+func (e *decodedError) Is(target error) bool {
 	return e.fmt == fmtError(target)
 }
 
+// Unwrap returns the errors wrapped by the original error.
+func (e *decodedError) Unwrap() []error {
+	return e.wrapped
+}
+
 // fmtError serializes an error value including its type info using fmt.Sprintf.
 func fmtError(v error) string {
 	// Include package and type info explicitly since %#v uses a shortened path.
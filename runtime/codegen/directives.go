@@ -0,0 +1,178 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codegen
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// MethodDirectives holds the options set by //mx: directives on a method of a
+// component interface. For example, the following directives
+//
+//	type Cache interface {
+//	    //mx:timeout=2s
+//	    //mx:cache=30s
+//	    Get(context.Context, string) (string, error)
+//	}
+//
+// result in MethodDirectives{Timeout: 2 * time.Second, Cache: 30 * time.Second}
+// for method Get. The //mx:hedge directive is recorded in Registration.Hedged
+// instead.
+type MethodDirectives struct {
+	Timeout    time.Duration // if positive, calls fail after Timeout
+	Cache      time.Duration // if positive, successful results are reused for Cache
+	Idempotent bool          // the method can safely execute more than once; not enforced
+	Deprecated bool          // calls log a warning
+}
+
+// maxCacheEntries is the maximum number of results cached per method and
+// caller for methods with a //mx:cache directive.
+const maxCacheEntries = 1024
+
+// ApplyDirectives returns a value that implements the component interface of
+// reg by enforcing the method directives of reg on calls to stub: it bounds
+// the duration of calls, reuses cached results, and logs calls to deprecated
+// methods to logger. If no directive of reg needs enforcing, stub is returned
+// unchanged.
+func ApplyDirectives(reg *Registration, caller string, stub any, logger *slog.Logger) any {
+	enforced := false
+	for _, d := range reg.Directives {
+		if d.Timeout > 0 || d.Cache > 0 || d.Deprecated {
+			enforced = true
+			break
+		}
+	}
+	if !enforced {
+		return stub
+	}
+
+	type method struct {
+		fn         reflect.Value
+		directives MethodDirectives
+		deprecated sync.Once
+		cache      *resultCache // nil if results are not cached
+	}
+	v := reflect.ValueOf(stub)
+	methods := make(map[string]*method, reg.Iface.NumMethod())
+	for i := 0; i < reg.Iface.NumMethod(); i++ {
+		name := reg.Iface.Method(i).Name
+		fn := v.MethodByName(name)
+		if !fn.IsValid() {
+			panic(fmt.Errorf("%T does not implement method %s of %v", stub, name, reg.Iface))
+		}
+		m := &method{fn: fn, directives: reg.Directives[i]}
+		if m.directives.Cache > 0 {
+			m.cache = &resultCache{ttl: m.directives.Cache, entries: map[any]cacheEntry{}}
+		}
+		methods[name] = m
+	}
+
+	return reg.ReflectStubFn(func(name string, ctx context.Context, args []any, returns []any) error {
+		m, ok := methods[name]
+		if !ok {
+			return fmt.Errorf("component %s has no method %s", reg.Name, name)
+		}
+		if m.directives.Deprecated {
+			m.deprecated.Do(func() {
+				logger.Warn("Call to deprecated method", "component", reg.Name, "method", name, "caller", caller)
+			})
+		}
+
+		var key any
+		if m.cache != nil {
+			var ok bool
+			if key, ok = cacheKey(args); ok {
+				if results, ok := m.cache.get(key); ok {
+					setReturns(returns, results)
+					return nil
+				}
+			}
+		}
+
+		if m.directives.Timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, m.directives.Timeout)
+			defer cancel()
+		}
+		results, err := invokeMethod(m.fn, ctx, args)
+		if err == nil && key != nil {
+			m.cache.put(key, results)
+		}
+		setReturns(returns, results)
+		return err
+	})
+}
+
+// resultCache caches the results of the calls to a method, keyed by the call
+// arguments. A resultCache is safe for concurrent use.
+type resultCache struct {
+	ttl     time.Duration
+	mu      sync.Mutex
+	entries map[any]cacheEntry
+}
+
+type cacheEntry struct {
+	results []any
+	expires time.Time
+}
+
+// get returns the unexpired results cached for the provided key.
+func (c *resultCache) get(key any) ([]any, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[key]
+	if !ok || time.Now().After(e.expires) {
+		return nil, false
+	}
+	return e.results, true
+}
+
+// put caches results for the provided key. If the cache is full, expired
+// entries are dropped first, and results are not cached if that doesn't free
+// any space.
+func (c *resultCache) put(key any, results []any) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now()
+	if len(c.entries) >= maxCacheEntries {
+		for k, e := range c.entries {
+			if now.After(e.expires) {
+				delete(c.entries, k)
+			}
+		}
+		if len(c.entries) >= maxCacheEntries {
+			return
+		}
+	}
+	c.entries[key] = cacheEntry{results: results, expires: now.Add(c.ttl)}
+}
+
+// cacheKey returns a map key that identifies the provided call arguments. It
+// returns false if an argument is not comparable.
+func cacheKey(args []any) (any, bool) {
+	key := reflect.New(reflect.ArrayOf(len(args), reflect.TypeFor[any]())).Elem()
+	for i, arg := range args {
+		if arg != nil && !reflect.ValueOf(arg).Comparable() {
+			return nil, false
+		}
+		key.Index(i).Set(reflect.ValueOf(&arg).Elem())
+	}
+	return key.Interface(), true
+}
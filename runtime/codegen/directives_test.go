@@ -0,0 +1,179 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codegen
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type store interface {
+	Get(ctx context.Context, key string) (int, error)
+	Old(ctx context.Context) error
+	Wait(ctx context.Context) error
+}
+
+type storeImpl struct {
+	gets int
+}
+
+func (s *storeImpl) Get(_ context.Context, key string) (int, error) {
+	s.gets++
+	if key == "" {
+		return 0, errors.New("empty key")
+	}
+	return len(key), nil
+}
+
+func (s *storeImpl) Old(context.Context) error { return nil }
+
+func (s *storeImpl) Wait(ctx context.Context) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+// storeReflectStub is a hand-written equivalent of a generated reflect stub.
+type storeReflectStub struct {
+	caller func(string, context.Context, []any, []any) error
+}
+
+func (s storeReflectStub) Get(ctx context.Context, a0 string) (r0 int, err error) {
+	err = s.caller("Get", ctx, []any{a0}, []any{&r0})
+	return
+}
+
+func (s storeReflectStub) Old(ctx context.Context) (err error) {
+	err = s.caller("Old", ctx, []any{}, []any{})
+	return
+}
+
+func (s storeReflectStub) Wait(ctx context.Context) (err error) {
+	err = s.caller("Wait", ctx, []any{}, []any{})
+	return
+}
+
+// storeReg returns the registration of store with the provided directives
+// for methods Get, Old, and Wait.
+func storeReg(get, old, wait MethodDirectives) *Registration {
+	return &Registration{
+		Name:       "store",
+		Iface:      reflect.TypeOf((*store)(nil)).Elem(),
+		Directives: []MethodDirectives{get, old, wait},
+		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
+			return storeReflectStub{caller}
+		},
+	}
+}
+
+func TestDirectivesCache(t *testing.T) {
+	ctx := context.Background()
+	impl := &storeImpl{}
+	reg := storeReg(MethodDirectives{Cache: time.Hour}, MethodDirectives{}, MethodDirectives{})
+	s := ApplyDirectives(reg, "caller", impl, slog.Default()).(store)
+
+	for _, test := range []struct {
+		key      string
+		want     int
+		wantErr  bool
+		wantGets int
+	}{
+		{"a", 1, false, 1},
+		{"a", 1, false, 1}, // cached
+		{"bb", 2, false, 2},
+		{"", 0, true, 3},
+		{"", 0, true, 4}, // errors are not cached
+		{"bb", 2, false, 4},
+	} {
+		got, err := s.Get(ctx, test.key)
+		if got != test.want || (err != nil) != test.wantErr {
+			t.Errorf("Get(%q): got (%d, %v), want (%d, error=%t)", test.key, got, err, test.want, test.wantErr)
+		}
+		if impl.gets != test.wantGets {
+			t.Errorf("Get(%q): got %d calls, want %d", test.key, impl.gets, test.wantGets)
+		}
+	}
+}
+
+func TestDirectivesCacheExpiry(t *testing.T) {
+	ctx := context.Background()
+	impl := &storeImpl{}
+	reg := storeReg(MethodDirectives{Cache: time.Millisecond}, MethodDirectives{}, MethodDirectives{})
+	s := ApplyDirectives(reg, "caller", impl, slog.Default()).(store)
+	s.Get(ctx, "a")
+	time.Sleep(10 * time.Millisecond)
+	s.Get(ctx, "a")
+	if impl.gets != 2 {
+		t.Errorf("Get: got %d calls, want 2", impl.gets)
+	}
+}
+
+func TestDirectivesTimeout(t *testing.T) {
+	reg := storeReg(MethodDirectives{}, MethodDirectives{}, MethodDirectives{Timeout: 10 * time.Millisecond})
+	s := ApplyDirectives(reg, "caller", &storeImpl{}, slog.Default()).(store)
+	if err := s.Wait(context.Background()); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Wait: got %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestDirectivesDeprecated(t *testing.T) {
+	var b bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&b, nil))
+	reg := storeReg(MethodDirectives{}, MethodDirectives{Deprecated: true}, MethodDirectives{})
+	s := ApplyDirectives(reg, "caller", &storeImpl{}, logger).(store)
+	for i := 0; i < 3; i++ {
+		if err := s.Old(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := s.Get(context.Background(), "a"); err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Count(b.String(), "deprecated"); got != 1 {
+		t.Fatalf("got %d warnings, want 1:\n%s", got, b.String())
+	}
+	if !strings.Contains(b.String(), "method=Old") {
+		t.Fatalf("warning doesn't name method Old:\n%s", b.String())
+	}
+}
+
+func TestDirectivesNone(t *testing.T) {
+	impl := &storeImpl{}
+	reg := storeReg(MethodDirectives{Idempotent: true}, MethodDirectives{}, MethodDirectives{})
+	if got := ApplyDirectives(reg, "caller", impl, slog.Default()); got != any(impl) {
+		t.Fatalf("ApplyDirectives without enforced directives: got %T, want %T", got, impl)
+	}
+}
+
+func TestCacheKey(t *testing.T) {
+	type point struct{ X, Y int }
+	k1, ok1 := cacheKey([]any{"a", point{1, 2}, nil})
+	k2, ok2 := cacheKey([]any{"a", point{1, 2}, nil})
+	k3, ok3 := cacheKey([]any{"a", point{2, 1}, nil})
+	if !ok1 || !ok2 || !ok3 {
+		t.Fatalf("cacheKey: comparable arguments are not comparable")
+	}
+	if k1 != k2 || k1 == k3 {
+		t.Errorf("cacheKey: got %v == %v == %v", k1, k2, k3)
+	}
+	if _, ok := cacheKey([]any{[]int{1}}); ok {
+		t.Errorf("cacheKey: slice argument is comparable")
+	}
+}
@@ -19,6 +19,8 @@ import (
 	"encoding/binary"
 	"fmt"
 	"math"
+	"reflect"
+	"sync"
 
 	"google.golang.org/protobuf/proto"
 )
@@ -51,6 +53,33 @@ func NewEncoder() *Encoder {
 	return &enc
 }
 
+// maxPooledEncoderSize is the largest buffer capacity retained by a pooled
+// encoder. Larger buffers are dropped to avoid pinning rarely used memory.
+const maxPooledEncoderSize = 64 << 10
+
+// encoderPool holds encoders released by PutEncoder.
+var encoderPool = sync.Pool{
+	New: func() any { return NewEncoder() },
+}
+
+// GetEncoder returns an empty Encoder from a pool of encoders. The encoder
+// should be returned to the pool with PutEncoder when its data is no longer
+// needed.
+func GetEncoder() *Encoder {
+	return encoderPool.Get().(*Encoder)
+}
+
+// PutEncoder returns an encoder obtained from GetEncoder to the pool. Neither
+// e nor the slice returned by e.Data() may be used after the call.
+func PutEncoder(e *Encoder) {
+	if cap(e.data) > maxPooledEncoderSize {
+		e.data = e.space[:0]
+	} else {
+		e.data = e.data[:0]
+	}
+	encoderPool.Put(e)
+}
+
 // Reset resets the Encoder to use a buffer with a capacity of at least the
 // provided size. All encoded data is lost.
 func (e *Encoder) Reset(n int) {
@@ -251,19 +280,24 @@ func (e *Encoder) Len(l int) {
 
 // Error encoding
 //
-// An error can be composed of a tree of errors (see the errors package)
-// We encode such a tree in a linear list of errors visited in depth-first
-// order.
+// An error can be composed of a tree of errors (see the errors package). We
+// encode such a tree in depth-first order, preserving its shape so that the
+// decoded error has the same message and the same wrapped errors as the
+// original error.
 //
-// The encoding is a list of encoded errors terminated by a <endOfErrors>.  An
-// encoded error is one of:
+// A nil error is encoded as <endOfErrors>. A non-nil error is encoded as one
+// of:
 //
 // <serializedErrorVal,typKey,serial> for registered serializable error types.
 //
 // <serializedErrorPtr,typeKey,serial> where a pointer to an error has been
 // registered as serializable.
 //
-// <emulatedError,message,fmtError> for unregistered error types.
+// <emulatedError,message,fmtError,child...,endOfErrors> for unregistered
+// error types, where every child is an encoded error wrapped by the error.
+//
+// Note that the errors wrapped by a serializable error are not encoded
+// separately, since the serialized form should contain all of them.
 const (
 	endOfErrors        uint8 = 0
 	serializedErrorVal uint8 = 1
@@ -274,27 +308,16 @@ const (
 // Error encodes an arg of type error. We save enough type information
 // to allow errors.Unwrap(), errors.Is(), and errors.As() to work correctly.
 func (e *Encoder) Error(err error) {
-	// Convert the tree of wrapped errors into a single list.
-	// We do not use errors.Unwrap() since it does not visit the
-	// children found by "Unwrap()[]error".
-	//
-	// The encoding is a list of encoded errors terminated by a <0>.
-	// An encoded error is either <1,typKey,serialization> for
-	// registered custom error types, or <2,message,fmtError> for
-	// unregistered error types.
+	if err == nil {
+		e.Uint8(endOfErrors)
+		return
+	}
+
+	// We do not use errors.Unwrap() since it does not visit the children
+	// found by "Unwrap() []error".
 	seen := map[error]struct{}{}
 	var dfs func(error)
 	dfs = func(err error) {
-		if err == nil {
-			return
-		}
-
-		// Avoid cycles and potential exponential expansion of diamond patterns.
-		if _, ok := seen[err]; ok {
-			return
-		}
-		seen[err] = struct{}{}
-
 		// If err can be marshaled, do that and skip extracting its children
 		// since serialized form should contain all of them.
 		if am, ok := err.(AutoMarshal); ok {
@@ -312,22 +335,46 @@ func (e *Encoder) Error(err error) {
 		}
 
 		// Send the error message and the representation of err so we
-		// can do plain comparisons at the other end.
+		// can do plain comparisons at the other end. A previously decoded
+		// error (e.g., one that is being relayed to another process) keeps
+		// the representation of the original error.
 		e.Uint8(emulatedError)
 		e.String(err.Error())
-		e.String(fmtError(err))
+		if d, ok := err.(*decodedError); ok {
+			e.String(d.fmt)
+		} else {
+			e.String(fmtError(err))
+		}
 
+		var children []error
 		switch u := err.(type) {
 		case interface{ Unwrap() error }:
-			dfs(u.Unwrap())
+			children = []error{u.Unwrap()}
 		case interface{ Unwrap() []error }:
-			for _, child := range u.Unwrap() {
-				dfs(child)
+			children = u.Unwrap()
+		}
+		for _, child := range children {
+			if child == nil {
+				continue
+			}
+
+			// Avoid cycles and potential exponential expansion of diamond
+			// patterns. Errors that are not comparable cannot be part of a
+			// cycle and are always visited.
+			if reflect.TypeOf(child).Comparable() {
+				if _, ok := seen[child]; ok {
+					continue
+				}
+				seen[child] = struct{}{}
 			}
+			dfs(child)
 		}
+		e.Uint8(endOfErrors)
+	}
+	if reflect.TypeOf(err).Comparable() {
+		seen[err] = struct{}{}
 	}
 	dfs(err)
-	e.Uint8(endOfErrors)
 }
 
 // Interface encodes value prefixed with its concrete type.
@@ -124,6 +124,71 @@ func TestEncodeDecodeRandom(t *testing.T) {
 	}
 }
 
+// TestDecoderCopies checks that values decoded by NewDecoder don't alias the
+// decoded bytes, and that values decoded by NewZeroCopyDecoder do.
+func TestDecoderCopies(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		newDec  func([]byte) *Decoder
+		aliased bool
+	}{
+		{"Copy", NewDecoder, false},
+		{"ZeroCopy", NewZeroCopyDecoder, true},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			enc := newEncoder()
+			enc.Bytes([]byte("bytes"))
+			enc.String("string")
+			enc.Bytes(nil)
+			enc.Bytes([]byte{})
+			data := enc.Data()
+
+			dec := test.newDec(data)
+			b, s := dec.Bytes(), dec.String()
+			if nilBytes := dec.Bytes(); nilBytes != nil {
+				t.Fatalf("got %v, want nil", nilBytes)
+			}
+			if emptyBytes := dec.Bytes(); emptyBytes == nil || len(emptyBytes) != 0 {
+				t.Fatalf("got %v, want empty slice", emptyBytes)
+			}
+
+			// Overwrite the decoded bytes.
+			for i := range data {
+				data[i] = 'x'
+			}
+			if got := string(b) != "bytes"; got != test.aliased {
+				t.Errorf("bytes %q: aliased = %t, want %t", b, got, test.aliased)
+			}
+			if got := s != "string"; got != test.aliased {
+				t.Errorf("string %q: aliased = %t, want %t", s, got, test.aliased)
+			}
+		})
+	}
+}
+
+// TestPooledEncoder checks that pooled encoders are returned empty.
+func TestPooledEncoder(t *testing.T) {
+	for _, n := range []int{10, maxPooledEncoderSize + 1} {
+		enc := GetEncoder()
+		enc.Reset(n)
+		enc.String("this is garbage text that will get reset")
+		PutEncoder(enc)
+
+		enc = GetEncoder()
+		if got := len(enc.Data()); got != 0 {
+			t.Fatalf("len(enc.Data()): got %d, want 0", got)
+		}
+		if got := cap(enc.Data()); got > maxPooledEncoderSize {
+			t.Fatalf("cap(enc.Data()): got %d, want at most %d", got, maxPooledEncoderSize)
+		}
+		enc.Int(42)
+		if got := NewDecoder(enc.Data()).Int(); got != 42 {
+			t.Fatalf("got %d, want 42", got)
+		}
+		PutEncoder(enc)
+	}
+}
+
 // convertCallPanicToError catches and returns errors detected during fn's execution.
 func convertCallPanicToError(fn func()) (err error) {
 	defer func() { err = CatchPanics(recover()) }()
@@ -139,7 +204,7 @@ func TestErrorDecUnableToRead(t *testing.T) {
 		enc := newEncoder()
 		enc.Int(12345)
 
-		dec := Decoder{enc.data}
+		dec := Decoder{data: enc.data}
 		dec.Int()
 		dec.Bool()
 	})
@@ -156,7 +221,7 @@ func TestErrorUnableToDecBool(t *testing.T) {
 		enc := newEncoder()
 		enc.Int(123)
 
-		dec := Decoder{enc.data}
+		dec := Decoder{data: enc.data}
 		dec.Bool()
 	})
 	if !strings.Contains(err.Error(), "unable to decode bool") {
@@ -172,7 +237,7 @@ func TestErrorUnableToDecBytes(t *testing.T) {
 		enc := newEncoder()
 		enc.Int(-10)
 
-		dec := Decoder{enc.data}
+		dec := Decoder{data: enc.data}
 		dec.Bytes()
 	})
 	if !strings.Contains(err.Error(), "unable to decode bytes; expected length") {
@@ -207,10 +272,32 @@ func (c *cyclicError) Unwrap() error          { return c }
 func (c *cyclicError) MXMarshal(e *Encoder)   { e.String(c.msg) }
 func (c *cyclicError) MXUnmarshal(d *Decoder) { c.msg = d.String() }
 
+// wrappingError is a serializable error that wraps another error.
+type wrappingError struct {
+	code int
+	err  error
+}
+
+func (w *wrappingError) Error() string { return fmt.Sprintf("wrapping(%d): %v", w.code, w.err) }
+func (w *wrappingError) Unwrap() error { return w.err }
+func (w *wrappingError) Is(target error) bool {
+	x, ok := target.(*wrappingError)
+	return ok && w.code == x.code
+}
+func (w *wrappingError) MXMarshal(e *Encoder) {
+	e.Int(w.code)
+	e.Error(w.err)
+}
+func (w *wrappingError) MXUnmarshal(d *Decoder) {
+	w.code = d.Int()
+	w.err = d.Error()
+}
+
 func init() {
 	RegisterSerializable[*customTestError]()
 	RegisterSerializable[*alternateError]()
 	RegisterSerializable[*cyclicError]()
+	RegisterSerializable[*wrappingError]()
 }
 
 func TestErrorValues(t *testing.T) {
@@ -229,6 +316,9 @@ func TestErrorValues(t *testing.T) {
 		{"wrap-custom", fmt.Errorf("hello %w", customTestError{"a"}), []error{customTestError{"a"}}, nil},
 		{"wrap-two", fmt.Errorf("hello %w %w", customTestError{"a"}, &alternateError{"b"}), []error{customTestError{"a"}, &alternateError{"b"}}, []error{customTestError{"other"}, &alternateError{"other"}}},
 		{"ptr", &alternateError{"a"}, []error{&alternateError{"a"}}, nil},
+		{"join", errors.Join(os.ErrNotExist, customTestError{"a"}), []error{os.ErrNotExist, customTestError{"a"}}, nil},
+		{"typed-wrap", &wrappingError{1, customTestError{"a"}}, []error{customTestError{"a"}}, []error{os.ErrNotExist}},
+		{"typed-chain", fmt.Errorf("hello %w", &wrappingError{2, fmt.Errorf("world %w", os.ErrNotExist)}), []error{os.ErrNotExist}, nil},
 	} {
 		t.Run(c.name, func(t *testing.T) {
 			// Encode/decode and get resulting error value.
@@ -268,10 +358,62 @@ func TestErrorValues(t *testing.T) {
 					t.Errorf("decoded error (%#v) (from %#v) does not match unwrapped error (%#v)", dst, src, u)
 				}
 			}
+
+			// Check that the error message is preserved.
+			if src != nil && dst.Error() != src.Error() {
+				t.Errorf("decoded error message %q, expecting %q", dst.Error(), src.Error())
+			}
 		})
 	}
 }
 
+func TestErrorAs(t *testing.T) {
+	// Encode/decode a chain of errors that holds serializable errors at
+	// various depths.
+	src := fmt.Errorf("hello %w", &wrappingError{1, fmt.Errorf("world %w", customTestError{"a"})})
+	enc := newEncoder()
+	enc.Error(src)
+	dec := Decoder{data: enc.data}
+	dst := dec.Error()
+	if !dec.Empty() {
+		t.Fatalf("leftover bytes in decoder")
+	}
+
+	var w *wrappingError
+	if !errors.As(dst, &w) {
+		t.Fatalf("decoded error (%v) is not a *wrappingError", dst)
+	}
+	if w.code != 1 {
+		t.Errorf("decoded *wrappingError has code %d, expecting 1", w.code)
+	}
+	var c customTestError
+	if !errors.As(w, &c) {
+		t.Fatalf("wrapped error (%v) is not a customTestError", w.err)
+	}
+	if c.f != "a" {
+		t.Errorf("decoded customTestError has f %q, expecting %q", c.f, "a")
+	}
+}
+
+func TestRelayedError(t *testing.T) {
+	// Encode and decode an error twice, as is done when a component relays an
+	// error returned by another component.
+	src := fmt.Errorf("hello %w", os.ErrNotExist)
+	dst := src
+	for i := 0; i < 2; i++ {
+		enc := newEncoder()
+		enc.Error(dst)
+		dec := Decoder{data: enc.data}
+		dst = dec.Error()
+		if !dec.Empty() {
+			t.Fatalf("leftover bytes in decoder")
+		}
+	}
+	if !errors.Is(dst, os.ErrNotExist) {
+		t.Errorf("relayed error (%v) does not match target (%v)", dst, os.ErrNotExist)
+	}
+}
+
 func TestCyclicError(t *testing.T) {
 	// Special test for cyclic errors since errors.Is etc. can get
 	// into an infinite loop on cycles.
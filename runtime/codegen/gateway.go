@@ -0,0 +1,69 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codegen
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// GatewayMethod is a component method that can be called through an HTTP+JSON
+// gateway. Gateway methods are generated by "mx generate -gateway".
+type GatewayMethod struct {
+	Name   string // method name
+	Args   string // JSON schema of the method's arguments
+	Result string // JSON schema of the method's results
+
+	// Call decodes the JSON-encoded arguments, calls the method, and returns
+	// its results. If the method has a single result (other than its error),
+	// Call returns it. If it has several, Call returns them in a []any.
+	Call func(ctx context.Context, args []byte) (any, error)
+}
+
+// GatewayArgsError is the error returned by GatewayMethod.Call when the
+// arguments of a call cannot be decoded.
+type GatewayArgsError struct {
+	err error
+}
+
+// Error implements the error interface.
+func (e GatewayArgsError) Error() string {
+	return fmt.Sprintf("bad arguments: %v", e.err)
+}
+
+// Unwrap returns the underlying error.
+func (e GatewayArgsError) Unwrap() error {
+	return e.err
+}
+
+// DecodeGatewayArgs decodes the JSON object in data into args, which must be a
+// pointer to a struct with a field per method argument. Empty data decodes
+// into zero arguments. Unknown arguments are rejected.
+func DecodeGatewayArgs(data []byte, args any) error {
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(args); err != nil {
+		return GatewayArgsError{err}
+	}
+	if dec.More() {
+		return GatewayArgsError{fmt.Errorf("unexpected data after the arguments")}
+	}
+	return nil
+}
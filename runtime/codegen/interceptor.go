@@ -0,0 +1,190 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codegen
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// MethodCall describes a single component method call observed by an
+// Interceptor.
+type MethodCall struct {
+	Component string // full package-prefixed component name
+	Method    string // method name
+	Caller    string // calling component, or "root"; empty in server interceptors
+
+	// Args holds the method arguments, excluding the leading context. An
+	// interceptor may replace arguments before calling invoke.
+	Args []any
+
+	// Results holds the method results, excluding the trailing error. It is
+	// populated when invoke returns, and an interceptor may replace results
+	// before returning. A replacement must have the declared result type.
+	Results []any
+}
+
+// Interceptor intercepts a component method call. An interceptor must call
+// invoke to continue the call, typically with the context it was passed or
+// one derived from it. An interceptor that doesn't call invoke must return a
+// non-nil error.
+type Interceptor func(ctx context.Context, call *MethodCall, invoke func(context.Context) error) error
+
+var (
+	interceptorsMu     sync.Mutex
+	clientInterceptors []Interceptor
+	serverInterceptors []Interceptor
+)
+
+// RegisterClientInterceptor registers an interceptor that runs in the caller
+// of every component method call. Interceptors run in registration order, the
+// first registered being the outermost.
+func RegisterClientInterceptor(i Interceptor) {
+	interceptorsMu.Lock()
+	defer interceptorsMu.Unlock()
+	clientInterceptors = append(clientInterceptors, i)
+}
+
+// RegisterServerInterceptor registers an interceptor that runs in the callee
+// of every component method call. Interceptors run in registration order, the
+// first registered being the outermost.
+func RegisterServerInterceptor(i Interceptor) {
+	interceptorsMu.Lock()
+	defer interceptorsMu.Unlock()
+	serverInterceptors = append(serverInterceptors, i)
+}
+
+// InterceptClient returns a value that implements the component interface of
+// reg by running the registered client interceptors around calls to stub. If
+// no client interceptors are registered, stub is returned unchanged.
+func InterceptClient(reg *Registration, caller string, stub any) any {
+	interceptorsMu.Lock()
+	chain := clientInterceptors
+	interceptorsMu.Unlock()
+	return intercept(reg, caller, stub, chain)
+}
+
+// InterceptServer returns a value that implements the component interface of
+// reg by running the registered server interceptors around calls to impl. If
+// no server interceptors are registered, impl is returned unchanged.
+func InterceptServer(reg *Registration, impl any) any {
+	interceptorsMu.Lock()
+	chain := serverInterceptors
+	interceptorsMu.Unlock()
+	return intercept(reg, "", impl, chain)
+}
+
+// intercept wraps target, which implements the component interface of reg,
+// with the provided chain of interceptors.
+func intercept(reg *Registration, caller string, target any, chain []Interceptor) any {
+	if len(chain) == 0 {
+		return target
+	}
+
+	// Resolve the component methods once, up front.
+	v := reflect.ValueOf(target)
+	methods := make(map[string]reflect.Value, reg.Iface.NumMethod())
+	for i := 0; i < reg.Iface.NumMethod(); i++ {
+		name := reg.Iface.Method(i).Name
+		m := v.MethodByName(name)
+		if !m.IsValid() {
+			panic(fmt.Errorf("%T does not implement method %s of %v", target, name, reg.Iface))
+		}
+		methods[name] = m
+	}
+
+	return reg.ReflectStubFn(func(method string, ctx context.Context, args []any, returns []any) error {
+		m, ok := methods[method]
+		if !ok {
+			return fmt.Errorf("component %s has no method %s", reg.Name, method)
+		}
+		call := &MethodCall{
+			Component: reg.Name,
+			Method:    method,
+			Caller:    caller,
+			Args:      args,
+		}
+		invoke := func(ctx context.Context) error {
+			var err error
+			call.Results, err = invokeMethod(m, ctx, call.Args)
+			return err
+		}
+		for i := len(chain) - 1; i >= 0; i-- {
+			interceptor, next := chain[i], invoke
+			invoke = func(ctx context.Context) error {
+				return interceptor(ctx, call, next)
+			}
+		}
+		err := invoke(ctx)
+		setReturns(returns, call.Results)
+		return err
+	})
+}
+
+// setReturns stores results in the return values of a reflect stub call. Note
+// that returns[i] has static type any but dynamic type *T for some T.
+func setReturns(returns, results []any) {
+	for i, r := range returns {
+		if i >= len(results) {
+			break
+		}
+		dst := reflect.ValueOf(r).Elem()
+		if results[i] == nil {
+			dst.SetZero()
+		} else {
+			dst.Set(reflect.ValueOf(results[i]))
+		}
+	}
+}
+
+// invokeMethod calls m with ctx and args. It returns the non-error results and
+// the error returned by m.
+func invokeMethod(m reflect.Value, ctx context.Context, args []any) ([]any, error) {
+	t := m.Type()
+	if len(args) != t.NumIn()-1 {
+		return nil, fmt.Errorf("invalid number of arguments: want %d, got %d", t.NumIn()-1, len(args))
+	}
+	in := make([]reflect.Value, t.NumIn())
+	in[0] = reflect.Zero(t.In(0))
+	if ctx != nil {
+		in[0] = reflect.ValueOf(ctx)
+	}
+	for i, arg := range args {
+		if arg == nil {
+			in[i+1] = reflect.Zero(t.In(i + 1))
+		} else {
+			in[i+1] = reflect.ValueOf(arg)
+		}
+	}
+
+	var out []reflect.Value
+	if t.IsVariadic() {
+		out = m.CallSlice(in)
+	} else {
+		out = m.Call(in)
+	}
+
+	results := make([]any, len(out)-1)
+	for i := range results {
+		results[i] = out[i].Interface()
+	}
+	var err error
+	if e := out[len(out)-1].Interface(); e != nil {
+		err = e.(error)
+	}
+	return results, err
+}
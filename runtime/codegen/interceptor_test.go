@@ -0,0 +1,110 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codegen
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+type joiner interface {
+	Join(ctx context.Context, sep *string, parts ...string) (string, int, error)
+}
+
+type joinerImpl struct{}
+
+func (joinerImpl) Join(_ context.Context, sep *string, parts ...string) (string, int, error) {
+	s := ","
+	if sep != nil {
+		s = *sep
+	}
+	return strings.Join(parts, s), len(parts), nil
+}
+
+// joinerReflectStub is a hand-written equivalent of a generated reflect stub.
+type joinerReflectStub struct {
+	caller func(string, context.Context, []any, []any) error
+}
+
+func (s joinerReflectStub) Join(ctx context.Context, a0 *string, a1 ...string) (r0 string, r1 int, err error) {
+	err = s.caller("Join", ctx, []any{a0, a1}, []any{&r0, &r1})
+	return
+}
+
+var joinerReg = &Registration{
+	Name:  "joiner",
+	Iface: reflect.TypeOf((*joiner)(nil)).Elem(),
+	ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
+		return joinerReflectStub{caller}
+	},
+}
+
+func TestInterceptOrder(t *testing.T) {
+	var trace []string
+	tracing := func(name string) Interceptor {
+		return func(ctx context.Context, call *MethodCall, invoke func(context.Context) error) error {
+			trace = append(trace, name+" before "+call.Method)
+			err := invoke(ctx)
+			trace = append(trace, name+" after "+call.Method)
+			return err
+		}
+	}
+	j := intercept(joinerReg, "caller", joinerImpl{}, []Interceptor{tracing("a"), tracing("b")}).(joiner)
+	got, n, err := j.Join(context.Background(), nil, "x", "y")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "x,y" || n != 2 {
+		t.Fatalf("Join: got (%q, %d), want (%q, %d)", got, n, "x,y", 2)
+	}
+	want := []string{"a before Join", "b before Join", "b after Join", "a after Join"}
+	if diff := cmp.Diff(want, trace); diff != "" {
+		t.Fatalf("trace (-want +got):\n%s", diff)
+	}
+}
+
+func TestInterceptRewrite(t *testing.T) {
+	rewrite := func(ctx context.Context, call *MethodCall, invoke func(context.Context) error) error {
+		if call.Component != "joiner" || call.Method != "Join" || call.Caller != "caller" {
+			t.Errorf("unexpected call %+v", call)
+		}
+		sep := "-"
+		call.Args[0] = &sep
+		if err := invoke(ctx); err != nil {
+			return err
+		}
+		call.Results[0] = strings.ToUpper(call.Results[0].(string))
+		return nil
+	}
+	j := intercept(joinerReg, "caller", joinerImpl{}, []Interceptor{rewrite}).(joiner)
+	got, n, err := j.Join(context.Background(), nil, "x", "y", "z")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "X-Y-Z" || n != 3 {
+		t.Fatalf("Join: got (%q, %d), want (%q, %d)", got, n, "X-Y-Z", 3)
+	}
+}
+
+func TestInterceptNoInterceptors(t *testing.T) {
+	impl := joinerImpl{}
+	if got := intercept(joinerReg, "caller", impl, nil); got != any(impl) {
+		t.Fatalf("intercept without interceptors: got %T, want %T", got, impl)
+	}
+}
@@ -24,7 +24,6 @@ import (
 
 	"github.com/sh3lk/mx/internal/config"
 	"github.com/sh3lk/mx/runtime"
-	"github.com/sh3lk/mx/runtime/protos"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -65,6 +64,13 @@ type Registration struct {
 	Routed    bool         // True if calls to this component should be routed
 	Listeners []string     // the names of any mx.Listeners
 	NoRetry   []int        // indices of methods that should not be retried
+	Hedged    []int        // indices of methods whose calls may be hedged
+	Streaming []int        // indices of streaming methods
+
+	// Directives holds the options set by //mx: directives on the methods of
+	// the component interface, indexed like the methods of Iface. It is nil
+	// if no method has such options.
+	Directives []MethodDirectives
 
 	// Functions that return different types of stubs.
 	LocalStubFn   func(impl any, caller string, tracer trace.Tracer) any
@@ -72,6 +78,16 @@ type Registration struct {
 	ServerStubFn  func(impl any, load func(key uint64, load float64)) Server
 	ReflectStubFn func(func(method string, ctx context.Context, args []any, returns []any) error) any
 
+	// GRPCFile is the serialized FileDescriptorProto that defines the gRPC
+	// service of the component, named after its interface. It is nil unless
+	// the component was selected by "mx generate -grpc".
+	GRPCFile []byte
+
+	// GatewayFn returns the methods of the provided component that can be
+	// called through an HTTP+JSON gateway. It is nil unless the code was
+	// generated by "mx generate -gateway".
+	GatewayFn func(impl any) []GatewayMethod
+
 	// RefData holds a string containing the result of MakeEdgeString(Name, Dst)
 	// for all components named Dst used by this component.
 	RefData string
@@ -124,6 +140,9 @@ func verifyRegistration(reg Registration) error {
 	if reg.ServerStubFn == nil {
 		return errors.New("nil ServerStubFn")
 	}
+	if reg.Directives != nil && len(reg.Directives) != reg.Iface.NumMethod() {
+		return fmt.Errorf("%d method directives for %d methods", len(reg.Directives), reg.Iface.NumMethod())
+	}
 	return nil
 }
 
@@ -157,15 +176,35 @@ func ComponentConfigValidator(path, cfg string) error {
 		// Not for a known component.
 		return nil
 	}
+	sections := map[string]string{path: cfg}
+	calls, err := runtime.ParseCallConfig(path, sections)
+	if err != nil {
+		return fmt.Errorf("%v: bad config: %w", info.Iface, err)
+	}
+	if calls.Admission != nil {
+		for method := range calls.Admission.Methods {
+			if _, ok := info.Iface.MethodByName(method); !ok {
+				return fmt.Errorf("%v: bad config: admission: unknown method %q", info.Iface, method)
+			}
+		}
+	}
+	for _, caller := range calls.AllowedCallers {
+		if _, ok := globalRegistry.find(caller); !ok {
+			return fmt.Errorf("%v: bad config: allowed_callers: unknown component %q", info.Iface, caller)
+		}
+	}
 	componentConfig := config.Config(reflect.New(info.Impl))
 	if componentConfig == nil {
-		return fmt.Errorf("unexpected configuration for component %v "+
-			"that does not support configuration (add a "+
-			"mx.WithConfig[configType] embedded field to %v)",
-			info.Name, info.Iface)
+		// The section may only configure the calls to the component.
+		if err := runtime.ParseComponentConfigSection(path, sections, &struct{}{}); err != nil {
+			return fmt.Errorf("unexpected configuration for component %v "+
+				"that does not support configuration (add a "+
+				"mx.WithConfig[configType] embedded field to %v)",
+				info.Name, info.Iface)
+		}
+		return nil
 	}
-	config := &protos.AppConfig{Sections: map[string]string{path: cfg}}
-	if err := runtime.ParseConfigSection(path, "", config.Sections, componentConfig); err != nil {
+	if err := runtime.ParseComponentConfigSection(path, sections, componentConfig); err != nil {
 		return fmt.Errorf("%v: bad config: %w", info.Iface, err)
 	}
 	return nil
@@ -27,8 +27,15 @@ import (
 )
 
 func TestComponentConfigValidator(t *testing.T) {
-	if err := codegen.ComponentConfigValidator(typeWithConfig, `Foo = "hello"`); err != nil {
-		t.Fatal(err)
+	for _, test := range []struct{ path, config string }{
+		{typeWithConfig, `Foo = "hello"`},
+		{typeWithConfig, "Foo = \"hello\"\nretry_budget = {ratio = 0.1}"},
+		{typeWithoutConfig, `circuit_breaker = {failures = 5, timeout = "10s"}`},
+		{typeWithoutConfig, `allowed_callers = ["codegen_test/withConfig"]`},
+	} {
+		if err := codegen.ComponentConfigValidator(test.path, test.config); err != nil {
+			t.Errorf("%s: %v", test.config, err)
+		}
 	}
 }
 
@@ -54,6 +61,36 @@ func TestComponentConfigValidatorErrors(t *testing.T) {
 			config:        `Bar = -100`,
 			expectedError: "invalid value",
 		},
+		{
+			path:          typeWithoutConfig,
+			config:        `circuit_breaker = {failures = -1}`,
+			expectedError: "negative failures",
+		},
+		{
+			path:          typeWithoutConfig,
+			config:        `circuit_breaker = {retries = 1}`,
+			expectedError: "unknown keys",
+		},
+		{
+			path:          typeWithoutConfig,
+			config:        `admission = {methods.Missing = {max_concurrency = 1}}`,
+			expectedError: "unknown method",
+		},
+		{
+			path:          typeWithoutConfig,
+			config:        `admission = {max_queue = -1}`,
+			expectedError: "negative max_queue",
+		},
+		{
+			path:          typeWithoutConfig,
+			config:        `allowed_callers = ["codegen_test/Missing"]`,
+			expectedError: "unknown component",
+		},
+		{
+			path:          typeWithoutConfig,
+			config:        `allowed_callers = [""]`,
+			expectedError: "empty component name",
+		},
 	} {
 		t.Run(test.expectedError, func(t *testing.T) {
 			err := codegen.ComponentConfigValidator(test.path, test.config)
@@ -0,0 +1,154 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codegen
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// The wire schema of every component, i.e., how the arguments and results of
+// its methods are encoded, is embedded in the generated binary as a specially
+// formatted string. These strings can be extracted from a binary to check
+// that a new version of an application can exchange data with an old one,
+// without having to execute the binary.
+//
+// The schema of a component is represented by a string fragment that looks
+// like:
+// ⟦checksum:MxSchema:component→schema⟧
+//
+// checksum is the first 8 bytes of the hex encoding of the SHA-256 of the
+// string "MxSchema:component→schema"; component is the fully qualified
+// component type name; schema is the JSON encoding of the methods of a
+// ComponentSchema.
+
+// ComponentSchema is the wire schema of a component.
+type ComponentSchema struct {
+	// Fully qualified component type name, e.g.,
+	//   github.com/sh3lk/mx/Main.
+	Component string
+
+	// The non-streaming methods of the component, sorted by name.
+	Methods []MethodSchema
+}
+
+// MethodSchema is the wire schema of a component method.
+type MethodSchema struct {
+	Name    string      `json:"name"`
+	Args    []*WireType `json:"args,omitempty"`    // excluding the context
+	Results []*WireType `json:"results,omitempty"` // excluding the error
+}
+
+// WireType describes how values of a Go type are encoded by an Encoder.
+// Encoded values carry no field names or type information, so two types are
+// wire-compatible only if their descriptions match.
+type WireType struct {
+	// Kind is one of the following:
+	//
+	//   - the name of a basic type, where int and uint are encoded as int64
+	//     and uint64 respectively;
+	//   - "pointer", "slice", "array", "map", or "struct";
+	//   - "error";
+	//   - "proto" for a proto.Message, "binary" for an
+	//     encoding.BinaryMarshaler, or "automarshal" for an AutoMarshal
+	//     whose methods were not generated with the component, which are
+	//     encoded opaquely.
+	Kind   string      `json:"kind"`
+	Name   string      `json:"name,omitempty"`   // Go type name, if named
+	Len    int         `json:"len,omitempty"`    // length of an array
+	Key    *WireType   `json:"key,omitempty"`    // key of a map
+	Elem   *WireType   `json:"elem,omitempty"`   // element of a pointer, slice, array, or map
+	Fields []WireField `json:"fields,omitempty"` // encoded fields of a struct, in order
+}
+
+// WireField is an encoded field of a struct.
+type WireField struct {
+	Name string    `json:"name"`
+	Type *WireType `json:"type"`
+}
+
+// String returns a Go-like representation of t, e.g., "map[string][]int64".
+func (t *WireType) String() string {
+	if t.Name != "" {
+		return t.Name
+	}
+	switch t.Kind {
+	case "pointer":
+		return "*" + t.Elem.String()
+	case "slice":
+		return "[]" + t.Elem.String()
+	case "array":
+		return fmt.Sprintf("[%d]%s", t.Len, t.Elem)
+	case "map":
+		return fmt.Sprintf("map[%s]%s", t.Key, t.Elem)
+	case "struct":
+		fields := make([]string, len(t.Fields))
+		for i, f := range t.Fields {
+			fields[i] = f.Name + " " + f.Type.String()
+		}
+		return "struct{" + strings.Join(fields, "; ") + "}"
+	default:
+		return t.Kind
+	}
+}
+
+// MakeSchemaString returns a string that should be emitted into generated code
+// to represent the wire schema of a component.
+func MakeSchemaString(schema ComponentSchema) string {
+	methods := append([]MethodSchema(nil), schema.Methods...)
+	sort.Slice(methods, func(i, j int) bool { return methods[i].Name < methods[j].Name })
+	data, err := json.Marshal(methods)
+	if err != nil {
+		// Schemas only hold strings, integers, slices, and pointers.
+		panic(fmt.Errorf("MakeSchemaString: %w", err))
+	}
+	return fmt.Sprintf("⟦%s:MxSchema:%s→%s⟧\n", checksumSchema(schema.Component, string(data)), schema.Component, data)
+}
+
+// ExtractSchemas returns the component schemas encoded using
+// MakeSchemaString() in data.
+func ExtractSchemas(data []byte) []ComponentSchema {
+	var results []ComponentSchema
+	re := regexp.MustCompile(`⟦([0-9a-fA-F]+):MxSchema:([a-zA-Z0-9\-.~_/]*?)→([^⟧]*)⟧`)
+	for _, m := range re.FindAllSubmatch(data, -1) {
+		if len(m) != 4 {
+			continue
+		}
+		sum, component, methods := string(m[1]), string(m[2]), string(m[3])
+		if sum != checksumSchema(component, methods) {
+			continue
+		}
+		schema := ComponentSchema{Component: component}
+		if err := json.Unmarshal([]byte(methods), &schema.Methods); err != nil {
+			continue
+		}
+		results = append(results, schema)
+	}
+	// Generate a stable list.
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Component < results[j].Component
+	})
+	return results
+}
+
+func checksumSchema(component, methods string) string {
+	str := fmt.Sprintf("MxSchema:%s→%s", component, methods)
+	sum := sha256.Sum256([]byte(str))
+	return fmt.Sprintf("%0x", sum)[:8]
+}
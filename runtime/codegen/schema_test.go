@@ -0,0 +1,73 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codegen_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sh3lk/mx/runtime/codegen"
+)
+
+func TestSchemas(t *testing.T) {
+	point := &codegen.WireType{
+		Kind: "struct",
+		Name: "foo.Point",
+		Fields: []codegen.WireField{
+			{Name: "X", Type: &codegen.WireType{Kind: "int64"}},
+			{Name: "Y", Type: &codegen.WireType{Kind: "int64"}},
+		},
+	}
+	b := codegen.ComponentSchema{
+		Component: "b",
+		Methods: []codegen.MethodSchema{
+			{Name: "Get", Args: []*codegen.WireType{{Kind: "string"}}, Results: []*codegen.WireType{point}},
+			{Name: "Del", Args: []*codegen.WireType{{Kind: "slice", Elem: &codegen.WireType{Kind: "string"}}}},
+		},
+	}
+	a := codegen.ComponentSchema{
+		Component: "a",
+		Methods: []codegen.MethodSchema{
+			{Name: "Ping"},
+		},
+	}
+	data := codegen.MakeSchemaString(b) + codegen.MakeSchemaString(a)
+	t.Log(data)
+
+	got := codegen.ExtractSchemas([]byte(data))
+	// Methods are sorted by name.
+	b.Methods[0], b.Methods[1] = b.Methods[1], b.Methods[0]
+	want := []codegen.ComponentSchema{a, b}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ExtractSchemas (-want +got):\n%s", diff)
+	}
+}
+
+func TestWireTypeString(t *testing.T) {
+	for _, test := range []struct {
+		t    *codegen.WireType
+		want string
+	}{
+		{&codegen.WireType{Kind: "int64"}, "int64"},
+		{&codegen.WireType{Kind: "string", Name: "foo.ID"}, "foo.ID"},
+		{&codegen.WireType{Kind: "map", Key: &codegen.WireType{Kind: "string"}, Elem: &codegen.WireType{Kind: "slice", Elem: &codegen.WireType{Kind: "bool"}}}, "map[string][]bool"},
+		{&codegen.WireType{Kind: "array", Len: 4, Elem: &codegen.WireType{Kind: "uint8"}}, "[4]uint8"},
+		{&codegen.WireType{Kind: "pointer", Elem: &codegen.WireType{Kind: "struct", Fields: []codegen.WireField{{Name: "A", Type: &codegen.WireType{Kind: "error"}}}}}, "*struct{A error}"},
+	} {
+		if got := test.t.String(); got != test.want {
+			t.Errorf("String: got %q, want %q", got, test.want)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codegen
+
+import (
+	"context"
+	"io"
+)
+
+// A ByteStream is a stream of serialized values exchanged between the client
+// and server stubs of a streaming component method.
+type ByteStream interface {
+	// Recv returns the next serialized value in the stream. It returns io.EOF
+	// when the stream has ended successfully, and any other non-nil error if
+	// the stream failed.
+	Recv(ctx context.Context) ([]byte, error)
+
+	// Close releases the resources held by the stream. Close must be called
+	// once the receiver is no longer interested in the stream, even if the
+	// stream has already ended. Close can be called more than once.
+	Close()
+}
+
+// TypedStream is a stream of values of type T. It has the same method set as
+// mx.Stream[T], and is redeclared here to avoid cyclic dependencies.
+type TypedStream[T any] interface {
+	Recv(ctx context.Context) (T, error)
+	Close()
+}
+
+// EncodeStream returns a ByteStream that serializes, using enc, the values
+// received from s.
+func EncodeStream[T any](s TypedStream[T], enc func(*Encoder, T)) ByteStream {
+	return &encodedStream[T]{s: s, enc: enc}
+}
+
+// DecodeStream returns a stream that deserializes, using dec, the values
+// received from s.
+func DecodeStream[T any](s ByteStream, dec func(*Decoder) T) TypedStream[T] {
+	return &decodedStream[T]{s: s, dec: dec}
+}
+
+type encodedStream[T any] struct {
+	s   TypedStream[T]
+	enc func(*Encoder, T)
+}
+
+var _ ByteStream = &encodedStream[int]{}
+
+// Recv implements the ByteStream interface.
+func (e *encodedStream[T]) Recv(ctx context.Context) (data []byte, err error) {
+	v, err := e.s.Recv(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if x := CatchPanics(recover()); x != nil {
+			err = x
+		}
+	}()
+	enc := NewEncoder()
+	e.enc(enc, v)
+	return enc.Data(), nil
+}
+
+// Close implements the ByteStream interface.
+func (e *encodedStream[T]) Close() {
+	e.s.Close()
+}
+
+type decodedStream[T any] struct {
+	s   ByteStream
+	dec func(*Decoder) T
+}
+
+var _ TypedStream[int] = &decodedStream[int]{}
+
+// Recv implements the TypedStream interface.
+func (d *decodedStream[T]) Recv(ctx context.Context) (v T, err error) {
+	data, err := d.s.Recv(ctx)
+	if err != nil {
+		return v, err
+	}
+	defer func() {
+		if x := CatchPanics(recover()); x != nil {
+			err = x
+		}
+	}()
+	return d.dec(NewDecoder(data)), nil
+}
+
+// Close implements the TypedStream interface.
+func (d *decodedStream[T]) Close() {
+	d.s.Close()
+}
+
+// EmptyStream returns a ByteStream that holds no values.
+func EmptyStream() ByteStream {
+	return emptyStream{}
+}
+
+type emptyStream struct{}
+
+func (emptyStream) Recv(context.Context) ([]byte, error) { return nil, io.EOF }
+func (emptyStream) Close()                               {}
@@ -33,8 +33,15 @@ type Stub interface {
 	// At code generation time, an object's methods are deterministically
 	// ordered. method is the index into this slice. args and results are the
 	// serialized arguments and results, respectively. shardKey is the shard
-	// key for routed components, and 0 otherwise.
+	// key for routed components, and 0 otherwise. args is not retained once
+	// Run returns, and results is owned by the caller.
 	Run(ctx context.Context, method int, args []byte, shardKey uint64) (results []byte, err error)
+
+	// RunStream is like Run, but executes a streaming method. If in is not
+	// nil, the values it produces are streamed to the server after args. out
+	// holds the values streamed back by the server, and must be closed by the
+	// caller if err is nil.
+	RunStream(ctx context.Context, method int, args []byte, in ByteStream, shardKey uint64) (results []byte, out ByteStream, err error)
 }
 
 // A Server allows a MX component in one process to receive and execute
@@ -49,3 +56,15 @@ type Server interface {
 	// TODO(mwhittaker): Rename GetHandler? This is returning a call.Handler.
 	GetStubFn(method string) func(ctx context.Context, args []byte) ([]byte, error)
 }
+
+// A StreamServer is a Server for a component that has streaming methods.
+type StreamServer interface {
+	Server
+
+	// GetStreamStubFn returns a handler function for the given streaming
+	// method, or nil if the method is not a streaming method. The handler is
+	// passed the values streamed by the client in in, and returns the values
+	// to stream back to the client in out. out is nil if there is nothing to
+	// stream back.
+	GetStreamStubFn(method string) func(ctx context.Context, args []byte, in ByteStream) (results []byte, out ByteStream, err error)
+}
@@ -62,10 +62,27 @@ func ParseConfig(file string, input string, sectionValidator func(string, string
 	return config, nil
 }
 
+// ParseAppSection parses the common MX application configuration in the
+// provided config sections (see AppConfig.Sections). Unlike ParseConfig, it
+// doesn't canonicalize the returned config.
+func ParseAppSection(sections map[string]string) (*protos.AppConfig, error) {
+	config := &protos.AppConfig{Sections: sections}
+	if err := parseApp(config); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
 // ParseConfigSection parses the config section for key into dst.
 // If shortKey is not empty, either key or shortKey is accepted.
 // If the named section is not found, returns nil without changing dst.
 func ParseConfigSection(key, shortKey string, sections map[string]string, dst any) error {
+	return parseConfigSection(key, shortKey, sections, dst, func(toml.Key) bool { return false })
+}
+
+// parseConfigSection parses the config section for key into dst, like
+// ParseConfigSection, ignoring the keys for which ignore returns true.
+func parseConfigSection(key, shortKey string, sections map[string]string, dst any, ignore func(toml.Key) bool) error {
 	section, ok := sections[key]
 	if shortKey != "" {
 		// Fetch section listed for shortKey, if any.
@@ -85,7 +102,13 @@ func ParseConfigSection(key, shortKey string, sections map[string]string, dst an
 	if err != nil {
 		return err
 	}
-	if unknown := md.Undecoded(); len(unknown) != 0 {
+	var unknown []toml.Key
+	for _, k := range md.Undecoded() {
+		if !ignore(k) {
+			unknown = append(unknown, k)
+		}
+	}
+	if len(unknown) != 0 {
 		return fmt.Errorf("section %q has unknown keys %v", key, unknown)
 	}
 	if x, ok := dst.(interface{ Validate() error }); ok {
@@ -96,19 +119,27 @@ func ParseConfigSection(key, shortKey string, sections map[string]string, dst an
 	return nil
 }
 
-func extractApp(file string, config *protos.AppConfig) error {
+// parseApp parses the [mx] section of the provided config into the config's
+// fields.
+func parseApp(config *protos.AppConfig) error {
 	const appKey = "github.com/sh3lk/mx"
 	const shortAppKey = "mx"
 
 	// appConfig holds the data from under appKey in the TOML config.
 	// It matches the contents of the Config proto.
 	type appConfig struct {
-		Name     string
-		Binary   string
-		Args     []string
-		Env      []string
-		Colocate [][]string
-		Rollout  time.Duration
+		Name                 string
+		Binary               string
+		Args                 []string
+		Env                  []string
+		Colocate             [][]string
+		Rollout              time.Duration
+		Balancers            map[string]string
+		HedgePercentile      float64       `toml:"hedge_percentile"`
+		KeepaliveInterval    time.Duration `toml:"keepalive_interval"`
+		KeepaliveTimeout     time.Duration `toml:"keepalive_timeout"`
+		Compression          string
+		CompressionThreshold int64 `toml:"compression_threshold"`
 	}
 
 	parsed := &appConfig{}
@@ -126,6 +157,41 @@ func extractApp(file string, config *protos.AppConfig) error {
 		group := &protos.ComponentGroup{Components: colocate}
 		config.Colocate = append(config.Colocate, group)
 	}
+	for component, balancer := range parsed.Balancers {
+		// NOTE: Keep in sync with call.NewBalancer.
+		switch balancer {
+		case "round_robin", "least_loaded", "p2c":
+		default:
+			return fmt.Errorf("invalid balancer %q for component %q", balancer, component)
+		}
+	}
+	config.Balancers = parsed.Balancers
+	if parsed.HedgePercentile < 0 || parsed.HedgePercentile >= 1 {
+		return fmt.Errorf("invalid hedge_percentile %v: must be in the range [0, 1)", parsed.HedgePercentile)
+	}
+	config.HedgePercentile = parsed.HedgePercentile
+	if parsed.KeepaliveTimeout < 0 {
+		return fmt.Errorf("invalid keepalive_timeout %v: must be non-negative", parsed.KeepaliveTimeout)
+	}
+	config.KeepaliveIntervalNanos = int64(parsed.KeepaliveInterval)
+	config.KeepaliveTimeoutNanos = int64(parsed.KeepaliveTimeout)
+	// NOTE: Keep in sync with call.ParseCompression.
+	switch parsed.Compression {
+	case "", "zstd", "snappy":
+	default:
+		return fmt.Errorf("invalid compression %q", parsed.Compression)
+	}
+	config.Compression = parsed.Compression
+	config.CompressionThreshold = parsed.CompressionThreshold
+	return nil
+}
+
+// extractApp parses the [mx] section of the provided config, like parseApp,
+// and canonicalizes the config.
+func extractApp(file string, config *protos.AppConfig) error {
+	if err := parseApp(config); err != nil {
+		return err
+	}
 
 	// Canonicalize the config.
 	if err := canonicalizeConfig(config, filepath.Dir(file)); err != nil {
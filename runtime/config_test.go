@@ -19,6 +19,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/sh3lk/mx/runtime"
@@ -102,6 +103,64 @@ func TestParseConfigSection(t *testing.T) {
 	}
 }
 
+func TestParseCallConfig(t *testing.T) {
+	sections := map[string]string{
+		"component": `
+Foo = "foo"
+circuit_breaker = {failures = 5, timeout = "10s", half_open_calls = 2}
+retry_budget = {ratio = 0.1, min_per_second = 2}
+allowed_callers = ["caller"]
+
+[admission]
+max_concurrency = 100
+max_queue = 10
+methods.Put = {adaptive = true}
+`,
+	}
+
+	// The component's config ignores the call config.
+	type section struct{ Foo string }
+	var got section
+	if err := runtime.ParseComponentConfigSection("component", sections, &got); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(section{"foo"}, got); diff != "" {
+		t.Fatalf("ParseComponentConfigSection: (-want +got):\n%s", diff)
+	}
+
+	// The call config ignores the component's config.
+	calls, err := runtime.ParseCallConfig("component", sections)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &runtime.CallConfig{
+		CircuitBreaker: &runtime.CircuitBreakerConfig{
+			Failures:      5,
+			Timeout:       10 * time.Second,
+			HalfOpenCalls: 2,
+		},
+		RetryBudget: &runtime.RetryBudgetConfig{Ratio: 0.1, MinPerSecond: 2},
+		Admission: &runtime.AdmissionConfig{
+			AdmissionLimits: runtime.AdmissionLimits{MaxConcurrency: 100, MaxQueue: 10},
+			Methods: map[string]runtime.AdmissionLimits{
+				"Put": {Adaptive: true},
+			},
+		},
+		AllowedCallers: []string{"caller"},
+	}
+	if diff := cmp.Diff(want, calls); diff != "" {
+		t.Fatalf("ParseCallConfig: (-want +got):\n%s", diff)
+	}
+
+	// Methods without limits of their own use the default limits.
+	if got, want := calls.Admission.Limits("Get").MaxConcurrency, 100; got != want {
+		t.Errorf("Get max concurrency: got %d, want %d", got, want)
+	}
+	if got, want := calls.Admission.Limits("Put").MaxConcurrency, 0; got != want {
+		t.Errorf("Put max concurrency: got %d, want %d", got, want)
+	}
+}
+
 func TestConfigErrors(t *testing.T) {
 	type testCase struct {
 		name          string
@@ -152,6 +211,38 @@ rollout = "hello"
 `,
 			expectedError: "invalid duration",
 		},
+		{
+			name: "bad balancer",
+			cfg: `
+[mx]
+balancers = {"a" = "random"}
+`,
+			expectedError: "invalid balancer",
+		},
+		{
+			name: "bad hedge percentile",
+			cfg: `
+[mx]
+hedge_percentile = 95.0
+`,
+			expectedError: "invalid hedge_percentile",
+		},
+		{
+			name: "bad keepalive timeout",
+			cfg: `
+[mx]
+keepalive_timeout = "-1s"
+`,
+			expectedError: "invalid keepalive_timeout",
+		},
+		{
+			name: "bad compression",
+			cfg: `
+[mx]
+compression = "gzip"
+`,
+			expectedError: "invalid compression",
+		},
 	} {
 		t.Run(c.name, func(t *testing.T) {
 			_, err := runtime.ParseConfig("mx.toml", c.cfg, codegen.ComponentConfigValidator)
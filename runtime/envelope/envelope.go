@@ -86,6 +86,25 @@ type EnvelopeHandler interface {
 	// by passing it a MXNArgs with mtls=true.
 	VerifyServerCertificate(context.Context, *protos.VerifyServerCertificateRequest) (*protos.VerifyServerCertificateReply, error)
 
+	// GetSelfToken returns the bearer token the mxn should send with the
+	// calls it makes to other mxns, along with its expiration time. The
+	// mxn caches the token, and issues this request again before the token
+	// expires.
+	//
+	// NOTE: This method is only called if token authentication was enabled
+	// for the mxn, by passing it a MXNArgs with token_auth=true.
+	GetSelfToken(context.Context, *protos.GetSelfTokenRequest) (*protos.GetSelfTokenReply, error)
+
+	// VerifyClientToken verifies the bearer token sent by a client with a
+	// call to the mxn. It returns an error if the call should be rejected.
+	// Otherwise, it returns the list of mxn components that the client is
+	// authorized to invoke methods on, and the time until which the mxn
+	// may accept the token without verifying it again.
+	//
+	// NOTE: This method is only called if token authentication was enabled
+	// for the mxn, by passing it a MXNArgs with token_auth=true.
+	VerifyClientToken(context.Context, *protos.VerifyClientTokenRequest) (*protos.VerifyClientTokenReply, error)
+
 	// LogBatches handles a batch of log entries.
 	LogBatch(context.Context, *protos.LogEntryBatch) error
 
@@ -117,6 +136,9 @@ type Envelope struct {
 	// State needed to process metric updates.
 	metricsMu sync.Mutex
 	metrics   metrics.Importer
+
+	// Error returned by the mxn process, set when Serve returns.
+	exitErr error
 }
 
 // Options contains optional arguments for the envelope.
@@ -132,6 +154,10 @@ type Options struct {
 
 	// Child is used to run the mxn. If nil, a sub-process is created.
 	Child Child
+
+	// If SharedMemory is true, the mxn also accepts shared-memory connections
+	// from mxns running on the same machine. See SharedMemorySocket.
+	SharedMemory bool
 }
 
 // NewEnvelope creates a new envelope, starting a mxn subprocess (via child.Start) and
@@ -174,6 +200,9 @@ func NewEnvelope(ctx context.Context, wlet *protos.MXNArgs, config *protos.AppCo
 
 	wlet = protomsg.Clone(wlet)
 	wlet.ControlSocket = deployers.NewUnixSocketPath(tmpDir)
+	if options.SharedMemory {
+		wlet.SharedMemorySocket = deployers.NewUnixSocketPath(tmpDir)
+	}
 	wlet.Redirects = []*protos.MXNArgs_Redirect{
 		// Point mxn at my control.DeployerControl component
 		{
@@ -292,11 +321,19 @@ func (e *Envelope) Serve(h EnvelopeHandler) error {
 	// Wait for the mxn command to finish. This needs to be done after
 	// we're done reading from stdout/stderr pipes, per comments on
 	// exec.Cmd.StdoutPipe and exec.Cmd.StderrPipe.
-	stop(e.child.Wait())
+	e.exitErr = e.child.Wait()
+	stop(e.exitErr)
 
 	return stopErr
 }
 
+// ExitError returns the error returned by the mxn process when it exited,
+// which is nil if the process exited successfully. It must only be called
+// after [Serve] returns.
+func (e *Envelope) ExitError() error {
+	return e.exitErr
+}
+
 // Pid returns the process id of the mxn, if it is running in a separate process.
 func (e *Envelope) Pid() (int, bool) {
 	return e.child.Pid()
@@ -308,6 +345,12 @@ func (e *Envelope) MXNAddress() string {
 	return e.mxnAddr
 }
 
+// SharedMemorySocket returns the Unix socket where the mxn accepts
+// shared-memory connections, or the empty string if it doesn't accept them.
+func (e *Envelope) SharedMemorySocket() string {
+	return e.mxn.SharedMemorySocket
+}
+
 // GetHealth returns the health status of the mxn.
 func (e *Envelope) GetHealth() *protos.GetHealthReply {
 	reply, err := e.controller.GetHealth(context.TODO(), &protos.GetHealthRequest{})
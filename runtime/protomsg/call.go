@@ -120,7 +120,8 @@ func toWire(msgs ...proto.Message) (data []byte, err error) {
 func fromWire(in []byte, msgs ...proto.Message) (err error) {
 	// Catch and return any panics detected during the decoding.
 	defer func() { err = codegen.CatchPanics(recover()) }()
-	dec := codegen.NewDecoder(in)
+	// proto.Unmarshal copies what it retains, so there is no need to copy.
+	dec := codegen.NewZeroCopyDecoder(in)
 	for _, msg := range msgs {
 		b := dec.Bytes()
 		err = proto.Unmarshal(b, msg)
@@ -37,13 +37,13 @@ const (
 	// the deployer API in v0.13.0 of MX, then we leave the
 	// deployer API at v0.12.0.
 	DeployerMajor = 0
-	DeployerMinor = 24
+	DeployerMinor = 27
 
 	// The version of the codegen API. As with the deployer API, we assign a
 	// new version every time we change how code is generated, and we use
 	// mx module versions.
 	CodegenMajor = 0
-	CodegenMinor = 24
+	CodegenMinor = 25
 )
 
 var (
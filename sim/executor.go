@@ -323,7 +323,7 @@ func (e *executor) reset(workload Workload, fakes map[reflect.Type]any, ops []*o
 			// Fill config.
 			if e.info.hasConfig[reg.Iface] {
 				if cfg := mx.GetConfig(obj); cfg != nil {
-					if err := runtime.ParseConfigSection(reg.Name, "", e.config.Sections, cfg); err != nil {
+					if err := runtime.ParseComponentConfigSection(reg.Name, e.config.Sections, cfg); err != nil {
 						return err
 					}
 				}
@@ -30,7 +30,7 @@ func init() {
 		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
 			return bank_reflect_stub{caller: caller}
 		},
-		RefData: "⟦dab0c530:MxEdge:github.com/sh3lk/mx/sim/internal/bank/Bank→github.com/sh3lk/mx/sim/internal/bank/Store⟧\n",
+		RefData: "⟦b7640c52:MxEdge:github.com/sh3lk/mx/sim/internal/bank/Bank→github.com/sh3lk/mx/sim/internal/bank/Store⟧\n⟦3683a6e4:MxSchema:github.com/sh3lk/mx/sim/internal/bank/Bank→[{\"name\":\"Deposit\",\"args\":[{\"kind\":\"string\"},{\"kind\":\"int64\"}],\"results\":[{\"kind\":\"int64\"}]},{\"name\":\"Withdraw\",\"args\":[{\"kind\":\"string\"},{\"kind\":\"int64\"}],\"results\":[{\"kind\":\"int64\"}]}]⟧\n",
 	})
 	codegen.Register(codegen.Registration{
 		Name:  "github.com/sh3lk/mx/sim/internal/bank/Store",
@@ -48,7 +48,7 @@ func init() {
 		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
 			return store_reflect_stub{caller: caller}
 		},
-		RefData: "",
+		RefData: "⟦f0adfc6e:MxSchema:github.com/sh3lk/mx/sim/internal/bank/Store→[{\"name\":\"Add\",\"args\":[{\"kind\":\"string\"},{\"kind\":\"int64\"}],\"results\":[{\"kind\":\"int64\"}]},{\"name\":\"Get\",\"args\":[{\"kind\":\"string\"}],\"results\":[{\"kind\":\"int64\"}]}]⟧\n",
 	})
 }
 
@@ -206,7 +206,8 @@ func (s bank_client_stub) Deposit(ctx context.Context, a0 string, a1 int) (r0 in
 	size := 0
 	size += (4 + len(a0))
 	size += 8
-	enc := codegen.NewEncoder()
+	enc := codegen.GetEncoder()
+	defer codegen.PutEncoder(enc)
 	enc.Reset(size)
 
 	// Encode arguments.
@@ -225,7 +226,7 @@ func (s bank_client_stub) Deposit(ctx context.Context, a0 string, a1 int) (r0 in
 	}
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewZeroCopyDecoder(results)
 	r0 = dec.Int()
 	err = dec.Error()
 	return
@@ -264,7 +265,8 @@ func (s bank_client_stub) Withdraw(ctx context.Context, a0 string, a1 int) (r0 i
 	size := 0
 	size += (4 + len(a0))
 	size += 8
-	enc := codegen.NewEncoder()
+	enc := codegen.GetEncoder()
+	defer codegen.PutEncoder(enc)
 	enc.Reset(size)
 
 	// Encode arguments.
@@ -283,7 +285,7 @@ func (s bank_client_stub) Withdraw(ctx context.Context, a0 string, a1 int) (r0 i
 	}
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewZeroCopyDecoder(results)
 	r0 = dec.Int()
 	err = dec.Error()
 	return
@@ -331,7 +333,8 @@ func (s store_client_stub) Add(ctx context.Context, a0 string, a1 int) (r0 int,
 	size := 0
 	size += (4 + len(a0))
 	size += 8
-	enc := codegen.NewEncoder()
+	enc := codegen.GetEncoder()
+	defer codegen.PutEncoder(enc)
 	enc.Reset(size)
 
 	// Encode arguments.
@@ -350,7 +353,7 @@ func (s store_client_stub) Add(ctx context.Context, a0 string, a1 int) (r0 int,
 	}
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewZeroCopyDecoder(results)
 	r0 = dec.Int()
 	err = dec.Error()
 	return
@@ -388,7 +391,8 @@ func (s store_client_stub) Get(ctx context.Context, a0 string) (r0 int, err erro
 	// Preallocate a buffer of the right size.
 	size := 0
 	size += (4 + len(a0))
-	enc := codegen.NewEncoder()
+	enc := codegen.GetEncoder()
+	defer codegen.PutEncoder(enc)
 	enc.Reset(size)
 
 	// Encode arguments.
@@ -406,7 +410,7 @@ func (s store_client_stub) Get(ctx context.Context, a0 string) (r0 int, err erro
 	}
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewZeroCopyDecoder(results)
 	r0 = dec.Int()
 	err = dec.Error()
 	return
@@ -415,10 +419,10 @@ func (s store_client_stub) Get(ctx context.Context, a0 string) (r0 int, err erro
 // Note that "mx generate" will always generate the error message below.
 // Everything is okay. The error message is only relevant if you see it when
 // you run "go build" or "go run".
-var _ codegen.LatestVersion = codegen.Version[[0][24]struct{}](`
+var _ codegen.LatestVersion = codegen.Version[[0][25]struct{}](`
 
 ERROR: You generated this file with 'mx generate' (devel) (codegen
-version v0.24.0). The generated code is incompatible with the version of the
+version v0.25.0). The generated code is incompatible with the version of the
 github.com/sh3lk/mx module that you're using. The mx module
 version can be found in your go.mod file or by running the following command.
 
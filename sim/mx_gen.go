@@ -31,7 +31,7 @@ func init() {
 		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
 			return blocker_reflect_stub{caller: caller}
 		},
-		RefData: "",
+		RefData: "⟦6a60bc08:MxSchema:github.com/sh3lk/mx/sim/blocker→[{\"name\":\"Block\"}]⟧\n",
 	})
 	codegen.Register(codegen.Registration{
 		Name:  "github.com/sh3lk/mx/sim/div",
@@ -49,7 +49,7 @@ func init() {
 		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
 			return div_reflect_stub{caller: caller}
 		},
-		RefData: "⟦6ddebe91:MxEdge:github.com/sh3lk/mx/sim/div→github.com/sh3lk/mx/sim/identity⟧\n",
+		RefData: "⟦3c099480:MxEdge:github.com/sh3lk/mx/sim/div→github.com/sh3lk/mx/sim/identity⟧\n⟦e4a911d1:MxSchema:github.com/sh3lk/mx/sim/div→[{\"name\":\"Div\",\"args\":[{\"kind\":\"int64\"},{\"kind\":\"int64\"}],\"results\":[{\"kind\":\"int64\"}]}]⟧\n",
 	})
 	codegen.Register(codegen.Registration{
 		Name:  "github.com/sh3lk/mx/sim/divMod",
@@ -67,7 +67,7 @@ func init() {
 		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
 			return divMod_reflect_stub{caller: caller}
 		},
-		RefData: "⟦df3a80a0:MxEdge:github.com/sh3lk/mx/sim/divMod→github.com/sh3lk/mx/sim/div⟧\n⟦b28314dd:MxEdge:github.com/sh3lk/mx/sim/divMod→github.com/sh3lk/mx/sim/mod⟧\n",
+		RefData: "⟦d4555e60:MxEdge:github.com/sh3lk/mx/sim/divMod→github.com/sh3lk/mx/sim/div⟧\n⟦a17857a5:MxEdge:github.com/sh3lk/mx/sim/divMod→github.com/sh3lk/mx/sim/mod⟧\n⟦93147bbb:MxSchema:github.com/sh3lk/mx/sim/divMod→[{\"name\":\"DivMod\",\"args\":[{\"kind\":\"int64\"},{\"kind\":\"int64\"}],\"results\":[{\"kind\":\"int64\"},{\"kind\":\"int64\"}]}]⟧\n",
 	})
 	codegen.Register(codegen.Registration{
 		Name:  "github.com/sh3lk/mx/sim/identity",
@@ -85,7 +85,7 @@ func init() {
 		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
 			return identity_reflect_stub{caller: caller}
 		},
-		RefData: "",
+		RefData: "⟦1ba1d324:MxSchema:github.com/sh3lk/mx/sim/identity→[{\"name\":\"Identity\",\"args\":[{\"kind\":\"int64\"}],\"results\":[{\"kind\":\"int64\"}]}]⟧\n",
 	})
 	codegen.Register(codegen.Registration{
 		Name:  "github.com/sh3lk/mx/sim/mod",
@@ -103,7 +103,7 @@ func init() {
 		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
 			return mod_reflect_stub{caller: caller}
 		},
-		RefData: "⟦5bf2dcf2:MxEdge:github.com/sh3lk/mx/sim/mod→github.com/sh3lk/mx/sim/identity⟧\n",
+		RefData: "⟦5a050b51:MxEdge:github.com/sh3lk/mx/sim/mod→github.com/sh3lk/mx/sim/identity⟧\n⟦a2e1b4f8:MxSchema:github.com/sh3lk/mx/sim/mod→[{\"name\":\"Mod\",\"args\":[{\"kind\":\"int64\"},{\"kind\":\"int64\"}],\"results\":[{\"kind\":\"int64\"}]}]⟧\n",
 	})
 	codegen.Register(codegen.Registration{
 		Name:  "github.com/sh3lk/mx/sim/panicker",
@@ -121,7 +121,7 @@ func init() {
 		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
 			return panicker_reflect_stub{caller: caller}
 		},
-		RefData: "",
+		RefData: "⟦c3c5858e:MxSchema:github.com/sh3lk/mx/sim/panicker→[{\"name\":\"Panic\",\"args\":[{\"kind\":\"bool\"}]}]⟧\n",
 	})
 }
 
@@ -368,7 +368,7 @@ func (s blocker_client_stub) Block(ctx context.Context) (err error) {
 	}
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewZeroCopyDecoder(results)
 	err = dec.Error()
 	return
 }
@@ -414,7 +414,8 @@ func (s div_client_stub) Div(ctx context.Context, a0 int, a1 int) (r0 int, err e
 	size := 0
 	size += 8
 	size += 8
-	enc := codegen.NewEncoder()
+	enc := codegen.GetEncoder()
+	defer codegen.PutEncoder(enc)
 	enc.Reset(size)
 
 	// Encode arguments.
@@ -433,7 +434,7 @@ func (s div_client_stub) Div(ctx context.Context, a0 int, a1 int) (r0 int, err e
 	}
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewZeroCopyDecoder(results)
 	r0 = dec.Int()
 	err = dec.Error()
 	return
@@ -480,7 +481,8 @@ func (s divMod_client_stub) DivMod(ctx context.Context, a0 int, a1 int) (r0 int,
 	size := 0
 	size += 8
 	size += 8
-	enc := codegen.NewEncoder()
+	enc := codegen.GetEncoder()
+	defer codegen.PutEncoder(enc)
 	enc.Reset(size)
 
 	// Encode arguments.
@@ -499,7 +501,7 @@ func (s divMod_client_stub) DivMod(ctx context.Context, a0 int, a1 int) (r0 int,
 	}
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewZeroCopyDecoder(results)
 	r0 = dec.Int()
 	r1 = dec.Int()
 	err = dec.Error()
@@ -546,7 +548,8 @@ func (s identity_client_stub) Identity(ctx context.Context, a0 int) (r0 int, err
 	// Preallocate a buffer of the right size.
 	size := 0
 	size += 8
-	enc := codegen.NewEncoder()
+	enc := codegen.GetEncoder()
+	defer codegen.PutEncoder(enc)
 	enc.Reset(size)
 
 	// Encode arguments.
@@ -564,7 +567,7 @@ func (s identity_client_stub) Identity(ctx context.Context, a0 int) (r0 int, err
 	}
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewZeroCopyDecoder(results)
 	r0 = dec.Int()
 	err = dec.Error()
 	return
@@ -611,7 +614,8 @@ func (s mod_client_stub) Mod(ctx context.Context, a0 int, a1 int) (r0 int, err e
 	size := 0
 	size += 8
 	size += 8
-	enc := codegen.NewEncoder()
+	enc := codegen.GetEncoder()
+	defer codegen.PutEncoder(enc)
 	enc.Reset(size)
 
 	// Encode arguments.
@@ -630,7 +634,7 @@ func (s mod_client_stub) Mod(ctx context.Context, a0 int, a1 int) (r0 int, err e
 	}
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewZeroCopyDecoder(results)
 	r0 = dec.Int()
 	err = dec.Error()
 	return
@@ -676,7 +680,8 @@ func (s panicker_client_stub) Panic(ctx context.Context, a0 bool) (err error) {
 	// Preallocate a buffer of the right size.
 	size := 0
 	size += 1
-	enc := codegen.NewEncoder()
+	enc := codegen.GetEncoder()
+	defer codegen.PutEncoder(enc)
 	enc.Reset(size)
 
 	// Encode arguments.
@@ -694,7 +699,7 @@ func (s panicker_client_stub) Panic(ctx context.Context, a0 bool) (err error) {
 	}
 
 	// Decode the results.
-	dec := codegen.NewDecoder(results)
+	dec := codegen.NewZeroCopyDecoder(results)
 	err = dec.Error()
 	return
 }
@@ -702,10 +707,10 @@ func (s panicker_client_stub) Panic(ctx context.Context, a0 bool) (err error) {
 // Note that "mx generate" will always generate the error message below.
 // Everything is okay. The error message is only relevant if you see it when
 // you run "go build" or "go run".
-var _ codegen.LatestVersion = codegen.Version[[0][24]struct{}](`
+var _ codegen.LatestVersion = codegen.Version[[0][25]struct{}](`
 
-ERROR: You generated this file with 'mx generate' v0.24.7-0.20250401231336-b01860e0378a+dirty (codegen
-version v0.24.0). The generated code is incompatible with the version of the
+ERROR: You generated this file with 'mx generate' (devel) (codegen
+version v0.25.0). The generated code is incompatible with the version of the
 github.com/sh3lk/mx module that you're using. The mx module
 version can be found in your go.mod file or by running the following command.
 
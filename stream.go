@@ -0,0 +1,162 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mx
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+)
+
+// Stream[T] is a stream of values of type T that flows between a caller and a
+// component method. A component method may return a stream, in which case the
+// method streams values back to the caller, or it may accept a stream as an
+// argument, in which case the caller streams values to the method.
+//
+// # Example
+//
+// Consider a Store component that returns a potentially large number of rows
+// for a query. Rather than returning all of the rows in a single reply, Query
+// can return a stream of rows:
+//
+//	type Store interface {
+//	    Query(ctx context.Context, q string) (mx.Stream[Row], error)
+//	}
+//
+//	func (s *store) Query(ctx context.Context, q string) (mx.Stream[Row], error) {
+//	    return mx.NewStream(func(ctx context.Context, send func(Row) error) error {
+//	        for _, row := range s.rows(q) {
+//	            if err := send(row); err != nil {
+//	                return err
+//	            }
+//	        }
+//	        return nil
+//	    }), nil
+//	}
+//
+// The caller receives the rows one at a time:
+//
+//	rows, err := store.Query(ctx, q)
+//	if err != nil {
+//	    return err
+//	}
+//	defer rows.Close()
+//	for {
+//	    row, err := rows.Recv(ctx)
+//	    if errors.Is(err, io.EOF) {
+//	        break
+//	    } else if err != nil {
+//	        return err
+//	    }
+//	    // Use row...
+//	}
+//
+// # Restrictions
+//
+// T must be serializable. A component method may accept at most one stream
+// argument, and a method that returns a stream must have type
+// func(context.Context, ...) (mx.Stream[T], error). A method may not both
+// accept and return a stream. Streaming methods are never retried.
+//
+// # Flow Control
+//
+// A stream never buffers more than a bounded number of values that have not
+// yet been received. A sender that gets too far ahead of its receiver is
+// blocked until the receiver catches up.
+type Stream[T any] interface {
+	// Recv returns the next value in the stream. It returns io.EOF when the
+	// stream has ended successfully, and any other non-nil error if the
+	// stream failed.
+	Recv(ctx context.Context) (T, error)
+
+	// Close stops the stream. Close must be called once the receiver is no
+	// longer interested in the stream, even if the stream has already ended.
+	// Close can be called more than once.
+	Close()
+}
+
+// NewStream returns a stream whose values are produced by produce. produce is
+// called in a separate goroutine on the first call to Recv, and should call
+// send for every value in the stream. send blocks until the value is received
+// and returns a non-nil error if the stream was closed, in which case produce
+// should return promptly. The stream ends when produce returns; if produce
+// returns a non-nil error, the error is returned by Recv.
+//
+// The context passed to produce is canceled when the stream is closed.
+func NewStream[T any](produce func(ctx context.Context, send func(T) error) error) Stream[T] {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &producerStream[T]{
+		produce: produce,
+		ctx:     ctx,
+		cancel:  cancel,
+		values:  make(chan T),
+		done:    make(chan struct{}),
+	}
+}
+
+// producerStream is the implementation of the stream returned by NewStream.
+type producerStream[T any] struct {
+	produce func(context.Context, func(T) error) error
+	ctx     context.Context // canceled when the stream is closed
+	cancel  func()
+	start   sync.Once
+	values  chan T        // values produced by produce
+	done    chan struct{} // closed when produce returns
+	err     error         // returned by produce; valid once done is closed
+}
+
+var _ Stream[int] = &producerStream[int]{}
+
+// Recv implements the Stream interface.
+func (s *producerStream[T]) Recv(ctx context.Context) (T, error) {
+	s.start.Do(func() { go s.run() })
+	var zero T
+	select {
+	case v := <-s.values:
+		return v, nil
+	case <-s.done:
+		if s.err != nil {
+			return zero, s.err
+		}
+		return zero, io.EOF
+	case <-ctx.Done():
+		return zero, ctx.Err()
+	}
+}
+
+// Close implements the Stream interface.
+func (s *producerStream[T]) Close() {
+	s.cancel()
+}
+
+// run runs the producer.
+func (s *producerStream[T]) run() {
+	defer close(s.done)
+	send := func(v T) error {
+		select {
+		case s.values <- v:
+			return nil
+		case <-s.ctx.Done():
+			return s.ctx.Err()
+		}
+	}
+	err := s.produce(s.ctx, send)
+	if err != nil && errors.Is(err, context.Canceled) && s.ctx.Err() != nil {
+		// The stream was closed. Nobody is interested in the error.
+		err = nil
+	}
+	s.err = err
+}
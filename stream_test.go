@@ -0,0 +1,94 @@
+// Copyright 2024 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mx
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestNewStream(t *testing.T) {
+	ctx := context.Background()
+	s := NewStream(func(ctx context.Context, send func(int) error) error {
+		for i := 0; i < 3; i++ {
+			if err := send(i); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	defer s.Close()
+	for i := 0; i < 3; i++ {
+		x, err := s.Recv(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if x != i {
+			t.Fatalf("Recv: got %d, want %d", x, i)
+		}
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := s.Recv(ctx); !errors.Is(err, io.EOF) {
+			t.Fatalf("Recv: got error %v, want io.EOF", err)
+		}
+	}
+}
+
+func TestNewStreamError(t *testing.T) {
+	want := errors.New("failed")
+	s := NewStream(func(ctx context.Context, send func(int) error) error {
+		return want
+	})
+	defer s.Close()
+	if _, err := s.Recv(context.Background()); !errors.Is(err, want) {
+		t.Fatalf("Recv: got error %v, want %v", err, want)
+	}
+}
+
+func TestNewStreamClose(t *testing.T) {
+	done := make(chan error)
+	s := NewStream(func(ctx context.Context, send func(int) error) error {
+		for i := 0; ; i++ {
+			if err := send(i); err != nil {
+				done <- err
+				return err
+			}
+		}
+	})
+	if _, err := s.Recv(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	// Closing the stream should unblock the producer.
+	s.Close()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Fatalf("send: got error %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestNewStreamRecvCanceled(t *testing.T) {
+	s := NewStream(func(ctx context.Context, send func(int) error) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	defer s.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := s.Recv(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Recv: got error %v, want %v", err, context.Canceled)
+	}
+}
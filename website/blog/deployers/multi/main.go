@@ -173,3 +173,13 @@ func (*handler) VerifyServerCertificate(context.Context, *protos.VerifyServerCer
 	// This deployer doesn't enable mTLS.
 	panic("unused")
 }
+
+func (*handler) GetSelfToken(context.Context, *protos.GetSelfTokenRequest) (*protos.GetSelfTokenReply, error) {
+	// This deployer doesn't enable token authentication.
+	panic("unused")
+}
+
+func (*handler) VerifyClientToken(context.Context, *protos.VerifyClientTokenRequest) (*protos.VerifyClientTokenReply, error) {
+	// This deployer doesn't enable token authentication.
+	panic("unused")
+}
@@ -127,3 +127,13 @@ func (*deployer) VerifyServerCertificate(context.Context, *protos.VerifyServerCe
 	// This deployer doesn't enable network-level security.
 	panic("unused")
 }
+
+func (*deployer) GetSelfToken(context.Context, *protos.GetSelfTokenRequest) (*protos.GetSelfTokenReply, error) {
+	// This deployer doesn't enable token authentication.
+	panic("unused")
+}
+
+func (*deployer) VerifyClientToken(context.Context, *protos.VerifyClientTokenRequest) (*protos.VerifyClientTokenReply, error) {
+	// This deployer doesn't enable token authentication.
+	panic("unused")
+}